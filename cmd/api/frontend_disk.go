@@ -0,0 +1,9 @@
+//go:build !embed
+
+package main
+
+import "io/fs"
+
+// embeddedFrontend returns nil: without -tags embed the frontend is served
+// from FRONTEND_DIR.
+func embeddedFrontend() fs.FS { return nil }
@@ -0,0 +1,19 @@
+//go:build embed
+
+package main
+
+import (
+	"io/fs"
+
+	folio "github.com/Saul-Punybz/folio"
+)
+
+// embeddedFrontend returns the frontend built into the binary. Building with
+// -tags embed requires frontend/dist to exist (make frontend).
+func embeddedFrontend() fs.FS {
+	fsys, err := fs.Sub(folio.FrontendDist, "frontend/dist")
+	if err != nil {
+		return nil
+	}
+	return fsys
+}
@@ -7,7 +7,6 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
-	"strings"
 	"syscall"
 	"time"
 
@@ -15,24 +14,37 @@ import (
 	chimw "github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 
+	"github.com/Saul-Punybz/folio/internal/agents"
 	"github.com/Saul-Punybz/folio/internal/ai"
 	"github.com/Saul-Punybz/folio/internal/config"
+	"github.com/Saul-Punybz/folio/internal/coverage"
 	"github.com/Saul-Punybz/folio/internal/crawler"
 	"github.com/Saul-Punybz/folio/internal/db"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/flags"
 	"github.com/Saul-Punybz/folio/internal/handlers"
+	"github.com/Saul-Punybz/folio/internal/i18n"
+	"github.com/Saul-Punybz/folio/internal/logging"
 	"github.com/Saul-Punybz/folio/internal/middleware"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/scraper"
 	"github.com/Saul-Punybz/folio/internal/storage"
+	"github.com/Saul-Punybz/folio/internal/useragent"
+	"github.com/Saul-Punybz/folio/internal/webui"
 )
 
 func main() {
-	// Structured logging.
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	})))
-
 	cfg := config.Load()
+	logging.Setup("api", cfg.Log, logging.Text)
+	useragent.Setup(cfg.Crawl)
+	i18n.SetDefault(cfg.Server.DefaultLanguage)
+	if err := coverage.Setup(cfg.Coverage.ProfilePath); err != nil {
+		slog.Error("failed to load coverage profile", "err", err)
+		os.Exit(1)
+	}
+	if err := ai.LoadTaskOptions(cfg.AI.TaskOptions); err != nil {
+		slog.Warn("ignoring AI_TASK_OPTIONS", "err", err)
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -54,11 +66,33 @@ func main() {
 	briefStore := models.NewBriefStore(pool)
 	watchlistOrgStore := models.NewWatchlistOrgStore(pool)
 	watchlistHitStore := models.NewWatchlistHitStore(pool)
+	watchlistDigestStore := models.NewWatchlistDigestStore(pool)
+	webhookStore := models.NewWebhookStore(pool)
 	fingerprintStore := models.NewFingerprintStore(pool)
 	chatSessionStore := models.NewChatSessionStore(pool)
 	researchProjectStore := models.NewResearchProjectStore(pool)
 	researchFindingStore := models.NewResearchFindingStore(pool)
 	entityStore := models.NewEntityStore(pool)
+	jobStore := models.NewJobStore(pool)
+	workerJobStore := models.NewWorkerJobStore(pool)
+	searchUsageStore := models.NewSearchUsageStore(pool)
+	scraper.SetSearchQuota(&scraper.SearchQuota{Usage: searchUsageStore, Budgets: cfg.Search.Budgets()})
+	scraper.SetSitemapLookback(time.Duration(cfg.Ingest.SitemapLookbackDays) * 24 * time.Hour)
+	agents.SetSocialConfig(cfg.Social)
+	tagStore := models.NewTagStore(pool)
+	ai.SetTaxonomySource(tagStore)
+	if cfg.AI.CacheDays > 0 {
+		ai.SetCache(models.NewAICacheStore(pool))
+	}
+	flags.SetSource(models.NewFeatureFlagStore(pool), cfg.Flags.DefaultsOn())
+	renderer := scraper.NewRenderer(scraper.RendererConfig{
+		ExecPath:    cfg.Render.ChromePath,
+		MaxTabs:     cfg.Render.MaxTabs,
+		PageTimeout: cfg.Render.PageTimeout,
+		Settle:      cfg.Render.Settle,
+	})
+	defer renderer.Close()
+	scraper.SetRenderer(renderer)
 
 	// Crawler stores.
 	crawlDomainStore := models.NewCrawlDomainStore(pool)
@@ -81,41 +115,88 @@ func main() {
 		Users:    userStore,
 		Sessions: sessionStore,
 	}
+
+	// bgCtx is cancelled on shutdown so background work started by handlers
+	// (inline enrichment, manual ingestion) stops with the server.
+	bgCtx, bgCancel := context.WithCancel(context.Background())
+	defer bgCancel()
+
+	logLevelStore := models.NewLogLevelStore(pool)
+	feedAccessStore := models.NewFeedAccessStore(pool)
+	go logging.Watch(bgCtx, logLevelStore)
+	go fetchlog.Run(bgCtx, models.NewOutboundFetchStore(pool))
+
+	// AI client — Ollama (local), OpenAI-compatible or Anthropic APIs (cloud).
+	aiClient, err := ai.NewFromConfig(cfg.AI)
+	if err != nil {
+		slog.Error("invalid AI configuration", "err", err)
+		os.Exit(1)
+	}
+
 	itemsHandler := &handlers.ItemsHandler{
-		Articles: articleStore,
-		Scraper:  scraper.NewScraper(),
-		AI:       ai.NewClient(cfg.Ollama.Host, cfg.Ollama.InstructModel, cfg.Ollama.EmbedModel),
+		Articles:   articleStore,
+		Scraper:    scraper.NewScraper(),
+		AI:         aiClient,
+		Jobs:       jobStore,
+		Notes:      noteStore,
+		Hits:       watchlistHitStore,
+		Storage:    storageClient,
+		FeedAccess: feedAccessStore,
+		Background: bgCtx,
+
+		TrashPurgeDays: cfg.Trash.PurgeDays,
 	}
 	searchHandler := &handlers.SearchHandler{
 		Articles: articleStore,
+		AI:       aiClient,
 	}
 	sourcesHandler := &handlers.SourcesHandler{
 		Sources: sourceStore,
+		Bundles: models.NewSourceBundleStore(pool),
 		Scraper: scraper.NewScraper(),
-		AI:      ai.NewClient(cfg.Ollama.Host, cfg.Ollama.InstructModel, cfg.Ollama.EmbedModel),
+		AI:      aiClient,
 	}
+	retentionRulesHandler := &handlers.RetentionRulesHandler{Rules: models.NewRetentionRuleStore(pool)}
+	tagsHandler := &handlers.TagsHandler{Tags: tagStore}
+	grantsHandler := &handlers.GrantsHandler{Grants: models.NewGrantStore(pool)}
 	notesHandler := &handlers.NotesHandler{
 		Notes:    noteStore,
 		Articles: articleStore,
 	}
-	// AI client for manual brief generation.
-	aiClient := ai.NewClient(
-		cfg.Ollama.Host,
-		cfg.Ollama.InstructModel,
-		cfg.Ollama.EmbedModel,
-	)
-
 	briefHandler := &handlers.BriefHandler{
 		Briefs:   briefStore,
 		Articles: articleStore,
+		Entities: entityStore,
 		AI:       aiClient,
+		Branding: handlers.DocxBranding{
+			Organization: cfg.Brief.DocxOrganization,
+			Font:         cfg.Brief.DocxFont,
+			HeadingColor: cfg.Brief.DocxHeadingColor,
+			LogoPath:     cfg.Brief.DocxLogo,
+			TemplatePath: cfg.Brief.DocxTemplate,
+		},
+	}
+	entitiesHandler := &handlers.EntitiesHandler{Entities: entityStore}
+	triageHandler := &handlers.TriageHandler{Suggestions: models.NewTriageSuggestionStore(pool)}
+	flagsHandler := &handlers.FlagsHandler{Flags: models.NewFeatureFlagStore(pool)}
+	logLevelHandler := &handlers.LogLevelHandler{Levels: logLevelStore}
+	intakeKeyStore := models.NewIntakeKeyStore(pool)
+	intakeHandler := &handlers.IntakeHandler{
+		Keys:     intakeKeyStore,
+		Tips:     models.NewTipStore(pool),
+		Articles: articleStore,
+		Items:    itemsHandler,
 	}
 	watchlistHandler := &handlers.WatchlistHandler{
 		Orgs:     watchlistOrgStore,
 		Hits:     watchlistHitStore,
 		Articles: articleStore,
 		AI:       aiClient,
+		Digests:  watchlistDigestStore,
+		Reports:  models.NewWatchlistReportStore(pool),
+		Worker:   workerJobStore,
 	}
+	webhooksHandler := &handlers.WebhooksHandler{Webhooks: webhookStore}
 	exportHandler := &handlers.ExportHandler{
 		Articles: articleStore,
 		Notes:    noteStore,
@@ -123,10 +204,16 @@ func main() {
 	}
 	chatHandler := &handlers.ChatHandler{
 		Sessions: chatSessionStore,
+		Articles: articleStore,
+		Hits:     watchlistHitStore,
+		AI:       aiClient,
+		Collect:  itemsHandler.CollectURL,
 	}
 	feedHandler := &handlers.FeedHandler{
-		Users: userStore,
-		Hits:  watchlistHitStore,
+		Users:    userStore,
+		Hits:     watchlistHitStore,
+		Articles: articleStore,
+		Access:   feedAccessStore,
 	}
 
 	researchHandler := &handlers.ResearchHandler{
@@ -136,11 +223,18 @@ func main() {
 		AI:       aiClient,
 	}
 
+	healthHandler := &handlers.HealthHandler{
+		Pool:    pool,
+		AI:      aiClient,
+		Storage: storageClient,
+	}
 	analyticsHandler := &handlers.AnalyticsHandler{
-		Pool: pool,
+		Pool:  pool,
+		Views: models.NewAnalyticsStore(pool),
 	}
 
 	sc := scraper.NewScraper()
+	auditStore := models.NewAuditStore(pool)
 	adminHandler := &handlers.AdminHandler{
 		Articles:     articleStore,
 		Sources:      sourceStore,
@@ -148,6 +242,18 @@ func main() {
 		AI:           aiClient,
 		Scraper:      sc,
 		Storage:      storageClient,
+		Jobs:         jobStore,
+		SearchUsage:  searchUsageStore,
+		Backups:      models.NewBackupRunStore(pool),
+		Ingestions:   models.NewIngestionRunStore(pool),
+		Orgs:         watchlistOrgStore,
+		Hits:         watchlistHitStore,
+		Audit:        auditStore,
+		Fetches:      models.NewOutboundFetchStore(pool),
+		Worker:       workerJobStore,
+		Background:   bgCtx,
+
+		ReclassifyModel: cfg.AI.ReclassifyModel,
 	}
 
 	crawlerDeps := crawler.Deps{
@@ -192,12 +298,16 @@ func main() {
 	r.Use(chimw.RealIP)
 	r.Use(chimw.Logger)
 	r.Use(chimw.Recoverer)
-	r.Use(chimw.Timeout(60 * time.Second))
+	r.Use(middleware.RouteTimeouts(cfg.Server.Timeouts.Default, middleware.DefaultRouteTimeouts(middleware.Timeouts{
+		Read:   cfg.Server.Timeouts.Read,
+		AI:     cfg.Server.Timeouts.AI,
+		Export: cfg.Server.Timeouts.Export,
+	})))
 	r.Use(middleware.MaxBodySize(10 << 20)) // 10 MB max body
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:*", "https://localhost:*", "http://127.0.0.1:*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-API-Key"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -207,23 +317,47 @@ func main() {
 
 	// Public routes.
 	r.Get("/api/health", handlers.Health)
+	r.Get("/api/health/deep", healthHandler.DeepHealth)
 	r.With(middleware.RateLimit(loginLimiter)).Post("/api/login", authHandler.Login)
 	r.Get("/feed/{token}.xml", feedHandler.ServeFeed)
+	r.Get("/feed/{token}.atom", feedHandler.ServeAtomFeed)
+	r.Get("/feed/{token}.json", feedHandler.ServeJSONFeed)
+	r.Get("/feed/{token}/saved.xml", feedHandler.ServeSavedFeed)
+
+	// Share pages carry link-preview tags for articles shared outside Folio.
+	r.Get("/share/{id}", itemsHandler.SharePage)
+	r.Get("/share/{id}/image", itemsHandler.ShareImage)
+
+	// Tip intake for external forms and partner systems, authenticated by an
+	// intake API key rather than a session.
+	intakeLimiter := middleware.NewRateLimiter(60, time.Hour)
+	r.With(middleware.RateLimit(intakeLimiter), middleware.IntakeKeyAuth(intakeKeyStore)).Post("/api/intake", intakeHandler.SubmitTip)
 
 	// Authenticated routes.
 	r.Group(func(r chi.Router) {
 		r.Use(middleware.SessionAuth(sessionStore, userStore))
+		r.Use(middleware.Audit(auditStore))
 
 		r.Post("/api/logout", authHandler.Logout)
 		r.Get("/api/me", authHandler.Me)
+		r.Put("/api/me/language", authHandler.SetLanguage)
 
 		// Items (articles).
 		r.Get("/api/items", itemsHandler.ListItems)
+		r.Get("/api/items/expiring", itemsHandler.ListExpiring)
+		r.Get("/api/items/{id}", itemsHandler.GetItem)
+		r.Get("/api/items/{id}/card", itemsHandler.GetCard)
+		r.Get("/api/items/{id}/history", itemsHandler.GetStatusHistory)
+		r.Get("/api/items/{id}/evidence", itemsHandler.GetEvidence)
+		r.Get("/api/items/{id}/evidence/meta", itemsHandler.GetEvidenceMeta)
+		r.Get("/api/items/{id}/evidence/extracted", itemsHandler.GetExtractedEvidence)
 		r.Post("/api/items/{id}/save", itemsHandler.SaveItem)
 		r.Post("/api/items/{id}/trash", itemsHandler.TrashItem)
 		r.Post("/api/items/{id}/pin", itemsHandler.PinItem)
 		r.Post("/api/items/{id}/undo", itemsHandler.UndoItem)
+		r.Post("/api/items/{id}/restore", itemsHandler.RestoreItem)
 		r.Post("/api/collect", itemsHandler.CollectItem)
+		r.Get("/api/items/{id}/tips", intakeHandler.ListTips)
 
 		// Search.
 		r.Get("/api/search", searchHandler.Search)
@@ -238,26 +372,55 @@ func main() {
 		r.Get("/api/briefs/latest", briefHandler.GetLatestBrief)
 		r.Get("/api/briefs", briefHandler.ListBriefs)
 		r.Post("/api/briefs/generate", briefHandler.GenerateBrief)
+		r.Get("/api/briefs/{id}/export.docx", briefHandler.ExportBriefDocx)
 
 		// Watchlist.
 		r.Route("/api/watchlist", func(r chi.Router) {
 			r.Get("/orgs", watchlistHandler.ListOrgs)
 			r.Post("/orgs", watchlistHandler.CreateOrg)
+			r.Post("/orgs/import", watchlistHandler.ImportOrgs)
+			r.Get("/orgs/export.csv", watchlistHandler.ExportOrgs)
 			r.Put("/orgs/{id}", watchlistHandler.UpdateOrg)
 			r.Delete("/orgs/{id}", watchlistHandler.DeleteOrg)
 			r.Patch("/orgs/{id}/toggle", watchlistHandler.ToggleOrg)
 
 			r.Get("/hits", watchlistHandler.ListHits)
+			r.Get("/timeline", watchlistHandler.Timeline)
 			r.Get("/hits/unseen", watchlistHandler.CountUnseen)
+			r.Get("/digest/latest", watchlistHandler.LatestDigest)
+			r.Get("/reports", watchlistHandler.ListReports)
+			r.Get("/reports/{id}", watchlistHandler.GetReport)
 			r.Post("/hits/{id}/seen", watchlistHandler.MarkSeen)
 			r.Post("/hits/seen-all", watchlistHandler.MarkAllSeen)
 			r.Delete("/hits/{id}", watchlistHandler.DeleteHit)
-
+			r.Put("/hits/{id}/draft", watchlistHandler.EditDraft)
+			r.Post("/hits/{id}/draft/status", watchlistHandler.SetDraftStatus)
+			r.Post("/hits/{id}/mute-story", watchlistHandler.MuteStory)
+			r.Post("/hits/{id}/collect", itemsHandler.CollectHit)
+			r.Put("/hits/{id}/sentiment", watchlistHandler.SetSentiment)
+			r.Get("/communications/export", watchlistHandler.ExportCommunications)
+
+			r.Get("/scan", watchlistHandler.ScanStatus)
 			r.Post("/scan", watchlistHandler.TriggerScan)
+			r.Post("/preview", watchlistHandler.PreviewScan)
 			r.Post("/orgs/{id}/enrich", watchlistHandler.EnrichOrg)
+			r.Get("/orgs/{id}/keyword-suggestions", watchlistHandler.KeywordSuggestions)
+			r.Post("/orgs/{id}/keyword-suggestions/accept", watchlistHandler.AcceptKeywords)
+			r.Get("/orgs/{id}/muted-stories", watchlistHandler.ListMutedStories)
+			r.Delete("/orgs/{id}/muted-stories/{muteId}", watchlistHandler.UnmuteStory)
 
 			r.Get("/feed-url", feedHandler.GetFeedURL)
 			r.Post("/feed-url/regenerate", feedHandler.RegenerateFeedURL)
+			r.Get("/feed-url/access", feedHandler.FeedAccessLog)
+		})
+
+		// Webhook targets for watchlist hits.
+		r.Route("/api/webhooks", func(r chi.Router) {
+			r.Get("/", webhooksHandler.List)
+			r.Post("/", webhooksHandler.Create)
+			r.Put("/{id}", webhooksHandler.Update)
+			r.Delete("/{id}", webhooksHandler.Delete)
+			r.Post("/{id}/test", webhooksHandler.Test)
 		})
 
 		// Chat sessions.
@@ -265,7 +428,11 @@ func main() {
 		r.Get("/api/chat/sessions/{id}", chatHandler.GetSession)
 		r.Post("/api/chat/sessions", chatHandler.CreateSession)
 		r.Put("/api/chat/sessions/{id}", chatHandler.UpdateSession)
+		r.Post("/api/chat/sessions/{id}/message", chatHandler.SendMessage)
 		r.Delete("/api/chat/sessions/{id}", chatHandler.DeleteSession)
+		r.Delete("/api/chat/sessions", chatHandler.DeleteAllSessions)
+		r.Post("/api/chat/ask", chatHandler.Ask)
+		r.Post("/api/chat/agent", chatHandler.AgentChat)
 
 		// Research (deep investigation).
 		r.Route("/api/research", func(r chi.Router) {
@@ -318,10 +485,13 @@ func main() {
 		// Analytics (authenticated, not admin-only)
 		r.Get("/api/analytics/tags", analyticsHandler.TagTrends)
 		r.Get("/api/analytics/entities", analyticsHandler.TopEntities)
+		r.Get("/api/entities/{name}/articles", entitiesHandler.ListArticles)
 		r.Get("/api/analytics/co-occurrences", analyticsHandler.CoOccurrences)
 		r.Get("/api/analytics/sentiment", analyticsHandler.SentimentDistribution)
 		r.Get("/api/analytics/sources", analyticsHandler.SourceHealth)
 		r.Get("/api/analytics/volume", analyticsHandler.ArticleVolume)
+		r.Get("/api/analytics/regions", analyticsHandler.RegionVolume)
+		r.Get("/api/analytics/hits", analyticsHandler.OrgHits)
 
 		// Export.
 		r.Get("/api/items/{id}/export", exportHandler.ExportArticle)
@@ -330,45 +500,128 @@ func main() {
 		// Retention.
 		r.Put("/api/items/{id}/retention", itemsHandler.UpdateRetention)
 
+		// Triage suggestions.
+		r.Get("/api/triage/suggestions", triageHandler.ListSuggestions)
+		r.Post("/api/triage/suggestions/accept", triageHandler.AcceptSuggestions)
+		r.Delete("/api/triage/suggestions/{id}", triageHandler.DismissSuggestion)
+
 		// Sources (admin only).
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.RequireAdmin)
 			r.Get("/api/sources", sourcesHandler.ListSources)
 			r.Post("/api/sources", sourcesHandler.CreateSource)
 			r.Post("/api/sources/quick", sourcesHandler.QuickCreateSource)
+			r.Post("/api/sources/import", sourcesHandler.ImportOPML)
+			r.Get("/api/sources/export.opml", sourcesHandler.ExportOPML)
+			r.Get("/api/sources/bundles", sourcesHandler.ListBundles)
+			r.Post("/api/sources/bundles/{slug}/install", sourcesHandler.InstallBundle)
 			r.Put("/api/sources/{id}", sourcesHandler.UpdateSource)
 			r.Patch("/api/sources/{id}/toggle", sourcesHandler.ToggleSource)
 			r.Delete("/api/sources/{id}", sourcesHandler.DeleteSource)
+			r.Post("/api/sources/{id}/archive", sourcesHandler.ArchiveSource)
+			r.Delete("/api/sources/{id}/archive", sourcesHandler.UnarchiveSource)
 			r.Post("/api/sources/{id}/test", sourcesHandler.TestScrape)
 		})
 
+		// Retention rules (admin only).
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireAdmin)
+			r.Get("/api/retention-rules", retentionRulesHandler.ListRules)
+			r.Post("/api/retention-rules", retentionRulesHandler.CreateRule)
+			r.Post("/api/retention-rules/preview", retentionRulesHandler.PreviewDraft)
+			r.Put("/api/retention-rules/{id}", retentionRulesHandler.UpdateRule)
+			r.Delete("/api/retention-rules/{id}", retentionRulesHandler.DeleteRule)
+			r.Get("/api/retention-rules/{id}/preview", retentionRulesHandler.PreviewRule)
+		})
+
+		// Feature flags evaluated for the current user.
+		r.Get("/api/flags/me", flagsHandler.MyFlags)
+
+		// Grants.gov opportunities and Federal Register documents.
+		r.Get("/api/grants/opportunities", grantsHandler.ListOpportunities)
+		r.Get("/api/grants/deadlines", grantsHandler.ListDeadlines)
+		r.Get("/api/grants/postings", grantsHandler.ListPostings)
+		r.Put("/api/grants/opportunities/{id}/tracking", grantsHandler.SetTracking)
+		r.Get("/api/grants/federal-register", grantsHandler.ListFederalRegister)
+
+		// Tag taxonomy: anyone can read it, admins edit it.
+		r.Get("/api/tags", tagsHandler.ListTags)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireAdmin)
+			r.Post("/api/tags", tagsHandler.CreateTag)
+			r.Put("/api/tags/{name}", tagsHandler.UpdateTag)
+			r.Delete("/api/tags/{name}", tagsHandler.DeleteTag)
+		})
+
+		// Intake API keys (admin only).
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireAdmin)
+			r.Get("/api/intake/keys", intakeHandler.ListKeys)
+			r.Post("/api/intake/keys", intakeHandler.CreateKey)
+			r.Delete("/api/intake/keys/{id}", intakeHandler.RevokeKey)
+		})
+
+		// Feature flags and per-user overrides (admin only).
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireAdmin)
+			r.Get("/api/flags", flagsHandler.ListFlags)
+			r.Post("/api/flags", flagsHandler.CreateFlag)
+			r.Put("/api/flags/{name}", flagsHandler.UpdateFlag)
+			r.Delete("/api/flags/{name}", flagsHandler.DeleteFlag)
+			r.Put("/api/flags/{name}/users/{userId}", flagsHandler.SetOverride)
+			r.Delete("/api/flags/{name}/users/{userId}", flagsHandler.DeleteOverride)
+		})
+
 		// Admin actions.
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.RequireAdmin)
 			r.Post("/api/admin/reenrich", adminHandler.Reenrich)
+			r.Post("/api/admin/watchlist/reclassify", adminHandler.ReclassifySentiment)
+			r.Post("/api/admin/retention", adminHandler.BulkRetention)
+			r.Get("/api/admin/audit", adminHandler.ListAudit)
+			r.Get("/api/admin/fetches", adminHandler.ListFetches)
+			r.Get("/api/admin/fetches/domains", adminHandler.ListFetchDomains)
+			r.Get("/api/admin/stats", adminHandler.Stats)
+			r.Get("/api/admin/users", authHandler.ListUsers)
+			r.Post("/api/admin/users", authHandler.CreateUser)
+			r.Put("/api/admin/users/{id}", authHandler.UpdateUser)
+			r.Delete("/api/admin/users/{id}", authHandler.DeleteUser)
+			r.Get("/api/admin/ingest", adminHandler.IngestStatus)
 			r.Post("/api/admin/ingest", adminHandler.TriggerIngest)
+			r.Get("/api/admin/ingestions", adminHandler.ListIngestions)
+			r.Get("/api/admin/embeddings/backfill", adminHandler.EmbeddingBackfillStatus)
+			r.Post("/api/admin/embeddings/backfill", adminHandler.TriggerEmbeddingBackfill)
+			r.Get("/api/admin/worker/jobs", adminHandler.ListWorkerJobs)
+			r.Post("/api/admin/worker/jobs/{name}/pause", adminHandler.PauseWorkerJob)
+			r.Post("/api/admin/worker/jobs/{name}/resume", adminHandler.ResumeWorkerJob)
+			r.Post("/api/admin/worker/jobs/{name}/run", adminHandler.RunWorkerJob)
+			r.Get("/api/admin/worker/commands", adminHandler.ListWorkerCommands)
+			r.Get("/api/admin/worker/commands/{id}", adminHandler.GetWorkerCommand)
+			r.Post("/api/admin/filters/test", adminHandler.TestFilters)
+			r.Get("/api/admin/log-levels", logLevelHandler.List)
+			r.Put("/api/admin/log-levels/{service}", logLevelHandler.Set)
+			r.Delete("/api/admin/log-levels/{service}", logLevelHandler.Delete)
 			r.Post("/api/admin/chat", adminHandler.ChatWithNews)
+			r.Post("/api/chat/stream", adminHandler.ChatStream)
 		})
 	})
 
-	// Serve static frontend files if the directory exists.
-	frontendDir := "./frontend/dist"
-	if _, err := os.Stat(frontendDir); err == nil {
-		fileServer := http.FileServer(http.Dir(frontendDir))
-		r.Get("/*", func(w http.ResponseWriter, r *http.Request) {
-			// If the path starts with /api, let Chi handle it (404).
-			if strings.HasPrefix(r.URL.Path, "/api") {
-				http.NotFound(w, r)
-				return
-			}
-			// Try to serve the file; fall back to index.html for SPA routing.
-			path := frontendDir + r.URL.Path
-			if _, err := os.Stat(path); os.IsNotExist(err) {
-				http.ServeFile(w, r, frontendDir+"/index.html")
-				return
-			}
-			fileServer.ServeHTTP(w, r)
-		})
+	// Serve the frontend: embedded when built with -tags embed, otherwise
+	// from FRONTEND_DIR if it exists. Files are indexed once here, so a
+	// rebuilt frontend needs a restart.
+	frontendFS := embeddedFrontend()
+	if frontendFS == nil {
+		if _, err := os.Stat(cfg.Server.FrontendDir); err == nil {
+			frontendFS = os.DirFS(cfg.Server.FrontendDir)
+		}
+	}
+	if frontendFS != nil {
+		frontend, err := webui.New(frontendFS)
+		if err != nil {
+			slog.Error("frontend not served", "err", err)
+		} else {
+			r.Get("/*", frontend.ServeHTTP)
+		}
 	}
 
 	// Start server.
@@ -377,7 +630,7 @@ func main() {
 		Addr:         addr,
 		Handler:      r,
 		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 30 * time.Second,
+		WriteTimeout: 30 * time.Second, // extended per route by middleware.RouteTimeouts
 		IdleTimeout:  60 * time.Second,
 	}
 
@@ -395,6 +648,7 @@ func main() {
 
 	<-done
 	slog.Info("shutting down...")
+	bgCancel()
 
 	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer shutdownCancel()
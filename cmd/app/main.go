@@ -14,7 +14,6 @@ import (
 	"os/signal"
 	"path/filepath"
 	"runtime"
-	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -28,23 +27,30 @@ import (
 	folio "github.com/Saul-Punybz/folio"
 	"github.com/Saul-Punybz/folio/internal/agents"
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/backup"
 	"github.com/Saul-Punybz/folio/internal/config"
+	"github.com/Saul-Punybz/folio/internal/coverage"
 	"github.com/Saul-Punybz/folio/internal/crawler"
 	"github.com/Saul-Punybz/folio/internal/db"
 	"github.com/Saul-Punybz/folio/internal/embedded"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/flags"
 	"github.com/Saul-Punybz/folio/internal/generator"
 	"github.com/Saul-Punybz/folio/internal/handlers"
+	"github.com/Saul-Punybz/folio/internal/i18n"
+	"github.com/Saul-Punybz/folio/internal/logging"
 	"github.com/Saul-Punybz/folio/internal/middleware"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/research"
 	"github.com/Saul-Punybz/folio/internal/scraper"
 	"github.com/Saul-Punybz/folio/internal/storage"
+	"github.com/Saul-Punybz/folio/internal/useragent"
+	"github.com/Saul-Punybz/folio/internal/webui"
+	"github.com/Saul-Punybz/folio/internal/workerctl"
 )
 
 func main() {
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	})))
+	logging.Setup("app", config.Load().Log, logging.Text)
 
 	slog.Info("Folio starting...", "version", "1.0.0")
 
@@ -97,7 +103,7 @@ func main() {
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	pool, err := db.ConnectWithDSN(ctx, pg.DSN(), migrationsFS)
+	pool, err := db.ConnectWithDSN(ctx, pg.DSN(), config.Load().DB, migrationsFS)
 	cancel()
 	if err != nil {
 		slog.Error("failed to connect to database", "err", err)
@@ -114,12 +120,24 @@ func main() {
 	os.Setenv("DB_NAME", "folio")
 	os.Setenv("DB_SSLMODE", "disable")
 	cfg := config.Load()
+	if err := ai.LoadTaskOptions(cfg.AI.TaskOptions); err != nil {
+		slog.Warn("ignoring AI_TASK_OPTIONS", "err", err)
+	}
+	useragent.Setup(cfg.Crawl)
+	i18n.SetDefault(cfg.Server.DefaultLanguage)
+	if err := coverage.Setup(cfg.Coverage.ProfilePath); err != nil {
+		slog.Error("failed to load coverage profile", "err", err)
+		os.Exit(1)
+	}
 
 	// ── Check AI Provider ─────────────────────────────────────────
-	if cfg.AI.Provider == "openai" {
-		slog.Info("AI provider: OpenAI-compatible API",
+	if cfg.AI.Provider == "openai" || cfg.AI.Provider == "anthropic" {
+		slog.Info("AI provider: hosted API",
+			"provider", cfg.AI.Provider,
 			"host", cfg.AI.Host,
 			"model", cfg.AI.InstructModel)
+	} else if cfg.AI.Provider == "fake" {
+		slog.Warn("AI provider: fake — canned responses, no model calls")
 	} else {
 		ollamaAvailable := checkOllama(cfg.AI.Host)
 		if !ollamaAvailable {
@@ -152,6 +170,41 @@ func main() {
 	entityRelStore := models.NewEntityRelationshipStore(pool)
 	escritoStore := models.NewEscritoStore(pool)
 	escritoSourceStore := models.NewEscritoSourceStore(pool)
+	jobStore := models.NewJobStore(pool)
+	retentionRuleStore := models.NewRetentionRuleStore(pool)
+	searchUsageStore := models.NewSearchUsageStore(pool)
+	scraper.SetSearchQuota(&scraper.SearchQuota{Usage: searchUsageStore, Budgets: cfg.Search.Budgets()})
+	scraper.SetSitemapLookback(time.Duration(cfg.Ingest.SitemapLookbackDays) * 24 * time.Hour)
+	agents.SetSocialConfig(cfg.Social)
+	ai.SetTaxonomySource(models.NewTagStore(pool))
+	if cfg.AI.CacheDays > 0 {
+		ai.SetCache(models.NewAICacheStore(pool))
+	}
+	flags.SetSource(models.NewFeatureFlagStore(pool), cfg.Flags.DefaultsOn())
+	renderer := scraper.NewRenderer(scraper.RendererConfig{
+		ExecPath:    cfg.Render.ChromePath,
+		MaxTabs:     cfg.Render.MaxTabs,
+		PageTimeout: cfg.Render.PageTimeout,
+		Settle:      cfg.Render.Settle,
+	})
+	defer renderer.Close()
+	scraper.SetRenderer(renderer)
+	if cfg.Render.Screenshots {
+		// A separate browser, since the scraping one skips images.
+		screenshots := scraper.NewRenderer(scraper.RendererConfig{
+			ExecPath:    cfg.Render.ChromePath,
+			MaxTabs:     1,
+			PageTimeout: cfg.Render.PageTimeout,
+			Settle:      cfg.Render.Settle,
+			LoadImages:  true,
+		})
+		defer screenshots.Close()
+		scraper.SetScreenshotRenderer(screenshots)
+	}
+	watchlistDigestStore := models.NewWatchlistDigestStore(pool)
+	notificationStore := models.NewNotificationStore(pool)
+	webhookStore := models.NewWebhookStore(pool)
+	backupRunStore := models.NewBackupRunStore(pool)
 
 	// S3 storage (optional).
 	storageCtx, storageCancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -163,11 +216,23 @@ func main() {
 	}
 
 	// AI client — supports both Ollama (local) and OpenAI-compatible APIs (cloud).
-	aiClient := ai.NewFromConfig(cfg.AI.Provider, cfg.AI.Host, cfg.AI.APIKey, cfg.AI.InstructModel, cfg.AI.EmbedModel)
+	aiClient, err := ai.NewFromConfig(cfg.AI)
+	if err != nil {
+		slog.Error("invalid AI configuration", "err", err)
+		os.Exit(1)
+	}
+
+	// workerCtx is cancelled on shutdown; cron work and background work
+	// started by handlers run under it.
+	var wg sync.WaitGroup
+	workerCtx, workerCancel := context.WithCancel(context.Background())
+	defer workerCancel()
+	go logging.Watch(workerCtx, models.NewLogLevelStore(pool))
+	go fetchlog.Run(workerCtx, models.NewOutboundFetchStore(pool))
 
 	// ── Setup Router (same as cmd/api) ───────────────────────────
 	r := setupRouter(
-		cfg, aiClient, storageClient,
+		workerCtx, cfg, aiClient, storageClient,
 		articleStore, userStore, sessionStore, sourceStore, noteStore,
 		briefStore, watchlistOrgStore, watchlistHitStore, fingerprintStore,
 		chatSessionStore, researchProjectStore, researchFindingStore,
@@ -178,34 +243,22 @@ func main() {
 
 	// ── Serve Embedded Frontend ──────────────────────────────────
 	frontendFS, _ := fs.Sub(folio.FrontendDist, "frontend/dist")
-	fileServer := http.FileServer(http.FS(frontendFS))
-	r.Get("/*", func(w http.ResponseWriter, req *http.Request) {
-		if strings.HasPrefix(req.URL.Path, "/api") {
-			http.NotFound(w, req)
-			return
-		}
-		// Try exact file first, fall back to index.html for SPA routing
-		f, err := frontendFS.Open(strings.TrimPrefix(req.URL.Path, "/"))
-		if err != nil {
-			// Serve index.html for SPA client-side routing
-			req.URL.Path = "/"
-		} else {
-			f.Close()
-		}
-		fileServer.ServeHTTP(w, req)
-	})
+	frontend, err := webui.New(frontendFS)
+	if err != nil {
+		slog.Error("frontend not served", "err", err)
+	} else {
+		r.Get("/*", frontend.ServeHTTP)
+	}
 
 	// ── Start Worker Cron Jobs (inline) ──────────────────────────
-	var wg sync.WaitGroup
-	workerCtx, workerCancel := context.WithCancel(context.Background())
-	defer workerCancel()
-
 	c := startWorkerCron(workerCtx, &wg, cfg, aiClient, storageClient,
 		articleStore, sourceStore, fingerprintStore, sessionStore,
 		briefStore, watchlistOrgStore, watchlistHitStore, entityStore,
 		researchProjectStore, researchFindingStore, crawlDomainStore,
 		crawlQueueStore, crawledPageStore, crawlLinkStore, crawlRunStore,
 		pageEntityStore, entityRelStore, escritoStore, escritoSourceStore,
+		jobStore, retentionRuleStore, watchlistDigestStore, notificationStore,
+		webhookStore, backupRunStore, pool,
 	)
 
 	// ── Start HTTP Server ────────────────────────────────────────
@@ -217,7 +270,7 @@ func main() {
 		Addr:         addr,
 		Handler:      r,
 		ReadTimeout:  15 * time.Second,
-		WriteTimeout: 30 * time.Second,
+		WriteTimeout: 30 * time.Second, // extended per route by middleware.RouteTimeouts
 		IdleTimeout:  60 * time.Second,
 	}
 
@@ -266,8 +319,9 @@ func main() {
 
 // setupRouter creates the Chi router with all API routes.
 func setupRouter(
+	bgCtx context.Context,
 	cfg config.Config,
-	aiClient *ai.OllamaClient,
+	aiClient ai.Provider,
 	storageClient *storage.Client,
 	articleStore *models.ArticleStore,
 	userStore *models.UserStore,
@@ -294,24 +348,66 @@ func setupRouter(
 	pool *pgxpool.Pool,
 ) *chi.Mux {
 	sc := scraper.NewScraper()
+	jobStore := models.NewJobStore(pool)
+	webhookStore := models.NewWebhookStore(pool)
+	workerJobStore := models.NewWorkerJobStore(pool)
+	feedAccessStore := models.NewFeedAccessStore(pool)
 
 	authHandler := &handlers.AuthHandler{Users: userStore, Sessions: sessionStore}
+	healthHandler := &handlers.HealthHandler{Pool: pool, AI: aiClient, Storage: storageClient}
 	itemsHandler := &handlers.ItemsHandler{
 		Articles: articleStore,
 		Scraper:  sc,
 		AI:       aiClient,
+		Jobs:     jobStore,
+		Notes:    noteStore,
+		Hits:     watchlistHitStore,
+		Storage:  storageClient,
+
+		FeedAccess:     feedAccessStore,
+		Background:     bgCtx,
+		TrashPurgeDays: cfg.Trash.PurgeDays,
 	}
-	searchHandler := &handlers.SearchHandler{Articles: articleStore}
-	sourcesHandler := &handlers.SourcesHandler{Sources: sourceStore, Scraper: sc, AI: aiClient}
+	searchHandler := &handlers.SearchHandler{Articles: articleStore, AI: aiClient}
+	sourcesHandler := &handlers.SourcesHandler{Sources: sourceStore, Bundles: models.NewSourceBundleStore(pool), Scraper: sc, AI: aiClient}
+	retentionRulesHandler := &handlers.RetentionRulesHandler{Rules: models.NewRetentionRuleStore(pool)}
+	tagsHandler := &handlers.TagsHandler{Tags: models.NewTagStore(pool)}
+	grantsHandler := &handlers.GrantsHandler{Grants: models.NewGrantStore(pool)}
 	notesHandler := &handlers.NotesHandler{Notes: noteStore, Articles: articleStore}
-	briefHandler := &handlers.BriefHandler{Briefs: briefStore, Articles: articleStore, AI: aiClient}
+	briefHandler := &handlers.BriefHandler{
+		Briefs: briefStore, Articles: articleStore, Entities: entityStore, AI: aiClient,
+		Branding: handlers.DocxBranding{
+			Organization: cfg.Brief.DocxOrganization,
+			Font:         cfg.Brief.DocxFont,
+			HeadingColor: cfg.Brief.DocxHeadingColor,
+			LogoPath:     cfg.Brief.DocxLogo,
+			TemplatePath: cfg.Brief.DocxTemplate,
+		},
+	}
+	entitiesHandler := &handlers.EntitiesHandler{Entities: entityStore}
+	triageHandler := &handlers.TriageHandler{Suggestions: models.NewTriageSuggestionStore(pool)}
+	flagsHandler := &handlers.FlagsHandler{Flags: models.NewFeatureFlagStore(pool)}
+	logLevelHandler := &handlers.LogLevelHandler{Levels: models.NewLogLevelStore(pool)}
+	intakeKeyStore := models.NewIntakeKeyStore(pool)
+	intakeHandler := &handlers.IntakeHandler{
+		Keys:     intakeKeyStore,
+		Tips:     models.NewTipStore(pool),
+		Articles: articleStore,
+		Items:    itemsHandler,
+	}
 	watchlistHandler := &handlers.WatchlistHandler{
 		Orgs: watchlistOrgStore, Hits: watchlistHitStore,
 		Articles: articleStore, AI: aiClient,
+		Digests: models.NewWatchlistDigestStore(pool), Reports: models.NewWatchlistReportStore(pool),
+		Worker: workerJobStore,
 	}
+	webhooksHandler := &handlers.WebhooksHandler{Webhooks: webhookStore}
 	exportHandler := &handlers.ExportHandler{Articles: articleStore, Notes: noteStore, Storage: storageClient}
-	chatHandler := &handlers.ChatHandler{Sessions: chatSessionStore}
-	feedHandler := &handlers.FeedHandler{Users: userStore, Hits: watchlistHitStore}
+	chatHandler := &handlers.ChatHandler{
+		Sessions: chatSessionStore, Articles: articleStore, Hits: watchlistHitStore,
+		AI: aiClient, Collect: itemsHandler.CollectURL,
+	}
+	feedHandler := &handlers.FeedHandler{Users: userStore, Hits: watchlistHitStore, Articles: articleStore, Access: feedAccessStore}
 	researchHandler := &handlers.ResearchHandler{
 		Projects: researchProjectStore, Findings: researchFindingStore,
 		Articles: articleStore, AI: aiClient,
@@ -331,30 +427,51 @@ func setupRouter(
 		Escritos: escritoStore, Sources: escritoSourceStore,
 		Articles: articleStore, AI: aiClient,
 	}
+	auditStore := models.NewAuditStore(pool)
 	adminHandler := &handlers.AdminHandler{
 		Articles: articleStore, Sources: sourceStore, Fingerprints: fingerprintStore,
-		AI: aiClient, Scraper: sc, Storage: storageClient,
+		AI: aiClient, Scraper: sc, Storage: storageClient, Jobs: jobStore,
+		SearchUsage: models.NewSearchUsageStore(pool), Backups: models.NewBackupRunStore(pool),
+		Orgs: watchlistOrgStore, Hits: watchlistHitStore, Ingestions: models.NewIngestionRunStore(pool),
+		Audit: auditStore, Fetches: models.NewOutboundFetchStore(pool),
+		Worker: workerJobStore, Background: bgCtx,
+		ReclassifyModel: cfg.AI.ReclassifyModel,
 	}
 
 	r := chi.NewRouter()
 	r.Use(chimw.RequestID, chimw.RealIP, chimw.Logger, chimw.Recoverer)
-	r.Use(chimw.Timeout(60 * time.Second))
+	r.Use(middleware.RouteTimeouts(cfg.Server.Timeouts.Default, middleware.DefaultRouteTimeouts(middleware.Timeouts{
+		Read:   cfg.Server.Timeouts.Read,
+		AI:     cfg.Server.Timeouts.AI,
+		Export: cfg.Server.Timeouts.Export,
+	})))
 	r.Use(middleware.MaxBodySize(10 << 20)) // 10 MB max body
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:*", "https://localhost:*", "http://127.0.0.1:*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-API-Key"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
 
 	// Public routes.
 	r.Get("/api/health", handlers.Health)
+	r.Get("/api/health/deep", healthHandler.DeepHealth)
 	r.Get("/feed/{token}.xml", feedHandler.ServeFeed)
+	r.Get("/feed/{token}.atom", feedHandler.ServeAtomFeed)
+	r.Get("/feed/{token}.json", feedHandler.ServeJSONFeed)
+	r.Get("/feed/{token}/saved.xml", feedHandler.ServeSavedFeed)
+
+	// Share pages carry link-preview tags for articles shared outside Folio.
+	r.Get("/share/{id}", itemsHandler.SharePage)
+	r.Get("/share/{id}/image", itemsHandler.ShareImage)
+
+	r.With(middleware.RateLimit(middleware.NewRateLimiter(60, time.Hour)), middleware.IntakeKeyAuth(intakeKeyStore)).Post("/api/intake", intakeHandler.SubmitTip)
 
 	// All routes auto-authenticated (local macOS app, no login needed).
 	r.Group(func(r chi.Router) {
 		r.Use(middleware.AutoAuth(userStore))
+		r.Use(middleware.Audit(auditStore))
 
 		// Auth compatibility endpoints (no-op for local app).
 		r.Post("/api/login", func(w http.ResponseWriter, r *http.Request) {
@@ -367,13 +484,23 @@ func setupRouter(
 			w.Write([]byte(`{"status":"logged out"}`))
 		})
 		r.Get("/api/me", authHandler.Me)
+		r.Put("/api/me/language", authHandler.SetLanguage)
 
 		r.Get("/api/items", itemsHandler.ListItems)
+		r.Get("/api/items/expiring", itemsHandler.ListExpiring)
+		r.Get("/api/items/{id}", itemsHandler.GetItem)
+		r.Get("/api/items/{id}/card", itemsHandler.GetCard)
+		r.Get("/api/items/{id}/history", itemsHandler.GetStatusHistory)
+		r.Get("/api/items/{id}/evidence", itemsHandler.GetEvidence)
+		r.Get("/api/items/{id}/evidence/meta", itemsHandler.GetEvidenceMeta)
+		r.Get("/api/items/{id}/evidence/extracted", itemsHandler.GetExtractedEvidence)
 		r.Post("/api/items/{id}/save", itemsHandler.SaveItem)
 		r.Post("/api/items/{id}/trash", itemsHandler.TrashItem)
 		r.Post("/api/items/{id}/pin", itemsHandler.PinItem)
 		r.Post("/api/items/{id}/undo", itemsHandler.UndoItem)
+		r.Post("/api/items/{id}/restore", itemsHandler.RestoreItem)
 		r.Post("/api/collect", itemsHandler.CollectItem)
+		r.Get("/api/items/{id}/tips", intakeHandler.ListTips)
 
 		r.Get("/api/search", searchHandler.Search)
 		r.Get("/api/items/{id}/similar", searchHandler.Similar)
@@ -385,29 +512,61 @@ func setupRouter(
 		r.Get("/api/briefs/latest", briefHandler.GetLatestBrief)
 		r.Get("/api/briefs", briefHandler.ListBriefs)
 		r.Post("/api/briefs/generate", briefHandler.GenerateBrief)
+		r.Get("/api/briefs/{id}/export.docx", briefHandler.ExportBriefDocx)
 
 		r.Route("/api/watchlist", func(r chi.Router) {
 			r.Get("/orgs", watchlistHandler.ListOrgs)
 			r.Post("/orgs", watchlistHandler.CreateOrg)
+			r.Post("/orgs/import", watchlistHandler.ImportOrgs)
+			r.Get("/orgs/export.csv", watchlistHandler.ExportOrgs)
 			r.Put("/orgs/{id}", watchlistHandler.UpdateOrg)
 			r.Delete("/orgs/{id}", watchlistHandler.DeleteOrg)
 			r.Patch("/orgs/{id}/toggle", watchlistHandler.ToggleOrg)
 			r.Get("/hits", watchlistHandler.ListHits)
+			r.Get("/timeline", watchlistHandler.Timeline)
 			r.Get("/hits/unseen", watchlistHandler.CountUnseen)
+			r.Get("/digest/latest", watchlistHandler.LatestDigest)
+			r.Get("/reports", watchlistHandler.ListReports)
+			r.Get("/reports/{id}", watchlistHandler.GetReport)
 			r.Post("/hits/{id}/seen", watchlistHandler.MarkSeen)
 			r.Post("/hits/seen-all", watchlistHandler.MarkAllSeen)
 			r.Delete("/hits/{id}", watchlistHandler.DeleteHit)
+			r.Put("/hits/{id}/draft", watchlistHandler.EditDraft)
+			r.Post("/hits/{id}/draft/status", watchlistHandler.SetDraftStatus)
+			r.Post("/hits/{id}/mute-story", watchlistHandler.MuteStory)
+			r.Post("/hits/{id}/collect", itemsHandler.CollectHit)
+			r.Put("/hits/{id}/sentiment", watchlistHandler.SetSentiment)
+			r.Get("/communications/export", watchlistHandler.ExportCommunications)
+			r.Get("/scan", watchlistHandler.ScanStatus)
 			r.Post("/scan", watchlistHandler.TriggerScan)
+			r.Post("/preview", watchlistHandler.PreviewScan)
 			r.Post("/orgs/{id}/enrich", watchlistHandler.EnrichOrg)
+			r.Get("/orgs/{id}/keyword-suggestions", watchlistHandler.KeywordSuggestions)
+			r.Post("/orgs/{id}/keyword-suggestions/accept", watchlistHandler.AcceptKeywords)
+			r.Get("/orgs/{id}/muted-stories", watchlistHandler.ListMutedStories)
+			r.Delete("/orgs/{id}/muted-stories/{muteId}", watchlistHandler.UnmuteStory)
 			r.Get("/feed-url", feedHandler.GetFeedURL)
 			r.Post("/feed-url/regenerate", feedHandler.RegenerateFeedURL)
+			r.Get("/feed-url/access", feedHandler.FeedAccessLog)
+		})
+
+		r.Route("/api/webhooks", func(r chi.Router) {
+			r.Get("/", webhooksHandler.List)
+			r.Post("/", webhooksHandler.Create)
+			r.Put("/{id}", webhooksHandler.Update)
+			r.Delete("/{id}", webhooksHandler.Delete)
+			r.Post("/{id}/test", webhooksHandler.Test)
 		})
 
 		r.Get("/api/chat/sessions", chatHandler.ListSessions)
 		r.Get("/api/chat/sessions/{id}", chatHandler.GetSession)
 		r.Post("/api/chat/sessions", chatHandler.CreateSession)
 		r.Put("/api/chat/sessions/{id}", chatHandler.UpdateSession)
+		r.Post("/api/chat/sessions/{id}/message", chatHandler.SendMessage)
 		r.Delete("/api/chat/sessions/{id}", chatHandler.DeleteSession)
+		r.Delete("/api/chat/sessions", chatHandler.DeleteAllSessions)
+		r.Post("/api/chat/ask", chatHandler.Ask)
+		r.Post("/api/chat/agent", chatHandler.AgentChat)
 
 		r.Route("/api/research", func(r chi.Router) {
 			r.Post("/", researchHandler.CreateProject)
@@ -452,34 +611,109 @@ func setupRouter(
 			})
 		})
 
-		analyticsHandler := &handlers.AnalyticsHandler{Pool: pool}
+		analyticsHandler := &handlers.AnalyticsHandler{Pool: pool, Views: models.NewAnalyticsStore(pool)}
 		r.Get("/api/analytics/tags", analyticsHandler.TagTrends)
 		r.Get("/api/analytics/entities", analyticsHandler.TopEntities)
+		r.Get("/api/entities/{name}/articles", entitiesHandler.ListArticles)
 		r.Get("/api/analytics/co-occurrences", analyticsHandler.CoOccurrences)
 		r.Get("/api/analytics/sentiment", analyticsHandler.SentimentDistribution)
 		r.Get("/api/analytics/sources", analyticsHandler.SourceHealth)
 		r.Get("/api/analytics/volume", analyticsHandler.ArticleVolume)
+		r.Get("/api/analytics/regions", analyticsHandler.RegionVolume)
+		r.Get("/api/analytics/hits", analyticsHandler.OrgHits)
 
 		r.Get("/api/items/{id}/export", exportHandler.ExportArticle)
 		r.Post("/api/export", exportHandler.ExportBulk)
 		r.Put("/api/items/{id}/retention", itemsHandler.UpdateRetention)
+		r.Get("/api/triage/suggestions", triageHandler.ListSuggestions)
+		r.Post("/api/triage/suggestions/accept", triageHandler.AcceptSuggestions)
+		r.Delete("/api/triage/suggestions/{id}", triageHandler.DismissSuggestion)
 
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.RequireAdmin)
 			r.Get("/api/sources", sourcesHandler.ListSources)
 			r.Post("/api/sources", sourcesHandler.CreateSource)
 			r.Post("/api/sources/quick", sourcesHandler.QuickCreateSource)
+			r.Post("/api/sources/import", sourcesHandler.ImportOPML)
+			r.Get("/api/sources/export.opml", sourcesHandler.ExportOPML)
+			r.Get("/api/sources/bundles", sourcesHandler.ListBundles)
+			r.Post("/api/sources/bundles/{slug}/install", sourcesHandler.InstallBundle)
 			r.Put("/api/sources/{id}", sourcesHandler.UpdateSource)
 			r.Patch("/api/sources/{id}/toggle", sourcesHandler.ToggleSource)
 			r.Delete("/api/sources/{id}", sourcesHandler.DeleteSource)
+			r.Post("/api/sources/{id}/archive", sourcesHandler.ArchiveSource)
+			r.Delete("/api/sources/{id}/archive", sourcesHandler.UnarchiveSource)
 			r.Post("/api/sources/{id}/test", sourcesHandler.TestScrape)
 		})
 
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireAdmin)
+			r.Get("/api/retention-rules", retentionRulesHandler.ListRules)
+			r.Post("/api/retention-rules", retentionRulesHandler.CreateRule)
+			r.Post("/api/retention-rules/preview", retentionRulesHandler.PreviewDraft)
+			r.Put("/api/retention-rules/{id}", retentionRulesHandler.UpdateRule)
+			r.Delete("/api/retention-rules/{id}", retentionRulesHandler.DeleteRule)
+			r.Get("/api/retention-rules/{id}/preview", retentionRulesHandler.PreviewRule)
+		})
+
+		// Feature flags evaluated for the current user.
+		r.Get("/api/flags/me", flagsHandler.MyFlags)
+
+		// Grants.gov opportunities and Federal Register documents.
+		r.Get("/api/grants/opportunities", grantsHandler.ListOpportunities)
+		r.Get("/api/grants/deadlines", grantsHandler.ListDeadlines)
+		r.Get("/api/grants/postings", grantsHandler.ListPostings)
+		r.Put("/api/grants/opportunities/{id}/tracking", grantsHandler.SetTracking)
+		r.Get("/api/grants/federal-register", grantsHandler.ListFederalRegister)
+
+		// Tag taxonomy: anyone can read it, admins edit it.
+		r.Get("/api/tags", tagsHandler.ListTags)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireAdmin)
+			r.Post("/api/tags", tagsHandler.CreateTag)
+			r.Put("/api/tags/{name}", tagsHandler.UpdateTag)
+			r.Delete("/api/tags/{name}", tagsHandler.DeleteTag)
+			r.Get("/api/intake/keys", intakeHandler.ListKeys)
+			r.Post("/api/intake/keys", intakeHandler.CreateKey)
+			r.Delete("/api/intake/keys/{id}", intakeHandler.RevokeKey)
+			r.Get("/api/flags", flagsHandler.ListFlags)
+			r.Post("/api/flags", flagsHandler.CreateFlag)
+			r.Put("/api/flags/{name}", flagsHandler.UpdateFlag)
+			r.Delete("/api/flags/{name}", flagsHandler.DeleteFlag)
+			r.Put("/api/flags/{name}/users/{userId}", flagsHandler.SetOverride)
+			r.Delete("/api/flags/{name}/users/{userId}", flagsHandler.DeleteOverride)
+		})
+
 		r.Group(func(r chi.Router) {
 			r.Use(middleware.RequireAdmin)
 			r.Post("/api/admin/reenrich", adminHandler.Reenrich)
+			r.Post("/api/admin/watchlist/reclassify", adminHandler.ReclassifySentiment)
+			r.Post("/api/admin/retention", adminHandler.BulkRetention)
+			r.Get("/api/admin/audit", adminHandler.ListAudit)
+			r.Get("/api/admin/fetches", adminHandler.ListFetches)
+			r.Get("/api/admin/fetches/domains", adminHandler.ListFetchDomains)
+			r.Get("/api/admin/stats", adminHandler.Stats)
+			r.Get("/api/admin/users", authHandler.ListUsers)
+			r.Post("/api/admin/users", authHandler.CreateUser)
+			r.Put("/api/admin/users/{id}", authHandler.UpdateUser)
+			r.Delete("/api/admin/users/{id}", authHandler.DeleteUser)
+			r.Get("/api/admin/ingest", adminHandler.IngestStatus)
 			r.Post("/api/admin/ingest", adminHandler.TriggerIngest)
+			r.Get("/api/admin/ingestions", adminHandler.ListIngestions)
+			r.Get("/api/admin/embeddings/backfill", adminHandler.EmbeddingBackfillStatus)
+			r.Post("/api/admin/embeddings/backfill", adminHandler.TriggerEmbeddingBackfill)
+			r.Get("/api/admin/worker/jobs", adminHandler.ListWorkerJobs)
+			r.Post("/api/admin/worker/jobs/{name}/pause", adminHandler.PauseWorkerJob)
+			r.Post("/api/admin/worker/jobs/{name}/resume", adminHandler.ResumeWorkerJob)
+			r.Post("/api/admin/worker/jobs/{name}/run", adminHandler.RunWorkerJob)
+			r.Get("/api/admin/worker/commands", adminHandler.ListWorkerCommands)
+			r.Get("/api/admin/worker/commands/{id}", adminHandler.GetWorkerCommand)
+			r.Post("/api/admin/filters/test", adminHandler.TestFilters)
+			r.Get("/api/admin/log-levels", logLevelHandler.List)
+			r.Put("/api/admin/log-levels/{service}", logLevelHandler.Set)
+			r.Delete("/api/admin/log-levels/{service}", logLevelHandler.Delete)
 			r.Post("/api/admin/chat", adminHandler.ChatWithNews)
+			r.Post("/api/chat/stream", adminHandler.ChatStream)
 		})
 	})
 
@@ -489,7 +723,7 @@ func setupRouter(
 // startWorkerCron starts the background cron jobs (ingestion, cleanup, etc).
 func startWorkerCron(
 	ctx context.Context, wg *sync.WaitGroup,
-	cfg config.Config, aiClient *ai.OllamaClient, storageClient *storage.Client,
+	cfg config.Config, aiClient ai.Provider, storageClient *storage.Client,
 	articleStore *models.ArticleStore,
 	sourceStore *models.SourceStore,
 	fingerprintStore *models.FingerprintStore,
@@ -509,6 +743,13 @@ func startWorkerCron(
 	entityRelStore *models.EntityRelationshipStore,
 	escritoStore *models.EscritoStore,
 	escritoSourceStore *models.EscritoSourceStore,
+	jobStore *models.JobStore,
+	retentionRuleStore *models.RetentionRuleStore,
+	watchlistDigestStore *models.WatchlistDigestStore,
+	notificationStore *models.NotificationStore,
+	webhookStore *models.WebhookStore,
+	backupRunStore *models.BackupRunStore,
+	pool *pgxpool.Pool,
 ) *cron.Cron {
 	sc := scraper.NewScraper()
 	stores := scraper.Stores{
@@ -516,6 +757,10 @@ func startWorkerCron(
 		Sources:      sourceStore,
 		Fingerprints: fingerprintStore,
 		Entities:     entityStore,
+		Jobs:         jobStore,
+		Webhooks:     webhookStore,
+		Runs:         models.NewIngestionRunStore(pool),
+		Grants:       models.NewGrantStore(pool),
 	}
 
 	crawlerDeps := crawler.Deps{
@@ -525,46 +770,72 @@ func startWorkerCron(
 	}
 
 	c := cron.New()
+	jobs := workerctl.New(ctx, models.NewWorkerJobStore(pool), wg)
 
 	// Ingestion: every 4 hours
-	c.AddFunc("0 */4 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-		jobCtx, cancel := context.WithTimeout(ctx, 3*time.Hour)
-		defer cancel()
+	jobs.Add(c, workerctl.JobIngestion, "0 */4 * * *", 3*time.Hour, func(jobCtx context.Context) {
 		slog.Info("cron: ingestion")
 		scraper.RunIngestion(jobCtx, stores, sc, aiClient, storageClient)
 	})
 
+	// Grants.gov and Federal Register: every 6 hours
+	jobs.Add(c, "grants_ingestion", "30 */6 * * *", 30*time.Minute, func(jobCtx context.Context) {
+		scraper.RunGrantsIngestion(jobCtx, stores)
+	})
+
+	// Grant deadlines: daily at 7am
+	jobs.Add(c, "grant_deadlines", "0 7 * * *", 5*time.Minute, func(jobCtx context.Context) {
+		scraper.FlagClosingGrants(jobCtx, stores.Grants, notificationStore, models.NewTelegramUserStore(pool))
+	})
+
+	// Grant postings: hourly at :20
+	jobs.Add(c, "grant_postings", "20 * * * *", 30*time.Minute, func(jobCtx context.Context) {
+		scraper.RunGrantPostingExtraction(jobCtx, stores.Grants, aiClient)
+	})
+
+	// Evidence expiry warnings: daily at 7:15am
+	jobs.Add(c, "evidence_expiry", "15 7 * * *", 5*time.Minute, func(jobCtx context.Context) {
+		scraper.WarnExpiringEvidence(jobCtx, stores.Articles, notificationStore, models.NewTelegramUserStore(pool))
+	})
+
+	// Job queue (enrichment, evidence uploads): every minute
+	jobs.Add(c, "job_queue", "* * * * *", 30*time.Minute, func(jobCtx context.Context) {
+		scraper.RunJobs(jobCtx, stores, sc, aiClient, storageClient)
+	})
+
 	// Daily brief: 5am
-	c.AddFunc("0 5 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-		jobCtx, cancel := context.WithTimeout(ctx, 10*time.Minute)
-		defer cancel()
+	jobs.Add(c, "daily_brief", "0 5 * * *", 10*time.Minute, func(jobCtx context.Context) {
 		slog.Info("cron: daily brief")
-		scraper.GenerateDailyBrief(jobCtx, articleStore, briefStore, aiClient)
+		scraper.GenerateDailyBrief(jobCtx, articleStore, briefStore, entityStore, aiClient)
 	})
 
-	// Watchlist scan: 4x/day
-	c.AddFunc("0 1,7,13,19 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-		jobCtx, cancel := context.WithTimeout(ctx, 2*time.Hour)
-		defer cancel()
+	// Watchlist scan: 4x/day; the 7am scan is followed by the daily digest
+	jobs.Add(c, workerctl.JobWatchlistScan, "0 1,7,13,19 * * *", 2*time.Hour, func(jobCtx context.Context) {
+		morning := time.Now().Hour() == 7 && workerctl.TriggerFrom(jobCtx) == workerctl.TriggerSchedule
 		slog.Info("cron: watchlist scan")
-		agents.RunWatchlistScan(jobCtx, agents.Deps{
+		deps := agents.Deps{
 			Orgs: watchlistOrgStore, Hits: watchlistHitStore,
 			Articles: articleStore, AI: aiClient,
-		})
+			Digests: watchlistDigestStore, Notifications: notificationStore,
+			Webhooks: webhookStore, Jobs: jobStore,
+		}
+		agents.RunWatchlistScan(jobCtx, deps)
+		if morning {
+			agents.RunWatchlistDigest(jobCtx, deps)
+		}
+	})
+
+	// Weekly watchlist report: Mondays 9am, covering the previous week
+	watchlistReportStore := models.NewWatchlistReportStore(pool)
+	jobs.Add(c, "watchlist_report", "0 9 * * 1", 15*time.Minute, func(jobCtx context.Context) {
+		slog.Info("cron: weekly watchlist report")
+		agents.RunWatchlistReports(jobCtx, agents.Deps{
+			Orgs: watchlistOrgStore, Hits: watchlistHitStore, Reports: watchlistReportStore,
+		}, time.Now())
 	})
 
 	// Research: every 2 min
-	c.AddFunc("*/2 * * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-		jobCtx, cancel := context.WithTimeout(ctx, 2*time.Hour)
-		defer cancel()
+	jobs.Add(c, "research", "*/2 * * * *", 2*time.Hour, func(jobCtx context.Context) {
 		queued, err := researchProjectStore.ListQueued(jobCtx)
 		if err != nil || len(queued) == 0 {
 			return
@@ -577,11 +848,7 @@ func startWorkerCron(
 	})
 
 	// Escritos: every 2 min
-	c.AddFunc("*/2 * * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-		jobCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
-		defer cancel()
+	jobs.Add(c, "escritos", "*/2 * * * *", 30*time.Minute, func(jobCtx context.Context) {
 		queued, err := escritoStore.ListQueued(jobCtx)
 		if err != nil || len(queued) == 0 {
 			return
@@ -594,35 +861,94 @@ func startWorkerCron(
 	})
 
 	// Crawler: every 2 hours
-	c.AddFunc("0 */2 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-		jobCtx, cancel := context.WithTimeout(ctx, 2*time.Hour)
-		defer cancel()
+	jobs.Add(c, "crawl", "0 */2 * * *", 2*time.Hour, func(jobCtx context.Context) {
 		slog.Info("cron: crawler")
 		crawler.RunCrawl(jobCtx, crawlerDeps, 500)
 	})
 
 	// Evidence cleanup: 3am
-	c.AddFunc("0 3 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-		jobCtx, cancel := context.WithTimeout(ctx, 30*time.Minute)
-		defer cancel()
+	jobs.Add(c, "evidence_cleanup", "0 3 * * *", 30*time.Minute, func(jobCtx context.Context) {
 		scraper.RunEvidenceCleanup(jobCtx, stores, storageClient)
 	})
 
+	// Trash purge: 3:30am
+	jobs.Add(c, "trash_purge", "30 3 * * *", 30*time.Minute, func(jobCtx context.Context) {
+		scraper.PurgeTrash(jobCtx, stores.Articles, storageClient, cfg.Trash.PurgeDays)
+	})
+
+	// Trash embeddings: 3:45am
+	jobs.Add(c, "trash_embeddings", "45 3 * * *", 30*time.Minute, func(jobCtx context.Context) {
+		scraper.ArchiveTrashEmbeddings(jobCtx, stores.Articles, aiClient, cfg.Trash.EmbeddingDays)
+	})
+
+	// Embedding backfill: 4:15am
+	jobs.Add(c, workerctl.JobEmbeddingBackfill, "15 4 * * *", 2*time.Hour, func(jobCtx context.Context) {
+		scraper.BackfillEmbeddings(jobCtx, stores.Articles, aiClient, scraper.EmbedBackfillOptions{
+			BatchSize:   cfg.AI.EmbedBatchSize,
+			Concurrency: cfg.AI.EmbedConcurrency,
+		})
+	})
+
+	// Outbound fetch log prune: hourly at :20
+	jobs.Add(c, "fetch_log_prune", "20 * * * *", 10*time.Minute, func(jobCtx context.Context) {
+		fetchlog.Prune(jobCtx, models.NewOutboundFetchStore(pool), cfg.Crawl.LogDays, cfg.Crawl.LogMaxRows)
+	})
+
+	// Evidence text index: every 15 min
+	jobs.Add(c, "evidence_index", "*/15 * * * *", 10*time.Minute, func(jobCtx context.Context) {
+		scraper.RunEvidenceIndexing(jobCtx, articleStore, storageClient)
+	})
+
+	// Feed leak check: hourly
+	jobs.Add(c, "feed_leaks", "10 * * * *", 5*time.Minute, func(jobCtx context.Context) {
+		scraper.CheckFeedLeaks(jobCtx, models.NewFeedAccessStore(pool), models.NewUserStore(pool), notificationStore,
+			scraper.FeedLeakOptions{MinIPs: cfg.Feeds.LeakIPs, AutoRotate: cfg.Feeds.AutoRotate})
+	})
+
+	// Analytics views: every 15 min
+	jobs.Add(c, "analytics_refresh", "*/15 * * * *", 10*time.Minute, func(jobCtx context.Context) {
+		scraper.RefreshAnalytics(jobCtx, models.NewAnalyticsStore(pool))
+	})
+
+	// Retention rules: 3:30am
+	jobs.Add(c, "retention_rules", "30 3 * * *", 15*time.Minute, func(jobCtx context.Context) {
+		scraper.RunRetentionRules(jobCtx, retentionRuleStore)
+	})
+
+	// Triage suggestions (opt-in): every 30 min
+	if cfg.AI.TriageSuggestions {
+		triageStore := models.NewTriageSuggestionStore(pool)
+		jobs.Add(c, "triage_suggestions", "*/30 * * * *", 20*time.Minute, func(jobCtx context.Context) {
+			scraper.RunTriageSuggestions(jobCtx, articleStore, triageStore, watchlistOrgStore, aiClient)
+		})
+	}
+
 	// Session cleanup: 4am
-	c.AddFunc("0 4 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-		jobCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
-		defer cancel()
+	jobs.Add(c, "session_cleanup", "0 4 * * *", 5*time.Minute, func(jobCtx context.Context) {
 		scraper.RunSessionCleanup(jobCtx, sessionStore)
 	})
 
+	// Chat retention: 4:10am
+	jobs.Add(c, "chat_cleanup", "10 4 * * *", 5*time.Minute, func(jobCtx context.Context) {
+		scraper.RunChatCleanup(jobCtx, models.NewChatSessionStore(pool), cfg.Chat.RetentionDays)
+	})
+
+	// AI cache cleanup: 4:20am
+	jobs.Add(c, "ai_cache_cleanup", "20 4 * * *", 5*time.Minute, func(jobCtx context.Context) {
+		scraper.RunAICacheCleanup(jobCtx, models.NewAICacheStore(pool), cfg.AI.CacheDays)
+	})
+
+	// Database backup: Sundays 4:30am
+	jobs.Add(c, "backup", "30 4 * * 0", backup.Timeout, func(jobCtx context.Context) {
+		slog.Info("cron: database backup")
+		if _, err := backup.Run(jobCtx, backup.Deps{Pool: pool, Storage: storageClient, Runs: backupRunStore, Keep: cfg.Backup.Keep}); err != nil {
+			slog.Error("cron: database backup failed", "err", err)
+		}
+	})
+
 	c.Start()
 	slog.Info("worker cron started", "jobs", len(c.Entries()))
+	go jobs.Run()
 
 	// Initial ingestion after 10s
 	go func() {
@@ -631,12 +957,10 @@ func startWorkerCron(
 		case <-ctx.Done():
 			return
 		}
-		wg.Add(1)
-		defer wg.Done()
-		jobCtx, cancel := context.WithTimeout(ctx, 3*time.Hour)
-		defer cancel()
 		slog.Info("running initial ingestion")
-		scraper.RunIngestion(jobCtx, stores, sc, aiClient, storageClient)
+		if err := jobs.Execute(workerctl.JobIngestion, workerctl.TriggerStartup); err != nil {
+			slog.Warn("initial ingestion", "err", err)
+		}
 	}()
 
 	return c
@@ -10,17 +10,25 @@ import (
 
 	"github.com/Saul-Punybz/folio/internal/ai"
 	"github.com/Saul-Punybz/folio/internal/config"
+	"github.com/Saul-Punybz/folio/internal/coverage"
 	"github.com/Saul-Punybz/folio/internal/db"
+	"github.com/Saul-Punybz/folio/internal/i18n"
+	"github.com/Saul-Punybz/folio/internal/logging"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/telegram"
 )
 
 func main() {
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	})))
-
 	cfg := config.Load()
+	logging.Setup("bot", cfg.Log, logging.Text)
+	i18n.SetDefault(cfg.Server.DefaultLanguage)
+	if err := coverage.Setup(cfg.Coverage.ProfilePath); err != nil {
+		slog.Error("failed to load coverage profile", "err", err)
+		os.Exit(1)
+	}
+	if err := ai.LoadTaskOptions(cfg.AI.TaskOptions); err != nil {
+		slog.Warn("ignoring AI_TASK_OPTIONS", "err", err)
+	}
 
 	if cfg.Telegram.BotToken == "" {
 		slog.Error("TELEGRAM_BOT_TOKEN is required")
@@ -42,6 +50,7 @@ func main() {
 		os.Exit(1)
 	}
 	defer pool.Close()
+	go logging.Watch(ctx, models.NewLogLevelStore(pool))
 
 	// Create stores
 	articleStore := models.NewArticleStore(pool)
@@ -54,11 +63,11 @@ func main() {
 	userStore := models.NewUserStore(pool)
 
 	// AI client
-	aiClient := ai.NewClient(
-		cfg.Ollama.Host,
-		cfg.Ollama.InstructModel,
-		cfg.Ollama.EmbedModel,
-	)
+	aiClient, err := ai.NewFromConfig(cfg.AI)
+	if err != nil {
+		slog.Error("invalid AI configuration", "err", err)
+		os.Exit(1)
+	}
 
 	// Create bot
 	bot, err := telegram.New(cfg.Telegram.BotToken, allowlist, telegram.BotDeps{
@@ -0,0 +1,599 @@
+// Command folioctl is a small administration CLI for Folio. It talks to the
+// database (and object storage) directly using the same environment
+// configuration as the API and worker, so routine operations don't require
+// psql or curl.
+//
+// Usage:
+//
+//	folioctl user create -email you@example.com -password secret [-role admin]
+//	folioctl user rotate-feed-token -email you@example.com
+//	folioctl migrate
+//	folioctl ingest
+//	folioctl scan
+//	folioctl evidence verify -id <article-uuid>
+//	folioctl export -id <article-uuid> [-out file.zip]
+//	folioctl backup
+//	folioctl tags infer [-min-support 5] [-max-distance 0.3] [-max-tags 2]
+//	folioctl tags refine [-limit 100]
+package main
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/Saul-Punybz/folio/internal/agents"
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/backup"
+	"github.com/Saul-Punybz/folio/internal/config"
+	"github.com/Saul-Punybz/folio/internal/db"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
+	"github.com/Saul-Punybz/folio/internal/storage"
+	"github.com/Saul-Punybz/folio/internal/useragent"
+)
+
+const usage = `folioctl — Folio administration tool
+
+Commands:
+  user create -email E -password P [-role member|admin]   create a user
+  user rotate-feed-token -email E                          issue a new RSS feed token
+  migrate                                                  apply pending migrations
+  ingest                                                   run one ingestion cycle
+  scan                                                     run one watchlist scan
+  evidence verify -id ARTICLE_ID                           check evidence hashes in S3
+  export -id ARTICLE_ID [-out FILE]                        write an article export ZIP
+  backup                                                   dump the database to S3 now
+  languages                                                detect language for articles missing one
+  tags infer [-min-support N] [-max-distance D] [-max-tags N]
+                                                           tag untagged articles from tag centroids
+  tags refine [-limit N]                                   queue inferred-tag articles for the classifier
+
+Configuration is read from the same environment variables as the API server.
+`
+
+func main() {
+	// Keep library logging quiet unless something goes wrong; command output
+	// goes to stdout.
+	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{
+		Level: slog.LevelWarn,
+	})))
+
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	cfg := config.Load()
+	if err := ai.LoadTaskOptions(cfg.AI.TaskOptions); err != nil {
+		slog.Warn("ignoring AI_TASK_OPTIONS", "err", err)
+	}
+	useragent.Setup(cfg.Crawl)
+
+	var err error
+	switch os.Args[1] {
+	case "user":
+		err = runUser(ctx, cfg, os.Args[2:])
+	case "migrate":
+		err = runMigrate(ctx, cfg)
+	case "ingest":
+		err = runIngest(ctx, cfg)
+	case "scan":
+		err = runScan(ctx, cfg)
+	case "evidence":
+		err = runEvidence(ctx, cfg, os.Args[2:])
+	case "export":
+		err = runExport(ctx, cfg, os.Args[2:])
+	case "backup":
+		err = runBackup(ctx, cfg)
+	case "languages":
+		err = runLanguages(ctx, cfg)
+	case "tags":
+		err = runTags(ctx, cfg, os.Args[2:])
+	case "help", "-h", "--help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// connect opens the database pool. db.Connect applies pending migrations as a
+// side effect, which is what every command wants anyway.
+func connect(ctx context.Context, cfg config.Config) (*pgxpool.Pool, error) {
+	pool, err := db.Connect(ctx, cfg.DB)
+	if err != nil {
+		return nil, fmt.Errorf("connect database: %w", err)
+	}
+	return pool, nil
+}
+
+func newAIClient(cfg config.Config) (*ai.Client, error) {
+	return ai.NewFromConfig(cfg.AI)
+}
+
+func newStorageClient(ctx context.Context, cfg config.Config) (*storage.Client, error) {
+	client, err := storage.NewClient(ctx, cfg.S3)
+	if err != nil {
+		return nil, fmt.Errorf("storage client: %w", err)
+	}
+	return client, nil
+}
+
+// ── user ─────────────────────────────────────────────────────
+
+func runUser(ctx context.Context, cfg config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("user: expected subcommand (create, rotate-feed-token)")
+	}
+
+	switch args[0] {
+	case "create":
+		fs := flag.NewFlagSet("user create", flag.ExitOnError)
+		email := fs.String("email", "", "user email (required)")
+		password := fs.String("password", "", "initial password (required)")
+		role := fs.String("role", "member", "role: member or admin")
+		fs.Parse(args[1:])
+
+		if *email == "" || *password == "" {
+			return fmt.Errorf("user create: -email and -password are required")
+		}
+		if *role != "member" && *role != "admin" {
+			return fmt.Errorf("user create: invalid role %q", *role)
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(*password), bcrypt.DefaultCost)
+		if err != nil {
+			return fmt.Errorf("hash password: %w", err)
+		}
+
+		pool, err := connect(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		user := &models.User{Email: *email, PasswordHash: string(hash), Role: *role}
+		if err := models.NewUserStore(pool).Create(ctx, user); err != nil {
+			return err
+		}
+		fmt.Printf("created %s user %s (%s)\n", user.Role, user.Email, user.ID)
+		return nil
+
+	case "rotate-feed-token":
+		fs := flag.NewFlagSet("user rotate-feed-token", flag.ExitOnError)
+		email := fs.String("email", "", "user email (required)")
+		fs.Parse(args[1:])
+
+		if *email == "" {
+			return fmt.Errorf("user rotate-feed-token: -email is required")
+		}
+
+		pool, err := connect(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		users := models.NewUserStore(pool)
+		user, err := users.GetByEmail(ctx, *email)
+		if err != nil {
+			return err
+		}
+		token, err := users.ResetFeedToken(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("new feed token for %s: %s\n", user.Email, token)
+		fmt.Printf("feed path: /feed/%s.xml\n", token)
+		return nil
+
+	default:
+		return fmt.Errorf("user: unknown subcommand %q", args[0])
+	}
+}
+
+// ── migrate / ingest / scan ──────────────────────────────────
+
+func runMigrate(ctx context.Context, cfg config.Config) error {
+	pool, err := connect(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	var applied int
+	if err := pool.QueryRow(ctx, `SELECT COUNT(*) FROM _migrations`).Scan(&applied); err != nil {
+		return fmt.Errorf("count migrations: %w", err)
+	}
+	fmt.Printf("migrations up to date (%d applied)\n", applied)
+	return nil
+}
+
+// logFetches records the command's outbound fetches until the returned
+// function is called, which writes what is still queued.
+func logFetches(ctx context.Context, pool *pgxpool.Pool) func() {
+	ctx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		fetchlog.Run(ctx, models.NewOutboundFetchStore(pool))
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func runIngest(ctx context.Context, cfg config.Config) error {
+	pool, err := connect(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+	defer logFetches(ctx, pool)()
+
+	scraper.SetSitemapLookback(time.Duration(cfg.Ingest.SitemapLookbackDays) * 24 * time.Hour)
+	renderer := scraper.NewRenderer(scraper.RendererConfig{
+		ExecPath:    cfg.Render.ChromePath,
+		MaxTabs:     cfg.Render.MaxTabs,
+		PageTimeout: cfg.Render.PageTimeout,
+		Settle:      cfg.Render.Settle,
+	})
+	defer renderer.Close()
+	scraper.SetRenderer(renderer)
+
+	storageClient, err := newStorageClient(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	stores := scraper.Stores{
+		Articles:     models.NewArticleStore(pool),
+		Sources:      models.NewSourceStore(pool),
+		Fingerprints: models.NewFingerprintStore(pool),
+		Entities:     models.NewEntityStore(pool),
+		Jobs:         models.NewJobStore(pool),
+		Runs:         models.NewIngestionRunStore(pool),
+	}
+
+	aiClient, err := newAIClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("running ingestion (this may take a while)...")
+	scraper.RunIngestion(ctx, stores, scraper.NewScraper(), aiClient, storageClient)
+	fmt.Println("ingestion finished; enrichment is queued for the worker")
+	return nil
+}
+
+func runScan(ctx context.Context, cfg config.Config) error {
+	pool, err := connect(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+	defer logFetches(ctx, pool)()
+
+	scraper.SetSearchQuota(&scraper.SearchQuota{Usage: models.NewSearchUsageStore(pool), Budgets: cfg.Search.Budgets()})
+	agents.SetSocialConfig(cfg.Social)
+
+	aiClient, err := newAIClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("running watchlist scan...")
+	agents.RunWatchlistScan(ctx, agents.Deps{
+		Orgs:     models.NewWatchlistOrgStore(pool),
+		Hits:     models.NewWatchlistHitStore(pool),
+		Articles: models.NewArticleStore(pool),
+		AI:       aiClient,
+		Webhooks: models.NewWebhookStore(pool),
+	})
+	fmt.Println("watchlist scan finished")
+	return nil
+}
+
+// ── evidence ─────────────────────────────────────────────────
+
+func runEvidence(ctx context.Context, cfg config.Config, args []string) error {
+	if len(args) == 0 || args[0] != "verify" {
+		return fmt.Errorf("evidence: expected subcommand (verify)")
+	}
+
+	fs := flag.NewFlagSet("evidence verify", flag.ExitOnError)
+	idStr := fs.String("id", "", "article id (required)")
+	fs.Parse(args[1:])
+
+	id, err := uuid.Parse(*idStr)
+	if err != nil {
+		return fmt.Errorf("evidence verify: invalid -id: %w", err)
+	}
+
+	storageClient, err := newStorageClient(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	if !storageClient.Configured() {
+		return fmt.Errorf("evidence verify: S3 storage is not configured")
+	}
+
+	meta, err := storageClient.VerifyEvidence(ctx, id)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("evidence OK for %s\n", id)
+	fmt.Printf("  policy:       %s\n", meta.Policy)
+	fmt.Printf("  captured at:  %s\n", meta.CapturedAt.Format("2006-01-02 15:04:05 MST"))
+	fmt.Printf("  raw sha256:   %s\n", meta.RawHash)
+	fmt.Printf("  text sha256:  %s\n", meta.ExtractHash)
+	return nil
+}
+
+// ── backup ───────────────────────────────────────────────────
+
+func runBackup(ctx context.Context, cfg config.Config) error {
+	pool, err := connect(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	storageClient, err := newStorageClient(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("backing up database...")
+	run, err := backup.Run(ctx, backup.Deps{
+		Pool:    pool,
+		Storage: storageClient,
+		Runs:    models.NewBackupRunStore(pool),
+		Keep:    cfg.Backup.Keep,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("backup OK (%s)\n", run.Method)
+	fmt.Printf("  key:     %s\n", run.ObjectKey)
+	fmt.Printf("  size:    %d bytes\n", run.SizeBytes)
+	fmt.Printf("  tables:  %d\n", run.Tables)
+	fmt.Printf("  pruned:  %d old backup(s)\n", run.Pruned)
+	return nil
+}
+
+// ── languages ────────────────────────────────────────────────
+
+// runLanguages backfills articles.language for articles ingested before
+// language detection, which also re-indexes them for full-text search.
+func runLanguages(ctx context.Context, cfg config.Config) error {
+	pool, err := connect(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	articles := models.NewArticleStore(pool)
+	var checked, detected int
+	after := uuid.Nil
+	for {
+		batch, err := articles.ListMissingLanguage(ctx, after, 500)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		for _, a := range batch {
+			checked++
+			after = a.ID
+			lang := scraper.DetectLanguage(a.Title + " " + a.CleanText)
+			if lang == "" {
+				continue
+			}
+			if err := articles.SetLanguage(ctx, a.ID, lang); err != nil {
+				return err
+			}
+			detected++
+		}
+	}
+	fmt.Printf("languages: %d checked, %d detected, %d undetermined\n", checked, detected, checked-detected)
+	return nil
+}
+
+// ── tags ─────────────────────────────────────────────────────
+
+// runTags backfills tags without the LLM: infer matches untagged articles
+// to the centroids of classifier-tagged ones (see ArticleStore.InferTags),
+// and refine queues inferred articles for full enrichment, which replaces
+// their tags with the classifier's.
+func runTags(ctx context.Context, cfg config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("tags: expected subcommand (infer, refine)")
+	}
+
+	switch args[0] {
+	case "infer":
+		fs := flag.NewFlagSet("tags infer", flag.ExitOnError)
+		minSupport := fs.Int("min-support", 5, "classifier-tagged articles a tag needs")
+		maxDistance := fs.Float64("max-distance", 0.3, "max cosine distance to a tag centroid")
+		maxTags := fs.Int("max-tags", 2, "tags assigned per article")
+		fs.Parse(args[1:])
+
+		pool, err := connect(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		articles := models.NewArticleStore(pool)
+		opts := models.TagInference{MinSupport: *minSupport, MaxDistance: *maxDistance, MaxTags: *maxTags}
+		var checked, tagged int
+		after := uuid.Nil
+		for {
+			b, err := articles.InferTags(ctx, after, 500, opts)
+			if err != nil {
+				return err
+			}
+			if b.Checked == 0 {
+				break
+			}
+			checked += b.Checked
+			tagged += b.Tagged
+			after = b.Last
+		}
+		fmt.Printf("tags: %d untagged checked, %d tagged (tag_source=inferred), %d left untagged\n", checked, tagged, checked-tagged)
+		return nil
+
+	case "refine":
+		fs := flag.NewFlagSet("tags refine", flag.ExitOnError)
+		limit := fs.Int("limit", 100, "articles to queue")
+		fs.Parse(args[1:])
+
+		pool, err := connect(ctx, cfg)
+		if err != nil {
+			return err
+		}
+		defer pool.Close()
+
+		ids, err := models.NewArticleStore(pool).ListInferredTagIDs(ctx, *limit)
+		if err != nil {
+			return err
+		}
+		jobs := models.NewJobStore(pool)
+		for _, id := range ids {
+			if err := jobs.Enqueue(ctx, models.JobEnrichArticle, scraper.EnrichJobPayload{ArticleID: id, Reenrich: true}); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("tags: queued %d article(s) with inferred tags for enrichment\n", len(ids))
+		return nil
+
+	default:
+		return fmt.Errorf("tags: unknown subcommand %q", args[0])
+	}
+}
+
+// ── export ───────────────────────────────────────────────────
+
+func runExport(ctx context.Context, cfg config.Config, args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	idStr := fs.String("id", "", "article id (required)")
+	out := fs.String("out", "", "output file (default folio-export-<id>.zip)")
+	fs.Parse(args)
+
+	id, err := uuid.Parse(*idStr)
+	if err != nil {
+		return fmt.Errorf("export: invalid -id: %w", err)
+	}
+	if *out == "" {
+		*out = fmt.Sprintf("folio-export-%s.zip", id)
+	}
+
+	pool, err := connect(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	defer pool.Close()
+
+	article, err := models.NewArticleStore(pool).GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	notes, err := models.NewNoteStore(pool).ListByArticle(ctx, id)
+	if err != nil {
+		return err
+	}
+	if notes == nil {
+		notes = []models.Note{}
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		return fmt.Errorf("export: create %s: %w", *out, err)
+	}
+	defer f.Close()
+
+	zw := zip.NewWriter(f)
+
+	files := map[string]any{
+		"article.json": article,
+		"notes.json":   notes,
+	}
+	for name, v := range files {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("export: marshal %s: %w", name, err)
+		}
+		if err := writeZipFile(zw, name, data); err != nil {
+			return err
+		}
+	}
+
+	// Evidence is optional — include it when storage is configured and the
+	// article still has it.
+	storageClient, err := newStorageClient(ctx, cfg)
+	if err == nil && storageClient.Configured() {
+		if ev, err := storageClient.GetEvidence(ctx, id); err == nil {
+			if err := writeZipFile(zw, "evidence/raw.html", ev.RawHTML); err != nil {
+				return err
+			}
+			if err := writeZipFile(zw, "evidence/extracted.txt", ev.Extracted); err != nil {
+				return err
+			}
+			if len(ev.Screenshot) > 0 {
+				if err := writeZipFile(zw, "evidence/screenshot.png", ev.Screenshot); err != nil {
+					return err
+				}
+			}
+			if ev.Meta != nil {
+				metaJSON, _ := json.MarshalIndent(ev.Meta, "", "  ")
+				if err := writeZipFile(zw, "evidence/capture_meta.json", metaJSON); err != nil {
+					return err
+				}
+			}
+		} else {
+			fmt.Fprintf(os.Stderr, "warning: no evidence exported: %v\n", err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("export: finalize zip: %w", err)
+	}
+	fmt.Printf("exported %q to %s\n", article.Title, *out)
+	return nil
+}
+
+func writeZipFile(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("export: create %s: %w", name, err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("export: write %s: %w", name, err)
+	}
+	return nil
+}
@@ -16,27 +16,38 @@ import (
 
 	"github.com/Saul-Punybz/folio/internal/agents"
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/backup"
 	"github.com/Saul-Punybz/folio/internal/config"
+	"github.com/Saul-Punybz/folio/internal/coverage"
 	"github.com/Saul-Punybz/folio/internal/crawler"
 	"github.com/Saul-Punybz/folio/internal/db"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
 	"github.com/Saul-Punybz/folio/internal/generator"
+	"github.com/Saul-Punybz/folio/internal/i18n"
+	"github.com/Saul-Punybz/folio/internal/logging"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/research"
 	"github.com/Saul-Punybz/folio/internal/scraper"
 	"github.com/Saul-Punybz/folio/internal/storage"
+	"github.com/Saul-Punybz/folio/internal/useragent"
+	"github.com/Saul-Punybz/folio/internal/workerctl"
 )
 
 func main() {
-	// Structured JSON logging.
-	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	}))
-	slog.SetDefault(logger)
+	// Load configuration and set up structured JSON logging.
+	cfg := config.Load()
+	logging.Setup("worker", cfg.Log, logging.JSON)
+	useragent.Setup(cfg.Crawl)
+	i18n.SetDefault(cfg.Server.DefaultLanguage)
+	if err := coverage.Setup(cfg.Coverage.ProfilePath); err != nil {
+		slog.Error("failed to load coverage profile", "err", err)
+		os.Exit(1)
+	}
 
 	slog.Info("worker: starting folio worker")
-
-	// Load configuration.
-	cfg := config.Load()
+	if err := ai.LoadTaskOptions(cfg.AI.TaskOptions); err != nil {
+		slog.Warn("ignoring AI_TASK_OPTIONS", "err", err)
+	}
 
 	// Create a root context that is cancelled on shutdown.
 	ctx, cancel := context.WithCancel(context.Background())
@@ -49,6 +60,8 @@ func main() {
 		os.Exit(1)
 	}
 	defer pool.Close()
+	go logging.Watch(ctx, models.NewLogLevelStore(pool))
+	go fetchlog.Run(ctx, models.NewOutboundFetchStore(pool))
 
 	// Create stores.
 	articleStore := models.NewArticleStore(pool)
@@ -65,6 +78,41 @@ func main() {
 	researchFindingStore := models.NewResearchFindingStore(pool)
 	escritoStore := models.NewEscritoStore(pool)
 	escritoSourceStore := models.NewEscritoSourceStore(pool)
+	jobStore := models.NewJobStore(pool)
+	retentionRuleStore := models.NewRetentionRuleStore(pool)
+	analyticsStore := models.NewAnalyticsStore(pool)
+	searchUsageStore := models.NewSearchUsageStore(pool)
+	scraper.SetSearchQuota(&scraper.SearchQuota{Usage: searchUsageStore, Budgets: cfg.Search.Budgets()})
+	scraper.SetSitemapLookback(time.Duration(cfg.Ingest.SitemapLookbackDays) * 24 * time.Hour)
+	agents.SetSocialConfig(cfg.Social)
+	ai.SetTaxonomySource(models.NewTagStore(pool))
+	if cfg.AI.CacheDays > 0 {
+		ai.SetCache(models.NewAICacheStore(pool))
+	}
+	renderer := scraper.NewRenderer(scraper.RendererConfig{
+		ExecPath:    cfg.Render.ChromePath,
+		MaxTabs:     cfg.Render.MaxTabs,
+		PageTimeout: cfg.Render.PageTimeout,
+		Settle:      cfg.Render.Settle,
+	})
+	defer renderer.Close()
+	scraper.SetRenderer(renderer)
+	if cfg.Render.Screenshots {
+		// A separate browser, since the scraping one skips images.
+		screenshots := scraper.NewRenderer(scraper.RendererConfig{
+			ExecPath:    cfg.Render.ChromePath,
+			MaxTabs:     1,
+			PageTimeout: cfg.Render.PageTimeout,
+			Settle:      cfg.Render.Settle,
+			LoadImages:  true,
+		})
+		defer screenshots.Close()
+		scraper.SetScreenshotRenderer(screenshots)
+	}
+	watchlistDigestStore := models.NewWatchlistDigestStore(pool)
+	watchlistReportStore := models.NewWatchlistReportStore(pool)
+	webhookStore := models.NewWebhookStore(pool)
+	backupRunStore := models.NewBackupRunStore(pool)
 
 	// Crawler stores.
 	crawlDomainStore := models.NewCrawlDomainStore(pool)
@@ -80,17 +128,21 @@ func main() {
 		Sources:      sourceStore,
 		Fingerprints: fingerprintStore,
 		Entities:     entityStore,
+		Jobs:         jobStore,
+		Webhooks:     webhookStore,
+		Runs:         models.NewIngestionRunStore(pool),
+		Grants:       models.NewGrantStore(pool),
 	}
 
 	// Create scraper.
 	sc := scraper.NewScraper()
 
 	// Create AI client.
-	aiClient := ai.NewClient(
-		cfg.Ollama.Host,
-		cfg.Ollama.InstructModel,
-		cfg.Ollama.EmbedModel,
-	)
+	aiClient, err := ai.NewFromConfig(cfg.AI)
+	if err != nil {
+		slog.Error("invalid AI configuration", "err", err)
+		os.Exit(1)
+	}
 
 	// Create S3 storage client.
 	storageClient, err := storage.NewClient(ctx, cfg.S3)
@@ -102,17 +154,14 @@ func main() {
 	// Track in-flight jobs for graceful shutdown.
 	var wg sync.WaitGroup
 
-	// Set up cron scheduler (standard 5-field cron expressions).
+	// Set up cron scheduler (standard 5-field cron expressions). Jobs are
+	// added through workerctl, which records each run and honors the pause
+	// and run-now controls of the admin API.
 	c := cron.New()
+	jobs := workerctl.New(ctx, models.NewWorkerJobStore(pool), &wg)
 
 	// Ingestion: every 4 hours (6 times/day).
-	_, err = c.AddFunc("0 */4 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-
-		jobCtx, jobCancel := context.WithTimeout(ctx, 3*time.Hour)
-		defer jobCancel()
-
+	err = jobs.Add(c, workerctl.JobIngestion, "0 */4 * * *", 3*time.Hour, func(jobCtx context.Context) {
 		slog.Info("cron: ingestion job triggered")
 		scraper.RunIngestion(jobCtx, stores, sc, aiClient, storageClient)
 	})
@@ -121,14 +170,57 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Evidence cleanup: daily at 3am.
-	_, err = c.AddFunc("0 3 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
+	// Grants.gov and Federal Register: every 6 hours, off the ingestion hour.
+	err = jobs.Add(c, "grants_ingestion", "30 */6 * * *", 30*time.Minute, func(jobCtx context.Context) {
+		scraper.RunGrantsIngestion(jobCtx, stores)
+	})
+	if err != nil {
+		slog.Error("worker: add grants cron", "err", err)
+		os.Exit(1)
+	}
+
+	// Grant deadlines: daily at 7am — flag opportunities closing within
+	// 14 days.
+	err = jobs.Add(c, "grant_deadlines", "0 7 * * *", 5*time.Minute, func(jobCtx context.Context) {
+		scraper.FlagClosingGrants(jobCtx, stores.Grants, notificationStore, telegramUserStore)
+	})
+	if err != nil {
+		slog.Error("worker: add grant deadlines cron", "err", err)
+		os.Exit(1)
+	}
 
-		jobCtx, jobCancel := context.WithTimeout(ctx, 30*time.Minute)
-		defer jobCancel()
+	// Grant postings: hourly at :20 — extract funder, eligibility, award
+	// range and deadline from grant-kind articles enrichment did not cover.
+	err = jobs.Add(c, "grant_postings", "20 * * * *", 30*time.Minute, func(jobCtx context.Context) {
+		scraper.RunGrantPostingExtraction(jobCtx, stores.Grants, aiClient)
+	})
+	if err != nil {
+		slog.Error("worker: add grant postings cron", "err", err)
+		os.Exit(1)
+	}
 
+	// Evidence expiry warnings: daily at 7:15am — announce evidence the
+	// cleanup will delete within 7 days.
+	err = jobs.Add(c, "evidence_expiry", "15 7 * * *", 5*time.Minute, func(jobCtx context.Context) {
+		scraper.WarnExpiringEvidence(jobCtx, articleStore, notificationStore, telegramUserStore)
+	})
+	if err != nil {
+		slog.Error("worker: add evidence expiry warnings cron", "err", err)
+		os.Exit(1)
+	}
+
+	// Job queue: every minute — drain enrichment and evidence-upload jobs,
+	// including retries whose backoff has elapsed.
+	err = jobs.Add(c, "job_queue", "* * * * *", 30*time.Minute, func(jobCtx context.Context) {
+		scraper.RunJobs(jobCtx, stores, sc, aiClient, storageClient)
+	})
+	if err != nil {
+		slog.Error("worker: add job queue cron", "err", err)
+		os.Exit(1)
+	}
+
+	// Evidence cleanup: daily at 3am.
+	err = jobs.Add(c, "evidence_cleanup", "0 3 * * *", 30*time.Minute, func(jobCtx context.Context) {
 		slog.Info("cron: evidence cleanup job triggered")
 		scraper.RunEvidenceCleanup(jobCtx, stores, storageClient)
 	})
@@ -137,14 +229,106 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Session cleanup: daily at 4am.
-	_, err = c.AddFunc("0 4 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
+	// Trash purge: daily at 3:30am — delete articles trashed more than
+	// TRASH_PURGE_DAYS ago.
+	err = jobs.Add(c, "trash_purge", "30 3 * * *", 30*time.Minute, func(jobCtx context.Context) {
+		scraper.PurgeTrash(jobCtx, stores.Articles, storageClient, cfg.Trash.PurgeDays)
+	})
+	if err != nil {
+		slog.Error("worker: add trash purge cron", "err", err)
+		os.Exit(1)
+	}
+
+	// Trash embeddings: daily at 3:45am — drop embeddings of articles
+	// trashed more than TRASH_EMBEDDING_DAYS ago and regenerate those of
+	// restored ones.
+	err = jobs.Add(c, "trash_embeddings", "45 3 * * *", 30*time.Minute, func(jobCtx context.Context) {
+		scraper.ArchiveTrashEmbeddings(jobCtx, stores.Articles, aiClient, cfg.Trash.EmbeddingDays)
+	})
+	if err != nil {
+		slog.Error("worker: add trash embeddings cron", "err", err)
+		os.Exit(1)
+	}
+
+	// Embedding backfill: daily at 4:15am — embed articles whose embedding
+	// was never generated, in batches.
+	err = jobs.Add(c, workerctl.JobEmbeddingBackfill, "15 4 * * *", 2*time.Hour, func(jobCtx context.Context) {
+		scraper.BackfillEmbeddings(jobCtx, stores.Articles, aiClient, scraper.EmbedBackfillOptions{
+			BatchSize:   cfg.AI.EmbedBatchSize,
+			Concurrency: cfg.AI.EmbedConcurrency,
+		})
+	})
+	if err != nil {
+		slog.Error("worker: add embedding backfill cron", "err", err)
+		os.Exit(1)
+	}
 
-		jobCtx, jobCancel := context.WithTimeout(ctx, 5*time.Minute)
-		defer jobCancel()
+	// Outbound fetch log: hourly at :20 — apply OUTBOUND_LOG_DAYS and
+	// OUTBOUND_LOG_MAX_ROWS.
+	err = jobs.Add(c, "fetch_log_prune", "20 * * * *", 10*time.Minute, func(jobCtx context.Context) {
+		fetchlog.Prune(jobCtx, models.NewOutboundFetchStore(pool), cfg.Crawl.LogDays, cfg.Crawl.LogMaxRows)
+	})
+	if err != nil {
+		slog.Error("worker: add fetch log prune cron", "err", err)
+		os.Exit(1)
+	}
 
+	// Evidence text index: every 15 minutes — index the full extracted text
+	// preserved in S3 so search is not limited to clean_text.
+	err = jobs.Add(c, "evidence_index", "*/15 * * * *", 10*time.Minute, func(jobCtx context.Context) {
+		scraper.RunEvidenceIndexing(jobCtx, articleStore, storageClient)
+	})
+	if err != nil {
+		slog.Error("worker: add evidence index cron", "err", err)
+		os.Exit(1)
+	}
+
+	// Feed leak check: hourly — flag feed tokens fetched from many addresses
+	// or linked from other sites.
+	err = jobs.Add(c, "feed_leaks", "10 * * * *", 5*time.Minute, func(jobCtx context.Context) {
+		scraper.CheckFeedLeaks(jobCtx, models.NewFeedAccessStore(pool), models.NewUserStore(pool), notificationStore,
+			scraper.FeedLeakOptions{MinIPs: cfg.Feeds.LeakIPs, AutoRotate: cfg.Feeds.AutoRotate})
+	})
+	if err != nil {
+		slog.Error("worker: add feed leak cron", "err", err)
+		os.Exit(1)
+	}
+
+	// Analytics views: every 15 minutes — refresh the aggregates the
+	// analytics endpoints read.
+	err = jobs.Add(c, "analytics_refresh", "*/15 * * * *", 10*time.Minute, func(jobCtx context.Context) {
+		scraper.RefreshAnalytics(jobCtx, analyticsStore)
+	})
+	if err != nil {
+		slog.Error("worker: add analytics refresh cron", "err", err)
+		os.Exit(1)
+	}
+
+	// Retention rules: daily at 3:30am — auto-trash/save by tag or source.
+	err = jobs.Add(c, "retention_rules", "30 3 * * *", 15*time.Minute, func(jobCtx context.Context) {
+		slog.Info("cron: retention rules job triggered")
+		scraper.RunRetentionRules(jobCtx, retentionRuleStore)
+	})
+	if err != nil {
+		slog.Error("worker: add retention rules cron", "err", err)
+		os.Exit(1)
+	}
+
+	// Triage suggestions: every 30 minutes, when TRIAGE_SUGGESTIONS is set —
+	// propose save/trash for new inbox items; never applied automatically.
+	if cfg.AI.TriageSuggestions {
+		triageStore := models.NewTriageSuggestionStore(pool)
+		err = jobs.Add(c, "triage_suggestions", "*/30 * * * *", 20*time.Minute, func(jobCtx context.Context) {
+			scraper.RunTriageSuggestions(jobCtx, articleStore, triageStore, watchlistOrgStore, aiClient)
+		})
+		if err != nil {
+			slog.Error("worker: add triage suggestions cron", "err", err)
+			os.Exit(1)
+		}
+	}
+
+	// Session cleanup: daily at 4am.
+	err = jobs.Add(c, "session_cleanup", "0 4 * * *", 5*time.Minute, func(jobCtx context.Context) {
 		slog.Info("cron: session cleanup job triggered")
 		scraper.RunSessionCleanup(jobCtx, sessionStore)
 	})
@@ -153,16 +337,48 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Daily brief generation: daily at 5am.
-	_, err = c.AddFunc("0 5 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
+	// Chat retention: daily at 4:10am — delete chat sessions inactive for
+	// more than CHAT_RETENTION_DAYS.
+	err = jobs.Add(c, "chat_cleanup", "10 4 * * *", 5*time.Minute, func(jobCtx context.Context) {
+		scraper.RunChatCleanup(jobCtx, models.NewChatSessionStore(pool), cfg.Chat.RetentionDays)
+	})
+	if err != nil {
+		slog.Error("worker: add chat cleanup cron", "err", err)
+		os.Exit(1)
+	}
+
+	// AI cache cleanup: daily at 4:20am — delete results cached more than
+	// AI_CACHE_DAYS ago.
+	err = jobs.Add(c, "ai_cache_cleanup", "20 4 * * *", 5*time.Minute, func(jobCtx context.Context) {
+		scraper.RunAICacheCleanup(jobCtx, models.NewAICacheStore(pool), cfg.AI.CacheDays)
+	})
+	if err != nil {
+		slog.Error("worker: add ai cache cleanup cron", "err", err)
+		os.Exit(1)
+	}
 
-		jobCtx, jobCancel := context.WithTimeout(ctx, 10*time.Minute)
-		defer jobCancel()
+	// Database backup: weekly, Sundays at 4:30am — dump to S3 and rotate.
+	err = jobs.Add(c, "backup", "30 4 * * 0", backup.Timeout, func(jobCtx context.Context) {
+		slog.Info("cron: database backup triggered")
+		_, runErr := backup.Run(jobCtx, backup.Deps{
+			Pool:    pool,
+			Storage: storageClient,
+			Runs:    backupRunStore,
+			Keep:    cfg.Backup.Keep,
+		})
+		if runErr != nil {
+			slog.Error("cron: database backup failed", "err", runErr)
+		}
+	})
+	if err != nil {
+		slog.Error("worker: add database backup cron", "err", err)
+		os.Exit(1)
+	}
 
+	// Daily brief generation: daily at 5am.
+	err = jobs.Add(c, "daily_brief", "0 5 * * *", 10*time.Minute, func(jobCtx context.Context) {
 		slog.Info("cron: daily brief generation triggered")
-		scraper.GenerateDailyBrief(jobCtx, articleStore, briefStore, aiClient)
+		scraper.GenerateDailyBrief(jobCtx, articleStore, briefStore, entityStore, aiClient)
 
 		// Create digest notification for all telegram-linked users.
 		brief, briefErr := briefStore.GetLatest(jobCtx)
@@ -178,35 +394,48 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Watchlist scan: 4 times/day (1am, 7am, 1pm, 7pm).
-	_, err = c.AddFunc("0 1,7,13,19 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-
-		jobCtx, jobCancel := context.WithTimeout(ctx, 2*time.Hour)
-		defer jobCancel()
-
+	// Watchlist scan: 4 times/day (1am, 7am, 1pm, 7pm). The 7am scan is
+	// followed by each user's daily watchlist digest.
+	err = jobs.Add(c, workerctl.JobWatchlistScan, "0 1,7,13,19 * * *", 2*time.Hour, func(jobCtx context.Context) {
+		morning := time.Now().Hour() == 7 && workerctl.TriggerFrom(jobCtx) == workerctl.TriggerSchedule
 		slog.Info("cron: watchlist scan triggered")
-		agents.RunWatchlistScan(jobCtx, agents.Deps{
-			Orgs:     watchlistOrgStore,
-			Hits:     watchlistHitStore,
-			Articles: articleStore,
-			AI:       aiClient,
-		})
+		deps := agents.Deps{
+			Orgs:          watchlistOrgStore,
+			Hits:          watchlistHitStore,
+			Articles:      articleStore,
+			AI:            aiClient,
+			Digests:       watchlistDigestStore,
+			Notifications: notificationStore,
+			Webhooks:      webhookStore,
+			Jobs:          jobStore,
+		}
+		agents.RunWatchlistScan(jobCtx, deps)
+		if morning {
+			slog.Info("cron: watchlist digest triggered")
+			agents.RunWatchlistDigest(jobCtx, deps)
+		}
 	})
 	if err != nil {
 		slog.Error("worker: add watchlist scan cron", "err", err)
 		os.Exit(1)
 	}
 
-	// Research: every 2 minutes, pick up queued deep research projects.
-	_, err = c.AddFunc("*/2 * * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-
-		jobCtx, jobCancel := context.WithTimeout(ctx, 2*time.Hour)
-		defer jobCancel()
+	// Weekly watchlist report: Mondays at 9am, covering the previous week.
+	err = jobs.Add(c, "watchlist_report", "0 9 * * 1", 15*time.Minute, func(jobCtx context.Context) {
+		slog.Info("cron: weekly watchlist report triggered")
+		agents.RunWatchlistReports(jobCtx, agents.Deps{
+			Orgs:    watchlistOrgStore,
+			Hits:    watchlistHitStore,
+			Reports: watchlistReportStore,
+		}, time.Now())
+	})
+	if err != nil {
+		slog.Error("worker: add watchlist report cron", "err", err)
+		os.Exit(1)
+	}
 
+	// Research: every 2 minutes, pick up queued deep research projects.
+	err = jobs.Add(c, "research", "*/2 * * * *", 2*time.Hour, func(jobCtx context.Context) {
 		queued, qErr := researchProjectStore.ListQueued(jobCtx)
 		if qErr != nil || len(queued) == 0 {
 			return
@@ -227,13 +456,7 @@ func main() {
 	}
 
 	// Escritos: every 2 minutes, pick up queued SEO article generation.
-	_, err = c.AddFunc("*/2 * * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-
-		jobCtx, jobCancel := context.WithTimeout(ctx, 30*time.Minute)
-		defer jobCancel()
-
+	err = jobs.Add(c, "escritos", "*/2 * * * *", 30*time.Minute, func(jobCtx context.Context) {
 		queued, qErr := escritoStore.ListQueued(jobCtx)
 		if qErr != nil || len(queued) == 0 {
 			return
@@ -265,13 +488,7 @@ func main() {
 		AI:       aiClient,
 	}
 
-	_, err = c.AddFunc("0 */2 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-
-		jobCtx, jobCancel := context.WithTimeout(ctx, 2*time.Hour)
-		defer jobCancel()
-
+	err = jobs.Add(c, "crawl", "0 */2 * * *", 2*time.Hour, func(jobCtx context.Context) {
 		slog.Info("cron: crawler job triggered")
 		crawler.RunCrawl(jobCtx, crawlerDeps, 500)
 	})
@@ -281,13 +498,7 @@ func main() {
 	}
 
 	// Recrawl: daily at 2am — re-enqueue pages past next_crawl_at.
-	_, err = c.AddFunc("0 2 * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-
-		jobCtx, jobCancel := context.WithTimeout(ctx, 30*time.Minute)
-		defer jobCancel()
-
+	err = jobs.Add(c, "recrawl", "0 2 * * *", 30*time.Minute, func(jobCtx context.Context) {
 		slog.Info("cron: recrawl job triggered")
 		crawler.RunRecrawl(jobCtx, crawlerDeps)
 	})
@@ -297,13 +508,7 @@ func main() {
 	}
 
 	// Crawler enrichment: every 30 minutes — AI enrichment for un-enriched pages.
-	_, err = c.AddFunc("*/30 * * * *", func() {
-		wg.Add(1)
-		defer wg.Done()
-
-		jobCtx, jobCancel := context.WithTimeout(ctx, 1*time.Hour)
-		defer jobCancel()
-
+	err = jobs.Add(c, "crawl_enrichment", "*/30 * * * *", 1*time.Hour, func(jobCtx context.Context) {
 		slog.Info("cron: crawler enrichment job triggered")
 		crawler.RunEnrichment(jobCtx, crawlerDeps, 50)
 	})
@@ -312,18 +517,17 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Start the cron scheduler.
+	// Start the cron scheduler, and take pause/run-now commands from the
+	// admin API.
 	c.Start()
 	slog.Info("worker: cron scheduler started",
 		"jobs", len(c.Entries()),
 	)
+	go jobs.Run()
 
 	// Run an initial ingestion on startup so we don't wait 4 hours for the
-	// first run.
-	wg.Add(1)
+	// first run (unless ingestion is paused).
 	go func() {
-		defer wg.Done()
-
 		// Small delay to let everything settle.
 		select {
 		case <-time.After(5 * time.Second):
@@ -331,11 +535,10 @@ func main() {
 			return
 		}
 
-		jobCtx, jobCancel := context.WithTimeout(ctx, 3*time.Hour)
-		defer jobCancel()
-
 		slog.Info("worker: running initial ingestion on startup")
-		scraper.RunIngestion(jobCtx, stores, sc, aiClient, storageClient)
+		if err := jobs.Execute(workerctl.JobIngestion, workerctl.TriggerStartup); err != nil {
+			slog.Warn("worker: initial ingestion", "err", err)
+		}
 	}()
 
 	// ── Graceful Shutdown ──────────────────────────────────────────
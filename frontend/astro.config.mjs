@@ -8,7 +8,8 @@ export default defineConfig({
   vite: {
     server: {
       proxy: {
-        '/api': 'http://localhost:8080'
+        '/api': 'http://localhost:8080',
+        '/share': 'http://localhost:8080'
       }
     }
   }
@@ -2,6 +2,7 @@ import { useState, useEffect, useCallback, useRef } from 'react';
 import { api, type Article } from '../lib/api';
 import { timeAgo, regionColor, statusColor, isExpired, formatDate, debounce } from '../lib/utils';
 
+// Fallback until the taxonomy loads from /api/tags.
 const TAG_OPTIONS = [
   'politics', 'economy', 'health', 'education', 'infrastructure',
   'environment', 'crime', 'grants', 'federal', 'legislation',
@@ -24,6 +25,7 @@ export default function ArchiveSearch() {
   const [regionFilter, setRegionFilter] = useState('');
   const [statusFilter, setStatusFilter] = useState('');
   const [tagFilter, setTagFilter] = useState('');
+  const [tagOptions, setTagOptions] = useState<string[]>(TAG_OPTIONS);
 
   // Similar articles panel
   const [similarResults, setSimilarResults] = useState<Article[]>([]);
@@ -42,6 +44,7 @@ export default function ArchiveSearch() {
       performSearch(q, '', '', '', '', '', 0);
     }
     inputRef.current?.focus();
+    api.getTags().then((tags) => { if (tags.length) setTagOptions(tags.map((t) => t.name)); }).catch(() => {});
   }, []);
 
   const performSearch = useCallback(
@@ -71,6 +74,7 @@ export default function ArchiveSearch() {
         if (tag) params.tag = tag;
         params.limit = String(limit);
         params.offset = String(searchOffset);
+        if (searchOffset === 0) params.total = 'true';
 
         const data = await api.search(params);
 
@@ -79,7 +83,7 @@ export default function ArchiveSearch() {
         } else {
           setResults((prev) => [...prev, ...(data.results || [])]);
         }
-        setTotal(data.total || 0);
+        if (data.total !== undefined) setTotal(data.total);
         setOffset(searchOffset);
       } catch (err: any) {
         console.error('Search error:', err);
@@ -230,7 +234,7 @@ export default function ArchiveSearch() {
           className={selectClass}
         >
           <option value="">All Tags</option>
-          {TAG_OPTIONS.map((t) => (
+          {tagOptions.map((t) => (
             <option key={t} value={t}>
               {t.charAt(0).toUpperCase() + t.slice(1)}
             </option>
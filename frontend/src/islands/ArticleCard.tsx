@@ -50,6 +50,14 @@ export default function ArticleCard({
             </svg>
           )}
           <span className="font-medium text-zinc-300 truncate">{article.source}</span>
+          {(article.cluster_size ?? 1) > 1 && (
+            <span
+              className="text-zinc-500 shrink-0"
+              title={article.related?.map((m) => m.source).join(', ')}
+            >
+              +{(article.cluster_size ?? 1) - 1} {(article.cluster_size ?? 1) - 1 === 1 ? 'fuente' : 'fuentes'}
+            </span>
+          )}
           <span className="text-zinc-600 shrink-0">&middot;</span>
           <span className="text-zinc-500 shrink-0">{timeAgo(article.published_at || article.created_at)}</span>
         </div>
@@ -84,7 +92,8 @@ export default function ArticleCard({
             {article.tags.slice(0, 3).map((tag) => (
               <span
                 key={tag}
-                className="inline-flex items-center px-1.5 py-0.5 text-[9px] font-semibold uppercase tracking-wider rounded-sm bg-zinc-100 dark:bg-zinc-800 text-zinc-500 dark:text-zinc-500"
+                className={`inline-flex items-center px-1.5 py-0.5 text-[9px] font-semibold uppercase tracking-wider rounded-sm bg-zinc-100 dark:bg-zinc-800 text-zinc-500 dark:text-zinc-500${article.tag_source === 'inferred' ? ' italic' : ''}`}
+                title={article.tag_source === 'inferred' ? 'Inferred from similar articles' : undefined}
               >
                 {tag}
               </span>
@@ -149,12 +158,26 @@ export default function ArticleCard({
               </svg>
             </button>
           )}
+          {article.archive_url && (
+            <a
+              href={article.archive_url}
+              target="_blank"
+              rel="noopener noreferrer"
+              className="ml-auto p-1 text-zinc-400 hover:text-indigo-500 transition-colors"
+              title="Open Wayback Machine snapshot"
+              onClick={(e) => e.stopPropagation()}
+            >
+              <svg className="w-3 h-3" fill="none" viewBox="0 0 24 24" stroke="currentColor" strokeWidth={2}>
+                <path strokeLinecap="round" strokeLinejoin="round" d="M20.25 7.5l-.625 10.632a2.25 2.25 0 01-2.247 2.118H6.622a2.25 2.25 0 01-2.247-2.118L3.75 7.5M10 11.25h4M3.375 7.5h17.25c.621 0 1.125-.504 1.125-1.125v-1.5c0-.621-.504-1.125-1.125-1.125H3.375c-.621 0-1.125.504-1.125 1.125v1.5c0 .621.504 1.125 1.125 1.125z" />
+              </svg>
+            </a>
+          )}
           {article.url && (
             <a
               href={article.url}
               target="_blank"
               rel="noopener noreferrer"
-              className="ml-auto p-1 text-zinc-400 hover:text-indigo-500 transition-colors"
+              className={`${article.archive_url ? '' : 'ml-auto '}p-1 text-zinc-400 hover:text-indigo-500 transition-colors`}
               title="Open original"
               onClick={(e) => e.stopPropagation()}
             >
@@ -1,10 +1,11 @@
 import { useState, useEffect, useCallback, useRef } from 'react';
 import hotkeys from 'hotkeys-js';
-import { api, type Article, type Source } from '../lib/api';
+import { api, type Article, type Source, type Tip, type TriageSuggestion } from '../lib/api';
 import ArticleCard from './ArticleCard';
 import NotesPanel from './NotesPanel';
 import { timeAgo, formatDate } from '../lib/utils';
 
+// Fallback until the taxonomy loads from /api/tags.
 const TAG_OPTIONS = [
   'politics', 'economy', 'health', 'education', 'infrastructure',
   'environment', 'crime', 'grants', 'federal', 'legislation',
@@ -51,6 +52,28 @@ function ArticleBody({ text }: { text: string }) {
   );
 }
 
+/** Submitter details for items that came in through the tip intake */
+function TipDetails({ articleId }: { articleId: string }) {
+  const [tips, setTips] = useState<Tip[]>([]);
+
+  useEffect(() => { api.getItemTips(articleId).then(setTips).catch(() => {}); }, [articleId]);
+
+  if (tips.length === 0) return null;
+  return (
+    <div>
+      <h4 className="text-xs font-bold uppercase tracking-wider text-zinc-400 dark:text-zinc-500 mb-2">Tips</h4>
+      <div className="space-y-2">
+        {tips.map((t) => (
+          <div key={t.id} className="px-3 py-2 rounded-sm bg-amber-500/10 border-l-4 border-amber-500 text-xs text-zinc-600 dark:text-zinc-300">
+            <div className="font-medium">{[t.submitter_name || 'Anonymous', t.submitter_org, t.submitter_contact].filter(Boolean).join(' · ')}</div>
+            <div className="text-zinc-400 dark:text-zinc-500">Receipt {t.id.slice(0, 8)} &middot; {timeAgo(t.created_at)}</div>
+          </div>
+        ))}
+      </div>
+    </div>
+  );
+}
+
 /** Quick Add Source modal */
 function QuickAddSourceModal({ onClose, onCreated }: { onClose: () => void; onCreated: () => void }) {
   const [url, setUrl] = useState('');
@@ -62,6 +85,20 @@ function QuickAddSourceModal({ onClose, onCreated }: { onClose: () => void; onCr
 
   useEffect(() => { inputRef.current?.focus(); }, []);
 
+  const handleImport = async (e: React.ChangeEvent<HTMLInputElement>) => {
+    const file = e.target.files?.[0];
+    e.target.value = '';
+    if (!file) return;
+    setSubmitting(true); setError(''); setResult(null);
+    try {
+      const data = await api.importOPML(await file.text(), region);
+      setResult({ message: `Imported ${data.created.length} feeds, skipped ${data.skipped.length} already added.`, detected: true });
+      onCreated();
+    } catch (err: any) {
+      setError(err.message || 'Failed to import OPML');
+    } finally { setSubmitting(false); }
+  };
+
   const handleSubmit = async (e: React.FormEvent) => {
     e.preventDefault();
     if (!url.trim()) { setError('URL is required'); return; }
@@ -94,6 +131,11 @@ function QuickAddSourceModal({ onClose, onCreated }: { onClose: () => void; onCr
             {REGION_OPTIONS.map((r) => <option key={r} value={r}>{r}</option>)}
           </select>
           <div className="flex items-center justify-end gap-3 pt-1">
+            <label className="mr-auto text-xs text-indigo-500 hover:text-indigo-600 cursor-pointer">
+              Import OPML
+              <input type="file" accept=".opml,.xml,text/x-opml,text/xml" onChange={handleImport} disabled={submitting} className="hidden" />
+            </label>
+            <a href={api.exportOPMLURL} className="text-xs text-zinc-400 hover:text-zinc-600 dark:hover:text-zinc-300">Export</a>
             <button type="button" onClick={onClose} className="px-3 py-1.5 text-sm text-zinc-500 hover:text-zinc-700 dark:hover:text-zinc-300 transition-colors">Cancel</button>
             <button type="submit" disabled={submitting || !!result} className="px-4 py-1.5 text-sm font-medium text-white bg-indigo-500 hover:bg-indigo-600 rounded-lg transition-colors disabled:opacity-50">{submitting ? 'Detecting...' : 'Add Source'}</button>
           </div>
@@ -108,6 +150,7 @@ function CollectModal({ onClose, onCollected }: { onClose: () => void; onCollect
   const [url, setUrl] = useState('');
   const [title, setTitle] = useState('');
   const [region, setRegion] = useState('PR');
+  const [archive, setArchive] = useState(false);
   const [submitting, setSubmitting] = useState(false);
   const [error, setError] = useState('');
   const inputRef = useRef<HTMLInputElement>(null);
@@ -119,7 +162,7 @@ function CollectModal({ onClose, onCollected }: { onClose: () => void; onCollect
     if (!url.trim()) { setError('URL is required'); return; }
     setSubmitting(true); setError('');
     try {
-      await api.collectItem(url.trim(), title.trim(), region);
+      await api.collectItem(url.trim(), title.trim(), region, undefined, archive);
       onCollected(); onClose();
     } catch (err: any) { setError(err.message || 'Failed to collect article'); } finally { setSubmitting(false); }
   };
@@ -139,6 +182,11 @@ function CollectModal({ onClose, onCollected }: { onClose: () => void; onCollect
             className="w-full px-3 py-2 text-sm bg-zinc-50 dark:bg-zinc-800 border border-zinc-200 dark:border-zinc-700 rounded-lg text-zinc-900 dark:text-zinc-100 focus:outline-none focus:ring-2 focus:ring-indigo-500">
             {REGION_OPTIONS.map((r) => <option key={r} value={r}>{r}</option>)}
           </select>
+          <label className="flex items-center gap-2 text-xs text-zinc-600 dark:text-zinc-400 cursor-pointer">
+            <input type="checkbox" checked={archive} onChange={(e) => setArchive(e.target.checked)}
+              className="rounded border-zinc-300 dark:border-zinc-600 text-indigo-500 focus:ring-indigo-500" />
+            Save a Wayback Machine snapshot (paywalled or likely to be edited)
+          </label>
           <div className="flex items-center justify-end gap-3 pt-1">
             <button type="button" onClick={onClose} className="px-3 py-1.5 text-sm text-zinc-500 hover:text-zinc-700 dark:hover:text-zinc-300 transition-colors">Cancel</button>
             <button type="submit" disabled={submitting} className="px-4 py-1.5 text-sm font-medium text-white bg-indigo-500 hover:bg-indigo-600 rounded-lg transition-colors disabled:opacity-50">{submitting ? 'Collecting...' : 'Collect'}</button>
@@ -152,6 +200,7 @@ function CollectModal({ onClose, onCollected }: { onClose: () => void; onCollect
 export default function InboxFeed() {
   const [articles, setArticles] = useState<Article[]>([]);
   const [sources, setSources] = useState<Source[]>([]);
+  const [tagOptions, setTagOptions] = useState<string[]>(TAG_OPTIONS);
   const [selectedIndex, setSelectedIndex] = useState(0);
   const [expandedId, setExpandedId] = useState<string | null>(null);
   const [loading, setLoading] = useState(true);
@@ -161,6 +210,8 @@ export default function InboxFeed() {
   const [showCollect, setShowCollect] = useState(false);
   const [showAddSource, setShowAddSource] = useState(false);
   const [ingesting, setIngesting] = useState(false);
+  const [suggestions, setSuggestions] = useState<Record<string, TriageSuggestion>>({});
+  const [accepting, setAccepting] = useState(false);
   const listRef = useRef<HTMLDivElement>(null);
 
   // Filters
@@ -182,7 +233,32 @@ export default function InboxFeed() {
     try { setSources(await api.getSources()); } catch {}
   }, []);
 
-  useEffect(() => { fetchItems(); fetchSources(); }, [fetchItems, fetchSources]);
+  // Triage suggestions exist only when the worker runs with TRIAGE_SUGGESTIONS.
+  const fetchSuggestions = useCallback(async () => {
+    try {
+      const list = await api.getTriageSuggestions();
+      setSuggestions(Object.fromEntries(list.map((s) => [s.article_id, s])));
+    } catch {}
+  }, []);
+
+  useEffect(() => { fetchItems(); fetchSources(); fetchSuggestions(); }, [fetchItems, fetchSources, fetchSuggestions]);
+
+  const handleAcceptSuggestions = async (action: 'save' | 'trash') => {
+    setAccepting(true);
+    try {
+      await api.acceptTriageSuggestions(undefined, action);
+      await Promise.all([fetchItems(), fetchSuggestions()]);
+    } catch (err: any) { setError(err.message); } finally { setAccepting(false); }
+  };
+
+  const handleDismissSuggestion = async (id: string) => {
+    setSuggestions((prev) => { const next = { ...prev }; delete next[id]; return next; });
+    api.dismissTriageSuggestion(id).catch(() => {});
+  };
+
+  useEffect(() => {
+    api.getTags().then((tags) => { if (tags.length) setTagOptions(tags.map((t) => t.name)); }).catch(() => {});
+  }, []);
 
   const handleFetchNews = async () => {
     setIngesting(true);
@@ -313,6 +389,9 @@ export default function InboxFeed() {
   });
 
   const hasActiveFilters = activeTag || sourceFilter || regionFilter;
+  const pendingSuggestions = articles.map((a) => suggestions[a.id]).filter(Boolean);
+  const suggestedSaves = pendingSuggestions.filter((s) => s.action === 'save').length;
+  const suggestedTrash = pendingSuggestions.length - suggestedSaves;
   const expandedArticle = expandedId ? filteredArticles.find((a) => a.id === expandedId) : null;
   const activeSources = sources.filter((s) => s.active);
 
@@ -355,13 +434,32 @@ export default function InboxFeed() {
         </div>
       </div>
 
+      {/* ─── TRIAGE SUGGESTIONS ─── */}
+      {pendingSuggestions.length > 0 && (
+        <div className="flex items-center gap-3 mb-4 px-4 py-2.5 rounded-sm bg-indigo-50 dark:bg-indigo-500/10 border-l-4 border-indigo-500">
+          <span className="text-xs text-indigo-900 dark:text-indigo-200">
+            AI suggests saving <span className="font-bold">{suggestedSaves}</span> and trashing <span className="font-bold">{suggestedTrash}</span> item{pendingSuggestions.length !== 1 ? 's' : ''}. Open an item to see why.
+          </span>
+          <div className="ml-auto flex items-center gap-1.5">
+            {suggestedSaves > 0 && (
+              <button onClick={() => handleAcceptSuggestions('save')} disabled={accepting}
+                className="px-3 py-1.5 text-xs font-bold uppercase tracking-wider text-white bg-indigo-500 hover:bg-indigo-600 disabled:opacity-50 rounded-sm transition-colors">Save {suggestedSaves}</button>
+            )}
+            {suggestedTrash > 0 && (
+              <button onClick={() => handleAcceptSuggestions('trash')} disabled={accepting}
+                className="px-3 py-1.5 text-xs font-bold uppercase tracking-wider text-zinc-500 hover:text-red-500 bg-white dark:bg-zinc-800 hover:bg-red-50 dark:hover:bg-red-500/10 disabled:opacity-50 rounded-sm transition-colors">Trash {suggestedTrash}</button>
+            )}
+          </div>
+        </div>
+      )}
+
       {/* ─── TOPIC TABS ─── */}
       <div className="flex flex-wrap gap-1.5 mb-4">
         <button onClick={() => setActiveTag('')}
           className={`px-3 py-1.5 text-xs font-bold uppercase tracking-wider rounded-sm transition-colors ${activeTag === '' ? 'bg-zinc-900 dark:bg-zinc-100 text-white dark:text-zinc-900' : 'bg-zinc-100 dark:bg-zinc-800 text-zinc-500 dark:text-zinc-400 hover:bg-zinc-200 dark:hover:bg-zinc-700'}`}>
           All <span className="font-normal ml-1 opacity-60">{articles.length}</span>
         </button>
-        {TAG_OPTIONS.filter((t) => tagCounts[t]).map((tag) => (
+        {tagOptions.filter((t) => tagCounts[t]).map((tag) => (
           <button key={tag} onClick={() => setActiveTag(activeTag === tag ? '' : tag)}
             className={`px-3 py-1.5 text-xs font-bold uppercase tracking-wider rounded-sm transition-colors ${activeTag === tag ? 'bg-indigo-500 text-white' : 'bg-zinc-100 dark:bg-zinc-800 text-zinc-500 dark:text-zinc-400 hover:bg-zinc-200 dark:hover:bg-zinc-700'}`}>
             {tag} <span className="font-normal ml-1 opacity-60">{tagCounts[tag]}</span>
@@ -444,6 +542,16 @@ export default function InboxFeed() {
                   </button>
                 </div>
 
+                {suggestions[expandedArticle.id] && (
+                  <div className="flex items-start gap-3 px-4 py-3 rounded-sm bg-zinc-50 dark:bg-zinc-800/50 border border-zinc-200 dark:border-zinc-800">
+                    <div className="flex-1">
+                      <h4 className="text-xs font-bold uppercase tracking-wider text-zinc-400 dark:text-zinc-500 mb-1">Suggested: {suggestions[expandedArticle.id].action}</h4>
+                      <p className="text-sm text-zinc-600 dark:text-zinc-300">{suggestions[expandedArticle.id].rationale}</p>
+                    </div>
+                    <button onClick={() => handleDismissSuggestion(expandedArticle.id)} className="shrink-0 text-xs text-zinc-400 hover:text-zinc-600 dark:hover:text-zinc-300 transition-colors">Dismiss</button>
+                  </div>
+                )}
+
                 {expandedArticle.summary && expandedArticle.clean_text && expandedArticle.summary !== expandedArticle.clean_text.slice(0, expandedArticle.summary.length) && (
                   <div className="px-4 py-3 rounded-sm bg-indigo-50 dark:bg-indigo-500/10 border-l-4 border-indigo-500">
                     <h4 className="text-xs font-bold uppercase tracking-wider text-indigo-600 dark:text-indigo-400 mb-1.5">Summary</h4>
@@ -484,6 +592,8 @@ export default function InboxFeed() {
                   </div>
                 </div>
 
+                <TipDetails articleId={expandedArticle.id} />
+
                 <NotesPanel articleId={expandedArticle.id} />
               </div>
             </div>
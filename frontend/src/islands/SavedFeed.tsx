@@ -37,6 +37,7 @@ export default function SavedFeed() {
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState('');
   const [filter, setFilter] = useState('');
+  const [shareCopied, setShareCopied] = useState(false);
   const [expandedId, setExpandedId] = useState<string | null>(null);
 
   const fetchItems = useCallback(async () => {
@@ -84,6 +85,17 @@ export default function SavedFeed() {
     });
   }, [articles, filter]);
 
+  const handleCopyShareLink = useCallback(async (id: string) => {
+    try {
+      const card = await api.getItemCard(id);
+      await navigator.clipboard.writeText(card.share_url);
+      setShareCopied(true);
+      setTimeout(() => setShareCopied(false), 2000);
+    } catch {
+      // Clipboard or card unavailable; nothing to undo.
+    }
+  }, []);
+
   const handlePin = useCallback(async (id: string) => {
     setArticles((prev) =>
       prev.map((a) => (a.id === id ? { ...a, pinned: !a.pinned } : a))
@@ -340,6 +352,13 @@ export default function SavedFeed() {
                       </svg>
                       Export ZIP
                     </a>
+                    <button onClick={() => handleCopyShareLink(expandedArticle.id)}
+                      className="inline-flex items-center gap-1.5 px-3 py-1.5 text-xs font-medium text-zinc-500 dark:text-zinc-400 bg-zinc-100 dark:bg-zinc-800 hover:bg-zinc-200 dark:hover:bg-zinc-700 rounded-lg transition-colors">
+                      <svg className="w-3.5 h-3.5" fill="none" viewBox="0 0 24 24" stroke="currentColor" strokeWidth={2}>
+                        <path strokeLinecap="round" strokeLinejoin="round" d="M13.19 8.688a4.5 4.5 0 011.242 7.244l-4.5 4.5a4.5 4.5 0 01-6.364-6.364l1.757-1.757m13.35-.622l1.757-1.757a4.5 4.5 0 00-6.364-6.364l-4.5 4.5a4.5 4.5 0 001.242 7.244" />
+                      </svg>
+                      {shareCopied ? 'Copied' : 'Share link'}
+                    </button>
                     <button onClick={() => handlePin(expandedArticle.id)}
                       className={`inline-flex items-center gap-1.5 px-3 py-1.5 text-xs font-medium rounded-lg transition-colors ${
                         expandedArticle.pinned ? 'text-yellow-500 bg-yellow-500/10 hover:bg-yellow-500/20' : 'text-zinc-400 bg-zinc-100 dark:bg-zinc-800 hover:text-yellow-500 hover:bg-yellow-500/10'
@@ -1,5 +1,5 @@
 import { useState, useEffect, useCallback } from 'react';
-import { api, type Source } from '../lib/api';
+import { api, type Source, type APIMapping } from '../lib/api';
 import { formatDate } from '../lib/utils';
 
 interface SourceFormData {
@@ -13,8 +13,21 @@ interface SourceFormData {
   title_selector: string;
   body_selector: string;
   date_selector: string;
+  render_js: boolean;
+  archive_snapshots: boolean;
+  api_mapping: string;
 }
 
+const apiMappingExample = JSON.stringify({
+  items: 'data.articles',
+  url: 'url',
+  title: 'headline',
+  body: 'content_html',
+  published: 'published_at',
+  image: 'images.0.url',
+  auth: { type: 'bearer', secret_env: 'FOLIO_PARTNER_ENDI_TOKEN' },
+}, null, 2);
+
 const emptyForm: SourceFormData = {
   name: '',
   base_url: '',
@@ -26,6 +39,9 @@ const emptyForm: SourceFormData = {
   title_selector: '',
   body_selector: '',
   date_selector: '',
+  render_js: false,
+  archive_snapshots: false,
+  api_mapping: '',
 };
 
 function SourceFormModal({
@@ -55,11 +71,24 @@ function SourceFormModal({
       return;
     }
 
-    if (form.feed_type === 'rss' && !form.feed_url.trim()) {
-      setError('Feed URL is required for RSS sources');
+    if ((form.feed_type === 'rss' || form.feed_type === 'jsonfeed') && !form.feed_url.trim()) {
+      setError('Feed URL is required for RSS and JSON Feed sources');
       return;
     }
 
+    if (form.feed_type === 'api') {
+      if (!form.feed_url.trim()) {
+        setError('API URL is required for partner API sources');
+        return;
+      }
+      try {
+        JSON.parse(form.api_mapping);
+      } catch {
+        setError('API mapping must be valid JSON');
+        return;
+      }
+    }
+
     if (form.feed_type === 'scrape' && !form.list_urls.trim()) {
       setError('At least one List URL is required for scrape sources');
       return;
@@ -143,11 +172,15 @@ function SourceFormModal({
           {/* Feed Type — visual tabs */}
           <div>
             <label className={labelClass}>Feed Type</label>
-            <div className="grid grid-cols-3 gap-2 mt-1">
+            <div className="grid grid-cols-4 gap-2 mt-1">
               {[
                 { value: 'rss', label: 'RSS', desc: 'Standard RSS/Atom feed' },
+                { value: 'jsonfeed', label: 'JSON Feed', desc: 'JSON Feed 1.0/1.1' },
                 { value: 'scrape', label: 'Scrape', desc: 'HTML page with CSS selectors' },
                 { value: 'sitemap', label: 'Sitemap', desc: 'XML sitemap index' },
+                { value: 'api', label: 'API', desc: 'Licensed partner JSON API' },
+                { value: 'grantsgov', label: 'Grants.gov', desc: 'Search2 opportunities API' },
+                { value: 'federalregister', label: 'Fed. Register', desc: 'Federal Register documents API' },
               ].map((opt) => (
                 <button
                   key={opt.value}
@@ -168,18 +201,71 @@ function SourceFormModal({
             </div>
           </div>
 
-          {/* RSS / Sitemap URL — shown for rss and sitemap types */}
-          {(form.feed_type === 'rss' || form.feed_type === 'sitemap') && (
+          {/* Feed / Sitemap URL — shown for rss, jsonfeed, and sitemap types */}
+          {(form.feed_type === 'rss' || form.feed_type === 'jsonfeed' || form.feed_type === 'sitemap') && (
             <div>
-              <label className={labelClass}>{form.feed_type === 'rss' ? 'Feed URL' : 'Sitemap URL'}</label>
+              <label className={labelClass}>{form.feed_type === 'sitemap' ? 'Sitemap URL' : 'Feed URL'}</label>
               <input
                 type="url"
                 value={form.feed_url}
                 onChange={(e) => setForm({ ...form, feed_url: e.target.value })}
-                placeholder={form.feed_type === 'rss' ? 'https://www.elnuevodia.com/arcio/rss/' : 'https://example.com/sitemap.xml'}
+                placeholder={form.feed_type === 'sitemap' ? 'https://example.com/sitemap.xml' : form.feed_type === 'jsonfeed' ? 'https://example.org/feed.json' : 'https://www.elnuevodia.com/arcio/rss/'}
                 className={inputClass}
               />
-              <p className={hintClass}>{form.feed_type === 'rss' ? 'RSS or Atom feed URL' : 'XML sitemap URL'}</p>
+              <p className={hintClass}>{form.feed_type === 'sitemap' ? 'XML sitemap URL' : form.feed_type === 'jsonfeed' ? 'JSON Feed URL' : 'RSS or Atom feed URL'}</p>
+            </div>
+          )}
+
+          {/* Grants.gov search fields / Federal Register search URL */}
+          {(form.feed_type === 'grantsgov' || form.feed_type === 'federalregister') && (
+            <div>
+              <label className={labelClass}>{form.feed_type === 'grantsgov' ? 'Search fields' : 'Search URL'}</label>
+              <input
+                type="text"
+                value={form.feed_url}
+                onChange={(e) => setForm({ ...form, feed_url: e.target.value })}
+                placeholder={form.feed_type === 'grantsgov' ? 'keyword=Puerto Rico&agencies=HHS' : 'https://www.federalregister.gov/api/v1/documents.json?conditions[term]=Puerto+Rico'}
+                className={inputClass}
+              />
+              <p className={hintClass}>
+                {form.feed_type === 'grantsgov'
+                  ? 'Query string of Search2 fields: keyword, agencies, aln, oppStatuses, fundingCategories, eligibilities'
+                  : 'documents.json search URL; empty searches for "Puerto Rico"'}
+              </p>
+            </div>
+          )}
+
+          {/* Partner API — endpoint and JSON field mapping */}
+          {form.feed_type === 'api' && (
+            <div className="space-y-4 p-4 rounded-lg border-2 border-indigo-200 dark:border-indigo-500/30 bg-indigo-50/50 dark:bg-indigo-500/5">
+              <h3 className="text-xs font-bold uppercase tracking-wider text-indigo-600 dark:text-indigo-400">
+                Partner API
+              </h3>
+
+              <div>
+                <label className={labelClass}>API URL</label>
+                <input
+                  type="url"
+                  value={form.feed_url}
+                  onChange={(e) => setForm({ ...form, feed_url: e.target.value })}
+                  placeholder="https://api.partner.example/v1/articles"
+                  className={inputClass}
+                />
+              </div>
+
+              <div>
+                <label className={labelClass}>Mapping (JSON)</label>
+                <textarea
+                  value={form.api_mapping}
+                  onChange={(e) => setForm({ ...form, api_mapping: e.target.value })}
+                  placeholder={apiMappingExample}
+                  rows={10}
+                  className={`${inputClass} font-mono text-xs`}
+                />
+                <p className={hintClass}>
+                  Dot paths into the response. Credentials are read from the server environment variable named in auth.secret_env (must start with FOLIO_PARTNER_).
+                </p>
+              </div>
             </div>
           )}
 
@@ -254,6 +340,32 @@ function SourceFormModal({
             </div>
           )}
 
+          <label className="flex items-start gap-2 cursor-pointer">
+            <input
+              type="checkbox"
+              checked={form.render_js}
+              onChange={(e) => setForm({ ...form, render_js: e.target.checked })}
+              className="mt-0.5 rounded border-zinc-300 dark:border-zinc-600 text-indigo-500 focus:ring-indigo-500"
+            />
+            <span>
+              <span className="block text-sm font-medium text-zinc-700 dark:text-zinc-300">Render JavaScript</span>
+              <span className={`block ${hintClass}`}>Load pages in headless Chrome, for sites that build their content client-side (slower)</span>
+            </span>
+          </label>
+
+          <label className="flex items-start gap-2 cursor-pointer">
+            <input
+              type="checkbox"
+              checked={form.archive_snapshots}
+              onChange={(e) => setForm({ ...form, archive_snapshots: e.target.checked })}
+              className="mt-0.5 rounded border-zinc-300 dark:border-zinc-600 text-indigo-500 focus:ring-indigo-500"
+            />
+            <span>
+              <span className="block text-sm font-medium text-zinc-700 dark:text-zinc-300">Wayback Machine snapshots</span>
+              <span className={`block ${hintClass}`}>Archive each new article on web.archive.org, for paywalled outlets or ones that edit stories after publishing</span>
+            </span>
+          </label>
+
           <div className="flex items-center justify-end gap-3 pt-2">
             <button
               type="button"
@@ -287,8 +399,14 @@ function formToPayload(data: SourceFormData): Partial<Source> {
     title_selector: data.title_selector,
     body_selector: data.body_selector,
     date_selector: data.date_selector,
+    render_js: data.render_js,
+    archive_snapshots: data.archive_snapshots,
   };
 
+  if (data.feed_type === 'api' && data.api_mapping.trim()) {
+    payload.api_mapping = JSON.parse(data.api_mapping) as APIMapping;
+  }
+
   // Parse list_urls from newline-separated text.
   if (data.list_urls.trim()) {
     payload.list_urls = data.list_urls
@@ -315,6 +433,9 @@ function sourceToForm(source: Source): SourceFormData {
     title_selector: source.title_selector || '',
     body_selector: source.body_selector || '',
     date_selector: source.date_selector || '',
+    render_js: source.render_js ?? false,
+    archive_snapshots: source.archive_snapshots ?? false,
+    api_mapping: source.api_mapping ? JSON.stringify(source.api_mapping, null, 2) : '',
   };
 }
 
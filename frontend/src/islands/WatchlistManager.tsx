@@ -1,13 +1,16 @@
 import { useState, useEffect, useCallback } from 'react';
-import { api, type WatchlistOrg, type WatchlistHit } from '../lib/api';
+import { api, type WatchlistOrg, type WatchlistHit, type KeywordSuggestion, type WatchlistPreview } from '../lib/api';
 
 const SOURCE_TYPES = [
   { key: 'all', label: 'Todos' },
   { key: 'google_news', label: 'Google News' },
   { key: 'web', label: 'Web' },
   { key: 'local', label: 'Local' },
+  { key: 'semantic', label: 'Semántica' },
   { key: 'youtube', label: 'YouTube' },
   { key: 'reddit', label: 'Reddit' },
+  { key: 'social', label: 'Social' },
+  { key: 'gov', label: 'Gobierno' },
 ] as const;
 
 const SENTIMENT_STYLES: Record<string, { bg: string; text: string; label: string; pill: string }> = {
@@ -65,6 +68,18 @@ function SourceIcon({ type, className = 'w-3.5 h-3.5' }: { type: string; classNa
           <path strokeLinecap="round" strokeLinejoin="round" d="M20.25 8.511c.884.284 1.5 1.128 1.5 2.097v4.286c0 1.136-.847 2.1-1.98 2.193-.34.027-.68.052-1.02.072v3.091l-3-3c-1.354 0-2.694-.055-4.02-.163a2.115 2.115 0 01-.825-.242m9.345-8.334a2.126 2.126 0 00-.476-.095 48.64 48.64 0 00-8.048 0c-1.131.094-1.976 1.057-1.976 2.192v4.286c0 .837.46 1.58 1.155 1.951m9.345-8.334V6.637c0-1.621-1.152-3.026-2.76-3.235A48.455 48.455 0 0011.25 3c-2.115 0-4.198.137-6.24.402-1.608.209-2.76 1.614-2.76 3.235v6.226c0 1.621 1.152 3.026 2.76 3.235.577.075 1.157.14 1.74.194V21l4.155-4.155" />
         </svg>
       );
+    case 'social':
+      return (
+        <svg className={className} fill="none" viewBox="0 0 24 24" stroke="currentColor" strokeWidth={2}>
+          <path strokeLinecap="round" strokeLinejoin="round" d="M16.5 12a4.5 4.5 0 11-9 0 4.5 4.5 0 019 0zm0 0c0 1.657 1.007 3 2.25 3S21 13.657 21 12a9 9 0 10-2.636 6.364M16.5 12V8.25" />
+        </svg>
+      );
+    case 'gov':
+      return (
+        <svg className={className} fill="none" viewBox="0 0 24 24" stroke="currentColor" strokeWidth={2}>
+          <path strokeLinecap="round" strokeLinejoin="round" d="M12 21v-8.25M15.75 21v-8.25M8.25 21v-8.25M3 9l9-6 9 6m-1.5 12V10.332A48.36 48.36 0 0012 9.75c-2.551 0-5.056.2-7.5.582V21M3 21h18M12 6.75h.008v.008H12V6.75z" />
+        </svg>
+      );
     default:
       return (
         <svg className={className} fill="none" viewBox="0 0 24 24" stroke="currentColor" strokeWidth={2}>
@@ -78,8 +93,11 @@ const SOURCE_LABELS: Record<string, string> = {
   google_news: 'Google News',
   web: 'Web',
   local: 'Local',
+  semantic: 'Semántica',
   youtube: 'YouTube',
   reddit: 'Reddit',
+  social: 'Social',
+  gov: 'Gobierno',
 };
 
 export default function WatchlistManager() {
@@ -98,13 +116,17 @@ export default function WatchlistManager() {
   const [feedURL, setFeedURL] = useState('');
   const [feedCopied, setFeedCopied] = useState(false);
   const [regenerating, setRegenerating] = useState(false);
+  const [importResult, setImportResult] = useState('');
+  const [preview, setPreview] = useState<{ keyword: string; loading: boolean; data?: WatchlistPreview; error?: string } | null>(null);
 
   // Form
   const [formName, setFormName] = useState('');
   const [formWebsite, setFormWebsite] = useState('');
   const [formKeywords, setFormKeywords] = useState('');
   const [formYouTube, setFormYouTube] = useState('');
+  const [formSocialPages, setFormSocialPages] = useState('');
   const [enriching, setEnriching] = useState(false);
+  const [suggestions, setSuggestions] = useState<KeywordSuggestion[]>([]);
 
   // Expanded drafts
   const [expandedDraft, setExpandedDraft] = useState<string | null>(null);
@@ -151,14 +173,15 @@ export default function WatchlistManager() {
   const handleSaveOrg = async () => {
     const keywords = formKeywords.split(',').map(k => k.trim()).filter(Boolean);
     const youtube_channels = formYouTube.split('\n').map(c => c.trim()).filter(Boolean);
+    const social_pages = formSocialPages.split('\n').map(p => p.trim()).filter(Boolean);
     const website = formWebsite.trim();
 
     try {
       let savedOrg: import('../lib/api').WatchlistOrg;
       if (editingOrg) {
-        savedOrg = await api.updateWatchlistOrg(editingOrg.id, { name: formName, website, keywords, youtube_channels });
+        savedOrg = await api.updateWatchlistOrg(editingOrg.id, { name: formName, website, keywords, youtube_channels, social_pages });
       } else {
-        savedOrg = await api.createWatchlistOrg({ name: formName, website, keywords, youtube_channels });
+        savedOrg = await api.createWatchlistOrg({ name: formName, website, keywords, youtube_channels, social_pages });
       }
       setShowAddOrg(false);
       setEditingOrg(null);
@@ -214,14 +237,33 @@ export default function WatchlistManager() {
     setFormWebsite(org.website || '');
     setFormKeywords(org.keywords.join(', '));
     setFormYouTube(org.youtube_channels.join('\n'));
+    setFormSocialPages((org.social_pages || []).join('\n'));
     setShowAddOrg(true);
+    setSuggestions([]);
+    api.getKeywordSuggestions(org.id)
+      .then(res => setSuggestions(res.suggestions))
+      .catch(e => console.error('Failed to load keyword suggestions:', e));
+  };
+
+  const handleAcceptSuggestion = async (keyword: string) => {
+    if (!editingOrg) return;
+    try {
+      await api.acceptKeywordSuggestions(editingOrg.id, [keyword]);
+      setFormKeywords(prev => (prev.trim() ? `${prev.trim()}, ${keyword}` : keyword));
+      setSuggestions(prev => prev.filter(s => s.keyword !== keyword));
+      await fetchOrgs();
+    } catch (e) {
+      console.error('Failed to accept keyword:', e);
+    }
   };
 
   const resetForm = () => {
+    setSuggestions([]);
     setFormName('');
     setFormWebsite('');
     setFormKeywords('');
     setFormYouTube('');
+    setFormSocialPages('');
   };
 
   // Hit actions
@@ -257,21 +299,51 @@ export default function WatchlistManager() {
     setScanning(true);
     try {
       await api.triggerWatchlistScan();
-      // Poll for new results every 3 seconds for 30 seconds
-      let polls = 0;
+      // The worker runs the scan; poll its status and refresh hits until it finishes.
       const interval = setInterval(async () => {
-        polls++;
         await fetchHits();
-        if (polls >= 10) clearInterval(interval);
-      }, 3000);
-      // Also stop scanning indicator after 15s
-      setTimeout(() => setScanning(false), 15000);
+        try {
+          const { job, command } = await api.getWatchlistScanStatus();
+          const active = job.running > 0 || command?.status === 'pending' || command?.status === 'running';
+          if (!active) {
+            clearInterval(interval);
+            setScanning(false);
+          }
+        } catch {
+          clearInterval(interval);
+          setScanning(false);
+        }
+      }, 5000);
     } catch (e) {
       console.error('Failed to trigger scan:', e);
       setScanning(false);
     }
   };
 
+  const handlePreviewKeyword = async (keyword: string) => {
+    setPreview({ keyword, loading: true });
+    try {
+      const data = await api.previewWatchlistScan(keyword);
+      setPreview({ keyword, loading: false, data });
+    } catch (err: any) {
+      setPreview({ keyword, loading: false, error: err.message || 'No se pudo generar la vista previa' });
+    }
+  };
+
+  const handleImportCSV = async (e: React.ChangeEvent<HTMLInputElement>) => {
+    const file = e.target.files?.[0];
+    e.target.value = '';
+    if (!file) return;
+    try {
+      const data = await api.importWatchlistOrgs(await file.text());
+      const errors = data.errors.map(err => `linea ${err.line}: ${err.error}`).join(', ');
+      setImportResult(`${data.created.length} importadas, ${data.skipped.length} ya existian${errors ? ` — ${errors}` : ''}`);
+      await fetchOrgs();
+    } catch (err: any) {
+      setImportResult(err.message || 'No se pudo importar el CSV');
+    }
+  };
+
   const handleShowRSS = async () => {
     try {
       const data = await api.getWatchlistFeedURL();
@@ -381,6 +453,25 @@ export default function WatchlistManager() {
               + Agregar
             </button>
           </div>
+          <div className="flex items-center gap-3 px-1">
+            <label className="text-[10px] font-semibold text-zinc-400 hover:text-indigo-500 cursor-pointer transition-colors">
+              Importar CSV
+              <input type="file" accept=".csv,text/csv" onChange={handleImportCSV} className="hidden" />
+            </label>
+            <a href={api.exportWatchlistOrgsURL} className="text-[10px] font-semibold text-zinc-400 hover:text-indigo-500 transition-colors">
+              Exportar CSV
+            </a>
+            <a
+              href={api.exportCommunicationsURL({ org_id: selectedOrg || undefined })}
+              title="Respuestas aprobadas y enviadas de los ultimos 30 dias"
+              className="text-[10px] font-semibold text-zinc-400 hover:text-indigo-500 transition-colors"
+            >
+              Exportar respuestas
+            </a>
+          </div>
+          {importResult && (
+            <p className="px-1 text-[10px] text-zinc-500 dark:text-zinc-400">{importResult}</p>
+          )}
 
           {orgs.length === 0 && (
             <div className="p-4 rounded-sm border border-dashed border-zinc-300 dark:border-zinc-700 text-center">
@@ -525,6 +616,7 @@ export default function WatchlistManager() {
                   onToggleDraft={() => setExpandedDraft(expandedDraft === hit.id ? null : hit.id)}
                   onMarkSeen={() => handleMarkSeen(hit.id)}
                   onDelete={() => handleDeleteHit(hit.id)}
+                  onUpdated={(updated) => setHits(prev => prev.map(h => h.id === updated.id ? updated : h))}
                 />
               ))}
             </div>
@@ -580,12 +672,62 @@ export default function WatchlistManager() {
                 {formKeywords && (
                   <div className="mt-1.5 flex flex-wrap gap-1">
                     {formKeywords.split(',').map(k => k.trim()).filter(Boolean).map((kw, i) => (
-                      <span key={i} className="px-2 py-0.5 text-[10px] rounded-full bg-indigo-500/10 text-indigo-600 dark:text-indigo-400 font-medium">
+                      <button
+                        key={i}
+                        type="button"
+                        onClick={() => handlePreviewKeyword(kw)}
+                        title="Vista previa: buscar ahora sin guardar"
+                        className="px-2 py-0.5 text-[10px] rounded-full bg-indigo-500/10 text-indigo-600 dark:text-indigo-400 hover:bg-indigo-500/20 font-medium transition-colors"
+                      >
                         {kw}
-                      </span>
+                      </button>
                     ))}
                   </div>
                 )}
+                {preview && (
+                  <div className="mt-2 p-2 rounded-lg border border-zinc-200 dark:border-zinc-700 max-h-56 overflow-y-auto">
+                    <div className="flex items-center justify-between mb-1">
+                      <p className="text-[10px] font-medium text-zinc-500 dark:text-zinc-400">
+                        Vista previa: {preview.keyword}
+                        {preview.data && ` — ${preview.data.counts.new || 0} nuevas, ${preview.data.counts.filtered || 0} filtradas, ${preview.data.counts.existing || 0} existentes`}
+                      </p>
+                      <button type="button" onClick={() => setPreview(null)} className="text-[10px] text-zinc-400 hover:text-zinc-600">Cerrar</button>
+                    </div>
+                    {preview.loading && <p className="text-[10px] text-zinc-400">Buscando...</p>}
+                    {preview.error && <p className="text-[10px] text-red-500">{preview.error}</p>}
+                    {preview.data?.errors.map(e => <p key={e} className="text-[10px] text-amber-500">{e}</p>)}
+                    {preview.data?.hits.map((h, i) => (
+                      <div key={i} className="flex items-start gap-2 py-0.5">
+                        <span className={`shrink-0 px-1.5 text-[9px] rounded font-medium ${h.decision === 'new' ? 'bg-emerald-500/10 text-emerald-600' : 'bg-zinc-500/10 text-zinc-500'}`}>
+                          {h.decision}{h.rule ? `: ${h.rule}` : ''}
+                        </span>
+                        <a href={h.url} target="_blank" rel="noopener noreferrer" className="text-[10px] text-zinc-700 dark:text-zinc-300 hover:underline truncate">
+                          {h.title || h.url}
+                        </a>
+                      </div>
+                    ))}
+                  </div>
+                )}
+                {editingOrg && suggestions.length > 0 && (
+                  <div className="mt-2">
+                    <p className="text-[10px] font-medium text-zinc-500 dark:text-zinc-400 mb-1">
+                      Sugerencias de menciones revisadas
+                    </p>
+                    <div className="flex flex-wrap gap-1">
+                      {suggestions.map(s => (
+                        <button
+                          key={s.keyword}
+                          type="button"
+                          onClick={() => handleAcceptSuggestion(s.keyword)}
+                          title={s.examples?.join('\n') || `${s.hits} menciones`}
+                          className="px-2 py-0.5 text-[10px] rounded-full border border-dashed border-emerald-500/50 text-emerald-600 dark:text-emerald-400 hover:bg-emerald-500/10 font-medium transition-colors"
+                        >
+                          + {s.keyword} <span className="text-zinc-400">({s.hits})</span>
+                        </button>
+                      ))}
+                    </div>
+                  </div>
+                )}
               </div>
 
               <div>
@@ -600,6 +742,19 @@ export default function WatchlistManager() {
                   className="w-full px-3 py-2 text-sm bg-zinc-50 dark:bg-zinc-800 border border-zinc-200 dark:border-zinc-700 rounded-lg text-zinc-900 dark:text-zinc-100 placeholder-zinc-400 focus:outline-none focus:ring-2 focus:ring-indigo-500 resize-none"
                 />
               </div>
+
+              <div>
+                <label className="block text-xs font-medium text-zinc-500 dark:text-zinc-400 mb-1">
+                  Paginas de Facebook / Instagram <span className="text-zinc-400">(una por linea)</span>
+                </label>
+                <textarea
+                  value={formSocialPages}
+                  onChange={e => setFormSocialPages(e.target.value)}
+                  placeholder={'ej. facebook:mifundacion\ninstagram:17841400000000000'}
+                  rows={2}
+                  className="w-full px-3 py-2 text-sm bg-zinc-50 dark:bg-zinc-800 border border-zinc-200 dark:border-zinc-700 rounded-lg text-zinc-900 dark:text-zinc-100 placeholder-zinc-400 focus:outline-none focus:ring-2 focus:ring-indigo-500 resize-none"
+                />
+              </div>
             </div>
 
             <div className="mt-6 flex justify-end gap-2">
@@ -695,12 +850,14 @@ function HitCard({
   onToggleDraft,
   onMarkSeen,
   onDelete,
+  onUpdated,
 }: {
   hit: WatchlistHit;
   expanded: boolean;
   onToggleDraft: () => void;
   onMarkSeen: () => void;
   onDelete: () => void;
+  onUpdated: (hit: WatchlistHit) => void;
 }) {
   const sentiment = SENTIMENT_STYLES[hit.sentiment] || SENTIMENT_STYLES.unknown;
 
@@ -752,13 +909,7 @@ function HitCard({
       )}
 
       {/* AI Draft expansion */}
-      {hit.ai_draft && expanded && (
-        <div className="px-3 pb-2 bg-white dark:bg-zinc-900">
-          <div className="p-3 rounded-sm bg-indigo-500/5 border border-indigo-500/20 text-sm text-zinc-700 dark:text-zinc-300 whitespace-pre-wrap leading-relaxed">
-            {hit.ai_draft}
-          </div>
-        </div>
-      )}
+      {hit.ai_draft && expanded && <DraftPanel hit={hit} onUpdated={onUpdated} />}
 
       {/* Bottom bar */}
       <div className="flex items-center gap-1 px-2 py-1.5 bg-zinc-50 dark:bg-zinc-950 border-t border-zinc-200 dark:border-zinc-800">
@@ -794,6 +945,11 @@ function HitCard({
             {expanded ? 'Ocultar PR' : 'Borrador PR'}
           </button>
         )}
+        {hit.draft_status && hit.draft_status !== 'draft' && (
+          <span className={`px-1.5 py-0.5 text-[9px] font-bold uppercase tracking-widest rounded-sm ${DRAFT_STATUS_STYLES[hit.draft_status].pill}`}>
+            {DRAFT_STATUS_STYLES[hit.draft_status].label}
+          </span>
+        )}
         {hit.url && (
           <a
             href={hit.url}
@@ -811,3 +967,101 @@ function HitCard({
     </div>
   );
 }
+
+// ── Draft review ─────────────────────────────────────────────────
+
+const DRAFT_STATUS_STYLES: Record<string, { label: string; pill: string }> = {
+  draft: { label: 'Borrador IA', pill: 'bg-zinc-200 text-zinc-600 dark:bg-zinc-800 dark:text-zinc-400' },
+  edited: { label: 'Editado', pill: 'bg-amber-500/15 text-amber-600 dark:text-amber-400' },
+  approved: { label: 'Aprobado', pill: 'bg-emerald-500/15 text-emerald-600 dark:text-emerald-400' },
+  sent: { label: 'Enviado', pill: 'bg-indigo-500/15 text-indigo-600 dark:text-indigo-400' },
+};
+
+function DraftPanel({ hit, onUpdated }: { hit: WatchlistHit; onUpdated: (hit: WatchlistHit) => void }) {
+  const status = hit.draft_status || 'draft';
+  const [text, setText] = useState(hit.draft_text ?? hit.ai_draft ?? '');
+  const [busy, setBusy] = useState(false);
+  const [error, setError] = useState('');
+  const locked = status === 'approved' || status === 'sent';
+  const dirty = text.trim() !== (hit.draft_text ?? hit.ai_draft ?? '').trim();
+
+  const run = async (action: () => Promise<WatchlistHit>) => {
+    setBusy(true);
+    setError('');
+    try {
+      const updated = await action();
+      setText(updated.draft_text ?? updated.ai_draft ?? '');
+      onUpdated(updated);
+    } catch (err) {
+      setError(err instanceof Error ? err.message : 'Error');
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  return (
+    <div className="px-3 pb-2 bg-white dark:bg-zinc-900">
+      <div className="flex items-center gap-2 mb-1">
+        <span className={`px-1.5 py-0.5 text-[9px] font-bold uppercase tracking-widest rounded-sm ${DRAFT_STATUS_STYLES[status].pill}`}>
+          {DRAFT_STATUS_STYLES[status].label}
+        </span>
+        {hit.draft_text !== null && (
+          <button
+            onClick={() => setText(hit.ai_draft ?? '')}
+            disabled={locked}
+            className="text-[10px] text-zinc-400 hover:text-indigo-500 disabled:opacity-50 transition-colors"
+          >
+            Ver original IA
+          </button>
+        )}
+      </div>
+      <textarea
+        value={text}
+        onChange={(e) => setText(e.target.value)}
+        readOnly={locked}
+        rows={8}
+        className="w-full p-3 rounded-sm bg-indigo-500/5 border border-indigo-500/20 text-sm text-zinc-700 dark:text-zinc-300 leading-relaxed focus:outline-none focus:border-indigo-500"
+      />
+      {error && <p className="text-[10px] text-red-500 mt-1">{error}</p>}
+      <div className="flex items-center gap-1 mt-1">
+        {!locked && (
+          <button
+            onClick={() => run(() => api.editHitDraft(hit.id, text))}
+            disabled={busy || !dirty || !text.trim()}
+            className="px-2 py-1 text-[10px] font-bold uppercase tracking-wider text-white bg-zinc-800 hover:bg-indigo-600 disabled:opacity-50 rounded-sm transition-colors"
+          >
+            Guardar
+          </button>
+        )}
+        {!locked && (
+          <button
+            onClick={() => run(() => api.setHitDraftStatus(hit.id, 'approved'))}
+            disabled={busy || dirty}
+            title={dirty ? 'Guarda los cambios antes de aprobar' : undefined}
+            className="px-2 py-1 text-[10px] font-bold uppercase tracking-wider text-white bg-emerald-600 hover:bg-emerald-700 disabled:opacity-50 rounded-sm transition-colors"
+          >
+            Aprobar
+          </button>
+        )}
+        {status === 'approved' && (
+          <>
+            <button
+              onClick={() => run(() => api.setHitDraftStatus(hit.id, 'sent'))}
+              disabled={busy}
+              className="px-2 py-1 text-[10px] font-bold uppercase tracking-wider text-white bg-indigo-600 hover:bg-indigo-700 disabled:opacity-50 rounded-sm transition-colors"
+            >
+              Marcar enviado
+            </button>
+            <button
+              onClick={() => run(() => api.setHitDraftStatus(hit.id, 'draft'))}
+              disabled={busy}
+              className="px-2 py-1 text-[10px] font-bold uppercase tracking-wider text-zinc-500 hover:text-zinc-700 dark:hover:text-zinc-300 disabled:opacity-50 transition-colors"
+            >
+              Reabrir
+            </button>
+          </>
+        )}
+      </div>
+    </div>
+  );
+}
@@ -13,10 +13,45 @@ export interface Article {
   status: string;
   pinned: boolean;
   tags: string[];
+  scope?: 'local' | 'federal' | 'diaspora';
+  language?: 'es' | 'en';
+  archive_url?: string; // Wayback Machine snapshot
+  tag_source?: 'inferred'; // tags matched from similar articles, not the classifier
   evidence_policy: string;
   evidence_expires_at: string;
+  evidence_expires_in_days?: number;
+  // Set on status=trashed listings: when the article was trashed and when
+  // the purge job will delete it (absent when purging is disabled).
+  deleted_at?: string;
+  purge_at?: string;
   published_at: string;
   created_at: string;
+  // Set on grouped /items listings: the story cluster this article represents.
+  cluster_id?: string;
+  cluster_size?: number;
+  related?: ClusterMember[];
+}
+
+export interface ClusterMember {
+  id: string;
+  title: string;
+  source: string;
+  url: string;
+  published_at?: string;
+}
+
+export interface SimilarArticle extends Article {
+  distance: number;
+  similarity: number;
+  shared_tags: string[];
+  shared_entities: string[];
+}
+
+export interface Tag {
+  name: string;
+  description: string;
+  active: boolean;
+  created_at: string;
 }
 
 export interface Source {
@@ -31,20 +66,150 @@ export interface Source {
   title_selector: string;
   body_selector: string;
   date_selector: string;
+  render_js: boolean;
+  archive_snapshots: boolean; // Wayback Machine snapshot of each new article
+  api_mapping?: APIMapping;
   active: boolean;
   created_at: string;
+  archived_at?: string; // set while archived: not ingested, kept for attribution
+}
+
+// Funding opportunity from a "grantsgov" source.
+export interface GrantOpportunity {
+  id: string;
+  source_id?: string;
+  opportunity_id: string;
+  opportunity_number: string;
+  title: string;
+  agency: string;
+  agency_code: string;
+  cfda_numbers: string[];
+  opp_status: string;
+  doc_type: string;
+  open_date?: string;
+  close_date?: string;
+  url: string;
+  first_seen_at: string;
+  updated_at: string;
+  // From the opportunity detail; absent until fetched.
+  award_ceiling?: number;
+  award_floor?: number;
+  estimated_funding?: number;
+  eligibility: string;
+  applicant_types: string[];
+  details_fetched_at?: string;
+  tracking: GrantTracking | null;
+  tracking_updated_at?: string;
+  closing_flagged_at?: string; // when the 14-day deadline alert went out
+}
+
+export type GrantTracking = 'pursuing' | 'declined';
+
+export type GrantSort = 'deadline' | 'amount';
+
+// Funder, eligibility, award range and deadline extracted from an article tagged "grants".
+export interface GrantPosting {
+  article_id: string;
+  funder: string;
+  eligible_entities: string[];
+  award_ceiling?: number; // whole US dollars per award
+  award_floor?: number;
+  match_required: boolean | null; // null when the posting does not say
+  match_requirement: string;
+  deadline?: string;
+  extracted_at: string;
+  title: string;
+  source: string;
+  url: string;
+  region: string;
+  status: string;
+  published_at?: string;
+}
+
+// Document from a "federalregister" source.
+export interface FederalRegisterDocument {
+  document_number: string;
+  source_id?: string;
+  title: string;
+  doc_type: string;
+  abstract: string;
+  agencies: string[];
+  publication_date?: string;
+  comments_close_on?: string;
+  effective_on?: string;
+  url: string;
+  first_seen_at: string;
+  updated_at: string;
+}
+
+// Mapping for feed_type "api" sources (licensed partner APIs). Paths are
+// dot-separated keys; numeric segments index arrays.
+export interface APIMapping {
+  items: string;
+  url: string;
+  title: string;
+  body: string;
+  published?: string;
+  image?: string;
+  auth: { type: '' | 'bearer' | 'header' | 'query' | 'basic'; name?: string; secret_env?: string };
 }
 
 export interface ItemsResponse {
   items: Article[];
-  total: number;
+  count: number;
+  total?: number; // only with total=true
+  next_cursor?: string; // empty on the last page
+  as_of?: string; // only for as-of snapshots
+}
+
+// One status change of an article; from_status is null on creation.
+export interface StatusChange {
+  from_status: string | null;
+  to_status: string;
+  reason: 'created' | 'user' | 'retention' | 'triage' | 'backfill';
+  changed_at: string;
+}
+
+// AI-proposed triage action for an inbox item; applied only when accepted.
+export interface TriageSuggestion {
+  article_id: string;
+  action: 'save' | 'trash';
+  rationale: string;
+  created_at: string;
+  article?: Article;
+}
+
+// A tip received through POST /api/intake; its id is the submitter's receipt.
+export interface Tip {
+  id: string;
+  article_id?: string;
+  intake_key_id?: string;
+  url: string;
+  description: string;
+  submitter_name: string;
+  submitter_contact: string;
+  submitter_org: string;
+  created_at: string;
 }
 
 export interface SearchResponse {
   results: Article[];
-  total: number;
+  count: number;
+  total?: number; // only with total=true
+  next_cursor?: string; // empty on the last page
 }
 
+export interface CaptureMeta {
+  article_id: string;
+  captured_at: string;
+  raw_hash_sha256: string;
+  extract_hash_sha256: string;
+  screenshot_hash_sha256?: string;
+  evidence_policy: string;
+}
+
+export type EvidenceArtifact = 'raw' | 'extracted' | 'screenshot';
+
 export interface Note {
   id: string;
   article_id: string;
@@ -58,6 +223,19 @@ export interface NotesResponse {
   count: number;
 }
 
+// Result of GET /items/{id}/card.
+export interface ArticleCard {
+  id: string;
+  title: string;
+  summary: string;
+  image?: string;
+  source: string;
+  url: string;
+  share_url: string;
+  published_at?: string;
+  language?: string;
+}
+
 export interface WatchlistOrg {
   id: string;
   user_id: string;
@@ -65,21 +243,95 @@ export interface WatchlistOrg {
   website: string;
   keywords: string[];
   youtube_channels: string[];
+  social_pages: string[]; // "facebook:<page>" / "instagram:<account id>"
   active: boolean;
+  semantic_watch: boolean;
+  semantic_threshold: number; // minimum cosine similarity, 0 for the default
   created_at: string;
   updated_at: string;
 }
 
+export interface KeywordSuggestion {
+  keyword: string;
+  hits: number;
+  source: 'entity' | 'phrase';
+  type?: string;
+  examples?: string[];
+}
+
+export interface KeywordSuggestionsResponse {
+  hits_analyzed: number;
+  min_hits: number;
+  suggestions: KeywordSuggestion[];
+}
+
+// A story silenced for an org; see POST /watchlist/hits/{id}/mute-story.
+export interface MutedStory {
+  id: string;
+  org_id: string;
+  hit_id?: string;
+  title: string;
+  canonical_url: string;
+  entities: string[];
+  muted_hits: number;
+  created_at: string;
+  expires_at: string;
+  active: boolean;
+}
+
 export interface WatchlistOrgsResponse {
   orgs: WatchlistOrg[];
   count: number;
 }
 
+// A worker cron job and its last run.
+export interface WorkerJob {
+  name: string;
+  schedule: string;
+  paused: boolean;
+  running: number;
+  last_started_at?: string;
+  last_finished_at?: string;
+  last_trigger: string;
+  last_status: string;
+  last_error: string;
+  last_duration_ms: number;
+  run_count: number;
+}
+
+// A queued request for the worker to run a job now.
+export interface WorkerCommand {
+  id: string;
+  job: string;
+  status: 'pending' | 'running' | 'done' | 'failed';
+  error: string;
+  created_at: string;
+  started_at?: string;
+  finished_at?: string;
+}
+
+// Response to ingest/scan triggers; the worker runs the job, not the API.
+export interface WorkerTriggerResponse {
+  status: 'queued' | 'pending' | 'running';
+  message: string;
+  command?: WorkerCommand;
+  job?: WorkerJob;
+}
+
+export interface WorkerJobStatus {
+  job: WorkerJob;
+  command: WorkerCommand | null;
+}
+
+export interface EmbeddingBackfillStatus extends WorkerJobStatus {
+  missing: number;
+}
+
 export interface WatchlistHit {
   id: string;
   org_id: string;
   org_name: string;
-  source_type: 'google_news' | 'bing_news' | 'web' | 'local' | 'youtube' | 'reddit';
+  source_type: 'google_news' | 'bing_news' | 'web' | 'local' | 'semantic' | 'youtube' | 'reddit' | 'social' | 'gov';
   title: string;
   url: string;
   url_hash: string;
@@ -88,6 +340,34 @@ export interface WatchlistHit {
   ai_draft: string | null;
   seen: boolean;
   created_at: string;
+  // Response review: draft_text is the human revision of ai_draft.
+  draft_status?: 'draft' | 'edited' | 'approved' | 'sent';
+  draft_text: string | null;
+  draft_updated_at?: string;
+  draft_approved_at?: string;
+  draft_sent_at?: string;
+  article_id?: string; // article collected from the hit
+  sentiment_verified_at?: string; // set when a person overrode or confirmed the sentiment
+  // Every org that found the hit, the primary (org_id) first.
+  orgs?: { id: string; name: string }[];
+}
+
+// Result of POST /watchlist/preview: what a scan would do with each result.
+export interface WatchlistPreviewHit {
+  source_type: string;
+  title: string;
+  url: string;
+  snippet: string;
+  query: string;
+  decision: 'new' | 'filtered' | 'existing' | 'duplicate' | 'over_limit';
+  rule?: string;
+}
+
+export interface WatchlistPreview {
+  queries: string[];
+  hits: WatchlistPreviewHit[];
+  counts: Record<string, number>;
+  errors: string[];
 }
 
 export interface WatchlistHitsResponse {
@@ -95,6 +375,100 @@ export interface WatchlistHitsResponse {
   count: number;
 }
 
+export interface WatchlistTimelineResponse extends WatchlistHitsResponse {
+  next_before?: string;
+}
+
+// Weekly watchlist report (Monday to Monday, UTC). The list endpoint leaves
+// out orgs, notable_hits and summary.
+export interface WatchlistReport {
+  id: string;
+  user_id: string;
+  period_start: string;
+  period_end: string;
+  hit_count: number;
+  positive: number;
+  negative: number;
+  neutral: number;
+  orgs?: { org_id: string; org_name: string; hits: number; positive: number; negative: number; neutral: number }[];
+  notable_hits?: {
+    id: string;
+    org_id: string;
+    org_name: string;
+    title: string;
+    url: string;
+    source_type: string;
+    dup_count: number;
+    draft_status?: string;
+    response?: string;
+    created_at: string;
+  }[];
+  summary?: string;
+  created_at: string;
+}
+
+export interface AskSource {
+  id: string;
+  title: string;
+  source: string;
+  url: string;
+  summary?: string;
+  published_at?: string;
+  relevance?: number;
+}
+
+export interface AskResponse {
+  answer: string;
+  retrieval: 'vector' | 'keyword';
+  sources: AskSource[];
+}
+
+export interface AgentToolCall {
+  tool: 'search_articles' | 'get_article' | 'list_watchlist_hits' | 'collect_url' | string;
+  args: Record<string, unknown>;
+  result?: string;
+  error?: string;
+}
+
+// Paths of the user's public feeds: watchlist hits as RSS, Atom and JSON
+// Feed, and saved articles as RSS.
+export interface FeedURLs {
+  url: string;
+  atom_url: string;
+  json_url: string;
+  saved_url: string;
+}
+
+// Fetches of the user's public feed; referer is set only for links followed
+// from other sites.
+export interface FeedAccessLog {
+  accesses: {
+    id: number;
+    kind: 'feed' | 'share';
+    token_prefix?: string;
+    ip: string;
+    user_agent: string;
+    referer?: string;
+    status: number;
+    accessed_at: string;
+  }[];
+  summary: {
+    fetches_24h: number;
+    distinct_ips_24h: number;
+    distinct_ips_7d: number;
+    last_access_at?: string;
+  };
+  alerts: {
+    id: string;
+    token_prefix: string;
+    distinct_ips: number;
+    distinct_agents: number;
+    outside_referers: number;
+    rotated: boolean;
+    created_at: string;
+  }[];
+}
+
 export interface UnseenCountResponse {
   unseen: number;
 }
@@ -346,8 +720,15 @@ async function fetchAPI<T = any>(path: string, options?: RequestInit): Promise<T
 
 export const api = {
   // Items
-  getItems: (status: string, limit = 200, offset = 0): Promise<ItemsResponse> =>
-    fetchAPI(`/items?status=${status}&limit=${limit}&offset=${offset}`),
+  getItems: (status: string, limit = 200, offset = 0, cursor = ''): Promise<ItemsResponse> =>
+    fetchAPI(`/items?status=${status}&limit=${limit}&offset=${offset}${cursor ? `&cursor=${encodeURIComponent(cursor)}` : ''}`),
+
+  // Items that had the status at asOf (YYYY-MM-DD or RFC 3339).
+  getItemsAsOf: (status: string, asOf: string, limit = 200, offset = 0): Promise<ItemsResponse> =>
+    fetchAPI(`/items?status=${status}&as_of=${encodeURIComponent(asOf)}&limit=${limit}&offset=${offset}`),
+
+  getItemHistory: (id: string): Promise<{ history: StatusChange[]; count: number }> =>
+    fetchAPI(`/items/${id}/history`),
 
   saveItem: (id: string) =>
     fetchAPI(`/items/${id}/save`, { method: 'POST' }),
@@ -358,23 +739,117 @@ export const api = {
   pinItem: (id: string) =>
     fetchAPI(`/items/${id}/pin`, { method: 'POST' }),
 
+  restoreItem: (id: string): Promise<{ status: string }> =>
+    fetchAPI(`/items/${id}/restore`, { method: 'POST' }),
+
   undoItem: (id: string, previousStatus: string) =>
     fetchAPI(`/items/${id}/undo`, {
       method: 'POST',
       body: JSON.stringify({ previous_status: previousStatus }),
     }),
 
+  // Triage suggestions
+  getTriageSuggestions: async (action = ''): Promise<TriageSuggestion[]> => {
+    const data = await fetchAPI<{ suggestions: TriageSuggestion[]; count: number }>(`/triage/suggestions${action ? `?action=${action}` : ''}`);
+    return data.suggestions || [];
+  },
+
+  acceptTriageSuggestions: (ids?: string[], action?: 'save' | 'trash'): Promise<{ saved: number; trashed: number }> =>
+    fetchAPI('/triage/suggestions/accept', { method: 'POST', body: JSON.stringify({ ids, action }) }),
+
+  dismissTriageSuggestion: (articleId: string) =>
+    fetchAPI(`/triage/suggestions/${articleId}`, { method: 'DELETE' }),
+
+  // Tips behind an item (source "tip")
+  getItemTips: async (id: string): Promise<Tip[]> => {
+    const data = await fetchAPI<{ tips: Tip[]; count: number }>(`/items/${id}/tips`);
+    return data.tips || [];
+  },
+
+  // Feature flags evaluated for the current user
+  getMyFlags: async (): Promise<Record<string, boolean>> => {
+    const data = await fetchAPI<{ flags: Record<string, boolean> }>('/flags/me');
+    return data.flags || {};
+  },
+
   // Search
   search: (params: Record<string, string>): Promise<SearchResponse> =>
     fetchAPI(`/search?${new URLSearchParams(params)}`),
 
   // Similarity search
-  similar: (id: string, limit = 5): Promise<{ results: Article[]; count: number }> =>
-    fetchAPI(`/items/${id}/similar?limit=${limit}`),
+  similar: (id: string, limit = 5, minSimilarity = 0): Promise<{ results: SimilarArticle[]; count: number }> =>
+    fetchAPI(`/items/${id}/similar?limit=${limit}&min_similarity=${minSimilarity}`),
+
+  // Tag taxonomy
+  getTags: async (activeOnly = false): Promise<Tag[]> => {
+    const data = await fetchAPI<{ tags: Tag[]; count: number }>(`/tags${activeOnly ? '?active=true' : ''}`);
+    return data.tags || [];
+  },
+
+  createTag: (data: Partial<Tag>) =>
+    fetchAPI('/tags', { method: 'POST', body: JSON.stringify(data) }),
+
+  updateTag: (name: string, data: Partial<Tag>) =>
+    fetchAPI(`/tags/${encodeURIComponent(name)}`, { method: 'PUT', body: JSON.stringify(data) }),
+
+  deleteTag: (name: string) =>
+    fetchAPI(`/tags/${encodeURIComponent(name)}`, { method: 'DELETE' }),
+
+  // Grants.gov opportunities and Federal Register documents
+  getGrantOpportunities: async (params: { q?: string; agency?: string; cfda?: string; open?: boolean; tracking?: GrantTracking | 'none'; sort?: GrantSort; min_award?: number; limit?: number; offset?: number } = {}): Promise<GrantOpportunity[]> => {
+    const qs = new URLSearchParams();
+    if (params.q) qs.set('q', params.q);
+    if (params.agency) qs.set('agency', params.agency);
+    if (params.cfda) qs.set('cfda', params.cfda);
+    if (params.open) qs.set('open', 'true');
+    if (params.tracking) qs.set('tracking', params.tracking);
+    if (params.sort) qs.set('sort', params.sort);
+    if (params.min_award) qs.set('min_award', String(params.min_award));
+    if (params.limit) qs.set('limit', String(params.limit));
+    if (params.offset) qs.set('offset', String(params.offset));
+    const data = await fetchAPI<{ opportunities: GrantOpportunity[]; count: number }>(`/grants/opportunities?${qs}`);
+    return data.opportunities || [];
+  },
+
+  // Opportunities closing within `days` (default 14); declined ones only with tracking='declined'.
+  getGrantDeadlines: async (days = 14, tracking?: GrantTracking | 'none'): Promise<GrantOpportunity[]> => {
+    const qs = new URLSearchParams({ days: String(days) });
+    if (tracking) qs.set('tracking', tracking);
+    const data = await fetchAPI<{ opportunities: GrantOpportunity[]; count: number; days: number }>(`/grants/deadlines?${qs}`);
+    return data.opportunities || [];
+  },
+
+  getGrantPostings: async (params: { q?: string; funder?: string; eligible?: string; open?: boolean; sort?: GrantSort; min_award?: number; limit?: number; offset?: number } = {}): Promise<GrantPosting[]> => {
+    const qs = new URLSearchParams();
+    if (params.q) qs.set('q', params.q);
+    if (params.funder) qs.set('funder', params.funder);
+    if (params.eligible) qs.set('eligible', params.eligible);
+    if (params.open) qs.set('open', 'true');
+    if (params.sort) qs.set('sort', params.sort);
+    if (params.min_award) qs.set('min_award', String(params.min_award));
+    if (params.limit) qs.set('limit', String(params.limit));
+    if (params.offset) qs.set('offset', String(params.offset));
+    const data = await fetchAPI<{ postings: GrantPosting[]; count: number }>(`/grants/postings?${qs}`);
+    return data.postings || [];
+  },
+
+  setGrantTracking: (id: string, tracking: GrantTracking | null): Promise<GrantOpportunity> =>
+    fetchAPI(`/grants/opportunities/${id}/tracking`, { method: 'PUT', body: JSON.stringify({ tracking }) }),
+
+  getFederalRegister: async (params: { q?: string; agency?: string; type?: string; limit?: number; offset?: number } = {}): Promise<FederalRegisterDocument[]> => {
+    const qs = new URLSearchParams();
+    if (params.q) qs.set('q', params.q);
+    if (params.agency) qs.set('agency', params.agency);
+    if (params.type) qs.set('type', params.type);
+    if (params.limit) qs.set('limit', String(params.limit));
+    if (params.offset) qs.set('offset', String(params.offset));
+    const data = await fetchAPI<{ documents: FederalRegisterDocument[]; count: number }>(`/grants/federal-register?${qs}`);
+    return data.documents || [];
+  },
 
   // Sources
-  getSources: async (): Promise<Source[]> => {
-    const data = await fetchAPI<{ sources: Source[]; count: number }>('/sources');
+  getSources: async (includeArchived = false): Promise<Source[]> => {
+    const data = await fetchAPI<{ sources: Source[]; count: number }>(`/sources${includeArchived ? '?include_archived=true' : ''}`);
     return data.sources || [];
   },
 
@@ -387,16 +862,37 @@ export const api = {
   toggleSource: (id: string, active: boolean) =>
     fetchAPI(`/sources/${id}/toggle`, { method: 'PATCH', body: JSON.stringify({ active }) }),
 
+  // Fails with 409 when articles are attributed to the source; archive it instead.
   deleteSource: (id: string) =>
     fetchAPI(`/sources/${id}`, { method: 'DELETE' }),
 
+  archiveSource: (id: string) =>
+    fetchAPI(`/sources/${id}/archive`, { method: 'POST' }),
+
+  unarchiveSource: (id: string) =>
+    fetchAPI(`/sources/${id}/archive`, { method: 'DELETE' }),
+
   quickCreateSource: (url: string, region?: string): Promise<{ source: Source; feed_type: string; detected: boolean; message: string }> =>
     fetchAPI('/sources/quick', { method: 'POST', body: JSON.stringify({ url, region: region || undefined }) }),
 
+  // OPML: import skips feeds that already have a source; export is a download link
+  importOPML: (opml: string, region?: string): Promise<{ created: Source[]; skipped: string[] }> =>
+    fetchAPI(`/sources/import${region ? `?region=${encodeURIComponent(region)}` : ''}`, {
+      method: 'POST',
+      headers: { 'Content-Type': 'text/x-opml' },
+      body: opml,
+    }),
+
+  exportOPMLURL: `${API_BASE}/sources/export.opml`,
+
   // Auth
   login: (email: string, password: string) =>
     fetchAPI('/login', { method: 'POST', body: JSON.stringify({ email, password }) }),
 
+  // Language of API messages and notifications; '' follows the browser.
+  setLanguage: (language: 'es' | 'en' | ''): Promise<{ language: string }> =>
+    fetchAPI('/me/language', { method: 'PUT', body: JSON.stringify({ language }) }),
+
   // Notes
   getNotes: (articleId: string): Promise<NotesResponse> =>
     fetchAPI(`/items/${articleId}/notes`),
@@ -414,26 +910,71 @@ export const api = {
   getWatchlistOrgs: (): Promise<WatchlistOrgsResponse> =>
     fetchAPI('/watchlist/orgs'),
 
-  createWatchlistOrg: (data: { name: string; website?: string; keywords: string[]; youtube_channels?: string[] }): Promise<WatchlistOrg> =>
+  createWatchlistOrg: (data: { name: string; website?: string; keywords: string[]; youtube_channels?: string[]; social_pages?: string[] }): Promise<WatchlistOrg> =>
     fetchAPI('/watchlist/orgs', { method: 'POST', body: JSON.stringify(data) }),
 
-  updateWatchlistOrg: (id: string, data: { name: string; website?: string; keywords: string[]; youtube_channels?: string[]; active?: boolean }): Promise<WatchlistOrg> =>
+  updateWatchlistOrg: (id: string, data: { name: string; website?: string; keywords: string[]; youtube_channels?: string[]; social_pages?: string[]; active?: boolean }): Promise<WatchlistOrg> =>
     fetchAPI(`/watchlist/orgs/${id}`, { method: 'PUT', body: JSON.stringify(data) }),
 
   deleteWatchlistOrg: (id: string) =>
     fetchAPI(`/watchlist/orgs/${id}`, { method: 'DELETE' }),
 
+  // CSV columns: name, website, keywords, youtube_channels, social_pages (";"-separated), priority
+  importWatchlistOrgs: (csv: string): Promise<{ created: WatchlistOrg[]; skipped: string[]; errors: { line: number; error: string }[] }> =>
+    fetchAPI('/watchlist/orgs/import', { method: 'POST', headers: { 'Content-Type': 'text/csv' }, body: csv }),
+
+  exportWatchlistOrgsURL: `${API_BASE}/watchlist/orgs/export.csv`,
+
+  previewWatchlistScan: (query: string): Promise<WatchlistPreview> =>
+    fetchAPI('/watchlist/preview', { method: 'POST', body: JSON.stringify({ query }) }),
+
   toggleWatchlistOrg: (id: string, active: boolean) =>
     fetchAPI(`/watchlist/orgs/${id}/toggle`, { method: 'PATCH', body: JSON.stringify({ active }) }),
 
-  getWatchlistHits: (params?: { limit?: number; offset?: number; org_id?: string }): Promise<WatchlistHitsResponse> => {
+  // Dates are RFC3339 or YYYY-MM-DD (a date-only `to` is inclusive).
+  getWatchlistHits: (params?: {
+    limit?: number;
+    offset?: number;
+    org_id?: string;
+    sentiment?: WatchlistHit['sentiment'];
+    source_type?: string;
+    seen?: boolean;
+    from?: string;
+    to?: string;
+    q?: string;
+  }): Promise<WatchlistHitsResponse> => {
     const qs = new URLSearchParams();
     if (params?.limit) qs.set('limit', String(params.limit));
     if (params?.offset) qs.set('offset', String(params.offset));
     if (params?.org_id) qs.set('org_id', params.org_id);
+    if (params?.sentiment) qs.set('sentiment', params.sentiment);
+    if (params?.source_type) qs.set('source_type', params.source_type);
+    if (params?.seen !== undefined) qs.set('seen', String(params.seen));
+    if (params?.from) qs.set('from', params.from);
+    if (params?.to) qs.set('to', params.to);
+    if (params?.q) qs.set('q', params.q);
     return fetchAPI(`/watchlist/hits?${qs}`);
   },
 
+  // Merged view across orgs: each URL once. Pass next_before as before to page back.
+  getWatchlistTimeline: (params?: {
+    limit?: number;
+    before?: string;
+    org_id?: string;
+    sentiment?: WatchlistHit['sentiment'];
+    source_type?: string;
+    q?: string;
+  }): Promise<WatchlistTimelineResponse> => {
+    const qs = new URLSearchParams();
+    if (params?.limit) qs.set('limit', String(params.limit));
+    if (params?.before) qs.set('before', params.before);
+    if (params?.org_id) qs.set('org_id', params.org_id);
+    if (params?.sentiment) qs.set('sentiment', params.sentiment);
+    if (params?.source_type) qs.set('source_type', params.source_type);
+    if (params?.q) qs.set('q', params.q);
+    return fetchAPI(`/watchlist/timeline?${qs}`);
+  },
+
   getUnseenHitCount: (): Promise<UnseenCountResponse> =>
     fetchAPI('/watchlist/hits/unseen'),
 
@@ -446,33 +987,102 @@ export const api = {
   deleteHit: (id: string) =>
     fetchAPI(`/watchlist/hits/${id}`, { method: 'DELETE' }),
 
-  triggerWatchlistScan: (): Promise<{ status: string; message: string }> =>
+  editHitDraft: (id: string, text: string): Promise<WatchlistHit> =>
+    fetchAPI(`/watchlist/hits/${id}/draft`, { method: 'PUT', body: JSON.stringify({ text }) }),
+
+  setHitDraftStatus: (id: string, status: 'draft' | 'approved' | 'sent'): Promise<WatchlistHit> =>
+    fetchAPI(`/watchlist/hits/${id}/draft/status`, { method: 'POST', body: JSON.stringify({ status }) }),
+
+  // Approved/sent responses; dates are YYYY-MM-DD, `to` inclusive.
+  exportCommunicationsURL: (params: { org_id?: string; from?: string; to?: string; format?: 'csv' | 'json' }): string => {
+    const qs = new URLSearchParams();
+    Object.entries(params).forEach(([k, v]) => { if (v) qs.set(k, v); });
+    return `${API_BASE}/watchlist/communications/export?${qs}`;
+  },
+
+  getWatchlistReports: (limit = 20, offset = 0): Promise<WatchlistReport[]> =>
+    fetchAPI(`/watchlist/reports?limit=${limit}&offset=${offset}`),
+
+  getWatchlistReport: (id: string): Promise<WatchlistReport> =>
+    fetchAPI(`/watchlist/reports/${id}`),
+
+  triggerWatchlistScan: (): Promise<WorkerTriggerResponse> =>
     fetchAPI('/watchlist/scan', { method: 'POST' }),
 
+  getWatchlistScanStatus: (): Promise<WorkerJobStatus> =>
+    fetchAPI('/watchlist/scan'),
+
   enrichWatchlistOrg: (id: string): Promise<{ status: string; keywords: string[]; message: string }> =>
     fetchAPI(`/watchlist/orgs/${id}/enrich`, { method: 'POST' }),
 
-  getWatchlistFeedURL: (): Promise<{ url: string }> =>
+  getKeywordSuggestions: (id: string): Promise<KeywordSuggestionsResponse> =>
+    fetchAPI(`/watchlist/orgs/${id}/keyword-suggestions`),
+
+  acceptKeywordSuggestions: (id: string, keywords: string[]): Promise<WatchlistOrg> =>
+    fetchAPI(`/watchlist/orgs/${id}/keyword-suggestions/accept`, { method: 'POST', body: JSON.stringify({ keywords }) }),
+
+  // Hides the hit's story and suppresses new hits matching it for `days` (default 30).
+  muteHitStory: (id: string, days?: number): Promise<MutedStory> =>
+    fetchAPI(`/watchlist/hits/${id}/mute-story`, { method: 'POST', body: JSON.stringify({ days }) }),
+
+  getMutedStories: (orgId: string, all = false): Promise<{ muted_stories: MutedStory[]; count: number }> =>
+    fetchAPI(`/watchlist/orgs/${orgId}/muted-stories${all ? '?all=true' : ''}`),
+
+  unmuteStory: (orgId: string, muteId: string) =>
+    fetchAPI(`/watchlist/orgs/${orgId}/muted-stories/${muteId}`, { method: 'DELETE' }),
+
+  setHitSentiment: (id: string, sentiment: 'positive' | 'neutral' | 'negative'): Promise<{ id: string; sentiment: string; sentiment_verified_at: string }> =>
+    fetchAPI(`/watchlist/hits/${id}/sentiment`, { method: 'PUT', body: JSON.stringify({ sentiment }) }),
+
+  // Creates an inbox article from the hit (or returns the one already stored) and links them.
+  collectHit: (id: string, opts: { region?: string; archive?: boolean } = {}): Promise<Article> =>
+    fetchAPI(`/watchlist/hits/${id}/collect`, { method: 'POST', body: JSON.stringify(opts) }),
+
+  getWatchlistFeedURL: (): Promise<FeedURLs> =>
     fetchAPI('/watchlist/feed-url'),
 
-  regenerateWatchlistFeedURL: (): Promise<{ url: string }> =>
+  regenerateWatchlistFeedURL: (): Promise<FeedURLs> =>
     fetchAPI('/watchlist/feed-url/regenerate', { method: 'POST' }),
 
+  getWatchlistFeedAccess: (): Promise<FeedAccessLog> =>
+    fetchAPI('/watchlist/feed-url/access'),
+
+  // Link-preview card; share_url unfurls in Slack/WhatsApp.
+  getItemCard: (id: string): Promise<ArticleCard> =>
+    fetchAPI(`/items/${id}/card`),
+
   // Export
-  exportArticle: (id: string): string =>
-    `${API_BASE}/items/${id}/export`,
+  exportArticle: (id: string, format: 'zip' | 'pdf' = 'zip'): string =>
+    `${API_BASE}/items/${id}/export${format === 'pdf' ? '?format=pdf' : ''}`,
+
+  // Evidence
+  evidenceURL: (id: string, artifact: EvidenceArtifact = 'raw'): string =>
+    `${API_BASE}/items/${id}/evidence${artifact === 'raw' ? '' : `?artifact=${artifact}`}`,
+
+  getEvidenceMeta: (id: string): Promise<CaptureMeta> =>
+    fetchAPI(`/items/${id}/evidence/meta`),
 
   // Collect
-  collectItem: (url: string, title?: string, region?: string, snippet?: string) =>
+  collectItem: (url: string, title?: string, region?: string, snippet?: string, archive = false) =>
     fetchAPI('/collect', {
       method: 'POST',
-      body: JSON.stringify({ url, title: title || undefined, region: region || undefined, snippet: snippet || undefined }),
+      body: JSON.stringify({ url, title: title || undefined, region: region || undefined, snippet: snippet || undefined, archive: archive || undefined }),
     }),
 
   // Admin: trigger ingestion
-  triggerIngest: (): Promise<{ status: string; message: string }> =>
+  triggerIngest: (): Promise<WorkerTriggerResponse> =>
     fetchAPI('/admin/ingest', { method: 'POST' }),
 
+  getIngestStatus: (): Promise<WorkerJobStatus> =>
+    fetchAPI('/admin/ingest'),
+
+  // Admin: embed articles lacking an embedding
+  triggerEmbeddingBackfill: (): Promise<WorkerTriggerResponse> =>
+    fetchAPI('/admin/embeddings/backfill', { method: 'POST' }),
+
+  getEmbeddingBackfillStatus: (): Promise<EmbeddingBackfillStatus> =>
+    fetchAPI('/admin/embeddings/backfill'),
+
   // Admin: chat with news
   chatWithNews: (question: string): Promise<{ answer: string; articles_used: number; sources?: { title: string; source: string; url: string }[]; web_sources?: { title: string; source: string; url: string; snippet?: string; savable?: boolean }[] }> =>
     fetchAPI('/admin/chat', {
@@ -480,10 +1090,22 @@ export const api = {
       body: JSON.stringify({ question }),
     }),
 
+  // Answer from the articles most similar to the question (no web search);
+  // the answer cites sources as [N], 1-based
+  askChat: (question: string, topK?: number): Promise<AskResponse> =>
+    fetchAPI('/chat/ask', {
+      method: 'POST',
+      body: JSON.stringify({ question, top_k: topK }),
+    }),
+
   // Admin: re-enrich
   reenrich: (): Promise<{ cleared: number; queued: number; message: string }> =>
     fetchAPI('/admin/reenrich', { method: 'POST' }),
 
+  // Admin: re-run hit sentiment classification (dates YYYY-MM-DD; default last 30 days)
+  reclassifySentiment: (opts: { from?: string; to?: string; model?: string } = {}): Promise<{ hits: number; model: string; message: string }> =>
+    fetchAPI('/admin/watchlist/reclassify', { method: 'POST', body: JSON.stringify(opts) }),
+
   // Chat sessions
   getChatSessions: (): Promise<{ sessions: ChatSession[] }> =>
     fetchAPI('/chat/sessions'),
@@ -497,6 +1119,20 @@ export const api = {
       body: JSON.stringify({ title, messages }),
     }),
 
+  // Tool-calling chat: the model may search articles, read one, list hits or collect a URL
+  agentChat: (question: string): Promise<{ answer: string; tool_calls: AgentToolCall[] }> =>
+    fetchAPI('/chat/agent', {
+      method: 'POST',
+      body: JSON.stringify({ question }),
+    }),
+
+  // Ask within a session; the server appends the question and answer to it
+  sendChatMessage: (id: string, question: string): Promise<{ answer: string; articles_used: number; sources?: { title: string; source: string; url: string }[]; web_sources?: { title: string; source: string; url: string; snippet?: string; savable?: boolean }[]; session: ChatSession }> =>
+    fetchAPI(`/chat/sessions/${id}/message`, {
+      method: 'POST',
+      body: JSON.stringify({ question }),
+    }),
+
   updateChatSession: (id: string, title: string, messages: ChatMessage[]): Promise<ChatSession> =>
     fetchAPI(`/chat/sessions/${id}`, {
       method: 'PUT',
@@ -506,8 +1142,12 @@ export const api = {
   deleteChatSession: (id: string): Promise<void> =>
     fetchAPI(`/chat/sessions/${id}`, { method: 'DELETE' }),
 
-  // Analytics
-  getTagTrends: (days = 30): Promise<{ trends: { tag: string; day: string; count: number }[] }> =>
+  deleteAllChatSessions: (): Promise<{ status: string; deleted: number }> =>
+    fetchAPI('/chat/sessions', { method: 'DELETE' }),
+
+  // Analytics. View-backed figures carry refreshed_at, when the worker last
+  // refreshed them.
+  getTagTrends: (days = 30): Promise<{ trends: { tag: string; day: string; count: number }[]; refreshed_at?: string }> =>
     fetchAPI(`/analytics/tags?days=${days}`),
 
   getTopEntities: (days = 30, type = ''): Promise<{ entities: { name: string; type: string; count: number }[] }> =>
@@ -516,15 +1156,21 @@ export const api = {
   getCoOccurrences: (entity: string): Promise<{ co_occurrences: { name: string; type: string; count: number }[] }> =>
     fetchAPI(`/analytics/co-occurrences?entity=${encodeURIComponent(entity)}`),
 
-  getSentiment: (days = 30): Promise<{ sentiment: { sentiment: string; count: number }[] }> =>
+  getSentiment: (days = 30): Promise<{ sentiment: { sentiment: string; count: number }[]; refreshed_at?: string }> =>
     fetchAPI(`/analytics/sentiment?days=${days}`),
 
-  getSourceHealth: (): Promise<{ sources: { source: string; article_count: number; last_ingested: string; enriched_count: number }[] }> =>
+  getSourceHealth: (): Promise<{ sources: { source: string; article_count: number; last_ingested: string; enriched_count: number }[]; refreshed_at?: string }> =>
     fetchAPI(`/analytics/sources`),
 
-  getArticleVolume: (days = 30): Promise<{ volume: { day: string; count: number }[] }> =>
+  getArticleVolume: (days = 30): Promise<{ volume: { day: string; count: number }[]; refreshed_at?: string }> =>
     fetchAPI(`/analytics/volume?days=${days}`),
 
+  getRegionVolume: (days = 30): Promise<{ regions: { day: string; region: string; count: number }[]; refreshed_at?: string }> =>
+    fetchAPI(`/analytics/regions?days=${days}`),
+
+  getOrgHitCounts: (days = 30): Promise<{ hits: { day: string; org_id: string; org_name: string; sentiment: string; count: number }[]; refreshed_at?: string }> =>
+    fetchAPI(`/analytics/hits?days=${days}`),
+
   // Test scrape
   testSource: (id: string): Promise<{ success: boolean; title: string; text_length: number; image_found: boolean; error?: string }> =>
     fetchAPI(`/sources/${id}/test`, { method: 'POST' }),
@@ -6,8 +6,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
 	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
 )
 
 const (
@@ -21,12 +26,25 @@ type Deps struct {
 	Orgs     *models.WatchlistOrgStore
 	Hits     *models.WatchlistHitStore
 	Articles *models.ArticleStore
-	AI       *ai.OllamaClient
+	AI       ai.Provider
+
+	// Digests and Notifications are only needed by RunWatchlistDigest.
+	Digests       *models.WatchlistDigestStore
+	Notifications *models.NotificationStore
+
+	// Reports is only needed by RunWatchlistReports.
+	Reports *models.WatchlistReportStore
+
+	// Webhooks receive new hits after each scan. When Jobs is set deliveries
+	// are queued (and retried) instead of sent inline.
+	Webhooks *models.WebhookStore
+	Jobs     *models.JobStore
 }
 
 // RunWatchlistScan is the main entry point called by the worker cron.
 // It processes orgs SEQUENTIALLY to keep resource usage low.
 func RunWatchlistScan(ctx context.Context, deps Deps) {
+	ctx = fetchlog.WithPurpose(ctx, fetchlog.Watchlist)
 	ctx, cancel := context.WithTimeout(ctx, scanTimeout)
 	defer cancel()
 
@@ -53,9 +71,16 @@ func RunWatchlistScan(ctx context.Context, deps Deps) {
 		totalHits += hits
 	}
 
+	// Group new hits covering the same story across outlets, hiding those of
+	// muted stories before they are classified and drafted.
+	groupStories(ctx, deps)
+
 	// Classify sentiment and generate PR drafts for negative hits.
 	classifyAndDraft(ctx, deps)
 
+	// Push new hits to the users' webhook targets.
+	dispatchWebhooks(ctx, deps, start)
+
 	slog.Info("watchlist: scan complete",
 		"orgs", len(orgs),
 		"new_hits", totalHits,
@@ -63,9 +88,10 @@ func RunWatchlistScan(ctx context.Context, deps Deps) {
 	)
 }
 
-// scanOrg runs all 5 agents sequentially for a single org.
+// scanOrg runs the agents sequentially for a single org.
 func scanOrg(ctx context.Context, org models.WatchlistOrg, deps Deps) int {
 	slog.Info("watchlist: scanning org", "name", org.Name, "keywords", org.Keywords)
+	ctx = scraper.WithSearchPriority(ctx, org.Priority)
 	hits := 0
 
 	queries := buildSearchQueries(org)
@@ -74,32 +100,63 @@ func scanOrg(ctx context.Context, org models.WatchlistOrg, deps Deps) int {
 	hits += ScanBingNews(ctx, org, queries, deps)
 	hits += ScanWeb(ctx, org, queries, deps)
 	hits += ScanLocalArticles(ctx, org, deps)
+	hits += ScanSemantic(ctx, org, deps)
+	hits += ScanGov(ctx, org, deps)
 
 	if len(org.YouTubeChannels) > 0 {
 		hits += ScanYouTube(ctx, org, deps)
 	}
 
 	hits += ScanReddit(ctx, org, queries, deps)
+	hits += ScanSocial(ctx, org, deps)
+
+	if len(org.SocialPages) > 0 {
+		hits += ScanMetaPages(ctx, org, deps)
+	}
 
 	slog.Info("watchlist: org scan complete", "name", org.Name, "new_hits", hits)
 	return hits
 }
 
-// buildSearchQueries builds search queries from the org name and keywords.
-// Returns at most 5 queries for broader coverage.
+// buildSearchQueries builds search queries from the org name and keywords,
+// each scoped to the coverage jurisdiction. Returns at most 5 queries for
+// broader coverage.
 func buildSearchQueries(org models.WatchlistOrg) []string {
-	queries := []string{org.Name + " Puerto Rico"}
+	place := " " + coverage.Current().Name
+	queries := []string{org.Name + place}
 	for i, kw := range org.Keywords {
 		if i >= 4 {
 			break
 		}
 		if !strings.EqualFold(kw, org.Name) {
-			queries = append(queries, kw+" Puerto Rico")
+			queries = append(queries, kw+place)
 		}
 	}
 	return queries
 }
 
+// hitCandidate is a search result an agent may store as a hit.
+type hitCandidate struct {
+	SourceType string `json:"source_type"`
+	Title      string `json:"title"`
+	URL        string `json:"url"`
+	Snippet    string `json:"snippet"`
+}
+
+// hit returns the candidate as a new hit for an org.
+func (c hitCandidate) hit(orgID uuid.UUID) *models.WatchlistHit {
+	return &models.WatchlistHit{
+		ID:         uuid.New(),
+		OrgID:      orgID,
+		SourceType: c.SourceType,
+		Title:      c.Title,
+		URL:        c.URL,
+		URLHash:    scraper.HashURL(c.URL),
+		Snippet:    truncateStr(c.Snippet, 500),
+		Sentiment:  "unknown",
+	}
+}
+
 // containsAnyKeyword checks if text mentions the org name or any keyword.
 func containsAnyKeyword(text string, org models.WatchlistOrg) bool {
 	lower := strings.ToLower(text)
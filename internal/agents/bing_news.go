@@ -2,6 +2,7 @@ package agents
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/google/uuid"
@@ -18,10 +19,11 @@ func ScanBingNews(ctx context.Context, org models.WatchlistOrg, queries []string
 			break
 		}
 
-		agentCtx, cancel := context.WithTimeout(ctx, agentTimeout)
-		results, err := scraper.BingNewsSearch(agentCtx, query, maxResultsPerAgent)
-		cancel()
-
+		results, err := bingNewsCandidates(ctx, query)
+		if errors.Is(err, scraper.ErrSearchBudgetExhausted) {
+			slog.Warn("watchlist/bing_news: skipped", "org", org.Name, "err", err)
+			break
+		}
 		if err != nil {
 			slog.Warn("watchlist/bing_news: search", "query", query, "err", err)
 			continue
@@ -38,17 +40,7 @@ func ScanBingNews(ctx context.Context, org models.WatchlistOrg, queries []string
 				continue
 			}
 
-			urlHash := scraper.HashURL(item.URL)
-			hit := &models.WatchlistHit{
-				ID:         uuid.New(),
-				OrgID:      org.ID,
-				SourceType: "bing_news",
-				Title:      item.Title,
-				URL:        item.URL,
-				URLHash:    urlHash,
-				Snippet:    truncateStr(item.Snippet, 500),
-				Sentiment:  "unknown",
-			}
+			hit := item.hit(org.ID)
 
 			if err := deps.Hits.Create(ctx, hit); err != nil {
 				slog.Error("watchlist/bing_news: create hit", "err", err)
@@ -65,3 +57,24 @@ func ScanBingNews(ctx context.Context, org models.WatchlistOrg, queries []string
 	}
 	return hits
 }
+
+// bingNewsCandidates runs one Bing News search.
+func bingNewsCandidates(ctx context.Context, query string) ([]hitCandidate, error) {
+	agentCtx, cancel := context.WithTimeout(ctx, agentTimeout)
+	results, err := scraper.BingNewsSearch(agentCtx, query, maxResultsPerAgent)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]hitCandidate, 0, len(results))
+	for _, r := range results {
+		candidates = append(candidates, hitCandidate{
+			SourceType: "bing_news",
+			Title:      r.Title,
+			URL:        r.URL,
+			Snippet:    r.Snippet,
+		})
+	}
+	return candidates, nil
+}
@@ -0,0 +1,182 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+const (
+	// maxDigestHitsPerOrg caps how many of an org's hits a digest stores. The
+	// rest are still counted in the org's total and the digest's hit count.
+	maxDigestHitsPerOrg = 50
+
+	// digestHitsPerOrg is how many hits per org are listed in the text summary.
+	// The stored digest keeps up to maxDigestHitsPerOrg.
+	digestHitsPerOrg = 5
+
+	// defaultDigestPeriod is how far back a user's first digest looks.
+	defaultDigestPeriod = 24 * time.Hour
+)
+
+// RunWatchlistDigest builds one digest per user with active orgs, covering the
+// hits found since that user's previous digest, and queues a notification for
+// it. Users with no new hits are skipped. Called by the worker after the
+// morning scan.
+func RunWatchlistDigest(ctx context.Context, deps Deps) {
+	if deps.Digests == nil {
+		return
+	}
+
+	orgs, err := deps.Orgs.ListActive(ctx)
+	if err != nil {
+		slog.Error("watchlist/digest: list active orgs", "err", err)
+		return
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	created := 0
+	now := time.Now()
+	for _, org := range orgs {
+		if ctx.Err() != nil || seen[org.UserID] {
+			continue
+		}
+		seen[org.UserID] = true
+
+		digest, err := buildDigest(ctx, deps, org.UserID, now)
+		if err != nil {
+			slog.Error("watchlist/digest: build", "user_id", org.UserID, "err", err)
+			continue
+		}
+		if digest == nil {
+			continue
+		}
+		created++
+
+		if deps.Notifications != nil {
+			if err := deps.Notifications.CreateWatchlistDigest(ctx, digest.UserID, digest.ID, digest.HitCount, digest.Summary); err != nil {
+				slog.Error("watchlist/digest: notify", "user_id", digest.UserID, "err", err)
+			}
+		}
+	}
+
+	slog.Info("watchlist/digest: complete", "users", len(seen), "digests", created)
+}
+
+// buildDigest creates and stores the digest for one user. It returns nil if
+// the user has no new hits.
+func buildDigest(ctx context.Context, deps Deps, userID uuid.UUID, until time.Time) (*models.WatchlistDigest, error) {
+	since, err := deps.Digests.LastPeriodEnd(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if since.IsZero() {
+		since = until.Add(-defaultDigestPeriod)
+	}
+
+	hits, err := deps.Hits.ListNewByUser(ctx, userID, since, until, maxDigestHitsPerOrg)
+	if err != nil {
+		return nil, err
+	}
+	if len(hits) == 0 {
+		return nil, nil
+	}
+	counts, err := deps.Hits.CountNewByOrg(ctx, userID, since, until)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := groupDigestHits(hits, counts)
+	digest := &models.WatchlistDigest{
+		UserID:      userID,
+		PeriodStart: since,
+		PeriodEnd:   until,
+		Groups:      groups,
+	}
+	for _, g := range groups {
+		digest.HitCount += g.Total
+	}
+	digest.Summary = renderDigest(digest)
+
+	if err := deps.Digests.Create(ctx, digest); err != nil {
+		return nil, err
+	}
+	return digest, nil
+}
+
+// groupDigestHits groups hits by org, taking each org's total from counts.
+// Hits arrive ordered by org name, so groups keep that order.
+func groupDigestHits(hits []models.WatchlistHit, counts map[uuid.UUID]int) []models.WatchlistDigestGroup {
+	var groups []models.WatchlistDigestGroup
+	index := make(map[uuid.UUID]int)
+	for _, h := range hits {
+		i, ok := index[h.OrgID]
+		if !ok {
+			i = len(groups)
+			index[h.OrgID] = i
+			groups = append(groups, models.WatchlistDigestGroup{OrgID: h.OrgID, OrgName: h.OrgName, Total: counts[h.OrgID]})
+		}
+		g := &groups[i]
+
+		switch h.Sentiment {
+		case "positive":
+			g.Positive++
+		case "negative":
+			g.Negative++
+		default:
+			g.Neutral++
+		}
+		g.Hits = append(g.Hits, models.WatchlistDigestHit{
+			ID:         h.ID,
+			Title:      h.Title,
+			URL:        h.URL,
+			SourceType: h.SourceType,
+			Sentiment:  h.Sentiment,
+			CreatedAt:  h.CreatedAt,
+		})
+	}
+	// A hit muted or folded between the list and the count can leave a total short.
+	for i := range groups {
+		groups[i].Total = max(groups[i].Total, len(groups[i].Hits))
+	}
+	return groups
+}
+
+// sentimentIcon returns the marker shown next to a hit in the digest text.
+func sentimentIcon(sentiment string) string {
+	switch sentiment {
+	case "positive":
+		return "🟢"
+	case "negative":
+		return "🔴"
+	default:
+		return "⚪"
+	}
+}
+
+// renderDigest renders the plain-text summary delivered to notification channels.
+func renderDigest(d *models.WatchlistDigest) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d menciones nuevas en %d organizaciones\n", d.HitCount, len(d.Groups))
+
+	for _, g := range d.Groups {
+		fmt.Fprintf(&b, "\n%s (%d)  🟢 %d  🔴 %d  ⚪ %d\n",
+			g.OrgName, g.Total, g.Positive, g.Negative, g.Neutral)
+		for i, h := range g.Hits {
+			if i == digestHitsPerOrg {
+				break
+			}
+			fmt.Fprintf(&b, "%s %s\n   %s\n", sentimentIcon(h.Sentiment), h.Title, h.URL)
+		}
+		if more := g.Total - min(len(g.Hits), digestHitsPerOrg); more > 0 {
+			fmt.Fprintf(&b, "  … y %d más\n", more)
+		}
+	}
+	return b.String()
+}
@@ -6,7 +6,10 @@ import (
 	"log/slog"
 	"strings"
 
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/coverage"
 	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
 )
 
 // classifyAndDraft runs sentiment analysis on unclassified hits,
@@ -32,7 +35,11 @@ func classifyAndDraft(ctx context.Context, deps Deps) {
 			break
 		}
 
-		sentiment := classifySentiment(ctx, deps, hit)
+		sentiment, err := classifySentiment(ctx, deps.AI, "", hit)
+		if err != nil {
+			slog.Warn("watchlist/drafter: classify sentiment", "err", err)
+			sentiment = "neutral"
+		}
 		if err := deps.Hits.UpdateSentiment(ctx, hit.ID, sentiment); err != nil {
 			slog.Error("watchlist/drafter: update sentiment", "id", hit.ID, "err", err)
 			continue
@@ -55,7 +62,9 @@ func classifyAndDraft(ctx context.Context, deps Deps) {
 	slog.Info("watchlist/drafter: complete", "classified", classified, "drafted", drafted)
 }
 
-func classifySentiment(ctx context.Context, deps Deps, hit models.WatchlistHit) string {
+// classifySentiment asks the model for the hit's sentiment; model "" uses the
+// client's default (3b) model for speed. Unclear answers count as neutral.
+func classifySentiment(ctx context.Context, aiClient ai.Provider, model string, hit models.WatchlistHit) (string, error) {
 	systemPrompt := `You are a PR sentiment classifier. Classify the following news mention as one of: positive, neutral, negative.
 
 RULES:
@@ -68,45 +77,76 @@ RULES:
 
 	userPrompt := fmt.Sprintf("Title: %s\nSnippet: %s", hit.Title, hit.Snippet)
 
-	// Use the default model (3b) for fast classification.
-	resp, err := deps.AI.Generate(ctx, systemPrompt, userPrompt)
+	var resp string
+	var err error
+	if model == "" {
+		resp, err = aiClient.Generate(ai.WithTask(ctx, ai.TaskClassify), systemPrompt, userPrompt)
+	} else {
+		resp, err = aiClient.GenerateWithModel(ai.WithTask(ctx, ai.TaskClassify), model, systemPrompt, userPrompt)
+	}
 	if err != nil {
-		slog.Warn("watchlist/drafter: classify sentiment", "err", err)
-		return "neutral"
+		return "", err
 	}
 
 	resp = strings.TrimSpace(strings.ToLower(resp))
 	switch {
 	case strings.Contains(resp, "positive"):
-		return "positive"
+		return "positive", nil
 	case strings.Contains(resp, "negative"):
-		return "negative"
-	case strings.Contains(resp, "neutral"):
-		return "neutral"
+		return "negative", nil
 	default:
-		return "neutral"
+		return "neutral", nil
 	}
 }
 
 func generatePRDraft(ctx context.Context, deps Deps, hit models.WatchlistHit) string {
-	systemPrompt := `Eres un especialista en relaciones publicas para organizaciones sin fines de lucro en Puerto Rico. Tu trabajo es redactar respuestas de PR a menciones negativas en los medios.
+	profile := coverage.Current()
+	systemPrompt := `Eres un especialista en relaciones publicas para organizaciones sin fines de lucro en ` + profile.Name + `. Tu trabajo es redactar respuestas de PR a menciones negativas en los medios.
 
 REGLAS:
-- Escribe en español profesional
+- Escribe en ` + profile.LanguageName() + ` profesional
 - Se conciso (2-3 parrafos maximo)
 - Tono empatico pero firme
 - Reconoce la preocupacion del publico sin admitir culpa
 - Incluye una accion concreta que la organizacion tomara
 - No uses jerga legal
+- Si se incluyen citas de la cobertura, responde a lo que dicen usando sus palabras exactas entre comillas; nunca inventes citas
 - Empieza directamente con el borrador, sin titulos ni encabezados`
 
-	userPrompt := fmt.Sprintf("Mencion negativa:\nTitulo: %s\nDetalle: %s\n\nRedacta un comunicado de respuesta de PR.", hit.Title, hit.Snippet)
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Mencion negativa:\nTitulo: %s\nDetalle: %s\n", hit.Title, hit.Snippet)
+	if quotes := coverageQuotes(ctx, deps, hit); len(quotes) > 0 {
+		sb.WriteString("\nCitas textuales de la cobertura (puedes referirte a ellas; no las alteres):\n")
+		for _, q := range quotes {
+			attribution := q.Speaker
+			if q.Role != "" {
+				attribution += ", " + q.Role
+			}
+			fmt.Fprintf(&sb, "- \"%s\" — %s\n", q.Text, attribution)
+		}
+	}
+	sb.WriteString("\nRedacta un comunicado de respuesta de PR.")
+	userPrompt := sb.String()
 
 	// Use 8b model for quality PR drafts.
-	draft, err := deps.AI.GenerateWithModel(ctx, "llama3.1:8b", systemPrompt, userPrompt)
+	draft, err := deps.AI.GenerateWithModel(ai.WithTask(ctx, ai.TaskDraft), "llama3.1:8b", systemPrompt, userPrompt)
 	if err != nil {
 		slog.Error("watchlist/drafter: generate PR draft", "hit_id", hit.ID, "err", err)
 		return ""
 	}
 	return strings.TrimSpace(draft)
 }
+
+// coverageQuotes returns the quotes extracted from the ingested article the
+// hit points at, if Folio has collected it.
+func coverageQuotes(ctx context.Context, deps Deps, hit models.WatchlistHit) []models.Quote {
+	if deps.Articles == nil {
+		return nil
+	}
+	quotes, err := deps.Articles.QuotesByURL(ctx, hit.URL, scraper.CanonicalizeURL(hit.URL))
+	if err != nil {
+		slog.Warn("watchlist/drafter: load coverage quotes", "hit_id", hit.ID, "err", err)
+		return nil
+	}
+	return quotes
+}
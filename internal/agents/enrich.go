@@ -10,13 +10,17 @@ import (
 	"time"
 
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
 	"github.com/Saul-Punybz/folio/internal/scraper"
+	"github.com/Saul-Punybz/folio/internal/useragent"
 )
 
 // EnrichOrgKeywords fetches the org's website (if provided) and uses AI to extract
 // relevant keywords for monitoring. If no website is given, falls back to web search.
 // Returns the suggested keywords (does NOT save them — caller decides).
-func EnrichOrgKeywords(ctx context.Context, orgName, websiteURL string, aiClient *ai.OllamaClient) ([]string, error) {
+func EnrichOrgKeywords(ctx context.Context, orgName, websiteURL string, aiClient ai.Provider) ([]string, error) {
+	ctx = fetchlog.WithPurpose(ctx, fetchlog.Enrichment)
 	ctx, cancel := context.WithTimeout(ctx, 60*time.Second)
 	defer cancel()
 
@@ -35,7 +39,7 @@ func EnrichOrgKeywords(ctx context.Context, orgName, websiteURL string, aiClient
 	}
 
 	// Step 2: Also do a web search for extra context.
-	query := orgName + " Puerto Rico"
+	query := orgName + " " + coverage.Current().Name
 	var err error
 	results, err = scraper.WebSearch(ctx, query, 5)
 	if err != nil {
@@ -86,7 +90,7 @@ func EnrichOrgKeywords(ctx context.Context, orgName, websiteURL string, aiClient
 	}
 
 	// Step 4: Ask AI to extract keywords.
-	systemPrompt := `Eres un analista de monitoreo de medios para organizaciones sin fines de lucro en Puerto Rico.
+	systemPrompt := `Eres un analista de monitoreo de medios para organizaciones sin fines de lucro en ` + coverage.Current().Name + `.
 
 TAREA: Dado el nombre de una organizacion y datos de su pagina web/busqueda, extrae entre 6 y 10 palabras clave o frases cortas que serian utiles para monitorear menciones de esta organizacion en noticias, redes sociales y publicaciones.
 
@@ -95,12 +99,12 @@ REGLAS:
 - Incluye: nombre abreviado, siglas si existen, programa principal, director/lider si aparece, temas clave
 - Las palabras clave deben ser en español (a menos que el nombre sea en ingles)
 - Frases cortas (1-3 palabras max por keyword)
-- NO incluyas palabras genericas como "Puerto Rico", "organizacion", "sin fines de lucro"
+- NO incluyas palabras genericas como "` + coverage.Current().Name + `", "organizacion", "sin fines de lucro"
 - Output SOLO las palabras clave separadas por comas, sin numeros ni explicaciones
 - Si encuentras que la organizacion tiene programas especificos, incluye el nombre del programa
 - Si la organizacion tiene liderazgo conocido, incluye el nombre del director/presidente`
 
-	resp, err := aiClient.GenerateWithModel(ctx, "llama3.2:3b", systemPrompt, sb.String())
+	resp, err := aiClient.GenerateWithModel(ai.WithTask(ctx, ai.TaskExtract), "llama3.2:3b", systemPrompt, sb.String())
 	if err != nil {
 		return nil, fmt.Errorf("enrich: AI generation failed: %w", err)
 	}
@@ -124,9 +128,9 @@ func fetchPageText(ctx context.Context, rawURL string) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36")
+	useragent.Apply(req)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := fetchlog.Client.Do(req)
 	if err != nil {
 		return "", err
 	}
@@ -233,10 +237,14 @@ func parseKeywords(raw string, orgName string) []string {
 	return keywords
 }
 
-// isGenericKeyword returns true for words too generic to be useful as search terms.
+// isGenericKeyword returns true for words too generic to be useful as search
+// terms, including the coverage profile's generic terms.
 func isGenericKeyword(lower string) bool {
+	if coverage.Current().IsGeneric(lower) {
+		return true
+	}
 	generics := []string{
-		"puerto rico", "organizacion", "organización", "sin fines de lucro",
+		"organizacion", "organización", "sin fines de lucro",
 		"non-profit", "nonprofit", "ong", "ngo",
 		"comunidad", "community", "servicio", "programa",
 		"website", "pagina web", "contacto", "email",
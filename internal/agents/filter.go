@@ -3,6 +3,10 @@ package agents
 import (
 	"regexp"
 	"strings"
+
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
 )
 
 // IsSpamHit is the exported version of isSpamHit for use by other packages (e.g. research).
@@ -10,47 +14,58 @@ func IsSpamHit(url, title, snippet string, orgKeywords ...string) bool {
 	return isSpamHit(url, title, snippet, orgKeywords...)
 }
 
-// isSpamHit returns true if the URL/title/snippet indicate non-PR, NSFW, or irrelevant content.
+// SpamRule returns the spam rule that rejects the URL/title/snippet, or ""
+// when isSpamHit would let it through. Rules name the check and, for pattern
+// lists, the matching pattern (e.g. "nsfw: leaks", "off_region: mexico").
+func SpamRule(url, title, snippet string, orgKeywords ...string) string {
+	return spamRule(url, title, snippet, orgKeywords...)
+}
+
+// isSpamHit returns true if the URL/title/snippet indicate content about
+// another jurisdiction, NSFW, or irrelevant content.
 // This is applied BEFORE inserting into the DB to prevent noise.
 // orgKeywords is optional — when provided, Reddit results must mention at least one keyword.
 func isSpamHit(url, title, snippet string, orgKeywords ...string) bool {
+	return spamRule(url, title, snippet, orgKeywords...) != ""
+}
+
+// spamRule implements isSpamHit, returning the rule that matched.
+func spamRule(url, title, snippet string, orgKeywords ...string) string {
 	lower := strings.ToLower(title + " " + snippet + " " + url)
 
 	// 1. Reddit subreddit homepages (not actual posts)
 	if isRedditHomepage(url) {
-		return true
+		return "reddit_homepage"
 	}
 
 	// 2. Generic homepages / aggregator fronts
 	if isGenericHomepage(url) {
-		return true
+		return "generic_homepage"
 	}
 
 	// 3. NSFW / pornographic content
 	for _, pat := range nsfwPatterns {
 		if strings.Contains(lower, pat) {
-			return true
+			return "nsfw: " + pat
 		}
 	}
 
-	// 4. Non-PR geographic content (unless it also mentions Puerto Rico)
-	if !mentionsPR(lower) {
-		for _, pat := range nonPRPatterns {
-			if strings.Contains(lower, pat) {
-				return true
-			}
-		}
+	// 4. Content about other places (unless it also mentions the covered
+	// jurisdiction)
+	if pat := coverage.Current().IrrelevantPattern(lower); pat != "" {
+		return "off_region: " + pat
 	}
 
 	// 5. Clickbait / low-quality patterns
 	for _, pat := range spamPatterns {
 		if strings.Contains(lower, pat) {
-			return true
+			return "spam: " + pat
 		}
 	}
 
 	// 6. Reddit posts: require they mention at least one org keyword in title/snippet.
-	// "Puerto Rico" alone is not enough — many Reddit posts mention PR as a song, meme, etc.
+	// The jurisdiction's name alone is not enough — many Reddit posts mention
+	// it as a song, meme, etc.
 	if strings.Contains(strings.ToLower(url), "reddit.com") && len(orgKeywords) > 0 {
 		hasKeyword := false
 		for _, kw := range orgKeywords {
@@ -60,11 +75,18 @@ func isSpamHit(url, title, snippet string, orgKeywords ...string) bool {
 			}
 		}
 		if !hasKeyword {
-			return true
+			return "reddit_no_keyword"
 		}
 	}
 
-	return false
+	return ""
+}
+
+// MentionsRegion reports whether the text mentions the covered jurisdiction
+// (one of the coverage profile's relevance terms). Without such a mention,
+// hits naming another place are dropped.
+func MentionsRegion(text string) bool {
+	return coverage.Current().Mentions(text)
 }
 
 // redditPostRe matches actual Reddit post URLs: /r/sub/comments/id/...
@@ -95,24 +117,6 @@ func isGenericHomepage(rawURL string) bool {
 	return false
 }
 
-// mentionsPR checks whether the text mentions Puerto Rico or PR-related terms.
-func mentionsPR(lower string) bool {
-	for _, term := range prTerms {
-		if strings.Contains(lower, term) {
-			return true
-		}
-	}
-	return false
-}
-
-// prTerms are terms that indicate content is about Puerto Rico.
-var prTerms = []string{
-	"puerto rico", "boricua", "puertorriqueño", "puertorriquena",
-	"san juan", "bayamón", "bayamon", "ponce", "caguas", "mayagüez", "mayaguez",
-	"carolina pr", "arecibo", "guaynabo", "isla del encanto",
-	".pr/", "gobierno.pr",
-}
-
 // nsfwPatterns catch pornographic, adult, and NSFW content.
 var nsfwPatterns = []string{
 	"onlyfans", "caseros", "porn", "nsfw", "xxx", "nude", "nudes",
@@ -123,17 +127,6 @@ var nsfwPatterns = []string{
 	"gonewild", "rule34", "hentai", "milf", "fetish",
 }
 
-// nonPRPatterns indicate content about other countries/regions, not Puerto Rico.
-var nonPRPatterns = []string{
-	"dominicana", "dominicano", "santo domingo", "república dominicana",
-	"mexico", "méxico", "colombia", "venezuela", "argentina",
-	"españa", "spain", "paraguay", "chile", "perú", "peru",
-	"cuba", "panamá", "panama", "ecuador", "bolivia",
-	"guatemala", "honduras", "el salvador", "nicaragua", "costa rica",
-	"new jersey", "new york city", "florida man",
-	"india", "pakistan", "passport india",
-}
-
 // spamPatterns catch clickbait, low-quality, or irrelevant content.
 var spamPatterns = []string{
 	"blind bags", "mystery box", "unboxing haul",
@@ -143,3 +136,68 @@ var spamPatterns = []string{
 	"weight loss secret", "diet pill",
 	"google noticias", "news.google.com/stories",
 }
+
+// FilterCheck is the outcome of one filter rule for the admin filter
+// diagnostic. Rule names the matching rule or pattern when Matched is set.
+type FilterCheck struct {
+	Filter  string `json:"filter"`
+	Applies string `json:"applies"` // where the filter runs: ingestion, watchlist, youtube
+	Matched bool   `json:"matched"`
+	Rule    string `json:"rule,omitempty"`
+	Detail  string `json:"detail,omitempty"`
+}
+
+// ExplainFilters runs a URL/title/snippet through the content filters used by
+// ingestion and the watchlist agents and reports each outcome. org is
+// optional; when set, its name and keywords feed the keyword checks.
+func ExplainFilters(url, title, snippet string, org *models.WatchlistOrg) []FilterCheck {
+	var keywords []string
+	if org != nil {
+		keywords = append([]string{org.Name}, org.Keywords...)
+	}
+
+	noise := scraper.NoiseTitlePattern(title)
+	checks := []FilterCheck{{
+		Filter:  "noise_title",
+		Applies: "ingestion",
+		Matched: noise != "",
+		Rule:    noise,
+	}}
+
+	// Reddit keyword matching only applies to the reddit agent, which passes
+	// the org's keywords; the other agents call isSpamHit without them.
+	spam := spamRule(url, title, snippet)
+	if spam == "" && len(keywords) > 0 {
+		spam = spamRule(url, title, snippet, keywords...)
+	}
+	checks = append(checks, FilterCheck{
+		Filter:  "spam",
+		Applies: "watchlist",
+		Matched: spam != "",
+		Rule:    spam,
+	})
+
+	place := coverage.Current().Name
+	region := FilterCheck{Filter: "region_relevance", Applies: "watchlist"}
+	if MentionsRegion(title + " " + snippet + " " + url) {
+		region.Detail = "mentions " + place + "; patterns naming other places are ignored"
+	} else {
+		region.Detail = "no " + place + " mention; hits naming another country or region are dropped"
+		region.Matched = strings.HasPrefix(spam, "off_region: ")
+		if region.Matched {
+			region.Rule = spam
+		}
+	}
+	checks = append(checks, region)
+
+	if org != nil {
+		kw := FilterCheck{Filter: "org_keywords", Applies: "youtube"}
+		if !containsAnyKeyword(title+" "+snippet, *org) {
+			kw.Matched = true
+			kw.Rule = "no_keyword"
+			kw.Detail = "title and description mention neither " + org.Name + " nor its keywords"
+		}
+		checks = append(checks, kw)
+	}
+	return checks
+}
@@ -2,12 +2,12 @@ package agents
 
 import (
 	"context"
-	"fmt"
+	"errors"
 	"log/slog"
-	"net/url"
 
 	"github.com/google/uuid"
 
+	"github.com/Saul-Punybz/folio/internal/coverage"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/scraper"
 )
@@ -20,15 +20,11 @@ func ScanGoogleNews(ctx context.Context, org models.WatchlistOrg, queries []stri
 			break
 		}
 
-		feedURL := fmt.Sprintf(
-			"https://news.google.com/rss/search?q=%s&hl=es-419&gl=PR&ceid=PR:es-419",
-			url.QueryEscape(query),
-		)
-
-		agentCtx, cancel := context.WithTimeout(ctx, agentTimeout)
-		items, err := scraper.ParseFeed(agentCtx, feedURL)
-		cancel()
-
+		items, err := googleNewsCandidates(ctx, query)
+		if errors.Is(err, scraper.ErrSearchBudgetExhausted) {
+			slog.Warn("watchlist/google_news: skipped", "org", org.Name, "err", err)
+			break
+		}
 		if err != nil {
 			slog.Warn("watchlist/google_news: parse feed", "query", query, "err", err)
 			continue
@@ -38,24 +34,14 @@ func ScanGoogleNews(ctx context.Context, org models.WatchlistOrg, queries []stri
 			if hits >= maxResultsPerAgent {
 				break
 			}
-			if item.Link == "" {
+			if item.URL == "" {
 				continue
 			}
-			if isSpamHit(item.Link, item.Title, item.Description) {
+			if isSpamHit(item.URL, item.Title, item.Snippet) {
 				continue
 			}
 
-			urlHash := scraper.HashURL(item.Link)
-			hit := &models.WatchlistHit{
-				ID:         uuid.New(),
-				OrgID:      org.ID,
-				SourceType: "google_news",
-				Title:      item.Title,
-				URL:        item.Link,
-				URLHash:    urlHash,
-				Snippet:    truncateStr(item.Description, 500),
-				Sentiment:  "unknown",
-			}
+			hit := item.hit(org.ID)
 
 			if err := deps.Hits.Create(ctx, hit); err != nil {
 				slog.Error("watchlist/google_news: create hit", "err", err)
@@ -72,3 +58,30 @@ func ScanGoogleNews(ctx context.Context, org models.WatchlistOrg, queries []stri
 	}
 	return hits
 }
+
+// googleNewsCandidates fetches the Google News RSS results for one query.
+func googleNewsCandidates(ctx context.Context, query string) ([]hitCandidate, error) {
+	feedURL := coverage.Current().GoogleNewsSearchURL(query)
+
+	if err := scraper.ReserveSearch(ctx, scraper.EngineGoogleNews); err != nil {
+		return nil, err
+	}
+
+	agentCtx, cancel := context.WithTimeout(ctx, agentTimeout)
+	items, err := scraper.ParseFeed(agentCtx, feedURL)
+	cancel()
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]hitCandidate, 0, len(items))
+	for _, item := range items {
+		candidates = append(candidates, hitCandidate{
+			SourceType: "google_news",
+			Title:      item.Title,
+			URL:        item.Link,
+			Snippet:    item.Description,
+		})
+	}
+	return candidates, nil
+}
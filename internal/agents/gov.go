@@ -0,0 +1,183 @@
+package agents
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
+)
+
+const (
+	// govCacheTTL is how long fetched press releases are matched against
+	// orgs before the listing pages are checked again. A scan visits every
+	// org, so the pages are fetched once per scan rather than once per org.
+	govCacheTTL = 1 * time.Hour
+
+	// maxGovReleasesPerPage caps the releases read from one listing page.
+	maxGovReleasesPerPage = 10
+)
+
+// govPressPage is a government page that links to press releases.
+type govPressPage struct {
+	Agency       string
+	ListURL      string
+	LinkSelector string
+}
+
+// govLinkSelector matches press release links on agency sites that do not
+// have a dedicated listing page.
+const govLinkSelector = `a[href*="comunicado"], a[href*="prensa"], a[href*="noticia"]`
+
+// govPressPages are La Fortaleza's communiqués, the legislature's press pages
+// and the main agencies' sites.
+var govPressPages = []govPressPage{
+	{"La Fortaleza", "https://www.fortaleza.pr.gov/comunicados", `a[href*="comunicado"], article a, .views-row a`},
+	{"Senado de PR", "https://senado.pr.gov/Pages/Communications.aspx", `a[href*="Communications"], a[href*="comunicado"], .ms-vb a`},
+	{"Cámara de Representantes", "https://tucamarapr.org/noticias/", `article a, .entry-title a, h2 a[href*="/noticias/"]`},
+	{"Oficina del Contralor", "https://www.ocpr.gov.pr/", govLinkSelector},
+	{"AAFAF", "https://www.aafaf.pr.gov/", govLinkSelector},
+	{"Depto. de Hacienda", "https://www.hacienda.pr.gov/", govLinkSelector},
+	{"Depto. de Salud", "https://www.salud.pr.gov/", govLinkSelector},
+	{"Depto. de Educación", "https://www.educacion.pr.gov/", govLinkSelector},
+	{"Depto. de Justicia", "https://www.justicia.pr.gov/", govLinkSelector},
+	{"Depto. del Trabajo", "https://www.trabajo.pr.gov/", govLinkSelector},
+	{"DRNA", "https://www.drna.pr.gov/", govLinkSelector},
+	{"Junta de Planificación", "https://www.jp.pr.gov/", govLinkSelector},
+}
+
+// govRelease is a fetched press release.
+type govRelease struct {
+	Agency string
+	URL    string
+	Title  string
+	Text   string
+}
+
+// govCache holds the releases currently linked from the press pages. Releases
+// are fetched once and kept while their page still links to them.
+var govCache = struct {
+	mu        sync.Mutex
+	releases  map[string]govRelease // by URL
+	fetchedAt time.Time
+}{releases: map[string]govRelease{}}
+
+var govScraper = scraper.NewScraper()
+
+// ScanGov matches recent government press releases against the org and
+// stores them as "gov" hits.
+func ScanGov(ctx context.Context, org models.WatchlistOrg, deps Deps) int {
+	hits := 0
+	for _, rel := range govReleases(ctx) {
+		if hits >= maxResultsPerAgent || ctx.Err() != nil {
+			break
+		}
+		if !containsAnyKeyword(rel.Title+"\n"+rel.Text, org) {
+			continue
+		}
+
+		hit := hitCandidate{
+			SourceType: "gov",
+			Title:      rel.Agency + ": " + rel.Title,
+			URL:        rel.URL,
+			Snippet:    strings.Join(strings.Fields(rel.Text), " "),
+		}.hit(org.ID)
+		if err := deps.Hits.Create(ctx, hit); err != nil {
+			slog.Error("watchlist/gov: create hit", "url", rel.URL, "err", err)
+			continue
+		}
+		if hit.ID != uuid.Nil {
+			hits++
+		}
+	}
+
+	if hits > 0 {
+		slog.Info("watchlist/gov: done", "org", org.Name, "new_hits", hits)
+	}
+	return hits
+}
+
+// govReleases returns the cached releases, refreshing them from the press
+// pages when the cache is older than govCacheTTL.
+func govReleases(ctx context.Context) []govRelease {
+	govCache.mu.Lock()
+	defer govCache.mu.Unlock()
+
+	if time.Since(govCache.fetchedAt) >= govCacheTTL {
+		refreshGovReleases(ctx)
+	}
+
+	releases := make([]govRelease, 0, len(govCache.releases))
+	for _, rel := range govCache.releases {
+		releases = append(releases, rel)
+	}
+	return releases
+}
+
+// refreshGovReleases reads every press page and fetches the releases not yet
+// cached. Releases of a page that could not be read are kept. Called with
+// govCache.mu held.
+func refreshGovReleases(ctx context.Context) {
+	current := make(map[string]govRelease, len(govCache.releases))
+	fetched := 0
+	for _, page := range govPressPages {
+		if ctx.Err() != nil {
+			return
+		}
+
+		pageCtx, cancel := context.WithTimeout(ctx, agentTimeout)
+		links, err := govScraper.ScrapeLinks(pageCtx, page.ListURL, page.LinkSelector, false)
+		cancel()
+		if err != nil {
+			slog.Warn("watchlist/gov: list", "agency", page.Agency, "err", err)
+			for u, rel := range govCache.releases {
+				if rel.Agency == page.Agency {
+					current[u] = rel
+				}
+			}
+			continue
+		}
+
+		n := 0
+		for _, link := range links {
+			if n >= maxGovReleasesPerPage || ctx.Err() != nil {
+				break
+			}
+			if link == page.ListURL || strings.TrimSuffix(link, "/") == strings.TrimSuffix(page.ListURL, "/") {
+				continue
+			}
+			n++
+			if rel, ok := govCache.releases[link]; ok {
+				current[link] = rel
+				continue
+			}
+
+			relCtx, cancel := context.WithTimeout(ctx, agentTimeout)
+			article, err := govScraper.ScrapeArticle(relCtx, link, scraper.SourceSelectors{TitleSelector: "h1"})
+			cancel()
+			if err != nil {
+				slog.Debug("watchlist/gov: fetch release", "url", link, "err", err)
+				continue
+			}
+			if article.Title == "" || article.CleanText == "" {
+				continue
+			}
+			current[link] = govRelease{
+				Agency: page.Agency,
+				URL:    link,
+				Title:  strings.TrimSpace(article.Title),
+				Text:   article.CleanText,
+			}
+			fetched++
+		}
+	}
+
+	govCache.releases = current
+	govCache.fetchedAt = time.Now()
+	slog.Info("watchlist/gov: press releases refreshed", "releases", len(current), "fetched", fetched)
+}
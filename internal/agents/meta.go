@@ -0,0 +1,169 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// metaGraphURL is the Graph API base; pinned to a version so field names do
+// not shift under us.
+const metaGraphURL = "https://graph.facebook.com/v19.0"
+
+// metaPageIDRe matches Facebook page usernames/IDs and Instagram account IDs.
+var metaPageIDRe = regexp.MustCompile(`^[A-Za-z0-9.]{1,100}$`)
+
+// NormalizeSocialPage parses a watchlist social page as entered by a user —
+// "facebook:<page>", "instagram:<account id>", a bare Facebook page name or
+// a facebook.com page URL — into its stored "network:id" form.
+func NormalizeSocialPage(s string) (string, bool) {
+	s = strings.TrimSpace(s)
+	network, id := "facebook", s
+	if n, rest, ok := strings.Cut(s, ":"); ok && (n == "facebook" || n == "instagram") {
+		network, id = n, rest
+	} else if u, err := url.Parse(s); err == nil && u.Host != "" {
+		host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+		if host != "facebook.com" && host != "m.facebook.com" {
+			return "", false
+		}
+		id = strings.Split(strings.Trim(u.Path, "/"), "/")[0]
+	}
+	id = strings.TrimSpace(id)
+	if !metaPageIDRe.MatchString(id) {
+		return "", false
+	}
+	return network + ":" + id, true
+}
+
+// ScanMetaPages polls the org's Facebook pages and Instagram accounts through
+// the Graph API and stores posts that mention the org as "social" hits.
+// Skipped when no Graph API token is configured.
+func ScanMetaPages(ctx context.Context, org models.WatchlistOrg, deps Deps) int {
+	cfg := socialConfig.Load()
+	if cfg == nil || cfg.MetaToken == "" {
+		return 0
+	}
+
+	hits := 0
+	for _, page := range org.SocialPages {
+		if hits >= maxResultsPerAgent || ctx.Err() != nil {
+			break
+		}
+		network, id, ok := strings.Cut(page, ":")
+		if !ok {
+			continue
+		}
+
+		agentCtx, cancel := context.WithTimeout(ctx, agentTimeout)
+		var posts []hitCandidate
+		var err error
+		switch network {
+		case "facebook":
+			posts, err = facebookPagePosts(agentCtx, cfg.MetaToken, id)
+		case "instagram":
+			posts, err = instagramMedia(agentCtx, cfg.MetaToken, id)
+		default:
+			err = fmt.Errorf("unknown network %q", network)
+		}
+		cancel()
+		if err != nil {
+			slog.Warn("watchlist/meta: fetch", "page", page, "err", err)
+			continue
+		}
+
+		for _, post := range posts {
+			if hits >= maxResultsPerAgent {
+				break
+			}
+			if post.URL == "" || !containsAnyKeyword(post.Snippet, org) {
+				continue
+			}
+			if isSpamHit(post.URL, post.Title, post.Snippet) {
+				continue
+			}
+
+			hit := post.hit(org.ID)
+			if err := deps.Hits.Create(ctx, hit); err != nil {
+				slog.Error("watchlist/meta: create hit", "page", page, "err", err)
+				continue
+			}
+			if hit.ID != uuid.Nil {
+				hits++
+			}
+		}
+	}
+
+	if hits > 0 {
+		slog.Info("watchlist/meta: done", "org", org.Name, "new_hits", hits)
+	}
+	return hits
+}
+
+// facebookPagePosts returns a public page's recent posts.
+func facebookPagePosts(ctx context.Context, token, pageID string) ([]hitCandidate, error) {
+	params := url.Values{
+		"fields": {"message,permalink_url,from{name}"},
+		"limit":  {"25"},
+	}
+	var result struct {
+		Data []struct {
+			Message      string `json:"message"`
+			PermalinkURL string `json:"permalink_url"`
+			From         struct {
+				Name string `json:"name"`
+			} `json:"from"`
+		} `json:"data"`
+	}
+	endpoint := fmt.Sprintf("%s/%s/posts?%s", metaGraphURL, url.PathEscape(pageID), params.Encode())
+	if err := getSocialJSON(ctx, endpoint, token, &result); err != nil {
+		return nil, fmt.Errorf("facebook page %s: %w", pageID, err)
+	}
+
+	posts := make([]hitCandidate, 0, len(result.Data))
+	for _, p := range result.Data {
+		if p.Message == "" {
+			continue
+		}
+		author := p.From.Name
+		if author == "" {
+			author = pageID
+		}
+		posts = append(posts, socialCandidate(author, p.PermalinkURL, p.Message))
+	}
+	return posts, nil
+}
+
+// instagramMedia returns a business account's recent posts.
+func instagramMedia(ctx context.Context, token, accountID string) ([]hitCandidate, error) {
+	params := url.Values{
+		"fields": {"caption,permalink,username"},
+		"limit":  {"25"},
+	}
+	var result struct {
+		Data []struct {
+			Caption   string `json:"caption"`
+			Permalink string `json:"permalink"`
+			Username  string `json:"username"`
+		} `json:"data"`
+	}
+	endpoint := fmt.Sprintf("%s/%s/media?%s", metaGraphURL, url.PathEscape(accountID), params.Encode())
+	if err := getSocialJSON(ctx, endpoint, token, &result); err != nil {
+		return nil, fmt.Errorf("instagram account %s: %w", accountID, err)
+	}
+
+	posts := make([]hitCandidate, 0, len(result.Data))
+	for _, m := range result.Data {
+		if m.Caption == "" {
+			continue
+		}
+		posts = append(posts, socialCandidate(m.Username, m.Permalink, m.Caption))
+	}
+	return posts, nil
+}
@@ -0,0 +1,127 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
+)
+
+const (
+	// muteMatchDistance is the maximum cosine distance between a new hit's
+	// embedding and a muted story's for the hit to be hidden. It is looser
+	// than storyMatchDistance because follow-up coverage drifts from the
+	// first article; the entity check keeps it from catching other stories.
+	muteMatchDistance = 0.25
+
+	// maxMuteEntities caps the entities kept in a mute signature.
+	maxMuteEntities = 8
+)
+
+// MuteStory silences the story of one of the org's hits for the given
+// number of days. The signature is the hit's canonical URL, its title+snippet
+// embedding and the people and organizations it names (from the ingested
+// article when there is one, otherwise extracted by the model), leaving out
+// the org's own name and keywords, which every hit for the org mentions.
+// aiClient may be nil; the stored embedding is then used.
+func MuteStory(ctx context.Context, hits *models.WatchlistHitStore, aiClient ai.Provider, org models.WatchlistOrg, hit *models.WatchlistHit, days int, userID uuid.UUID) (*models.MutedStory, error) {
+	hitID := hit.ID
+	m := &models.MutedStory{
+		OrgID:        org.ID,
+		HitID:        &hitID,
+		Title:        hit.Title,
+		CanonicalURL: scraper.CanonicalizeURL(hit.URL),
+		Entities:     storyEntities(ctx, hits, aiClient, org, hit),
+		ExpiresAt:    time.Now().Add(time.Duration(days) * 24 * time.Hour),
+	}
+
+	var embedding []float32
+	if aiClient != nil {
+		var err error
+		embedding, err = aiClient.Embed(ctx, hit.Title+"\n"+hit.Snippet)
+		if err != nil {
+			slog.Warn("watchlist/mute: embed hit", "id", hit.ID, "err", err)
+			embedding = nil
+		}
+	}
+
+	if err := hits.MuteStory(ctx, m, embedding, userID); err != nil {
+		return nil, fmt.Errorf("mute story: %w", err)
+	}
+	return m, nil
+}
+
+// storyEntities returns the entities a muted story is recognized by.
+func storyEntities(ctx context.Context, hits *models.WatchlistHitStore, aiClient ai.Provider, org models.WatchlistOrg, hit *models.WatchlistHit) []string {
+	existing := append([]string{org.Name}, org.Keywords...)
+	seen := make(map[string]bool)
+	var entities []string
+	add := func(name string) {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if len(entities) >= maxMuteEntities || seen[key] || !suggestable(key, existing) {
+			return
+		}
+		seen[key] = true
+		entities = append(entities, name)
+	}
+
+	counts, err := hits.EntityCountsForHits(ctx, []uuid.UUID{hit.ID}, 50)
+	if err != nil {
+		slog.Warn("watchlist/mute: article entities", "id", hit.ID, "err", err)
+	}
+	for _, e := range counts {
+		if e.Type != "place" {
+			add(e.Name)
+		}
+	}
+	if len(entities) > 0 || aiClient == nil {
+		return entities
+	}
+
+	extracted, err := aiClient.ExtractEntities(ctx, hit.Title+"\n"+hit.Snippet)
+	if err != nil {
+		slog.Warn("watchlist/mute: extract entities", "id", hit.ID, "err", err)
+		return entities
+	}
+	for _, name := range extracted.People {
+		add(name)
+	}
+	for _, name := range extracted.Organizations {
+		add(name)
+	}
+	return entities
+}
+
+// mutedBy returns the active mute a new hit falls under, or uuid.Nil. A hit
+// matches a mute with the same canonical URL, or one whose embedding is close
+// enough when the hit also names one of the mute's entities (any close hit
+// matches a mute without entities).
+func mutedBy(ctx context.Context, hits *models.WatchlistHitStore, hit *models.WatchlistHit, canonical string, embedding []float32) (uuid.UUID, error) {
+	mutes, err := hits.MatchMutedStories(ctx, hit.ID, canonical, embedding, muteMatchDistance)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	text := strings.ToLower(hit.Title + " " + hit.Snippet)
+	for _, m := range mutes {
+		if m.CanonicalURL != "" && m.CanonicalURL == canonical {
+			return m.ID, nil
+		}
+		if len(m.Entities) == 0 {
+			return m.ID, nil
+		}
+		for _, e := range m.Entities {
+			if strings.Contains(text, strings.ToLower(e)) {
+				return m.ID, nil
+			}
+		}
+	}
+	return uuid.Nil, nil
+}
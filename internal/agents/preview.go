@@ -0,0 +1,142 @@
+package agents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
+)
+
+// Preview decisions: what a scan would do with a result.
+const (
+	PreviewNew       = "new"        // stored as a hit
+	PreviewFiltered  = "filtered"   // dropped by the spam/relevance filters
+	PreviewExisting  = "existing"   // URL is already one of the user's hits
+	PreviewDuplicate = "duplicate"  // URL returned earlier in the same scan
+	PreviewOverLimit = "over_limit" // past the agent's per-scan hit cap
+)
+
+// PreviewHit is one search result from a scan preview and its decision.
+type PreviewHit struct {
+	hitCandidate
+	Query    string `json:"query"`
+	Decision string `json:"decision"`
+	Rule     string `json:"rule,omitempty"` // filter rule for "filtered"
+}
+
+// ScanPreview is the outcome of running the web and news agents once for a
+// query without storing anything.
+type ScanPreview struct {
+	Queries []string       `json:"queries"`
+	Hits    []PreviewHit   `json:"hits"`
+	Counts  map[string]int `json:"counts"` // hits per decision
+	Errors  []string       `json:"errors"` // agents that failed or were out of search budget
+}
+
+// PreviewScan runs the Google News, Bing News and web agents for a keyword
+// the way a scheduled scan would, and reports which results would become hits
+// and why the others would not. Results the user already has hits for are
+// reported as existing. Search budgets are used as in a scan.
+func PreviewScan(ctx context.Context, userID uuid.UUID, keyword string, hits *models.WatchlistHitStore) (*ScanPreview, error) {
+	ctx = fetchlog.WithPurpose(ctx, fetchlog.Watchlist)
+	queries := buildSearchQueries(models.WatchlistOrg{Name: keyword})
+	preview := &ScanPreview{Queries: queries, Hits: []PreviewHit{}, Counts: map[string]int{}, Errors: []string{}}
+
+	agents := []struct {
+		name  string
+		fetch func(context.Context, string) ([]hitCandidate, error)
+	}{
+		{"google_news", googleNewsCandidates},
+		{"bing_news", bingNewsCandidates},
+		{"web", webCandidates},
+	}
+
+	seen := make(map[string]bool)
+	for _, agent := range agents {
+		for _, query := range queries {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			candidates, err := agent.fetch(ctx, query)
+			if errors.Is(err, scraper.ErrSearchBudgetExhausted) {
+				preview.Errors = append(preview.Errors, fmt.Sprintf("%s: search budget exhausted", agent.name))
+				break
+			}
+			if err != nil {
+				preview.Errors = append(preview.Errors, fmt.Sprintf("%s: %v", agent.name, err))
+				continue
+			}
+
+			for _, c := range candidates {
+				if c.URL == "" {
+					continue
+				}
+				ph := PreviewHit{hitCandidate: c, Query: query, Decision: PreviewNew}
+				hash := scraper.HashURL(c.URL)
+				if seen[hash] {
+					ph.Decision = PreviewDuplicate
+				} else if rule := spamRule(c.URL, c.Title, c.Snippet); rule != "" {
+					ph.Decision = PreviewFiltered
+					ph.Rule = rule
+				} else {
+					seen[hash] = true
+				}
+				preview.Hits = append(preview.Hits, ph)
+			}
+		}
+	}
+
+	if err := markExistingHits(ctx, hits, userID, preview.Hits); err != nil {
+		return nil, err
+	}
+
+	// Each agent stores at most maxResultsPerAgent new hits per scan.
+	created := make(map[string]int)
+	for i := range preview.Hits {
+		ph := &preview.Hits[i]
+		if ph.Decision != PreviewNew {
+			continue
+		}
+		if created[ph.SourceType] >= maxResultsPerAgent {
+			ph.Decision = PreviewOverLimit
+			continue
+		}
+		created[ph.SourceType]++
+	}
+
+	for _, ph := range preview.Hits {
+		preview.Counts[ph.Decision]++
+	}
+	return preview, nil
+}
+
+// markExistingHits flags new preview hits whose URL the user already has a
+// hit for.
+func markExistingHits(ctx context.Context, hits *models.WatchlistHitStore, userID uuid.UUID, preview []PreviewHit) error {
+	var hashes []string
+	for _, ph := range preview {
+		if ph.Decision == PreviewNew {
+			hashes = append(hashes, scraper.HashURL(ph.URL))
+		}
+	}
+	if len(hashes) == 0 {
+		return nil
+	}
+
+	existing, err := hits.ExistingURLHashes(ctx, userID, hashes)
+	if err != nil {
+		return err
+	}
+	for i := range preview {
+		ph := &preview[i]
+		if ph.Decision == PreviewNew && existing[scraper.HashURL(ph.URL)] {
+			ph.Decision = PreviewExisting
+		}
+	}
+	return nil
+}
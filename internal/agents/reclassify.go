@@ -0,0 +1,63 @@
+package agents
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// reclassifyPageSize is how many hits ReclassifySentiment loads at a time.
+const reclassifyPageSize = 100
+
+// ReclassifyResult reports what a sentiment re-classification run did.
+type ReclassifyResult struct {
+	Classified int // hits the model answered for
+	Changed    int // hits whose sentiment changed
+	Failed     int // hits left as they were after a model error
+}
+
+// ReclassifySentiment runs sentiment classification again, with the given
+// model, over the hits created in [from, to) that no person has verified.
+// Hits the model fails on keep their sentiment; existing response drafts are
+// left as they are.
+func ReclassifySentiment(ctx context.Context, hits *models.WatchlistHitStore, aiClient ai.Provider, model string, from, to time.Time) (ReclassifyResult, error) {
+	var res ReclassifyResult
+	after := uuid.Nil
+	for ctx.Err() == nil {
+		page, err := hits.ListForReclassify(ctx, from, to, after, reclassifyPageSize)
+		if err != nil {
+			return res, err
+		}
+		for _, hit := range page {
+			if ctx.Err() != nil {
+				break
+			}
+			sentiment, err := classifySentiment(ctx, aiClient, model, hit)
+			if err != nil {
+				slog.Warn("watchlist/reclassify: classify", "id", hit.ID, "model", model, "err", err)
+				res.Failed++
+				continue
+			}
+			res.Classified++
+			if sentiment == hit.Sentiment {
+				continue
+			}
+			if err := hits.UpdateSentiment(ctx, hit.ID, sentiment); err != nil {
+				slog.Error("watchlist/reclassify: update sentiment", "id", hit.ID, "err", err)
+				res.Failed++
+				continue
+			}
+			res.Changed++
+		}
+		if len(page) < reclassifyPageSize {
+			break
+		}
+		after = page[len(page)-1].ID
+	}
+	return res, ctx.Err()
+}
@@ -0,0 +1,140 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// reportNotableHits is how many negative hits a weekly report lists.
+const reportNotableHits = 10
+
+// RunWatchlistReports stores one weekly report per user with active orgs,
+// covering the previous Monday-to-Monday week (UTC) before now. Users with
+// no hits that week, and users whose report for it already exists, are
+// skipped. Called by the worker on Mondays.
+func RunWatchlistReports(ctx context.Context, deps Deps, now time.Time) {
+	if deps.Reports == nil {
+		return
+	}
+
+	end := reportWeekStart(now)
+	start := end.AddDate(0, 0, -7)
+
+	orgs, err := deps.Orgs.ListActive(ctx)
+	if err != nil {
+		slog.Error("watchlist/report: list active orgs", "err", err)
+		return
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	created := 0
+	for _, org := range orgs {
+		if ctx.Err() != nil || seen[org.UserID] {
+			continue
+		}
+		seen[org.UserID] = true
+
+		ok, err := buildReport(ctx, deps, org.UserID, start, end)
+		if err != nil {
+			slog.Error("watchlist/report: build", "user_id", org.UserID, "err", err)
+			continue
+		}
+		if ok {
+			created++
+		}
+	}
+
+	slog.Info("watchlist/report: complete", "week", start.Format("2006-01-02"), "users", len(seen), "reports", created)
+}
+
+// reportWeekStart returns midnight UTC of the Monday starting t's week.
+func reportWeekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7 // days since Monday
+	return day.AddDate(0, 0, -offset)
+}
+
+// buildReport creates and stores one user's report for [start, end). It
+// returns false when the user had no hits or already has the report.
+func buildReport(ctx context.Context, deps Deps, userID uuid.UUID, start, end time.Time) (bool, error) {
+	orgs, err := deps.Hits.CountByOrg(ctx, userID, start, end)
+	if err != nil {
+		return false, err
+	}
+	if len(orgs) == 0 {
+		return false, nil
+	}
+
+	negative, err := deps.Hits.ListNotableNegative(ctx, userID, start, end, reportNotableHits)
+	if err != nil {
+		return false, err
+	}
+
+	rep := &models.WatchlistReport{
+		UserID:      userID,
+		PeriodStart: start,
+		PeriodEnd:   end,
+		Orgs:        orgs,
+		NotableHits: make([]models.WatchlistReportHit, 0, len(negative)),
+	}
+	for _, o := range orgs {
+		rep.HitCount += o.Hits
+		rep.Positive += o.Positive
+		rep.Negative += o.Negative
+		rep.Neutral += o.Neutral
+	}
+	for i := range negative {
+		h := &negative[i]
+		rep.NotableHits = append(rep.NotableHits, models.WatchlistReportHit{
+			ID:          h.ID,
+			OrgID:       h.OrgID,
+			OrgName:     h.OrgName,
+			Title:       h.Title,
+			URL:         h.URL,
+			SourceType:  h.SourceType,
+			DupCount:    h.DupCount,
+			DraftStatus: h.DraftStatus,
+			Response:    h.ResponseText(),
+			CreatedAt:   h.CreatedAt,
+		})
+	}
+	rep.Summary = renderReport(rep)
+
+	return deps.Reports.Create(ctx, rep)
+}
+
+// renderReport renders the plain-text version of a report.
+func renderReport(rep *models.WatchlistReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Semana del %s al %s\n", rep.PeriodStart.Format("2006-01-02"), rep.PeriodEnd.AddDate(0, 0, -1).Format("2006-01-02"))
+	fmt.Fprintf(&b, "%d menciones en %d organizaciones  🟢 %d  🔴 %d  ⚪ %d\n",
+		rep.HitCount, len(rep.Orgs), rep.Positive, rep.Negative, rep.Neutral)
+
+	for _, o := range rep.Orgs {
+		fmt.Fprintf(&b, "\n%s (%d)  🟢 %d  🔴 %d  ⚪ %d", o.OrgName, o.Hits, o.Positive, o.Negative, o.Neutral)
+	}
+	b.WriteString("\n")
+
+	if len(rep.NotableHits) > 0 {
+		b.WriteString("\nMenciones negativas destacadas:\n")
+		for _, h := range rep.NotableHits {
+			fmt.Fprintf(&b, "\n🔴 %s — %s\n   %s\n", h.OrgName, h.Title, h.URL)
+			if h.Response != "" {
+				status := h.DraftStatus
+				if status == "" {
+					status = "draft"
+				}
+				fmt.Fprintf(&b, "   Respuesta (%s): %s\n", status, truncateStr(h.Response, 280))
+			}
+		}
+	}
+	return b.String()
+}
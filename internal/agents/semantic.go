@@ -0,0 +1,204 @@
+package agents
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
+)
+
+const (
+	// DefaultSemanticThreshold is the minimum cosine similarity between an
+	// org's profile and an article for a semantic hit, when the org sets none.
+	DefaultSemanticThreshold = 0.7
+
+	// semanticWindow is how far back articles are compared; hits already
+	// stored for an article are skipped, so overlapping scans are harmless.
+	semanticWindow = 48 * time.Hour
+
+	// maxSemanticPassages caps how many passages of a matched article are
+	// embedded to find the one that mentions the org.
+	maxSemanticPassages = 8
+
+	// semanticPassageLen is the length paragraphs are merged up to (and cut
+	// at) when splitting an article into passages.
+	semanticPassageLen = 500
+)
+
+// ScanSemantic compares the org's profile embedding with the embeddings of
+// recently ingested articles and stores those above the org's threshold as
+// "semantic" hits, catching mentions that use none of the keywords. The
+// snippet is the article passage closest to the profile. Only runs for orgs
+// with semantic watch on.
+func ScanSemantic(ctx context.Context, org models.WatchlistOrg, deps Deps) int {
+	if !org.SemanticWatch || deps.AI == nil {
+		return 0
+	}
+
+	profile, err := deps.AI.Embed(ctx, orgProfile(org))
+	if err != nil {
+		slog.Warn("watchlist/semantic: embed profile", "org", org.Name, "err", err)
+		return 0
+	}
+
+	threshold := org.SemanticThreshold
+	if threshold <= 0 {
+		threshold = DefaultSemanticThreshold
+	}
+	articles, similarities, err := deps.Articles.ListSimilarSince(ctx, profile,
+		time.Now().Add(-semanticWindow), 1-threshold, maxResultsPerAgent*3)
+	if err != nil {
+		slog.Error("watchlist/semantic: list similar articles", "org", org.Name, "err", err)
+		return 0
+	}
+	if len(articles) == 0 {
+		return 0
+	}
+
+	hashes := make([]string, len(articles))
+	for i, a := range articles {
+		hashes[i] = scraper.HashURL(a.URL)
+	}
+	existing, err := deps.Hits.LinkedURLHashes(ctx, org.ID, hashes)
+	if err != nil {
+		slog.Error("watchlist/semantic: existing hits", "org", org.Name, "err", err)
+		return 0
+	}
+
+	hits := 0
+	for i, article := range articles {
+		if hits >= maxResultsPerAgent || ctx.Err() != nil {
+			break
+		}
+		if existing[hashes[i]] || isSpamHit(article.URL, article.Title, article.CleanText) {
+			continue
+		}
+
+		hit := &models.WatchlistHit{
+			ID:         uuid.New(),
+			OrgID:      org.ID,
+			SourceType: "semantic",
+			Title:      article.Title,
+			URL:        article.URL,
+			URLHash:    hashes[i],
+			Snippet:    truncateStr(bestPassage(ctx, deps, profile, article), 500),
+			Sentiment:  "unknown",
+		}
+		if err := deps.Hits.Create(ctx, hit); err != nil {
+			slog.Error("watchlist/semantic: create hit", "err", err)
+			continue
+		}
+		if hit.ID != uuid.Nil {
+			hits++
+			slog.Debug("watchlist/semantic: hit", "org", org.Name, "url", article.URL, "similarity", similarities[i])
+		}
+	}
+
+	if hits > 0 {
+		slog.Info("watchlist/semantic: done", "org", org.Name, "new_hits", hits)
+	}
+	return hits
+}
+
+// orgProfile is the text embedded to represent an org: its name, website and
+// keywords.
+func orgProfile(org models.WatchlistOrg) string {
+	var b strings.Builder
+	b.WriteString(org.Name)
+	if org.Website != "" {
+		b.WriteString(" (" + org.Website + ")")
+	}
+	if len(org.Keywords) > 0 {
+		b.WriteString(". " + strings.Join(org.Keywords, ", "))
+	}
+	return b.String()
+}
+
+// bestPassage returns the passage of the article whose embedding is closest
+// to the profile, falling back to the summary (or the start of the text) when
+// no passage can be embedded.
+func bestPassage(ctx context.Context, deps Deps, profile []float32, article models.Article) string {
+	fallback := article.Summary
+	if fallback == "" {
+		fallback = article.CleanText
+	}
+
+	passages := splitPassages(article.CleanText)
+	if len(passages) <= 1 {
+		if len(passages) == 1 {
+			return passages[0]
+		}
+		return fallback
+	}
+
+	best, bestSim := "", -1.0
+	for _, p := range passages[:min(len(passages), maxSemanticPassages)] {
+		if ctx.Err() != nil {
+			break
+		}
+		embedding, err := deps.AI.Embed(ctx, p)
+		if err != nil {
+			slog.Warn("watchlist/semantic: embed passage", "url", article.URL, "err", err)
+			continue
+		}
+		if sim := cosineSimilarity(profile, embedding); sim > bestSim {
+			best, bestSim = p, sim
+		}
+	}
+	if best == "" {
+		return fallback
+	}
+	return best
+}
+
+// splitPassages splits text into passages of up to about semanticPassageLen
+// bytes, merging short paragraphs and cutting long ones.
+func splitPassages(text string) []string {
+	var passages []string
+	var cur strings.Builder
+	flush := func() {
+		if p := strings.TrimSpace(cur.String()); p != "" {
+			passages = append(passages, truncateStr(p, semanticPassageLen))
+		}
+		cur.Reset()
+	}
+	for _, para := range strings.Split(text, "\n") {
+		para = strings.TrimSpace(para)
+		if para == "" {
+			continue
+		}
+		if cur.Len() > 0 && cur.Len()+len(para) > semanticPassageLen {
+			flush()
+		}
+		if cur.Len() > 0 {
+			cur.WriteByte(' ')
+		}
+		cur.WriteString(para)
+	}
+	flush()
+	return passages
+}
+
+// cosineSimilarity returns the cosine similarity of two vectors, or 0 when
+// they differ in length or either is zero.
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
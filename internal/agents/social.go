@@ -0,0 +1,230 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/config"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/useragent"
+)
+
+// xSearchURL is the X API v2 recent search endpoint (last 7 days).
+const xSearchURL = "https://api.x.com/2/tweets/search/recent"
+
+// socialConfig is the process-wide config set by SetSocialConfig. Nil
+// disables the social agents.
+var socialConfig atomic.Pointer[config.SocialConfig]
+
+// SetSocialConfig installs the Mastodon instance and API tokens used by the
+// social agents.
+func SetSocialConfig(cfg config.SocialConfig) {
+	socialConfig.Store(&cfg)
+}
+
+// ScanSocial searches Mastodon and, when a bearer token is configured, X for
+// posts mentioning the org. Hits are stored with source_type "social".
+func ScanSocial(ctx context.Context, org models.WatchlistOrg, deps Deps) int {
+	cfg := socialConfig.Load()
+	if cfg == nil {
+		return 0
+	}
+
+	hits := 0
+	if cfg.MastodonHost != "" {
+		hits += scanSocialSource(ctx, org, deps, "mastodon", func(ctx context.Context, query string) ([]hitCandidate, error) {
+			return mastodonCandidates(ctx, cfg, query)
+		})
+	}
+	if cfg.XBearerToken != "" {
+		hits += scanSocialSource(ctx, org, deps, "x", func(ctx context.Context, query string) ([]hitCandidate, error) {
+			return xCandidates(ctx, cfg, query)
+		})
+	}
+	return hits
+}
+
+// scanSocialSource runs one social search for each of the org's terms and
+// stores the posts that mention the org.
+func scanSocialSource(ctx context.Context, org models.WatchlistOrg, deps Deps, name string, fetch func(context.Context, string) ([]hitCandidate, error)) int {
+	hits := 0
+	for _, query := range socialQueries(org) {
+		if hits >= maxResultsPerAgent || ctx.Err() != nil {
+			break
+		}
+
+		agentCtx, cancel := context.WithTimeout(ctx, agentTimeout)
+		posts, err := fetch(agentCtx, query)
+		cancel()
+		if err != nil {
+			slog.Warn("watchlist/social: search", "network", name, "query", query, "err", err)
+			continue
+		}
+
+		for _, post := range posts {
+			if hits >= maxResultsPerAgent {
+				break
+			}
+			if post.URL == "" {
+				continue
+			}
+			// Search matches hashtags and account names too; keep posts
+			// whose text mentions the org.
+			if !containsAnyKeyword(post.Snippet, org) {
+				continue
+			}
+			if isSpamHit(post.URL, post.Title, post.Snippet) {
+				continue
+			}
+
+			hit := post.hit(org.ID)
+			if err := deps.Hits.Create(ctx, hit); err != nil {
+				slog.Error("watchlist/social: create hit", "network", name, "err", err)
+				continue
+			}
+			if hit.ID != uuid.Nil {
+				hits++
+			}
+		}
+	}
+
+	if hits > 0 {
+		slog.Info("watchlist/social: done", "network", name, "org", org.Name, "new_hits", hits)
+	}
+	return hits
+}
+
+// socialQueries returns the org name and up to 4 keywords. Unlike the news
+// queries they are not suffixed with the jurisdiction name, which short posts rarely
+// spell out.
+func socialQueries(org models.WatchlistOrg) []string {
+	queries := []string{org.Name}
+	for i, kw := range org.Keywords {
+		if i >= 4 {
+			break
+		}
+		if !strings.EqualFold(kw, org.Name) {
+			queries = append(queries, kw)
+		}
+	}
+	return queries
+}
+
+// mastodonCandidates searches statuses on the configured Mastodon instance.
+func mastodonCandidates(ctx context.Context, cfg *config.SocialConfig, query string) ([]hitCandidate, error) {
+	params := url.Values{"q": {query}, "type": {"statuses"}, "limit": {"20"}}
+	endpoint := strings.TrimRight(cfg.MastodonHost, "/") + "/api/v2/search?" + params.Encode()
+
+	var result struct {
+		Statuses []struct {
+			URL     string `json:"url"`
+			URI     string `json:"uri"`
+			Content string `json:"content"`
+			Account struct {
+				Acct string `json:"acct"`
+			} `json:"account"`
+		} `json:"statuses"`
+	}
+	if err := getSocialJSON(ctx, endpoint, cfg.MastodonToken, &result); err != nil {
+		return nil, fmt.Errorf("mastodon search: %w", err)
+	}
+
+	candidates := make([]hitCandidate, 0, len(result.Statuses))
+	for _, st := range result.Statuses {
+		link := st.URL
+		if link == "" {
+			link = st.URI
+		}
+		candidates = append(candidates, socialCandidate(st.Account.Acct, link, html.UnescapeString(stripHTMLTags(st.Content))))
+	}
+	return candidates, nil
+}
+
+// xCandidates searches recent posts on X, leaving out reposts.
+func xCandidates(ctx context.Context, cfg *config.SocialConfig, query string) ([]hitCandidate, error) {
+	params := url.Values{
+		"query":       {fmt.Sprintf("%q -is:retweet", query)},
+		"max_results": {"20"},
+		"expansions":  {"author_id"},
+		"user.fields": {"username"},
+	}
+
+	var result struct {
+		Data []struct {
+			ID       string `json:"id"`
+			Text     string `json:"text"`
+			AuthorID string `json:"author_id"`
+		} `json:"data"`
+		Includes struct {
+			Users []struct {
+				ID       string `json:"id"`
+				Username string `json:"username"`
+			} `json:"users"`
+		} `json:"includes"`
+	}
+	if err := getSocialJSON(ctx, xSearchURL+"?"+params.Encode(), cfg.XBearerToken, &result); err != nil {
+		return nil, fmt.Errorf("x search: %w", err)
+	}
+
+	usernames := make(map[string]string, len(result.Includes.Users))
+	for _, u := range result.Includes.Users {
+		usernames[u.ID] = u.Username
+	}
+	candidates := make([]hitCandidate, 0, len(result.Data))
+	for _, post := range result.Data {
+		username := usernames[post.AuthorID]
+		link := "https://x.com/i/web/status/" + post.ID
+		if username != "" {
+			link = fmt.Sprintf("https://x.com/%s/status/%s", username, post.ID)
+		}
+		candidates = append(candidates, socialCandidate(username, link, html.UnescapeString(post.Text)))
+	}
+	return candidates, nil
+}
+
+// socialCandidate builds a hit from a post. Posts have no title, so the
+// author and the start of the text stand in for one.
+func socialCandidate(author, link, text string) hitCandidate {
+	text = strings.Join(strings.Fields(text), " ")
+	title := truncateStr(text, 120)
+	if author != "" {
+		title = "@" + author + ": " + title
+	}
+	return hitCandidate{SourceType: "social", Title: title, URL: link, Snippet: text}
+}
+
+// getSocialJSON GETs a social API endpoint with an optional bearer token and
+// decodes the JSON response into v.
+func getSocialJSON(ctx context.Context, endpoint, token string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	useragent.Apply(req)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := fetchlog.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
+	return json.NewDecoder(io.LimitReader(resp.Body, 2<<20)).Decode(v)
+}
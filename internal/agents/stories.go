@@ -0,0 +1,81 @@
+package agents
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/scraper"
+)
+
+const (
+	// maxGroupPerScan caps how many new hits are assigned to stories per scan.
+	maxGroupPerScan = 200
+
+	// storyMatchDistance is the maximum cosine distance between two hits'
+	// title+snippet embeddings for them to count as the same story.
+	storyMatchDistance = 0.15
+)
+
+// groupStories assigns new hits to stories: a hit joins the story of an
+// earlier hit for the same org with the same canonical URL or a close enough
+// embedding, and otherwise starts a story of its own. Hits matching a muted
+// story are hidden.
+func groupStories(ctx context.Context, deps Deps) {
+	hits, err := deps.Hits.ListUngrouped(ctx, maxGroupPerScan)
+	if err != nil {
+		slog.Error("watchlist/stories: list ungrouped", "err", err)
+		return
+	}
+	if len(hits) == 0 {
+		return
+	}
+
+	joined, muted := 0, 0
+	for i := range hits {
+		if ctx.Err() != nil {
+			break
+		}
+		hit := &hits[i]
+
+		canonical := scraper.CanonicalizeURL(hit.URL)
+
+		var embedding []float32
+		if deps.AI != nil {
+			embedding, err = deps.AI.Embed(ctx, hit.Title+"\n"+hit.Snippet)
+			if err != nil {
+				slog.Warn("watchlist/stories: embed hit", "id", hit.ID, "err", err)
+				embedding = nil
+			}
+		}
+
+		muteID, err := mutedBy(ctx, deps.Hits, hit, canonical, embedding)
+		if err != nil {
+			slog.Error("watchlist/stories: match muted stories", "id", hit.ID, "err", err)
+		} else if muteID != uuid.Nil {
+			if err := deps.Hits.HideMutedHit(ctx, hit.ID, muteID); err != nil {
+				slog.Error("watchlist/stories: hide muted hit", "id", hit.ID, "err", err)
+			} else {
+				muted++
+			}
+		}
+
+		storyID, err := deps.Hits.FindStory(ctx, hit, canonical, embedding, storyMatchDistance)
+		if err != nil {
+			slog.Error("watchlist/stories: find story", "id", hit.ID, "err", err)
+			continue
+		}
+		if storyID == uuid.Nil {
+			storyID = hit.ID
+		} else {
+			joined++
+		}
+
+		if err := deps.Hits.SetStory(ctx, hit.ID, storyID, canonical, embedding); err != nil {
+			slog.Error("watchlist/stories: set story", "id", hit.ID, "err", err)
+		}
+	}
+
+	slog.Info("watchlist/stories: grouped hits", "count", len(hits), "joined_existing", joined, "muted", muted)
+}
@@ -0,0 +1,240 @@
+package agents
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+const (
+	// suggestionWindow is how far back reviewed hits are mined for keywords.
+	suggestionWindow = 90 * 24 * time.Hour
+
+	// minReviewedHits is how many reviewed hits an org needs before keyword
+	// suggestions are meaningful.
+	minReviewedHits = 10
+
+	// minSuggestionSupport is the number of distinct hits a phrase or entity
+	// must appear in to be suggested.
+	minSuggestionSupport = 3
+
+	maxSuggestions        = 15
+	maxSuggestionExamples = 2
+)
+
+// KeywordSuggestion is a candidate keyword for a watchlist org.
+type KeywordSuggestion struct {
+	Keyword  string   `json:"keyword"`
+	Hits     int      `json:"hits"`   // distinct reviewed hits it appears in
+	Source   string   `json:"source"` // entity or phrase
+	Type     string   `json:"type,omitempty"`
+	Examples []string `json:"examples,omitempty"` // titles of hits mentioning it
+}
+
+// KeywordSuggestions is the result of SuggestKeywords.
+type KeywordSuggestions struct {
+	HitsAnalyzed int                 `json:"hits_analyzed"`
+	MinHits      int                 `json:"min_hits"`
+	Suggestions  []KeywordSuggestion `json:"suggestions"`
+}
+
+// SuggestKeywords proposes new keywords for an org from the hits users have
+// reviewed and kept: people and organizations extracted from the ingested
+// articles behind those hits, and proper-noun phrases that recur in hit
+// titles and snippets. Terms already covered by the org's name or keywords,
+// generic terms, and the covered jurisdiction's place names are left out. No suggestions are
+// made until the org has minReviewedHits reviewed hits.
+func SuggestKeywords(ctx context.Context, hits *models.WatchlistHitStore, org models.WatchlistOrg) (*KeywordSuggestions, error) {
+	reviewed, err := hits.ListReviewedByOrg(ctx, org.ID, time.Now().Add(-suggestionWindow), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &KeywordSuggestions{
+		HitsAnalyzed: len(reviewed),
+		MinHits:      minReviewedHits,
+		Suggestions:  []KeywordSuggestion{},
+	}
+	if len(reviewed) < minReviewedHits {
+		return result, nil
+	}
+
+	existing := append([]string{org.Name}, org.Keywords...)
+	candidates := make(map[string]*KeywordSuggestion)
+
+	// Proper-noun phrases, counted once per hit.
+	for _, hit := range reviewed {
+		seen := make(map[string]bool)
+		for _, phrase := range properNounPhrases(hit.Title + ". " + hit.Snippet) {
+			key := strings.ToLower(phrase)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			c, ok := candidates[key]
+			if !ok {
+				c = &KeywordSuggestion{Keyword: phrase, Source: "phrase"}
+				candidates[key] = c
+			}
+			c.Hits++
+			if len(c.Examples) < maxSuggestionExamples {
+				c.Examples = append(c.Examples, hit.Title)
+			}
+		}
+	}
+
+	// Entities from ingested articles take precedence over raw phrases.
+	ids := make([]uuid.UUID, len(reviewed))
+	for i, hit := range reviewed {
+		ids[i] = hit.ID
+	}
+	entities, err := hits.EntityCountsForHits(ctx, ids, 100)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entities {
+		if e.Type == "place" {
+			continue
+		}
+		key := strings.ToLower(e.Name)
+		c, ok := candidates[key]
+		if !ok {
+			c = &KeywordSuggestion{Keyword: e.Name}
+			candidates[key] = c
+		}
+		c.Source = "entity"
+		c.Type = e.Type
+		if e.Hits > c.Hits {
+			c.Hits = e.Hits
+		}
+	}
+
+	for key, c := range candidates {
+		if c.Hits < minSuggestionSupport || !suggestable(key, existing) {
+			continue
+		}
+		result.Suggestions = append(result.Suggestions, *c)
+	}
+
+	sort.Slice(result.Suggestions, func(i, j int) bool {
+		a, b := result.Suggestions[i], result.Suggestions[j]
+		if a.Hits != b.Hits {
+			return a.Hits > b.Hits
+		}
+		return a.Keyword < b.Keyword
+	})
+	if len(result.Suggestions) > maxSuggestions {
+		result.Suggestions = result.Suggestions[:maxSuggestions]
+	}
+	return result, nil
+}
+
+// suggestable reports whether a lowercased candidate is worth proposing given
+// the org's existing name and keywords.
+func suggestable(lower string, existing []string) bool {
+	if len(lower) < 3 || len(lower) > 50 || isGenericKeyword(lower) || coverage.Current().Mentions(lower) {
+		return false
+	}
+	for _, kw := range existing {
+		kw = strings.ToLower(strings.TrimSpace(kw))
+		if kw == "" {
+			continue
+		}
+		if strings.Contains(lower, kw) || strings.Contains(kw, lower) {
+			return false
+		}
+	}
+	return true
+}
+
+// phraseConnectors may appear inside a proper-noun phrase ("Junta de
+// Planificación", "Department of Health") but never start or end one.
+// Conjunctions are left out so "Salud y la AEE" yields two names.
+var phraseConnectors = map[string]bool{
+	"de": true, "del": true, "la": true, "las": true, "los": true,
+	"of": true, "the": true, "for": true,
+}
+
+// phraseStopwords are capitalized words that begin sentences or headlines
+// rather than names.
+var phraseStopwords = map[string]bool{
+	"el": true, "la": true, "los": true, "las": true, "un": true, "una": true,
+	"en": true, "con": true, "por": true, "para": true, "tras": true, "ante": true,
+	"the": true, "a": true, "an": true, "in": true, "on": true, "at": true,
+	"lunes": true, "martes": true, "miércoles": true, "jueves": true, "viernes": true,
+	"sábado": true, "domingo": true,
+	"enero": true, "febrero": true, "marzo": true, "abril": true, "mayo": true, "junio": true,
+	"julio": true, "agosto": true, "septiembre": true, "octubre": true, "noviembre": true,
+	"diciembre": true,
+}
+
+// properNounPhrases returns runs of two to five capitalized words (joined by
+// connectors) and standalone acronyms such as "AEE" or "LUMA".
+func properNounPhrases(text string) []string {
+	var phrases []string
+	var run []string
+
+	flush := func() {
+		// Trim connectors from the ends of the run.
+		for len(run) > 0 && phraseConnectors[strings.ToLower(run[len(run)-1])] {
+			run = run[:len(run)-1]
+		}
+		if len(run) >= 2 && len(run) <= 5 {
+			phrases = append(phrases, strings.Join(run, " "))
+		} else if len(run) == 1 && isAcronym(run[0]) {
+			phrases = append(phrases, run[0])
+		}
+		run = run[:0]
+	}
+
+	for _, raw := range strings.Fields(text) {
+		word := strings.TrimFunc(raw, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		last, _ := utf8.DecodeLastRuneInString(raw)
+		endsClause := strings.ContainsRune(".,;:!?)\"”", last)
+
+		switch {
+		case word == "":
+			flush()
+		case isCapitalized(word) && !(len(run) == 0 && phraseStopwords[strings.ToLower(word)]):
+			run = append(run, word)
+		case len(run) > 0 && phraseConnectors[strings.ToLower(word)]:
+			run = append(run, word)
+		default:
+			flush()
+		}
+		if endsClause {
+			flush()
+		}
+	}
+	flush()
+	return phrases
+}
+
+func isCapitalized(word string) bool {
+	for _, r := range word {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+func isAcronym(word string) bool {
+	if len(word) < 2 || len(word) > 6 {
+		return false
+	}
+	for _, r := range word {
+		if !unicode.IsUpper(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+package agents
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/notify"
+)
+
+const (
+	// maxWebhookHitsPerOrg caps how many of an org's hits one scan considers,
+	// so a noisy org cannot crowd the user's other orgs out.
+	maxWebhookHitsPerOrg = 10
+
+	// maxWebhookHitsPerTarget caps how many hits one scan posts to a target so
+	// a noisy scan cannot flood a channel.
+	maxWebhookHitsPerTarget = 50
+)
+
+// dispatchWebhooks posts hits created since the scan started to each user's
+// matching webhook targets. Deliveries go through the job queue (which retries
+// failures with backoff) when deps.Jobs is set, and are sent inline otherwise.
+// Hits over the per-org or per-target caps are skipped and logged.
+func dispatchWebhooks(ctx context.Context, deps Deps, since time.Time) {
+	if deps.Webhooks == nil {
+		return
+	}
+
+	targets, err := deps.Webhooks.ListActive(ctx)
+	if err != nil {
+		slog.Error("watchlist/webhooks: list targets", "err", err)
+		return
+	}
+	if len(targets) == 0 {
+		return
+	}
+
+	byUser := make(map[uuid.UUID][]models.WebhookTarget)
+	for _, t := range targets {
+		byUser[t.UserID] = append(byUser[t.UserID], t)
+	}
+
+	until := time.Now()
+	sent := 0
+	for userID, userTargets := range byUser {
+		if ctx.Err() != nil {
+			break
+		}
+		hits, err := deps.Hits.ListNewByUser(ctx, userID, since, until, maxWebhookHitsPerOrg)
+		if err != nil {
+			slog.Error("watchlist/webhooks: list new hits", "user", userID, "err", err)
+			continue
+		}
+		if counts, err := deps.Hits.CountNewByOrg(ctx, userID, since, until); err != nil {
+			slog.Warn("watchlist/webhooks: count new hits", "user", userID, "err", err)
+		} else if total := sumCounts(counts); total > len(hits) {
+			slog.Warn("watchlist/webhooks: org cap reached, hits skipped",
+				"user", userID, "new", total, "skipped", total-len(hits), "per_org", maxWebhookHitsPerOrg)
+		}
+
+		// Newest first, across orgs, so the per-target cap keeps the latest.
+		sort.SliceStable(hits, func(i, j int) bool {
+			return hits[i].CreatedAt.After(hits[j].CreatedAt)
+		})
+		for i := range userTargets {
+			target := &userTargets[i]
+			posted, skipped := 0, 0
+			for _, hit := range hits {
+				if !target.Matches(hit) {
+					continue
+				}
+				if posted == maxWebhookHitsPerTarget {
+					skipped++
+					continue
+				}
+				posted++
+				if deliverHit(ctx, deps, target, hit) {
+					sent++
+				}
+			}
+			if skipped > 0 {
+				slog.Warn("watchlist/webhooks: target cap reached, hits skipped",
+					"target", target.ID, "skipped", skipped, "limit", maxWebhookHitsPerTarget)
+			}
+		}
+	}
+
+	if sent > 0 {
+		slog.Info("watchlist/webhooks: deliveries dispatched", "count", sent, "queued", deps.Jobs != nil)
+	}
+}
+
+// sumCounts adds up per-org hit counts.
+func sumCounts(counts map[uuid.UUID]int) int {
+	total := 0
+	for _, n := range counts {
+		total += n
+	}
+	return total
+}
+
+func deliverHit(ctx context.Context, deps Deps, target *models.WebhookTarget, hit models.WatchlistHit) bool {
+	if deps.Jobs != nil {
+		payload := notify.WebhookJobPayload{TargetID: target.ID, Hit: hit}
+		if err := deps.Jobs.Enqueue(ctx, models.JobDeliverWebhook, payload); err != nil {
+			slog.Error("watchlist/webhooks: enqueue delivery", "target", target.ID, "hit", hit.ID, "err", err)
+			return false
+		}
+		return true
+	}
+
+	if err := notify.Deliver(ctx, target, hit); err != nil {
+		slog.Warn("watchlist/webhooks: delivery failed", "target", target.ID, "hit", hit.ID, "err", err)
+		return false
+	}
+	return true
+}
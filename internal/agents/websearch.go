@@ -2,6 +2,7 @@ package agents
 
 import (
 	"context"
+	"errors"
 	"log/slog"
 
 	"github.com/google/uuid"
@@ -18,7 +19,11 @@ func ScanWeb(ctx context.Context, org models.WatchlistOrg, queries []string, dep
 			break
 		}
 
-		results, err := scraper.WebSearch(ctx, query, maxResultsPerAgent)
+		results, err := webCandidates(ctx, query)
+		if errors.Is(err, scraper.ErrSearchBudgetExhausted) {
+			slog.Warn("watchlist/web: skipped", "org", org.Name, "err", err)
+			break
+		}
 		if err != nil {
 			slog.Warn("watchlist/web: search", "query", query, "err", err)
 			continue
@@ -35,17 +40,7 @@ func ScanWeb(ctx context.Context, org models.WatchlistOrg, queries []string, dep
 				continue
 			}
 
-			urlHash := scraper.HashURL(result.URL)
-			hit := &models.WatchlistHit{
-				ID:         uuid.New(),
-				OrgID:      org.ID,
-				SourceType: "web",
-				Title:      result.Title,
-				URL:        result.URL,
-				URLHash:    urlHash,
-				Snippet:    truncateStr(result.Snippet, 500),
-				Sentiment:  "unknown",
-			}
+			hit := result.hit(org.ID)
 
 			if err := deps.Hits.Create(ctx, hit); err != nil {
 				slog.Error("watchlist/web: create hit", "err", err)
@@ -62,3 +57,22 @@ func ScanWeb(ctx context.Context, org models.WatchlistOrg, queries []string, dep
 	}
 	return hits
 }
+
+// webCandidates runs one DuckDuckGo web search.
+func webCandidates(ctx context.Context, query string) ([]hitCandidate, error) {
+	results, err := scraper.WebSearch(ctx, query, maxResultsPerAgent)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]hitCandidate, 0, len(results))
+	for _, r := range results {
+		candidates = append(candidates, hitCandidate{
+			SourceType: "web",
+			Title:      r.Title,
+			URL:        r.URL,
+			Snippet:    r.Snippet,
+		})
+	}
+	return candidates, nil
+}
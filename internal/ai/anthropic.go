@@ -0,0 +1,195 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	// anthropicVersion is the Messages API version requested.
+	anthropicVersion = "2023-06-01"
+
+	// anthropicMaxTokens is the max_tokens sent when the task sets none;
+	// the Messages API requires one.
+	anthropicMaxTokens = 4096
+)
+
+// ── Anthropic protocol types ─────────────────────────────────
+
+type anthropicRequest struct {
+	Model       string          `json:"model"`
+	System      string          `json:"system,omitempty"`
+	Messages    []openaiMessage `json:"messages"`
+	MaxTokens   int             `json:"max_tokens"`
+	Temperature *float64        `json:"temperature,omitempty"`
+	Stream      bool            `json:"stream,omitempty"`
+}
+
+type anthropicResponse struct {
+	Content []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+type anthropicStreamEvent struct {
+	Type  string `json:"type"`
+	Delta struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"delta"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// ── Anthropic backend ────────────────────────────────────────
+
+// anthropicBackend speaks the Anthropic Messages API (POST /v1/messages).
+// Anthropic serves no embeddings, so it is only used for generation.
+type anthropicBackend struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newAnthropicBackend(baseURL, apiKey string) *anthropicBackend {
+	return &anthropicBackend{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: generateTimeout},
+	}
+}
+
+func (b *anthropicBackend) headers() http.Header {
+	return http.Header{
+		"X-Api-Key":         {b.apiKey},
+		"Anthropic-Version": {anthropicVersion},
+	}
+}
+
+// generate streams when onToken is set and otherwise makes one request.
+func (b *anthropicBackend) generate(ctx context.Context, model, systemPrompt, userPrompt string, onToken TokenFunc) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, generateTimeout)
+	defer cancel()
+
+	opts := OptionsForTask(taskFromContext(ctx))
+	maxTokens := opts.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = anthropicMaxTokens
+	}
+	body, err := json.Marshal(anthropicRequest{
+		Model:       model,
+		System:      systemPrompt,
+		Messages:    []openaiMessage{{Role: "user", Content: userPrompt}},
+		MaxTokens:   maxTokens,
+		Temperature: opts.Temperature,
+		Stream:      onToken != nil,
+	})
+	if err != nil {
+		return "", fmt.Errorf("generate: marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/v1/messages", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("generate: create request: %w", err)
+	}
+	req.Header = b.headers()
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("generate: request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("generate: status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var text string
+	if onToken != nil {
+		text, err = readAnthropicStream(resp.Body, onToken)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		var result anthropicResponse
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			return "", fmt.Errorf("generate: decode response: %w", err)
+		}
+		if result.Error != nil {
+			return "", fmt.Errorf("generate: API error: %s", result.Error.Message)
+		}
+		var sb strings.Builder
+		for _, block := range result.Content {
+			if block.Type == "text" {
+				sb.WriteString(block.Text)
+			}
+		}
+		text = sb.String()
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return "", fmt.Errorf("generate: empty response")
+	}
+	return text, nil
+}
+
+// readAnthropicStream reads the Server-Sent Events of a streamed message,
+// passing each text delta to onToken and returning the full text.
+func readAnthropicStream(r io.Reader, onToken TokenFunc) (string, error) {
+	var sb strings.Builder
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		var event anthropicStreamEvent
+		if err := json.Unmarshal([]byte(strings.TrimSpace(strings.TrimPrefix(line, "data:"))), &event); err != nil {
+			continue
+		}
+		switch event.Type {
+		case "error":
+			if event.Error != nil {
+				return "", fmt.Errorf("generate: API error: %s", event.Error.Message)
+			}
+			return "", fmt.Errorf("generate: API error")
+		case "message_stop":
+			return sb.String(), nil
+		case "content_block_delta":
+			if event.Delta.Text == "" {
+				continue
+			}
+			sb.WriteString(event.Delta.Text)
+			if err := onToken(event.Delta.Text); err != nil {
+				return "", fmt.Errorf("generate: stream callback: %w", err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil && sb.Len() == 0 {
+		return "", fmt.Errorf("generate: read stream: %w", err)
+	}
+	return sb.String(), nil
+}
+
+func (b *anthropicBackend) embed(context.Context, string, string) ([]float32, error) {
+	return nil, fmt.Errorf("embed: the anthropic provider has no embeddings API")
+}
+
+func (b *anthropicBackend) embedBatch(context.Context, string, []string) ([][]float32, error) {
+	return nil, fmt.Errorf("embed batch: the anthropic provider has no embeddings API")
+}
@@ -0,0 +1,91 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"sync/atomic"
+)
+
+// Cached operations.
+const (
+	cacheSummarize = "summarize"
+	cacheClassify  = "classify"
+	cacheEnrich    = "enrich"
+	cacheEmbed     = "embed"
+)
+
+// CacheStore persists AI results as JSON, keyed on the operation, the model
+// and a hash of the prompt and input text.
+type CacheStore interface {
+	GetAICache(ctx context.Context, operation, model, hash string) ([]byte, bool, error)
+	PutAICache(ctx context.Context, operation, model, hash string, result []byte) error
+}
+
+// cacheHolder wraps the installed store, since atomic.Pointer needs a
+// concrete type.
+type cacheHolder struct {
+	store CacheStore
+}
+
+var cache atomic.Pointer[cacheHolder]
+
+// SetCache installs the store Summarize, Classify, Enrich and Embed keep
+// their results in, so unchanged content is not sent to the model again.
+// nil removes it.
+func SetCache(store CacheStore) {
+	if store == nil {
+		cache.Store(nil)
+		return
+	}
+	cache.Store(&cacheHolder{store: store})
+}
+
+// contentHash returns the hex SHA-256 of the prompt and input of a call.
+// The prompt is part of the key, so editing it (or the tag taxonomy it
+// lists) misses the cache instead of returning stale results.
+func contentHash(prompt, input string) string {
+	h := sha256.New()
+	h.Write([]byte(prompt))
+	h.Write([]byte{0})
+	h.Write([]byte(input))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// cached returns the cached result of operation for model and the prompt
+// and input, or runs call on b and caches what it returns. Errors are not
+// cached, and a failing store only costs the cache: the call still runs.
+// The fake backend is never cached, so its canned output can't be served
+// once a real provider runs under the same model name.
+func cached[T any](ctx context.Context, b backend, operation, model, prompt, input string, call func() (T, error)) (T, error) {
+	holder := cache.Load()
+	if _, fake := b.(fakeBackend); holder == nil || fake {
+		return call()
+	}
+	hash := contentHash(prompt, input)
+
+	raw, ok, err := holder.store.GetAICache(ctx, operation, model, hash)
+	if err != nil {
+		slog.Warn("ai cache: get", "op", operation, "err", err)
+	} else if ok {
+		var result T
+		err := json.Unmarshal(raw, &result)
+		if err == nil {
+			return result, nil
+		}
+		slog.Warn("ai cache: decode", "op", operation, "err", err)
+	}
+
+	result, err := call()
+	if err != nil {
+		return result, err
+	}
+	if raw, err := json.Marshal(result); err != nil {
+		slog.Warn("ai cache: encode", "op", operation, "err", err)
+	} else if err := holder.store.PutAICache(ctx, operation, model, hash, raw); err != nil {
+		slog.Warn("ai cache: put", "op", operation, "err", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,169 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// Enrichment is an article's summary, topic tags and entities, as produced
+// by Enrich.
+type Enrichment struct {
+	Summary  string            `json:"summary"`
+	Tags     []string          `json:"tags"`
+	Entities ExtractedEntities `json:"entities"`
+}
+
+// enrichReply is the JSON object the enrichment prompt asks for.
+type enrichReply struct {
+	Summary       string   `json:"summary"`
+	Tags          []string `json:"tags"`
+	People        []string `json:"people"`
+	Organizations []string `json:"organizations"`
+	Places        []string `json:"places"`
+}
+
+// errPartialEnrichment marks a fallback enrichment whose tag or entity call
+// failed. It keeps the result out of the cache; Enrich still returns it.
+var errPartialEnrichment = errors.New("partial enrichment")
+
+// Enrich summarizes, tags and extracts the entities of an article in a
+// single generation that must return a JSON object. When the reply can't be
+// parsed, or its summary is empty or commentary, it falls back to separate
+// Summarize, Classify and ExtractEntities calls. An error means no summary
+// could be produced; a failed tag or entity call in the fallback only leaves
+// those fields empty, and that result is not cached.
+func (c *Client) Enrich(ctx context.Context, text string) (*Enrichment, error) {
+	tags := currentTaxonomy(ctx)
+	prompt := enrichPrompt(tags)
+	var partial *Enrichment
+	e, err := cached(ctx, c.gen, cacheEnrich, c.instructModel, prompt, text, func() (*Enrichment, error) {
+		resp, err := c.generate(withJSONFormat(WithTask(ctx, TaskExtract)), prompt, text)
+		if err != nil {
+			return nil, err
+		}
+		e, err := parseEnrichment(resp, tags)
+		if err == nil {
+			return e, nil
+		}
+		slog.Debug("ai: structured enrichment unusable, falling back to separate calls", "err", err)
+		e, complete, err := c.enrichSeparately(ctx, text)
+		if err == nil && !complete {
+			partial = e
+			return nil, errPartialEnrichment
+		}
+		return e, err
+	})
+	if errors.Is(err, errPartialEnrichment) {
+		return partial, nil
+	}
+	return e, err
+}
+
+// enrichSeparately is the three-call enrichment path. complete is false when
+// the tag or entity call failed and its fields were left empty.
+func (c *Client) enrichSeparately(ctx context.Context, text string) (e *Enrichment, complete bool, err error) {
+	summary, err := c.Summarize(ctx, text)
+	if err != nil {
+		return nil, false, err
+	}
+	e = &Enrichment{Summary: summary}
+	complete = true
+
+	if e.Tags, err = c.Classify(ctx, text); err != nil {
+		slog.Warn("ai: enrich: classify", "err", err)
+		complete = false
+	}
+	if entities, err := c.ExtractEntities(ctx, text); err != nil {
+		slog.Warn("ai: enrich: extract entities", "err", err)
+		complete = false
+	} else {
+		e.Entities = *entities
+	}
+	return e, complete, nil
+}
+
+// parseEnrichment decodes an enrichment reply, tolerating text around the
+// JSON object, and validates its summary and tags.
+func parseEnrichment(resp string, allowedTags map[string]string) (*Enrichment, error) {
+	var reply enrichReply
+	resp = strings.TrimSpace(resp)
+	if err := json.Unmarshal([]byte(resp), &reply); err != nil {
+		start, end := strings.Index(resp, "{"), strings.LastIndex(resp, "}")
+		if start == -1 || end <= start {
+			return nil, fmt.Errorf("parse enrichment: no JSON object")
+		}
+		if err := json.Unmarshal([]byte(resp[start:end+1]), &reply); err != nil {
+			return nil, fmt.Errorf("parse enrichment: %w", err)
+		}
+	}
+
+	summary := cleanAIResponse(reply.Summary)
+	if summary == "" {
+		return nil, fmt.Errorf("parse enrichment: empty or invalid summary")
+	}
+	return &Enrichment{
+		Summary: summary,
+		Tags:    parseAndValidateTags(strings.Join(reply.Tags, ","), allowedTags),
+		Entities: ExtractedEntities{
+			People:        nonEmpty(reply.People),
+			Organizations: nonEmpty(reply.Organizations),
+			Places:        nonEmpty(reply.Places),
+		},
+	}, nil
+}
+
+// nonEmpty returns the trimmed, non-empty strings of list.
+func nonEmpty(list []string) []string {
+	var out []string
+	for _, s := range list {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// enrichPrompt builds the single-call enrichment prompt for the tag
+// taxonomy.
+func enrichPrompt(tags map[string]string) string {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString(`You analyze news articles. Return a JSON object with these keys:
+- "summary": a 2-3 sentence factual summary, in the SAME language as the article
+- "tags": 1-3 topic tags, ONLY from the allowed tags below
+- "people": array of person names mentioned
+- "organizations": array of organization/company/agency names
+- "places": array of geographic locations
+
+ALLOWED TAGS: ` + strings.Join(names, ", ") + "\n")
+
+	var described []string
+	for _, name := range names {
+		if desc := strings.TrimSpace(tags[name]); desc != "" {
+			described = append(described, "- "+name+": "+desc)
+		}
+	}
+	if len(described) > 0 {
+		b.WriteString("\nTAG NOTES:\n" + strings.Join(described, "\n") + "\n")
+	}
+
+	b.WriteString(`
+RULES:
+- Output ONLY valid JSON, nothing else
+- Entity arrays can be empty; use the names as they appear in the text
+- The summary must NOT explain what you are doing or add commentary
+- If the text is short, summarize what is there
+
+Example: {"summary": "...", "tags": ["<tag>"], "people": ["<person>"], "organizations": ["<organization>"], "places": ["<place>"]}`)
+	return b.String()
+}
@@ -0,0 +1,114 @@
+package ai
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseEnrichment(t *testing.T) {
+	allowed := map[string]string{"government": "", "economy": "", "health": ""}
+
+	tests := []struct {
+		name    string
+		resp    string
+		want    *Enrichment
+		wantErr bool
+	}{
+		{
+			name: "plain JSON",
+			resp: `{"summary": "El Senado aprobó el presupuesto.", "tags": ["government", "economy"],
+				"people": ["Ana Ruiz"], "organizations": ["Senado"], "places": ["Ponce"]}`,
+			want: &Enrichment{
+				Summary: "El Senado aprobó el presupuesto.",
+				Tags:    []string{"government", "economy"},
+				Entities: ExtractedEntities{
+					People:        []string{"Ana Ruiz"},
+					Organizations: []string{"Senado"},
+					Places:        []string{"Ponce"},
+				},
+			},
+		},
+		{
+			name: "text around the object",
+			resp: "Here is the JSON:\n```json\n{\"summary\": \"A short summary.\", \"tags\": [\"health\"]}\n```",
+			want: &Enrichment{Summary: "A short summary.", Tags: []string{"health"}},
+		},
+		{
+			name: "tags outside the taxonomy are dropped, the rest normalized and deduplicated",
+			resp: `{"summary": "Resumen.", "tags": ["Government", "sports", "1. government", "economy"]}`,
+			want: &Enrichment{Summary: "Resumen.", Tags: []string{"government", "economy"}},
+		},
+		{
+			name: "blank entities are dropped and names trimmed",
+			resp: `{"summary": "Resumen.", "people": ["  Ana Ruiz ", "", "   "], "organizations": [], "places": [" Ponce"]}`,
+			want: &Enrichment{
+				Summary:  "Resumen.",
+				Entities: ExtractedEntities{People: []string{"Ana Ruiz"}, Places: []string{"Ponce"}},
+			},
+		},
+		{
+			name: "summary quotes are stripped",
+			resp: `{"summary": "\"Resumen entre comillas.\""}`,
+			want: &Enrichment{Summary: "Resumen entre comillas."},
+		},
+		{
+			name:    "empty summary",
+			resp:    `{"summary": "  ", "tags": ["government"]}`,
+			wantErr: true,
+		},
+		{
+			name:    "commentary instead of a summary",
+			resp:    `{"summary": "I cannot summarize this article.", "tags": []}`,
+			wantErr: true,
+		},
+		{
+			name:    "no JSON object",
+			resp:    "The article is about the budget.",
+			wantErr: true,
+		},
+		{
+			name:    "broken JSON",
+			resp:    `{"summary": "Resumen.", "tags": [}`,
+			wantErr: true,
+		},
+		{
+			name:    "wrong field type",
+			resp:    `{"summary": ["not", "a", "string"]}`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEnrichment(tt.resp, allowed)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseEnrichment = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseEnrichment: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseEnrichment = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnrichPrompt(t *testing.T) {
+	prompt := enrichPrompt(map[string]string{"health": "", "economy": "Budget and jobs", "government": ""})
+
+	for _, want := range []string{
+		"ALLOWED TAGS: economy, government, health\n",
+		"TAG NOTES:\n- economy: Budget and jobs\n",
+	} {
+		if !strings.Contains(prompt, want) {
+			t.Errorf("prompt is missing %q:\n%s", want, prompt)
+		}
+	}
+	if strings.Contains(prompt, "- health:") {
+		t.Errorf("prompt lists a note for a tag without a description:\n%s", prompt)
+	}
+}
@@ -0,0 +1,184 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// GrantPosting is the structured who/what/where/when of a grant or funding
+// opportunity posting. Anything the posting does not state is nil or empty.
+type GrantPosting struct {
+	Funder           string
+	EligibleEntities []string
+	AwardCeiling     *int64 // largest award per recipient, whole US dollars
+	AwardFloor       *int64 // smallest award per recipient, whole US dollars
+	MatchRequired    *bool
+	MatchRequirement string // e.g. "25% cost share"
+	Deadline         *time.Time
+}
+
+const (
+	maxEligibleEntities = 10
+	maxAwardAmount      = 100_000_000_000 // larger amounts are misreadings
+)
+
+// ExtractGrantPosting asks the LLM for the funder, eligible applicants, award
+// range, match requirement and application deadline of a grant posting.
+// Amounts and dates are validated: unparseable or implausible values are
+// dropped rather than stored as free text. Returns an empty posting when the
+// response cannot be parsed.
+func (c *Client) ExtractGrantPosting(ctx context.Context, text string) (*GrantPosting, error) {
+	systemPrompt := `Extract the key facts from this grant or funding opportunity posting. The posting may be written in Spanish or English.
+
+Return a JSON object: {"funder": "...", "eligible_entities": ["..."], "award_ceiling": 500000, "award_floor": 50000, "match_required": true, "match_requirement": "...", "deadline": "YYYY-MM-DD"}
+
+RULES:
+- Output ONLY valid JSON, nothing else
+- "funder" is the agency, foundation or company giving the money, otherwise ""
+- "eligible_entities" lists who may apply, in a few words each (e.g. "municipalities", "nonprofits", "universities"); at most 10; [] if not stated
+- "award_ceiling" and "award_floor" are the largest and smallest award per recipient in US dollars as plain numbers, not the program's total funding; a single per-award amount goes in "award_ceiling"; null if not stated
+- "match_required" is true if applicants must provide matching funds or a cost share, false if the posting says none is required, null if not stated
+- "match_requirement" describes the match in a few words (e.g. "25% cost share"), otherwise ""
+- "deadline" is the application deadline as YYYY-MM-DD; null if not stated
+- Never guess: use null or "" for anything the posting does not state`
+
+	resp, err := c.generate(WithTask(ctx, TaskExtract), systemPrompt, text)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Funder           string   `json:"funder"`
+		EligibleEntities []string `json:"eligible_entities"`
+		AwardCeiling     any      `json:"award_ceiling"`
+		AwardFloor       any      `json:"award_floor"`
+		MatchRequired    *bool    `json:"match_required"`
+		MatchRequirement string   `json:"match_requirement"`
+		Deadline         string   `json:"deadline"`
+	}
+	resp = strings.TrimSpace(resp)
+	if err := json.Unmarshal([]byte(resp), &result); err != nil {
+		start, end := strings.Index(resp, "{"), strings.LastIndex(resp, "}")
+		if start == -1 || end <= start || json.Unmarshal([]byte(resp[start:end+1]), &result) != nil {
+			return &GrantPosting{}, nil
+		}
+	}
+
+	p := &GrantPosting{
+		Funder:           truncateField(result.Funder, 200),
+		AwardCeiling:     parseAwardAmount(result.AwardCeiling),
+		AwardFloor:       parseAwardAmount(result.AwardFloor),
+		MatchRequired:    result.MatchRequired,
+		MatchRequirement: truncateField(result.MatchRequirement, 200),
+		Deadline:         parseDeadline(result.Deadline, time.Now()),
+	}
+	if p.AwardCeiling == nil {
+		p.AwardCeiling, p.AwardFloor = p.AwardFloor, nil
+	}
+	if p.AwardFloor != nil && *p.AwardFloor > *p.AwardCeiling {
+		p.AwardCeiling, p.AwardFloor = p.AwardFloor, p.AwardCeiling
+	}
+
+	seen := make(map[string]bool)
+	for _, e := range result.EligibleEntities {
+		e = truncateField(e, 100)
+		if e == "" || seen[strings.ToLower(e)] {
+			continue
+		}
+		seen[strings.ToLower(e)] = true
+		p.EligibleEntities = append(p.EligibleEntities, e)
+		if len(p.EligibleEntities) == maxEligibleEntities {
+			break
+		}
+	}
+	return p, nil
+}
+
+// truncateField trims s to one line of at most n bytes.
+func truncateField(s string, n int) string {
+	s = strings.TrimSpace(strings.SplitN(strings.TrimSpace(s), "\n", 2)[0])
+	if len(s) > n {
+		s = strings.TrimSpace(s[:n])
+	}
+	return s
+}
+
+// awardMultipliers scale amounts written with a word or suffix, e.g.
+// "$1.5 million" or "500K". Longer words come first so "millones" is not
+// read as "mil".
+var awardMultipliers = []struct {
+	suffix string
+	factor float64
+}{
+	{"billion", 1e9}, {"millones", 1e6}, {"million", 1e6}, {"millón", 1e6}, {"millon", 1e6},
+	{"thousand", 1e3}, {"mil", 1e3}, {"mm", 1e6}, {"b", 1e9}, {"m", 1e6}, {"k", 1e3},
+}
+
+// parseAwardAmount reads a dollar amount given by the model as a number or
+// as text such as "$250,000" or "1.5 million". Returns nil for missing,
+// non-positive or implausibly large amounts.
+func parseAwardAmount(v any) *int64 {
+	var amount float64
+	switch v := v.(type) {
+	case float64:
+		amount = v
+	case string:
+		s := strings.ToLower(strings.TrimSpace(v))
+		for _, cut := range []string{"us$", "usd", "$", "dollars", "dólares", "dolares"} {
+			s = strings.ReplaceAll(s, cut, "")
+		}
+		s = strings.TrimSpace(s)
+		factor := 1.0
+		for _, m := range awardMultipliers {
+			if rest, ok := strings.CutSuffix(s, m.suffix); ok {
+				s, factor = strings.TrimSpace(rest), m.factor
+				break
+			}
+		}
+		// "1,5 millones": with a multiplier, a lone comma is a decimal mark.
+		if factor > 1 && strings.Count(s, ",") == 1 && !strings.Contains(s, ".") {
+			s = strings.Replace(s, ",", ".", 1)
+		}
+		s = strings.ReplaceAll(s, ",", "")
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil
+		}
+		amount = f * factor
+	default:
+		return nil
+	}
+	if amount < 1 || amount > maxAwardAmount || math.IsNaN(amount) {
+		return nil
+	}
+	n := int64(math.Round(amount))
+	return &n
+}
+
+// deadlineLayouts are the date formats accepted for a grant deadline; the
+// prompt asks for the first.
+var deadlineLayouts = []string{"2006-01-02", "01/02/2006", "January 2, 2006", "Jan 2, 2006", "2 January 2006"}
+
+// parseDeadline reads a deadline date, rejecting dates before 2000 or more
+// than ten years after now.
+func parseDeadline(s string, now time.Time) *time.Time {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	for _, layout := range deadlineLayouts {
+		t, err := time.Parse(layout, s)
+		if err != nil {
+			continue
+		}
+		if t.Year() < 2000 || t.After(now.AddDate(10, 0, 0)) {
+			return nil
+		}
+		return &t
+	}
+	return nil
+}
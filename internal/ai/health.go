@@ -0,0 +1,91 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// CheckModels verifies the providers are reachable and serve the instruct
+// and embedding models. It returns an error naming any model that is missing.
+func (c *Client) CheckModels(ctx context.Context) error {
+	var missing []string
+	for _, check := range []struct {
+		b     backend
+		model string
+	}{{c.gen, c.instructModel}, {c.emb, c.embedModel}} {
+		if check.model == "" {
+			continue
+		}
+		available, err := check.b.models(ctx)
+		if err != nil {
+			return fmt.Errorf("check models: %w", err)
+		}
+		if available == nil || available[check.model] || available[check.model+":latest"] {
+			continue
+		}
+		missing = append(missing, check.model)
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("check models: not available: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// listModels fetches a provider's model list from url. Ollama lists
+// {"models":[{"name"}]}, OpenAI-compatible and Anthropic APIs {"data":[{"id"}]}.
+func listModels(ctx context.Context, hc *http.Client, url string, header http.Header) (map[string]bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("decode response: %w", err)
+	}
+	available := make(map[string]bool)
+	for _, m := range result.Models {
+		available[m.Name] = true
+	}
+	for _, m := range result.Data {
+		available[m.ID] = true
+	}
+	return available, nil
+}
+
+func (b *ollamaBackend) models(ctx context.Context) (map[string]bool, error) {
+	return listModels(ctx, b.httpClient, b.baseURL+"/api/tags", nil)
+}
+
+func (b *openaiBackend) models(ctx context.Context) (map[string]bool, error) {
+	return listModels(ctx, b.httpClient, b.baseURL+"/v1/models", http.Header{"Authorization": {"Bearer " + b.apiKey}})
+}
+
+func (b *anthropicBackend) models(ctx context.Context) (map[string]bool, error) {
+	return listModels(ctx, b.httpClient, b.baseURL+"/v1/models?limit=1000", b.headers())
+}
@@ -10,46 +10,70 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/Saul-Punybz/folio/internal/config"
+	"github.com/Saul-Punybz/folio/internal/coverage"
 )
 
 const (
-	generateTimeout  = 120 * time.Second
-	embeddingTimeout = 30 * time.Second
+	generateTimeout       = 120 * time.Second
+	embeddingTimeout      = 30 * time.Second
+	batchEmbeddingTimeout = 120 * time.Second
 )
 
-// OllamaClient is an HTTP client that supports both the Ollama API and
-// OpenAI-compatible APIs (OpenAI, Groq, Together, OpenRouter, etc.).
+// Client is the AI client the pipeline uses: the prompts for summaries, tags,
+// sentiment and extraction on top of a backend for the provider's wire
+// protocol. It speaks the Ollama API, OpenAI-compatible APIs (OpenAI, Groq,
+// Together, OpenRouter, etc.) and the Anthropic Messages API, and embeddings
+// may come from a different provider than generation.
 //
-// Set AI_PROVIDER=openai and AI_API_KEY=... to use cloud providers.
-type OllamaClient struct {
-	baseURL       string
-	apiKey        string // for OpenAI-compatible providers
-	protocol      string // "ollama" or "openai"
+// Set AI_PROVIDER=openai and AI_API_KEY=... to use cloud providers, or
+// AI_PROVIDER=fake for deterministic offline responses.
+type Client struct {
+	gen           backend // text generation
+	emb           backend // embeddings
 	instructModel string
 	embedModel    string
-	httpClient    *http.Client
 }
 
 // NewClient creates a new AI client with the Ollama protocol.
-func NewClient(baseURL, instructModel, embedModel string) *OllamaClient {
-	return &OllamaClient{
-		baseURL:       strings.TrimRight(baseURL, "/"),
-		protocol:      "ollama",
-		instructModel: instructModel,
-		embedModel:    embedModel,
-		httpClient: &http.Client{
-			Timeout: generateTimeout,
-		},
-	}
+func NewClient(baseURL, instructModel, embedModel string) *Client {
+	b := newOllamaBackend(baseURL)
+	return &Client{gen: b, emb: b, instructModel: instructModel, embedModel: embedModel}
 }
 
-// NewFromConfig creates the appropriate AI client based on the provider string.
-// provider="ollama" uses Ollama, provider="openai" uses OpenAI-compatible API.
-func NewFromConfig(provider, host, apiKey, instructModel, embedModel string) *OllamaClient {
-	if provider == "openai" {
-		return NewOpenAIClient(host, apiKey, instructModel, embedModel)
+// NewFromConfig creates the client for the configured providers:
+// AI_PROVIDER for generation and AI_EMBED_PROVIDER (default: the same) for
+// embeddings, each "ollama", "openai", "anthropic" or "fake". Anthropic has
+// no embeddings API, so it needs a different embedding provider.
+func NewFromConfig(cfg config.AIConfig) (*Client, error) {
+	gen, err := newBackend(cfg.Provider, cfg.Host, cfg.APIKey)
+	if err != nil {
+		return nil, err
+	}
+
+	embedProvider := cfg.EmbedProvider
+	if embedProvider == "" {
+		embedProvider = cfg.Provider
 	}
-	return NewClient(host, instructModel, embedModel)
+	if embedProvider == "anthropic" {
+		return nil, fmt.Errorf("ai: the anthropic provider has no embeddings, set AI_EMBED_PROVIDER")
+	}
+	emb := gen
+	if embedProvider != cfg.Provider || cfg.EmbedHost != "" {
+		host, apiKey := cfg.EmbedHost, cfg.EmbedAPIKey
+		if host == "" {
+			host = cfg.Host
+		}
+		if apiKey == "" && embedProvider == cfg.Provider {
+			apiKey = cfg.APIKey
+		}
+		if emb, err = newBackend(embedProvider, host, apiKey); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Client{gen: gen, emb: emb, instructModel: cfg.InstructModel, embedModel: cfg.EmbedModel}, nil
 }
 
 // NewOpenAIClient creates a new AI client using the OpenAI-compatible API.
@@ -62,26 +86,20 @@ func NewFromConfig(provider, host, apiKey, instructModel, embedModel string) *Ol
 //	Together:    https://api.together.xyz
 //	OpenRouter:  https://openrouter.ai/api
 //	Mistral:     https://api.mistral.ai
-func NewOpenAIClient(baseURL, apiKey, instructModel, embedModel string) *OllamaClient {
-	return &OllamaClient{
-		baseURL:       strings.TrimRight(baseURL, "/"),
-		apiKey:        apiKey,
-		protocol:      "openai",
-		instructModel: instructModel,
-		embedModel:    embedModel,
-		httpClient: &http.Client{
-			Timeout: generateTimeout,
-		},
-	}
+func NewOpenAIClient(baseURL, apiKey, instructModel, embedModel string) *Client {
+	b := newOpenAIBackend(baseURL, apiKey)
+	return &Client{gen: b, emb: b, instructModel: instructModel, embedModel: embedModel}
 }
 
 // ── Ollama protocol types ────────────────────────────────────
 
 type generateRequest struct {
-	Model  string `json:"model"`
-	System string `json:"system,omitempty"`
-	Prompt string `json:"prompt"`
-	Stream bool   `json:"stream"`
+	Model   string         `json:"model"`
+	System  string         `json:"system,omitempty"`
+	Prompt  string         `json:"prompt"`
+	Stream  bool           `json:"stream"`
+	Format  string         `json:"format,omitempty"`
+	Options map[string]any `json:"options,omitempty"`
 }
 
 type generateResponse struct {
@@ -98,45 +116,17 @@ type embeddingResponse struct {
 	Embedding []float32 `json:"embedding"`
 }
 
-// ── OpenAI protocol types ────────────────────────────────────
-
-type openaiMessage struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
-}
-
-type openaiChatRequest struct {
-	Model    string          `json:"model"`
-	Messages []openaiMessage `json:"messages"`
+type batchEmbeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
 }
 
-type openaiChatResponse struct {
-	Choices []struct {
-		Message struct {
-			Content string `json:"content"`
-		} `json:"message"`
-	} `json:"choices"`
-	Error *struct {
-		Message string `json:"message"`
-	} `json:"error,omitempty"`
-}
-
-type openaiEmbedRequest struct {
-	Model string `json:"model"`
-	Input string `json:"input"`
-}
-
-type openaiEmbedResponse struct {
-	Data []struct {
-		Embedding []float32 `json:"embedding"`
-	} `json:"data"`
-	Error *struct {
-		Message string `json:"message"`
-	} `json:"error,omitempty"`
+type batchEmbeddingResponse struct {
+	Embeddings [][]float32 `json:"embeddings"`
 }
 
 // Summarize asks the LLM to produce a 2-3 sentence summary of the given text.
-func (c *OllamaClient) Summarize(ctx context.Context, text string) (string, error) {
+func (c *Client) Summarize(ctx context.Context, text string) (string, error) {
 	systemPrompt := `You are a news summarizer. Your ONLY job is to output a 2-3 sentence summary.
 
 RULES:
@@ -148,47 +138,35 @@ RULES:
 - Do NOT add commentary, disclaimers, or meta-text
 - If the text is short, summarize what is there`
 
-	summary, err := c.generate(ctx, systemPrompt, text)
-	if err != nil {
-		return "", err
-	}
-
-	// Validate: reject responses that look like AI commentary instead of summaries.
-	summary = cleanAIResponse(summary)
-	if summary == "" {
-		return "", fmt.Errorf("ollama summarize: produced empty or invalid summary")
-	}
-	return summary, nil
-}
-
-// Classify asks the LLM to assign 1-3 topic tags from a fixed taxonomy.
-func (c *OllamaClient) Classify(ctx context.Context, text string) ([]string, error) {
-	systemPrompt := `You are a strict tag classifier. You receive article text and output ONLY comma-separated tags.
-
-ALLOWED TAGS: politics, economy, health, education, infrastructure, environment, crime, grants, federal, legislation, government, technology, culture, sports
-
-EXAMPLES:
-Article about governor signing a bill → politics, legislation
-Article about hospital funding cuts → health, economy
-Article about federal grants for schools → grants, education, federal
-Article about road construction project → infrastructure
-Article about arrests in Bayamón → crime
-Article about tech startup in San Juan → technology, economy
-
-RULES:
-- Output ONLY tags from the list above, comma-separated
-- Pick 1-3 tags that best fit
-- NO explanations, NO sentences, NO commentary
-- NEVER output anything except tag names separated by commas
-- If unsure, pick the closest match`
+	return cached(ctx, c.gen, cacheSummarize, c.instructModel, systemPrompt, text, func() (string, error) {
+		summary, err := c.generate(WithTask(ctx, TaskSummarize), systemPrompt, text)
+		if err != nil {
+			return "", err
+		}
 
-	resp, err := c.generate(ctx, systemPrompt, text)
-	if err != nil {
-		return nil, err
-	}
+		// Validate: reject responses that look like AI commentary instead of summaries.
+		summary = cleanAIResponse(summary)
+		if summary == "" {
+			return "", fmt.Errorf("ollama summarize: produced empty or invalid summary")
+		}
+		return summary, nil
+	})
+}
+
+// Classify asks the LLM to assign 1-3 topic tags from the tag taxonomy (see
+// SetTaxonomySource).
+func (c *Client) Classify(ctx context.Context, text string) ([]string, error) {
+	tags := currentTaxonomy(ctx)
+	prompt := classifyPrompt(tags)
+	return cached(ctx, c.gen, cacheClassify, c.instructModel, prompt, text, func() ([]string, error) {
+		resp, err := c.generate(WithTask(ctx, TaskClassify), prompt, text)
+		if err != nil {
+			return nil, err
+		}
 
-	// Clean and validate tags against the allowed set.
-	return parseAndValidateTags(resp), nil
+		// Clean and validate tags against the taxonomy.
+		return parseAndValidateTags(resp, tags), nil
+	})
 }
 
 // ExtractedEntities holds categorized entities extracted from article text by
@@ -201,7 +179,7 @@ type ExtractedEntities struct {
 
 // ExtractEntities asks the LLM to extract key people, organizations, and places
 // from article text, returning them in categorized form.
-func (c *OllamaClient) ExtractEntities(ctx context.Context, text string) (*ExtractedEntities, error) {
+func (c *Client) ExtractEntities(ctx context.Context, text string) (*ExtractedEntities, error) {
 	systemPrompt := `Extract key entities from this article text. Return a JSON object with these keys:
 - "people": array of person names mentioned
 - "organizations": array of organization/company/agency names
@@ -215,7 +193,7 @@ RULES:
 
 Example: {"people": ["Juan García", "María López"], "organizations": ["Senado de PR"], "places": ["San Juan"]}`
 
-	resp, err := c.generate(ctx, systemPrompt, text)
+	resp, err := c.generate(WithTask(ctx, TaskExtract), systemPrompt, text)
 	if err != nil {
 		return nil, err
 	}
@@ -239,7 +217,7 @@ Example: {"people": ["Juan García", "María López"], "organizations": ["Senado
 
 // ClassifySentiment asks the LLM to classify the overall sentiment of the text
 // as "positive", "neutral", or "negative". Returns "neutral" if parsing fails.
-func (c *OllamaClient) ClassifySentiment(ctx context.Context, text string) (string, error) {
+func (c *Client) ClassifySentiment(ctx context.Context, text string) (string, error) {
 	systemPrompt := `Classify the overall sentiment of this article text.
 
 RULES:
@@ -248,7 +226,7 @@ RULES:
 - Do NOT add any other text
 - If unsure, output "neutral"`
 
-	resp, err := c.generate(ctx, systemPrompt, text)
+	resp, err := c.generate(WithTask(ctx, TaskClassify), systemPrompt, text)
 	if err != nil {
 		return "neutral", err
 	}
@@ -271,141 +249,259 @@ RULES:
 	}
 }
 
-// Embed generates a vector embedding for the given text using the embedding model.
-func (c *OllamaClient) Embed(ctx context.Context, text string) ([]float32, error) {
-	if c.protocol == "openai" {
-		return c.embedOpenAI(ctx, text)
-	}
-	return c.embedOllama(ctx, text)
-}
+// ClassifyScope asks the LLM whether an article (in Spanish or English) is
+// local news about the covered jurisdiction, federal news affecting it, or
+// diaspora coverage. Returns "local" if parsing fails.
+func (c *Client) ClassifyScope(ctx context.Context, text string) (string, error) {
+	place := coverage.Current().Name
+	systemPrompt := `Classify the geographic scope of this news article about ` + place + `. The article may be written in Spanish or English.
 
-func (c *OllamaClient) embedOllama(ctx context.Context, text string) ([]float32, error) {
-	ctx, cancel := context.WithTimeout(ctx, embeddingTimeout)
-	defer cancel()
+CATEGORIES:
+- "local": events, government, or people in ` + place + ` (municipalities, the local government, local business)
+- "federal": US federal government actions that affect ` + place + ` (Congress, the White House, federal agencies, FEMA, oversight boards, federal courts)
+- "diaspora": people from ` + place + ` living elsewhere in the United States or abroad, and their communities
 
-	reqBody := embeddingRequest{
-		Model:  c.embedModel,
-		Prompt: text,
-	}
+RULES:
+- Output ONLY one word: "local", "federal", or "diaspora"
+- Do NOT explain your reasoning
+- If unsure, output "local"`
 
-	body, err := json.Marshal(reqBody)
+	resp, err := c.generate(WithTask(ctx, TaskClassify), systemPrompt, text)
 	if err != nil {
-		return nil, fmt.Errorf("embed: marshal request: %w", err)
+		return "local", err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/embeddings", bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("embed: create request: %w", err)
+	scope := strings.ToLower(strings.TrimSpace(resp))
+	switch scope {
+	case "local", "federal", "diaspora":
+		return scope, nil
+	default:
+		for _, valid := range []string{"diaspora", "federal", "local"} {
+			if strings.Contains(scope, valid) {
+				return valid, nil
+			}
+		}
+		return "local", nil
 	}
-	req.Header.Set("Content-Type", "application/json")
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("embed: request: %w", err)
-	}
-	defer resp.Body.Close()
+// TriageSuggestion is the action SuggestTriage proposes for an inbox item.
+type TriageSuggestion struct {
+	Action string `json:"action"` // "save" or "trash"
+	Reason string `json:"reason"`
+}
 
-	if resp.StatusCode != http.StatusOK {
-		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
-		return nil, fmt.Errorf("embed: status %d: %s", resp.StatusCode, string(respBody))
-	}
+// SuggestTriage asks the LLM whether an inbox item should be saved or
+// trashed. profile describes the newsroom's interests: titles it recently
+// saved and trashed and the organizations it watches. Returns nil when the
+// response cannot be parsed into a valid action.
+func (c *Client) SuggestTriage(ctx context.Context, profile, article string) (*TriageSuggestion, error) {
+	systemPrompt := `You help a news analyst triage their inbox. Decide whether the article should be kept ("save") or discarded ("trash"), judging by what the analyst kept and discarded before and by the organizations they watch. The article may be written in Spanish or English.
 
-	var result embeddingResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("embed: decode response: %w", err)
+` + profile + `
+
+Return a JSON object: {"action": "save", "reason": "..."}
+
+RULES:
+- Output ONLY valid JSON, nothing else
+- "action" is "save" or "trash"
+- "reason" is ONE short sentence in Spanish explaining the choice
+- Save anything that mentions a watched organization
+- If unsure, choose "save"`
+
+	resp, err := c.generate(WithTask(ctx, TaskExtract), systemPrompt, article)
+	if err != nil {
+		return nil, err
 	}
 
-	if len(result.Embedding) == 0 {
-		return nil, fmt.Errorf("embed: empty embedding returned")
+	var result TriageSuggestion
+	resp = strings.TrimSpace(resp)
+	if err := json.Unmarshal([]byte(resp), &result); err != nil {
+		start, end := strings.Index(resp, "{"), strings.LastIndex(resp, "}")
+		if start == -1 || end <= start || json.Unmarshal([]byte(resp[start:end+1]), &result) != nil {
+			return nil, nil
+		}
+	}
+	result.Action = strings.ToLower(strings.TrimSpace(result.Action))
+	result.Reason = strings.TrimSpace(strings.SplitN(result.Reason, "\n", 2)[0])
+	if result.Action != "save" && result.Action != "trash" {
+		return nil, nil
 	}
+	return &result, nil
+}
 
-	return result.Embedding, nil
+// Quote is a direct quotation from an article with its attribution.
+type Quote struct {
+	Text    string `json:"quote"`
+	Speaker string `json:"speaker"`
+	Role    string `json:"role,omitempty"` // title or affiliation, if stated
 }
 
-func (c *OllamaClient) embedOpenAI(ctx context.Context, text string) ([]float32, error) {
-	ctx, cancel := context.WithTimeout(ctx, embeddingTimeout)
-	defer cancel()
+// maxQuotes caps the quotes kept per article.
+const maxQuotes = 3
 
-	reqBody := openaiEmbedRequest{
-		Model: c.embedModel,
-		Input: text,
-	}
+// ExtractQuotes asks the LLM for the most quotable direct quotes in an article,
+// with who said them. Quotes that do not appear verbatim in the text are
+// dropped, so paraphrases and invented quotes never reach a brief or draft.
+// Returns an empty slice when the article has no attributable quotes.
+func (c *Client) ExtractQuotes(ctx context.Context, text string) ([]Quote, error) {
+	systemPrompt := `Extract the most newsworthy direct quotes from this news article. The article may be written in Spanish or English.
 
-	body, err := json.Marshal(reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("embed: marshal request: %w", err)
-	}
+Return a JSON object: {"quotes": [{"quote": "...", "speaker": "...", "role": "..."}]}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/embeddings", bytes.NewReader(body))
-	if err != nil {
-		return nil, fmt.Errorf("embed: create request: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+RULES:
+- Output ONLY valid JSON, nothing else
+- At most 3 quotes, most important first
+- Only words the article puts in quotation marks and attributes to a named person or organization
+- Copy each quote EXACTLY as written, without the quotation marks; do not translate, shorten, or fix it
+- "speaker" is the person or organization quoted; "role" is their title or affiliation if the article states it, otherwise ""
+- If there are no attributed direct quotes, output {"quotes": []}`
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.generate(WithTask(ctx, TaskExtract), systemPrompt, text)
 	if err != nil {
-		return nil, fmt.Errorf("embed: request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
-		return nil, fmt.Errorf("embed: status %d: %s", resp.StatusCode, string(respBody))
+	var result struct {
+		Quotes []Quote `json:"quotes"`
 	}
-
-	var result openaiEmbedResponse
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return nil, fmt.Errorf("embed: decode response: %w", err)
+	resp = strings.TrimSpace(resp)
+	if err := json.Unmarshal([]byte(resp), &result); err != nil {
+		start, end := strings.Index(resp, "{"), strings.LastIndex(resp, "}")
+		if start == -1 || end <= start || json.Unmarshal([]byte(resp[start:end+1]), &result) != nil {
+			return []Quote{}, nil
+		}
 	}
 
-	if result.Error != nil {
-		return nil, fmt.Errorf("embed: API error: %s", result.Error.Message)
+	haystack := normalizeQuoteText(text)
+	quotes := make([]Quote, 0, maxQuotes)
+	for _, q := range result.Quotes {
+		q.Text = strings.Trim(strings.TrimSpace(q.Text), "\"“”«»'‘’")
+		q.Speaker = strings.TrimSpace(q.Speaker)
+		q.Role = strings.TrimSpace(q.Role)
+		if len(q.Text) < 20 || q.Speaker == "" {
+			continue
+		}
+		if !strings.Contains(haystack, normalizeQuoteText(q.Text)) {
+			continue
+		}
+		quotes = append(quotes, q)
+		if len(quotes) == maxQuotes {
+			break
+		}
 	}
+	return quotes, nil
+}
 
-	if len(result.Data) == 0 || len(result.Data[0].Embedding) == 0 {
-		return nil, fmt.Errorf("embed: empty embedding returned")
-	}
+// normalizeQuoteText lowercases text and collapses whitespace so quotes can be
+// matched against the article despite line breaks.
+func normalizeQuoteText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// Embed generates a vector embedding for the given text using the embedding model.
+func (c *Client) Embed(ctx context.Context, text string) ([]float32, error) {
+	return cached(ctx, c.emb, cacheEmbed, c.embedModel, "", text, func() ([]float32, error) {
+		return c.emb.embed(ctx, c.embedModel, text)
+	})
+}
 
-	return result.Data[0].Embedding, nil
+// EmbedBatch generates the embeddings of several texts in one request to the
+// embedding backend, in the order given.
+func (c *Client) EmbedBatch(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+	embeddings, err := c.emb.embedBatch(ctx, c.embedModel, texts)
+	if err != nil {
+		return nil, err
+	}
+	if len(embeddings) != len(texts) {
+		return nil, fmt.Errorf("embed batch: got %d embeddings for %d texts", len(embeddings), len(texts))
+	}
+	for i, e := range embeddings {
+		if len(e) == 0 {
+			return nil, fmt.Errorf("embed batch: empty embedding for text %d", i)
+		}
+	}
+	return embeddings, nil
 }
 
 // Generate performs an LLM generation with a custom system prompt and user prompt.
-func (c *OllamaClient) Generate(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+func (c *Client) Generate(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
 	return c.generate(ctx, systemPrompt, userPrompt)
 }
 
 // GenerateWithModel performs an LLM generation using a specific model override.
 // Use this when you need a different model than the default instructModel (e.g.
 // a faster model for interactive chat vs a quality model for batch processing).
-func (c *OllamaClient) GenerateWithModel(ctx context.Context, model, systemPrompt, userPrompt string) (string, error) {
+func (c *Client) GenerateWithModel(ctx context.Context, model, systemPrompt, userPrompt string) (string, error) {
 	return c.generateWithModel(ctx, model, systemPrompt, userPrompt)
 }
 
+// TokenFunc receives each chunk of generated text as it arrives. Returning an
+// error aborts the generation.
+type TokenFunc func(token string) error
+
+// GenerateStream performs an LLM generation with a specific model, invoking
+// onToken for every chunk the provider streams back. The full (trimmed) text is
+// returned once generation finishes.
+func (c *Client) GenerateStream(ctx context.Context, model, systemPrompt, userPrompt string, onToken TokenFunc) (string, error) {
+	if model == "" {
+		model = c.instructModel
+	}
+	if onToken == nil {
+		onToken = func(string) error { return nil }
+	}
+	return c.gen.generate(ctx, model, systemPrompt, userPrompt, onToken)
+}
+
 // generate performs text generation using the default instructModel.
-func (c *OllamaClient) generate(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
+func (c *Client) generate(ctx context.Context, systemPrompt, userPrompt string) (string, error) {
 	return c.generateWithModel(ctx, c.instructModel, systemPrompt, userPrompt)
 }
 
-// generateWithModel performs text generation with a specific model.
-// Routes to either Ollama or OpenAI protocol based on client configuration.
-func (c *OllamaClient) generateWithModel(ctx context.Context, model, systemPrompt, userPrompt string) (string, error) {
-	if c.protocol == "openai" {
-		return c.generateOpenAI(ctx, model, systemPrompt, userPrompt)
+// generateWithModel performs text generation with a specific model on the
+// generation backend, without streaming.
+func (c *Client) generateWithModel(ctx context.Context, model, systemPrompt, userPrompt string) (string, error) {
+	return c.gen.generate(ctx, model, systemPrompt, userPrompt, nil)
+}
+
+// ── Ollama backend ───────────────────────────────────────────
+
+// ollamaBackend speaks the native Ollama API.
+type ollamaBackend struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+func newOllamaBackend(baseURL string) *ollamaBackend {
+	return &ollamaBackend{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: generateTimeout},
 	}
-	return c.generateOllama(ctx, model, systemPrompt, userPrompt)
 }
 
-// generateOllama uses the native Ollama API (POST /api/generate).
-func (c *OllamaClient) generateOllama(ctx context.Context, model, systemPrompt, userPrompt string) (string, error) {
+// generate streams from POST /api/generate; Ollama streams either way.
+func (b *ollamaBackend) generate(ctx context.Context, model, systemPrompt, userPrompt string, onToken TokenFunc) (string, error) {
+	return b.stream(ctx, model, systemPrompt, userPrompt, onToken)
+}
+
+// stream reads the newline-delimited JSON stream from POST /api/generate,
+// passing each chunk to onToken (if non-nil) and accumulating the full text.
+func (b *ollamaBackend) stream(ctx context.Context, model, systemPrompt, userPrompt string, onToken TokenFunc) (string, error) {
 	ctx, cancel := context.WithTimeout(ctx, generateTimeout)
 	defer cancel()
 
 	reqBody := generateRequest{
-		Model:  model,
-		System: systemPrompt,
-		Prompt: userPrompt,
-		Stream: true,
+		Model:   model,
+		System:  systemPrompt,
+		Prompt:  userPrompt,
+		Stream:  true,
+		Options: OptionsForTask(taskFromContext(ctx)).ollamaOptions(),
+	}
+	if jsonFormatFromContext(ctx) {
+		reqBody.Format = "json"
 	}
 
 	body, err := json.Marshal(reqBody)
@@ -413,13 +509,13 @@ func (c *OllamaClient) generateOllama(ctx context.Context, model, systemPrompt,
 		return "", fmt.Errorf("generate: marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/generate", bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/api/generate", bytes.NewReader(body))
 	if err != nil {
 		return "", fmt.Errorf("generate: create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := b.httpClient.Do(req)
 	if err != nil {
 		return "", fmt.Errorf("generate: request: %w", err)
 	}
@@ -441,6 +537,11 @@ func (c *OllamaClient) generateOllama(ctx context.Context, model, systemPrompt,
 			return "", fmt.Errorf("generate: decode chunk: %w", err)
 		}
 		sb.WriteString(chunk.Response)
+		if onToken != nil && chunk.Response != "" {
+			if err := onToken(chunk.Response); err != nil {
+				return "", fmt.Errorf("generate: stream callback: %w", err)
+			}
+		}
 		if chunk.Done {
 			break
 		}
@@ -454,74 +555,81 @@ func (c *OllamaClient) generateOllama(ctx context.Context, model, systemPrompt,
 	return result, nil
 }
 
-// generateOpenAI uses the OpenAI chat completions API (POST /v1/chat/completions).
-// Works with OpenAI, Groq, Together, OpenRouter, Mistral, and any compatible provider.
-func (c *OllamaClient) generateOpenAI(ctx context.Context, model, systemPrompt, userPrompt string) (string, error) {
-	ctx, cancel := context.WithTimeout(ctx, generateTimeout)
+func (b *ollamaBackend) embed(ctx context.Context, model, text string) ([]float32, error) {
+	ctx, cancel := context.WithTimeout(ctx, embeddingTimeout)
 	defer cancel()
 
-	messages := []openaiMessage{
-		{Role: "user", Content: userPrompt},
-	}
-	if systemPrompt != "" {
-		messages = append([]openaiMessage{{Role: "system", Content: systemPrompt}}, messages...)
-	}
-
-	reqBody := openaiChatRequest{
-		Model:    model,
-		Messages: messages,
+	reqBody := embeddingRequest{
+		Model:  model,
+		Prompt: text,
 	}
 
 	body, err := json.Marshal(reqBody)
 	if err != nil {
-		return "", fmt.Errorf("generate: marshal request: %w", err)
+		return nil, fmt.Errorf("embed: marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/v1/chat/completions", bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/api/embeddings", bytes.NewReader(body))
 	if err != nil {
-		return "", fmt.Errorf("generate: create request: %w", err)
+		return nil, fmt.Errorf("embed: create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.apiKey)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := b.httpClient.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("generate: request: %w", err)
+		return nil, fmt.Errorf("embed: request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
-		return "", fmt.Errorf("generate: status %d: %s", resp.StatusCode, string(respBody))
+		return nil, fmt.Errorf("embed: status %d: %s", resp.StatusCode, string(respBody))
 	}
 
-	var result openaiChatResponse
+	var result embeddingResponse
 	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return "", fmt.Errorf("generate: decode response: %w", err)
+		return nil, fmt.Errorf("embed: decode response: %w", err)
 	}
 
-	if result.Error != nil {
-		return "", fmt.Errorf("generate: API error: %s", result.Error.Message)
+	if len(result.Embedding) == 0 {
+		return nil, fmt.Errorf("embed: empty embedding returned")
 	}
 
-	if len(result.Choices) == 0 {
-		return "", fmt.Errorf("generate: empty response (no choices)")
+	return result.Embedding, nil
+}
+
+// embedBatch uses POST /api/embed, which takes a list of inputs.
+func (b *ollamaBackend) embedBatch(ctx context.Context, model string, texts []string) ([][]float32, error) {
+	ctx, cancel := context.WithTimeout(ctx, batchEmbeddingTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(batchEmbeddingRequest{Model: model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("embed batch: marshal request: %w", err)
 	}
 
-	text := strings.TrimSpace(result.Choices[0].Message.Content)
-	if text == "" {
-		return "", fmt.Errorf("generate: empty response")
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/api/embed", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("embed batch: create request: %w", err)
 	}
+	req.Header.Set("Content-Type", "application/json")
 
-	return text, nil
-}
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embed batch: request: %w", err)
+	}
+	defer resp.Body.Close()
 
-// allowedTags is the set of valid topic tags for classification.
-var allowedTags = map[string]bool{
-	"politics": true, "economy": true, "health": true, "education": true,
-	"infrastructure": true, "environment": true, "crime": true, "grants": true,
-	"federal": true, "legislation": true, "government": true, "technology": true,
-	"culture": true, "sports": true,
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("embed batch: status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result batchEmbeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("embed batch: decode response: %w", err)
+	}
+	return result.Embeddings, nil
 }
 
 // garbagePatterns are substrings that indicate the AI returned commentary instead
@@ -574,14 +682,14 @@ func cleanAIResponse(s string) string {
 }
 
 // parseAndValidateTags parses a CSV response and filters to only allowed tags.
-func parseAndValidateTags(s string) []string {
+func parseAndValidateTags(s string, allowedTags map[string]string) []string {
 	raw := parseCSV(s)
 	var valid []string
 	for _, tag := range raw {
 		t := strings.ToLower(strings.TrimSpace(tag))
 		// Strip surrounding noise like "1." or "-"
 		t = strings.TrimLeft(t, "0123456789.- ")
-		if allowedTags[t] {
+		if _, ok := allowedTags[t]; ok {
 			valid = append(valid, t)
 		}
 	}
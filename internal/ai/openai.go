@@ -0,0 +1,343 @@
+package ai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ── OpenAI protocol types ────────────────────────────────────
+
+type openaiMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openaiChatRequest struct {
+	Model          string                `json:"model"`
+	Messages       []openaiMessage       `json:"messages"`
+	Stream         bool                  `json:"stream,omitempty"`
+	Temperature    *float64              `json:"temperature,omitempty"`
+	MaxTokens      int                   `json:"max_tokens,omitempty"`
+	ResponseFormat *openaiResponseFormat `json:"response_format,omitempty"`
+}
+
+type openaiResponseFormat struct {
+	Type string `json:"type"`
+}
+
+type openaiStreamChunk struct {
+	Choices []struct {
+		Delta struct {
+			Content string `json:"content"`
+		} `json:"delta"`
+	} `json:"choices"`
+}
+
+type openaiChatResponse struct {
+	Choices []struct {
+		Message struct {
+			Content string `json:"content"`
+		} `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+type openaiEmbedRequest struct {
+	Model string `json:"model"`
+	Input string `json:"input"`
+}
+
+type openaiBatchEmbedRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type openaiEmbedResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
+// ── OpenAI-compatible backend ────────────────────────────────
+
+// openaiBackend speaks the OpenAI chat completions and embeddings APIs.
+type openaiBackend struct {
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+func newOpenAIBackend(baseURL, apiKey string) *openaiBackend {
+	return &openaiBackend{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{Timeout: generateTimeout},
+	}
+}
+
+// generate streams when onToken is set and otherwise makes one request.
+func (b *openaiBackend) generate(ctx context.Context, model, systemPrompt, userPrompt string, onToken TokenFunc) (string, error) {
+	if onToken == nil {
+		return b.complete(ctx, model, systemPrompt, userPrompt)
+	}
+	return b.stream(ctx, model, systemPrompt, userPrompt, onToken)
+}
+
+// complete uses the OpenAI chat completions API (POST /v1/chat/completions).
+// Works with OpenAI, Groq, Together, OpenRouter, Mistral, and any compatible provider.
+func (b *openaiBackend) complete(ctx context.Context, model, systemPrompt, userPrompt string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, generateTimeout)
+	defer cancel()
+
+	messages := []openaiMessage{
+		{Role: "user", Content: userPrompt},
+	}
+	if systemPrompt != "" {
+		messages = append([]openaiMessage{{Role: "system", Content: systemPrompt}}, messages...)
+	}
+
+	opts := OptionsForTask(taskFromContext(ctx))
+	reqBody := openaiChatRequest{
+		Model:       model,
+		Messages:    messages,
+		Temperature: opts.Temperature,
+		MaxTokens:   opts.MaxTokens,
+	}
+	if jsonFormatFromContext(ctx) {
+		reqBody.ResponseFormat = &openaiResponseFormat{Type: "json_object"}
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return "", fmt.Errorf("generate: marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/v1/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("generate: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+b.apiKey)
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("generate: request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("generate: status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result openaiChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("generate: decode response: %w", err)
+	}
+
+	if result.Error != nil {
+		return "", fmt.Errorf("generate: API error: %s", result.Error.Message)
+	}
+
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("generate: empty response (no choices)")
+	}
+
+	text := strings.TrimSpace(result.Choices[0].Message.Content)
+	if text == "" {
+		return "", fmt.Errorf("generate: empty response")
+	}
+
+	return text, nil
+}
+
+// stream uses the OpenAI chat completions API with stream=true, which
+// returns Server-Sent Events of the form "data: {...}" terminated by
+// "data: [DONE]".
+func (b *openaiBackend) stream(ctx context.Context, model, systemPrompt, userPrompt string, onToken TokenFunc) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, generateTimeout)
+	defer cancel()
+
+	messages := []openaiMessage{
+		{Role: "user", Content: userPrompt},
+	}
+	if systemPrompt != "" {
+		messages = append([]openaiMessage{{Role: "system", Content: systemPrompt}}, messages...)
+	}
+
+	opts := OptionsForTask(taskFromContext(ctx))
+	body, err := json.Marshal(openaiChatRequest{
+		Model:       model,
+		Messages:    messages,
+		Stream:      true,
+		Temperature: opts.Temperature,
+		MaxTokens:   opts.MaxTokens,
+	})
+	if err != nil {
+		return "", fmt.Errorf("generate: marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/v1/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("generate: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Authorization", "Bearer "+b.apiKey)
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("generate: request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("generate: status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var sb strings.Builder
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if !strings.HasPrefix(line, "data:") {
+			continue
+		}
+		data := strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		if data == "[DONE]" {
+			break
+		}
+		var chunk openaiStreamChunk
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil || len(chunk.Choices) == 0 {
+			continue
+		}
+		token := chunk.Choices[0].Delta.Content
+		if token == "" {
+			continue
+		}
+		sb.WriteString(token)
+		if onToken != nil {
+			if err := onToken(token); err != nil {
+				return "", fmt.Errorf("generate: stream callback: %w", err)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil && sb.Len() == 0 {
+		return "", fmt.Errorf("generate: read stream: %w", err)
+	}
+
+	result := strings.TrimSpace(sb.String())
+	if result == "" {
+		return "", fmt.Errorf("generate: empty response")
+	}
+
+	return result, nil
+}
+
+func (b *openaiBackend) embed(ctx context.Context, model, text string) ([]float32, error) {
+	ctx, cancel := context.WithTimeout(ctx, embeddingTimeout)
+	defer cancel()
+
+	reqBody := openaiEmbedRequest{
+		Model: model,
+		Input: text,
+	}
+
+	body, err := json.Marshal(reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("embed: marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/v1/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("embed: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+b.apiKey)
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embed: request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("embed: status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result openaiEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("embed: decode response: %w", err)
+	}
+
+	if result.Error != nil {
+		return nil, fmt.Errorf("embed: API error: %s", result.Error.Message)
+	}
+
+	if len(result.Data) == 0 || len(result.Data[0].Embedding) == 0 {
+		return nil, fmt.Errorf("embed: empty embedding returned")
+	}
+
+	return result.Data[0].Embedding, nil
+}
+
+// embedBatch sends all texts as the input list of one POST /v1/embeddings.
+// The response data is put back in input order by index.
+func (b *openaiBackend) embedBatch(ctx context.Context, model string, texts []string) ([][]float32, error) {
+	ctx, cancel := context.WithTimeout(ctx, batchEmbeddingTimeout)
+	defer cancel()
+
+	body, err := json.Marshal(openaiBatchEmbedRequest{Model: model, Input: texts})
+	if err != nil {
+		return nil, fmt.Errorf("embed batch: marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.baseURL+"/v1/embeddings", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("embed batch: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+b.apiKey)
+
+	resp, err := b.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embed batch: request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("embed batch: status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	var result openaiEmbedResponse
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("embed batch: decode response: %w", err)
+	}
+	if result.Error != nil {
+		return nil, fmt.Errorf("embed batch: API error: %s", result.Error.Message)
+	}
+
+	embeddings := make([][]float32, len(texts))
+	for _, d := range result.Data {
+		if d.Index < 0 || d.Index >= len(texts) {
+			return nil, fmt.Errorf("embed batch: embedding index %d out of range", d.Index)
+		}
+		embeddings[d.Index] = d.Embedding
+	}
+	return embeddings, nil
+}
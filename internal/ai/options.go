@@ -0,0 +1,194 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Generation tasks. Each has its own GenerateOptions so deterministic work
+// (classification, extraction) runs at temperature 0 while long-context work
+// (briefs, drafts, chat) gets a larger context window.
+const (
+	TaskDefault   = "default"
+	TaskClassify  = "classify"  // tags, sentiment, scope
+	TaskExtract   = "extract"   // entities, quotes, JSON extraction
+	TaskSummarize = "summarize" // article summaries
+	TaskBrief     = "brief"     // daily briefs and digests
+	TaskDraft     = "draft"     // drafted responses, generated content
+	TaskChat      = "chat"      // interactive Q&A
+)
+
+// GenerateOptions are the sampling and context parameters sent with a
+// generation request. Zero values leave the provider's default in place.
+type GenerateOptions struct {
+	Temperature *float64 // 0 is meaningful, so nil means unset
+	NumCtx      int      // context window in tokens (Ollama only)
+	MaxTokens   int      // cap on generated tokens (num_predict / max_tokens)
+}
+
+func temperature(t float64) *float64 { return &t }
+
+// DefaultTaskOptions are the options used when AI_TASK_OPTIONS does not
+// override a task.
+var DefaultTaskOptions = map[string]GenerateOptions{
+	TaskClassify:  {Temperature: temperature(0), MaxTokens: 32},
+	TaskExtract:   {Temperature: temperature(0), MaxTokens: 1024},
+	TaskSummarize: {Temperature: temperature(0.3), MaxTokens: 300},
+	TaskBrief:     {Temperature: temperature(0.4), NumCtx: 8192, MaxTokens: 2048},
+	TaskDraft:     {Temperature: temperature(0.5), NumCtx: 8192, MaxTokens: 4096},
+	TaskChat:      {NumCtx: 8192},
+}
+
+// taskOptions is the process-wide table set by SetTaskOptions.
+var taskOptions atomic.Pointer[map[string]GenerateOptions]
+
+// SetTaskOptions installs per-task options, layered over DefaultTaskOptions
+// field by field.
+func SetTaskOptions(overrides map[string]GenerateOptions) {
+	merged := make(map[string]GenerateOptions, len(DefaultTaskOptions)+len(overrides))
+	for task, opts := range DefaultTaskOptions {
+		merged[task] = opts
+	}
+	for task, o := range overrides {
+		opts := merged[task]
+		if o.Temperature != nil {
+			opts.Temperature = o.Temperature
+		}
+		if o.NumCtx > 0 {
+			opts.NumCtx = o.NumCtx
+		}
+		if o.MaxTokens > 0 {
+			opts.MaxTokens = o.MaxTokens
+		}
+		merged[task] = opts
+	}
+	taskOptions.Store(&merged)
+}
+
+// OptionsForTask returns the options for task, falling back to the default
+// task's options.
+func OptionsForTask(task string) GenerateOptions {
+	table := DefaultTaskOptions
+	if p := taskOptions.Load(); p != nil {
+		table = *p
+	}
+	if opts, ok := table[task]; ok {
+		return opts
+	}
+	return table[TaskDefault]
+}
+
+type taskKey struct{}
+
+// WithTask tags ctx with the generation task, selecting the options sent
+// with generations made under it. Methods such as Classify and Summarize tag
+// their own task; use WithTask around Generate and GenerateWithModel.
+func WithTask(ctx context.Context, task string) context.Context {
+	return context.WithValue(ctx, taskKey{}, task)
+}
+
+// taskFromContext returns the task ctx was tagged with, or TaskDefault.
+func taskFromContext(ctx context.Context) string {
+	if task, ok := ctx.Value(taskKey{}).(string); ok {
+		return task
+	}
+	return TaskDefault
+}
+
+type jsonFormatKey struct{}
+
+// withJSONFormat tags ctx so generations made under it are constrained to a
+// single JSON object (Ollama format=json, OpenAI response_format). Backends
+// without such a mode rely on the prompt alone.
+func withJSONFormat(ctx context.Context) context.Context {
+	return context.WithValue(ctx, jsonFormatKey{}, true)
+}
+
+// jsonFormatFromContext reports whether ctx asks for JSON output.
+func jsonFormatFromContext(ctx context.Context) bool {
+	on, _ := ctx.Value(jsonFormatKey{}).(bool)
+	return on
+}
+
+// ParseTaskOptions parses AI_TASK_OPTIONS, a semicolon-separated list of
+// task:key=value,... entries, e.g.
+//
+//	classify:temperature=0,max_tokens=16;brief:num_ctx=16384
+//
+// Keys are temperature, num_ctx and max_tokens.
+func ParseTaskOptions(s string) (map[string]GenerateOptions, error) {
+	result := make(map[string]GenerateOptions)
+	for _, entry := range strings.Split(s, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		task, params, ok := strings.Cut(entry, ":")
+		task = strings.TrimSpace(task)
+		if !ok || task == "" {
+			return nil, fmt.Errorf("task options: %q: expected task:key=value", entry)
+		}
+		opts := result[task]
+		for _, param := range strings.Split(params, ",") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok {
+				return nil, fmt.Errorf("task options: %s: %q: expected key=value", task, param)
+			}
+			key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+			switch key {
+			case "temperature":
+				t, err := strconv.ParseFloat(value, 64)
+				if err != nil || t < 0 {
+					return nil, fmt.Errorf("task options: %s: invalid temperature %q", task, value)
+				}
+				opts.Temperature = &t
+			case "num_ctx", "max_tokens":
+				n, err := strconv.Atoi(value)
+				if err != nil || n <= 0 {
+					return nil, fmt.Errorf("task options: %s: invalid %s %q", task, key, value)
+				}
+				if key == "num_ctx" {
+					opts.NumCtx = n
+				} else {
+					opts.MaxTokens = n
+				}
+			default:
+				return nil, fmt.Errorf("task options: %s: unknown key %q", task, key)
+			}
+		}
+		result[task] = opts
+	}
+	return result, nil
+}
+
+// LoadTaskOptions parses spec (AI_TASK_OPTIONS) and installs the result with
+// SetTaskOptions. On a parse error the defaults stay in place.
+func LoadTaskOptions(spec string) error {
+	overrides, err := ParseTaskOptions(spec)
+	if err != nil {
+		return err
+	}
+	SetTaskOptions(overrides)
+	return nil
+}
+
+// ollamaOptions converts opts to the "options" object of an Ollama request.
+func (o GenerateOptions) ollamaOptions() map[string]any {
+	m := make(map[string]any)
+	if o.Temperature != nil {
+		m["temperature"] = *o.Temperature
+	}
+	if o.NumCtx > 0 {
+		m["num_ctx"] = o.NumCtx
+	}
+	if o.MaxTokens > 0 {
+		m["num_predict"] = o.MaxTokens
+	}
+	if len(m) == 0 {
+		return nil
+	}
+	return m
+}
@@ -0,0 +1,68 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+)
+
+// Provider is the AI surface the rest of the application depends on. *Client
+// implements it for every configured backend; code that uses AI takes a
+// Provider so it can be handed a stand-in.
+type Provider interface {
+	Summarize(ctx context.Context, text string) (string, error)
+	Classify(ctx context.Context, text string) ([]string, error)
+	Embed(ctx context.Context, text string) ([]float32, error)
+	Generate(ctx context.Context, systemPrompt, userPrompt string) (string, error)
+
+	// Article enrichment.
+	Enrich(ctx context.Context, text string) (*Enrichment, error)
+	ExtractEntities(ctx context.Context, text string) (*ExtractedEntities, error)
+	ClassifySentiment(ctx context.Context, text string) (string, error)
+	ClassifyScope(ctx context.Context, text string) (string, error)
+	ExtractQuotes(ctx context.Context, text string) ([]Quote, error)
+	ExtractGrantPosting(ctx context.Context, text string) (*GrantPosting, error)
+	SuggestTriage(ctx context.Context, profile, article string) (*TriageSuggestion, error)
+	EmbedBatch(ctx context.Context, texts []string) ([][]float32, error)
+
+	// Free-form generation.
+	GenerateWithModel(ctx context.Context, model, systemPrompt, userPrompt string) (string, error)
+	GenerateStream(ctx context.Context, model, systemPrompt, userPrompt string, onToken TokenFunc) (string, error)
+	RunTools(ctx context.Context, model, systemPrompt, userPrompt string, tools []Tool) (string, []ToolCall, error)
+
+	// CheckModels reports whether the configured models are available.
+	CheckModels(ctx context.Context) error
+}
+
+var _ Provider = (*Client)(nil)
+
+// Providers lists the accepted AI_PROVIDER and AI_EMBED_PROVIDER values.
+var Providers = []string{"ollama", "openai", "anthropic", "fake"}
+
+// backend is one provider's wire protocol. The client's prompts run on top
+// of it.
+type backend interface {
+	// generate returns the trimmed completion of userPrompt. When onToken
+	// is non-nil the response is streamed and each chunk passed to it.
+	generate(ctx context.Context, model, systemPrompt, userPrompt string, onToken TokenFunc) (string, error)
+	// embed returns the embedding of text.
+	embed(ctx context.Context, model, text string) ([]float32, error)
+	// embedBatch returns the embeddings of texts in one request, in order.
+	embedBatch(ctx context.Context, model string, texts []string) ([][]float32, error)
+	// models lists the models the provider serves; nil when it can't tell.
+	models(ctx context.Context) (map[string]bool, error)
+}
+
+// newBackend returns the backend for a provider name.
+func newBackend(provider, host, apiKey string) (backend, error) {
+	switch provider {
+	case "", "ollama":
+		return newOllamaBackend(host), nil
+	case "openai":
+		return newOpenAIBackend(host, apiKey), nil
+	case "anthropic":
+		return newAnthropicBackend(host, apiKey), nil
+	case "fake":
+		return fakeBackend{}, nil
+	}
+	return nil, fmt.Errorf("ai: unknown provider %q, use one of %v", provider, Providers)
+}
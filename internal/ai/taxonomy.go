@@ -0,0 +1,151 @@
+package ai
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultTaxonomy is the tag set Classify uses when no TaxonomySource is
+// installed, or the source has never loaded. It matches the tags seeded by
+// migration 035.
+var DefaultTaxonomy = map[string]string{
+	"politics": "", "economy": "", "health": "", "education": "",
+	"infrastructure": "", "environment": "", "crime": "", "grants": "",
+	"federal": "", "legislation": "", "government": "", "technology": "",
+	"culture": "", "sports": "",
+}
+
+// TaxonomySource supplies the active topic tags, mapped to an optional
+// description that tells the model what belongs under the tag.
+type TaxonomySource interface {
+	TaxonomyTags(ctx context.Context) (map[string]string, error)
+}
+
+// taxonomyTTL is how long a loaded taxonomy is used before it is reloaded, so
+// edits made through one process reach the others.
+const taxonomyTTL = time.Minute
+
+// taxonomyCache holds the installed source and the last taxonomy it returned.
+type taxonomyCache struct {
+	source TaxonomySource
+
+	mu       sync.Mutex
+	tags     map[string]string
+	loadedAt time.Time
+}
+
+var taxonomy atomic.Pointer[taxonomyCache]
+
+// SetTaxonomySource installs the source Classify reads its tags from.
+func SetTaxonomySource(src TaxonomySource) {
+	taxonomy.Store(&taxonomyCache{source: src})
+}
+
+// InvalidateTaxonomy makes the next Classify reload the taxonomy. Call it
+// after editing tags.
+func InvalidateTaxonomy() {
+	if c := taxonomy.Load(); c != nil {
+		c.mu.Lock()
+		c.loadedAt = time.Time{}
+		c.mu.Unlock()
+	}
+}
+
+// currentTaxonomy returns the active tags, reloading them from the source
+// when the cached copy is stale. If a reload fails the previous tags (or
+// DefaultTaxonomy) are kept.
+func currentTaxonomy(ctx context.Context) map[string]string {
+	c := taxonomy.Load()
+	if c == nil {
+		return DefaultTaxonomy
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.tags != nil && time.Since(c.loadedAt) < taxonomyTTL {
+		return c.tags
+	}
+
+	tags, err := c.source.TaxonomyTags(ctx)
+	switch {
+	case err != nil:
+		slog.Warn("ai: load tag taxonomy", "err", err)
+	case len(tags) == 0:
+		slog.Warn("ai: tag taxonomy is empty, keeping previous tags")
+	default:
+		c.tags = tags
+	}
+	c.loadedAt = time.Now()
+	if c.tags == nil {
+		return DefaultTaxonomy
+	}
+	return c.tags
+}
+
+// classifyExamples are few-shot examples for the classifier prompt. An
+// example is only shown when all of its tags are in the taxonomy.
+var classifyExamples = []struct {
+	article string
+	tags    []string
+}{
+	{"governor signing a bill", []string{"politics", "legislation"}},
+	{"hospital funding cuts", []string{"health", "economy"}},
+	{"federal grants for schools", []string{"grants", "education", "federal"}},
+	{"road construction project", []string{"infrastructure"}},
+	{"arrests in Bayamón", []string{"crime"}},
+	{"tech startup in San Juan", []string{"technology", "economy"}},
+}
+
+// classifyPrompt builds the Classify system prompt for a taxonomy.
+func classifyPrompt(tags map[string]string) string {
+	names := make([]string, 0, len(tags))
+	for name := range tags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var b strings.Builder
+	b.WriteString("You are a strict tag classifier. You receive article text and output ONLY comma-separated tags.\n\n")
+	b.WriteString("ALLOWED TAGS: " + strings.Join(names, ", ") + "\n")
+
+	var described []string
+	for _, name := range names {
+		if desc := strings.TrimSpace(tags[name]); desc != "" {
+			described = append(described, "- "+name+": "+desc)
+		}
+	}
+	if len(described) > 0 {
+		b.WriteString("\nTAG NOTES:\n" + strings.Join(described, "\n") + "\n")
+	}
+
+	var examples []string
+	for _, ex := range classifyExamples {
+		ok := true
+		for _, t := range ex.tags {
+			if _, found := tags[t]; !found {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			examples = append(examples, "Article about "+ex.article+" → "+strings.Join(ex.tags, ", "))
+		}
+	}
+	if len(examples) > 0 {
+		b.WriteString("\nEXAMPLES:\n" + strings.Join(examples, "\n") + "\n")
+	}
+
+	b.WriteString(`
+RULES:
+- Output ONLY tags from the list above, comma-separated
+- Pick 1-3 tags that best fit
+- NO explanations, NO sentences, NO commentary
+- NEVER output anything except tag names separated by commas
+- If unsure, pick the closest match`)
+	return b.String()
+}
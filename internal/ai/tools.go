@@ -0,0 +1,175 @@
+package ai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Tool is an action the model may invoke while answering with RunTools.
+type Tool struct {
+	Name        string
+	Description string
+	// Params maps each argument name to its description; every argument is
+	// optional unless its description says otherwise.
+	Params map[string]string
+	// Run executes the tool and returns its result as text for the model.
+	Run func(ctx context.Context, args map[string]any) (string, error)
+}
+
+// ToolCall records one tool invocation made during RunTools.
+type ToolCall struct {
+	Tool   string         `json:"tool"`
+	Args   map[string]any `json:"args"`
+	Result string         `json:"result,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+const (
+	// maxToolSteps caps the tool calls of one RunTools conversation.
+	maxToolSteps = 4
+
+	// maxToolResultLen caps a tool result fed back to the model.
+	maxToolResultLen = 3000
+)
+
+// toolReply is the JSON object the model answers with on every step: either
+// a tool call or the final answer.
+type toolReply struct {
+	Tool   string         `json:"tool"`
+	Args   map[string]any `json:"args"`
+	Answer string         `json:"answer"`
+}
+
+// RunTools answers userPrompt with model, letting it call tools. Providers
+// differ in native function calling, so the protocol is plain JSON: on each
+// step the model replies with {"tool": ..., "args": {...}} to call a tool,
+// whose result is appended to the conversation, or {"answer": ...} to
+// finish. A reply that isn't JSON is taken as the answer. After
+// maxToolSteps calls the model must answer. The calls made are returned
+// with the answer.
+func (c *Client) RunTools(ctx context.Context, model, systemPrompt, userPrompt string, tools []Tool) (string, []ToolCall, error) {
+	byName := make(map[string]Tool, len(tools))
+	for _, t := range tools {
+		byName[t.Name] = t
+	}
+	system := systemPrompt + "\n\n" + toolInstructions(tools)
+
+	var calls []ToolCall
+	var transcript strings.Builder
+	transcript.WriteString(userPrompt)
+
+	for step := 0; ; step++ {
+		prompt := transcript.String()
+		if step == maxToolSteps {
+			prompt += "\n\nYa no puedes llamar más herramientas. Responde ahora con {\"answer\": \"...\"}."
+		}
+		resp, err := c.GenerateWithModel(ctx, model, system, prompt)
+		if err != nil {
+			return "", calls, fmt.Errorf("run tools: %w", err)
+		}
+
+		reply, ok := parseToolReply(resp)
+		if !ok {
+			return strings.TrimSpace(resp), calls, nil
+		}
+		if reply.Tool == "" || step == maxToolSteps {
+			return strings.TrimSpace(reply.Answer), calls, nil
+		}
+
+		call := ToolCall{Tool: reply.Tool, Args: reply.Args}
+		if call.Args == nil {
+			call.Args = map[string]any{}
+		}
+		tool, known := byName[reply.Tool]
+		if !known {
+			call.Error = "unknown tool"
+		} else if result, err := tool.Run(ctx, call.Args); err != nil {
+			call.Error = err.Error()
+		} else {
+			if len(result) > maxToolResultLen {
+				result = result[:maxToolResultLen] + "..."
+			}
+			call.Result = result
+		}
+		calls = append(calls, call)
+
+		args, _ := json.Marshal(call.Args)
+		fmt.Fprintf(&transcript, "\n\nLLAMADA: %s %s\n", call.Tool, args)
+		if call.Error != "" {
+			fmt.Fprintf(&transcript, "ERROR: %s\n", call.Error)
+		} else {
+			fmt.Fprintf(&transcript, "RESULTADO:\n%s\n", call.Result)
+		}
+	}
+}
+
+// parseToolReply extracts the tool call or answer from a model reply,
+// tolerating code fences and text around the JSON object. It reports false
+// when the reply holds no such object.
+func parseToolReply(s string) (toolReply, bool) {
+	var reply toolReply
+	s = strings.TrimSpace(s)
+	start := strings.Index(s, "{")
+	end := strings.LastIndex(s, "}")
+	if start == -1 || end <= start {
+		return reply, false
+	}
+	if err := json.Unmarshal([]byte(s[start:end+1]), &reply); err != nil {
+		return reply, false
+	}
+	if reply.Tool == "" && reply.Answer == "" {
+		return reply, false
+	}
+	return reply, true
+}
+
+// toolInstructions describes the tools and the reply protocol to the model.
+func toolInstructions(tools []Tool) string {
+	var b strings.Builder
+	b.WriteString("HERRAMIENTAS DISPONIBLES:\n")
+	for _, t := range tools {
+		fmt.Fprintf(&b, "- %s: %s\n", t.Name, t.Description)
+		names := make([]string, 0, len(t.Params))
+		for name := range t.Params {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Fprintf(&b, "    %s: %s\n", name, t.Params[name])
+		}
+	}
+	b.WriteString(`
+Responde SIEMPRE con un solo objeto JSON, sin texto adicional:
+- Para usar una herramienta: {"tool": "nombre", "args": {"argumento": "valor"}}
+- Para dar la respuesta final: {"answer": "tu respuesta"}
+Usa una herramienta a la vez; verás su resultado antes de continuar.`)
+	return b.String()
+}
+
+// ArgString returns the string argument name, or "" when absent.
+func ArgString(args map[string]any, name string) string {
+	switch v := args[name].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return fmt.Sprintf("%g", v)
+	}
+	return ""
+}
+
+// ArgInt returns the integer argument name, or def when absent or invalid.
+func ArgInt(args map[string]any, name string, def int) int {
+	switch v := args[name].(type) {
+	case float64:
+		return int(v)
+	case string:
+		var n int
+		if _, err := fmt.Sscanf(v, "%d", &n); err == nil {
+			return n
+		}
+	}
+	return def
+}
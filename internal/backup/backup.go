@@ -0,0 +1,315 @@
+// Package backup dumps the Folio database to object storage and rotates old
+// dumps.
+package backup
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/storage"
+)
+
+const (
+	// DefaultKeep is how many backups are retained when no count is configured.
+	DefaultKeep = 8
+
+	// Timeout bounds a whole backup run, dump and upload included.
+	Timeout = 2 * time.Hour
+
+	methodPgDump = "pg_dump"
+	methodCopy   = "copy"
+)
+
+// skippedTables hold short-lived or secret data that is not worth restoring.
+// Their schema is still dumped by pg_dump; only their rows are left out.
+var skippedTables = []string{"sessions"}
+
+// Deps groups what a backup run needs.
+type Deps struct {
+	Pool    *pgxpool.Pool
+	Storage *storage.Client
+	Runs    *models.BackupRunStore
+	Keep    int // backups to retain; <= 0 uses DefaultKeep
+}
+
+// Run dumps the database, uploads it under the storage backups prefix, and
+// deletes the oldest backups beyond deps.Keep. pg_dump (custom format) is
+// used when it is installed; otherwise every public table is exported as CSV
+// with COPY into a zip archive, inside one repeatable-read snapshot. The
+// outcome is recorded in deps.Runs.
+func Run(ctx context.Context, deps Deps) (*models.BackupRun, error) {
+	if deps.Storage == nil || !deps.Storage.Configured() {
+		return nil, fmt.Errorf("backup: object storage is not configured")
+	}
+	ctx, cancel := context.WithTimeout(ctx, Timeout)
+	defer cancel()
+
+	method := methodCopy
+	pgDump, lookErr := exec.LookPath("pg_dump")
+	if lookErr == nil {
+		method = methodPgDump
+	}
+
+	run, err := deps.Runs.Start(ctx, method)
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("backup: starting", "method", method)
+	start := time.Now()
+
+	runErr := dumpAndUpload(ctx, deps, run, pgDump)
+	if runErr != nil {
+		run.Error = runErr.Error()
+		slog.Error("backup: failed", "method", method, "err", runErr)
+	} else {
+		run.Pruned = prune(ctx, deps)
+		slog.Info("backup: complete",
+			"key", run.ObjectKey,
+			"bytes", run.SizeBytes,
+			"tables", run.Tables,
+			"pruned", run.Pruned,
+			"duration", time.Since(start).Round(time.Second),
+		)
+	}
+
+	// Record the outcome even if the run's context has expired.
+	finishCtx, finishCancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer finishCancel()
+	if err := deps.Runs.Finish(finishCtx, run); err != nil {
+		slog.Error("backup: record run", "err", err)
+	}
+	return run, runErr
+}
+
+// dumpAndUpload writes the dump to a temporary file and streams it to storage.
+func dumpAndUpload(ctx context.Context, deps Deps, run *models.BackupRun, pgDump string) error {
+	tmp, err := os.CreateTemp("", "folio-backup-*")
+	if err != nil {
+		return fmt.Errorf("create temp file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	stamp := time.Now().UTC().Format("20060102-150405")
+	var name string
+	if run.Method == methodPgDump {
+		name = "folio-" + stamp + ".dump"
+		run.Tables, err = dumpWithPgDump(ctx, deps.Pool, pgDump, tmp)
+	} else {
+		name = "folio-" + stamp + ".zip"
+		run.Tables, err = dumpWithCopy(ctx, deps.Pool, tmp)
+	}
+	if err != nil {
+		return err
+	}
+
+	size, err := tmp.Seek(0, io.SeekEnd)
+	if err != nil {
+		return fmt.Errorf("size dump: %w", err)
+	}
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("rewind dump: %w", err)
+	}
+
+	key, err := deps.Storage.PutBackup(ctx, name, tmp, size)
+	if err != nil {
+		return err
+	}
+	run.ObjectKey = key
+	run.SizeBytes = size
+	return nil
+}
+
+// dumpWithPgDump runs pg_dump in custom (compressed) format into w.
+func dumpWithPgDump(ctx context.Context, pool *pgxpool.Pool, pgDump string, w io.Writer) (int, error) {
+	tables, err := listTables(ctx, pool)
+	if err != nil {
+		return 0, err
+	}
+
+	args := []string{"--format=custom", "--no-owner", "--no-privileges"}
+	for _, t := range skippedTables {
+		args = append(args, "--exclude-table-data="+t)
+	}
+	// The password goes in the environment, not on the command line where
+	// any local user can read it from the process list.
+	connCfg := pool.Config().ConnConfig
+	dsn, err := withoutPassword(connCfg.ConnString())
+	if err != nil {
+		return 0, err
+	}
+	args = append(args, "--dbname="+dsn)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, pgDump, args...)
+	cmd.Env = os.Environ()
+	if connCfg.Password != "" {
+		cmd.Env = append(cmd.Env, "PGPASSWORD="+connCfg.Password)
+	}
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if len(msg) > 500 {
+			msg = msg[:500]
+		}
+		return 0, fmt.Errorf("pg_dump: %w: %s", err, msg)
+	}
+	return len(tables), nil
+}
+
+// passwordSetting matches a password setting in a keyword/value connection
+// string, quoted or not.
+var passwordSetting = regexp.MustCompile(`\bpassword\s*=\s*('(\\.|[^'])*'|\S*)`)
+
+// withoutPassword returns the connection string with any password removed,
+// in either the URL or the keyword/value form.
+func withoutPassword(connString string) (string, error) {
+	if strings.HasPrefix(connString, "postgres://") || strings.HasPrefix(connString, "postgresql://") {
+		u, err := url.Parse(connString)
+		if err != nil {
+			return "", fmt.Errorf("parse database url: %w", err)
+		}
+		if u.User != nil {
+			u.User = url.User(u.User.Username())
+		}
+		q := u.Query()
+		if q.Has("password") {
+			q.Del("password")
+			u.RawQuery = q.Encode()
+		}
+		return u.String(), nil
+	}
+	return strings.TrimSpace(passwordSetting.ReplaceAllString(connString, "")), nil
+}
+
+// copyManifest is written as manifest.json inside COPY-based backups.
+type copyManifest struct {
+	CreatedAt time.Time        `json:"created_at"`
+	Format    string           `json:"format"`
+	Tables    map[string]int64 `json:"tables"` // table -> rows
+	Skipped   []string         `json:"skipped"`
+}
+
+// dumpWithCopy exports every public table as CSV (with header) into a zip
+// archive written to w. All tables are read from one snapshot.
+func dumpWithCopy(ctx context.Context, pool *pgxpool.Pool, w io.Writer) (int, error) {
+	tables, err := listTables(ctx, pool)
+	if err != nil {
+		return 0, err
+	}
+
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("acquire connection: %w", err)
+	}
+	defer conn.Release()
+
+	tx, err := conn.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return 0, fmt.Errorf("begin snapshot: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	zw := zip.NewWriter(w)
+	manifest := copyManifest{
+		CreatedAt: time.Now().UTC(),
+		Format:    "csv-header",
+		Tables:    make(map[string]int64),
+		Skipped:   skippedTables,
+	}
+
+	for _, table := range tables {
+		if isSkipped(table) {
+			continue
+		}
+		fw, err := zw.Create(table + ".csv")
+		if err != nil {
+			return 0, fmt.Errorf("zip %s: %w", table, err)
+		}
+		sql := "COPY " + pgx.Identifier{table}.Sanitize() + " TO STDOUT WITH (FORMAT csv, HEADER)"
+		tag, err := conn.Conn().PgConn().CopyTo(ctx, fw, sql)
+		if err != nil {
+			return 0, fmt.Errorf("copy %s: %w", table, err)
+		}
+		manifest.Tables[table] = tag.RowsAffected()
+	}
+
+	mw, err := zw.Create("manifest.json")
+	if err != nil {
+		return 0, fmt.Errorf("zip manifest: %w", err)
+	}
+	if err := json.NewEncoder(mw).Encode(manifest); err != nil {
+		return 0, fmt.Errorf("write manifest: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return 0, fmt.Errorf("close zip: %w", err)
+	}
+	return len(manifest.Tables), nil
+}
+
+// listTables returns the base tables in the public schema.
+func listTables(ctx context.Context, pool *pgxpool.Pool) ([]string, error) {
+	rows, err := pool.Query(ctx, `
+		SELECT table_name
+		FROM information_schema.tables
+		WHERE table_schema = 'public' AND table_type = 'BASE TABLE'
+		ORDER BY table_name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("list tables: %w", err)
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func isSkipped(table string) bool {
+	for _, t := range skippedTables {
+		if t == table {
+			return true
+		}
+	}
+	return false
+}
+
+// prune deletes the oldest backups beyond the retention count and returns
+// how many were removed. Failures are logged; they never fail the run.
+func prune(ctx context.Context, deps Deps) int {
+	keep := deps.Keep
+	if keep <= 0 {
+		keep = DefaultKeep
+	}
+
+	backups, err := deps.Storage.ListBackups(ctx)
+	if err != nil {
+		slog.Error("backup: list for rotation", "err", err)
+		return 0
+	}
+	if len(backups) <= keep {
+		return 0
+	}
+
+	pruned := 0
+	for _, b := range backups[:len(backups)-keep] {
+		if err := deps.Storage.DeleteBackup(ctx, b.Key); err != nil {
+			slog.Error("backup: delete old backup", "key", b.Key, "err", err)
+			continue
+		}
+		pruned++
+	}
+	return pruned
+}
@@ -0,0 +1,27 @@
+package backup
+
+import "testing"
+
+func TestWithoutPassword(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"postgres://folio:s3cret@db:5432/folio?sslmode=disable", "postgres://folio@db:5432/folio?sslmode=disable"},
+		{"postgresql://folio@db/folio", "postgresql://folio@db/folio"},
+		{"postgres://db/folio?user=folio&password=s3cret", "postgres://db/folio?user=folio"},
+		{"host=db user=folio password=s3cret dbname=folio", "host=db user=folio  dbname=folio"},
+		{"host=db password='s3 \\'cret' dbname=folio", "host=db  dbname=folio"},
+		{"host=db sslpassword=keypass dbname=folio", "host=db sslpassword=keypass dbname=folio"},
+	}
+	for _, tt := range tests {
+		got, err := withoutPassword(tt.in)
+		if err != nil {
+			t.Errorf("withoutPassword(%q): %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("withoutPassword(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // Config holds the full application configuration.
@@ -15,6 +16,19 @@ type Config struct {
 	Ollama   OllamaConfig
 	AI       AIConfig
 	Telegram TelegramConfig
+	Search   SearchConfig
+	Social   SocialConfig
+	Backup   BackupConfig
+	Render   RenderConfig
+	Log      LogConfig
+	Feeds    FeedConfig
+	Ingest   IngestConfig
+	Trash    TrashConfig
+	Crawl    CrawlConfig
+	Chat     ChatConfig
+	Brief    BriefConfig
+	Coverage CoverageConfig
+	Flags    FlagsConfig
 }
 
 // DBConfig holds PostgreSQL connection parameters.
@@ -25,6 +39,12 @@ type DBConfig struct {
 	Pass    string
 	DBName  string
 	SSLMode string
+
+	// SlowQuery is the duration above which a query is logged and counted
+	// in the admin stats; QueryTimeout bounds each query run for an HTTP
+	// request (never past the request's own deadline).
+	SlowQuery    time.Duration
+	QueryTimeout time.Duration
 }
 
 // DSN returns a PostgreSQL connection string.
@@ -36,8 +56,22 @@ func (c DBConfig) DSN() string {
 
 // ServerConfig holds HTTP server parameters.
 type ServerConfig struct {
-	Port string
-	Host string
+	Port        string
+	Host        string
+	Timeouts    TimeoutConfig
+	FrontendDir string // built frontend served by cmd/api unless embedded
+
+	// DefaultLanguage is the language of API messages and notifications for
+	// users who have not chosen one ("es" or "en").
+	DefaultLanguage string
+}
+
+// TimeoutConfig holds per-route-class HTTP request timeouts.
+type TimeoutConfig struct {
+	Default time.Duration // writes and unlisted routes
+	Read    time.Duration // plain GETs
+	AI      time.Duration // synchronous AI calls and on-demand scraping
+	Export  time.Duration // article and bulk exports
 }
 
 // Addr returns the full listen address (host:port).
@@ -52,6 +86,7 @@ type S3Config struct {
 	AccessKey string
 	SecretKey string
 	Region    string
+	Fake      bool // keep evidence in memory instead of object storage
 }
 
 // OllamaConfig holds the Ollama LLM server parameters (legacy, still works).
@@ -64,6 +99,9 @@ type OllamaConfig struct {
 // AIConfig holds the unified AI provider configuration.
 // Supports both Ollama (local) and OpenAI-compatible APIs (cloud).
 //
+// Provider "fake": Deterministic canned responses and embeddings, no network.
+// For local development and soak tests without a GPU.
+//
 // Provider "ollama" (default): Uses Ollama at AI_HOST with any model.
 //
 //	Models: llama3.2:3b, mistral, gemma2, qwen2.5, phi4, deepseek-r1:8b, etc.
@@ -75,12 +113,155 @@ type OllamaConfig struct {
 //	Together:    AI_HOST=https://api.together.xyz         AI_MODEL=meta-llama/Llama-3-70b-chat-hf
 //	OpenRouter:  AI_HOST=https://openrouter.ai/api       AI_MODEL=anthropic/claude-sonnet-4-5-20250929
 //	Mistral:     AI_HOST=https://api.mistral.ai          AI_MODEL=mistral-small-latest
+//
+// Provider "anthropic": Uses the Anthropic Messages API for generation.
+//
+//	Anthropic:   AI_HOST=https://api.anthropic.com       AI_MODEL=claude-sonnet-4-5
+//
+// Embeddings use the same provider unless AI_EMBED_PROVIDER is set (required
+// with "anthropic", which serves none), with AI_EMBED_HOST and
+// AI_EMBED_API_KEY for its endpoint.
 type AIConfig struct {
-	Provider      string // "ollama" or "openai"
+	Provider      string // "ollama", "openai", "anthropic", or "fake"
 	Host          string // API base URL
 	APIKey        string // API key (for cloud providers)
 	InstructModel string // model for text generation
 	EmbedModel    string // model for embeddings
+	EmbedProvider string // provider for embeddings; "" for Provider
+	EmbedHost     string // embedding API base URL; "" for Host
+	EmbedAPIKey   string // embedding API key; "" for APIKey when the provider is the same
+	TaskOptions   string // per-task generation options, see ai.ParseTaskOptions
+
+	// EmbedBatchSize and EmbedConcurrency bound the embedding backfill: the
+	// articles sent per batch embedding request and the requests in flight.
+	EmbedBatchSize   int
+	EmbedConcurrency int
+
+	// CacheDays is how long summaries, tags, enrichments and embeddings are
+	// kept in ai_cache and reused for unchanged content; 0 disables the cache.
+	CacheDays int
+
+	// ReclassifyModel is the model admin sentiment re-classification runs
+	// use; larger and slower than InstructModel.
+	ReclassifyModel string
+
+	// TriageSuggestions enables the worker job that proposes save/trash for
+	// new inbox items. Suggestions are never applied without review.
+	TriageSuggestions bool
+}
+
+// SearchConfig holds daily query budgets for the scraped search engines.
+// 0 means unlimited (queries are still counted).
+type SearchConfig struct {
+	DDGDailyBudget        int
+	BingNewsDailyBudget   int
+	GoogleNewsDailyBudget int
+}
+
+// Budgets returns the budgets keyed by engine name as used by the scraper.
+func (c SearchConfig) Budgets() map[string]int {
+	return map[string]int{
+		"ddg":         c.DDGDailyBudget,
+		"bing_news":   c.BingNewsDailyBudget,
+		"google_news": c.GoogleNewsDailyBudget,
+	}
+}
+
+// SocialConfig holds the social network APIs the watchlist uses. The X and
+// Facebook/Instagram agents are skipped without a token; Mastodon status
+// search works without a token on some instances but most require one.
+type SocialConfig struct {
+	MastodonHost  string // instance base URL
+	MastodonToken string // access token with read:search scope
+	XBearerToken  string // X API v2 app bearer token
+	MetaToken     string // Graph API token with page/Instagram read access
+}
+
+// RenderConfig holds the headless Chrome settings used for sources with
+// render_js set and for evidence screenshots.
+type RenderConfig struct {
+	ChromePath  string        // Chrome/Chromium binary; empty searches the usual locations
+	MaxTabs     int           // pages rendered concurrently
+	PageTimeout time.Duration // budget for loading and rendering one page
+	Settle      time.Duration // extra wait after load for client-side rendering
+	Screenshots bool          // capture a full-page PNG with each article's evidence
+}
+
+// LogConfig holds logging parameters.
+type LogConfig struct {
+	Level       string // debug, info, warn or error
+	DebugSample int    // keep 1 in N debug records of each message; 1 keeps all
+}
+
+// BackupConfig holds database backup parameters.
+type BackupConfig struct {
+	Keep int // backups retained under the storage backups/ prefix
+}
+
+// FeedConfig holds the public feed leak detection parameters.
+type FeedConfig struct {
+	LeakIPs    int  // distinct addresses fetching a feed in a day that flag its token
+	AutoRotate bool // replace flagged feed tokens instead of only warning
+}
+
+// IngestConfig holds source discovery parameters.
+type IngestConfig struct {
+	SitemapLookbackDays int // sitemap URLs whose <lastmod> is older are not discovered
+}
+
+// CrawlConfig holds the identity presented to the sites Folio fetches from.
+type CrawlConfig struct {
+	UserAgent    string // full User-Agent override; built from the fields below when empty
+	ContactURL   string // page describing the crawler, advertised in the User-Agent
+	ContactEmail string // sent in the From header and the User-Agent when set
+
+	// Every outbound fetch is logged; entries older than LogDays, and all
+	// but the newest LogMaxRows, are pruned hourly.
+	LogDays    int
+	LogMaxRows int
+}
+
+// TrashConfig holds trashed article purge parameters.
+type TrashConfig struct {
+	PurgeDays     int // trashed articles older than this are deleted; 0 disables purging
+	EmbeddingDays int // trashed articles older than this lose their embedding until restored; 0 keeps it
+}
+
+// ChatConfig holds chat session retention parameters.
+type ChatConfig struct {
+	RetentionDays int // sessions not updated for this long are deleted; 0 keeps them forever
+}
+
+// BriefConfig holds the branding of daily briefs exported to Word.
+type BriefConfig struct {
+	DocxTemplate     string // .docx whose styles and theme the export reuses
+	DocxLogo         string // PNG or JPEG logo for the page header
+	DocxOrganization string // name shown in the page header
+	DocxFont         string
+	DocxHeadingColor string // hex RGB, e.g. 1F3864
+}
+
+// CoverageConfig selects the coverage profile: the jurisdiction monitored,
+// its relevance terms and its language.
+type CoverageConfig struct {
+	ProfilePath string // JSON profile file; empty uses the built-in Puerto Rico profile
+}
+
+// FlagsConfig holds the feature flags assumed until the feature_flags table
+// can first be read.
+type FlagsConfig struct {
+	Defaults string // comma-separated names of the flags that are on
+}
+
+// DefaultsOn returns the names of the flags on by default.
+func (c FlagsConfig) DefaultsOn() []string {
+	var names []string
+	for _, name := range strings.Split(c.Defaults, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
 }
 
 // TelegramConfig holds Telegram bot parameters.
@@ -120,10 +301,21 @@ func Load() Config {
 			Pass:    envOr("DB_PASS", "folio"),
 			DBName:  envOr("DB_NAME", "folio"),
 			SSLMode: envOr("DB_SSLMODE", "disable"),
+
+			SlowQuery:    envOrDuration("DB_SLOW_QUERY", 500*time.Millisecond),
+			QueryTimeout: envOrDuration("DB_QUERY_TIMEOUT", 30*time.Second),
 		},
 		Server: ServerConfig{
 			Port: envOr("SERVER_PORT", ":8080"),
 			Host: envOr("SERVER_HOST", ""),
+			Timeouts: TimeoutConfig{
+				Default: envOrDuration("HTTP_TIMEOUT_DEFAULT", 60*time.Second),
+				Read:    envOrDuration("HTTP_TIMEOUT_READ", 20*time.Second),
+				AI:      envOrDuration("HTTP_TIMEOUT_AI", 5*time.Minute),
+				Export:  envOrDuration("HTTP_TIMEOUT_EXPORT", 15*time.Minute),
+			},
+			FrontendDir:     envOr("FRONTEND_DIR", "./frontend/dist"),
+			DefaultLanguage: envOr("DEFAULT_LANGUAGE", "es"),
 		},
 		S3: S3Config{
 			Endpoint:  envOr("S3_ENDPOINT", ""),
@@ -131,6 +323,7 @@ func Load() Config {
 			AccessKey: envOr("S3_ACCESS_KEY", ""),
 			SecretKey: envOr("S3_SECRET_KEY", ""),
 			Region:    envOr("S3_REGION", "us-ashburn-1"),
+			Fake:      envOrBool("S3_FAKE", false),
 		},
 		Ollama: OllamaConfig{
 			Host:          envOr("OLLAMA_HOST", "http://localhost:11434"),
@@ -143,11 +336,82 @@ func Load() Config {
 			APIKey:        envOr("AI_API_KEY", ""),
 			InstructModel: envOr("AI_MODEL", envOr("OLLAMA_INSTRUCT_MODEL", "llama3.2:3b")),
 			EmbedModel:    envOr("AI_EMBED_MODEL", envOr("OLLAMA_EMBED_MODEL", "nomic-embed-text")),
+			EmbedProvider: envOr("AI_EMBED_PROVIDER", ""),
+			EmbedHost:     envOr("AI_EMBED_HOST", ""),
+			EmbedAPIKey:   envOr("AI_EMBED_API_KEY", ""),
+			TaskOptions:   envOr("AI_TASK_OPTIONS", ""),
+
+			EmbedBatchSize:   envOrInt("AI_EMBED_BATCH_SIZE", 16),
+			EmbedConcurrency: envOrInt("AI_EMBED_CONCURRENCY", 2),
+			CacheDays:        envOrInt("AI_CACHE_DAYS", 30),
+
+			ReclassifyModel: envOr("AI_RECLASSIFY_MODEL", "llama3.1:8b"),
+
+			TriageSuggestions: envOrBool("TRIAGE_SUGGESTIONS", false),
 		},
 		Telegram: TelegramConfig{
 			BotToken:  envOr("TELEGRAM_BOT_TOKEN", ""),
 			Allowlist: envOr("TELEGRAM_ALLOWLIST", ""),
 		},
+		Search: SearchConfig{
+			DDGDailyBudget:        envOrInt("SEARCH_BUDGET_DDG", 0),
+			BingNewsDailyBudget:   envOrInt("SEARCH_BUDGET_BING_NEWS", 0),
+			GoogleNewsDailyBudget: envOrInt("SEARCH_BUDGET_GOOGLE_NEWS", 0),
+		},
+		Social: SocialConfig{
+			MastodonHost:  envOr("MASTODON_HOST", "https://mastodon.social"),
+			MastodonToken: envOr("MASTODON_TOKEN", ""),
+			XBearerToken:  envOr("X_BEARER_TOKEN", ""),
+			MetaToken:     envOr("META_GRAPH_TOKEN", ""),
+		},
+		Backup: BackupConfig{
+			Keep: envOrInt("BACKUP_KEEP", 8),
+		},
+		Render: RenderConfig{
+			ChromePath:  envOr("RENDER_CHROME_PATH", ""),
+			MaxTabs:     envOrInt("RENDER_MAX_TABS", 2),
+			PageTimeout: envOrDuration("RENDER_TIMEOUT", 30*time.Second),
+			Settle:      envOrDuration("RENDER_SETTLE", 2*time.Second),
+			Screenshots: envOrBool("EVIDENCE_SCREENSHOTS", false),
+		},
+		Log: LogConfig{
+			Level:       envOr("LOG_LEVEL", "info"),
+			DebugSample: envOrInt("LOG_DEBUG_SAMPLE", 1),
+		},
+		Feeds: FeedConfig{
+			LeakIPs:    envOrInt("FEED_LEAK_IPS", 5),
+			AutoRotate: envOrBool("FEED_AUTO_ROTATE", false),
+		},
+		Ingest: IngestConfig{
+			SitemapLookbackDays: envOrInt("SITEMAP_LOOKBACK_DAYS", 7),
+		},
+		Trash: TrashConfig{
+			PurgeDays:     envOrInt("TRASH_PURGE_DAYS", 30),
+			EmbeddingDays: envOrInt("TRASH_EMBEDDING_DAYS", 7),
+		},
+		Chat: ChatConfig{
+			RetentionDays: envOrInt("CHAT_RETENTION_DAYS", 90),
+		},
+		Brief: BriefConfig{
+			DocxTemplate:     envOr("BRIEF_DOCX_TEMPLATE", ""),
+			DocxLogo:         envOr("BRIEF_DOCX_LOGO", ""),
+			DocxOrganization: envOr("BRIEF_DOCX_ORG", "Folio"),
+			DocxFont:         envOr("BRIEF_DOCX_FONT", "Calibri"),
+			DocxHeadingColor: envOr("BRIEF_DOCX_HEADING_COLOR", "1F3864"),
+		},
+		Coverage: CoverageConfig{
+			ProfilePath: envOr("COVERAGE_PROFILE", ""),
+		},
+		Flags: FlagsConfig{
+			Defaults: envOr("FLAG_DEFAULTS", "semantic_search,auto_triage,sse_chat"),
+		},
+		Crawl: CrawlConfig{
+			UserAgent:    envOr("CRAWL_USER_AGENT", ""),
+			ContactURL:   envOr("CRAWL_CONTACT_URL", "https://github.com/Saul-Punybz/folio"),
+			ContactEmail: envOr("CRAWL_CONTACT_EMAIL", ""),
+			LogDays:      envOrInt("OUTBOUND_LOG_DAYS", 30),
+			LogMaxRows:   envOrInt("OUTBOUND_LOG_MAX_ROWS", 1000000),
+		},
 	}
 }
 
@@ -169,3 +433,29 @@ func envOrInt(key string, fallback int) int {
 	}
 	return n
 }
+
+func envOrBool(key string, fallback bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fallback
+	}
+	return b
+}
+
+// envOrDuration parses a Go duration ("90s", "5m"); invalid or non-positive
+// values fall back.
+func envOrDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
@@ -0,0 +1,237 @@
+// Package coverage holds the coverage profile of a Folio deployment: the
+// jurisdiction it monitors, how to tell whether text is about it, and the
+// language its coverage and generated text are in. Prompts, web search
+// queries and relevance filters read the profile instead of naming a place,
+// so the same build can monitor another jurisdiction.
+//
+//	if err := coverage.Setup(cfg.Coverage.ProfilePath); err != nil { ... }
+//	p := coverage.Current()
+//
+// Until Setup is called, or when no profile file is configured, the built-in
+// Puerto Rico profile is used.
+package coverage
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"sync/atomic"
+)
+
+// Profile describes the jurisdiction a deployment covers.
+type Profile struct {
+	// Name is the jurisdiction as written in prompts and appended to web
+	// search queries, e.g. "Puerto Rico".
+	Name string `json:"name"`
+	// Region is the region code given to new sources and collected
+	// articles when none is set, e.g. "PR".
+	Region string `json:"region"`
+	// Language is the language of local coverage and of generated text,
+	// "es" or "en".
+	Language string `json:"language"`
+	// Locale is the locale news searches are run in (Google News' hl),
+	// e.g. "es-419". Defaults to "es-419", or "en-US" for English.
+	Locale string `json:"locale"`
+	// NewsRegion is the country or region code news searches are localized
+	// to (Google News' gl), e.g. "PR". Defaults to Region.
+	NewsRegion string `json:"news_region"`
+	// RelevanceTerms are lowercase terms that show text is about the
+	// jurisdiction: its name and demonyms, cities, government domains.
+	RelevanceTerms []string `json:"relevance_terms"`
+	// IrrelevantPatterns are lowercase names of other places. Watchlist hits
+	// and chat web results naming one are dropped unless they also contain
+	// a relevance term.
+	IrrelevantPatterns []string `json:"irrelevant_patterns"`
+	// GenericTerms are lowercase words too broad to search or tag by (the
+	// jurisdiction's name and nicknames); keyword extraction skips them.
+	GenericTerms []string `json:"generic_terms"`
+	// ReferenceSources are the sources generated articles cite first, one
+	// kind per entry with example domains, in Spanish like the prompts.
+	// Defaults to Wikipedia and the jurisdiction's government, universities
+	// and media.
+	ReferenceSources []string `json:"reference_sources"`
+}
+
+// PuertoRico is the built-in profile.
+var PuertoRico = Profile{
+	Name:       "Puerto Rico",
+	Region:     "PR",
+	Language:   "es",
+	Locale:     "es-419",
+	NewsRegion: "PR",
+	RelevanceTerms: []string{
+		"puerto rico", "boricua", "puertorriqueño", "puertorriquena",
+		"san juan", "bayamón", "bayamon", "ponce", "caguas", "mayagüez", "mayaguez",
+		"carolina pr", "arecibo", "guaynabo", "isla del encanto",
+		".pr/", "gobierno.pr",
+	},
+	IrrelevantPatterns: []string{
+		"dominicana", "dominicano", "santo domingo", "república dominicana", "republica dominicana",
+		"mexico", "méxico", "colombia", "venezuela", "argentina",
+		"españa", "spain", "paraguay", "chile", "perú", "peru",
+		"cuba", "panamá", "panama", "ecuador", "bolivia",
+		"guatemala", "honduras", "el salvador", "nicaragua", "costa rica",
+		"new jersey", "new york city", "florida man",
+		"india", "pakistan", "passport india",
+	},
+	GenericTerms: []string{"puerto rico", "puerto", "rico", "isla", "boricua"},
+	ReferenceSources: []string{
+		"Wikipedia en español (es.wikipedia.org)",
+		"Agencias de gobierno de PR (.pr.gov, energia.pr.gov, aee.pr.gov)",
+		"Gobierno federal de EE.UU. (.gov - DOE, EPA, EIA, FEMA)",
+		"Universidades de PR (UPR, Inter, Sagrado)",
+		"Organizaciones internacionales (ONU, IRENA, IEA)",
+		"Medios de PR verificados (elnuevodia.com, primerahora.com, metro.pr)",
+	},
+}
+
+var current atomic.Pointer[Profile]
+
+func init() {
+	p := PuertoRico
+	current.Store(&p)
+}
+
+// Current returns the profile in use.
+func Current() Profile {
+	return *current.Load()
+}
+
+// Set installs p as the process-wide profile.
+func Set(p Profile) {
+	current.Store(&p)
+}
+
+// Setup loads the profile from the JSON file at path and installs it; an
+// empty path keeps the built-in Puerto Rico profile. Call it once at startup.
+func Setup(path string) error {
+	if path == "" {
+		return nil
+	}
+	p, err := Load(path)
+	if err != nil {
+		return err
+	}
+	Set(p)
+	return nil
+}
+
+// Load reads a profile from a JSON file. Name and Region are required;
+// Language defaults to "es", and Locale, NewsRegion and ReferenceSources
+// follow from the rest. Terms are lowercased.
+func Load(path string) (Profile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Profile{}, fmt.Errorf("coverage profile: %w", err)
+	}
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Profile{}, fmt.Errorf("coverage profile %s: %w", path, err)
+	}
+	p.Name = strings.TrimSpace(p.Name)
+	p.Region = strings.TrimSpace(p.Region)
+	if p.Name == "" || p.Region == "" {
+		return Profile{}, fmt.Errorf("coverage profile %s: name and region are required", path)
+	}
+	switch p.Language {
+	case "":
+		p.Language = "es"
+	case "es", "en":
+	default:
+		return Profile{}, fmt.Errorf("coverage profile %s: language must be es or en", path)
+	}
+	if p.Locale = strings.TrimSpace(p.Locale); p.Locale == "" {
+		p.Locale = "es-419"
+		if p.Language == "en" {
+			p.Locale = "en-US"
+		}
+	}
+	if p.NewsRegion = strings.TrimSpace(p.NewsRegion); p.NewsRegion == "" {
+		p.NewsRegion = p.Region
+	}
+	if len(p.ReferenceSources) == 0 {
+		p.ReferenceSources = []string{
+			fmt.Sprintf("Wikipedia en %s (%s.wikipedia.org)", p.LanguageName(), p.Language),
+			"Agencias de gobierno de " + p.Name,
+			"Universidades de " + p.Name,
+			"Organizaciones internacionales (ONU, IRENA, IEA)",
+			"Medios de " + p.Name + " verificados",
+		}
+	}
+	p.RelevanceTerms = lowerAll(p.RelevanceTerms)
+	p.IrrelevantPatterns = lowerAll(p.IrrelevantPatterns)
+	p.GenericTerms = lowerAll(p.GenericTerms)
+	if len(p.RelevanceTerms) == 0 {
+		p.RelevanceTerms = []string{strings.ToLower(p.Name)}
+	}
+	return p, nil
+}
+
+// Mentions reports whether text contains one of the relevance terms.
+func (p Profile) Mentions(text string) bool {
+	lower := strings.ToLower(text)
+	for _, term := range p.RelevanceTerms {
+		if strings.Contains(lower, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// IrrelevantPattern returns the first irrelevant pattern text contains, or
+// "" when there is none or text also mentions the jurisdiction.
+func (p Profile) IrrelevantPattern(text string) string {
+	lower := strings.ToLower(text)
+	if p.Mentions(lower) {
+		return ""
+	}
+	for _, pat := range p.IrrelevantPatterns {
+		if strings.Contains(lower, pat) {
+			return pat
+		}
+	}
+	return ""
+}
+
+// IsGeneric reports whether a lowercase word or phrase is one of the
+// generic terms.
+func (p Profile) IsGeneric(term string) bool {
+	for _, g := range p.GenericTerms {
+		if term == g {
+			return true
+		}
+	}
+	return false
+}
+
+// LanguageName returns the profile language's name in Spanish ("español",
+// "inglés"), for the Spanish-language prompts.
+func (p Profile) LanguageName() string {
+	if p.Language == "en" {
+		return "inglés"
+	}
+	return "español"
+}
+
+// GoogleNewsSearchURL returns the Google News RSS search URL for query,
+// localized to the profile's locale and news region.
+func (p Profile) GoogleNewsSearchURL(query string) string {
+	// ceid repeats the region and the locale's language, which drops the
+	// region when it is the same ("US:en" for en-US, "PR:es-419").
+	lang := strings.TrimSuffix(p.Locale, "-"+p.NewsRegion)
+	return fmt.Sprintf("https://news.google.com/rss/search?q=%s&hl=%s&gl=%s&ceid=%s:%s",
+		url.QueryEscape(query), url.QueryEscape(p.Locale), url.QueryEscape(p.NewsRegion),
+		url.QueryEscape(p.NewsRegion), url.QueryEscape(lang))
+}
+
+func lowerAll(terms []string) []string {
+	out := make([]string, 0, len(terms))
+	for _, t := range terms {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+package coverage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGoogleNewsSearchURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile Profile
+		want    string
+	}{
+		{
+			name:    "built-in profile",
+			profile: PuertoRico,
+			want:    "https://news.google.com/rss/search?q=junta+de+planificaci%C3%B3n&hl=es-419&gl=PR&ceid=PR:es-419",
+		},
+		{
+			name:    "locale naming the news region",
+			profile: Profile{Locale: "en-US", NewsRegion: "US"},
+			want:    "https://news.google.com/rss/search?q=junta+de+planificaci%C3%B3n&hl=en-US&gl=US&ceid=US:en",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.profile.GoogleNewsSearchURL("junta de planificación"); got != tt.want {
+				t.Errorf("GoogleNewsSearchURL = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "coverage.json")
+	if err := os.WriteFile(path, []byte(`{"name": "Guam", "region": "GU", "language": "en"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if p.Locale != "en-US" || p.NewsRegion != "GU" {
+		t.Errorf("locale %q, news region %q; want en-US, GU", p.Locale, p.NewsRegion)
+	}
+	if len(p.ReferenceSources) == 0 || p.ReferenceSources[0] != "Wikipedia en inglés (en.wikipedia.org)" {
+		t.Errorf("reference sources = %q", p.ReferenceSources)
+	}
+}
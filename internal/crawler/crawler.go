@@ -24,7 +24,7 @@ type Deps struct {
 	Entities *models.EntityStore
 	PageEnts *models.PageEntityStore
 	Rels     *models.EntityRelationshipStore
-	AI       *ai.OllamaClient
+	AI       ai.Provider
 }
 
 const (
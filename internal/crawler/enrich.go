@@ -30,27 +30,14 @@ func EnrichPage(ctx context.Context, deps Deps, page *models.CrawledPage) error
 		return nil
 	}
 
-	// 1. Summarize
-	summary, err := deps.AI.Summarize(ctx, text)
+	// 1-3. Summarize, classify tags and extract entities
+	enrichment, err := deps.AI.Enrich(ctx, text)
 	if err != nil {
-		slog.Warn("crawler/enrich: summarize", "id", page.ID, "err", err)
-		summary = ""
-	}
-
-	// 2. Classify tags
-	tags, err := deps.AI.Classify(ctx, text)
-	if err != nil {
-		slog.Warn("crawler/enrich: classify", "id", page.ID, "err", err)
-		tags = nil
+		slog.Warn("crawler/enrich: enrich", "id", page.ID, "err", err)
+		enrichment = &ai.Enrichment{}
 	}
+	summary, tags, extracted := enrichment.Summary, enrichment.Tags, &enrichment.Entities
 	tagsJSON, _ := json.Marshal(tags)
-
-	// 3. Extract entities
-	extracted, err := deps.AI.ExtractEntities(ctx, text)
-	if err != nil {
-		slog.Warn("crawler/enrich: extract entities", "id", page.ID, "err", err)
-		extracted = &ai.ExtractedEntities{}
-	}
 	entitiesJSON, _ := json.Marshal(extracted)
 
 	// 4. Sentiment
@@ -98,7 +85,7 @@ func EnrichPage(ctx context.Context, deps Deps, page *models.CrawledPage) error
 
 // DetectChangeSummary uses AI to describe what changed between old and new
 // content.
-func DetectChangeSummary(ctx context.Context, aiClient *ai.OllamaClient, oldText, newText string) (string, error) {
+func DetectChangeSummary(ctx context.Context, aiClient ai.Provider, oldText, newText string) (string, error) {
 	if aiClient == nil {
 		return "", nil
 	}
@@ -113,7 +100,7 @@ func DetectChangeSummary(ctx context.Context, aiClient *ai.OllamaClient, oldText
 		"Compare these two versions of a web page and describe what changed in 1-2 sentences. Focus on substantive content changes, not formatting.\n\nOLD VERSION:\n%s\n\nNEW VERSION:\n%s\n\nWhat changed:",
 		oldText, newText,
 	)
-	summary, err := aiClient.Generate(ctx, "You are a change detection assistant. Be concise and factual.", prompt)
+	summary, err := aiClient.Generate(ai.WithTask(ctx, ai.TaskSummarize), "You are a change detection assistant. Be concise and factual.", prompt)
 	if err != nil {
 		return "", fmt.Errorf("detect change summary: %w", err)
 	}
@@ -122,7 +109,7 @@ func DetectChangeSummary(ctx context.Context, aiClient *ai.OllamaClient, oldText
 
 // ExtractRelationships uses AI to infer relationships between entities found
 // in text.
-func ExtractRelationships(ctx context.Context, aiClient *ai.OllamaClient, entities *ai.ExtractedEntities, text string) ([]RelationshipTriple, error) {
+func ExtractRelationships(ctx context.Context, aiClient ai.Provider, entities *ai.ExtractedEntities, text string) ([]RelationshipTriple, error) {
 	if aiClient == nil {
 		return nil, nil
 	}
@@ -154,7 +141,7 @@ Example: [{"source":"Juan Garcia","target":"Senado de PR","relation":"member_of"
 		strings.Join(allNames, ", "), text,
 	)
 
-	response, err := aiClient.Generate(ctx, "You are a relationship extraction assistant. Return only valid JSON.", prompt)
+	response, err := aiClient.Generate(ai.WithTask(ctx, ai.TaskExtract), "You are a relationship extraction assistant. Return only valid JSON.", prompt)
 	if err != nil {
 		return nil, fmt.Errorf("extract relationships: %w", err)
 	}
@@ -10,7 +10,9 @@ import (
 
 	"github.com/gocolly/colly/v2"
 
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
 	"github.com/Saul-Punybz/folio/internal/scraper"
+	"github.com/Saul-Punybz/folio/internal/useragent"
 )
 
 // DiscoveredLink represents a link found on a crawled page.
@@ -37,10 +39,11 @@ type FetchResult struct {
 // Colly collector with respectful rate limiting.
 func FetchPage(ctx context.Context, pageURL string, allowedDomains map[string]bool) (*FetchResult, error) {
 	c := colly.NewCollector(
-		colly.UserAgent("FolioBot/1.0 (+https://folio.pr)"),
+		colly.UserAgent(useragent.String()),
 		colly.AllowURLRevisit(),
 		colly.MaxDepth(0),
 	)
+	c.WithTransport(fetchlog.Transport(nil, fetchlog.Crawl))
 
 	_ = c.Limit(&colly.LimitRule{
 		DomainGlob:  "*",
@@ -52,6 +55,9 @@ func FetchPage(ctx context.Context, pageURL string, allowedDomains map[string]bo
 	c.OnRequest(func(r *colly.Request) {
 		r.Headers.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
 		r.Headers.Set("Accept-Language", "en-US,en;q=0.9,es;q=0.8")
+		if email := useragent.Email(); email != "" {
+			r.Headers.Set("From", email)
+		}
 	})
 
 	parsedBase, err := url.Parse(pageURL)
@@ -68,6 +74,9 @@ func FetchPage(ctx context.Context, pageURL string, allowedDomains map[string]bo
 	result.URL = pageURL
 
 	c.OnResponse(func(r *colly.Response) {
+		if ct := r.Headers.Get("Content-Type"); strings.Contains(strings.ToLower(ct), "html") || ct == "" {
+			r.Body = scraper.ToUTF8(r.Body, ct)
+		}
 		mu.Lock()
 		result.StatusCode = r.StatusCode
 		result.RawHTML = string(r.Body)
@@ -49,11 +49,14 @@ func ConnectWithFS(ctx context.Context, cfg config.DBConfig, migrationsFS fs.FS)
 }
 
 // ConnectWithDSN creates a pool using a raw DSN string (for embedded PG).
-func ConnectWithDSN(ctx context.Context, dsn string, migrationsFS fs.FS) (*pgxpool.Pool, error) {
+// Only the slow query threshold and query timeout are taken from cfg; the
+// DSN replaces its connection settings.
+func ConnectWithDSN(ctx context.Context, dsn string, cfg config.DBConfig, migrationsFS fs.FS) (*pgxpool.Pool, error) {
 	poolCfg, err := pgxpool.ParseConfig(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("db: parse config: %w", err)
 	}
+	poolCfg.ConnConfig.Tracer = newTracer(cfg.SlowQuery, cfg.QueryTimeout)
 
 	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
@@ -80,6 +83,7 @@ func connectPool(ctx context.Context, cfg config.DBConfig) (*pgxpool.Pool, error
 	if err != nil {
 		return nil, fmt.Errorf("db: parse config: %w", err)
 	}
+	poolCfg.ConnConfig.Tracer = newTracer(cfg.SlowQuery, cfg.QueryTimeout)
 
 	pool, err := pgxpool.NewWithConfig(ctx, poolCfg)
 	if err != nil {
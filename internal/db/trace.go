@@ -0,0 +1,190 @@
+package db
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultSlowQuery    = 500 * time.Millisecond
+	defaultQueryTimeout = 30 * time.Second
+
+	// maxSlowQueries bounds the distinct statements kept for SlowQueries.
+	maxSlowQueries = 200
+	// maxSQLLen truncates statements in logs and stats.
+	maxSQLLen = 300
+)
+
+// SlowQuery aggregates the slow executions of one statement.
+type SlowQuery struct {
+	SQL       string    `json:"sql"`
+	Count     int       `json:"count"`
+	Timeouts  int       `json:"timeouts"` // hit the per-query timeout
+	TotalMS   int64     `json:"total_ms"`
+	MaxMS     int64     `json:"max_ms"`
+	LastRoute string    `json:"last_route,omitempty"`
+	LastSeen  time.Time `json:"last_seen"`
+
+	total, max time.Duration
+}
+
+// tracer is a pgx.QueryTracer that bounds queries run for an HTTP request by
+// the query timeout (and so never past the request's own deadline), and logs
+// queries slower than the threshold with the route and request ID.
+type tracer struct {
+	slow    time.Duration
+	timeout time.Duration
+}
+
+func newTracer(slow, timeout time.Duration) *tracer {
+	if slow <= 0 {
+		slow = defaultSlowQuery
+	}
+	if timeout <= 0 {
+		timeout = defaultQueryTimeout
+	}
+	return &tracer{slow: slow, timeout: timeout}
+}
+
+type traceKey struct{}
+
+type queryTrace struct {
+	sql    string
+	start  time.Time
+	cancel context.CancelFunc
+}
+
+// TraceQueryStart implements pgx.QueryTracer. pgx runs the query under the
+// returned context, and for Query until its rows are closed.
+func (t *tracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	q := &queryTrace{sql: data.SQL, start: time.Now()}
+	// Only request queries get a deadline: migrations and worker batches
+	// legitimately run longer.
+	if chi.RouteContext(ctx) != nil {
+		ctx, q.cancel = context.WithTimeout(ctx, t.timeout)
+	}
+	return context.WithValue(ctx, traceKey{}, q)
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (t *tracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	q, ok := ctx.Value(traceKey{}).(*queryTrace)
+	if !ok {
+		return
+	}
+	timedOut := ctx.Err() == context.DeadlineExceeded
+	if q.cancel != nil {
+		q.cancel()
+	}
+
+	d := time.Since(q.start)
+	if d < t.slow {
+		return
+	}
+	route := routePattern(ctx)
+	sql := normalizeSQL(q.sql)
+	slowQueries.record(sql, route, d, timedOut)
+	slog.WarnContext(ctx, "db: slow query",
+		"duration_ms", d.Milliseconds(),
+		"route", route,
+		"request_id", chimw.GetReqID(ctx),
+		"timed_out", timedOut,
+		"sql", sql,
+		"err", data.Err,
+	)
+}
+
+// routePattern returns the chi route ("GET /api/items/{id}") the query ran
+// for, or "" outside a request.
+func routePattern(ctx context.Context) string {
+	rc := chi.RouteContext(ctx)
+	if rc == nil {
+		return ""
+	}
+	if p := rc.RoutePattern(); p != "" {
+		return rc.RouteMethod + " " + p
+	}
+	return rc.RouteMethod + " " + rc.RoutePath
+}
+
+// normalizeSQL collapses whitespace so one statement aggregates under one key.
+func normalizeSQL(sql string) string {
+	s := strings.Join(strings.Fields(sql), " ")
+	if len(s) > maxSQLLen {
+		s = s[:maxSQLLen] + "…"
+	}
+	return s
+}
+
+// slowLog holds this process's slow query aggregates since startup.
+type slowLog struct {
+	mu      sync.Mutex
+	queries map[string]*SlowQuery
+}
+
+var slowQueries = &slowLog{queries: make(map[string]*SlowQuery)}
+
+func (l *slowLog) record(sql, route string, d time.Duration, timedOut bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	q, ok := l.queries[sql]
+	if !ok {
+		if len(l.queries) >= maxSlowQueries {
+			l.evict()
+		}
+		q = &SlowQuery{SQL: sql}
+		l.queries[sql] = q
+	}
+	q.Count++
+	if timedOut {
+		q.Timeouts++
+	}
+	q.total += d
+	if d > q.max {
+		q.max = d
+	}
+	if route != "" {
+		q.LastRoute = route
+	}
+	q.LastSeen = time.Now()
+}
+
+// evict drops the statement with the least total time. Callers hold mu.
+func (l *slowLog) evict() {
+	var victim string
+	var least time.Duration = -1
+	for sql, q := range l.queries {
+		if least < 0 || q.total < least {
+			victim, least = sql, q.total
+		}
+	}
+	delete(l.queries, victim)
+}
+
+// SlowQueries returns up to n statements that ran slower than the threshold,
+// most total time spent first.
+func SlowQueries(n int) []SlowQuery {
+	slowQueries.mu.Lock()
+	out := make([]SlowQuery, 0, len(slowQueries.queries))
+	for _, q := range slowQueries.queries {
+		c := *q
+		c.TotalMS, c.MaxMS = q.total.Milliseconds(), q.max.Milliseconds()
+		out = append(out, c)
+	}
+	slowQueries.mu.Unlock()
+
+	sort.Slice(out, func(i, j int) bool { return out[i].total > out[j].total })
+	if len(out) > n {
+		out = out[:n]
+	}
+	return out
+}
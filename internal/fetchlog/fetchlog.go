@@ -0,0 +1,194 @@
+// Package fetchlog records every request Folio makes to another site in the
+// outbound_fetches table, with the purpose it was made for, so publishers
+// asking what Folio fetched from them can be answered.
+//
+//	go fetchlog.Run(ctx, models.NewOutboundFetchStore(pool))
+//	ctx = fetchlog.WithPurpose(ctx, fetchlog.Ingestion)
+//	resp, err := fetchlog.Client.Do(req.WithContext(ctx))
+//
+// Fetches are queued and written in batches by Run; when the queue is full
+// (or Run is not running) they are dropped rather than slowing the fetch.
+package fetchlog
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// Purposes a fetch is made for.
+const (
+	Ingestion  = "ingestion"  // source feeds, sitemaps and article pages
+	Watchlist  = "watchlist"  // watchlist agents
+	Chat       = "chat"       // web search for chat answers
+	Enrichment = "enrichment" // images, archive snapshots, org keyword discovery
+	Crawl      = "crawl"      // the site crawler
+	Research   = "research"   // research projects
+	Share      = "share"      // article images for share cards
+	Other      = "other"
+)
+
+const (
+	queueSize     = 4096
+	batchSize     = 500
+	flushInterval = 5 * time.Second
+	writeTimeout  = 10 * time.Second
+)
+
+var (
+	queue   = make(chan models.OutboundFetch, queueSize)
+	dropped atomic.Int64
+)
+
+type purposeKey struct{}
+
+// WithPurpose returns a context whose fetches are recorded with purpose.
+func WithPurpose(ctx context.Context, purpose string) context.Context {
+	return context.WithValue(ctx, purposeKey{}, purpose)
+}
+
+// PurposeFrom returns the purpose set on ctx, or Other.
+func PurposeFrom(ctx context.Context) string {
+	if p, ok := ctx.Value(purposeKey{}).(string); ok && p != "" {
+		return p
+	}
+	return Other
+}
+
+// Client is an http.Client whose requests are recorded.
+var Client = &http.Client{Transport: Transport(nil, "")}
+
+// Transport returns a RoundTripper that records each request made through
+// base (http.DefaultTransport when nil). An empty purpose is taken from the
+// request's context; colly collectors, whose requests carry no context, get
+// a fixed one.
+func Transport(base http.RoundTripper, purpose string) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{base: base, purpose: purpose}
+}
+
+type transport struct {
+	base    http.RoundTripper
+	purpose string
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	f := models.OutboundFetch{
+		FetchedAt: time.Now(),
+		Purpose:   t.purpose,
+		Method:    req.Method,
+		URL:       req.URL.Redacted(),
+		Domain:    models.NormalizeFetchDomain(req.URL.Hostname()),
+	}
+	if f.Purpose == "" {
+		f.Purpose = PurposeFrom(req.Context())
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		f.DurationMS = int(time.Since(f.FetchedAt).Milliseconds())
+		f.Error = err.Error()
+		record(f)
+		return nil, err
+	}
+	f.Status = resp.StatusCode
+	resp.Body = &countingBody{ReadCloser: resp.Body, fetch: f}
+	return resp, nil
+}
+
+// countingBody records its fetch, with the bytes read, when closed.
+type countingBody struct {
+	io.ReadCloser
+	fetch models.OutboundFetch
+	once  sync.Once
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.fetch.Bytes += int64(n)
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(func() {
+		b.fetch.DurationMS = int(time.Since(b.fetch.FetchedAt).Milliseconds())
+		record(b.fetch)
+	})
+	return err
+}
+
+// record queues a fetch for Run, dropping it when the queue is full.
+func record(f models.OutboundFetch) {
+	select {
+	case queue <- f:
+	default:
+		dropped.Add(1)
+	}
+}
+
+// Run writes queued fetches to store in batches until ctx is done, then
+// writes what is left.
+func Run(ctx context.Context, store *models.OutboundFetchStore) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	batch := make([]models.OutboundFetch, 0, batchSize)
+	flush := func() {
+		if n := dropped.Swap(0); n > 0 {
+			slog.Warn("fetch log: queue full, fetches not recorded", "dropped", n)
+		}
+		if len(batch) == 0 {
+			return
+		}
+		wctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), writeTimeout)
+		defer cancel()
+		if err := store.InsertBatch(wctx, batch); err != nil {
+			slog.Error("fetch log: write", "fetches", len(batch), "err", err)
+		}
+		batch = batch[:0]
+	}
+
+	for {
+		select {
+		case f := <-queue:
+			batch = append(batch, f)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		case <-ctx.Done():
+			for {
+				select {
+				case f := <-queue:
+					batch = append(batch, f)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// Prune applies the log's retention: entries older than days, and all but
+// the newest maxRows, are deleted.
+func Prune(ctx context.Context, store *models.OutboundFetchStore, days, maxRows int) {
+	pruned, err := store.Prune(ctx, days, maxRows)
+	if err != nil {
+		slog.Error("fetch log: prune", "err", err)
+		return
+	}
+	if pruned > 0 {
+		slog.Info("fetch log: pruned", "deleted", pruned)
+	}
+}
@@ -0,0 +1,167 @@
+// Package flags evaluates feature flags so large features can be dark
+// launched and rolled out to users gradually, without a separate deployment.
+//
+//	if flags.Enabled(ctx, flags.SemanticSearch) { ... }
+//
+// Flags are stored in the feature_flags table (see models.FeatureFlagStore)
+// and cached per process; admin edits made through one process reach the
+// others within cacheTTL.
+package flags
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/middleware"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// Flags checked in code. Each is seeded by a migration.
+const (
+	SemanticSearch = "semantic_search" // semantic and hybrid search modes
+	AutoTriage     = "auto_triage"     // AI triage suggestions in the inbox
+	SSEChat        = "sse_chat"        // streaming chat
+)
+
+// Source supplies the current flags keyed by name.
+type Source interface {
+	FeatureFlags(ctx context.Context) (map[string]models.FeatureFlag, error)
+}
+
+// cacheTTL is how long loaded flags are used before they are reloaded.
+const cacheTTL = 30 * time.Second
+
+type cache struct {
+	source   Source
+	defaults map[string]models.FeatureFlag // used until the first load succeeds
+
+	mu       sync.Mutex
+	flags    map[string]models.FeatureFlag
+	loadedAt time.Time
+}
+
+var current atomic.Pointer[cache]
+
+// SetSource installs the store flags are read from, and the flags that are on
+// (for everyone) while it cannot be read at first. Until it is called every
+// flag is off.
+func SetSource(src Source, defaults []string) {
+	c := &cache{source: src, defaults: make(map[string]models.FeatureFlag, len(defaults))}
+	for _, name := range defaults {
+		c.defaults[name] = models.FeatureFlag{Name: name, Enabled: true, RolloutPercent: 100}
+	}
+	current.Store(c)
+}
+
+// Invalidate makes the next check reload the flags. Call it after editing
+// flags or overrides.
+func Invalidate() {
+	if c := current.Load(); c != nil {
+		c.mu.Lock()
+		c.loadedAt = time.Time{}
+		c.mu.Unlock()
+	}
+}
+
+// Enabled reports whether the flag is on for the user authenticated on ctx.
+// Without a user (background jobs) only a flag enabled for everyone is on.
+func Enabled(ctx context.Context, name string) bool {
+	var userID uuid.UUID
+	if u := middleware.UserFromContext(ctx); u != nil {
+		userID = u.ID
+	}
+	return EnabledFor(ctx, name, userID)
+}
+
+// EnabledFor reports whether the flag is on for a user; uuid.Nil means no
+// user.
+func EnabledFor(ctx context.Context, name string, userID uuid.UUID) bool {
+	f, ok := lookup(ctx, name)
+	if !ok {
+		return false
+	}
+	return evaluate(f, userID)
+}
+
+// All evaluates every known flag for the user authenticated on ctx.
+func All(ctx context.Context) map[string]bool {
+	var userID uuid.UUID
+	if u := middleware.UserFromContext(ctx); u != nil {
+		userID = u.ID
+	}
+	all := load(ctx)
+	result := make(map[string]bool, len(all))
+	for name, f := range all {
+		result[name] = evaluate(f, userID)
+	}
+	return result
+}
+
+// evaluate applies a user's override, then the flag's enabled state and
+// rollout percentage.
+func evaluate(f models.FeatureFlag, userID uuid.UUID) bool {
+	if userID != uuid.Nil {
+		if on, ok := f.Overrides[userID]; ok {
+			return on
+		}
+	}
+	if !f.Enabled {
+		return false
+	}
+	if f.RolloutPercent >= 100 {
+		return true
+	}
+	if userID == uuid.Nil || f.RolloutPercent <= 0 {
+		return false
+	}
+	return bucket(f.Name, userID) < f.RolloutPercent
+}
+
+// bucket places a user in 0..99 for a flag. Hashing the flag name with the
+// user id spreads early adopters across flags.
+func bucket(name string, userID uuid.UUID) int {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	h.Write(userID[:])
+	return int(h.Sum32() % 100)
+}
+
+func lookup(ctx context.Context, name string) (models.FeatureFlag, bool) {
+	f, ok := load(ctx)[name]
+	return f, ok
+}
+
+// load returns the cached flags, reloading them when stale. If a reload
+// fails the previous flags are kept; if the first load fails the defaults
+// are used until a load succeeds.
+func load(ctx context.Context) map[string]models.FeatureFlag {
+	c := current.Load()
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.flags != nil && time.Since(c.loadedAt) < cacheTTL {
+		return c.flags
+	}
+
+	all, err := c.source.FeatureFlags(ctx)
+	switch {
+	case err != nil && c.flags == nil:
+		slog.Warn("flags: load feature flags; using the defaults", "defaults", len(c.defaults), "err", err)
+		c.flags = c.defaults
+	case err != nil:
+		slog.Warn("flags: load feature flags", "err", err)
+	default:
+		c.flags = all
+	}
+	c.loadedAt = time.Now()
+	return c.flags
+}
@@ -0,0 +1,53 @@
+package flags
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// fakeSource returns flags, or err when it is set.
+type fakeSource struct {
+	flags map[string]models.FeatureFlag
+	err   error
+}
+
+func (s *fakeSource) FeatureFlags(ctx context.Context) (map[string]models.FeatureFlag, error) {
+	return s.flags, s.err
+}
+
+func TestDefaultsUntilFirstLoad(t *testing.T) {
+	ctx := context.Background()
+	src := &fakeSource{err: errors.New("connection refused")}
+	SetSource(src, []string{SemanticSearch, SSEChat})
+	t.Cleanup(func() { current.Store(nil) })
+
+	user := uuid.New()
+	for name, want := range map[string]bool{SemanticSearch: true, SSEChat: true, AutoTriage: false} {
+		if got := EnabledFor(ctx, name, user); got != want {
+			t.Errorf("database down: %s = %v, want the default %v", name, got, want)
+		}
+	}
+
+	src.flags, src.err = map[string]models.FeatureFlag{
+		SemanticSearch: {Name: SemanticSearch, Enabled: false, RolloutPercent: 100},
+		AutoTriage:     {Name: AutoTriage, Enabled: true, RolloutPercent: 100},
+	}, nil
+	Invalidate()
+	for name, want := range map[string]bool{SemanticSearch: false, SSEChat: false, AutoTriage: true} {
+		if got := EnabledFor(ctx, name, user); got != want {
+			t.Errorf("after loading: %s = %v, want %v", name, got, want)
+		}
+	}
+
+	// A later failure keeps the loaded flags rather than the defaults.
+	src.err = errors.New("connection refused")
+	Invalidate()
+	if EnabledFor(ctx, SemanticSearch, user) {
+		t.Errorf("reload failure brought back the default for %s", SemanticSearch)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/coverage"
 	"github.com/Saul-Punybz/folio/internal/models"
 )
 
@@ -21,7 +22,7 @@ type Deps struct {
 	Escritos *models.EscritoStore
 	Sources  *models.EscritoSourceStore
 	Articles *models.ArticleStore
-	AI       *ai.OllamaClient
+	AI       ai.Provider
 }
 
 // ArticleSection represents a planned section for the article.
@@ -231,7 +232,7 @@ func runPhase2(ctx context.Context, deps Deps, escrito *models.Escrito, sources
 		contextBuf.WriteString(entry)
 	}
 
-	systemPrompt := `Eres un editor SEO experto en contenido en español para Puerto Rico.
+	systemPrompt := `Eres un editor SEO experto en contenido en español para ` + coverage.Current().Name + `.
 Tu tarea es crear un plan de articulo SEO basado en las fuentes proporcionadas.
 
 REGLAS ESTRICTAS:
@@ -255,7 +256,7 @@ Fuentes disponibles:
 Genera el plan de articulo como JSON array. Ejemplo de formato:
 [{"heading":"Introduccion","angle":"APP formula: contexto del tema en PR","word_target":180},{"heading":"Estado Actual de [Tema]","angle":"situacion presente con datos","word_target":300}]`, escrito.Topic, contextBuf.String())
 
-	result, err := deps.AI.Generate(ctx, systemPrompt, userPrompt)
+	result, err := deps.AI.Generate(ai.WithTask(ctx, ai.TaskDraft), systemPrompt, userPrompt)
 	if err != nil {
 		return nil, fmt.Errorf("AI planning: %w", err)
 	}
@@ -351,39 +352,38 @@ func runPhase3(ctx context.Context, deps Deps, escrito *models.Escrito, sources
 	return nil
 }
 
-func generateSection(ctx context.Context, aiClient *ai.OllamaClient, topic string, section ArticleSection, srcContext string) (string, error) {
+func generateSection(ctx context.Context, aiClient ai.Provider, topic string, section ArticleSection, srcContext string) (string, error) {
 	isReferences := strings.Contains(strings.ToLower(section.Heading), "referencia") ||
 		strings.Contains(strings.ToLower(section.Heading), "recurso") ||
 		strings.Contains(strings.ToLower(section.Heading), "bibliograf")
 
+	profile := coverage.Current()
 	var systemPrompt string
 	if isReferences {
-		systemPrompt = fmt.Sprintf(`Eres un investigador experto que compila bibliografias para articulos en español sobre Puerto Rico.
+		var sources strings.Builder
+		for _, src := range profile.ReferenceSources {
+			sources.WriteString("\n  * " + src)
+		}
+		systemPrompt = fmt.Sprintf(`Eres un investigador experto que compila bibliografias para articulos en español sobre %s.
 Genera la seccion "%s" para un articulo sobre "%s".
 
 REGLAS ESTRICTAS:
 - Compila una lista de 8-15 fuentes externas REALES y verificables
 - Formato markdown con hyperlinks: [Nombre de la Fuente](URL)
-- PRIORIZA estas fuentes reales:
-  * Wikipedia en español (es.wikipedia.org)
-  * Agencias de gobierno de PR (.pr.gov, energia.pr.gov, aee.pr.gov)
-  * Gobierno federal de EE.UU. (.gov - DOE, EPA, EIA, FEMA)
-  * Universidades de PR (UPR, Inter, Sagrado)
-  * Organizaciones internacionales (ONU, IRENA, IEA)
-  * Medios de PR verificados (elnuevodia.com, primerahora.com, metro.pr)
+- PRIORIZA estas fuentes reales:%s
 - Las URLs deben ser de dominios reales que existen
 - Agrupa por tipo: Gobierno, Academicas, Organizaciones, Medios
 - Comienza con "## %s"
-- Responde SOLO con el contenido de la seccion`, section.Heading, topic, section.Heading)
+- Responde SOLO con el contenido de la seccion`, profile.Name, section.Heading, topic, sources.String(), section.Heading)
 	} else {
-		systemPrompt = fmt.Sprintf(`Eres un escritor SEO experto en español para Puerto Rico.
+		systemPrompt = fmt.Sprintf(`Eres un escritor SEO experto en español para `+profile.Name+`.
 Escribe la seccion "%s" de un articulo sobre "%s".
 
 REGLAS ESTRICTAS:
 - Escribe EXACTAMENTE en español
 - NO uses frases como "es importante destacar", "cabe señalar", "sin duda alguna"
 - NO uses lenguaje artificial o generico
-- Escribe con voz activa, datos concretos, ejemplos locales de PR
+- Escribe con voz activa, datos concretos, ejemplos locales de `+profile.Name+`
 - Cuando cites un dato, estadistica o hecho, incluye la fuente entre parentesis
   Ejemplo: "La generacion solar aumento un 45%% en 2024 (Autoridad de Energia Electrica de PR)"
 - Incluye 1-2 hyperlinks por seccion a fuentes externas reales en formato markdown
@@ -403,7 +403,7 @@ Informacion de fuentes:
 
 Escribe la seccion ahora:`, section.Angle, truncate(srcContext, 4000))
 
-	result, err := aiClient.Generate(ctx, systemPrompt, userPrompt)
+	result, err := aiClient.Generate(ai.WithTask(ctx, ai.TaskDraft), systemPrompt, userPrompt)
 	if err != nil {
 		return "", err
 	}
@@ -411,7 +411,7 @@ Escribe la seccion ahora:`, section.Angle, truncate(srcContext, 4000))
 	return strings.TrimSpace(result), nil
 }
 
-func generateMetadata(ctx context.Context, aiClient *ai.OllamaClient, topic, content string) (title, slug, metaDesc string, keywords, hashtags []string) {
+func generateMetadata(ctx context.Context, aiClient ai.Provider, topic, content string) (title, slug, metaDesc string, keywords, hashtags []string) {
 	// Defaults
 	title = topic
 	slug = slugify(topic)
@@ -437,7 +437,7 @@ REGLAS:
 	userPrompt := fmt.Sprintf("Tema: %s\n\nPrimeros 500 caracteres del articulo:\n%s",
 		topic, truncate(content, 500))
 
-	result, err := aiClient.Generate(ctx, systemPrompt, userPrompt)
+	result, err := aiClient.Generate(ai.WithTask(ctx, ai.TaskExtract), systemPrompt, userPrompt)
 	if err != nil {
 		return
 	}
@@ -478,8 +478,8 @@ REGLAS:
 
 // ImproveContent takes existing escrito content and user instructions,
 // then asks the AI to rewrite/improve the article accordingly.
-func ImproveContent(ctx context.Context, aiClient *ai.OllamaClient, escrito *models.Escrito, instructions string) (string, error) {
-	systemPrompt := `Eres un editor experto en contenido SEO en español para Puerto Rico.
+func ImproveContent(ctx context.Context, aiClient ai.Provider, escrito *models.Escrito, instructions string) (string, error) {
+	systemPrompt := `Eres un editor experto en contenido SEO en español para ` + coverage.Current().Name + `.
 Tu tarea es MEJORAR un articulo existente segun las instrucciones del usuario.
 
 REGLAS:
@@ -504,7 +504,7 @@ ARTICULO ACTUAL:
 
 Reescribe el articulo completo aplicando las mejoras solicitadas:`, instructions, content)
 
-	result, err := aiClient.Generate(ctx, systemPrompt, userPrompt)
+	result, err := aiClient.Generate(ai.WithTask(ctx, ai.TaskDraft), systemPrompt, userPrompt)
 	if err != nil {
 		return "", fmt.Errorf("AI improve: %w", err)
 	}
@@ -609,7 +609,7 @@ func extractJSON(s string) string {
 }
 
 // extractTopicKeywords returns meaningful keywords from a topic string,
-// filtering out stopwords and geographic noise like "puerto rico".
+// filtering out stopwords and the coverage profile's generic terms.
 func extractTopicKeywords(topic string) []string {
 	stopwords := map[string]bool{
 		"el": true, "la": true, "los": true, "las": true, "un": true, "una": true,
@@ -618,15 +618,13 @@ func extractTopicKeywords(topic string) []string {
 		"para": true, "al": true, "como": true, "su": true, "a": true,
 		"the": true, "is": true, "are": true, "in": true, "of": true, "and": true,
 	}
-	geoterms := map[string]bool{
-		"puerto": true, "rico": true, "isla": true, "boricua": true,
-	}
+	profile := coverage.Current()
 
 	words := strings.Fields(strings.ToLower(topic))
 	var keywords []string
 	for _, w := range words {
 		w = strings.Trim(w, "¿?¡!.,;:\"'()[]")
-		if len(w) >= 3 && !stopwords[w] && !geoterms[w] {
+		if len(w) >= 3 && !stopwords[w] && !profile.IsGeneric(w) {
 			keywords = append(keywords, w)
 		}
 	}
@@ -655,10 +653,11 @@ func articleMatchesKeywords(title, summary string, keywords []string) bool {
 }
 
 func defaultPlan(topic string) []ArticleSection {
+	place := coverage.Current().Name
 	return []ArticleSection{
-		{Heading: "Introduccion", Angle: "APP formula: contexto del tema en Puerto Rico", WordTarget: 180},
+		{Heading: "Introduccion", Angle: "APP formula: contexto del tema en " + place, WordTarget: 180},
 		{Heading: "Estado Actual", Angle: "situacion presente con datos y ejemplos", WordTarget: 300},
-		{Heading: "Impacto en Puerto Rico", Angle: "como afecta a la isla y sus comunidades", WordTarget: 300},
+		{Heading: "Impacto en " + place, Angle: "como afecta a " + place + " y sus comunidades", WordTarget: 300},
 		{Heading: "Perspectivas y Desarrollo", Angle: "iniciativas, proyectos y futuro del tema", WordTarget: 300},
 		{Heading: "Preguntas Frecuentes", Angle: "FAQ con 3-5 preguntas comunes sobre " + topic, WordTarget: 250},
 		{Heading: "Conclusion", Angle: "resumen y llamado a accion", WordTarget: 170},
@@ -5,13 +5,21 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/agents"
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/db"
+	"github.com/Saul-Punybz/folio/internal/flags"
 	"github.com/Saul-Punybz/folio/internal/intelligence"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/scraper"
 	"github.com/Saul-Punybz/folio/internal/storage"
+	"github.com/Saul-Punybz/folio/internal/workerctl"
 )
 
 // AdminHandler groups admin-only HTTP handlers.
@@ -19,9 +27,25 @@ type AdminHandler struct {
 	Articles     *models.ArticleStore
 	Sources      *models.SourceStore
 	Fingerprints *models.FingerprintStore
-	AI           *ai.OllamaClient
+	AI           ai.Provider
 	Scraper      *scraper.Scraper
 	Storage      *storage.Client
+	Jobs         *models.JobStore
+	SearchUsage  *models.SearchUsageStore
+	Backups      *models.BackupRunStore
+	Ingestions   *models.IngestionRunStore
+	Orgs         *models.WatchlistOrgStore
+	Hits         *models.WatchlistHitStore
+	Audit        *models.AuditStore
+	Fetches      *models.OutboundFetchStore
+	Worker       *models.WorkerJobStore
+
+	// ReclassifyModel is the default model for sentiment re-classification.
+	ReclassifyModel string
+
+	// Background is cancelled on shutdown; ingestion and re-enrichment
+	// started from the admin API run under it.
+	Background context.Context
 }
 
 // Reenrich handles POST /api/admin/reenrich.
@@ -33,7 +57,7 @@ func (h *AdminHandler) Reenrich(w http.ResponseWriter, r *http.Request) {
 	cleared, err := h.Articles.ClearGarbageEnrichment(ctx)
 	if err != nil {
 		slog.Error("reenrich: clear garbage", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to clear garbage data"})
+		writeError(w, r, http.StatusInternalServerError, "failed to clear garbage data")
 		return
 	}
 
@@ -43,24 +67,44 @@ func (h *AdminHandler) Reenrich(w http.ResponseWriter, r *http.Request) {
 	articles, err := h.Articles.ListNeedingEnrichment(ctx, 100)
 	if err != nil {
 		slog.Error("reenrich: list needing enrichment", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list articles"})
+		writeError(w, r, http.StatusInternalServerError, "failed to list articles")
 		return
 	}
 
-	// Step 3: Re-enrich in background (don't block the request).
+	// Step 3: Re-enrich in background (don't block the request). Queue the
+	// work when possible so it survives a restart.
 	if len(articles) > 0 {
-		go h.reenrichArticles(articles)
+		if inline := h.enqueueReenrich(ctx, articles); len(inline) > 0 {
+			go h.reenrichArticles(backgroundContext(h.Background), inline)
+		}
 	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
 		"cleared":  cleared,
 		"queued":   len(articles),
-		"message":  "Re-enrichment started. Articles will be processed in the background.",
+		"message":  localize(r, "Re-enrichment started. Articles will be processed in the background."),
 	})
 }
 
-func (h *AdminHandler) reenrichArticles(articles []models.Article) {
-	ctx := context.Background()
+// enqueueReenrich queues an enrichment job per article and returns the
+// articles that could not be queued, to be processed inline.
+func (h *AdminHandler) enqueueReenrich(ctx context.Context, articles []models.Article) []models.Article {
+	if h.Jobs == nil {
+		return articles
+	}
+	var inline []models.Article
+	for _, art := range articles {
+		if err := h.Jobs.Enqueue(ctx, models.JobEnrichArticle, scraper.EnrichJobPayload{ArticleID: art.ID, Reenrich: true}); err != nil {
+			slog.Error("reenrich: enqueue", "id", art.ID, "err", err)
+			inline = append(inline, art)
+		}
+	}
+	return inline
+}
+
+// reenrichArticles re-enriches articles inline, three at a time. Cancelling
+// ctx aborts in-flight AI calls and skips the articles not yet started.
+func (h *AdminHandler) reenrichArticles(ctx context.Context, articles []models.Article) {
 	sem := make(chan struct{}, 3)
 	var wg sync.WaitGroup
 
@@ -69,8 +113,15 @@ func (h *AdminHandler) reenrichArticles(articles []models.Article) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			sem <- struct{}{}
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
 			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				return
+			}
 
 			text := art.CleanText
 			if len(text) > 8000 {
@@ -107,28 +158,199 @@ func (h *AdminHandler) reenrichArticles(articles []models.Article) {
 	}
 
 	wg.Wait()
+	if ctx.Err() != nil {
+		slog.Warn("reenrich: stopped by shutdown", "count", len(articles))
+		return
+	}
 	slog.Info("reenrich: all articles processed", "count", len(articles))
 }
 
 // TriggerIngest handles POST /api/admin/ingest.
-// Manually triggers the RSS/scraper ingestion cycle.
+// Queues an RSS/scraper ingestion cycle for the worker; the API process
+// never runs it, so deploys do not cut it short.
 func (h *AdminHandler) TriggerIngest(w http.ResponseWriter, r *http.Request) {
-	if h.Sources == nil || h.Fingerprints == nil {
-		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "ingestion not configured"})
+	queueWorkerJob(w, r, h.Worker, workerctl.JobIngestion,
+		"Ingestion queued; the worker will start it within seconds. New articles will appear shortly.",
+		"Ingestion already in progress. New articles will appear shortly.")
+}
+
+// IngestStatus handles GET /api/admin/ingest.
+// Returns the ingestion job's last run and the latest ingest request.
+func (h *AdminHandler) IngestStatus(w http.ResponseWriter, r *http.Request) {
+	workerJobStatus(w, r, h.Worker, workerctl.JobIngestion)
+}
+
+// TriggerEmbeddingBackfill handles POST /api/admin/embeddings/backfill.
+// Queues the worker job that embeds articles lacking an embedding, in
+// batches; progress is reported by GET /api/admin/embeddings/backfill.
+func (h *AdminHandler) TriggerEmbeddingBackfill(w http.ResponseWriter, r *http.Request) {
+	queueWorkerJob(w, r, h.Worker, workerctl.JobEmbeddingBackfill,
+		"Embedding backfill queued; the worker will start it within seconds.",
+		"Embedding backfill already in progress.")
+}
+
+// EmbeddingBackfillStatus handles GET /api/admin/embeddings/backfill.
+// Returns the backfill job's last run, the latest backfill request and
+// "missing", the number of articles still lacking an embedding.
+func (h *AdminHandler) EmbeddingBackfillStatus(w http.ResponseWriter, r *http.Request) {
+	status, ok := loadWorkerJobStatus(w, r, h.Worker, workerctl.JobEmbeddingBackfill)
+	if !ok {
+		return
+	}
+	missing, err := h.Articles.CountUnembedded(r.Context())
+	if err != nil {
+		slog.Error("admin: count unembedded articles", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	status["missing"] = missing
+	writeJSON(w, http.StatusOK, status)
+}
+
+// ListIngestions handles GET /api/admin/ingestions.
+// Returns recent ingestion runs, newest first, with their per-source counts
+// and errors. Query param: limit (default 20, max 100).
+func (h *AdminHandler) ListIngestions(w http.ResponseWriter, r *http.Request) {
+	if h.Ingestions == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "ingestion history not configured")
 		return
 	}
 
-	stores := scraper.Stores{
-		Articles:     h.Articles,
-		Sources:      h.Sources,
-		Fingerprints: h.Fingerprints,
+	limit := 20
+	if v := r.URL.Query().Get("limit"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit = n
+		}
+	}
+	if limit > 100 {
+		limit = 100
 	}
 
-	go scraper.RunIngestion(context.Background(), stores, h.Scraper, h.AI, h.Storage)
+	runs, err := h.Ingestions.List(r.Context(), limit)
+	if err != nil {
+		slog.Error("admin: list ingestions", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if runs == nil {
+		runs = []models.IngestionRun{}
+	}
 
-	writeJSON(w, http.StatusAccepted, map[string]string{
-		"status":  "started",
-		"message": "Ingestion started in background. New articles will appear shortly.",
+	writeJSON(w, http.StatusOK, map[string]any{
+		"runs":  runs,
+		"count": len(runs),
+	})
+}
+
+// TestFilters handles POST /api/admin/filters/test.
+// Body: { "url": "...", "title": "...", "snippet": "...", "org_id": "..." }
+// Runs the input through the ingestion and watchlist content filters and the
+// dedup checks, and reports which rules matched. org_id is optional; when
+// given, the org's keywords and hits are used for the org-specific checks.
+func (h *AdminHandler) TestFilters(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		URL     string `json:"url"`
+		Title   string `json:"title"`
+		Snippet string `json:"snippet"`
+		OrgID   string `json:"org_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if body.URL == "" && body.Title == "" && body.Snippet == "" {
+		writeError(w, r, http.StatusBadRequest, "url, title, or snippet is required")
+		return
+	}
+
+	ctx := r.Context()
+	var org *models.WatchlistOrg
+	if body.OrgID != "" {
+		orgID, err := uuid.Parse(body.OrgID)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid org_id")
+			return
+		}
+		if h.Orgs == nil {
+			writeError(w, r, http.StatusServiceUnavailable, "watchlist not configured")
+			return
+		}
+		if org, err = h.Orgs.GetByID(ctx, orgID); err != nil {
+			writeError(w, r, http.StatusNotFound, "org not found")
+			return
+		}
+	}
+
+	checks := agents.ExplainFilters(body.URL, body.Title, body.Snippet, org)
+
+	if body.URL != "" {
+		urlHash := scraper.HashURL(body.URL)
+
+		if h.Fingerprints != nil {
+			c := agents.FilterCheck{Filter: "fingerprint", Applies: "ingestion"}
+			exists, blocked, err := h.Fingerprints.ExistsOrBlocked(ctx, urlHash)
+			switch {
+			case err != nil:
+				slog.Error("filter test: fingerprint", "err", err)
+				c.Detail = "check failed"
+			case blocked:
+				c.Matched, c.Rule = true, "blocked"
+			case exists:
+				c.Matched, c.Rule = true, "already_ingested"
+			}
+			checks = append(checks, c)
+		}
+
+		if h.Hits != nil {
+			c := agents.FilterCheck{Filter: "hit_url", Applies: "watchlist"}
+			userID := uuid.Nil
+			if org != nil {
+				userID = org.UserID
+			}
+			id, err := h.Hits.FindByURLHash(ctx, userID, urlHash)
+			var linked map[string]bool
+			if err == nil && id != uuid.Nil && org != nil {
+				linked, err = h.Hits.LinkedURLHashes(ctx, org.ID, []string{urlHash})
+			}
+			switch {
+			case err != nil:
+				slog.Error("filter test: hit url", "err", err)
+				c.Detail = "check failed"
+			case id == uuid.Nil:
+			case org != nil && !linked[urlHash]:
+				// Another of the user's orgs found it: linked instead of dropped.
+				c.Rule, c.Detail = "linked_url", "existing hit "+id.String()+" would be linked to this org"
+			default:
+				c.Matched, c.Rule, c.Detail = true, "duplicate_url", "existing hit "+id.String()
+			}
+			checks = append(checks, c)
+
+			if org != nil {
+				c := agents.FilterCheck{Filter: "hit_content", Applies: "watchlist"}
+				contentHash := models.HitContentHash(body.Title, body.Snippet)
+				id, err := h.Hits.FindContentDuplicate(ctx, org.ID, contentHash, urlHash)
+				if err != nil {
+					slog.Error("filter test: hit content", "err", err)
+					c.Detail = "check failed"
+				} else if id != uuid.Nil {
+					c.Matched, c.Rule, c.Detail = true, "syndicated_copy", "folded into hit "+id.String()
+				}
+				checks = append(checks, c)
+			}
+		}
+	}
+
+	matched := []string{}
+	for _, c := range checks {
+		if c.Matched {
+			matched = append(matched, c.Filter)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"filtered": len(matched) > 0,
+		"matched":  matched,
+		"checks":   checks,
 	})
 }
 
@@ -138,7 +360,7 @@ func (h *AdminHandler) ChatWithNews(w http.ResponseWriter, r *http.Request) {
 		Question string `json:"question"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Question == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "question is required"})
+		writeError(w, r, http.StatusBadRequest, "question is required")
 		return
 	}
 
@@ -150,7 +372,7 @@ func (h *AdminHandler) ChatWithNews(w http.ResponseWriter, r *http.Request) {
 	})
 	if err != nil {
 		slog.Error("chat: generate", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "AI failed to respond"})
+		writeError(w, r, http.StatusInternalServerError, "AI failed to respond")
 		return
 	}
 
@@ -161,3 +383,114 @@ func (h *AdminHandler) ChatWithNews(w http.ResponseWriter, r *http.Request) {
 		"web_sources":   resp.WebSources,
 	})
 }
+
+// ChatStream handles POST /api/chat/stream.
+// Same as ChatWithNews, but streams the answer as Server-Sent Events:
+// "token" events carry {"text": ...} chunks as the model generates them, a
+// final "done" event carries the full response with sources, and an "error"
+// event is sent if generation fails after the stream has started.
+func (h *AdminHandler) ChatStream(w http.ResponseWriter, r *http.Request) {
+	if !flags.Enabled(r.Context(), flags.SSEChat) {
+		writeError(w, r, http.StatusForbidden, "streaming chat is not enabled for your account, use /api/admin/chat")
+		return
+	}
+	var body struct {
+		Question string `json:"question"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body.Question == "" {
+		writeError(w, r, http.StatusBadRequest, "question is required")
+		return
+	}
+
+	// Generation outlives the server's default write timeout.
+	rc := http.NewResponseController(w)
+	if err := rc.SetWriteDeadline(time.Time{}); err != nil {
+		slog.Warn("chat stream: clear write deadline", "err", err)
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	if err := rc.Flush(); err != nil {
+		slog.Error("chat stream: streaming unsupported", "err", err)
+		return
+	}
+
+	resp, err := intelligence.ChatStream(r.Context(), intelligence.Deps{
+		Articles: h.Articles,
+		AI:       h.AI,
+	}, intelligence.ChatRequest{
+		Question: body.Question,
+	}, func(token string) error {
+		return writeSSE(w, "token", map[string]string{"text": token})
+	})
+	if err != nil {
+		slog.Error("chat stream: generate", "err", err)
+		_ = writeSSE(w, "error", map[string]string{"error": localize(r, "AI failed to respond")})
+		return
+	}
+
+	_ = writeSSE(w, "done", map[string]any{
+		"answer":        resp.Answer,
+		"articles_used": resp.ArticlesUsed,
+		"sources":       resp.Sources,
+		"web_sources":   resp.WebSources,
+	})
+}
+
+// Stats handles GET /api/admin/stats.
+// Reports today's web search usage per engine against its budget, plus the
+// last week of daily counts, the state of the weekly database backups, and
+// the statements this server has seen run slowest since it started.
+func (h *AdminHandler) Stats(w http.ResponseWriter, r *http.Request) {
+	search := map[string]any{"engines": []scraper.EngineUsage{}, "history": []models.SearchUsage{}}
+
+	if q := scraper.CurrentSearchQuota(); q != nil && q.Usage != nil {
+		today, err := q.Today(r.Context())
+		if err != nil {
+			slog.Error("admin stats: search usage", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
+		}
+		search["engines"] = today
+	}
+	if h.SearchUsage != nil {
+		history, err := h.SearchUsage.ListSince(r.Context(), 7)
+		if err != nil {
+			slog.Error("admin stats: search history", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
+		}
+		if history != nil {
+			search["history"] = history
+		}
+	}
+
+	backups := map[string]any{"last_success": nil, "runs": []models.BackupRun{}}
+	if h.Backups != nil {
+		last, err := h.Backups.LastSuccess(r.Context())
+		if err != nil {
+			slog.Error("admin stats: last backup", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
+		}
+		backups["last_success"] = last
+		runs, err := h.Backups.List(r.Context(), 10)
+		if err != nil {
+			slog.Error("admin stats: backup runs", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
+		}
+		if runs != nil {
+			backups["runs"] = runs
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"search":       search,
+		"backup":       backups,
+		"slow_queries": db.SlowQueries(10),
+	})
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// ListAudit handles GET /api/admin/audit.
+// Returns audit log entries, newest first. Query params: user (ID or
+// email), action (substring of "METHOD /route", e.g. "trash" or "DELETE"),
+// entity and entity_id, from/to (RFC3339 or YYYY-MM-DD; a date-only to is
+// inclusive), limit (default 100, max 500), offset, total=true.
+func (h *AdminHandler) ListAudit(w http.ResponseWriter, r *http.Request) {
+	if h.Audit == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "audit log not configured")
+		return
+	}
+
+	q := r.URL.Query()
+	filter := models.AuditFilter{
+		Action:   q.Get("action"),
+		Entity:   q.Get("entity"),
+		EntityID: q.Get("entity_id"),
+	}
+	if u := q.Get("user"); u != "" {
+		if id, err := uuid.Parse(u); err == nil {
+			filter.UserID = id
+		} else {
+			filter.UserEmail = u
+		}
+	}
+	if s := q.Get("from"); s != "" {
+		t, _, ok := parseHitDate(s)
+		if !ok {
+			writeError(w, r, http.StatusBadRequest, "invalid from, use RFC3339 or YYYY-MM-DD")
+			return
+		}
+		filter.From = t
+	}
+	if s := q.Get("to"); s != "" {
+		t, dateOnly, ok := parseHitDate(s)
+		if !ok {
+			writeError(w, r, http.StatusBadRequest, "invalid to, use RFC3339 or YYYY-MM-DD")
+			return
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		filter.To = t
+	}
+
+	limit := 100
+	if n, err := strconv.Atoi(q.Get("limit")); err == nil && n > 0 {
+		limit = min(n, 500)
+	}
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	entries, err := h.Audit.List(r.Context(), filter, limit, offset)
+	if err != nil {
+		slog.Error("admin: list audit", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if entries == nil {
+		entries = []models.AuditEntry{}
+	}
+
+	resp := map[string]any{
+		"entries": entries,
+		"count":   len(entries),
+		"limit":   limit,
+		"offset":  offset,
+	}
+	if wantTotal(r) {
+		total, err := h.Audit.Count(r.Context(), filter)
+		if err != nil {
+			slog.Error("admin: count audit", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
+		}
+		resp["total"] = total
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// defaultFetchSummaryDays is how far back ListFetchDomains and the per-day
+// breakdown look without from.
+const defaultFetchSummaryDays = 30
+
+// parseFetchFilter reads domain, purpose and from/to (RFC3339 or
+// YYYY-MM-DD; a date-only to is inclusive). It returns a user-facing error
+// message when a parameter is invalid.
+func parseFetchFilter(r *http.Request) (models.OutboundFetchFilter, string) {
+	q := r.URL.Query()
+	f := models.OutboundFetchFilter{
+		Domain:  q.Get("domain"),
+		Purpose: q.Get("purpose"),
+	}
+	if s := q.Get("from"); s != "" {
+		t, _, ok := parseHitDate(s)
+		if !ok {
+			return f, "invalid from, use RFC3339 or YYYY-MM-DD"
+		}
+		f.From = t
+	}
+	if s := q.Get("to"); s != "" {
+		t, dateOnly, ok := parseHitDate(s)
+		if !ok {
+			return f, "invalid to, use RFC3339 or YYYY-MM-DD"
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		f.To = t
+	}
+	return f, ""
+}
+
+// ListFetches handles GET /api/admin/fetches.
+// Returns the outbound fetch log, newest first. Query params: domain (also
+// matches subdomains), purpose (ingestion, watchlist, chat, enrichment,
+// crawl, research, share, other), from/to, limit (default 100, max 1000),
+// offset. With a domain, a per-day and per-purpose breakdown over the same
+// range (default the last 30 days) is included.
+func (h *AdminHandler) ListFetches(w http.ResponseWriter, r *http.Request) {
+	if h.Fetches == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "fetch log not configured")
+		return
+	}
+	filter, msg := parseFetchFilter(r)
+	if msg != "" {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+
+	limit := 100
+	if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && n > 0 {
+		limit = min(n, 1000)
+	}
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	fetches, err := h.Fetches.List(r.Context(), filter, limit, offset)
+	if err != nil {
+		slog.Error("admin: list fetches", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if fetches == nil {
+		fetches = []models.OutboundFetch{}
+	}
+	resp := map[string]any{
+		"fetches": fetches,
+		"count":   len(fetches),
+		"limit":   limit,
+		"offset":  offset,
+	}
+
+	if filter.Domain != "" {
+		daily := filter
+		if daily.From.IsZero() {
+			daily.From = time.Now().AddDate(0, 0, -defaultFetchSummaryDays)
+		}
+		days, err := h.Fetches.Daily(r.Context(), daily)
+		if err != nil {
+			slog.Error("admin: fetches by day", "domain", filter.Domain, "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
+		}
+		if days == nil {
+			days = []models.OutboundFetchDay{}
+		}
+		resp["daily"] = days
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// ListFetchDomains handles GET /api/admin/fetches/domains.
+// Returns the most fetched domains with their fetch count, bytes and last
+// fetch. Accepts the ListFetches filters (from defaults to 30 days ago) and
+// limit (default 50, max 500).
+func (h *AdminHandler) ListFetchDomains(w http.ResponseWriter, r *http.Request) {
+	if h.Fetches == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "fetch log not configured")
+		return
+	}
+	filter, msg := parseFetchFilter(r)
+	if msg != "" {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+	if filter.From.IsZero() {
+		filter.From = time.Now().AddDate(0, 0, -defaultFetchSummaryDays)
+	}
+
+	limit := 50
+	if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && n > 0 {
+		limit = min(n, 500)
+	}
+
+	domains, err := h.Fetches.Domains(r.Context(), filter, limit)
+	if err != nil {
+		slog.Error("admin: fetch domains", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if domains == nil {
+		domains = []models.OutboundDomain{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"domains": domains,
+		"count":   len(domains),
+		"since":   filter.From,
+	})
+}
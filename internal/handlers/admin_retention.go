@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/middleware"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+type bulkRetentionRequest struct {
+	Policy string `json:"policy"`
+	Filter struct {
+		IDs                []uuid.UUID `json:"ids"`
+		Status             string      `json:"status"`
+		Source             string      `json:"source"`
+		Tag                string      `json:"tag"`
+		Region             string      `json:"region"`
+		Policy             string      `json:"policy"`
+		From               string      `json:"from"`
+		To                 string      `json:"to"`
+		ExpiringWithinDays int         `json:"expiring_within_days"`
+	} `json:"filter"`
+	DryRun bool `json:"dry_run"`
+}
+
+// toFilter validates the request's filter. It returns a user-facing error
+// message when the request is invalid.
+func (req *bulkRetentionRequest) toFilter() (models.RetentionFilter, string) {
+	f := models.RetentionFilter{
+		IDs:                req.Filter.IDs,
+		Status:             req.Filter.Status,
+		Source:             req.Filter.Source,
+		Tag:                req.Filter.Tag,
+		Region:             req.Filter.Region,
+		Policy:             req.Filter.Policy,
+		ExpiringWithinDays: req.Filter.ExpiringWithinDays,
+	}
+	if req.Filter.From != "" {
+		t, err := time.Parse("2006-01-02", req.Filter.From)
+		if err != nil {
+			return f, "invalid filter.from, use YYYY-MM-DD"
+		}
+		f.From = t
+	}
+	if req.Filter.To != "" {
+		t, err := time.Parse("2006-01-02", req.Filter.To)
+		if err != nil {
+			return f, "invalid filter.to, use YYYY-MM-DD"
+		}
+		f.To = t.AddDate(0, 0, 1) // inclusive
+	}
+
+	switch {
+	case req.Policy != "ret_6m" && req.Policy != "ret_12m" && req.Policy != "keep":
+		return f, "policy must be ret_6m, ret_12m, or keep"
+	case f.Policy != "" && !containsString(evidencePolicies, f.Policy):
+		return f, "filter.policy must be ret_3m, ret_6m, ret_12m, or keep"
+	case f.ExpiringWithinDays < 0:
+		return f, "filter.expiring_within_days must not be negative"
+	case f.Empty():
+		return f, "filter must select something; set at least one field"
+	}
+	return f, ""
+}
+
+// evidencePolicies are the evidence retention policies an article can have.
+var evidencePolicies = []string{"ret_3m", "ret_6m", "ret_12m", "keep"}
+
+// BulkRetention handles POST /api/admin/retention.
+// Body: {"policy": "keep", "filter": {"tag": "legal", "from": "2025-01-01"}, "dry_run": true}
+// Sets the evidence retention policy of every article matching the filter
+// (ids, status, source, tag, region, current policy, from/to creation dates,
+// expiring_within_days), recalculating expiry from now. With dry_run only
+// the number of matching articles is returned.
+func (h *AdminHandler) BulkRetention(w http.ResponseWriter, r *http.Request) {
+	var req bulkRetentionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	filter, msg := req.toFilter()
+	if msg != "" {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+
+	if req.DryRun {
+		matched, err := h.Articles.CountRetention(r.Context(), filter)
+		if err != nil {
+			slog.Error("bulk retention: count", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"dry_run": true, "matched": matched, "policy": req.Policy})
+		return
+	}
+
+	updated, err := h.Articles.BulkUpdateRetention(r.Context(), filter, req.Policy)
+	if err != nil {
+		slog.Error("bulk retention: update", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not update retention")
+		return
+	}
+
+	var userID uuid.UUID
+	if user := middleware.UserFromContext(r.Context()); user != nil {
+		userID = user.ID
+	}
+	slog.Info("bulk retention: updated", "user_id", userID, "policy", req.Policy, "articles", updated)
+	writeJSON(w, http.StatusOK, map[string]any{"status": "updated", "updated": updated, "policy": req.Policy})
+}
@@ -0,0 +1,103 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Saul-Punybz/folio/internal/agents"
+)
+
+// reclassifyRunning keeps a second re-classification from starting while one
+// is in progress in this process.
+var reclassifyRunning atomic.Bool
+
+// ReclassifySentiment handles POST /api/admin/watchlist/reclassify.
+// Body: { "from": "YYYY-MM-DD", "to": "YYYY-MM-DD", "model": "llama3.1:8b" },
+// all optional (last 30 days, AI_RECLASSIFY_MODEL). Re-runs sentiment
+// classification in the background for the hits created in the range that
+// no person has verified. Returns how many hits will be classified.
+func (h *AdminHandler) ReclassifySentiment(w http.ResponseWriter, r *http.Request) {
+	if h.AI == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "AI not configured")
+		return
+	}
+
+	var req struct {
+		From  string `json:"from"`
+		To    string `json:"to"`
+		Model string `json:"model"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	from, to := today.AddDate(0, 0, -30), today
+	if req.From != "" {
+		parsed, err := time.Parse("2006-01-02", req.From)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid 'from' date, use YYYY-MM-DD")
+			return
+		}
+		from = parsed
+	}
+	if req.To != "" {
+		parsed, err := time.Parse("2006-01-02", req.To)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid 'to' date, use YYYY-MM-DD")
+			return
+		}
+		to = parsed
+	}
+	if to.Before(from) {
+		writeError(w, r, http.StatusBadRequest, "'to' must not be before 'from'")
+		return
+	}
+	to = to.AddDate(0, 0, 1) // inclusive
+	model := strings.TrimSpace(req.Model)
+	if model == "" {
+		model = h.ReclassifyModel
+	}
+
+	count, err := h.Hits.CountForReclassify(r.Context(), from, to)
+	if err != nil {
+		slog.Error("reclassify sentiment: count", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if count > 0 {
+		if !reclassifyRunning.CompareAndSwap(false, true) {
+			writeError(w, r, http.StatusConflict, "a re-classification is already running")
+			return
+		}
+		go h.reclassify(backgroundContext(h.Background), model, from, to)
+	}
+
+	writeJSON(w, http.StatusAccepted, map[string]any{
+		"hits":    count,
+		"model":   model,
+		"message": localize(r, "Re-classification started. Hits will be updated in the background."),
+	})
+}
+
+// reclassify runs a sentiment re-classification and logs its outcome.
+func (h *AdminHandler) reclassify(ctx context.Context, model string, from, to time.Time) {
+	defer reclassifyRunning.Store(false)
+
+	start := time.Now()
+	res, err := agents.ReclassifySentiment(ctx, h.Hits, h.AI, model, from, to)
+	if err != nil {
+		slog.Error("reclassify sentiment", "model", model, "err", err)
+	}
+	slog.Info("reclassify sentiment: done", "model", model,
+		"classified", res.Classified, "changed", res.Changed, "failed", res.Failed,
+		"duration", time.Since(start).Round(time.Second))
+}
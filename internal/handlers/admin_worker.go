@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+
+	"github.com/Saul-Punybz/folio/internal/middleware"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// ListWorkerJobs handles GET /api/admin/worker/jobs.
+// Returns the worker's cron jobs with their schedule, pause state, runs in
+// progress, last run (trigger, status, error, duration) and next scheduled
+// run.
+func (h *AdminHandler) ListWorkerJobs(w http.ResponseWriter, r *http.Request) {
+	if h.Worker == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "worker control not configured")
+		return
+	}
+	jobs, err := h.Worker.List(r.Context())
+	if err != nil {
+		slog.Error("admin: list worker jobs", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if jobs == nil {
+		jobs = []models.WorkerJob{}
+	}
+	now := time.Now()
+	for i := range jobs {
+		if jobs[i].Paused {
+			continue
+		}
+		if sched, err := cron.ParseStandard(jobs[i].Schedule); err == nil {
+			next := sched.Next(now)
+			jobs[i].NextRunAt = &next
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"jobs":  jobs,
+		"count": len(jobs),
+	})
+}
+
+// PauseWorkerJob handles POST /api/admin/worker/jobs/{name}/pause.
+// Scheduled runs of the job are skipped until it is resumed; runs in
+// progress finish, and run-now requests still run.
+func (h *AdminHandler) PauseWorkerJob(w http.ResponseWriter, r *http.Request) {
+	h.setWorkerJobPaused(w, r, true)
+}
+
+// ResumeWorkerJob handles POST /api/admin/worker/jobs/{name}/resume.
+func (h *AdminHandler) ResumeWorkerJob(w http.ResponseWriter, r *http.Request) {
+	h.setWorkerJobPaused(w, r, false)
+}
+
+func (h *AdminHandler) setWorkerJobPaused(w http.ResponseWriter, r *http.Request, paused bool) {
+	if h.Worker == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "worker control not configured")
+		return
+	}
+	name := chi.URLParam(r, "name")
+	job, err := h.Worker.SetPaused(r.Context(), name, paused)
+	if err != nil {
+		slog.Error("admin: set worker job paused", "job", name, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if job == nil {
+		writeError(w, r, http.StatusNotFound, "job not found")
+		return
+	}
+	slog.Info("admin: worker job paused", "job", name, "paused", paused)
+	writeJSON(w, http.StatusOK, job)
+}
+
+// RunWorkerJob handles POST /api/admin/worker/jobs/{name}/run.
+// Queues a request for the worker to run the job now, even if paused, and
+// returns the command, whose status can be followed at
+// /api/admin/worker/commands/{id}. The command fails if the job is already
+// running in the worker, or if no worker picks it up within 15 minutes.
+func (h *AdminHandler) RunWorkerJob(w http.ResponseWriter, r *http.Request) {
+	if h.Worker == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "worker control not configured")
+		return
+	}
+	name := chi.URLParam(r, "name")
+	job, err := h.Worker.Get(r.Context(), name)
+	if err != nil {
+		slog.Error("admin: get worker job", "job", name, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if job == nil {
+		writeError(w, r, http.StatusNotFound, "job not found")
+		return
+	}
+
+	var requestedBy *uuid.UUID
+	if user := middleware.UserFromContext(r.Context()); user != nil {
+		requestedBy = &user.ID
+	}
+	cmd, err := h.Worker.Enqueue(r.Context(), name, requestedBy)
+	if err != nil {
+		slog.Error("admin: enqueue worker job", "job", name, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusAccepted, cmd)
+}
+
+// ListWorkerCommands handles GET /api/admin/worker/commands.
+// Returns recent run-now requests, newest first. Query params: job, limit
+// (default 50, max 500).
+func (h *AdminHandler) ListWorkerCommands(w http.ResponseWriter, r *http.Request) {
+	if h.Worker == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "worker control not configured")
+		return
+	}
+	limit := 50
+	if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil && n > 0 {
+		limit = min(n, 500)
+	}
+	cmds, err := h.Worker.ListCommands(r.Context(), r.URL.Query().Get("job"), limit)
+	if err != nil {
+		slog.Error("admin: list worker commands", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if cmds == nil {
+		cmds = []models.WorkerCommand{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"commands": cmds,
+		"count":    len(cmds),
+	})
+}
+
+// GetWorkerCommand handles GET /api/admin/worker/commands/{id}.
+func (h *AdminHandler) GetWorkerCommand(w http.ResponseWriter, r *http.Request) {
+	if h.Worker == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "worker control not configured")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid command id")
+		return
+	}
+	cmd, err := h.Worker.GetCommand(r.Context(), id)
+	if err != nil {
+		slog.Error("admin: get worker command", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if cmd == nil {
+		writeError(w, r, http.StatusNotFound, "command not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, cmd)
+}
+
+// queueWorkerJob asks the worker to run job now, for endpoints that start
+// long-running work without running it in the API process. If a run is
+// already queued or in progress no new one is queued; the response says so,
+// with activeMsg instead of queuedMsg. Both are catalog messages, translated
+// into the request's language.
+func queueWorkerJob(w http.ResponseWriter, r *http.Request, store *models.WorkerJobStore, name, queuedMsg, activeMsg string) {
+	if store == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "worker control not configured")
+		return
+	}
+	job, err := store.Get(r.Context(), name)
+	if err != nil {
+		slog.Error("worker: get job", "job", name, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if job == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "worker not running: job %s not registered", name)
+		return
+	}
+	if job.Running > 0 {
+		writeJSON(w, http.StatusAccepted, map[string]any{
+			"status":  "running",
+			"message": localize(r, activeMsg),
+			"job":     job,
+		})
+		return
+	}
+
+	var requestedBy *uuid.UUID
+	if user := middleware.UserFromContext(r.Context()); user != nil {
+		requestedBy = &user.ID
+	}
+	cmd, queued, err := store.EnqueueOnce(r.Context(), name, requestedBy)
+	if err != nil {
+		slog.Error("worker: enqueue job", "job", name, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	status, msg := "queued", queuedMsg
+	if !queued {
+		status, msg = cmd.Status, activeMsg
+	}
+	writeJSON(w, http.StatusAccepted, map[string]any{
+		"status":  status,
+		"message": localize(r, msg),
+		"command": cmd,
+	})
+}
+
+// workerJobStatus writes a job's last run and its most recent run-now
+// request, if any.
+func workerJobStatus(w http.ResponseWriter, r *http.Request, store *models.WorkerJobStore, name string) {
+	if status, ok := loadWorkerJobStatus(w, r, store, name); ok {
+		writeJSON(w, http.StatusOK, status)
+	}
+}
+
+// loadWorkerJobStatus returns the response of workerJobStatus, for endpoints
+// that add their own progress to it. On failure it writes the error and
+// reports false.
+func loadWorkerJobStatus(w http.ResponseWriter, r *http.Request, store *models.WorkerJobStore, name string) (map[string]any, bool) {
+	if store == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "worker control not configured")
+		return nil, false
+	}
+	job, err := store.Get(r.Context(), name)
+	if err != nil {
+		slog.Error("worker: get job", "job", name, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return nil, false
+	}
+	if job == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "worker not running: job %s not registered", name)
+		return nil, false
+	}
+	cmds, err := store.ListCommands(r.Context(), name, 1)
+	if err != nil {
+		slog.Error("worker: list commands", "job", name, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return nil, false
+	}
+	var latest *models.WorkerCommand
+	if len(cmds) > 0 {
+		latest = &cmds[0]
+	}
+	return map[string]any{
+		"job":     job,
+		"command": latest,
+	}, true
+}
@@ -1,18 +1,44 @@
 package handlers
 
 import (
+	"context"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Saul-Punybz/folio/internal/middleware"
+	"github.com/Saul-Punybz/folio/internal/models"
 )
 
 // AnalyticsHandler provides HTTP handlers for analytics endpoints that query
 // article, entity, and tag data for dashboards and trend analysis.
+// Tag, source, region, sentiment, volume and hit figures come from the
+// materialized views the worker refreshes (models.AnalyticsStore); those
+// responses carry the views' refreshed_at.
 type AnalyticsHandler struct {
-	Pool *pgxpool.Pool
+	Pool  *pgxpool.Pool
+	Views *models.AnalyticsStore
+}
+
+// sinceDay is the SQL for the first UTC day of a window of $1 days.
+const sinceDay = `(NOW() AT TIME ZONE 'UTC')::date - $1::int`
+
+// refreshedAt returns when the analytics views were last refreshed, or nil
+// if that is unknown.
+func (h *AnalyticsHandler) refreshedAt(ctx context.Context) *time.Time {
+	if h.Views == nil {
+		return nil
+	}
+	t, err := h.Views.RefreshedAt(ctx)
+	if err != nil {
+		slog.Warn("analytics: refreshed at", "err", err)
+		return nil
+	}
+	return &t
 }
 
 // getDaysParam parses the "days" query parameter from the request. Returns 30
@@ -39,15 +65,14 @@ func (h *AnalyticsHandler) TagTrends(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	rows, err := h.Pool.Query(ctx, `
-		SELECT t.tag, date_trunc('day', a.created_at)::date as day, count(*) as cnt
-		FROM articles a, jsonb_array_elements_text(a.tags) AS t(tag)
-		WHERE a.created_at >= NOW() - make_interval(days => $1)
-		GROUP BY t.tag, day
-		ORDER BY day DESC, cnt DESC
+		SELECT tag, day, articles
+		FROM mv_tag_daily
+		WHERE day >= `+sinceDay+`
+		ORDER BY day DESC, articles DESC
 	`, days)
 	if err != nil {
 		slog.Error("analytics: tag trends", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to query tag trends"})
+		writeError(w, r, http.StatusInternalServerError, "failed to query tag trends")
 		return
 	}
 	defer rows.Close()
@@ -64,7 +89,7 @@ func (h *AnalyticsHandler) TagTrends(w http.ResponseWriter, r *http.Request) {
 		var day time.Time
 		if err := rows.Scan(&t.Tag, &day, &t.Count); err != nil {
 			slog.Error("analytics: tag trends scan", "err", err)
-			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to scan tag trends"})
+			writeError(w, r, http.StatusInternalServerError, "failed to scan tag trends")
 			return
 		}
 		t.Day = day.Format("2006-01-02")
@@ -72,11 +97,11 @@ func (h *AnalyticsHandler) TagTrends(w http.ResponseWriter, r *http.Request) {
 	}
 	if err := rows.Err(); err != nil {
 		slog.Error("analytics: tag trends rows", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to iterate tag trends"})
+		writeError(w, r, http.StatusInternalServerError, "failed to iterate tag trends")
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]any{"trends": trends})
+	writeJSON(w, http.StatusOK, map[string]any{"trends": trends, "refreshed_at": h.refreshedAt(ctx)})
 }
 
 // TopEntities handles GET /api/analytics/entities?days=30&type=person.
@@ -99,7 +124,7 @@ func (h *AnalyticsHandler) TopEntities(w http.ResponseWriter, r *http.Request) {
 	`, days, entityType)
 	if err != nil {
 		slog.Error("analytics: top entities", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to query top entities"})
+		writeError(w, r, http.StatusInternalServerError, "failed to query top entities")
 		return
 	}
 	defer rows.Close()
@@ -115,14 +140,14 @@ func (h *AnalyticsHandler) TopEntities(w http.ResponseWriter, r *http.Request) {
 		var e entityRow
 		if err := rows.Scan(&e.Name, &e.Type, &e.Count); err != nil {
 			slog.Error("analytics: top entities scan", "err", err)
-			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to scan top entities"})
+			writeError(w, r, http.StatusInternalServerError, "failed to scan top entities")
 			return
 		}
 		entities = append(entities, e)
 	}
 	if err := rows.Err(); err != nil {
 		slog.Error("analytics: top entities rows", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to iterate top entities"})
+		writeError(w, r, http.StatusInternalServerError, "failed to iterate top entities")
 		return
 	}
 
@@ -134,7 +159,7 @@ func (h *AnalyticsHandler) TopEntities(w http.ResponseWriter, r *http.Request) {
 func (h *AnalyticsHandler) CoOccurrences(w http.ResponseWriter, r *http.Request) {
 	entityName := r.URL.Query().Get("entity")
 	if entityName == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "entity parameter is required"})
+		writeError(w, r, http.StatusBadRequest, "entity parameter is required")
 		return
 	}
 	ctx := r.Context()
@@ -152,7 +177,7 @@ func (h *AnalyticsHandler) CoOccurrences(w http.ResponseWriter, r *http.Request)
 	`, entityName)
 	if err != nil {
 		slog.Error("analytics: co-occurrences", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to query co-occurrences"})
+		writeError(w, r, http.StatusInternalServerError, "failed to query co-occurrences")
 		return
 	}
 	defer rows.Close()
@@ -168,14 +193,14 @@ func (h *AnalyticsHandler) CoOccurrences(w http.ResponseWriter, r *http.Request)
 		var c coEntity
 		if err := rows.Scan(&c.Name, &c.Type, &c.Count); err != nil {
 			slog.Error("analytics: co-occurrences scan", "err", err)
-			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to scan co-occurrences"})
+			writeError(w, r, http.StatusInternalServerError, "failed to scan co-occurrences")
 			return
 		}
 		coOccurrences = append(coOccurrences, c)
 	}
 	if err := rows.Err(); err != nil {
 		slog.Error("analytics: co-occurrences rows", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to iterate co-occurrences"})
+		writeError(w, r, http.StatusInternalServerError, "failed to iterate co-occurrences")
 		return
 	}
 
@@ -189,14 +214,14 @@ func (h *AnalyticsHandler) SentimentDistribution(w http.ResponseWriter, r *http.
 	ctx := r.Context()
 
 	rows, err := h.Pool.Query(ctx, `
-		SELECT sentiment, count(*) as cnt
-		FROM articles
-		WHERE sentiment != '' AND created_at >= NOW() - make_interval(days => $1)
+		SELECT sentiment, sum(articles)::bigint
+		FROM mv_article_daily
+		WHERE sentiment != '' AND day >= `+sinceDay+`
 		GROUP BY sentiment
 	`, days)
 	if err != nil {
 		slog.Error("analytics: sentiment distribution", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to query sentiment distribution"})
+		writeError(w, r, http.StatusInternalServerError, "failed to query sentiment distribution")
 		return
 	}
 	defer rows.Close()
@@ -211,18 +236,18 @@ func (h *AnalyticsHandler) SentimentDistribution(w http.ResponseWriter, r *http.
 		var s sentimentRow
 		if err := rows.Scan(&s.Sentiment, &s.Count); err != nil {
 			slog.Error("analytics: sentiment scan", "err", err)
-			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to scan sentiment"})
+			writeError(w, r, http.StatusInternalServerError, "failed to scan sentiment")
 			return
 		}
 		distribution = append(distribution, s)
 	}
 	if err := rows.Err(); err != nil {
 		slog.Error("analytics: sentiment rows", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to iterate sentiment"})
+		writeError(w, r, http.StatusInternalServerError, "failed to iterate sentiment")
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]any{"distribution": distribution})
+	writeJSON(w, http.StatusOK, map[string]any{"distribution": distribution, "refreshed_at": h.refreshedAt(ctx)})
 }
 
 // SourceHealth handles GET /api/analytics/sources.
@@ -231,16 +256,16 @@ func (h *AnalyticsHandler) SourceHealth(w http.ResponseWriter, r *http.Request)
 	ctx := r.Context()
 
 	rows, err := h.Pool.Query(ctx, `
-		SELECT source, count(*) as article_count,
-		       max(created_at) as last_ingested,
-		       count(*) FILTER (WHERE summary != '' AND summary IS NOT NULL) as enriched_count
-		FROM articles
+		SELECT source, sum(articles)::bigint AS article_count,
+		       max(last_created_at) AS last_ingested,
+		       sum(enriched)::bigint AS enriched_count
+		FROM mv_article_daily
 		GROUP BY source
 		ORDER BY article_count DESC
 	`)
 	if err != nil {
 		slog.Error("analytics: source health", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to query source health"})
+		writeError(w, r, http.StatusInternalServerError, "failed to query source health")
 		return
 	}
 	defer rows.Close()
@@ -258,7 +283,7 @@ func (h *AnalyticsHandler) SourceHealth(w http.ResponseWriter, r *http.Request)
 		var lastIngested time.Time
 		if err := rows.Scan(&s.Source, &s.ArticleCount, &lastIngested, &s.EnrichedCount); err != nil {
 			slog.Error("analytics: source health scan", "err", err)
-			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to scan source health"})
+			writeError(w, r, http.StatusInternalServerError, "failed to scan source health")
 			return
 		}
 		s.LastIngested = lastIngested.Format(time.RFC3339)
@@ -266,11 +291,11 @@ func (h *AnalyticsHandler) SourceHealth(w http.ResponseWriter, r *http.Request)
 	}
 	if err := rows.Err(); err != nil {
 		slog.Error("analytics: source health rows", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to iterate source health"})
+		writeError(w, r, http.StatusInternalServerError, "failed to iterate source health")
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]any{"sources": sources})
+	writeJSON(w, http.StatusOK, map[string]any{"sources": sources, "refreshed_at": h.refreshedAt(ctx)})
 }
 
 // ArticleVolume handles GET /api/analytics/volume?days=30.
@@ -280,15 +305,15 @@ func (h *AnalyticsHandler) ArticleVolume(w http.ResponseWriter, r *http.Request)
 	ctx := r.Context()
 
 	rows, err := h.Pool.Query(ctx, `
-		SELECT date_trunc('day', created_at)::date as day, count(*) as cnt
-		FROM articles
-		WHERE created_at >= NOW() - make_interval(days => $1)
+		SELECT day, sum(articles)::bigint
+		FROM mv_article_daily
+		WHERE day >= `+sinceDay+`
 		GROUP BY day
 		ORDER BY day ASC
 	`, days)
 	if err != nil {
 		slog.Error("analytics: article volume", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to query article volume"})
+		writeError(w, r, http.StatusInternalServerError, "failed to query article volume")
 		return
 	}
 	defer rows.Close()
@@ -304,7 +329,7 @@ func (h *AnalyticsHandler) ArticleVolume(w http.ResponseWriter, r *http.Request)
 		var day time.Time
 		if err := rows.Scan(&day, &v.Count); err != nil {
 			slog.Error("analytics: article volume scan", "err", err)
-			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to scan article volume"})
+			writeError(w, r, http.StatusInternalServerError, "failed to scan article volume")
 			return
 		}
 		v.Day = day.Format("2006-01-02")
@@ -312,9 +337,110 @@ func (h *AnalyticsHandler) ArticleVolume(w http.ResponseWriter, r *http.Request)
 	}
 	if err := rows.Err(); err != nil {
 		slog.Error("analytics: article volume rows", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to iterate article volume"})
+		writeError(w, r, http.StatusInternalServerError, "failed to iterate article volume")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"volume": volume, "refreshed_at": h.refreshedAt(ctx)})
+}
+
+// RegionVolume handles GET /api/analytics/regions?days=30.
+// Returns daily article counts per region.
+func (h *AnalyticsHandler) RegionVolume(w http.ResponseWriter, r *http.Request) {
+	days := getDaysParam(r)
+	ctx := r.Context()
+
+	rows, err := h.Pool.Query(ctx, `
+		SELECT day, region, sum(articles)::bigint
+		FROM mv_article_daily
+		WHERE day >= `+sinceDay+`
+		GROUP BY day, region
+		ORDER BY day ASC, region
+	`, days)
+	if err != nil {
+		slog.Error("analytics: region volume", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "failed to query region volume")
+		return
+	}
+	defer rows.Close()
+
+	type regionRow struct {
+		Day    string `json:"day"`
+		Region string `json:"region"`
+		Count  int    `json:"count"`
+	}
+
+	var regions []regionRow
+	for rows.Next() {
+		var v regionRow
+		var day time.Time
+		if err := rows.Scan(&day, &v.Region, &v.Count); err != nil {
+			slog.Error("analytics: region volume scan", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "failed to scan region volume")
+			return
+		}
+		v.Day = day.Format("2006-01-02")
+		regions = append(regions, v)
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("analytics: region volume rows", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "failed to iterate region volume")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"regions": regions, "refreshed_at": h.refreshedAt(ctx)})
+}
+
+// OrgHits handles GET /api/analytics/hits?days=30.
+// Returns daily watchlist hit counts by sentiment for each of the user's orgs.
+func (h *AnalyticsHandler) OrgHits(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	days := getDaysParam(r)
+	ctx := r.Context()
+
+	rows, err := h.Pool.Query(ctx, `
+		SELECT v.day, v.org_id, wo.name, v.sentiment, v.hits
+		FROM mv_org_hit_daily v
+		JOIN watchlist_orgs wo ON wo.id = v.org_id
+		WHERE wo.user_id = $2 AND v.day >= `+sinceDay+`
+		ORDER BY v.day ASC, wo.name, v.sentiment
+	`, days, user.ID)
+	if err != nil {
+		slog.Error("analytics: org hits", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "failed to query org hits")
+		return
+	}
+	defer rows.Close()
+
+	type hitRow struct {
+		Day       string    `json:"day"`
+		OrgID     uuid.UUID `json:"org_id"`
+		OrgName   string    `json:"org_name"`
+		Sentiment string    `json:"sentiment"`
+		Count     int       `json:"count"`
+	}
+
+	var hits []hitRow
+	for rows.Next() {
+		var v hitRow
+		var day time.Time
+		if err := rows.Scan(&day, &v.OrgID, &v.OrgName, &v.Sentiment, &v.Count); err != nil {
+			slog.Error("analytics: org hits scan", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "failed to scan org hits")
+			return
+		}
+		v.Day = day.Format("2006-01-02")
+		hits = append(hits, v)
+	}
+	if err := rows.Err(); err != nil {
+		slog.Error("analytics: org hits rows", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "failed to iterate org hits")
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]any{"volume": volume})
+	writeJSON(w, http.StatusOK, map[string]any{"hits": hits, "refreshed_at": h.refreshedAt(ctx)})
 }
@@ -6,16 +6,23 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 
+	"github.com/Saul-Punybz/folio/internal/i18n"
 	"github.com/Saul-Punybz/folio/internal/middleware"
 	"github.com/Saul-Punybz/folio/internal/models"
 )
 
 const sessionDuration = 30 * 24 * time.Hour // 30 days
 
+// minPasswordLen is the shortest password accepted for new or reset passwords.
+const minPasswordLen = 8
+
 // AuthHandler groups authentication-related HTTP handlers.
 type AuthHandler struct {
 	Users    *models.UserStore
@@ -33,25 +40,31 @@ type loginRequest struct {
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req loginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if req.Email == "" || req.Password == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "email and password required"})
+		writeError(w, r, http.StatusBadRequest, "email and password required")
 		return
 	}
 
 	user, err := h.Users.GetByEmail(r.Context(), req.Email)
 	if err != nil {
 		slog.Debug("login: user not found", "email", req.Email)
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid credentials"})
+		writeError(w, r, http.StatusUnauthorized, "invalid credentials")
 		return
 	}
 
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
 		slog.Debug("login: bad password", "email", req.Email)
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid credentials"})
+		writeError(w, r, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+
+	if !user.Active {
+		slog.Debug("login: user deactivated", "email", req.Email)
+		writeError(w, r, http.StatusUnauthorized, "invalid credentials")
 		return
 	}
 
@@ -59,7 +72,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	tokenBytes := make([]byte, 32)
 	if _, err := rand.Read(tokenBytes); err != nil {
 		slog.Error("login: generate token", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	token := hex.EncodeToString(tokenBytes)
@@ -72,7 +85,7 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.Sessions.Create(r.Context(), session); err != nil {
 		slog.Error("login: create session", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -123,7 +136,7 @@ func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -131,6 +144,251 @@ func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
 		"id":         user.ID,
 		"email":      user.Email,
 		"role":       user.Role,
+		"language":   user.Language,
 		"created_at": user.CreatedAt,
 	})
 }
+
+// SetLanguage handles PUT /api/me/language.
+// Body: { "language": "es" | "en" | "" } — "" follows the browser's
+// Accept-Language, then the server default.
+func (h *AuthHandler) SetLanguage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req struct {
+		Language string `json:"language"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	language := ""
+	if strings.TrimSpace(req.Language) != "" {
+		lang, ok := i18n.Parse(req.Language)
+		if !ok {
+			writeError(w, r, http.StatusBadRequest, "language must be es, en, or empty")
+			return
+		}
+		language = string(lang)
+	}
+
+	if err := h.Users.SetLanguage(r.Context(), user.ID, language); err != nil {
+		slog.Error("set language", "user_id", user.ID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"language": language})
+}
+
+// ── User management (admin only) ─────────────────────────────────
+
+type createUserRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+}
+
+// CreateUser handles POST /api/admin/users.
+// Body: { "email", "password", "role" } — role defaults to member.
+func (h *AuthHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var req createUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if email == "" || !strings.Contains(email, "@") {
+		writeError(w, r, http.StatusBadRequest, "valid email required")
+		return
+	}
+	if req.Role == "" {
+		req.Role = "member"
+	}
+	if !validRole(req.Role) {
+		writeError(w, r, http.StatusBadRequest, "role must be admin or member")
+		return
+	}
+	hash, msg := hashPassword(req.Password)
+	if msg != "" {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+
+	if _, err := h.Users.GetByEmail(r.Context(), email); err == nil {
+		writeError(w, r, http.StatusConflict, "email already registered")
+		return
+	}
+
+	user := &models.User{Email: email, PasswordHash: hash, Role: req.Role}
+	if err := h.Users.Create(r.Context(), user); err != nil {
+		slog.Error("create user", "email", email, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not create user")
+		return
+	}
+
+	slog.Info("user created", "id", user.ID, "email", user.Email, "role", user.Role)
+	writeJSON(w, http.StatusCreated, user)
+}
+
+// ListUsers handles GET /api/admin/users.
+func (h *AuthHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.Users.List(r.Context())
+	if err != nil {
+		slog.Error("list users", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if users == nil {
+		users = []models.User{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"users": users, "count": len(users)})
+}
+
+type updateUserRequest struct {
+	Role     *string `json:"role"`
+	Active   *bool   `json:"active"`
+	Password *string `json:"password"`
+}
+
+// UpdateUser handles PUT /api/admin/users/{id}.
+// Body: any of { "role", "active", "password" }. Deactivating a user or
+// resetting their password signs them out of all sessions.
+func (h *AuthHandler) UpdateUser(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	var req updateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	user, err := h.Users.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, "user not found")
+		return
+	}
+	wasActiveAdmin := user.Role == "admin" && user.Active
+
+	if req.Role != nil {
+		if !validRole(*req.Role) {
+			writeError(w, r, http.StatusBadRequest, "role must be admin or member")
+			return
+		}
+		user.Role = *req.Role
+	}
+	if req.Active != nil {
+		user.Active = *req.Active
+	}
+	passwordReset := false
+	if req.Password != nil {
+		hash, msg := hashPassword(*req.Password)
+		if msg != "" {
+			writeError(w, r, http.StatusBadRequest, msg)
+			return
+		}
+		user.PasswordHash = hash
+		passwordReset = true
+	}
+
+	// Never leave the system without an active admin.
+	if wasActiveAdmin && (user.Role != "admin" || !user.Active) {
+		admins, err := h.Users.CountActiveAdmins(r.Context())
+		if err != nil {
+			slog.Error("update user: count admins", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
+		}
+		if admins <= 1 {
+			writeError(w, r, http.StatusConflict, "cannot remove the last active admin")
+			return
+		}
+	}
+
+	if err := h.Users.Update(r.Context(), user); err != nil {
+		slog.Error("update user", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not update user")
+		return
+	}
+
+	if !user.Active || passwordReset {
+		if err := h.Sessions.DeleteByUser(r.Context(), user.ID); err != nil {
+			slog.Error("update user: revoke sessions", "id", id, "err", err)
+		}
+	}
+
+	slog.Info("user updated", "id", user.ID, "role", user.Role, "active", user.Active, "password_reset", passwordReset)
+	writeJSON(w, http.StatusOK, user)
+}
+
+// DeleteUser handles DELETE /api/admin/users/{id}.
+// Removes the account along with everything it owns: sessions, chat
+// sessions, watchlist, notes and the rest go with it. Admins cannot delete
+// themselves, and the last active admin cannot be deleted.
+func (h *AuthHandler) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid user id")
+		return
+	}
+	if current := middleware.UserFromContext(r.Context()); current != nil && current.ID == id {
+		writeError(w, r, http.StatusConflict, "cannot delete your own account")
+		return
+	}
+
+	user, err := h.Users.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, "user not found")
+		return
+	}
+	if user.Role == "admin" && user.Active {
+		admins, err := h.Users.CountActiveAdmins(r.Context())
+		if err != nil {
+			slog.Error("delete user: count admins", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
+		}
+		if admins <= 1 {
+			writeError(w, r, http.StatusConflict, "cannot remove the last active admin")
+			return
+		}
+	}
+
+	if err := h.Users.Delete(r.Context(), id); err != nil {
+		slog.Error("delete user", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not delete user")
+		return
+	}
+
+	slog.Info("user deleted", "id", id)
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+func validRole(role string) bool {
+	return role == "admin" || role == "member"
+}
+
+// hashPassword bcrypt-hashes a password. It returns a user-facing error
+// message if the password is unacceptable.
+func hashPassword(password string) (string, string) {
+	if len(password) < minPasswordLen {
+		return "", "password must be at least 8 characters"
+	}
+	if len(password) > 72 {
+		return "", "password must be at most 72 bytes" // bcrypt limit
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		slog.Error("hash password", "err", err)
+		return "", "could not hash password"
+	}
+	return string(hash), ""
+}
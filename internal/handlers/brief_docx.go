@@ -0,0 +1,567 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	_ "image/jpeg" // logo formats
+	_ "image/png"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
+)
+
+// DocxBranding configures the Word export of daily briefs. Every field is
+// optional.
+type DocxBranding struct {
+	Organization string // shown in the page header; defaults to "Folio"
+	Font         string // body and heading font; defaults to Calibri
+	HeadingColor string // title and heading color as hex RGB; defaults to 1F3864
+	LogoPath     string // PNG or JPEG placed in the page header
+
+	// TemplatePath is a .docx whose styles (and theme) replace the generated
+	// ones, so headings follow the organization's Word template. Styles the
+	// export uses that the template lacks (Title, Subtitle, Heading1,
+	// Heading2, Quote, FootnoteText, FootnoteReference, Hyperlink) are added
+	// from the defaults.
+	TemplatePath string
+}
+
+const (
+	docxNS    = "http://schemas.openxmlformats.org/wordprocessingml/2006/main"
+	docxRelNS = "http://schemas.openxmlformats.org/officeDocument/2006/relationships"
+	docxPkgNS = "http://schemas.openxmlformats.org/package/2006/relationships"
+
+	// Logo height in the page header, in EMU (914400 per inch); the width
+	// follows the image's aspect ratio, up to docxLogoMaxWidth.
+	docxLogoHeight   = 457200
+	docxLogoMaxWidth = 2286000
+)
+
+var (
+	reHexColor = regexp.MustCompile(`^[0-9A-Fa-f]{6}$`)
+
+	spanishMonths = [...]string{"enero", "febrero", "marzo", "abril", "mayo", "junio",
+		"julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"}
+)
+
+// docxLogo is a logo image embedded in the document.
+type docxLogo struct {
+	data          []byte
+	ext           string // png or jpeg
+	width, height int64  // EMU
+}
+
+// docxTemplate holds the parts taken from a branding template.
+type docxTemplate struct {
+	styles string
+	theme  []byte // nil when the template has no theme
+}
+
+// docxPart is a file of the document package.
+type docxPart struct {
+	name string
+	data []byte
+}
+
+// docxDocument accumulates the body and footnotes of a document being built.
+type docxDocument struct {
+	body      strings.Builder
+	footnotes strings.Builder
+	links     []string // footnote hyperlink targets; link i has id rIdLink<i+1>
+	nextNote  int
+}
+
+// writeBriefDocx renders a brief as a Word document: a title with the date,
+// the summary with its section headings, the day's most mentioned entities,
+// its quotes and top tags. Quotes cited verbatim in the summary, and those
+// in the quotes section, get a footnote citing the article they came from.
+// A logo or template that cannot be read is an error, so misconfigured
+// branding is noticed rather than silently dropped.
+func writeBriefDocx(w io.Writer, b *models.Brief, branding DocxBranding) error {
+	org := branding.Organization
+	if org == "" {
+		org = "Folio"
+	}
+	font := branding.Font
+	if font == "" {
+		font = "Calibri"
+	}
+	color := branding.HeadingColor
+	if !reHexColor.MatchString(color) {
+		color = "1F3864"
+	}
+
+	var logo *docxLogo
+	if branding.LogoPath != "" {
+		var err error
+		if logo, err = loadDocxLogo(branding.LogoPath); err != nil {
+			return err
+		}
+	}
+	styles := defaultDocxStyles(font, color)
+	var tpl *docxTemplate
+	if branding.TemplatePath != "" {
+		var err error
+		if tpl, err = loadDocxTemplate(branding.TemplatePath); err != nil {
+			return err
+		}
+		styles = mergeDocxStyles(tpl.styles, color)
+	}
+
+	d := &docxDocument{nextNote: 1}
+	date := spanishDate(b.Date)
+	d.paragraph("Title", docxText("Resumen diario", false))
+	d.paragraph("Subtitle", docxText(fmt.Sprintf("%s · %d artículos", date, b.ArticleCount), false))
+
+	d.summary(b.Summary, b.Quotes)
+
+	if len(b.TopEntities) > 0 {
+		d.paragraph("Heading1", docxText("Entidades más mencionadas", false))
+		for _, e := range b.TopEntities {
+			d.paragraph("", docxText(e.Name, true)+
+				docxText(fmt.Sprintf(" — %s, %d noticias", scraper.EntityTypeLabel(e.Type), e.Count), false))
+		}
+	}
+
+	if len(b.Quotes) > 0 {
+		d.paragraph("Heading1", docxText("Citas", false))
+		for _, q := range b.Quotes {
+			runs := docxText("“"+q.Text+"”", false)
+			if who := scraper.QuoteAttribution(q.Quote); who != "" {
+				runs += docxText(" — "+who, false)
+			}
+			d.paragraph("Quote", runs+d.footnote(q))
+		}
+	}
+
+	if len(b.TopTags) > 0 {
+		d.paragraph("Heading1", docxText("Temas", false))
+		d.paragraph("", docxText(strings.Join(b.TopTags, ", "), false))
+	}
+
+	theme := tpl != nil && tpl.theme != nil
+	parts := []docxPart{
+		{"[Content_Types].xml", []byte(docxContentTypes(logo, theme))},
+		{"_rels/.rels", []byte(docxRels([][3]string{{"rId1", docxRelNS + "/officeDocument", "word/document.xml"}}))},
+		{"word/_rels/document.xml.rels", []byte(docxDocumentRels(theme))},
+		{"word/document.xml", []byte(d.document())},
+		{"word/styles.xml", []byte(styles)},
+		{"word/settings.xml", []byte(docxSettings)},
+		{"word/footnotes.xml", []byte(d.footnotesXML())},
+		{"word/_rels/footnotes.xml.rels", []byte(d.footnoteRels())},
+		{"word/header1.xml", []byte(docxHeader(org, logo))},
+		{"word/footer1.xml", []byte(docxFooter(org, date))},
+	}
+	if logo != nil {
+		parts = append(parts,
+			docxPart{"word/_rels/header1.xml.rels", []byte(docxRels([][3]string{{"rId1", docxRelNS + "/image", "media/logo." + logo.ext}}))},
+			docxPart{"word/media/logo." + logo.ext, logo.data},
+		)
+	}
+	if theme {
+		parts = append(parts, docxPart{"word/theme/theme1.xml", tpl.theme})
+	}
+
+	zw := zip.NewWriter(w)
+	for _, p := range parts {
+		fw, err := zw.Create(p.name)
+		if err != nil {
+			return fmt.Errorf("docx %s: %w", p.name, err)
+		}
+		if _, err := fw.Write(p.data); err != nil {
+			return fmt.Errorf("docx %s: %w", p.name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// summary renders the brief summary. Markdown headings, lines that are only
+// bold text, and bare section names (the jurisdiction, "Federal", "Diáspora")
+// become headings; other lines are paragraphs, with **bold** kept.
+func (d *docxDocument) summary(text string, quotes []models.BriefQuote) {
+	sections := map[string]bool{}
+	for _, scope := range []string{models.ScopeLocal, models.ScopeFederal, models.ScopeDiaspora} {
+		sections[strings.ToLower(scraper.BriefSection(scope))] = true
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if heading, style, ok := summaryHeading(line, sections); ok {
+			d.paragraph(style, docxText(heading, false))
+			continue
+		}
+		if rest, ok := strings.CutPrefix(line, "- "); ok {
+			line = "• " + rest
+		} else if rest, ok := strings.CutPrefix(line, "* "); ok {
+			line = "• " + rest
+		}
+
+		var runs strings.Builder
+		for i, part := range strings.Split(line, "**") {
+			runs.WriteString(d.citedText(part, i%2 == 1, quotes))
+		}
+		d.paragraph("", runs.String())
+	}
+}
+
+// summaryHeading reports whether a summary line is a heading, with its text
+// and style. Sections and "#"/"##" headings are Heading1, deeper headings
+// and bold lines Heading2.
+func summaryHeading(line string, sections map[string]bool) (string, string, bool) {
+	if text := strings.TrimLeft(line, "#"); text != line {
+		style := "Heading1"
+		if len(line)-len(text) > 2 {
+			style = "Heading2"
+		}
+		text = strings.TrimSpace(text)
+		if sections[strings.ToLower(strings.TrimSuffix(text, ":"))] {
+			style = "Heading1"
+		}
+		return text, style, true
+	}
+	if len(line) > 4 && len(line) < 80 && strings.HasPrefix(line, "**") && strings.HasSuffix(line, "**") &&
+		!strings.Contains(line[2:len(line)-2], "**") {
+		text := strings.TrimSuffix(strings.TrimSpace(line[2:len(line)-2]), ":")
+		if sections[strings.ToLower(text)] {
+			return text, "Heading1", true
+		}
+		return text, "Heading2", true
+	}
+	if name := strings.TrimSuffix(line, ":"); sections[strings.ToLower(name)] {
+		return name, "Heading1", true
+	}
+	return "", "", false
+}
+
+// citedText returns runs for text, with a footnote reference after each
+// brief quote it contains.
+func (d *docxDocument) citedText(text string, bold bool, quotes []models.BriefQuote) string {
+	type cite struct {
+		end   int
+		quote models.BriefQuote
+	}
+	var cites []cite
+	for _, q := range quotes {
+		if q.Text == "" {
+			continue
+		}
+		i := strings.Index(text, q.Text)
+		if i < 0 {
+			continue
+		}
+		end := i + len(q.Text)
+		// Place the reference after the closing quotation mark.
+		for _, mark := range []string{`"`, "”", "»", "'"} {
+			if strings.HasPrefix(text[end:], mark) {
+				end += len(mark)
+				break
+			}
+		}
+		cites = append(cites, cite{end, q})
+	}
+	sort.Slice(cites, func(i, j int) bool { return cites[i].end < cites[j].end })
+
+	var runs strings.Builder
+	start := 0
+	for _, c := range cites {
+		runs.WriteString(docxText(text[start:c.end], bold))
+		runs.WriteString(d.footnote(c.quote))
+		start = c.end
+	}
+	runs.WriteString(docxText(text[start:], bold))
+	return runs.String()
+}
+
+// paragraph appends a paragraph with the given style ("" for Normal) and runs.
+func (d *docxDocument) paragraph(style, runs string) {
+	d.body.WriteString("<w:p>")
+	if style != "" {
+		d.body.WriteString(`<w:pPr><w:pStyle w:val="` + style + `"/></w:pPr>`)
+	}
+	d.body.WriteString(runs)
+	d.body.WriteString("</w:p>")
+}
+
+// footnote adds a footnote citing the quote's article and returns the
+// reference run to place in the text.
+func (d *docxDocument) footnote(q models.BriefQuote) string {
+	id := d.nextNote
+	d.nextNote++
+
+	var cite []string
+	if q.Source != "" {
+		cite = append(cite, q.Source)
+	}
+	if q.Title != "" {
+		cite = append(cite, "«"+q.Title+"»")
+	}
+	text := strings.Join(cite, ", ")
+	if text != "" {
+		text += "."
+	}
+
+	fmt.Fprintf(&d.footnotes, `<w:footnote w:id="%d"><w:p><w:pPr><w:pStyle w:val="FootnoteText"/></w:pPr>`, id)
+	d.footnotes.WriteString(`<w:r><w:rPr><w:rStyle w:val="FootnoteReference"/></w:rPr><w:footnoteRef/></w:r>`)
+	d.footnotes.WriteString(docxText(" "+text, false))
+	if q.URL != "" {
+		d.links = append(d.links, q.URL)
+		fmt.Fprintf(&d.footnotes, `<w:r><w:t xml:space="preserve"> </w:t></w:r><w:hyperlink r:id="rIdLink%d">`, len(d.links))
+		d.footnotes.WriteString(`<w:r><w:rPr><w:rStyle w:val="Hyperlink"/></w:rPr><w:t>` + docxEscape(q.URL) + `</w:t></w:r></w:hyperlink>`)
+	}
+	d.footnotes.WriteString("</w:p></w:footnote>")
+
+	return fmt.Sprintf(`<w:r><w:rPr><w:rStyle w:val="FootnoteReference"/></w:rPr><w:footnoteReference w:id="%d"/></w:r>`, id)
+}
+
+func (d *docxDocument) document() string {
+	return xml.Header + `<w:document xmlns:w="` + docxNS + `" xmlns:r="` + docxRelNS + `"><w:body>` +
+		d.body.String() +
+		`<w:sectPr><w:headerReference w:type="default" r:id="rIdHeader"/><w:footerReference w:type="default" r:id="rIdFooter"/>` +
+		`<w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1418" w:right="1134" w:bottom="1134" w:left="1134" w:header="567" w:footer="567" w:gutter="0"/></w:sectPr>` +
+		`</w:body></w:document>`
+}
+
+func (d *docxDocument) footnotesXML() string {
+	return xml.Header + `<w:footnotes xmlns:w="` + docxNS + `" xmlns:r="` + docxRelNS + `">` +
+		`<w:footnote w:type="separator" w:id="-1"><w:p><w:pPr><w:spacing w:after="0" w:line="240" w:lineRule="auto"/></w:pPr><w:r><w:separator/></w:r></w:p></w:footnote>` +
+		`<w:footnote w:type="continuationSeparator" w:id="0"><w:p><w:pPr><w:spacing w:after="0" w:line="240" w:lineRule="auto"/></w:pPr><w:r><w:continuationSeparator/></w:r></w:p></w:footnote>` +
+		d.footnotes.String() +
+		`</w:footnotes>`
+}
+
+func (d *docxDocument) footnoteRels() string {
+	var b strings.Builder
+	b.WriteString(xml.Header + `<Relationships xmlns="` + docxPkgNS + `">`)
+	for i, link := range d.links {
+		fmt.Fprintf(&b, `<Relationship Id="rIdLink%d" Type="%s/hyperlink" Target="%s" TargetMode="External"/>`,
+			i+1, docxRelNS, docxEscape(link))
+	}
+	b.WriteString("</Relationships>")
+	return b.String()
+}
+
+// docxText returns a run of text, optionally bold.
+func docxText(text string, bold bool) string {
+	if text == "" {
+		return ""
+	}
+	rPr := ""
+	if bold {
+		rPr = "<w:rPr><w:b/></w:rPr>"
+	}
+	return `<w:r>` + rPr + `<w:t xml:space="preserve">` + docxEscape(text) + `</w:t></w:r>`
+}
+
+// docxEscape escapes text for XML; characters XML does not allow are
+// replaced with U+FFFD.
+func docxEscape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// spanishDate formats a date as "15 de octubre de 2026".
+func spanishDate(t time.Time) string {
+	return fmt.Sprintf("%d de %s de %d", t.Day(), spanishMonths[t.Month()-1], t.Year())
+}
+
+func docxRels(rels [][3]string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header + `<Relationships xmlns="` + docxPkgNS + `">`)
+	for _, r := range rels {
+		fmt.Fprintf(&b, `<Relationship Id="%s" Type="%s" Target="%s"/>`, r[0], r[1], r[2])
+	}
+	b.WriteString("</Relationships>")
+	return b.String()
+}
+
+func docxDocumentRels(theme bool) string {
+	rels := [][3]string{
+		{"rIdStyles", docxRelNS + "/styles", "styles.xml"},
+		{"rIdSettings", docxRelNS + "/settings", "settings.xml"},
+		{"rIdFootnotes", docxRelNS + "/footnotes", "footnotes.xml"},
+		{"rIdHeader", docxRelNS + "/header", "header1.xml"},
+		{"rIdFooter", docxRelNS + "/footer", "footer1.xml"},
+	}
+	if theme {
+		rels = append(rels, [3]string{"rIdTheme", docxRelNS + "/theme", "theme/theme1.xml"})
+	}
+	return docxRels(rels)
+}
+
+func docxContentTypes(logo *docxLogo, theme bool) string {
+	const wml = "application/vnd.openxmlformats-officedocument.wordprocessingml."
+	var b strings.Builder
+	b.WriteString(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	b.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	b.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	if logo != nil {
+		fmt.Fprintf(&b, `<Default Extension="%s" ContentType="image/%s"/>`, logo.ext, logo.ext)
+	}
+	for _, o := range [][2]string{
+		{"/word/document.xml", wml + "document.main+xml"},
+		{"/word/styles.xml", wml + "styles+xml"},
+		{"/word/settings.xml", wml + "settings+xml"},
+		{"/word/footnotes.xml", wml + "footnotes+xml"},
+		{"/word/header1.xml", wml + "header+xml"},
+		{"/word/footer1.xml", wml + "footer+xml"},
+	} {
+		fmt.Fprintf(&b, `<Override PartName="%s" ContentType="%s"/>`, o[0], o[1])
+	}
+	if theme {
+		b.WriteString(`<Override PartName="/word/theme/theme1.xml" ContentType="application/vnd.openxmlformats-officedocument.theme+xml"/>`)
+	}
+	b.WriteString("</Types>")
+	return b.String()
+}
+
+const docxSettings = xml.Header + `<w:settings xmlns:w="` + docxNS + `">` +
+	`<w:defaultTabStop w:val="709"/>` +
+	`<w:footnotePr><w:footnote w:id="-1"/><w:footnote w:id="0"/></w:footnotePr>` +
+	`<w:compat><w:compatSetting w:name="compatibilityMode" w:uri="http://schemas.microsoft.com/office/word" w:val="15"/></w:compat>` +
+	`</w:settings>`
+
+// docxHeader is the page header: the logo, when configured, and the
+// organization name, over a rule.
+func docxHeader(org string, logo *docxLogo) string {
+	var b strings.Builder
+	b.WriteString(xml.Header + `<w:hdr xmlns:w="` + docxNS + `" xmlns:r="` + docxRelNS +
+		`" xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing">`)
+	b.WriteString(`<w:p><w:pPr><w:pBdr><w:bottom w:val="single" w:sz="4" w:space="4" w:color="BFBFBF"/></w:pBdr></w:pPr>`)
+	if logo != nil {
+		fmt.Fprintf(&b, `<w:r><w:drawing><wp:inline distT="0" distB="0" distL="0" distR="0"><wp:extent cx="%d" cy="%d"/><wp:docPr id="1" name="Logo"/>`, logo.width, logo.height)
+		b.WriteString(`<a:graphic xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/picture">`)
+		b.WriteString(`<pic:pic xmlns:pic="http://schemas.openxmlformats.org/drawingml/2006/picture"><pic:nvPicPr><pic:cNvPr id="1" name="logo.` + logo.ext + `"/><pic:cNvPicPr/></pic:nvPicPr>`)
+		b.WriteString(`<pic:blipFill><a:blip r:embed="rId1"/><a:stretch><a:fillRect/></a:stretch></pic:blipFill>`)
+		fmt.Fprintf(&b, `<pic:spPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="%d" cy="%d"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></pic:spPr>`, logo.width, logo.height)
+		b.WriteString(`</pic:pic></a:graphicData></a:graphic></wp:inline></w:drawing></w:r>`)
+		b.WriteString(`<w:r><w:t xml:space="preserve">  </w:t></w:r>`)
+	}
+	b.WriteString(docxText(org, true))
+	b.WriteString(`</w:p></w:hdr>`)
+	return b.String()
+}
+
+// docxFooter is the page footer: the organization, brief date and page number.
+func docxFooter(org, date string) string {
+	return xml.Header + `<w:ftr xmlns:w="` + docxNS + `">` +
+		`<w:p><w:pPr><w:jc w:val="right"/><w:rPr><w:color w:val="7F7F7F"/><w:sz w:val="16"/></w:rPr></w:pPr>` +
+		`<w:r><w:rPr><w:color w:val="7F7F7F"/><w:sz w:val="16"/></w:rPr><w:t xml:space="preserve">` +
+		docxEscape(org+" · resumen diario del "+date+" · página ") + `</w:t></w:r>` +
+		`<w:fldSimple w:instr="PAGE"><w:r><w:rPr><w:color w:val="7F7F7F"/><w:sz w:val="16"/></w:rPr><w:t>1</w:t></w:r></w:fldSimple>` +
+		`</w:p></w:ftr>`
+}
+
+// loadDocxLogo reads a PNG or JPEG logo and sizes it for the page header.
+func loadDocxLogo(path string) (*docxLogo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("docx logo: %w", err)
+	}
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("docx logo %s: %w", path, err)
+	}
+	if (format != "png" && format != "jpeg") || cfg.Width == 0 || cfg.Height == 0 {
+		return nil, fmt.Errorf("docx logo %s: must be a PNG or JPEG image", path)
+	}
+
+	height := int64(docxLogoHeight)
+	width := height * int64(cfg.Width) / int64(cfg.Height)
+	if width > docxLogoMaxWidth {
+		width = docxLogoMaxWidth
+		height = width * int64(cfg.Height) / int64(cfg.Width)
+	}
+	return &docxLogo{data: data, ext: format, width: width, height: height}, nil
+}
+
+// loadDocxTemplate reads the styles and theme of a .docx template.
+func loadDocxTemplate(path string) (*docxTemplate, error) {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return nil, fmt.Errorf("docx template: %w", err)
+	}
+	defer zr.Close()
+
+	read := func(name string) ([]byte, error) {
+		f, err := zr.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return io.ReadAll(f)
+	}
+	styles, err := read("word/styles.xml")
+	if err != nil {
+		return nil, fmt.Errorf("docx template %s: styles: %w", path, err)
+	}
+	if !bytes.Contains(styles, []byte("</w:styles>")) {
+		return nil, fmt.Errorf("docx template %s: styles.xml is not a WordprocessingML style sheet", path)
+	}
+	tpl := &docxTemplate{styles: string(styles)}
+	if theme, err := read("word/theme/theme1.xml"); err == nil {
+		tpl.theme = theme
+	}
+	return tpl, nil
+}
+
+// docxStyles returns the export's default style definitions, by style ID.
+func docxStyles(color string) [][2]string {
+	return [][2]string{
+		{"Normal", `<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>`},
+		{"Title", `<w:style w:type="paragraph" w:styleId="Title"><w:name w:val="Title"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+			`<w:pPr><w:spacing w:after="60"/></w:pPr><w:rPr><w:b/><w:color w:val="` + color + `"/><w:sz w:val="44"/><w:szCs w:val="44"/></w:rPr></w:style>`},
+		{"Subtitle", `<w:style w:type="paragraph" w:styleId="Subtitle"><w:name w:val="Subtitle"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+			`<w:pPr><w:spacing w:after="360"/></w:pPr><w:rPr><w:color w:val="595959"/><w:sz w:val="24"/><w:szCs w:val="24"/></w:rPr></w:style>`},
+		{"Heading1", `<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+			`<w:pPr><w:keepNext/><w:spacing w:before="360" w:after="120"/><w:outlineLvl w:val="0"/></w:pPr><w:rPr><w:b/><w:color w:val="` + color + `"/><w:sz w:val="30"/><w:szCs w:val="30"/></w:rPr></w:style>`},
+		{"Heading2", `<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+			`<w:pPr><w:keepNext/><w:spacing w:before="240" w:after="80"/><w:outlineLvl w:val="1"/></w:pPr><w:rPr><w:b/><w:color w:val="` + color + `"/><w:sz w:val="26"/><w:szCs w:val="26"/></w:rPr></w:style>`},
+		{"Quote", `<w:style w:type="paragraph" w:styleId="Quote"><w:name w:val="Quote"/><w:basedOn w:val="Normal"/><w:next w:val="Normal"/><w:qFormat/>` +
+			`<w:pPr><w:ind w:left="567" w:right="567"/></w:pPr><w:rPr><w:i/><w:color w:val="404040"/></w:rPr></w:style>`},
+		{"FootnoteText", `<w:style w:type="paragraph" w:styleId="FootnoteText"><w:name w:val="footnote text"/><w:basedOn w:val="Normal"/>` +
+			`<w:pPr><w:spacing w:after="0" w:line="240" w:lineRule="auto"/></w:pPr><w:rPr><w:sz w:val="18"/><w:szCs w:val="18"/></w:rPr></w:style>`},
+		{"FootnoteReference", `<w:style w:type="character" w:styleId="FootnoteReference"><w:name w:val="footnote reference"/><w:rPr><w:vertAlign w:val="superscript"/></w:rPr></w:style>`},
+		{"Hyperlink", `<w:style w:type="character" w:styleId="Hyperlink"><w:name w:val="Hyperlink"/><w:rPr><w:color w:val="0563C1"/><w:u w:val="single"/></w:rPr></w:style>`},
+	}
+}
+
+// defaultDocxStyles is the style sheet used without a template.
+func defaultDocxStyles(font, color string) string {
+	var b strings.Builder
+	b.WriteString(xml.Header + `<w:styles xmlns:w="` + docxNS + `">`)
+	fmt.Fprintf(&b, `<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="%[1]s" w:hAnsi="%[1]s" w:eastAsia="%[1]s" w:cs="%[1]s"/>`, docxEscape(font))
+	b.WriteString(`<w:sz w:val="22"/><w:szCs w:val="22"/><w:lang w:val="es-PR"/></w:rPr></w:rPrDefault>`)
+	b.WriteString(`<w:pPrDefault><w:pPr><w:spacing w:after="160" w:line="276" w:lineRule="auto"/></w:pPr></w:pPrDefault></w:docDefaults>`)
+	for _, s := range docxStyles(color) {
+		b.WriteString(s[1])
+	}
+	b.WriteString("</w:styles>")
+	return b.String()
+}
+
+// mergeDocxStyles adds the export's styles missing from a template's style
+// sheet, keeping the template's definitions of the rest.
+func mergeDocxStyles(styles, color string) string {
+	var missing strings.Builder
+	for _, s := range docxStyles(color) {
+		if s[0] == "Normal" || strings.Contains(styles, `w:styleId="`+s[0]+`"`) {
+			continue
+		}
+		missing.WriteString(s[1])
+	}
+	i := strings.LastIndex(styles, "</w:styles>")
+	return styles[:i] + missing.String() + styles[i:]
+}
@@ -1,21 +1,33 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"encoding/json"
 	"github.com/Saul-Punybz/folio/internal/ai"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/scraper"
+	"strings"
+	"time"
 )
 
 // BriefHandler groups daily brief HTTP handlers.
 type BriefHandler struct {
 	Briefs   *models.BriefStore
 	Articles *models.ArticleStore
-	AI       *ai.OllamaClient
+	Entities *models.EntityStore
+	AI       ai.Provider
+	Branding DocxBranding // Word export branding
 }
 
 // GetLatestBrief handles GET /api/briefs/latest.
@@ -24,24 +36,106 @@ func (h *BriefHandler) GetLatestBrief(w http.ResponseWriter, r *http.Request) {
 	brief, err := h.Briefs.GetLatest(r.Context())
 	if err != nil {
 		slog.Error("get latest brief", "err", err)
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "no briefs available"})
+		writeError(w, r, http.StatusNotFound, "no briefs available")
 		return
 	}
 
 	writeJSON(w, http.StatusOK, brief)
 }
 
+// maxBriefDays caps the date range of a generated brief.
+const maxBriefDays = 31
+
+// generateBriefRequest is the optional body of POST /api/briefs/generate.
+type generateBriefRequest struct {
+	From     string   `json:"from"`
+	To       string   `json:"to"`
+	Tags     []string `json:"tags"`
+	Region   string   `json:"region"`
+	Language string   `json:"language"`
+	Model    string   `json:"model"`
+}
+
+// toParams validates the request and fills in the daily brief's defaults.
+// It returns a user-facing error message when the request is invalid.
+func (req *generateBriefRequest) toParams(now time.Time) (models.BriefParams, string) {
+	p := scraper.DefaultBriefParams(now)
+	if req.To != "" {
+		t, err := time.Parse("2006-01-02", req.To)
+		if err != nil {
+			return p, "invalid 'to' date, use YYYY-MM-DD"
+		}
+		p.To = t.AddDate(0, 0, 1) // inclusive
+		p.From = t
+	}
+	if req.From != "" {
+		t, err := time.Parse("2006-01-02", req.From)
+		if err != nil {
+			return p, "invalid 'from' date, use YYYY-MM-DD"
+		}
+		p.From = t
+	}
+	if !p.From.Before(p.To) {
+		return p, "'to' must not be before 'from'"
+	}
+
+	for _, tag := range req.Tags {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			p.Tags = append(p.Tags, tag)
+		}
+	}
+	p.Region = strings.TrimSpace(req.Region)
+
+	switch req.Language {
+	case "":
+	case "es", "en":
+		p.Language = req.Language
+	default:
+		return p, "invalid language, use es or en"
+	}
+	if model := strings.TrimSpace(req.Model); model != "" {
+		if len(model) > 100 {
+			return p, "invalid model"
+		}
+		p.Model = model
+	}
+	return p, ""
+}
+
 // GenerateBrief handles POST /api/briefs/generate.
-// Manually triggers daily brief generation.
+// Body (optional): {"from": "2025-01-06", "to": "2025-01-12", "tags": ["salud"],
+// "region": "PR", "language": "en", "model": "llama3.1:8b"}. Generates a
+// brief in the background. Dates are YYYY-MM-DD, to inclusive; without them
+// the brief covers the last 24 hours. Tags (any of them) and region narrow
+// the articles; language and model default to the daily brief's. The brief
+// is stored under its last day, replacing that day's brief, with the
+// parameters used.
 func (h *BriefHandler) GenerateBrief(w http.ResponseWriter, r *http.Request) {
 	if h.Articles == nil || h.AI == nil {
-		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "AI not configured"})
+		writeError(w, r, http.StatusServiceUnavailable, "AI not configured")
+		return
+	}
+
+	var req generateBriefRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+	params, msg := req.toParams(time.Now())
+	if msg != "" {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+	if params.To.Sub(params.From) > maxBriefDays*24*time.Hour {
+		writeError(w, r, http.StatusBadRequest, "date range must not exceed %d days", maxBriefDays)
 		return
 	}
 
-	go scraper.GenerateDailyBrief(context.Background(), h.Articles, h.Briefs, h.AI)
+	go scraper.GenerateBrief(context.Background(), h.Articles, h.Briefs, h.Entities, h.AI, params)
 
-	writeJSON(w, http.StatusAccepted, map[string]string{"status": "generating"})
+	writeJSON(w, http.StatusAccepted, map[string]any{"status": "generating", "params": params})
 }
 
 // ListBriefs handles GET /api/briefs?limit=7.
@@ -55,7 +149,7 @@ func (h *BriefHandler) ListBriefs(w http.ResponseWriter, r *http.Request) {
 	briefs, err := h.Briefs.List(r.Context(), limit)
 	if err != nil {
 		slog.Error("list briefs", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -68,3 +162,38 @@ func (h *BriefHandler) ListBriefs(w http.ResponseWriter, r *http.Request) {
 		"count":  len(briefs),
 	})
 }
+
+// ExportBriefDocx handles GET /api/briefs/{id}/export.docx.
+// Returns the brief as a Word document with the configured branding (logo,
+// template styles), its cited quotes as footnotes.
+func (h *BriefHandler) ExportBriefDocx(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid brief id")
+		return
+	}
+
+	brief, err := h.Briefs.GetByID(r.Context(), id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		writeError(w, r, http.StatusNotFound, "brief not found")
+		return
+	}
+	if err != nil {
+		slog.Error("export brief docx", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	// Render fully before writing headers so a failure can still be
+	// reported as JSON.
+	var buf bytes.Buffer
+	if err := writeBriefDocx(&buf, brief, h.Branding); err != nil {
+		slog.Error("export brief docx", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "failed to render docx")
+		return
+	}
+	w.Header().Set("Content-Type", "application/vnd.openxmlformats-officedocument.wordprocessingml.document")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="folio-brief-%s.docx"`, brief.Date.Format("2006-01-02")))
+	w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	w.Write(buf.Bytes())
+}
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/useragent"
+)
+
+const (
+	// cardSummaryLen caps the description in cards; unfurlers cut longer
+	// ones anyway.
+	cardSummaryLen = 300
+
+	// maxCardImageSize caps a proxied article image.
+	maxCardImageSize = 5 << 20
+)
+
+// ArticleCard is the link-preview metadata of an article. Image is the
+// share image proxy URL, empty when the article has no image.
+type ArticleCard struct {
+	ID          uuid.UUID  `json:"id"`
+	Title       string     `json:"title"`
+	Summary     string     `json:"summary"`
+	Image       string     `json:"image,omitempty"`
+	Source      string     `json:"source"`
+	URL         string     `json:"url"`
+	ShareURL    string     `json:"share_url"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	Language    string     `json:"language,omitempty"`
+}
+
+// GetCard handles GET /api/items/{id}/card.
+// Returns OpenGraph-style metadata for the article and its public share URL.
+func (h *ItemsHandler) GetCard(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.cardArticle(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, articleCard(article, requestBaseURL(r)))
+}
+
+// SharePage handles GET /share/{id}.
+// A public page carrying OpenGraph and Twitter card tags so shared links
+// unfurl in Slack, WhatsApp and the like; people opening it are sent on to
+// the original article. Trashed articles are not shared.
+func (h *ItemsHandler) SharePage(w http.ResponseWriter, r *http.Request) {
+	access := models.FeedAccess{Kind: models.FeedAccessShare, Status: http.StatusOK}
+	defer func() { recordFeedAccess(h.FeedAccess, r, access) }()
+
+	article, ok := h.cardArticle(w, r)
+	if !ok {
+		access.Status = http.StatusNotFound
+		return
+	}
+	access.ArticleID = &article.ID
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+	w.Header().Set("X-Robots-Tag", "noindex")
+	if err := sharePageTmpl.Execute(w, articleCard(article, requestBaseURL(r))); err != nil {
+		slog.Error("share page: render", "id", article.ID, "err", err)
+	}
+}
+
+// ShareImage handles GET /share/{id}/image.
+// Proxies the article's image so cards do not depend on the publisher
+// allowing hotlinks. Only public addresses are fetched.
+func (h *ItemsHandler) ShareImage(w http.ResponseWriter, r *http.Request) {
+	article, ok := h.cardArticle(w, r)
+	if !ok {
+		return
+	}
+	if article.ImageURL == "" {
+		writeError(w, r, http.StatusNotFound, "article has no image")
+		return
+	}
+
+	u, err := url.Parse(article.ImageURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		writeError(w, r, http.StatusNotFound, "article has no image")
+		return
+	}
+
+	req, err := http.NewRequestWithContext(r.Context(), http.MethodGet, u.String(), nil)
+	if err != nil {
+		writeError(w, r, http.StatusBadGateway, "image unavailable")
+		return
+	}
+	useragent.Apply(req)
+	req.Header.Set("Accept", "image/*")
+
+	resp, err := cardImageClient.Do(req)
+	if err != nil {
+		slog.Warn("share image: fetch", "id", article.ID, "err", err)
+		writeError(w, r, http.StatusBadGateway, "image unavailable")
+		return
+	}
+	defer resp.Body.Close()
+
+	contentType := resp.Header.Get("Content-Type")
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(contentType, "image/") || resp.ContentLength > maxCardImageSize {
+		writeError(w, r, http.StatusBadGateway, "image unavailable")
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCardImageSize+1))
+	if err != nil || len(data) > maxCardImageSize {
+		writeError(w, r, http.StatusBadGateway, "image unavailable")
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Cache-Control", "public, max-age=86400")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+// cardArticle loads the {id} article for a card, writing a 400 or 404 and
+// returning false when there is none to show.
+func (h *ItemsHandler) cardArticle(w http.ResponseWriter, r *http.Request) (*models.Article, bool) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
+		return nil, false
+	}
+	article, err := h.Articles.GetByID(r.Context(), id)
+	if err != nil || article.Status == "trashed" {
+		writeError(w, r, http.StatusNotFound, "article not found")
+		return nil, false
+	}
+	return article, true
+}
+
+// articleCard builds the card of an article; baseURL is the scheme and host
+// the share URLs point at.
+func articleCard(a *models.Article, baseURL string) ArticleCard {
+	summary := a.Summary
+	if summary == "" {
+		summary = a.CleanText
+	}
+	summary = strings.Join(strings.Fields(summary), " ")
+	if len(summary) > cardSummaryLen {
+		summary = summary[:cardSummaryLen]
+		for !utf8.ValidString(summary) {
+			summary = summary[:len(summary)-1]
+		}
+		summary += "…"
+	}
+
+	link := a.CanonicalURL
+	if link == "" {
+		link = a.URL
+	}
+	// The share page redirects to the link; only web URLs are followed.
+	if u, err := url.Parse(link); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		link = ""
+	}
+
+	shareURL := fmt.Sprintf("%s/share/%s", baseURL, a.ID)
+	card := ArticleCard{
+		ID:          a.ID,
+		Title:       a.Title,
+		Summary:     summary,
+		Source:      a.Source,
+		URL:         link,
+		ShareURL:    shareURL,
+		PublishedAt: a.PublishedAt,
+		Language:    a.Language,
+	}
+	if a.ImageURL != "" {
+		card.Image = shareURL + "/image"
+	}
+	return card
+}
+
+// requestBaseURL returns the scheme and host the request was made to.
+func requestBaseURL(r *http.Request) string {
+	scheme := "https"
+	if r.TLS == nil && r.Header.Get("X-Forwarded-Proto") != "https" {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s", scheme, r.Host)
+}
+
+// cardImageClient fetches article images for the share image proxy. Its
+// dialer refuses loopback, private and link-local addresses so image URLs
+// taken from scraped pages cannot reach internal services.
+var cardImageClient = &http.Client{
+	Timeout: 10 * time.Second,
+	Transport: fetchlog.Transport(&http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				ip := net.ParseIP(host)
+				if ip == nil || !ip.IsGlobalUnicast() || ip.IsPrivate() {
+					return errPrivateImageHost
+				}
+				return nil
+			},
+		}).DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 5 * time.Second,
+	}, fetchlog.Share),
+	CheckRedirect: func(req *http.Request, via []*http.Request) error {
+		if len(via) >= 3 {
+			return errors.New("too many redirects")
+		}
+		return nil
+	},
+}
+
+var errPrivateImageHost = errors.New("image host is not a public address")
+
+var sharePageTmpl = template.Must(template.New("share").Parse(`<!DOCTYPE html>
+<html lang="{{if .Language}}{{.Language}}{{else}}es{{end}}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{.Title}} — {{.Source}}</title>
+<meta name="description" content="{{.Summary}}">
+<meta property="og:type" content="article">
+<meta property="og:site_name" content="Folio">
+<meta property="og:title" content="{{.Title}}">
+<meta property="og:description" content="{{.Summary}}">
+<meta property="og:url" content="{{.ShareURL}}">
+{{- if .Image}}
+<meta property="og:image" content="{{.Image}}">
+<meta name="twitter:card" content="summary_large_image">
+<meta name="twitter:image" content="{{.Image}}">
+{{- else}}
+<meta name="twitter:card" content="summary">
+{{- end}}
+<meta name="twitter:title" content="{{.Title}}">
+<meta name="twitter:description" content="{{.Summary}}">
+{{- if .PublishedAt}}
+<meta property="article:published_time" content="{{.PublishedAt.UTC.Format "2006-01-02T15:04:05Z07:00"}}">
+{{- end}}
+{{- if .URL}}
+<meta http-equiv="refresh" content="0; url={{.URL}}">
+<link rel="canonical" href="{{.URL}}">
+{{- end}}
+</head>
+<body style="font-family: system-ui, sans-serif; max-width: 40rem; margin: 3rem auto; padding: 0 1rem;">
+<p style="color: #71717a; font-size: .8rem; text-transform: uppercase;">{{.Source}}</p>
+<h1 style="font-size: 1.4rem;">{{.Title}}</h1>
+<p>{{.Summary}}</p>
+{{- if .URL}}
+<p><a href="{{.URL}}">Leer en {{.Source}}</a></p>
+{{- end}}
+</body>
+</html>
+`))
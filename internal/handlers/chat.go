@@ -1,33 +1,130 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/intelligence"
 	"github.com/Saul-Punybz/folio/internal/middleware"
 	"github.com/Saul-Punybz/folio/internal/models"
 )
 
 type ChatHandler struct {
 	Sessions *models.ChatSessionStore
+	Articles *models.ArticleStore
+	Hits     *models.WatchlistHitStore
+	AI       ai.Provider
+
+	// Collect backs the agent's collect_url tool (ItemsHandler.CollectURL);
+	// the tool is not offered when nil.
+	Collect func(ctx context.Context, rawURL, title string) (*models.Article, bool, error)
+}
+
+// AgentChat handles POST /api/chat/agent.
+// Body: {"question": "..."}. The model may call tools while answering:
+// search_articles, get_article, list_watchlist_hits (the caller's hits) and
+// collect_url (adds an inbox article). Returns the answer and the tool
+// calls made, with their arguments and results.
+func (h *ChatHandler) AgentChat(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	if h.AI == nil || h.Articles == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "AI not configured")
+		return
+	}
+
+	var body struct {
+		Question string `json:"question"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || strings.TrimSpace(body.Question) == "" {
+		writeError(w, r, http.StatusBadRequest, "question is required")
+		return
+	}
+
+	resp, err := intelligence.Agent(r.Context(), intelligence.AgentDeps{
+		Deps:    intelligence.Deps{Articles: h.Articles, AI: h.AI},
+		Hits:    h.Hits,
+		Collect: h.Collect,
+	}, intelligence.AgentRequest{
+		UserID:   user.ID,
+		Question: strings.TrimSpace(body.Question),
+	})
+	if err != nil {
+		slog.Error("chat agent: generate", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "AI failed to respond")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// askRequest is the body of POST /api/chat/ask.
+type askRequest struct {
+	Question string `json:"question"`
+	TopK     int    `json:"top_k"`
+}
+
+// Ask handles POST /api/chat/ask.
+// Answers the question from the top_k stored articles closest to it by
+// embedding (default 8, max 20), citing them as [N] against the returned
+// sources. Falls back to keyword retrieval when embedding fails or nothing
+// is similar enough; never searches the web.
+func (h *ChatHandler) Ask(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	if h.AI == nil || h.Articles == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "AI not configured")
+		return
+	}
+
+	var req askRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Question) == "" {
+		writeError(w, r, http.StatusBadRequest, "question is required")
+		return
+	}
+
+	resp, err := intelligence.Ask(r.Context(), intelligence.Deps{
+		Articles: h.Articles,
+		AI:       h.AI,
+	}, intelligence.AskRequest{
+		Question: strings.TrimSpace(req.Question),
+		TopK:     req.TopK,
+	})
+	if err != nil {
+		slog.Error("chat ask: generate", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "AI failed to respond")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
 }
 
 // ListSessions handles GET /api/chat/sessions.
 func (h *ChatHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	sessions, err := h.Sessions.ListByUser(r.Context(), user.ID, 50)
 	if err != nil {
 		slog.Error("list chat sessions", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -44,24 +141,24 @@ func (h *ChatHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
 func (h *ChatHandler) GetSession(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid session id"})
+		writeError(w, r, http.StatusBadRequest, "invalid session id")
 		return
 	}
 
 	session, err := h.Sessions.GetByID(r.Context(), id)
 	if err != nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "session not found"})
+		writeError(w, r, http.StatusNotFound, "session not found")
 		return
 	}
 
 	if session.UserID != user.ID {
-		writeJSON(w, http.StatusForbidden, map[string]string{"error": "forbidden"})
+		writeError(w, r, http.StatusForbidden, "forbidden")
 		return
 	}
 
@@ -77,13 +174,13 @@ type createSessionRequest struct {
 func (h *ChatHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	var req createSessionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
@@ -95,13 +192,113 @@ func (h *ChatHandler) CreateSession(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.Sessions.Create(r.Context(), session); err != nil {
 		slog.Error("create chat session", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not create session"})
+		writeError(w, r, http.StatusInternalServerError, "could not create session")
 		return
 	}
 
 	writeJSON(w, http.StatusCreated, session)
 }
 
+// sessionTitleLen caps the title given to an untitled session from its
+// first question.
+const sessionTitleLen = 60
+
+// SendMessage handles POST /api/chat/sessions/{id}/message.
+// Answers the question like /api/admin/chat, with the session's earlier
+// messages condensed into the prompt so follow-up questions keep their
+// context, then appends the question and answer to the session in one
+// update. An untitled session is titled after the question.
+func (h *ChatHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	if h.AI == nil || h.Articles == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "AI not configured")
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid session id")
+		return
+	}
+
+	var body struct {
+		Question string `json:"question"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || strings.TrimSpace(body.Question) == "" {
+		writeError(w, r, http.StatusBadRequest, "question is required")
+		return
+	}
+	question := strings.TrimSpace(body.Question)
+
+	session, err := h.Sessions.GetByID(r.Context(), id)
+	if err != nil || session.UserID != user.ID {
+		writeError(w, r, http.StatusNotFound, "session not found")
+		return
+	}
+
+	var prior []models.ChatMessage
+	if len(session.Messages) > 0 {
+		if err := json.Unmarshal(session.Messages, &prior); err != nil {
+			slog.Warn("chat message: decode session messages", "session_id", id, "err", err)
+		}
+	}
+	history := make([]intelligence.ChatTurn, 0, len(prior))
+	for _, m := range prior {
+		history = append(history, intelligence.ChatTurn{Role: m.Role, Content: m.Content})
+	}
+
+	resp, err := intelligence.Chat(r.Context(), intelligence.Deps{
+		Articles: h.Articles,
+		AI:       h.AI,
+	}, intelligence.ChatRequest{
+		Question: question,
+		History:  history,
+	})
+	if err != nil {
+		slog.Error("chat message: generate", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "AI failed to respond")
+		return
+	}
+
+	reply := models.ChatMessage{Role: "assistant", Content: resp.Answer}
+	if len(resp.Sources) > 0 {
+		reply.Sources, _ = json.Marshal(resp.Sources)
+	}
+	if len(resp.WebSources) > 0 {
+		reply.WebSources, _ = json.Marshal(resp.WebSources)
+	}
+	title := question
+	if runes := []rune(title); len(runes) > sessionTitleLen {
+		title = string(runes[:sessionTitleLen]) + "..."
+	}
+
+	session, err = h.Sessions.AppendMessages(r.Context(), id, user.ID, title, []models.ChatMessage{
+		{Role: "user", Content: question},
+		reply,
+	})
+	if errors.Is(err, models.ErrChatSessionNotFound) {
+		writeError(w, r, http.StatusNotFound, "session not found")
+		return
+	}
+	if err != nil {
+		slog.Error("chat message: append", "session_id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not update session")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"answer":        resp.Answer,
+		"articles_used": resp.ArticlesUsed,
+		"sources":       resp.Sources,
+		"web_sources":   resp.WebSources,
+		"session":       session,
+	})
+}
+
 type updateSessionRequest struct {
 	Title    string          `json:"title"`
 	Messages json.RawMessage `json:"messages"`
@@ -111,36 +308,36 @@ type updateSessionRequest struct {
 func (h *ChatHandler) UpdateSession(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid session id"})
+		writeError(w, r, http.StatusBadRequest, "invalid session id")
 		return
 	}
 
 	// Verify ownership.
 	session, err := h.Sessions.GetByID(r.Context(), id)
 	if err != nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "session not found"})
+		writeError(w, r, http.StatusNotFound, "session not found")
 		return
 	}
 	if session.UserID != user.ID {
-		writeJSON(w, http.StatusForbidden, map[string]string{"error": "forbidden"})
+		writeError(w, r, http.StatusForbidden, "forbidden")
 		return
 	}
 
 	var req updateSessionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if err := h.Sessions.Update(r.Context(), id, req.Title, req.Messages); err != nil {
 		slog.Error("update chat session", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not update session"})
+		writeError(w, r, http.StatusInternalServerError, "could not update session")
 		return
 	}
 
@@ -151,32 +348,51 @@ func (h *ChatHandler) UpdateSession(w http.ResponseWriter, r *http.Request) {
 func (h *ChatHandler) DeleteSession(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid session id"})
+		writeError(w, r, http.StatusBadRequest, "invalid session id")
 		return
 	}
 
 	// Verify ownership.
 	session, err := h.Sessions.GetByID(r.Context(), id)
 	if err != nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "session not found"})
+		writeError(w, r, http.StatusNotFound, "session not found")
 		return
 	}
 	if session.UserID != user.ID {
-		writeJSON(w, http.StatusForbidden, map[string]string{"error": "forbidden"})
+		writeError(w, r, http.StatusForbidden, "forbidden")
 		return
 	}
 
 	if err := h.Sessions.Delete(r.Context(), id); err != nil {
 		slog.Error("delete chat session", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not delete session"})
+		writeError(w, r, http.StatusInternalServerError, "could not delete session")
 		return
 	}
 
 	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
 }
+
+// DeleteAllSessions handles DELETE /api/chat/sessions.
+// Deletes all of the user's chat sessions.
+func (h *ChatHandler) DeleteAllSessions(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	deleted, err := h.Sessions.DeleteAllByUser(r.Context(), user.ID)
+	if err != nil {
+		slog.Error("delete all chat sessions", "user_id", user.ID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not delete sessions")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"status": "deleted", "deleted": deleted})
+}
@@ -56,7 +56,7 @@ func (h *CrawlerHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
 
 	runs, err := h.Runs.ListRecent(r.Context(), limit)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]any{"runs": runs, "count": len(runs)})
@@ -66,7 +66,7 @@ func (h *CrawlerHandler) ListRuns(w http.ResponseWriter, r *http.Request) {
 func (h *CrawlerHandler) QueueStats(w http.ResponseWriter, r *http.Request) {
 	counts, err := h.Queue.CountsByStatus(r.Context())
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]any{"queue": counts})
@@ -84,7 +84,7 @@ func (h *CrawlerHandler) ListPages(w http.ResponseWriter, r *http.Request) {
 	if domainIDStr != "" {
 		domainID, parseErr := uuid.Parse(domainIDStr)
 		if parseErr != nil {
-			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid domain_id"})
+			writeError(w, r, http.StatusBadRequest, "invalid domain_id")
 			return
 		}
 		pages, err = h.Pages.ListByDomain(r.Context(), domainID, limit, offset)
@@ -93,7 +93,7 @@ func (h *CrawlerHandler) ListPages(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]any{"pages": pages, "count": len(pages)})
@@ -103,14 +103,14 @@ func (h *CrawlerHandler) ListPages(w http.ResponseWriter, r *http.Request) {
 func (h *CrawlerHandler) SearchPages(w http.ResponseWriter, r *http.Request) {
 	query := r.URL.Query().Get("q")
 	if query == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "missing q parameter"})
+		writeError(w, r, http.StatusBadRequest, "missing q parameter")
 		return
 	}
 
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 	pages, err := h.Pages.SearchFTS(r.Context(), query, limit)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]any{"pages": pages, "count": len(pages)})
@@ -121,7 +121,7 @@ func (h *CrawlerHandler) ListChangedPages(w http.ResponseWriter, r *http.Request
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 	pages, err := h.Pages.ListChanged(r.Context(), limit)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]any{"pages": pages, "count": len(pages)})
@@ -131,13 +131,13 @@ func (h *CrawlerHandler) ListChangedPages(w http.ResponseWriter, r *http.Request
 func (h *CrawlerHandler) GetPage(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid id"})
+		writeError(w, r, http.StatusBadRequest, "invalid id")
 		return
 	}
 
 	page, err := h.Pages.GetByID(r.Context(), id)
 	if err != nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "page not found"})
+		writeError(w, r, http.StatusNotFound, "page not found")
 		return
 	}
 
@@ -158,7 +158,7 @@ func (h *CrawlerHandler) GetGraph(w http.ResponseWriter, r *http.Request) {
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 	nodes, edges, err := h.Rels.GetGraph(r.Context(), limit)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]any{"nodes": nodes, "edges": edges})
@@ -168,13 +168,13 @@ func (h *CrawlerHandler) GetGraph(w http.ResponseWriter, r *http.Request) {
 func (h *CrawlerHandler) GetEntityRelations(w http.ResponseWriter, r *http.Request) {
 	entityID, err := uuid.Parse(chi.URLParam(r, "entityId"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid entityId"})
+		writeError(w, r, http.StatusBadRequest, "invalid entityId")
 		return
 	}
 
 	rels, entities, err := h.Rels.GetEntityRelations(r.Context(), entityID)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]any{"relationships": rels, "entities": entities})
@@ -184,7 +184,7 @@ func (h *CrawlerHandler) GetEntityRelations(w http.ResponseWriter, r *http.Reque
 func (h *CrawlerHandler) ListDomains(w http.ResponseWriter, r *http.Request) {
 	domains, err := h.Domains.List(r.Context())
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]any{"domains": domains, "count": len(domains)})
@@ -201,11 +201,11 @@ func (h *CrawlerHandler) CreateDomain(w http.ResponseWriter, r *http.Request) {
 		Priority     int    `json:"priority"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 	if req.Domain == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "domain is required"})
+		writeError(w, r, http.StatusBadRequest, "domain is required")
 		return
 	}
 	if req.MaxDepth <= 0 {
@@ -230,7 +230,7 @@ func (h *CrawlerHandler) CreateDomain(w http.ResponseWriter, r *http.Request) {
 		Priority:     req.Priority,
 	}
 	if err := h.Domains.Create(r.Context(), d); err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to create domain"})
+		writeError(w, r, http.StatusInternalServerError, "failed to create domain")
 		return
 	}
 	writeJSON(w, http.StatusCreated, d)
@@ -240,7 +240,7 @@ func (h *CrawlerHandler) CreateDomain(w http.ResponseWriter, r *http.Request) {
 func (h *CrawlerHandler) UpdateDomain(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid id"})
+		writeError(w, r, http.StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -252,7 +252,7 @@ func (h *CrawlerHandler) UpdateDomain(w http.ResponseWriter, r *http.Request) {
 		Priority     int    `json:"priority"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
@@ -265,7 +265,7 @@ func (h *CrawlerHandler) UpdateDomain(w http.ResponseWriter, r *http.Request) {
 		Priority:     req.Priority,
 	}
 	if err := h.Domains.Update(r.Context(), d); err != nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "domain not found"})
+		writeError(w, r, http.StatusNotFound, "domain not found")
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]string{"status": "updated"})
@@ -275,7 +275,7 @@ func (h *CrawlerHandler) UpdateDomain(w http.ResponseWriter, r *http.Request) {
 func (h *CrawlerHandler) ToggleDomain(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid id"})
+		writeError(w, r, http.StatusBadRequest, "invalid id")
 		return
 	}
 
@@ -283,12 +283,12 @@ func (h *CrawlerHandler) ToggleDomain(w http.ResponseWriter, r *http.Request) {
 		Active bool `json:"active"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if err := h.Domains.ToggleActive(r.Context(), id, req.Active); err != nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "domain not found"})
+		writeError(w, r, http.StatusNotFound, "domain not found")
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]string{"status": "toggled"})
@@ -298,12 +298,12 @@ func (h *CrawlerHandler) ToggleDomain(w http.ResponseWriter, r *http.Request) {
 func (h *CrawlerHandler) DeleteDomain(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid id"})
+		writeError(w, r, http.StatusBadRequest, "invalid id")
 		return
 	}
 
 	if err := h.Domains.Delete(r.Context(), id); err != nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "domain not found"})
+		writeError(w, r, http.StatusNotFound, "domain not found")
 		return
 	}
 	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
@@ -318,5 +318,5 @@ func (h *CrawlerHandler) TriggerCrawl(w http.ResponseWriter, r *http.Request) {
 		crawler.RunCrawl(ctx, h.CrawlDeps, 100)
 		slog.Info("crawler: manual trigger finished")
 	}()
-	writeJSON(w, http.StatusOK, map[string]string{"status": "triggered", "message": "Crawl started in background"})
+	writeJSON(w, http.StatusOK, map[string]string{"status": "triggered", "message": localize(r, "Crawl started in background")})
 }
@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// EntitiesHandler groups entity HTTP handlers.
+type EntitiesHandler struct {
+	Entities *models.EntityStore
+}
+
+// ListArticles handles GET /api/entities/{name}/articles?type=&limit=&offset=.
+// name is matched case-insensitively against the entity's canonical name;
+// type (person, organization, place) narrows it when a name has several.
+func (h *EntitiesHandler) ListArticles(w http.ResponseWriter, r *http.Request) {
+	name, err := url.PathUnescape(chi.URLParam(r, "name"))
+	if err != nil || name == "" {
+		writeError(w, r, http.StatusBadRequest, "invalid entity name")
+		return
+	}
+	entityType := r.URL.Query().Get("type")
+	if entityType != "" && entityType != "person" && entityType != "organization" && entityType != "place" {
+		writeError(w, r, http.StatusBadRequest, "invalid type, use person, organization, or place")
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	entities, err := h.Entities.GetByName(r.Context(), name, entityType)
+	if err != nil {
+		slog.Error("get entity", "name", name, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if len(entities) == 0 {
+		writeError(w, r, http.StatusNotFound, "entity not found")
+		return
+	}
+
+	articles, err := h.Entities.ArticlesForEntity(r.Context(), name, entityType, limit, offset)
+	if err != nil {
+		slog.Error("entity articles", "name", name, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if articles == nil {
+		articles = []models.Article{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"entities": entities,
+		"articles": articles,
+		"count":    len(articles),
+		"limit":    limit,
+		"offset":   offset,
+	})
+}
@@ -22,7 +22,7 @@ type EscritosHandler struct {
 	Escritos *models.EscritoStore
 	Sources  *models.EscritoSourceStore
 	Articles *models.ArticleStore
-	AI       *ai.OllamaClient
+	AI       ai.Provider
 }
 
 type createEscritoRequest struct {
@@ -34,19 +34,19 @@ type createEscritoRequest struct {
 func (h *EscritosHandler) CreateEscrito(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	var req createEscritoRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	topic := strings.TrimSpace(req.Topic)
 	if topic == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "topic is required"})
+		writeError(w, r, http.StatusBadRequest, "topic is required")
 		return
 	}
 
@@ -57,7 +57,7 @@ func (h *EscritosHandler) CreateEscrito(w http.ResponseWriter, r *http.Request)
 
 	if err := h.Escritos.Create(r.Context(), escrito); err != nil {
 		slog.Error("create escrito", "user_id", user.ID, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not create escrito"})
+		writeError(w, r, http.StatusInternalServerError, "could not create escrito")
 		return
 	}
 
@@ -84,14 +84,14 @@ func (h *EscritosHandler) CreateEscrito(w http.ResponseWriter, r *http.Request)
 func (h *EscritosHandler) ListEscritos(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	escritos, err := h.Escritos.ListByUser(r.Context(), user.ID)
 	if err != nil {
 		slog.Error("list escritos", "user_id", user.ID, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	if escritos == nil {
@@ -105,23 +105,23 @@ func (h *EscritosHandler) ListEscritos(w http.ResponseWriter, r *http.Request) {
 func (h *EscritosHandler) GetEscrito(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid escrito id"})
+		writeError(w, r, http.StatusBadRequest, "invalid escrito id")
 		return
 	}
 
 	escrito, err := h.Escritos.GetByID(r.Context(), id)
 	if err != nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "escrito not found"})
+		writeError(w, r, http.StatusNotFound, "escrito not found")
 		return
 	}
 	if escrito.UserID != user.ID {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "escrito not found"})
+		writeError(w, r, http.StatusNotFound, "escrito not found")
 		return
 	}
 
@@ -140,19 +140,19 @@ func (h *EscritosHandler) GetEscrito(w http.ResponseWriter, r *http.Request) {
 func (h *EscritosHandler) UpdateEscrito(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid escrito id"})
+		writeError(w, r, http.StatusBadRequest, "invalid escrito id")
 		return
 	}
 
 	escrito, err := h.Escritos.GetByID(r.Context(), id)
 	if err != nil || escrito.UserID != user.ID {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "escrito not found"})
+		writeError(w, r, http.StatusNotFound, "escrito not found")
 		return
 	}
 
@@ -165,7 +165,7 @@ func (h *EscritosHandler) UpdateEscrito(w http.ResponseWriter, r *http.Request)
 		PublishStatus *string  `json:"publish_status"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
@@ -173,11 +173,11 @@ func (h *EscritosHandler) UpdateEscrito(w http.ResponseWriter, r *http.Request)
 	if req.PublishStatus != nil {
 		ps := *req.PublishStatus
 		if ps != "draft" && ps != "reviewing" && ps != "published" {
-			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid publish_status"})
+			writeError(w, r, http.StatusBadRequest, "invalid publish_status")
 			return
 		}
 		if err := h.Escritos.UpdatePublishStatus(r.Context(), id, ps); err != nil {
-			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not update status"})
+			writeError(w, r, http.StatusInternalServerError, "could not update status")
 			return
 		}
 	}
@@ -207,7 +207,7 @@ func (h *EscritosHandler) UpdateEscrito(w http.ResponseWriter, r *http.Request)
 
 	if req.Title != nil || req.MetaDesc != nil || req.Content != nil || req.Keywords != nil || req.Hashtags != nil {
 		if err := h.Escritos.UpdateEdited(r.Context(), id, title, metaDesc, content, keywords, hashtags); err != nil {
-			writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not update escrito"})
+			writeError(w, r, http.StatusInternalServerError, "could not update escrito")
 			return
 		}
 	}
@@ -215,7 +215,7 @@ func (h *EscritosHandler) UpdateEscrito(w http.ResponseWriter, r *http.Request)
 	// Re-fetch updated
 	updated, err := h.Escritos.GetByID(r.Context(), id)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not re-fetch escrito"})
+		writeError(w, r, http.StatusInternalServerError, "could not re-fetch escrito")
 		return
 	}
 
@@ -226,19 +226,19 @@ func (h *EscritosHandler) UpdateEscrito(w http.ResponseWriter, r *http.Request)
 func (h *EscritosHandler) RegenerateEscrito(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid escrito id"})
+		writeError(w, r, http.StatusBadRequest, "invalid escrito id")
 		return
 	}
 
 	escrito, err := h.Escritos.GetByID(r.Context(), id)
 	if err != nil || escrito.UserID != user.ID {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "escrito not found"})
+		writeError(w, r, http.StatusNotFound, "escrito not found")
 		return
 	}
 
@@ -246,7 +246,7 @@ func (h *EscritosHandler) RegenerateEscrito(w http.ResponseWriter, r *http.Reque
 	_ = h.Sources.DeleteByEscrito(r.Context(), id)
 
 	if err := h.Escritos.UpdateStatus(r.Context(), id, "queued", 0); err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not reset escrito"})
+		writeError(w, r, http.StatusInternalServerError, "could not reset escrito")
 		return
 	}
 
@@ -260,7 +260,7 @@ func (h *EscritosHandler) RegenerateEscrito(w http.ResponseWriter, r *http.Reque
 
 	writeJSON(w, http.StatusAccepted, map[string]string{
 		"status":  "regenerating",
-		"message": "Regenerando articulo desde cero.",
+		"message": localize(r, "Regenerating the article from scratch."),
 	})
 }
 
@@ -269,24 +269,24 @@ func (h *EscritosHandler) RegenerateEscrito(w http.ResponseWriter, r *http.Reque
 func (h *EscritosHandler) ImproveEscrito(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid escrito id"})
+		writeError(w, r, http.StatusBadRequest, "invalid escrito id")
 		return
 	}
 
 	escrito, err := h.Escritos.GetByID(r.Context(), id)
 	if err != nil || escrito.UserID != user.ID {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "escrito not found"})
+		writeError(w, r, http.StatusNotFound, "escrito not found")
 		return
 	}
 
 	if escrito.Content == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "no content to improve"})
+		writeError(w, r, http.StatusBadRequest, "no content to improve")
 		return
 	}
 
@@ -294,7 +294,7 @@ func (h *EscritosHandler) ImproveEscrito(w http.ResponseWriter, r *http.Request)
 		Instructions string `json:"instructions"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Instructions) == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "instructions required"})
+		writeError(w, r, http.StatusBadRequest, "instructions required")
 		return
 	}
 
@@ -335,7 +335,7 @@ func (h *EscritosHandler) ImproveEscrito(w http.ResponseWriter, r *http.Request)
 
 	writeJSON(w, http.StatusAccepted, map[string]string{
 		"status":  "improving",
-		"message": "Mejorando articulo con tus instrucciones.",
+		"message": localize(r, "Improving the article with your instructions."),
 	})
 }
 
@@ -343,19 +343,19 @@ func (h *EscritosHandler) ImproveEscrito(w http.ResponseWriter, r *http.Request)
 func (h *EscritosHandler) RecalcSEO(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid escrito id"})
+		writeError(w, r, http.StatusBadRequest, "invalid escrito id")
 		return
 	}
 
 	escrito, err := h.Escritos.GetByID(r.Context(), id)
 	if err != nil || escrito.UserID != user.ID {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "escrito not found"})
+		writeError(w, r, http.StatusNotFound, "escrito not found")
 		return
 	}
 
@@ -367,12 +367,12 @@ func (h *EscritosHandler) RecalcSEO(w http.ResponseWriter, r *http.Request) {
 	score := generator.ScoreArticle(escrito.Content, escrito.Title, escrito.MetaDescription, primaryKW)
 	scoreJSON, err := json.Marshal(score)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not calculate score"})
+		writeError(w, r, http.StatusInternalServerError, "could not calculate score")
 		return
 	}
 
 	if err := h.Escritos.UpdateSEOScore(r.Context(), id, scoreJSON); err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not save score"})
+		writeError(w, r, http.StatusInternalServerError, "could not save score")
 		return
 	}
 
@@ -383,24 +383,24 @@ func (h *EscritosHandler) RecalcSEO(w http.ResponseWriter, r *http.Request) {
 func (h *EscritosHandler) DeleteEscrito(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid escrito id"})
+		writeError(w, r, http.StatusBadRequest, "invalid escrito id")
 		return
 	}
 
 	escrito, err := h.Escritos.GetByID(r.Context(), id)
 	if err != nil || escrito.UserID != user.ID {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "escrito not found"})
+		writeError(w, r, http.StatusNotFound, "escrito not found")
 		return
 	}
 
 	if err := h.Escritos.Delete(r.Context(), id); err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not delete escrito"})
+		writeError(w, r, http.StatusInternalServerError, "could not delete escrito")
 		return
 	}
 
@@ -411,19 +411,19 @@ func (h *EscritosHandler) DeleteEscrito(w http.ResponseWriter, r *http.Request)
 func (h *EscritosHandler) ExportEscrito(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid escrito id"})
+		writeError(w, r, http.StatusBadRequest, "invalid escrito id")
 		return
 	}
 
 	escrito, err := h.Escritos.GetByID(r.Context(), id)
 	if err != nil || escrito.UserID != user.ID {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "escrito not found"})
+		writeError(w, r, http.StatusNotFound, "escrito not found")
 		return
 	}
 
@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"math"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/scraper"
+	"github.com/Saul-Punybz/folio/internal/storage"
+)
+
+// reextractChangedBelow is the similarity under which a fresh extraction is
+// reported as changed from the stored one.
+const reextractChangedBelow = 0.9
+
+// evidenceContentTypes is the Content-Type each evidence artifact is served
+// with.
+var evidenceContentTypes = map[string]string{
+	storage.ArtifactRaw:        "text/html; charset=utf-8",
+	storage.ArtifactExtracted:  "application/json; charset=utf-8",
+	storage.ArtifactScreenshot: "image/png",
+}
+
+// GetEvidence handles GET /api/items/{id}/evidence?artifact=raw.
+// Streams one stored evidence artifact: raw (the captured HTML, the
+// default), extracted (the enrichment JSON) or screenshot (PNG). Raw HTML is
+// served sandboxed so the captured page's scripts never run on our origin.
+func (h *ItemsHandler) GetEvidence(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
+		return
+	}
+	artifact := r.URL.Query().Get("artifact")
+	if artifact == "" {
+		artifact = storage.ArtifactRaw
+	}
+	contentType, ok := evidenceContentTypes[artifact]
+	if !ok {
+		writeError(w, r, http.StatusBadRequest, "artifact must be raw, extracted or screenshot")
+		return
+	}
+
+	ctx := r.Context()
+	article, err := h.Articles.GetByID(ctx, id)
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, "article not found")
+		return
+	}
+	if h.Storage == nil || !h.Storage.Configured() {
+		writeError(w, r, http.StatusServiceUnavailable, "evidence storage not configured")
+		return
+	}
+
+	body, err := h.Storage.OpenArtifact(ctx, id, article.EvidencePolicy, artifact)
+	if errors.Is(err, storage.ErrNoEvidence) {
+		writeError(w, r, http.StatusNotFound, "no %s evidence for this article", artifact)
+		return
+	}
+	if err != nil {
+		slog.Error("evidence: open", "id", id, "artifact", artifact, "err", err)
+		writeError(w, r, http.StatusBadGateway, "evidence store unavailable")
+		return
+	}
+	defer body.Close()
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	if artifact == storage.ArtifactRaw {
+		w.Header().Set("Content-Security-Policy", "sandbox; default-src 'none'; img-src * data:; style-src * 'unsafe-inline'; font-src * data:")
+	}
+	if _, err := io.Copy(w, body); err != nil {
+		slog.Warn("evidence: stream", "id", id, "artifact", artifact, "err", err)
+	}
+}
+
+// GetEvidenceMeta handles GET /api/items/{id}/evidence/meta.
+// Returns the capture metadata: capture time, retention policy and the
+// SHA-256 of each artifact.
+func (h *ItemsHandler) GetEvidenceMeta(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
+		return
+	}
+
+	ctx := r.Context()
+	article, err := h.Articles.GetByID(ctx, id)
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, "article not found")
+		return
+	}
+	if h.Storage == nil || !h.Storage.Configured() {
+		writeError(w, r, http.StatusServiceUnavailable, "evidence storage not configured")
+		return
+	}
+
+	meta, err := h.Storage.GetCaptureMeta(ctx, id, article.EvidencePolicy)
+	if err != nil {
+		slog.Error("evidence meta", "id", id, "err", err)
+		writeError(w, r, http.StatusBadGateway, "evidence store unavailable")
+		return
+	}
+	if meta == nil {
+		writeError(w, r, http.StatusNotFound, "no evidence captured for this article")
+		return
+	}
+	writeJSON(w, http.StatusOK, meta)
+}
+
+// GetExtractedEvidence handles GET /api/items/{id}/evidence/extracted.
+// Returns the extracted.json stored at capture next to a fresh extraction of
+// the preserved raw.html with today's rules (scraper.ReextractText), and the
+// similarity of the two texts, so an extraction change that would alter how
+// preserved evidence reads is caught.
+func (h *ItemsHandler) GetExtractedEvidence(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
+		return
+	}
+
+	ctx := r.Context()
+	article, err := h.Articles.GetByID(ctx, id)
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, "article not found")
+		return
+	}
+	if h.Storage == nil || !h.Storage.Configured() {
+		writeError(w, r, http.StatusServiceUnavailable, "evidence storage not configured")
+		return
+	}
+
+	ev, err := h.Storage.GetEvidenceForPolicy(ctx, id, article.EvidencePolicy)
+	if errors.Is(err, storage.ErrNoEvidence) {
+		writeError(w, r, http.StatusNotFound, "no evidence captured for this article")
+		return
+	}
+	if err != nil {
+		slog.Error("evidence extracted: fetch", "id", id, "err", err)
+		writeError(w, r, http.StatusBadGateway, "evidence store unavailable")
+		return
+	}
+
+	// extracted.json is the enrichment document; older captures stored the
+	// text alone.
+	var stored any = string(ev.Extracted)
+	storedText := string(ev.Extracted)
+	var doc struct {
+		Text string `json:"text"`
+	}
+	if json.Valid(ev.Extracted) {
+		stored = json.RawMessage(ev.Extracted)
+		if err := json.Unmarshal(ev.Extracted, &doc); err == nil {
+			storedText = doc.Text
+		}
+	}
+
+	freshText := scraper.ReextractText(string(ev.RawHTML))
+	similarity := scraper.TextSimilarity(storedText, freshText)
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"article_id": id,
+		"meta":       ev.Meta,
+		"stored":     stored,
+		"fresh": map[string]any{
+			"text":   freshText,
+			"length": len(freshText),
+		},
+		"stored_length": len(storedText),
+		"similarity":    math.Round(similarity*1000) / 1000,
+		"changed":       similarity < reextractChangedBelow,
+	})
+}
@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"archive/zip"
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -22,17 +27,40 @@ type ExportHandler struct {
 }
 
 // ExportArticle handles GET /api/items/{id}/export.
-// Returns a ZIP file containing the article data, notes, and evidence.
+// Returns a ZIP file containing the article data, notes, and evidence, or with
+// ?format=pdf a printable PDF report of the same.
 func (h *ExportHandler) ExportArticle(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid article id"})
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
 		return
 	}
 
-	article, err := h.Articles.GetByID(r.Context(), id)
-	if err != nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "article not found"})
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "zip" && format != "pdf" {
+		writeError(w, r, http.StatusBadRequest, "format must be zip or pdf")
+		return
+	}
+
+	item := h.fetchExportItem(r.Context(), id.String())
+	if item.Article == nil {
+		writeError(w, r, http.StatusNotFound, "article not found")
+		return
+	}
+
+	if format == "pdf" {
+		// Render fully before writing headers so a failure can still be
+		// reported as JSON.
+		var buf bytes.Buffer
+		if err := writeArticlePDF(&buf, item); err != nil {
+			slog.Error("export article pdf", "id", id, "err", err)
+			writeError(w, r, http.StatusInternalServerError, "failed to render pdf")
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="folio-report-%s.pdf"`, id))
+		w.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+		w.Write(buf.Bytes())
 		return
 	}
 
@@ -42,67 +70,224 @@ func (h *ExportHandler) ExportArticle(w http.ResponseWriter, r *http.Request) {
 	zw := zip.NewWriter(w)
 	defer zw.Close()
 
-	if err := h.writeArticleToZip(zw, "", article, r); err != nil {
+	if err := writeArticleToZip(zw, "", item); err != nil {
 		slog.Error("export article", "id", id, "err", err)
 		return
 	}
 }
 
+const (
+	// exportWorkers bounds how many articles a bulk export fetches (notes and
+	// evidence) ahead of the zip writer.
+	exportWorkers = 6
+
+	// exportArticleTimeout bounds the fetch of a single article's data.
+	exportArticleTimeout = 30 * time.Second
+)
+
 type bulkExportRequest struct {
 	IDs []string `json:"ids"`
 }
 
+// exportItem is one article's fetched data, ready to be written to the zip.
+type exportItem struct {
+	ID       string
+	Article  *models.Article
+	Notes    []models.Note
+	Evidence *storage.Evidence
+	Entry    exportManifestEntry
+}
+
+// exportManifestEntry records what happened to one requested article.
+type exportManifestEntry struct {
+	ID         string `json:"id"`
+	Status     string `json:"status"`   // ok, skipped
+	Evidence   string `json:"evidence"` // included, none, unconfigured, timeout, error
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// exportManifest is written as the last zip entry of a bulk export, once
+// every article has been processed.
+type exportManifest struct {
+	Requested   int                   `json:"requested"`
+	Exported    int                   `json:"exported"`
+	Skipped     int                   `json:"skipped"`
+	NoEvidence  int                   `json:"no_evidence"`
+	TimedOut    int                   `json:"timed_out"`
+	Articles    []exportManifestEntry `json:"articles"`
+	GeneratedAt time.Time             `json:"generated_at"`
+}
+
 // ExportBulk handles POST /api/export.
 // Body: { "ids": ["uuid1", "uuid2", ...] }
-// Returns a ZIP with folders for each article.
+// Returns a ZIP with folders for each article plus a trailing manifest.json
+// recording, per article, whether it was exported and whether its evidence
+// was included, missing, or timed out. Articles are fetched by a bounded
+// worker pool and written to the zip in request order as they become ready.
 func (h *ExportHandler) ExportBulk(w http.ResponseWriter, r *http.Request) {
 	var req bulkExportRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if len(req.IDs) == 0 {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "at least one id is required"})
+		writeError(w, r, http.StatusBadRequest, "at least one id is required")
 		return
 	}
 
 	if len(req.IDs) > 100 {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "maximum 100 articles per export"})
+		writeError(w, r, http.StatusBadRequest, "maximum 100 articles per export")
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/zip")
 	w.Header().Set("Content-Disposition", `attachment; filename="folio-bulk-export.zip"`)
 
+	ctx := r.Context()
 	zw := zip.NewWriter(w)
 	defer zw.Close()
 
-	for _, idStr := range req.IDs {
-		id, err := uuid.Parse(idStr)
-		if err != nil {
-			slog.Warn("export bulk: invalid id", "id", idStr)
-			continue
+	// Each article gets a one-slot channel; the writer drains them in order.
+	// A worker slot is taken before a fetch starts and released once the
+	// writer has consumed its result, so at most exportWorkers articles are
+	// held in memory at a time.
+	slots := make([]chan exportItem, len(req.IDs))
+	for i := range slots {
+		slots[i] = make(chan exportItem, 1)
+	}
+	sem := make(chan struct{}, exportWorkers)
+	go func() {
+		for i, idStr := range req.IDs {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			go func(i int, idStr string) {
+				slots[i] <- h.fetchExportItem(ctx, idStr)
+			}(i, idStr)
 		}
+	}()
 
-		article, err := h.Articles.GetByID(r.Context(), id)
-		if err != nil {
-			slog.Warn("export bulk: article not found", "id", id)
-			continue
+	manifest := exportManifest{Requested: len(req.IDs)}
+	for i := range req.IDs {
+		var item exportItem
+		select {
+		case item = <-slots[i]:
+		case <-ctx.Done():
+			slog.Warn("export bulk: client went away", "written", i, "requested", len(req.IDs))
+			return
 		}
+		<-sem
 
-		prefix := fmt.Sprintf("%s/", id)
-		if err := h.writeArticleToZip(zw, prefix, article, r); err != nil {
-			slog.Error("export bulk: write article", "id", id, "err", err)
-			continue
+		if item.Article != nil {
+			if err := writeArticleToZip(zw, item.ID+"/", item); err != nil {
+				slog.Error("export bulk: write article", "id", item.ID, "err", err)
+				item.Entry.Status = "skipped"
+				item.Entry.Error = err.Error()
+			}
+		}
+
+		if item.Entry.Status == "skipped" {
+			manifest.Skipped++
+		} else {
+			manifest.Exported++
 		}
+		switch item.Entry.Evidence {
+		case "none":
+			manifest.NoEvidence++
+		case "timeout":
+			manifest.TimedOut++
+		}
+		manifest.Articles = append(manifest.Articles, item.Entry)
+	}
+
+	manifest.GeneratedAt = time.Now().UTC()
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		slog.Error("export bulk: marshal manifest", "err", err)
+		return
+	}
+	mw, err := zw.Create("manifest.json")
+	if err != nil {
+		slog.Error("export bulk: create manifest", "err", err)
+		return
 	}
+	mw.Write(manifestJSON)
+
+	slog.Info("export bulk: done",
+		"requested", manifest.Requested,
+		"exported", manifest.Exported,
+		"skipped", manifest.Skipped,
+		"evidence_timeouts", manifest.TimedOut,
+	)
+}
+
+// fetchExportItem loads an article, its notes, and its evidence, bounded by
+// exportArticleTimeout. Failures are recorded in the item's manifest entry
+// rather than returned.
+func (h *ExportHandler) fetchExportItem(ctx context.Context, idStr string) (item exportItem) {
+	start := time.Now()
+	item = exportItem{ID: idStr, Entry: exportManifestEntry{ID: idStr, Status: "ok", Evidence: "unconfigured"}}
+	defer func() { item.Entry.DurationMS = time.Since(start).Milliseconds() }()
+
+	ctx, cancel := context.WithTimeout(ctx, exportArticleTimeout)
+	defer cancel()
+
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		item.Entry.Status = "skipped"
+		item.Entry.Evidence = "none"
+		item.Entry.Error = "invalid id"
+		return item
+	}
+
+	article, err := h.Articles.GetByID(ctx, id)
+	if err != nil {
+		item.Entry.Status = "skipped"
+		item.Entry.Evidence = "none"
+		item.Entry.Error = "article not found"
+		return item
+	}
+	item.Article = article
+
+	notes, err := h.Notes.ListByArticle(ctx, id)
+	if err != nil {
+		slog.Warn("export: notes fetch failed", "article_id", id, "err", err)
+	} else {
+		if notes == nil {
+			notes = []models.Note{}
+		}
+		item.Notes = notes
+	}
+
+	if h.Storage != nil && h.Storage.Configured() {
+		evidence, err := h.Storage.GetEvidenceForPolicy(ctx, id, article.EvidencePolicy)
+		switch {
+		case err == nil:
+			item.Evidence = evidence
+			item.Entry.Evidence = "included"
+		case errors.Is(err, storage.ErrNoEvidence):
+			item.Entry.Evidence = "none"
+		case errors.Is(ctx.Err(), context.DeadlineExceeded):
+			item.Entry.Evidence = "timeout"
+			item.Entry.Error = "evidence fetch timed out"
+		default:
+			slog.Warn("export: evidence fetch failed", "article_id", id, "err", err)
+			item.Entry.Evidence = "error"
+			item.Entry.Error = "evidence fetch failed"
+		}
+	}
+
+	return item
 }
 
 // writeArticleToZip writes a single article's data into the zip writer.
-func (h *ExportHandler) writeArticleToZip(zw *zip.Writer, prefix string, article *models.Article, r *http.Request) error {
+func writeArticleToZip(zw *zip.Writer, prefix string, item exportItem) error {
 	// Article metadata.
-	articleJSON, err := json.MarshalIndent(article, "", "  ")
+	articleJSON, err := json.MarshalIndent(item.Article, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal article: %w", err)
 	}
@@ -116,14 +301,8 @@ func (h *ExportHandler) writeArticleToZip(zw *zip.Writer, prefix string, article
 	}
 
 	// Notes.
-	notes, err := h.Notes.ListByArticle(r.Context(), article.ID)
-	if err != nil {
-		slog.Warn("export: notes fetch failed", "article_id", article.ID, "err", err)
-	} else {
-		if notes == nil {
-			notes = []models.Note{}
-		}
-		notesJSON, err := json.MarshalIndent(notes, "", "  ")
+	if item.Notes != nil {
+		notesJSON, err := json.MarshalIndent(item.Notes, "", "  ")
 		if err != nil {
 			return fmt.Errorf("marshal notes: %w", err)
 		}
@@ -136,26 +315,28 @@ func (h *ExportHandler) writeArticleToZip(zw *zip.Writer, prefix string, article
 		}
 	}
 
-	// Evidence from S3 (if configured and available).
-	if h.Storage != nil && h.Storage.Configured() {
-		evidence, err := h.Storage.GetEvidence(r.Context(), article.ID)
-		if err == nil {
-			// Raw HTML.
-			if len(evidence.RawHTML) > 0 {
-				rw, err := zw.Create(prefix + "evidence/raw.html")
-				if err == nil {
-					rw.Write(evidence.RawHTML)
-				}
+	// Evidence from S3 (if it was fetched).
+	if evidence := item.Evidence; evidence != nil {
+		// Raw HTML.
+		if len(evidence.RawHTML) > 0 {
+			rw, err := zw.Create(prefix + "evidence/raw.html")
+			if err == nil {
+				rw.Write(evidence.RawHTML)
 			}
-			// Extracted text.
-			if len(evidence.Extracted) > 0 {
-				ew, err := zw.Create(prefix + "evidence/extracted.json")
-				if err == nil {
-					ew.Write(evidence.Extracted)
-				}
+		}
+		// Extracted text.
+		if len(evidence.Extracted) > 0 {
+			ew, err := zw.Create(prefix + "evidence/extracted.json")
+			if err == nil {
+				ew.Write(evidence.Extracted)
+			}
+		}
+		// Screenshot.
+		if len(evidence.Screenshot) > 0 {
+			sw, err := zw.Create(prefix + "evidence/screenshot.png")
+			if err == nil {
+				sw.Write(evidence.Screenshot)
 			}
-		} else {
-			slog.Debug("export: no evidence available", "article_id", article.ID)
 		}
 	}
 
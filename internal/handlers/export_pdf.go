@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+)
+
+const (
+	pdfMargin     = 18.0 // mm
+	pdfLineHeight = 5.0
+	pdfLabelWidth = 42.0
+)
+
+// writeArticlePDF renders an exported article as a printable A4 report:
+// metadata, summary, full text, notes, and evidence hashes. The core PDF
+// fonts only cover Windows-1252, so characters outside it (emoji, CJK) are
+// dropped; Spanish accents and punctuation render correctly.
+func writeArticlePDF(w io.Writer, item exportItem) error {
+	a := item.Article
+	generated := time.Now().UTC()
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetAutoPageBreak(true, pdfMargin)
+	pdf.SetTitle(a.Title, true)
+	pdf.SetCreator("Folio", true)
+	pdf.AliasNbPages("")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, 4, tr(fmt.Sprintf("Folio · artículo %s · generado %s", a.ID, generated.Format("2006-01-02 15:04 UTC"))), "", 0, "L", false, 0, "")
+		pdf.SetX(pdfMargin)
+		pdf.CellFormat(0, 4, fmt.Sprintf("%d/{nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+	pdf.AddPage()
+
+	// Title block.
+	pdf.SetFont("Helvetica", "B", 9)
+	pdf.SetTextColor(120, 120, 120)
+	pdf.CellFormat(0, pdfLineHeight, tr("INFORME DE ARTÍCULO"), "", 1, "L", false, 0, "")
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.MultiCell(0, 7, tr(a.Title), "", "L", false)
+	pdf.Ln(3)
+
+	// Metadata.
+	meta := [][2]string{
+		{"Fuente", a.Source},
+		{"URL", a.URL},
+	}
+	if a.CanonicalURL != "" && a.CanonicalURL != a.URL {
+		meta = append(meta, [2]string{"URL canónica", a.CanonicalURL})
+	}
+	if a.PublishedAt != nil {
+		meta = append(meta, [2]string{"Publicado", a.PublishedAt.UTC().Format("2006-01-02 15:04 UTC")})
+	}
+	meta = append(meta,
+		[2]string{"Guardado", a.CreatedAt.UTC().Format("2006-01-02 15:04 UTC")},
+		[2]string{"Región", a.Region},
+		[2]string{"Estado", a.Status},
+	)
+	if len(a.Tags) > 0 {
+		meta = append(meta, [2]string{"Etiquetas", strings.Join(a.Tags, ", ")})
+	}
+	pdfKeyValues(pdf, tr, meta)
+
+	if a.Summary != "" {
+		pdfSection(pdf, tr, "Resumen")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.MultiCell(0, pdfLineHeight, tr(a.Summary), "", "L", false)
+	}
+
+	if a.CleanText != "" {
+		pdfSection(pdf, tr, "Texto completo")
+		pdf.SetFont("Times", "", 10.5)
+		for _, para := range strings.Split(a.CleanText, "\n") {
+			para = strings.TrimSpace(para)
+			if para == "" {
+				continue
+			}
+			pdf.MultiCell(0, pdfLineHeight, tr(para), "", "J", false)
+			pdf.Ln(1.5)
+		}
+	}
+
+	if len(item.Notes) > 0 {
+		pdfSection(pdf, tr, "Notas")
+		for _, n := range item.Notes {
+			pdf.SetFont("Helvetica", "B", 8)
+			pdf.SetTextColor(90, 90, 90)
+			pdf.CellFormat(0, pdfLineHeight, n.CreatedAt.UTC().Format("2006-01-02 15:04 UTC"), "", 1, "L", false, 0, "")
+			pdf.SetFont("Helvetica", "", 10)
+			pdf.SetTextColor(0, 0, 0)
+			pdf.MultiCell(0, pdfLineHeight, tr(n.Content), "", "L", false)
+			pdf.Ln(2)
+		}
+	}
+
+	pdfSection(pdf, tr, "Evidencia")
+	policy := a.EvidencePolicy
+	if policy == "" {
+		policy = "—"
+	}
+	evidence := [][2]string{{"Política", policy}}
+	if a.EvidenceExpiresAt != nil && a.EvidencePolicy != "keep" {
+		evidence = append(evidence, [2]string{"Expira", a.EvidenceExpiresAt.UTC().Format("2006-01-02")})
+	}
+	if ev := item.Evidence; ev != nil {
+		rawHash, extractHash := sha256Hex(ev.RawHTML), sha256Hex(ev.Extracted)
+		if ev.Meta != nil {
+			evidence = append(evidence, [2]string{"Capturado", ev.Meta.CapturedAt.UTC().Format("2006-01-02 15:04 UTC")})
+			rawHash, extractHash = ev.Meta.RawHash, ev.Meta.ExtractHash
+		}
+		evidence = append(evidence,
+			[2]string{"SHA-256 HTML", rawHash},
+			[2]string{"SHA-256 texto", extractHash},
+		)
+		if ev.Meta != nil && ev.Meta.ScreenshotHash != "" {
+			evidence = append(evidence, [2]string{"SHA-256 captura", ev.Meta.ScreenshotHash})
+		}
+	} else {
+		evidence = append(evidence, [2]string{"Estado", evidenceStatusLabel(item.Entry.Evidence)})
+	}
+	pdfKeyValues(pdf, tr, evidence)
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("render pdf: %w", err)
+	}
+	return nil
+}
+
+// pdfSection starts a titled section with a rule underneath.
+func pdfSection(pdf *fpdf.Fpdf, tr func(string) string, title string) {
+	pdf.Ln(4)
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.CellFormat(0, 7, tr(title), "", 1, "L", false, 0, "")
+	x, y := pdf.GetXY()
+	pageW, _ := pdf.GetPageSize()
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.Line(x, y, pageW-pdfMargin, y)
+	pdf.Ln(2)
+}
+
+// pdfKeyValues renders label/value rows; long values wrap under their column.
+func pdfKeyValues(pdf *fpdf.Fpdf, tr func(string) string, rows [][2]string) {
+	for _, row := range rows {
+		if row[1] == "" {
+			continue
+		}
+		pdf.SetFont("Helvetica", "B", 9)
+		pdf.SetTextColor(90, 90, 90)
+		pdf.CellFormat(pdfLabelWidth, pdfLineHeight, tr(row[0]), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.MultiCell(0, pdfLineHeight, tr(row[1]), "", "L", false)
+	}
+}
+
+func evidenceStatusLabel(status string) string {
+	switch status {
+	case "none":
+		return "sin evidencia almacenada"
+	case "unconfigured":
+		return "almacenamiento no configurado"
+	case "timeout":
+		return "no disponible (tiempo agotado)"
+	case "error":
+		return "no disponible (error)"
+	default:
+		return status
+	}
+}
+
+func sha256Hex(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -4,74 +4,182 @@ import (
 	"encoding/xml"
 	"fmt"
 	"html"
+	"log/slog"
+	"net"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 
+	"github.com/Saul-Punybz/folio/internal/coverage"
 	"github.com/Saul-Punybz/folio/internal/middleware"
 	"github.com/Saul-Punybz/folio/internal/models"
 )
 
-// FeedHandler serves public RSS feeds authenticated by feed token.
+// FeedHandler serves public RSS, Atom and JSON feeds authenticated by feed
+// token.
 type FeedHandler struct {
-	Users *models.UserStore
-	Hits  *models.WatchlistHitStore
+	Users    *models.UserStore
+	Hits     *models.WatchlistHitStore
+	Articles *models.ArticleStore
+	Access   *models.FeedAccessStore // optional; logs every feed fetch
 }
 
+// feedUser resolves the feed token in the URL, responding 404 (and marking
+// the access) when it is unknown.
+func (h *FeedHandler) feedUser(w http.ResponseWriter, r *http.Request, access *models.FeedAccess) (*models.User, bool) {
+	token := chi.URLParam(r, "token")
+	user, err := h.Users.GetByFeedToken(r.Context(), token)
+	if err != nil {
+		access.Status = http.StatusNotFound
+		http.NotFound(w, r)
+		return nil, false
+	}
+	access.UserID = &user.ID
+	return user, true
+}
+
+// feedNotModified sets the caching headers of a feed whose newest entry is
+// lastMod and answers a matching conditional GET with 304. variant keeps the
+// ETags of other formats of the same entries apart ("" for RSS).
+func feedNotModified(w http.ResponseWriter, r *http.Request, access *models.FeedAccess, lastMod time.Time, count int, variant string) bool {
+	lastMod = lastMod.UTC()
+	w.Header().Set("Last-Modified", lastMod.Format(http.TimeFormat))
+	etag := fmt.Sprintf(`"%x-%d%s"`, lastMod.Unix(), count, variant)
+	w.Header().Set("ETag", etag)
+
+	// Handle conditional GET (If-Modified-Since).
+	if ifMod := r.Header.Get("If-Modified-Since"); ifMod != "" {
+		if t, err := http.ParseTime(ifMod); err == nil && !lastMod.After(t) {
+			access.Status = http.StatusNotModified
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	// Handle conditional GET (If-None-Match).
+	if ifNone := r.Header.Get("If-None-Match"); ifNone != "" {
+		if strings.Contains(ifNone, etag) {
+			access.Status = http.StatusNotModified
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
+
+// feedBaseURL returns the scheme and host the feed was requested on.
+func feedBaseURL(r *http.Request) string {
+	scheme := "https"
+	if r.TLS == nil {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s", scheme, r.Host)
+}
+
+// writeRSS writes an RSS 2.0 document for the channel.
+func writeRSS(w http.ResponseWriter, channel rssChannel) {
+	rss := rssFeed{
+		Version:   "2.0",
+		NSContent: "http://purl.org/rss/1.0/modules/content/",
+		NSAtom:    "http://www.w3.org/2005/Atom",
+		Channel:   channel,
+	}
+
+	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	enc.Encode(rss)
+}
+
+// Formats the hit feed is served in, by URL suffix.
+const (
+	feedFormatRSS  = "xml"
+	feedFormatAtom = "atom"
+	feedFormatJSON = "json"
+)
+
 // ServeFeed serves an RSS 2.0 XML feed of watchlist hits for the user
 // identified by the feed token in the URL. No session auth required.
+// Every fetch, including unknown tokens, is logged to the feed access log.
 func (h *FeedHandler) ServeFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveHitFeed(w, r, feedFormatRSS)
+}
+
+// ServeAtomFeed serves the hit feed of ServeFeed as Atom 1.0
+// (/feed/{token}.atom).
+func (h *FeedHandler) ServeAtomFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveHitFeed(w, r, feedFormatAtom)
+}
+
+// ServeJSONFeed serves the hit feed of ServeFeed as JSON Feed 1.1
+// (/feed/{token}.json). Each item carries the org, sentiment and source type
+// in a "_folio" extension object for automation.
+func (h *FeedHandler) ServeJSONFeed(w http.ResponseWriter, r *http.Request) {
+	h.serveHitFeed(w, r, feedFormatJSON)
+}
+
+// serveHitFeed serves the user's 100 newest hits in the given format. The
+// hits can be narrowed with the query parameters of GET /api/watchlist/hits
+// (org_id, sentiment, source_type, ...), so one feed URL can follow, say,
+// negative press about a single org.
+func (h *FeedHandler) serveHitFeed(w http.ResponseWriter, r *http.Request, format string) {
 	token := chi.URLParam(r, "token")
 	if token == "" {
 		http.NotFound(w, r)
 		return
 	}
 
-	user, err := h.Users.GetByFeedToken(r.Context(), token)
-	if err != nil {
-		http.NotFound(w, r)
+	access := models.FeedAccess{Kind: models.FeedAccessFeed, TokenPrefix: token, Status: http.StatusOK}
+	defer func() { recordFeedAccess(h.Access, r, access) }()
+
+	user, ok := h.feedUser(w, r, &access)
+	if !ok {
+		return
+	}
+
+	filter, msg := parseHitFilter(r)
+	if msg != "" {
+		access.Status = http.StatusBadRequest
+		http.Error(w, msg, http.StatusBadRequest)
 		return
 	}
 
-	hits, err := h.Hits.ListRecentByUser(r.Context(), user.ID, 100)
+	hits, err := h.Hits.ListRecentByUser(r.Context(), user.ID, filter, 100)
 	if err != nil {
+		access.Status = http.StatusInternalServerError
 		http.Error(w, "internal error", http.StatusInternalServerError)
 		return
 	}
 
 	// HTTP caching: use most recent hit's CreatedAt as Last-Modified.
-	if len(hits) > 0 {
-		lastMod := hits[0].CreatedAt.UTC()
-		w.Header().Set("Last-Modified", lastMod.Format(http.TimeFormat))
-		etag := fmt.Sprintf(`"%x-%d"`, lastMod.Unix(), len(hits))
-		w.Header().Set("ETag", etag)
-
-		// Handle conditional GET (If-Modified-Since).
-		if ifMod := r.Header.Get("If-Modified-Since"); ifMod != "" {
-			if t, err := http.ParseTime(ifMod); err == nil && !lastMod.After(t) {
-				w.WriteHeader(http.StatusNotModified)
-				return
-			}
-		}
-		// Handle conditional GET (If-None-Match).
-		if ifNone := r.Header.Get("If-None-Match"); ifNone != "" {
-			if strings.Contains(ifNone, etag) {
-				w.WriteHeader(http.StatusNotModified)
-				return
-			}
-		}
+	variant := ""
+	if format != feedFormatRSS {
+		variant = "-" + format
+	}
+	if len(hits) > 0 && feedNotModified(w, r, &access, hits[0].CreatedAt, len(hits), variant) {
+		return
 	}
 	w.Header().Set("Cache-Control", "public, max-age=1800")
 
-	scheme := "https"
-	if r.TLS == nil {
-		scheme = "http"
+	baseURL := feedBaseURL(r)
+	selfURL := fmt.Sprintf("%s/feed/%s.%s", baseURL, token, format)
+	if r.URL.RawQuery != "" {
+		selfURL += "?" + r.URL.RawQuery
+	}
+	title := fmt.Sprintf("Folio Vigilancia — %s", user.Email)
+
+	switch format {
+	case feedFormatAtom:
+		writeAtom(w, hitAtomFeed(title, baseURL, selfURL, hits))
+		return
+	case feedFormatJSON:
+		writeJSONFeed(w, hitJSONFeed(title, baseURL, selfURL, hits))
+		return
 	}
-	host := r.Host
-	baseURL := fmt.Sprintf("%s://%s", scheme, host)
-	selfURL := fmt.Sprintf("%s/feed/%s.xml", baseURL, token)
 
 	lastBuild := time.Now().UTC().Format(time.RFC1123Z)
 	if len(hits) > 0 {
@@ -79,7 +187,7 @@ func (h *FeedHandler) ServeFeed(w http.ResponseWriter, r *http.Request) {
 	}
 
 	feed := rssChannel{
-		Title:       fmt.Sprintf("Folio Vigilancia — %s", user.Email),
+		Title:       title,
 		Link:        baseURL,
 		Description: "Menciones de organizaciones monitoreadas",
 		Language:    "es",
@@ -93,51 +201,119 @@ func (h *FeedHandler) ServeFeed(w http.ResponseWriter, r *http.Request) {
 	}
 
 	for _, hit := range hits {
-		// Plain text description (for readers that only show description).
-		desc := hit.Snippet
-		if hit.Sentiment != "" {
-			desc += fmt.Sprintf(" [%s]", hit.Sentiment)
-		}
-		if hit.AIDraft != nil && *hit.AIDraft != "" {
-			preview := *hit.AIDraft
-			if len(preview) > 200 {
-				preview = preview[:200] + "..."
-			}
-			desc += "\n\nBorrador PR: " + preview
-		}
-
-		// Rich HTML for content:encoded.
-		contentHTML := buildContentHTML(hit)
-
 		item := rssItem{
-			Title:          fmt.Sprintf("[%s] %s", hit.OrgName, hit.Title),
+			Title:          hitFeedTitle(hit),
 			Link:           hit.URL,
-			Desc:           desc,
-			ContentEncoded: cdataStr{Value: contentHTML},
+			Desc:           hitFeedText(hit),
+			ContentEncoded: cdataStr{Value: buildContentHTML(hit)},
 			Author:         hit.OrgName,
 			PubDate:        hit.CreatedAt.UTC().Format(time.RFC1123Z),
 			GUID: rssGUID{
 				IsPermaLink: "false",
 				Value:       hit.ID.String(),
 			},
-			Category: hit.SourceType,
+			Categories: []string{hit.SourceType},
 		}
 		feed.Items = append(feed.Items, item)
 	}
 
-	rss := rssFeed{
-		Version:   "2.0",
-		NSContent: "http://purl.org/rss/1.0/modules/content/",
-		NSAtom:    "http://www.w3.org/2005/Atom",
-		Channel:   feed,
+	writeRSS(w, feed)
+}
+
+// ServeSavedFeed serves an RSS 2.0 feed of the newest saved and pinned
+// articles, with their summaries and tags, for the user identified by the
+// feed token. Items are ordered and dated by when they were saved or pinned,
+// so an old article saved today is new to the reader. Like ServeFeed it needs
+// no session and every fetch is logged.
+func (h *FeedHandler) ServeSavedFeed(w http.ResponseWriter, r *http.Request) {
+	token := chi.URLParam(r, "token")
+	if token == "" || h.Articles == nil {
+		http.NotFound(w, r)
+		return
 	}
 
-	w.Header().Set("Content-Type", "application/rss+xml; charset=utf-8")
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(xml.Header))
-	enc := xml.NewEncoder(w)
-	enc.Indent("", "  ")
-	enc.Encode(rss)
+	access := models.FeedAccess{Kind: models.FeedAccessFeed, TokenPrefix: token, Status: http.StatusOK}
+	defer func() { recordFeedAccess(h.Access, r, access) }()
+
+	user, ok := h.feedUser(w, r, &access)
+	if !ok {
+		return
+	}
+
+	articles, err := h.Articles.ListSavedForFeed(r.Context(), 100)
+	if err != nil {
+		slog.Error("saved feed: list articles", "err", err)
+		access.Status = http.StatusInternalServerError
+		http.Error(w, "internal error", http.StatusInternalServerError)
+		return
+	}
+
+	if len(articles) > 0 && feedNotModified(w, r, &access, articles[0].SavedAt, len(articles), "") {
+		return
+	}
+	w.Header().Set("Cache-Control", "public, max-age=1800")
+
+	baseURL := feedBaseURL(r)
+	lastBuild := time.Now().UTC().Format(time.RFC1123Z)
+	if len(articles) > 0 {
+		lastBuild = articles[0].SavedAt.UTC().Format(time.RFC1123Z)
+	}
+
+	feed := rssChannel{
+		Title:       fmt.Sprintf("Folio Guardados — %s", user.Email),
+		Link:        baseURL,
+		Description: "Artículos guardados y fijados",
+		Language:    coverage.Current().Language,
+		LastBuild:   lastBuild,
+		TTL:         360,
+		AtomLink: rssAtomLink{
+			Href: fmt.Sprintf("%s/feed/%s/saved.xml", baseURL, token),
+			Rel:  "self",
+			Type: "application/rss+xml",
+		},
+	}
+
+	for _, a := range articles {
+		feed.Items = append(feed.Items, rssItem{
+			Title:          a.Title,
+			Link:           a.URL,
+			Desc:           a.Summary,
+			ContentEncoded: cdataStr{Value: buildArticleContentHTML(a.Article)},
+			Author:         a.Source,
+			PubDate:        a.SavedAt.UTC().Format(time.RFC1123Z),
+			GUID: rssGUID{
+				IsPermaLink: "false",
+				Value:       a.ID.String(),
+			},
+			Categories: a.Tags,
+		})
+	}
+
+	writeRSS(w, feed)
+}
+
+// recordFeedAccess logs a fetch of a public feed or share page. Failures are
+// only logged; the fetch is served either way.
+func recordFeedAccess(store *models.FeedAccessStore, r *http.Request, a models.FeedAccess) {
+	if store == nil {
+		return
+	}
+	a.IP = r.RemoteAddr // chi's RealIP has applied X-Forwarded-For
+	if host, _, err := net.SplitHostPort(a.IP); err == nil {
+		a.IP = host
+	}
+	a.UserAgent = r.UserAgent()
+	if len(a.UserAgent) > 512 {
+		a.UserAgent = a.UserAgent[:512]
+	}
+	// A referrer on our own host is someone opening their link from Folio;
+	// only links followed from other sites are kept.
+	if ref, err := url.Parse(r.Referer()); err == nil && ref.Host != "" && ref.Host != r.Host {
+		a.Referer = ref.Scheme + "://" + ref.Host + ref.Path
+	}
+	if err := store.Record(r.Context(), &a); err != nil {
+		slog.Warn("feed access: record", "kind", a.Kind, "err", err)
+	}
 }
 
 // buildContentHTML creates rich HTML for the content:encoded field.
@@ -190,42 +366,156 @@ func buildContentHTML(hit models.WatchlistHit) string {
 	return b.String()
 }
 
-// GetFeedURL returns the RSS feed URL for the authenticated user.
+// hitFeedTitle is the title of a hit's feed entry.
+func hitFeedTitle(hit models.WatchlistHit) string {
+	return fmt.Sprintf("[%s] %s", hit.OrgName, hit.Title)
+}
+
+// hitFeedText is the plain-text description of a hit's feed entry, for
+// readers that don't show the HTML content.
+func hitFeedText(hit models.WatchlistHit) string {
+	desc := hit.Snippet
+	if hit.Sentiment != "" {
+		desc += fmt.Sprintf(" [%s]", hit.Sentiment)
+	}
+	if hit.AIDraft != nil && *hit.AIDraft != "" {
+		preview := *hit.AIDraft
+		if len(preview) > 200 {
+			preview = preview[:200] + "..."
+		}
+		desc += "\n\nBorrador PR: " + preview
+	}
+	return desc
+}
+
+// buildArticleContentHTML creates rich HTML for a saved article's
+// content:encoded field: image, summary, tags and source.
+func buildArticleContentHTML(a models.Article) string {
+	var b strings.Builder
+
+	if a.ImageURL != "" {
+		b.WriteString(`<p><img src="`)
+		b.WriteString(html.EscapeString(a.ImageURL))
+		b.WriteString(`" alt="" style="max-width:100%"/></p>`)
+	}
+	if a.Summary != "" {
+		b.WriteString("<p>")
+		b.WriteString(html.EscapeString(a.Summary))
+		b.WriteString("</p>")
+	}
+	if len(a.Tags) > 0 {
+		b.WriteString("<p>")
+		for i, tag := range a.Tags {
+			if i > 0 {
+				b.WriteString(" ")
+			}
+			b.WriteString(`<span style="display:inline-block;padding:2px 8px;border-radius:4px;font-size:12px;background:#e5e7eb;color:#374151">`)
+			b.WriteString(html.EscapeString(tag))
+			b.WriteString("</span>")
+		}
+		b.WriteString("</p>")
+	}
+
+	b.WriteString("<p style=\"font-size:11px;color:#9ca3af;\">")
+	b.WriteString(html.EscapeString(a.Source))
+	if a.Pinned {
+		b.WriteString(" &mdash; 📌")
+	}
+	b.WriteString("</p>")
+
+	return b.String()
+}
+
+// feedURLs returns the paths of the feeds published under token.
+func feedURLs(token string) map[string]string {
+	return map[string]string{
+		"url":       fmt.Sprintf("/feed/%s.xml", token),
+		"atom_url":  fmt.Sprintf("/feed/%s.atom", token),
+		"json_url":  fmt.Sprintf("/feed/%s.json", token),
+		"saved_url": fmt.Sprintf("/feed/%s/saved.xml", token),
+	}
+}
+
+// GetFeedURL returns the RSS feed URLs for the authenticated user.
 // Generates a feed token if the user doesn't have one yet.
 func (h *FeedHandler) GetFeedURL(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	token, err := h.Users.SetFeedToken(r.Context(), user.ID)
 	if err != nil {
-		http.Error(w, `{"error":"failed to generate feed token"}`, http.StatusInternalServerError)
+		writeError(w, r, http.StatusInternalServerError, "failed to generate feed token")
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{
-		"url": fmt.Sprintf("/feed/%s.xml", token),
-	})
+	writeJSON(w, http.StatusOK, feedURLs(token))
 }
 
 // RegenerateFeedURL generates a new feed token, invalidating the old one.
 func (h *FeedHandler) RegenerateFeedURL(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	token, err := h.Users.ResetFeedToken(r.Context(), user.ID)
 	if err != nil {
-		http.Error(w, `{"error":"failed to regenerate feed token"}`, http.StatusInternalServerError)
+		writeError(w, r, http.StatusInternalServerError, "failed to regenerate feed token")
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{
-		"url": fmt.Sprintf("/feed/%s.xml", token),
+	writeJSON(w, http.StatusOK, feedURLs(token))
+}
+
+// FeedAccessLog handles GET /api/watchlist/feed-url/access.
+// Returns the latest fetches of the user's feed (address, user agent,
+// outside referrer), distinct-address counts for the last day and week, and
+// any alerts raised because the token looked shared publicly.
+func (h *FeedHandler) FeedAccessLog(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	if h.Access == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "feed access log not configured")
+		return
+	}
+	ctx := r.Context()
+
+	accesses, err := h.Access.ListByUser(ctx, user.ID, 50)
+	if err != nil {
+		slog.Error("feed access log: list", "user_id", user.ID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	summary, err := h.Access.SummaryByUser(ctx, user.ID)
+	if err != nil {
+		slog.Error("feed access log: summary", "user_id", user.ID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	alerts, err := h.Access.ListAlerts(ctx, user.ID, 10)
+	if err != nil {
+		slog.Error("feed access log: alerts", "user_id", user.ID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if accesses == nil {
+		accesses = []models.FeedAccess{}
+	}
+	if alerts == nil {
+		alerts = []models.FeedLeak{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"accesses": accesses,
+		"summary":  summary,
+		"alerts":   alerts,
 	})
 }
 
@@ -264,7 +554,7 @@ type rssItem struct {
 	Author         string   `xml:"author"`
 	PubDate        string   `xml:"pubDate"`
 	GUID           rssGUID  `xml:"guid"`
-	Category       string   `xml:"category"`
+	Categories     []string `xml:"category"`
 }
 
 type rssGUID struct {
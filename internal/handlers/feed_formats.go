@@ -0,0 +1,189 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// hitAtomFeed builds the Atom 1.0 version of the hit feed.
+func hitAtomFeed(title, baseURL, selfURL string, hits []models.WatchlistHit) atomFeed {
+	updated := time.Now().UTC()
+	if len(hits) > 0 {
+		updated = hits[0].CreatedAt.UTC()
+	}
+
+	feed := atomFeed{
+		NS:       "http://www.w3.org/2005/Atom",
+		Lang:     "es",
+		ID:       selfURL,
+		Title:    title,
+		Subtitle: "Menciones de organizaciones monitoreadas",
+		Updated:  updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: selfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: baseURL, Rel: "alternate", Type: "text/html"},
+		},
+		Author: atomPerson{Name: "Folio"},
+	}
+
+	for _, hit := range hits {
+		ts := hit.CreatedAt.UTC().Format(time.RFC3339)
+		feed.Entries = append(feed.Entries, atomEntry{
+			ID:         "urn:uuid:" + hit.ID.String(),
+			Title:      hitFeedTitle(hit),
+			Links:      []atomLink{{Href: hit.URL, Rel: "alternate", Type: "text/html"}},
+			Published:  ts,
+			Updated:    ts,
+			Summary:    atomText{Type: "text", Value: hitFeedText(hit)},
+			Content:    atomText{Type: "html", Value: buildContentHTML(hit)},
+			Author:     atomPerson{Name: hit.OrgName},
+			Categories: []atomCategory{{Term: hit.SourceType}},
+		})
+	}
+	return feed
+}
+
+// writeAtom writes an Atom 1.0 document.
+func writeAtom(w http.ResponseWriter, feed atomFeed) {
+	w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	enc.Encode(feed)
+}
+
+// hitJSONFeed builds the JSON Feed 1.1 version of the hit feed.
+func hitJSONFeed(title, baseURL, selfURL string, hits []models.WatchlistHit) jsonFeed {
+	feed := jsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       title,
+		HomePageURL: baseURL,
+		FeedURL:     selfURL,
+		Description: "Menciones de organizaciones monitoreadas",
+		Language:    "es",
+		Items:       make([]jsonFeedItem, 0, len(hits)),
+	}
+
+	for _, hit := range hits {
+		feed.Items = append(feed.Items, jsonFeedItem{
+			ID:            hit.ID.String(),
+			URL:           hit.URL,
+			Title:         hitFeedTitle(hit),
+			ContentHTML:   buildContentHTML(hit),
+			ContentText:   hitFeedText(hit),
+			Summary:       hit.Snippet,
+			DatePublished: hit.CreatedAt.UTC().Format(time.RFC3339),
+			Authors:       []jsonFeedAuthor{{Name: hit.OrgName}},
+			Tags:          []string{hit.SourceType},
+			Folio: jsonFeedHit{
+				OrgID:      hit.OrgID,
+				OrgName:    hit.OrgName,
+				Sentiment:  hit.Sentiment,
+				SourceType: hit.SourceType,
+				DupCount:   hit.DupCount,
+			},
+		})
+	}
+	return feed
+}
+
+// writeJSONFeed writes a JSON Feed document.
+func writeJSONFeed(w http.ResponseWriter, feed jsonFeed) {
+	w.Header().Set("Content-Type", "application/feed+json; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	enc.Encode(feed)
+}
+
+// ── Atom XML types ───────────────────────────────────────────────
+
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	NS       string      `xml:"xmlns,attr"`
+	Lang     string      `xml:"xml:lang,attr"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   atomPerson  `xml:"author"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+type atomPerson struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID         string         `xml:"id"`
+	Title      string         `xml:"title"`
+	Links      []atomLink     `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Summary    atomText       `xml:"summary"`
+	Content    atomText       `xml:"content"`
+	Author     atomPerson     `xml:"author"`
+	Categories []atomCategory `xml:"category"`
+}
+
+type atomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+type atomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// ── JSON Feed types ──────────────────────────────────────────────
+
+type jsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageURL string         `json:"home_page_url"`
+	FeedURL     string         `json:"feed_url"`
+	Description string         `json:"description"`
+	Language    string         `json:"language"`
+	Items       []jsonFeedItem `json:"items"`
+}
+
+type jsonFeedItem struct {
+	ID            string           `json:"id"`
+	URL           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHTML   string           `json:"content_html"`
+	ContentText   string           `json:"content_text"`
+	Summary       string           `json:"summary,omitempty"`
+	DatePublished string           `json:"date_published"`
+	Authors       []jsonFeedAuthor `json:"authors"`
+	Tags          []string         `json:"tags"`
+	Folio         jsonFeedHit      `json:"_folio"`
+}
+
+type jsonFeedAuthor struct {
+	Name string `json:"name"`
+}
+
+// jsonFeedHit is the "_folio" extension of a JSON Feed item: the hit's
+// fields that automation filters or routes on.
+type jsonFeedHit struct {
+	OrgID      uuid.UUID `json:"org_id"`
+	OrgName    string    `json:"org_name"`
+	Sentiment  string    `json:"sentiment"`
+	SourceType string    `json:"source_type"`
+	DupCount   int       `json:"dup_count"`
+}
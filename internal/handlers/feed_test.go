@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Saul-Punybz/folio/internal/config"
+	"github.com/Saul-Punybz/folio/internal/db"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// testPool connects to the database in FOLIO_TEST_DSN, applying the
+// migrations, or skips the test when it is unset.
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	dsn := os.Getenv("FOLIO_TEST_DSN")
+	if dsn == "" {
+		t.Skip("FOLIO_TEST_DSN not set")
+	}
+	pool, err := db.ConnectWithDSN(context.Background(), dsn, config.DBConfig{}, os.DirFS("../../migrations"))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func TestServeSavedFeedDatesItemsBySave(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	users, articles := models.NewUserStore(pool), models.NewArticleStore(pool)
+
+	user := &models.User{Email: "feed-test-" + uuid.NewString() + "@example.com", PasswordHash: "x"}
+	if err := users.Create(ctx, user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	token, err := users.SetFeedToken(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("set feed token: %v", err)
+	}
+
+	create := func(title string) *models.Article {
+		a := &models.Article{
+			Title:  title,
+			Source: "test",
+			URL:    "https://feed-test.example/" + uuid.NewString(),
+			Region: "PR",
+			Status: "inbox",
+		}
+		if err := articles.Create(ctx, a); err != nil {
+			t.Fatalf("create article: %v", err)
+		}
+		return a
+	}
+	fresh, old := create("Fresh"), create("Old")
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM articles WHERE id = ANY($1)`, []uuid.UUID{fresh.ID, old.ID})
+		pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, user.ID)
+	})
+
+	// The old article was ingested weeks ago; the fresh one was saved a
+	// minute ago, before the reader last polled.
+	if _, err := pool.Exec(ctx, `
+		UPDATE articles SET created_at = NOW() - INTERVAL '60 days',
+		                    published_at = NOW() - INTERVAL '60 days'
+		WHERE id = $1
+	`, old.ID); err != nil {
+		t.Fatalf("backdate article: %v", err)
+	}
+	if err := articles.UpdateStatus(ctx, fresh.ID, "saved"); err != nil {
+		t.Fatalf("save fresh: %v", err)
+	}
+	if _, err := pool.Exec(ctx, `
+		UPDATE article_status_history SET changed_at = NOW() - INTERVAL '1 minute'
+		WHERE article_id = $1
+	`, fresh.ID); err != nil {
+		t.Fatalf("backdate save: %v", err)
+	}
+
+	router := chi.NewRouter()
+	router.Get("/feed/{token}/saved.xml", (&FeedHandler{Users: users, Articles: articles}).ServeSavedFeed)
+	get := func(lastModified, etag string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, "/feed/"+token+"/saved.xml", nil)
+		if lastModified != "" {
+			r.Header.Set("If-Modified-Since", lastModified)
+			r.Header.Set("If-None-Match", etag)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w
+	}
+
+	first := get("", "")
+	if first.Code != http.StatusOK {
+		t.Fatalf("first fetch status = %d, want 200", first.Code)
+	}
+	lastModified, etag := first.Header().Get("Last-Modified"), first.Header().Get("ETag")
+
+	if err := articles.UpdateStatus(ctx, old.ID, "saved"); err != nil {
+		t.Fatalf("save old: %v", err)
+	}
+
+	w := get(lastModified, etag)
+	if w.Code != http.StatusOK {
+		t.Fatalf("conditional fetch after saving status = %d, want 200", w.Code)
+	}
+	var feed struct {
+		Items []struct {
+			GUID string `xml:"guid"`
+		} `xml:"channel>item"`
+	}
+	if err := xml.Unmarshal(w.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("parse feed: %v", err)
+	}
+	if len(feed.Items) == 0 || feed.Items[0].GUID != old.ID.String() {
+		t.Fatalf("first item = %+v, want the article just saved (%s)", feed.Items, old.ID)
+	}
+	if w.Header().Get("Last-Modified") == lastModified {
+		t.Errorf("Last-Modified unchanged after saving an article: %s", lastModified)
+	}
+}
@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
+)
+
+// GrantsHandler groups the Grants.gov and Federal Register HTTP handlers.
+type GrantsHandler struct {
+	Grants *models.GrantStore
+}
+
+// grantFilters reads the filters shared by the grant listings:
+// q, agency, limit (default 50, max 200) and offset.
+func grantFilters(r *http.Request) models.GrantFilters {
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	if offset < 0 {
+		offset = 0
+	}
+	return models.GrantFilters{
+		Query:  q.Get("q"),
+		Agency: q.Get("agency"),
+		Limit:  limit,
+		Offset: offset,
+	}
+}
+
+// grantSort reads the sort and min_award params of the opportunity and
+// posting listings. sort is deadline (the default) or amount; min_award is
+// in whole dollars. ok is false, after writing a 400, when either is
+// invalid.
+func grantSort(w http.ResponseWriter, r *http.Request, f *models.GrantFilters) (ok bool) {
+	q := r.URL.Query()
+	switch q.Get("sort") {
+	case "", "deadline":
+	case models.GrantSortAmount:
+		f.Sort = models.GrantSortAmount
+	default:
+		writeError(w, r, http.StatusBadRequest, "sort must be deadline or amount")
+		return false
+	}
+	if v := q.Get("min_award"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 0 {
+			writeError(w, r, http.StatusBadRequest, "min_award must be a whole number of dollars")
+			return false
+		}
+		f.MinAward = n
+	}
+	return true
+}
+
+// ListOpportunities handles
+// GET /api/grants/opportunities?q=&agency=&cfda=&open=true&tracking=&sort=&min_award=&limit=&offset=.
+// Returns Grants.gov opportunities, soonest closing first or, with
+// sort=amount, largest award ceiling first. With open=true only those
+// closing today or later are listed; tracking is pursuing, declined or none.
+func (h *GrantsHandler) ListOpportunities(w http.ResponseWriter, r *http.Request) {
+	f := grantFilters(r)
+	if !grantSort(w, r, &f) {
+		return
+	}
+	f.CFDA = r.URL.Query().Get("cfda")
+	if r.URL.Query().Get("open") == "true" {
+		f.ClosingAfter = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	f.Tracking = r.URL.Query().Get("tracking")
+	if !validGrantTracking(f.Tracking) {
+		writeError(w, r, http.StatusBadRequest, "tracking must be pursuing, declined or none")
+		return
+	}
+
+	opps, err := h.Grants.ListOpportunities(r.Context(), f)
+	if err != nil {
+		slog.Error("list grant opportunities", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if opps == nil {
+		opps = []models.GrantOpportunity{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"opportunities": opps, "count": len(opps)})
+}
+
+// ListDeadlines handles GET /api/grants/deadlines?days=14&tracking=.
+// Returns the opportunities closing between today and days from now
+// (default scraper.GrantClosingDays, max 365), soonest first. Declined
+// opportunities are left out unless tracking=declined.
+func (h *GrantsHandler) ListDeadlines(w http.ResponseWriter, r *http.Request) {
+	f := grantFilters(r)
+	days, _ := strconv.Atoi(r.URL.Query().Get("days"))
+	if days <= 0 || days > 365 {
+		days = scraper.GrantClosingDays
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	f.ClosingAfter = today
+	f.ClosingBy = today.AddDate(0, 0, days)
+	f.Tracking = r.URL.Query().Get("tracking")
+	if !validGrantTracking(f.Tracking) {
+		writeError(w, r, http.StatusBadRequest, "tracking must be pursuing, declined or none")
+		return
+	}
+
+	f.SkipDeclined = f.Tracking == ""
+
+	opps, err := h.Grants.ListOpportunities(r.Context(), f)
+	if err != nil {
+		slog.Error("list grant deadlines", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if opps == nil {
+		opps = []models.GrantOpportunity{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"opportunities": opps, "count": len(opps), "days": days})
+}
+
+// ListPostings handles
+// GET /api/grants/postings?q=&funder=&eligible=&open=true&sort=&min_award=&limit=&offset=.
+// Returns grant-kind articles (tagged "grants") with the funder, eligible
+// entities, award range, match requirement and deadline extracted from them,
+// soonest deadline first or, with sort=amount, largest award first. q matches
+// the title or funder; with open=true only postings whose deadline is today
+// or later are listed.
+func (h *GrantsHandler) ListPostings(w http.ResponseWriter, r *http.Request) {
+	f := grantFilters(r)
+	if !grantSort(w, r, &f) {
+		return
+	}
+	f.Agency = r.URL.Query().Get("funder")
+	f.Eligible = r.URL.Query().Get("eligible")
+	if r.URL.Query().Get("open") == "true" {
+		f.ClosingAfter = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+
+	postings, err := h.Grants.ListPostings(r.Context(), f)
+	if err != nil {
+		slog.Error("list grant postings", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if postings == nil {
+		postings = []models.GrantPosting{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"postings": postings, "count": len(postings)})
+}
+
+// SetTracking handles PUT /api/grants/opportunities/{id}/tracking.
+// Body: {"tracking": "pursuing"|"declined"|null}; null clears the mark.
+func (h *GrantsHandler) SetTracking(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid opportunity id")
+		return
+	}
+	var body struct {
+		Tracking *string `json:"tracking"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid JSON body")
+		return
+	}
+	if body.Tracking != nil && *body.Tracking != models.GrantPursuing && *body.Tracking != models.GrantDeclined {
+		writeError(w, r, http.StatusBadRequest, "tracking must be pursuing, declined or null")
+		return
+	}
+
+	g, err := h.Grants.SetTracking(r.Context(), id, body.Tracking)
+	if err != nil {
+		slog.Error("set grant tracking", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if g == nil {
+		writeError(w, r, http.StatusNotFound, "opportunity not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, g)
+}
+
+// validGrantTracking reports whether s is a tracking filter value.
+func validGrantTracking(s string) bool {
+	return s == "" || s == "none" || s == models.GrantPursuing || s == models.GrantDeclined
+}
+
+// ListFederalRegister handles
+// GET /api/grants/federal-register?q=&agency=&type=&limit=&offset=.
+// Returns Federal Register documents, newest first. type is the document
+// type, e.g. Notice or Rule.
+func (h *GrantsHandler) ListFederalRegister(w http.ResponseWriter, r *http.Request) {
+	f := grantFilters(r)
+	f.DocType = r.URL.Query().Get("type")
+
+	docs, err := h.Grants.ListFederalDocuments(r.Context(), f)
+	if err != nil {
+		slog.Error("list federal register documents", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if docs == nil {
+		docs = []models.FederalRegisterDocument{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"documents": docs, "count": len(docs)})
+}
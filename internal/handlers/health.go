@@ -1,13 +1,88 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/storage"
 )
 
+// healthCheckTimeout bounds each dependency check of DeepHealth.
+const healthCheckTimeout = 5 * time.Second
+
 // Health returns a simple health check response.
 func Health(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
 	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
 }
+
+// HealthHandler checks the services Folio depends on.
+type HealthHandler struct {
+	Pool    *pgxpool.Pool
+	AI      ai.Provider
+	Storage *storage.Client // nil when object storage failed to initialize
+}
+
+// dependencyStatus is the result of checking one dependency.
+type dependencyStatus struct {
+	Status    string `json:"status"` // ok, error, or disabled
+	LatencyMS int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// DeepHealth handles GET /api/health/deep.
+// Checks database connectivity, that the AI provider serves the configured
+// models, and object storage bucket access, concurrently. Responds 200 with
+// status "ok" when every configured dependency is up, otherwise 503 with
+// status "degraded"; each dependency reports its status and latency.
+func (h *HealthHandler) DeepHealth(w http.ResponseWriter, r *http.Request) {
+	checks := map[string]func(context.Context) error{
+		"database": h.Pool.Ping,
+		"ai":       h.AI.CheckModels,
+	}
+	if h.Storage != nil && h.Storage.Configured() {
+		checks["storage"] = h.Storage.CheckBucket
+	}
+
+	results := map[string]dependencyStatus{"storage": {Status: "disabled"}}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check(ctx)
+			res := dependencyStatus{Status: "ok", LatencyMS: time.Since(start).Milliseconds()}
+			if err != nil {
+				res.Status = "error"
+				res.Error = err.Error()
+			}
+			mu.Lock()
+			results[name] = res
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	status, code := "ok", http.StatusOK
+	for _, res := range results {
+		if res.Status == "error" {
+			status, code = "degraded", http.StatusServiceUnavailable
+		}
+	}
+	writeJSON(w, code, map[string]any{
+		"status":       status,
+		"dependencies": results,
+	})
+}
@@ -1,11 +1,30 @@
 package handlers
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"log/slog"
+	"mime"
 	"net/http"
+
+	"github.com/Saul-Punybz/folio/internal/i18n"
+	"github.com/Saul-Punybz/folio/internal/middleware"
 )
 
+// backgroundContext returns base, the context background work started by a
+// handler runs under (cancelled on shutdown), or context.Background() when
+// the handler was built without one. Request contexts are not used for
+// such work since they end when the response is written.
+func backgroundContext(base context.Context) context.Context {
+	if base == nil {
+		return context.Background()
+	}
+	return base
+}
+
 // writeJSON encodes v as JSON and writes it to w with the given status code.
 func writeJSON(w http.ResponseWriter, status int, v any) {
 	w.Header().Set("Content-Type", "application/json")
@@ -14,3 +33,53 @@ func writeJSON(w http.ResponseWriter, status int, v any) {
 		slog.Error("write json", "err", err)
 	}
 }
+
+// writeError writes a JSON error response with msg, an English catalog
+// message formatted with args, translated into the request's language.
+func writeError(w http.ResponseWriter, r *http.Request, status int, msg string, args ...any) {
+	writeJSON(w, status, map[string]string{"error": i18n.T(middleware.Lang(r), msg, args...)})
+}
+
+// localize translates an English catalog message into the request's
+// language.
+func localize(r *http.Request, msg string, args ...any) string {
+	return i18n.T(middleware.Lang(r), msg, args...)
+}
+
+// writeSSE writes a single Server-Sent Event with a JSON-encoded data payload
+// and flushes it to the client immediately.
+func writeSSE(w http.ResponseWriter, event string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("sse marshal: %w", err)
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	return http.NewResponseController(w).Flush()
+}
+
+// readUpload returns an uploaded file of at most maxSize bytes, sent either
+// as the "file" field of a multipart form or as the raw request body.
+func readUpload(w http.ResponseWriter, r *http.Request, maxSize int64) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "multipart/form-data" {
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			return nil, fmt.Errorf("file is required")
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+
+	data, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read upload (max %d KB)", maxSize>>10)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, fmt.Errorf("file is required")
+	}
+	return data, nil
+}
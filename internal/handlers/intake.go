@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/middleware"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/notify"
+)
+
+// Caps on tip fields, in characters.
+const (
+	maxTipDescription = 5000
+	maxTipField       = 200
+	tipTitleLength    = 120
+)
+
+// IntakeHandler receives tips from external forms and partner systems and
+// manages the API keys they authenticate with.
+type IntakeHandler struct {
+	Keys     *models.IntakeKeyStore
+	Tips     *models.TipStore
+	Articles *models.ArticleStore
+	Items    *ItemsHandler // queues scraping of tipped URLs
+}
+
+type tipRequest struct {
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Region      string `json:"region"`
+	Submitter   struct {
+		Name         string `json:"name"`
+		Contact      string `json:"contact"`
+		Organization string `json:"organization"`
+	} `json:"submitter"`
+}
+
+// validate normalizes the request and returns a user-facing error message, or
+// "" if the request is valid. The URL is fetched server-side, so it must
+// resolve to a public address.
+func (req *tipRequest) validate(ctx context.Context) string {
+	req.URL = strings.TrimSpace(req.URL)
+	req.Title = strings.TrimSpace(req.Title)
+	req.Description = strings.TrimSpace(req.Description)
+	req.Submitter.Name = strings.TrimSpace(req.Submitter.Name)
+	req.Submitter.Contact = strings.TrimSpace(req.Submitter.Contact)
+	req.Submitter.Organization = strings.TrimSpace(req.Submitter.Organization)
+
+	if req.URL == "" && req.Description == "" {
+		return "url or description is required"
+	}
+	if req.URL != "" {
+		u, err := url.Parse(req.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return "url must be an absolute http(s) URL"
+		}
+		if err := notify.CheckURL(ctx, req.URL); err != nil {
+			return "url must resolve to a public address"
+		}
+	}
+	if utf8.RuneCountInString(req.Description) > maxTipDescription {
+		return "description is too long"
+	}
+	for _, f := range []string{req.Title, req.Submitter.Name, req.Submitter.Contact, req.Submitter.Organization} {
+		if utf8.RuneCountInString(f) > maxTipField {
+			return "title and submitter fields must be at most 200 characters"
+		}
+	}
+	return ""
+}
+
+// tipTitle is the inbox title for a tip without one: the first line of the
+// description, or the URL.
+func (req *tipRequest) tipTitle() string {
+	if req.Title != "" {
+		return req.Title
+	}
+	if req.Description == "" {
+		return req.URL
+	}
+	line, _, _ := strings.Cut(req.Description, "\n")
+	if r := []rune(strings.TrimSpace(line)); len(r) > tipTitleLength {
+		return string(r[:tipTitleLength]) + "…"
+	}
+	return strings.TrimSpace(line)
+}
+
+// SubmitTip handles POST /api/intake, authenticated by an intake API key.
+// Body: { "url", "title", "description", "region",
+// "submitter": { "name", "contact", "organization" } }; url or description is
+// required. The tip becomes an inbox item with source "tip" (a URL already
+// in Folio is linked instead of duplicated). The response is the receipt.
+func (h *IntakeHandler) SubmitTip(w http.ResponseWriter, r *http.Request) {
+	var req tipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if msg := req.validate(r.Context()); msg != "" {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+
+	tip := &models.Tip{
+		URL:              req.URL,
+		Description:      req.Description,
+		SubmitterName:    req.Submitter.Name,
+		SubmitterContact: req.Submitter.Contact,
+		SubmitterOrg:     req.Submitter.Organization,
+	}
+	if key := middleware.IntakeKeyFromContext(r.Context()); key != nil {
+		tip.IntakeKeyID = &key.ID
+	}
+
+	if req.URL != "" {
+		existing, err := h.Articles.IDByURL(r.Context(), req.URL)
+		if err != nil {
+			slog.Error("intake: look up url", "url", req.URL, "err", err)
+			writeError(w, r, http.StatusInternalServerError, "could not record tip")
+			return
+		}
+		if existing != uuid.Nil {
+			tip.ArticleID = &existing
+		}
+	}
+
+	if tip.ArticleID == nil {
+		region := req.Region
+		if region == "" {
+			region = coverage.Current().Region
+		}
+		article := &models.Article{
+			Title:          req.tipTitle(),
+			Source:         models.SourceTip,
+			URL:            req.URL,
+			CanonicalURL:   req.URL,
+			Region:         region,
+			Status:         "inbox",
+			Summary:        req.Description,
+			EvidencePolicy: "ret_3m",
+		}
+		if err := h.Articles.Create(r.Context(), article); err != nil {
+			slog.Error("intake: create article", "url", req.URL, "err", err)
+			writeError(w, r, http.StatusInternalServerError, "could not record tip")
+			return
+		}
+		tip.ArticleID = &article.ID
+		if req.URL != "" && h.Items != nil {
+			h.Items.queueCollectEnrichment(r.Context(), article)
+		}
+	}
+
+	if err := h.Tips.Create(r.Context(), tip); err != nil {
+		slog.Error("intake: create tip", "url", req.URL, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not record tip")
+		return
+	}
+
+	slog.Info("intake: tip received", "receipt", tip.ID, "article", tip.ArticleID, "key", tip.IntakeKeyID)
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"receipt":     tip.ID,
+		"status":      "received",
+		"received_at": tip.CreatedAt,
+	})
+}
+
+// ListTips handles GET /api/items/{id}/tips: the tips (with submitter
+// metadata) behind an inbox item.
+func (h *IntakeHandler) ListTips(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
+		return
+	}
+	tips, err := h.Tips.ListByArticle(r.Context(), id)
+	if err != nil {
+		slog.Error("list tips", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if tips == nil {
+		tips = []models.Tip{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"tips": tips, "count": len(tips)})
+}
+
+// ListKeys handles GET /api/intake/keys.
+func (h *IntakeHandler) ListKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.Keys.List(r.Context())
+	if err != nil {
+		slog.Error("list intake keys", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if keys == nil {
+		keys = []models.IntakeKey{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"keys": keys, "count": len(keys)})
+}
+
+// CreateKey handles POST /api/intake/keys.
+// Body: { "name": "Formulario web" }. The response carries the key itself,
+// which is not shown again.
+func (h *IntakeHandler) CreateKey(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Name string `json:"name"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" {
+		writeError(w, r, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	var createdBy *uuid.UUID
+	if user := middleware.UserFromContext(r.Context()); user != nil {
+		createdBy = &user.ID
+	}
+	key, plain, err := h.Keys.Create(r.Context(), req.Name, createdBy)
+	if err != nil {
+		slog.Error("create intake key", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not create key")
+		return
+	}
+	writeJSON(w, http.StatusCreated, map[string]any{"key": key, "api_key": plain})
+}
+
+// RevokeKey handles DELETE /api/intake/keys/{id}.
+func (h *IntakeHandler) RevokeKey(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid key id")
+		return
+	}
+	if err := h.Keys.Revoke(r.Context(), id); err != nil {
+		writeError(w, r, http.StatusNotFound, "key not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
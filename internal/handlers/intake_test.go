@@ -0,0 +1,41 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Saul-Punybz/folio/internal/i18n"
+)
+
+func TestSubmitTipRejectsNonPublicURLs(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{"loopback", "http://127.0.0.1:8080/admin"},
+		{"localhost", "http://localhost/"},
+		{"IPv6 loopback", "http://[::1]/"},
+		{"private network", "https://10.0.0.5/internal"},
+		{"link-local metadata", "http://169.254.169.254/latest/meta-data/"},
+		{"unspecified", "http://0.0.0.0/"},
+	}
+	// The URL is refused before any store is touched, so none are needed.
+	h := &IntakeHandler{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := strings.NewReader(`{"url": "` + tt.url + `", "description": "tip"}`)
+			r := httptest.NewRequest(http.MethodPost, "/api/intake", body)
+			w := httptest.NewRecorder()
+			h.SubmitTip(w, r)
+
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d (body %s)", w.Code, http.StatusBadRequest, w.Body)
+			}
+			if want := i18n.T(i18n.Default(), "url must resolve to a public address"); !strings.Contains(w.Body.String(), want) {
+				t.Errorf("body = %s, want the public address error", w.Body)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -12,18 +13,52 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/middleware"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/scraper"
+	"github.com/Saul-Punybz/folio/internal/storage"
 )
 
 // ItemsHandler groups article/item-related HTTP handlers.
 type ItemsHandler struct {
 	Articles *models.ArticleStore
 	Scraper  *scraper.Scraper
-	AI       *ai.OllamaClient
+	AI       ai.Provider
+	Jobs     *models.JobStore // queue for scrape+enrich of collected items
+	Notes    *models.NoteStore
+	Hits     *models.WatchlistHitStore
+	Storage  *storage.Client
+
+	FeedAccess *models.FeedAccessStore // optional; logs share page fetches
+
+	// TrashPurgeDays is how long trashed items are kept before the worker
+	// purges them; 0 when purging is disabled.
+	TrashPurgeDays int
+
+	// Background is cancelled on shutdown; inline enrichment runs under it.
+	Background context.Context
 }
 
+// defaultExpiringWindowDays is the look-ahead of the pre-expiry review queue.
+const defaultExpiringWindowDays = 14
+
 // ListItems handles GET /api/items?status=inbox&limit=50&offset=0.
+// With expiring_within=N, only items whose evidence expires in the next N
+// days are returned, soonest first. Items listed with status=trashed carry
+// deleted_at and, when purging is enabled, purge_at.
+//
+// Pass cursor (the next_cursor of the previous page) instead of offset for
+// keyset pagination; next_cursor is empty on the last page. total=true adds
+// the number of items with the status.
+//
+// Articles covering the same story are grouped: each cluster is listed once,
+// as its first article, with cluster_size and the other members in related.
+// group=false lists every article individually.
+//
+// as_of=2025-05-01 (or an RFC 3339 time) lists the items that had the status
+// at that time, per the status history; a date means the end of that day
+// (UTC). Such lists are ungrouped and paged by offset.
 func (h *ItemsHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 	status := r.URL.Query().Get("status")
 	if status == "" {
@@ -36,10 +71,52 @@ func (h *ItemsHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 	}
 	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
 
-	articles, err := h.Articles.ListByStatus(r.Context(), status, limit, offset)
+	if s := r.URL.Query().Get("as_of"); s != "" {
+		h.listAsOf(w, r, s, status, limit, offset)
+		return
+	}
+
+	cursor, err := models.DecodeArticleCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+
+	group := true
+	if g := r.URL.Query().Get("group"); g != "" {
+		group, _ = strconv.ParseBool(g)
+	}
+	if group && r.URL.Query().Get("expiring_within") == "" {
+		h.listClusters(w, r, status, cursor, limit, offset)
+		return
+	}
+
+	var articles []models.Article
+	var next *models.ArticleCursor
+	if ew := r.URL.Query().Get("expiring_within"); ew != "" {
+		days, perr := strconv.Atoi(ew)
+		if perr != nil || days <= 0 {
+			writeError(w, r, http.StatusBadRequest, "expiring_within must be a positive number of days")
+			return
+		}
+		if cursor != nil {
+			writeError(w, r, http.StatusBadRequest, "cursor is not supported with expiring_within, use offset")
+			return
+		}
+		articles, err = h.Articles.ListExpiring(r.Context(), days, status, limit, offset)
+	} else if cursor != nil || offset == 0 {
+		var page *models.ArticlePage
+		page, err = h.Articles.ListByStatusAfter(r.Context(), status, cursor, limit)
+		if page != nil {
+			articles, next = page.Articles, page.Next
+		}
+		offset = 0
+	} else {
+		articles, err = h.Articles.ListByStatus(r.Context(), status, limit, offset)
+	}
 	if err != nil {
 		slog.Error("list items", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -47,10 +124,195 @@ func (h *ItemsHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 	if articles == nil {
 		articles = []models.Article{}
 	}
+	if status == "trashed" {
+		trashed := make([]*models.Article, len(articles))
+		for i := range articles {
+			trashed[i] = &articles[i]
+		}
+		h.setTrashTimes(r, trashed)
+	}
+
+	resp := map[string]any{
+		"items":       articles,
+		"count":       len(articles),
+		"limit":       limit,
+		"offset":      offset,
+		"next_cursor": next.Encode(),
+	}
+	if wantTotal(r) && r.URL.Query().Get("expiring_within") == "" {
+		total, err := h.Articles.CountByStatus(r.Context(), status)
+		if err != nil {
+			slog.Error("list items: count", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
+		}
+		resp["total"] = total
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// listClusters serves ListItems with articles grouped by story cluster.
+func (h *ItemsHandler) listClusters(w http.ResponseWriter, r *http.Request, status string, cursor *models.ArticleCursor, limit, offset int) {
+	if cursor != nil {
+		offset = 0
+	}
+	clusters, next, err := h.Articles.ListClustersByStatus(r.Context(), status, cursor, limit, offset)
+	if err != nil {
+		slog.Error("list items: clusters", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if clusters == nil {
+		clusters = []models.ArticleCluster{}
+	}
+	if status == "trashed" {
+		trashed := make([]*models.Article, len(clusters))
+		for i := range clusters {
+			trashed[i] = &clusters[i].Article
+		}
+		h.setTrashTimes(r, trashed)
+	}
+
+	resp := map[string]any{
+		"items":       clusters,
+		"count":       len(clusters),
+		"limit":       limit,
+		"offset":      offset,
+		"next_cursor": next.Encode(),
+		"grouped":     true,
+	}
+	if wantTotal(r) {
+		total, err := h.Articles.CountClustersByStatus(r.Context(), status)
+		if err != nil {
+			slog.Error("list items: count clusters", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
+		}
+		resp["total"] = total
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// setTrashTimes fills deleted_at and purge_at on a trash listing. A failure
+// is logged and leaves them unset.
+func (h *ItemsHandler) setTrashTimes(r *http.Request, articles []*models.Article) {
+	if err := h.Articles.SetTrashTimes(r.Context(), articles, h.TrashPurgeDays); err != nil {
+		slog.Error("list items: trash times", "err", err)
+	}
+}
+
+// wantTotal reports whether the request asked for a total count (?total=true).
+// Counting is opt-in because it scans every matching row.
+// listAsOf serves ListItems with as_of: the items that had the status at
+// that time.
+func (h *ItemsHandler) listAsOf(w http.ResponseWriter, r *http.Request, asOfParam, status string, limit, offset int) {
+	asOf, err := time.Parse(time.RFC3339, asOfParam)
+	if err != nil {
+		day, derr := time.Parse("2006-01-02", asOfParam)
+		if derr != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid as_of, use YYYY-MM-DD or RFC 3339")
+			return
+		}
+		asOf = day.Add(24*time.Hour - time.Nanosecond)
+	}
+	if asOf.After(time.Now()) {
+		writeError(w, r, http.StatusBadRequest, "as_of must not be in the future")
+		return
+	}
+	if r.URL.Query().Get("cursor") != "" || r.URL.Query().Get("expiring_within") != "" {
+		writeError(w, r, http.StatusBadRequest, "as_of cannot be combined with cursor or expiring_within")
+		return
+	}
+
+	articles, err := h.Articles.ListByStatusAsOf(r.Context(), status, asOf, limit, offset)
+	if err != nil {
+		slog.Error("list items as of", "as_of", asOf, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if articles == nil {
+		articles = []models.Article{}
+	}
+
+	resp := map[string]any{
+		"items":  articles,
+		"count":  len(articles),
+		"limit":  limit,
+		"offset": offset,
+		"as_of":  asOf,
+	}
+	if wantTotal(r) {
+		total, err := h.Articles.CountByStatusAsOf(r.Context(), status, asOf)
+		if err != nil {
+			slog.Error("list items as of: count", "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
+		}
+		resp["total"] = total
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// GetStatusHistory handles GET /api/items/{id}/history.
+// Returns the item's status changes, oldest first.
+func (h *ItemsHandler) GetStatusHistory(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
+		return
+	}
+	changes, err := h.Articles.StatusHistory(r.Context(), id)
+	if err != nil {
+		slog.Error("article status history", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if changes == nil {
+		changes = []models.StatusChange{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"history": changes, "count": len(changes)})
+}
+
+func wantTotal(r *http.Request) bool {
+	v, _ := strconv.ParseBool(r.URL.Query().Get("total"))
+	return v
+}
+
+// ListExpiring handles GET /api/items/expiring?days=14&limit=50&offset=0.
+// The pre-expiry review queue: items of any status whose evidence will be
+// removed by the daily cleanup within the window, soonest first. Retention is
+// extended with PUT /api/items/{id}/retention.
+func (h *ItemsHandler) ListExpiring(w http.ResponseWriter, r *http.Request) {
+	days := defaultExpiringWindowDays
+	if ds := r.URL.Query().Get("days"); ds != "" {
+		parsed, err := strconv.Atoi(ds)
+		if err != nil || parsed <= 0 {
+			writeError(w, r, http.StatusBadRequest, "days must be a positive number")
+			return
+		}
+		days = parsed
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	articles, err := h.Articles.ListExpiring(r.Context(), days, r.URL.Query().Get("status"), limit, offset)
+	if err != nil {
+		slog.Error("list expiring items", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if articles == nil {
+		articles = []models.Article{}
+	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
 		"items":  articles,
 		"count":  len(articles),
+		"days":   days,
 		"limit":  limit,
 		"offset": offset,
 	})
@@ -60,13 +322,13 @@ func (h *ItemsHandler) ListItems(w http.ResponseWriter, r *http.Request) {
 func (h *ItemsHandler) SaveItem(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid article id"})
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
 		return
 	}
 
 	if err := h.Articles.UpdateStatus(r.Context(), id, "saved"); err != nil {
 		slog.Error("save item", "id", id, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not save item"})
+		writeError(w, r, http.StatusInternalServerError, "could not save item")
 		return
 	}
 
@@ -74,42 +336,64 @@ func (h *ItemsHandler) SaveItem(w http.ResponseWriter, r *http.Request) {
 }
 
 // TrashItem handles POST /api/items/{id}/trash.
-// Sets status to trashed and applies the default 3-month retention policy.
+// Moves the item to the trash and applies the 3-month evidence retention
+// policy; restoring it gives back the policy it had. Trashed items are
+// purged after TRASH_PURGE_DAYS.
 func (h *ItemsHandler) TrashItem(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid article id"})
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
 		return
 	}
 
-	// Get the article so we can modify it.
-	article, err := h.Articles.GetByID(r.Context(), id)
+	if err := h.Articles.Trash(r.Context(), id); err != nil {
+		slog.Error("trash item", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not trash item")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{"status": "trashed"})
+}
+
+// RestoreItem handles POST /api/items/{id}/restore.
+// Moves a trashed item back to the status it had before it was trashed
+// (inbox when unknown) and gives back its evidence retention policy. List
+// the trash with GET /api/items?status=trashed.
+func (h *ItemsHandler) RestoreItem(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		slog.Error("trash item: get", "id", id, "err", err)
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "item not found"})
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
 		return
 	}
 
-	article.Status = "trashed"
-	article.EvidencePolicy = "ret_3m"
-	expiresAt := time.Now().Add(90 * 24 * time.Hour) // 3 months
-	article.EvidenceExpiresAt = &expiresAt
+	article, err := h.Articles.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, "item not found")
+		return
+	}
+	if article.Status != "trashed" {
+		writeError(w, r, http.StatusConflict, "item is not in the trash")
+		return
+	}
 
-	if err := h.Articles.UpdateStatus(r.Context(), id, "trashed"); err != nil {
-		slog.Error("trash item: update status", "id", id, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not trash item"})
+	status, err := h.Articles.Restore(r.Context(), id)
+	if err != nil {
+		slog.Error("restore item", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not restore item")
 		return
 	}
 
-	// Update evidence policy and expiry separately.
-	_, dbErr := h.Articles.Pool().Exec(r.Context(), `
-		UPDATE articles SET evidence_policy = $1, evidence_expires_at = $2 WHERE id = $3
-	`, article.EvidencePolicy, article.EvidenceExpiresAt, id)
-	if dbErr != nil {
-		slog.Error("trash item: update evidence", "id", id, "err", dbErr)
+	// Articles left in the trash lose their embedding; have the worker
+	// regenerate it so the item is back in similarity results.
+	if archived, err := h.Articles.EmbeddingArchived(r.Context(), id); err != nil {
+		slog.Error("restore item: check embedding", "id", id, "err", err)
+	} else if archived && h.Jobs != nil {
+		if err := h.Jobs.Enqueue(r.Context(), models.JobEmbedArticle, scraper.EmbedJobPayload{ArticleID: id}); err != nil {
+			slog.Error("restore item: enqueue embedding", "id", id, "err", err)
+		}
 	}
 
-	writeJSON(w, http.StatusOK, map[string]string{"status": "trashed"})
+	writeJSON(w, http.StatusOK, map[string]string{"status": status})
 }
 
 // PinItem handles POST /api/items/{id}/pin.
@@ -117,21 +401,21 @@ func (h *ItemsHandler) TrashItem(w http.ResponseWriter, r *http.Request) {
 func (h *ItemsHandler) PinItem(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid article id"})
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
 		return
 	}
 
 	article, err := h.Articles.GetByID(r.Context(), id)
 	if err != nil {
 		slog.Error("pin item: get", "id", id, "err", err)
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "item not found"})
+		writeError(w, r, http.StatusNotFound, "item not found")
 		return
 	}
 
 	newPinned := !article.Pinned
 	if err := h.Articles.SetPinned(r.Context(), id, newPinned); err != nil {
 		slog.Error("pin item: set", "id", id, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not pin item"})
+		writeError(w, r, http.StatusInternalServerError, "could not pin item")
 		return
 	}
 
@@ -143,7 +427,7 @@ func (h *ItemsHandler) PinItem(w http.ResponseWriter, r *http.Request) {
 func (h *ItemsHandler) UndoItem(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid article id"})
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
 		return
 	}
 
@@ -159,7 +443,7 @@ func (h *ItemsHandler) UndoItem(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.Articles.UpdateStatus(r.Context(), id, body.PreviousStatus); err != nil {
 		slog.Error("undo item", "id", id, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not undo item"})
+		writeError(w, r, http.StatusInternalServerError, "could not undo item")
 		return
 	}
 
@@ -170,30 +454,94 @@ type updateRetentionRequest struct {
 	Policy string `json:"policy"`
 }
 
+// GetItem handles GET /api/items/{id}.
+// Returns the full article plus what the UI needs for a provenance panel: the
+// number of notes, the user's watchlist hits pointing at the article's URL,
+// and whether evidence was captured (with its hashes and capture time).
+func (h *ItemsHandler) GetItem(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
+		return
+	}
+
+	ctx := r.Context()
+	article, err := h.Articles.GetByID(ctx, id)
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, "article not found")
+		return
+	}
+
+	notesCount := 0
+	if h.Notes != nil {
+		if notesCount, err = h.Notes.CountByArticle(ctx, id); err != nil {
+			slog.Error("get item: count notes", "id", id, "err", err)
+		}
+	}
+
+	hits := []models.WatchlistHit{}
+	if user := middleware.UserFromContext(ctx); user != nil && h.Hits != nil {
+		found, err := h.Hits.ListByArticleURL(ctx, user.ID, article.URL, article.CanonicalURL)
+		if err != nil {
+			slog.Error("get item: list hits", "id", id, "err", err)
+		} else if found != nil {
+			hits = found
+		}
+	}
+
+	evidence := map[string]any{"configured": false, "exists": false}
+	if h.Storage != nil && h.Storage.Configured() {
+		evidence["configured"] = true
+		meta, err := h.Storage.GetCaptureMeta(ctx, id, article.EvidencePolicy)
+		if err != nil {
+			slog.Error("get item: capture meta", "id", id, "err", err)
+			evidence["error"] = "evidence store unavailable"
+		} else if meta != nil {
+			evidence["exists"] = true
+			evidence["meta"] = meta
+		}
+	}
+
+	quotes := []models.Quote{}
+	if found, err := h.Articles.GetQuotes(ctx, id); err != nil {
+		slog.Error("get item: quotes", "id", id, "err", err)
+	} else if found != nil {
+		quotes = found
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"article":     article,
+		"notes_count": notesCount,
+		"hits":        hits,
+		"evidence":    evidence,
+		"quotes":      quotes,
+	})
+}
+
 // UpdateRetention handles PUT /api/items/{id}/retention.
 // Body: { "policy": "ret_6m" | "ret_12m" | "keep" }
 func (h *ItemsHandler) UpdateRetention(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid article id"})
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
 		return
 	}
 
 	var req updateRetentionRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	validPolicies := map[string]bool{"ret_6m": true, "ret_12m": true, "keep": true}
 	if !validPolicies[req.Policy] {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "policy must be ret_6m, ret_12m, or keep"})
+		writeError(w, r, http.StatusBadRequest, "policy must be ret_6m, ret_12m, or keep")
 		return
 	}
 
 	if err := h.Articles.UpdateRetention(r.Context(), id, req.Policy); err != nil {
 		slog.Error("update retention", "id", id, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not update retention"})
+		writeError(w, r, http.StatusInternalServerError, "could not update retention")
 		return
 	}
 
@@ -205,150 +553,187 @@ type collectRequest struct {
 	Title   string `json:"title,omitempty"`
 	Region  string `json:"region,omitempty"`
 	Snippet string `json:"snippet,omitempty"`
+	Archive bool   `json:"archive,omitempty"` // also request a Wayback Machine snapshot
 }
 
 // CollectItem handles POST /api/collect.
-// Creates a new article from a manually provided URL.
+// Creates a new article from a manually provided URL. With "archive": true a
+// Wayback Machine snapshot is requested too and its URL stored as the
+// article's archive_url.
 func (h *ItemsHandler) CollectItem(w http.ResponseWriter, r *http.Request) {
 	var req collectRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if req.URL == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "url is required"})
+		writeError(w, r, http.StatusBadRequest, "url is required")
 		return
 	}
 
-	region := req.Region
-	if region == "" {
-		region = "PR"
-	}
-	title := req.Title
-	if title == "" {
-		title = req.URL // placeholder, worker will extract real title later
-	}
-
-	article := &models.Article{
-		Title:          title,
-		Source:         "manual",
-		URL:            req.URL,
-		CanonicalURL:   req.URL,
-		Region:         region,
-		Status:         "inbox",
-		Summary:        req.Snippet,
-		EvidencePolicy: "ret_3m",
-	}
-
-	if err := h.Articles.Create(r.Context(), article); err != nil {
+	article, err := h.collect(r.Context(), req)
+	if err != nil {
 		slog.Error("collect item", "url", req.URL, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not collect item"})
+		writeError(w, r, http.StatusInternalServerError, "could not collect item")
 		return
 	}
-
-	// Scrape + enrich in background so the article has full data.
-	if h.Scraper != nil && h.AI != nil {
-		go h.enrichCollectedArticle(article.ID, article.URL)
-	}
-
 	writeJSON(w, http.StatusCreated, article)
 }
 
-// enrichCollectedArticle scrapes the URL for content, image, then runs AI
-// summarization, classification, and embedding to fill in all missing data.
-func (h *ItemsHandler) enrichCollectedArticle(id uuid.UUID, articleURL string) {
-	ctx, cancel := context.WithTimeout(context.Background(), 90*time.Second)
-	defer cancel()
-
-	slog.Info("collect: enriching", "id", id, "url", articleURL)
+// CollectHit handles POST /api/watchlist/hits/{id}/collect.
+// Body (optional): { "region": "PR", "archive": false }. Creates an article
+// from the hit's URL the way POST /api/collect does and links the hit to it.
+// Returns 201 with the new article, or 200 with the one already collected
+// from the hit or stored under its URL.
+func (h *ItemsHandler) CollectHit(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid hit id")
+		return
+	}
 
-	// Step 1: Extract og:image (always try, independent of text scraping).
-	imageURL := h.Scraper.ExtractImageURL(ctx, articleURL)
-	if imageURL != "" {
-		if err := h.Articles.SetImageURL(ctx, id, imageURL); err != nil {
-			slog.Warn("collect: set image", "id", id, "err", err)
+	var req collectRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid request body")
+			return
 		}
 	}
 
-	// Step 2: Try multiple selector strategies to extract text.
-	selectorSets := []scraper.SourceSelectors{
-		{TitleSelector: "h1", BodySelector: "article p"},
-		{TitleSelector: "h1", BodySelector: ".article-body p, .entry-content p, .post-content p"},
-		{TitleSelector: "h1", BodySelector: "main p"},
-		{TitleSelector: "h1", BodySelector: ".content p, #content p, .story-body p, .nota-body p"},
-		{TitleSelector: "h1", BodySelector: "p"},
+	ctx := r.Context()
+	hit, err := h.Hits.GetForUser(ctx, user.ID, id)
+	if errors.Is(err, models.ErrHitNotFound) {
+		writeError(w, r, http.StatusNotFound, "hit not found")
+		return
+	}
+	if err != nil {
+		slog.Error("collect hit: get hit", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
 	}
 
-	var scraped *scraper.ScrapedArticle
-	for _, sel := range selectorSets {
-		result, err := h.Scraper.ScrapeArticle(ctx, articleURL, sel)
+	// Already collected, or the article was stored by ingestion or by hand.
+	existingID := uuid.Nil
+	if hit.ArticleID != nil {
+		existingID = *hit.ArticleID
+	} else if existingID, err = h.Articles.IDByURL(ctx, hit.URL); err != nil {
+		slog.Error("collect hit: find article", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if existingID != uuid.Nil {
+		article, err := h.Articles.GetByID(ctx, existingID)
 		if err != nil {
-			slog.Warn("collect: scrape attempt failed", "id", id, "selector", sel.BodySelector, "err", err)
-			break // Site is unreachable, no point trying more selectors.
+			slog.Error("collect hit: get article", "id", id, "article_id", existingID, "err", err)
+			writeError(w, r, http.StatusInternalServerError, "internal error")
+			return
 		}
-		if result != nil && len(result.CleanText) > 100 {
-			scraped = result
-			slog.Info("collect: scraped text", "id", id, "selector", sel.BodySelector, "len", len(result.CleanText))
-			break
+		if hit.ArticleID == nil {
+			if err := h.Hits.SetArticle(ctx, user.ID, hit.ID, article.ID); err != nil {
+				slog.Error("collect hit: link article", "id", id, "err", err)
+			}
 		}
+		writeJSON(w, http.StatusOK, article)
+		return
 	}
 
-	if scraped == nil || len(scraped.CleanText) < 50 {
-		slog.Warn("collect: no text extracted, skipping AI enrichment", "id", id, "url", articleURL)
+	req.URL = hit.URL
+	req.Title = hit.Title
+	req.Snippet = hit.Snippet
+	article, err := h.collect(ctx, req)
+	if err != nil {
+		slog.Error("collect hit", "id", id, "url", hit.URL, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not collect item")
 		return
 	}
-
-	// Step 3: Update title and clean_text.
-	cleanText := scraped.CleanText
-	title := scraped.Title
-
-	var pubAt *time.Time
-	if !scraped.PublishedAt.IsZero() {
-		pubAt = &scraped.PublishedAt
+	if err := h.Hits.SetArticle(ctx, user.ID, hit.ID, article.ID); err != nil {
+		slog.Error("collect hit: link article", "id", id, "article_id", article.ID, "err", err)
 	}
+	writeJSON(w, http.StatusCreated, article)
+}
 
-	_, err := h.Articles.Pool().Exec(ctx, `
-		UPDATE articles
-		SET clean_text = CASE WHEN clean_text = '' OR clean_text IS NULL THEN $1 ELSE clean_text END,
-		    title = CASE WHEN $2 != '' THEN $2 ELSE title END,
-		    published_at = COALESCE(published_at, $3)
-		WHERE id = $4
-	`, cleanText, title, pubAt, id)
+// CollectURL stores an inbox article for rawURL like POST /api/collect, or
+// returns the article already stored under it. It reports whether the
+// article is new. The chat agent's collect_url tool calls it.
+func (h *ItemsHandler) CollectURL(ctx context.Context, rawURL, title string) (*models.Article, bool, error) {
+	id, err := h.Articles.IDByURL(ctx, rawURL)
+	if err != nil {
+		return nil, false, err
+	}
+	if id != uuid.Nil {
+		article, err := h.Articles.GetByID(ctx, id)
+		return article, false, err
+	}
+	article, err := h.collect(ctx, collectRequest{URL: rawURL, Title: title})
 	if err != nil {
-		slog.Warn("collect: update content", "id", id, "err", err)
+		return nil, false, err
 	}
+	return article, true, nil
+}
 
-	// Step 4: AI enrichment — summarize, classify, embed.
-	text := cleanText
-	if len(text) > 8000 {
-		text = text[:8000]
+// collect stores an inbox article for req.URL and queues its scrape and
+// enrichment (and Wayback snapshot when req.Archive is set).
+func (h *ItemsHandler) collect(ctx context.Context, req collectRequest) (*models.Article, error) {
+	region := req.Region
+	if region == "" {
+		region = coverage.Current().Region
+	}
+	title := req.Title
+	if title == "" {
+		title = req.URL // placeholder, worker will extract real title later
 	}
 
-	summary, err := h.AI.Summarize(ctx, text)
-	if err != nil {
-		slog.Warn("collect: summarize", "id", id, "err", err)
-		return
+	article := &models.Article{
+		Title:          title,
+		Source:         "manual",
+		URL:            req.URL,
+		CanonicalURL:   req.URL,
+		Region:         region,
+		Status:         "inbox",
+		Summary:        req.Snippet,
+		EvidencePolicy: "ret_3m",
 	}
 
-	tags, err := h.AI.Classify(ctx, text)
-	if err != nil {
-		slog.Warn("collect: classify", "id", id, "err", err)
-		tags = nil
+	if err := h.Articles.Create(ctx, article); err != nil {
+		return nil, err
 	}
 
-	embedding, err := h.AI.Embed(ctx, text)
-	if err != nil {
-		slog.Warn("collect: embed", "id", id, "err", err)
-		embedding = nil
+	h.queueCollectEnrichment(ctx, article)
+	if req.Archive {
+		scraper.QueueArchiveSnapshot(ctx, h.Jobs, h.Articles, scraper.ArchiveJobPayload{ArticleID: article.ID, URL: article.URL})
 	}
+	return article, nil
+}
 
-	// Only overwrite summary if we got a better one from AI (don't clobber snippet).
-	if summary != "" {
-		if err := h.Articles.UpdateEnrichment(ctx, id, summary, tags, embedding); err != nil {
-			slog.Warn("collect: update enrichment", "id", id, "err", err)
-		}
+// queueCollectEnrichment scrapes and enriches a collected article in the
+// background so it has full data. The work is queued when possible so it
+// survives a restart.
+func (h *ItemsHandler) queueCollectEnrichment(ctx context.Context, article *models.Article) {
+	if h.Scraper == nil || h.AI == nil {
+		return
+	}
+	payload := scraper.CollectJobPayload{ArticleID: article.ID, URL: article.URL}
+	if h.Jobs == nil {
+		go h.enrichCollectedArticle(payload)
+	} else if err := h.Jobs.Enqueue(ctx, models.JobCollectArticle, payload); err != nil {
+		slog.Error("collect item: enqueue enrichment", "id", article.ID, "err", err)
+		go h.enrichCollectedArticle(payload)
 	}
+}
 
-	slog.Info("collect: enrichment complete", "id", id)
+// enrichCollectedArticle runs collected-item enrichment inline, for when no
+// job queue is available.
+func (h *ItemsHandler) enrichCollectedArticle(p scraper.CollectJobPayload) {
+	ctx, cancel := context.WithTimeout(backgroundContext(h.Background), 90*time.Second)
+	defer cancel()
+
+	if err := scraper.EnrichCollected(ctx, h.Articles, h.Scraper, h.AI, p.ArticleID, p.URL); err != nil {
+		slog.Warn("collect: enrichment failed", "id", p.ArticleID, "err", err)
+	}
 }
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Saul-Punybz/folio/internal/logging"
+	"github.com/Saul-Punybz/folio/internal/middleware"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// maxLogOverride caps how long an override lasts, so a forgotten debug
+// level does not flood the logs for days.
+const maxLogOverride = 24 * time.Hour
+
+// LogLevelHandler groups the runtime log level HTTP handlers.
+type LogLevelHandler struct {
+	Levels *models.LogLevelStore
+}
+
+// List handles GET /api/admin/log-levels.
+// Returns the active overrides and the level this process logs at.
+func (h *LogLevelHandler) List(w http.ResponseWriter, r *http.Request) {
+	overrides, err := h.Levels.List(r.Context())
+	if err != nil {
+		slog.Error("list log level overrides", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if overrides == nil {
+		overrides = []models.LogLevelOverride{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"overrides": overrides,
+		"services":  logging.Services,
+		"current": map[string]string{
+			"service": logging.Service(),
+			"level":   logging.LevelName(logging.Level()),
+			"base":    logging.LevelName(logging.BaseLevel()),
+		},
+	})
+}
+
+// Set handles PUT /api/admin/log-levels/{service}.
+// Body: { "level": "debug", "minutes": 30 }. The service logs at level until
+// the override expires (default 30 minutes, at most 24 hours); other
+// processes pick it up within a minute.
+func (h *LogLevelHandler) Set(w http.ResponseWriter, r *http.Request) {
+	service := chi.URLParam(r, "service")
+	if !slices.Contains(logging.Services, service) {
+		writeError(w, r, http.StatusNotFound, "unknown service")
+		return
+	}
+
+	var req struct {
+		Level   string `json:"level"`
+		Minutes int    `json:"minutes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	lvl, err := logging.ParseLevel(req.Level)
+	if err != nil || req.Level == "" {
+		writeError(w, r, http.StatusBadRequest, "level must be debug, info, warn, or error")
+		return
+	}
+	if req.Minutes == 0 {
+		req.Minutes = 30
+	}
+	d := time.Duration(req.Minutes) * time.Minute
+	if d <= 0 || d > maxLogOverride {
+		writeError(w, r, http.StatusBadRequest, "minutes must be between 1 and 1440")
+		return
+	}
+
+	o := &models.LogLevelOverride{
+		Service:   service,
+		Level:     logging.LevelName(lvl),
+		ExpiresAt: time.Now().Add(d),
+	}
+	if user := middleware.UserFromContext(r.Context()); user != nil {
+		o.SetBy = &user.ID
+	}
+	if err := h.Levels.Set(r.Context(), o); err != nil {
+		slog.Error("set log level override", "service", service, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	slog.Info("log level override set", "service", service, "level", o.Level, "expires_at", o.ExpiresAt)
+	if service == logging.Service() {
+		logging.Refresh(r.Context(), h.Levels)
+	}
+	writeJSON(w, http.StatusOK, o)
+}
+
+// Delete handles DELETE /api/admin/log-levels/{service}, returning the
+// service to its configured level.
+func (h *LogLevelHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	service := chi.URLParam(r, "service")
+	if !slices.Contains(logging.Services, service) {
+		writeError(w, r, http.StatusNotFound, "unknown service")
+		return
+	}
+	if err := h.Levels.Delete(r.Context(), service); err != nil {
+		slog.Error("delete log level override", "service", service, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if service == logging.Service() {
+		logging.Refresh(r.Context(), h.Levels)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
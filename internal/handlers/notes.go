@@ -23,14 +23,14 @@ type NotesHandler struct {
 func (h *NotesHandler) ListNotes(w http.ResponseWriter, r *http.Request) {
 	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid article id"})
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
 		return
 	}
 
 	notes, err := h.Notes.ListByArticle(r.Context(), articleID)
 	if err != nil {
 		slog.Error("list notes", "article_id", articleID, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -53,30 +53,30 @@ type createNoteRequest struct {
 func (h *NotesHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
 	articleID, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid article id"})
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
 		return
 	}
 
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	var req createNoteRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if req.Content == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "content is required"})
+		writeError(w, r, http.StatusBadRequest, "content is required")
 		return
 	}
 
 	// Verify the article exists.
 	if _, err := h.Articles.GetByID(r.Context(), articleID); err != nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "article not found"})
+		writeError(w, r, http.StatusNotFound, "article not found")
 		return
 	}
 
@@ -88,7 +88,7 @@ func (h *NotesHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
 
 	if err := h.Notes.Create(r.Context(), note); err != nil {
 		slog.Error("create note", "article_id", articleID, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not create note"})
+		writeError(w, r, http.StatusInternalServerError, "could not create note")
 		return
 	}
 
@@ -100,32 +100,32 @@ func (h *NotesHandler) CreateNote(w http.ResponseWriter, r *http.Request) {
 func (h *NotesHandler) DeleteNote(w http.ResponseWriter, r *http.Request) {
 	noteID, err := uuid.Parse(chi.URLParam(r, "noteId"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid note id"})
+		writeError(w, r, http.StatusBadRequest, "invalid note id")
 		return
 	}
 
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	// Get the note to check ownership.
 	note, err := h.Notes.GetByID(r.Context(), noteID)
 	if err != nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "note not found"})
+		writeError(w, r, http.StatusNotFound, "note not found")
 		return
 	}
 
 	// Only the author or an admin can delete.
 	if note.UserID != user.ID && user.Role != "admin" {
-		writeJSON(w, http.StatusForbidden, map[string]string{"error": "forbidden"})
+		writeError(w, r, http.StatusForbidden, "forbidden")
 		return
 	}
 
 	if err := h.Notes.Delete(r.Context(), noteID); err != nil {
 		slog.Error("delete note", "note_id", noteID, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not delete note"})
+		writeError(w, r, http.StatusInternalServerError, "could not delete note")
 		return
 	}
 
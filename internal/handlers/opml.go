@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
+)
+
+// maxOPMLSize caps an uploaded OPML file. Reader exports with a few hundred
+// feeds are well under this.
+const maxOPMLSize = 2 << 20
+
+// opmlDoc is an OPML 2.0 subscription list. Outlines nest when the exporting
+// reader groups feeds into folders.
+type opmlDoc struct {
+	XMLName xml.Name `xml:"opml"`
+	Version string   `xml:"version,attr"`
+	Head    struct {
+		Title       string `xml:"title"`
+		DateCreated string `xml:"dateCreated,omitempty"`
+	} `xml:"head"`
+	Body struct {
+		Outlines []opmlOutline `xml:"outline"`
+	} `xml:"body"`
+}
+
+type opmlOutline struct {
+	Text     string        `xml:"text,attr"`
+	Title    string        `xml:"title,attr,omitempty"`
+	Type     string        `xml:"type,attr,omitempty"`
+	XMLURL   string        `xml:"xmlUrl,attr,omitempty"`
+	HTMLURL  string        `xml:"htmlUrl,attr,omitempty"`
+	Outlines []opmlOutline `xml:"outline"`
+}
+
+// ImportOPML handles POST /api/sources/import?region=PR.
+// The OPML file is sent as the request body or as the "file" field of a
+// multipart form. Every feed outline becomes an active source in the given
+// region (default PR); feeds whose URL already has a source are skipped.
+func (h *SourcesHandler) ImportOPML(w http.ResponseWriter, r *http.Request) {
+	data, err := readUpload(w, r, maxOPMLSize)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	var doc opmlDoc
+	if err := xml.Unmarshal(scraper.ToUTF8(data, r.Header.Get("Content-Type")), &doc); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid OPML: %v", err)
+		return
+	}
+
+	region := r.URL.Query().Get("region")
+	if region == "" {
+		region = coverage.Current().Region
+	}
+	sources := opmlSources(doc.Body.Outlines, region)
+	if len(sources) == 0 {
+		writeError(w, r, http.StatusBadRequest, "no feeds found in OPML")
+		return
+	}
+
+	result, err := h.Sources.ImportFeeds(r.Context(), sources)
+	if err != nil {
+		slog.Error("import opml", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not import sources")
+		return
+	}
+
+	slog.Info("opml imported", "feeds", len(sources), "created", len(result.Created), "skipped", len(result.Skipped))
+	writeJSON(w, http.StatusOK, result)
+}
+
+// ExportOPML handles GET /api/sources/export.opml — every RSS and JSON Feed
+// source as an OPML file, for backups or moving to a feed reader. Scrape and
+// partner API sources have no feed URL and are left out, as are archived
+// sources.
+func (h *SourcesHandler) ExportOPML(w http.ResponseWriter, r *http.Request) {
+	sources, err := h.Sources.ListUnarchived(r.Context())
+	if err != nil {
+		slog.Error("export opml", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	doc := opmlDoc{Version: "2.0"}
+	doc.Head.Title = "Folio sources"
+	doc.Head.DateCreated = time.Now().UTC().Format(time.RFC1123Z)
+	for _, src := range sources {
+		if src.FeedURL == "" || (src.FeedType != "rss" && src.FeedType != "jsonfeed") {
+			continue
+		}
+		doc.Body.Outlines = append(doc.Body.Outlines, opmlOutline{
+			Text:    src.Name,
+			Title:   src.Name,
+			Type:    src.FeedType,
+			XMLURL:  src.FeedURL,
+			HTMLURL: src.BaseURL,
+		})
+	}
+
+	out, err := xml.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		slog.Error("export opml: marshal", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/x-opml; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="folio-sources-%s.opml"`, time.Now().Format("2006-01-02")))
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte(xml.Header))
+	w.Write(out)
+}
+
+// opmlSources flattens the outline tree into feed sources. Folder outlines
+// (no xmlUrl) only contribute their children.
+func opmlSources(outlines []opmlOutline, region string) []models.Source {
+	var sources []models.Source
+	for _, o := range outlines {
+		if feedURL := strings.TrimSpace(o.XMLURL); feedURL != "" {
+			parsed, err := url.Parse(feedURL)
+			if err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "" {
+				sources = append(sources, opmlSource(o, feedURL, parsed, region))
+			}
+		}
+		sources = append(sources, opmlSources(o.Outlines, region)...)
+	}
+	return sources
+}
+
+func opmlSource(o opmlOutline, feedURL string, parsed *url.URL, region string) models.Source {
+	src := models.Source{
+		Name:     strings.TrimSpace(o.Title),
+		BaseURL:  fmt.Sprintf("%s://%s", parsed.Scheme, parsed.Host),
+		Region:   region,
+		FeedType: "rss",
+		FeedURL:  feedURL,
+		Active:   true,
+	}
+	if strings.EqualFold(o.Type, "jsonfeed") {
+		src.FeedType = "jsonfeed"
+	}
+	if src.Name == "" {
+		src.Name = strings.TrimSpace(o.Text)
+	}
+	if src.Name == "" {
+		src.Name = parsed.Host
+	}
+	if site, err := url.Parse(strings.TrimSpace(o.HTMLURL)); err == nil && site.Host != "" && (site.Scheme == "http" || site.Scheme == "https") {
+		src.BaseURL = fmt.Sprintf("%s://%s", site.Scheme, site.Host)
+	}
+	return src
+}
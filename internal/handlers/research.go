@@ -22,7 +22,7 @@ type ResearchHandler struct {
 	Projects *models.ResearchProjectStore
 	Findings *models.ResearchFindingStore
 	Articles *models.ArticleStore
-	AI       *ai.OllamaClient
+	AI       ai.Provider
 }
 
 type createResearchRequest struct {
@@ -34,19 +34,19 @@ type createResearchRequest struct {
 func (h *ResearchHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	var req createResearchRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	topic := strings.TrimSpace(req.Topic)
 	if topic == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "topic is required"})
+		writeError(w, r, http.StatusBadRequest, "topic is required")
 		return
 	}
 
@@ -62,7 +62,7 @@ func (h *ResearchHandler) CreateProject(w http.ResponseWriter, r *http.Request)
 
 	if err := h.Projects.Create(r.Context(), project); err != nil {
 		slog.Error("create research project", "user_id", user.ID, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not create project"})
+		writeError(w, r, http.StatusInternalServerError, "could not create project")
 		return
 	}
 
@@ -81,14 +81,14 @@ func (h *ResearchHandler) CreateProject(w http.ResponseWriter, r *http.Request)
 func (h *ResearchHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	projects, err := h.Projects.ListByUser(r.Context(), user.ID)
 	if err != nil {
 		slog.Error("list research projects", "user_id", user.ID, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	if projects == nil {
@@ -113,23 +113,23 @@ func (h *ResearchHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
 func (h *ResearchHandler) GetProject(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid project id"})
+		writeError(w, r, http.StatusBadRequest, "invalid project id")
 		return
 	}
 
 	project, err := h.Projects.GetByID(r.Context(), id)
 	if err != nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "project not found"})
+		writeError(w, r, http.StatusNotFound, "project not found")
 		return
 	}
 	if project.UserID != user.ID {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "project not found"})
+		writeError(w, r, http.StatusNotFound, "project not found")
 		return
 	}
 
@@ -145,20 +145,20 @@ func (h *ResearchHandler) GetProject(w http.ResponseWriter, r *http.Request) {
 func (h *ResearchHandler) GetFindings(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid project id"})
+		writeError(w, r, http.StatusBadRequest, "invalid project id")
 		return
 	}
 
 	// Verify ownership
 	project, err := h.Projects.GetByID(r.Context(), id)
 	if err != nil || project.UserID != user.ID {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "project not found"})
+		writeError(w, r, http.StatusNotFound, "project not found")
 		return
 	}
 
@@ -179,7 +179,7 @@ func (h *ResearchHandler) GetFindings(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		slog.Error("list research findings", "project_id", id, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	if findings == nil {
@@ -193,20 +193,20 @@ func (h *ResearchHandler) GetFindings(w http.ResponseWriter, r *http.Request) {
 func (h *ResearchHandler) StopProject(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid project id"})
+		writeError(w, r, http.StatusBadRequest, "invalid project id")
 		return
 	}
 
 	// Verify ownership
 	project, err := h.Projects.GetByID(r.Context(), id)
 	if err != nil || project.UserID != user.ID {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "project not found"})
+		writeError(w, r, http.StatusNotFound, "project not found")
 		return
 	}
 
@@ -222,25 +222,25 @@ func (h *ResearchHandler) StopProject(w http.ResponseWriter, r *http.Request) {
 func (h *ResearchHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid project id"})
+		writeError(w, r, http.StatusBadRequest, "invalid project id")
 		return
 	}
 
 	// Verify ownership
 	project, err := h.Projects.GetByID(r.Context(), id)
 	if err != nil || project.UserID != user.ID {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "project not found"})
+		writeError(w, r, http.StatusNotFound, "project not found")
 		return
 	}
 
 	if err := h.Projects.Delete(r.Context(), id); err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not delete project"})
+		writeError(w, r, http.StatusInternalServerError, "could not delete project")
 		return
 	}
 
@@ -251,24 +251,24 @@ func (h *ResearchHandler) DeleteProject(w http.ResponseWriter, r *http.Request)
 func (h *ResearchHandler) TriggerResearch(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid project id"})
+		writeError(w, r, http.StatusBadRequest, "invalid project id")
 		return
 	}
 
 	project, err := h.Projects.GetByID(r.Context(), id)
 	if err != nil || project.UserID != user.ID {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "project not found"})
+		writeError(w, r, http.StatusNotFound, "project not found")
 		return
 	}
 
 	if project.Status != "queued" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "project is not in queued state"})
+		writeError(w, r, http.StatusBadRequest, "project is not in queued state")
 		return
 	}
 
@@ -281,6 +281,6 @@ func (h *ResearchHandler) TriggerResearch(w http.ResponseWriter, r *http.Request
 
 	writeJSON(w, http.StatusAccepted, map[string]string{
 		"status":  "started",
-		"message": "Investigacion iniciada. Los resultados apareceran en minutos.",
+		"message": localize(r, "Research started. Results will appear within minutes."),
 	})
 }
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// retentionPreviewLimit caps the sample articles returned by a dry run.
+const retentionPreviewLimit = 20
+
+// RetentionRulesHandler groups retention rule HTTP handlers.
+type RetentionRulesHandler struct {
+	Rules *models.RetentionRuleStore
+}
+
+type retentionRuleRequest struct {
+	Name       string `json:"name"`
+	Tag        string `json:"tag"`
+	Source     string `json:"source"`
+	FromStatus string `json:"from_status"`
+	MinAgeDays int    `json:"min_age_days"`
+	Action     string `json:"action"`
+	Active     *bool  `json:"active"`
+}
+
+// toRule validates the request and converts it into a rule. It returns a
+// user-facing error message when the request is invalid.
+func (req *retentionRuleRequest) toRule() (*models.RetentionRule, string) {
+	rule := &models.RetentionRule{
+		Name:       strings.TrimSpace(req.Name),
+		Tag:        strings.TrimSpace(req.Tag),
+		Source:     strings.TrimSpace(req.Source),
+		FromStatus: req.FromStatus,
+		MinAgeDays: req.MinAgeDays,
+		Action:     req.Action,
+		Active:     true,
+	}
+	if req.Active != nil {
+		rule.Active = *req.Active
+	}
+	if rule.FromStatus == "" {
+		rule.FromStatus = "inbox"
+	}
+
+	switch {
+	case rule.Tag == "" && rule.Source == "":
+		return nil, "tag or source is required"
+	case rule.FromStatus != "inbox" && rule.FromStatus != "saved":
+		return nil, "from_status must be inbox or saved"
+	case rule.Action != "trash" && rule.Action != "save":
+		return nil, "action must be trash or save"
+	case rule.TargetStatus() == rule.FromStatus:
+		return nil, "action would not change the status"
+	case rule.MinAgeDays < 0:
+		return nil, "min_age_days must not be negative"
+	}
+	if rule.Name == "" {
+		rule.Name = rule.Action + " " + rule.Tag + rule.Source
+	}
+	return rule, ""
+}
+
+// ListRules handles GET /api/retention-rules.
+func (h *RetentionRulesHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	rules, err := h.Rules.List(r.Context(), false)
+	if err != nil {
+		slog.Error("list retention rules", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if rules == nil {
+		rules = []models.RetentionRule{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"rules": rules, "count": len(rules)})
+}
+
+// CreateRule handles POST /api/retention-rules.
+// Body: { "name", "tag", "source", "from_status", "min_age_days", "action", "active" }
+func (h *RetentionRulesHandler) CreateRule(w http.ResponseWriter, r *http.Request) {
+	var req retentionRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	rule, msg := req.toRule()
+	if rule == nil {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+
+	if err := h.Rules.Create(r.Context(), rule); err != nil {
+		slog.Error("create retention rule", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not create rule")
+		return
+	}
+	writeJSON(w, http.StatusCreated, rule)
+}
+
+// UpdateRule handles PUT /api/retention-rules/{id}.
+func (h *RetentionRulesHandler) UpdateRule(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid rule id")
+		return
+	}
+
+	var req retentionRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	rule, msg := req.toRule()
+	if rule == nil {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+	rule.ID = id
+
+	if err := h.Rules.Update(r.Context(), rule); err != nil {
+		writeError(w, r, http.StatusNotFound, "rule not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, rule)
+}
+
+// DeleteRule handles DELETE /api/retention-rules/{id}.
+func (h *RetentionRulesHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid rule id")
+		return
+	}
+
+	if err := h.Rules.Delete(r.Context(), id); err != nil {
+		writeError(w, r, http.StatusNotFound, "rule not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// PreviewRule handles GET /api/retention-rules/{id}/preview.
+// Dry run of a saved rule: what the next daily run would move.
+func (h *RetentionRulesHandler) PreviewRule(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid rule id")
+		return
+	}
+
+	rule, err := h.Rules.GetByID(r.Context(), id)
+	if err != nil {
+		writeError(w, r, http.StatusNotFound, "rule not found")
+		return
+	}
+	h.writePreview(w, r, rule)
+}
+
+// PreviewDraft handles POST /api/retention-rules/preview.
+// Dry run of an unsaved rule, so the UI can show its effect before creating it.
+func (h *RetentionRulesHandler) PreviewDraft(w http.ResponseWriter, r *http.Request) {
+	var req retentionRuleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	rule, msg := req.toRule()
+	if rule == nil {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+	h.writePreview(w, r, rule)
+}
+
+func (h *RetentionRulesHandler) writePreview(w http.ResponseWriter, r *http.Request, rule *models.RetentionRule) {
+	total, sample, err := h.Rules.Preview(r.Context(), rule, retentionPreviewLimit)
+	if err != nil {
+		slog.Error("preview retention rule", "rule", rule.Name, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if sample == nil {
+		sample = []models.Article{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"rule":          rule,
+		"would_move":    total,
+		"target_status": rule.TargetStatus(),
+		"sample":        sample,
+	})
+}
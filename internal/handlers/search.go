@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"errors"
 	"log/slog"
 	"net/http"
 	"strconv"
@@ -9,28 +10,55 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/flags"
 	"github.com/Saul-Punybz/folio/internal/models"
 )
 
 // SearchHandler groups search-related HTTP handlers.
 type SearchHandler struct {
 	Articles *models.ArticleStore
+	AI       ai.Provider // query embeddings for semantic/hybrid modes
 }
 
-// Search handles GET /api/search?q=&from=&to=&region=&status=&tag=&limit=&offset=.
+// Search handles GET /api/search?q=&from=&to=&region=&status=&tag=&scope=&lang=&limit=&offset=&mode=.
+//
+// scope filters by article scope: local, federal, or diaspora. lang filters
+// by detected language (es or en) and parses q with that language's stemmer.
+//
+// Full-text results can be paged with cursor (the previous page's
+// next_cursor) instead of offset; total=true adds the number of matches.
+//
+// mode selects the ranking strategy:
+//   - "fulltext" (default): Postgres full-text matching ranked by ts_rank
+//   - "semantic": embeds q and ranks by pgvector cosine distance
+//   - "hybrid": blends ts_rank and vector similarity (weight=0..1, default 0.5)
+//
+// semantic and hybrid require the semantic_search feature flag.
 func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 	q := r.URL.Query().Get("q")
+	mode := r.URL.Query().Get("mode")
 	fromStr := r.URL.Query().Get("from")
 	toStr := r.URL.Query().Get("to")
 	region := r.URL.Query().Get("region")
 	status := r.URL.Query().Get("status")
 	tag := r.URL.Query().Get("tag")
+	scope := r.URL.Query().Get("scope")
+	lang := r.URL.Query().Get("lang")
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
 
 	if limit <= 0 {
 		limit = 50
 	}
+	if scope != "" && !models.ValidScope(scope) {
+		writeError(w, r, http.StatusBadRequest, "invalid scope, use local, federal, or diaspora")
+		return
+	}
+	if lang != "" && !models.ValidLanguage(lang) {
+		writeError(w, r, http.StatusBadRequest, "invalid lang, use es or en")
+		return
+	}
 
 	var from, to time.Time
 	if fromStr != "" {
@@ -39,7 +67,7 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 			// Try date-only format.
 			parsed, err = time.Parse("2006-01-02", fromStr)
 			if err != nil {
-				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid 'from' date, use RFC3339 or YYYY-MM-DD"})
+				writeError(w, r, http.StatusBadRequest, "invalid 'from' date, use RFC3339 or YYYY-MM-DD")
 				return
 			}
 		}
@@ -50,40 +78,148 @@ func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			parsed, err = time.Parse("2006-01-02", toStr)
 			if err != nil {
-				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid 'to' date, use RFC3339 or YYYY-MM-DD"})
+				writeError(w, r, http.StatusBadRequest, "invalid 'to' date, use RFC3339 or YYYY-MM-DD")
 				return
 			}
 		}
 		to = parsed
 	}
 
-	articles, err := h.Articles.Search(r.Context(), q, from, to, region, status, tag, limit, offset)
+	filters := models.SearchFilters{
+		From: from, To: to, Region: region, Status: status, Tag: tag, Scope: scope, Language: lang,
+	}
+	cursor, err := models.DecodeArticleCursor(r.URL.Query().Get("cursor"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid cursor")
+		return
+	}
+	if mode == "semantic" || mode == "hybrid" {
+		if !flags.Enabled(r.Context(), flags.SemanticSearch) {
+			writeError(w, r, http.StatusForbidden, "%s search is not enabled for your account", mode)
+			return
+		}
+		if cursor != nil {
+			writeError(w, r, http.StatusBadRequest, "cursor is only supported for fulltext search, use offset")
+			return
+		}
+		h.vectorSearch(w, r, mode, q, filters, limit, offset)
+		return
+	}
+	if mode != "" && mode != "fulltext" {
+		writeError(w, r, http.StatusBadRequest, "invalid mode, use fulltext, semantic, or hybrid")
+		return
+	}
+
+	var articles []models.Article
+	var next *models.ArticleCursor
+	if cursor != nil || offset == 0 {
+		var page *models.ArticlePage
+		page, err = h.Articles.SearchAfter(r.Context(), q, filters, cursor, limit)
+		if page != nil {
+			articles, next = page.Articles, page.Next
+		}
+		offset = 0
+	} else {
+		articles, err = h.Articles.Search(r.Context(), q, filters, limit, offset)
+	}
+	if errors.Is(err, models.ErrInvalidCursor) {
+		writeError(w, r, http.StatusBadRequest, "cursor does not match this search")
+		return
+	}
 	if err != nil {
 		slog.Error("search", "query", q, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "search failed"})
+		writeError(w, r, http.StatusInternalServerError, "search failed")
+		return
+	}
+
+	if articles == nil {
+		articles = []models.Article{}
+	}
+
+	resp := map[string]any{
+		"results":     articles,
+		"count":       len(articles),
+		"query":       q,
+		"mode":        "fulltext",
+		"limit":       limit,
+		"offset":      offset,
+		"next_cursor": next.Encode(),
+	}
+	if wantTotal(r) {
+		total, err := h.Articles.SearchCount(r.Context(), q, filters)
+		if err != nil {
+			slog.Error("search: count", "query", q, "err", err)
+			writeError(w, r, http.StatusInternalServerError, "search failed")
+			return
+		}
+		resp["total"] = total
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// vectorSearch serves the semantic and hybrid search modes, which both need
+// the query embedded first.
+func (h *SearchHandler) vectorSearch(w http.ResponseWriter, r *http.Request, mode, q string, filters models.SearchFilters, limit, offset int) {
+	if q == "" {
+		writeError(w, r, http.StatusBadRequest, "q is required for %s search", mode)
+		return
+	}
+	if h.AI == nil {
+		writeError(w, r, http.StatusServiceUnavailable, "AI not configured")
+		return
+	}
+
+	embedding, err := h.AI.Embed(r.Context(), q)
+	if err != nil {
+		slog.Error("search: embed query", "query", q, "err", err)
+		writeError(w, r, http.StatusBadGateway, "could not embed query")
+		return
+	}
+
+	var articles []models.Article
+	var scores []float64
+	if mode == "semantic" {
+		articles, scores, err = h.Articles.SemanticSearch(r.Context(), embedding, filters, limit, offset)
+	} else {
+		weight := 0.5
+		if ws := r.URL.Query().Get("weight"); ws != "" {
+			if parsed, perr := strconv.ParseFloat(ws, 64); perr == nil && parsed >= 0 && parsed <= 1 {
+				weight = parsed
+			}
+		}
+		articles, scores, err = h.Articles.HybridSearch(r.Context(), q, embedding, filters, weight, limit, offset)
+	}
+	if err != nil {
+		slog.Error("search", "mode", mode, "query", q, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "search failed")
 		return
 	}
 
 	if articles == nil {
 		articles = []models.Article{}
+		scores = []float64{}
 	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
 		"results": articles,
+		"scores":  scores,
 		"count":   len(articles),
 		"query":   q,
+		"mode":    mode,
 		"limit":   limit,
 		"offset":  offset,
 	})
 }
 
-// Similar handles GET /api/items/{id}/similar?limit=5.
-// Returns articles similar to the given article based on embedding cosine distance.
+// Similar handles GET /api/items/{id}/similar?limit=5&min_similarity=0.6.
+// Returns articles similar to the given article based on embedding cosine
+// distance. Each result carries its distance, similarity, and the tags and
+// entities it shares with the article; min_similarity (0-1) drops weak matches.
 func (h *SearchHandler) Similar(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := uuid.Parse(idStr)
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid article id"})
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
 		return
 	}
 
@@ -95,19 +231,30 @@ func (h *SearchHandler) Similar(w http.ResponseWriter, r *http.Request) {
 		limit = 20
 	}
 
-	articles, err := h.Articles.SimilarArticles(r.Context(), id, limit)
+	var minSimilarity float64
+	if ms := r.URL.Query().Get("min_similarity"); ms != "" {
+		parsed, perr := strconv.ParseFloat(ms, 64)
+		if perr != nil || parsed < 0 || parsed > 1 {
+			writeError(w, r, http.StatusBadRequest, "min_similarity must be between 0 and 1")
+			return
+		}
+		minSimilarity = parsed
+	}
+
+	results, err := h.Articles.SimilarArticles(r.Context(), id, limit, minSimilarity)
 	if err != nil {
 		slog.Error("similar articles", "id", id, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not find similar articles"})
+		writeError(w, r, http.StatusInternalServerError, "could not find similar articles")
 		return
 	}
 
-	if articles == nil {
-		articles = []models.Article{}
+	if results == nil {
+		results = []models.SimilarArticle{}
 	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
-		"results": articles,
-		"count":   len(articles),
+		"results":        results,
+		"count":          len(results),
+		"min_similarity": minSimilarity,
 	})
 }
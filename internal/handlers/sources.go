@@ -3,6 +3,7 @@ package handlers
 import (
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -16,23 +17,31 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/scraper"
+	"github.com/Saul-Punybz/folio/internal/useragent"
 )
 
 // SourcesHandler groups source management HTTP handlers.
 type SourcesHandler struct {
 	Sources *models.SourceStore
+	Bundles *models.SourceBundleStore
 	Scraper *scraper.Scraper
-	AI      *ai.OllamaClient
+	AI      ai.Provider
 }
 
 // ListSources handles GET /api/sources — returns ALL sources (active and inactive).
 func (h *SourcesHandler) ListSources(w http.ResponseWriter, r *http.Request) {
-	sources, err := h.Sources.ListAll(r.Context())
+	list := h.Sources.ListUnarchived
+	if r.URL.Query().Get("include_archived") == "true" {
+		list = h.Sources.ListAll
+	}
+	sources, err := list(r.Context())
 	if err != nil {
 		slog.Error("list sources", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -50,18 +59,22 @@ func (h *SourcesHandler) ListSources(w http.ResponseWriter, r *http.Request) {
 func (h *SourcesHandler) CreateSource(w http.ResponseWriter, r *http.Request) {
 	var src models.Source
 	if err := json.NewDecoder(r.Body).Decode(&src); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if src.Name == "" || src.BaseURL == "" || src.Region == "" || src.FeedType == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "name, base_url, region, and feed_type are required"})
+		writeError(w, r, http.StatusBadRequest, "name, base_url, region, and feed_type are required")
+		return
+	}
+	if msg := validateAPISource(&src); msg != "" {
+		writeError(w, r, http.StatusBadRequest, msg)
 		return
 	}
 
 	if err := h.Sources.Create(r.Context(), &src); err != nil {
 		slog.Error("create source", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not create source"})
+		writeError(w, r, http.StatusInternalServerError, "could not create source")
 		return
 	}
 
@@ -72,32 +85,51 @@ func (h *SourcesHandler) CreateSource(w http.ResponseWriter, r *http.Request) {
 func (h *SourcesHandler) UpdateSource(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid source id"})
+		writeError(w, r, http.StatusBadRequest, "invalid source id")
 		return
 	}
 
 	var src models.Source
 	if err := json.NewDecoder(r.Body).Decode(&src); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	src.ID = id
+	if msg := validateAPISource(&src); msg != "" {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
 
 	if err := h.Sources.Update(r.Context(), &src); err != nil {
 		slog.Error("update source", "id", id, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not update source"})
+		writeError(w, r, http.StatusInternalServerError, "could not update source")
 		return
 	}
 
 	writeJSON(w, http.StatusOK, src)
 }
 
+// validateAPISource checks the feed_url and api_mapping of a feed_type "api"
+// source, returning a user-facing error message or "".
+func validateAPISource(src *models.Source) string {
+	if src.FeedType != "api" {
+		return ""
+	}
+	if src.FeedURL == "" {
+		return "feed_url is required for api sources"
+	}
+	if src.APIMapping == nil {
+		return "api_mapping is required for api sources"
+	}
+	return src.APIMapping.Validate()
+}
+
 // ToggleSource handles PATCH /api/sources/{id}/toggle.
 func (h *SourcesHandler) ToggleSource(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid source id"})
+		writeError(w, r, http.StatusBadRequest, "invalid source id")
 		return
 	}
 
@@ -105,13 +137,22 @@ func (h *SourcesHandler) ToggleSource(w http.ResponseWriter, r *http.Request) {
 		Active bool `json:"active"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
-	if err := h.Sources.ToggleActive(r.Context(), id, body.Active); err != nil {
+	err = h.Sources.ToggleActive(r.Context(), id, body.Active)
+	if errors.Is(err, models.ErrSourceNotFound) {
+		writeError(w, r, http.StatusNotFound, "source not found")
+		return
+	}
+	if errors.Is(err, models.ErrSourceArchived) {
+		writeError(w, r, http.StatusConflict, "source is archived; unarchive it first")
+		return
+	}
+	if err != nil {
 		slog.Error("toggle source", "id", id, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not toggle source"})
+		writeError(w, r, http.StatusInternalServerError, "could not toggle source")
 		return
 	}
 
@@ -119,22 +160,126 @@ func (h *SourcesHandler) ToggleSource(w http.ResponseWriter, r *http.Request) {
 }
 
 // DeleteSource handles DELETE /api/sources/{id}.
+// Sources that articles are attributed to cannot be deleted (409); archive
+// them instead.
 func (h *SourcesHandler) DeleteSource(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid source id"})
+		writeError(w, r, http.StatusBadRequest, "invalid source id")
 		return
 	}
 
-	if err := h.Sources.Delete(r.Context(), id); err != nil {
+	err = h.Sources.Delete(r.Context(), id)
+	if errors.Is(err, models.ErrSourceNotFound) {
+		writeError(w, r, http.StatusNotFound, "source not found")
+		return
+	}
+	if errors.Is(err, models.ErrSourceInUse) {
+		writeError(w, r, http.StatusConflict, "source has articles attributed to it; archive it instead")
+		return
+	}
+	if err != nil {
 		slog.Error("delete source", "id", id, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not delete source"})
+		writeError(w, r, http.StatusInternalServerError, "could not delete source")
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ArchiveSource handles POST /api/sources/{id}/archive.
+// Deactivates the source and hides it from ingestion and source lists while
+// keeping it for the attribution of its articles.
+func (h *SourcesHandler) ArchiveSource(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid source id")
+		return
+	}
+
+	archivedAt, err := h.Sources.Archive(r.Context(), id)
+	if errors.Is(err, models.ErrSourceNotFound) {
+		writeError(w, r, http.StatusNotFound, "source not found")
+		return
+	}
+	if err != nil {
+		slog.Error("archive source", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not archive source")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "active": false, "archived_at": archivedAt})
+}
+
+// UnarchiveSource handles DELETE /api/sources/{id}/archive.
+// The source comes back inactive; toggle it on to resume ingestion.
+func (h *SourcesHandler) UnarchiveSource(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid source id")
+		return
+	}
+
+	err = h.Sources.Unarchive(r.Context(), id)
+	if errors.Is(err, models.ErrSourceNotFound) {
+		writeError(w, r, http.StatusNotFound, "source not found")
+		return
+	}
+	if err != nil {
+		slog.Error("unarchive source", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not unarchive source")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"id": id, "active": false, "archived_at": nil})
+}
+
+// ListBundles handles GET /api/sources/bundles — returns the predefined
+// source bundles available for installation.
+func (h *SourcesHandler) ListBundles(w http.ResponseWriter, r *http.Request) {
+	bundles, err := h.Bundles.List(r.Context())
+	if err != nil {
+		slog.Error("list source bundles", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	if bundles == nil {
+		bundles = []models.SourceBundle{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"bundles": bundles,
+		"count":   len(bundles),
+	})
+}
+
+// InstallBundle handles POST /api/sources/bundles/{slug}/install.
+// Creates every source in the bundle that does not already exist.
+func (h *SourcesHandler) InstallBundle(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+	bundle, err := h.Bundles.GetBySlug(r.Context(), slug)
+	if err != nil {
+		slog.Error("get source bundle", "slug", slug, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if bundle == nil {
+		writeError(w, r, http.StatusNotFound, "bundle not found")
+		return
+	}
+
+	result, err := h.Bundles.Install(r.Context(), bundle)
+	if err != nil {
+		slog.Error("install source bundle", "slug", slug, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not install bundle")
+		return
+	}
+
+	slog.Info("source bundle installed", "slug", slug, "created", len(result.Created), "skipped", len(result.Skipped))
+	writeJSON(w, http.StatusOK, result)
+}
+
 // QuickCreateSource handles POST /api/sources/quick.
 // Accepts just a URL, auto-detects if it's RSS/Atom, and creates a source.
 func (h *SourcesHandler) QuickCreateSource(w http.ResponseWriter, r *http.Request) {
@@ -143,23 +288,23 @@ func (h *SourcesHandler) QuickCreateSource(w http.ResponseWriter, r *http.Reques
 		Region string `json:"region,omitempty"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if body.URL == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "url is required"})
+		writeError(w, r, http.StatusBadRequest, "url is required")
 		return
 	}
 
 	region := body.Region
 	if region == "" {
-		region = "PR"
+		region = coverage.Current().Region
 	}
 
 	parsed, err := url.Parse(body.URL)
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid url"})
+		writeError(w, r, http.StatusBadRequest, "invalid url")
 		return
 	}
 
@@ -180,7 +325,7 @@ func (h *SourcesHandler) QuickCreateSource(w http.ResponseWriter, r *http.Reques
 		FeedType: result.feedType,
 	}
 
-	if result.feedType == "rss" {
+	if result.feedType == "rss" || result.feedType == "jsonfeed" {
 		src.FeedURL = result.feedURL
 		src.Name = result.title
 		if src.Name == "" {
@@ -195,36 +340,37 @@ func (h *SourcesHandler) QuickCreateSource(w http.ResponseWriter, r *http.Reques
 
 	if err := h.Sources.Create(r.Context(), &src); err != nil {
 		slog.Error("quick source: create", "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not create source"})
+		writeError(w, r, http.StatusInternalServerError, "could not create source")
 		return
 	}
 
 	writeJSON(w, http.StatusCreated, map[string]any{
 		"source":    src,
 		"feed_type": result.feedType,
-		"detected":  result.feedType == "rss",
-		"message":   quickSourceMessage(result.feedType),
+		"detected":  result.feedType != "scrape",
+		"message":   localize(r, quickSourceMessage(result.feedType)),
 	})
 }
 
 type probeResult struct {
-	feedType string // "rss" or "scrape"
+	feedType string // "rss", "jsonfeed", or "scrape"
 	feedURL  string // resolved feed URL (might differ from input)
 	title    string // feed title if found
 }
 
 var reRSSLink = regexp.MustCompile(`<link[^>]+type=["']application/(rss|atom)\+xml["'][^>]*>`)
+var reJSONFeedLink = regexp.MustCompile(`<link[^>]+type=["']application/feed\+json["'][^>]*>`)
 var reHrefAttr = regexp.MustCompile(`href=["']([^"']+)["']`)
 var reTitleAttr = regexp.MustCompile(`title=["']([^"']+)["']`)
 
 func probeURL(rawURL string) (*probeResult, error) {
-	client := &http.Client{Timeout: 15 * time.Second}
+	client := &http.Client{Timeout: 15 * time.Second, Transport: fetchlog.Transport(nil, fetchlog.Ingestion)}
 	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("User-Agent", "Folio/1.0")
-	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml, text/xml, text/html")
+	useragent.Apply(req)
+	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/feed+json, application/xml, text/xml, text/html")
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -242,13 +388,20 @@ func probeURL(rawURL string) (*probeResult, error) {
 	}
 
 	ct := resp.Header.Get("Content-Type")
+	bodyBytes = scraper.ToUTF8(bodyBytes, ct)
 
-	// Check if the response itself is XML/RSS/Atom.
-	if isXMLContentType(ct) || looksLikeXML(bodyBytes) {
+	// Check if the response itself is XML/RSS/Atom. The body is sniffed too
+	// because some feeds are served as text/plain or octet-stream.
+	if isXMLContentType(ct) || scraper.SniffFeedFormat(bodyBytes) != "" {
 		title := extractFeedTitle(bodyBytes)
 		return &probeResult{feedType: "rss", feedURL: rawURL, title: title}, nil
 	}
 
+	// Or a JSON Feed.
+	if title, ok := scraper.ProbeJSONFeed(bodyBytes); ok {
+		return &probeResult{feedType: "jsonfeed", feedURL: rawURL, title: title}, nil
+	}
+
 	// It's HTML — look for <link rel="alternate" type="application/rss+xml">
 	if feedURL := findRSSLinkInHTML(string(bodyBytes), rawURL); feedURL != "" {
 		// Try to extract the title from the RSS link tag or fall back to probing
@@ -256,6 +409,12 @@ func probeURL(rawURL string) (*probeResult, error) {
 		return &probeResult{feedType: "rss", feedURL: feedURL, title: title}, nil
 	}
 
+	// Then for <link rel="alternate" type="application/feed+json">.
+	if feedURL := findFeedLinkInHTML(reJSONFeedLink, string(bodyBytes), rawURL); feedURL != "" {
+		title := findFeedLinkTitle(reJSONFeedLink, string(bodyBytes))
+		return &probeResult{feedType: "jsonfeed", feedURL: feedURL, title: title}, nil
+	}
+
 	// No RSS found — treat as scrape
 	return &probeResult{feedType: "scrape"}, nil
 }
@@ -265,11 +424,6 @@ func isXMLContentType(ct string) bool {
 	return strings.Contains(ct, "xml") || strings.Contains(ct, "rss") || strings.Contains(ct, "atom")
 }
 
-func looksLikeXML(data []byte) bool {
-	trimmed := strings.TrimSpace(string(data[:min(500, len(data))]))
-	return strings.HasPrefix(trimmed, "<?xml") || strings.HasPrefix(trimmed, "<rss") || strings.HasPrefix(trimmed, "<feed")
-}
-
 func extractFeedTitle(data []byte) string {
 	// Try RSS
 	type rssProbe struct {
@@ -293,11 +447,27 @@ func extractFeedTitle(data []byte) string {
 		return strings.TrimSpace(atom.Title)
 	}
 
+	// Try RSS 1.0 (RDF)
+	type rdfProbe struct {
+		XMLName xml.Name `xml:"RDF"`
+		Channel struct {
+			Title string `xml:"title"`
+		} `xml:"channel"`
+	}
+	var rdf rdfProbe
+	if err := xml.Unmarshal(data, &rdf); err == nil && rdf.Channel.Title != "" {
+		return strings.TrimSpace(rdf.Channel.Title)
+	}
+
 	return ""
 }
 
 func findRSSLinkInHTML(html, baseURL string) string {
-	matches := reRSSLink.FindAllString(html, 5)
+	return findFeedLinkInHTML(reRSSLink, html, baseURL)
+}
+
+func findFeedLinkInHTML(re *regexp.Regexp, html, baseURL string) string {
+	matches := re.FindAllString(html, 5)
 	for _, m := range matches {
 		href := reHrefAttr.FindStringSubmatch(m)
 		if len(href) >= 2 {
@@ -315,7 +485,11 @@ func findRSSLinkInHTML(html, baseURL string) string {
 }
 
 func findRSSLinkTitle(html string) string {
-	matches := reRSSLink.FindAllString(html, 1)
+	return findFeedLinkTitle(reRSSLink, html)
+}
+
+func findFeedLinkTitle(re *regexp.Regexp, html string) string {
+	matches := re.FindAllString(html, 1)
 	if len(matches) > 0 {
 		title := reTitleAttr.FindStringSubmatch(matches[0])
 		if len(title) >= 2 {
@@ -329,6 +503,9 @@ func quickSourceMessage(feedType string) string {
 	if feedType == "rss" {
 		return "RSS feed detected and source created. Articles will appear on next worker cycle."
 	}
+	if feedType == "jsonfeed" {
+		return "JSON Feed detected and source created. Articles will appear on next worker cycle."
+	}
 	return "No RSS feed detected. Source created as scrape type — configure CSS selectors in Settings > Sources."
 }
 
@@ -337,7 +514,7 @@ func quickSourceMessage(feedType string) string {
 func (h *SourcesHandler) TestScrape(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid source id"})
+		writeError(w, r, http.StatusBadRequest, "invalid source id")
 		return
 	}
 
@@ -346,7 +523,7 @@ func (h *SourcesHandler) TestScrape(w http.ResponseWriter, r *http.Request) {
 	// Get the source
 	sources, err := h.Sources.ListAll(ctx)
 	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to list sources"})
+		writeError(w, r, http.StatusInternalServerError, "failed to list sources")
 		return
 	}
 
@@ -358,12 +535,12 @@ func (h *SourcesHandler) TestScrape(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	if src == nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "source not found"})
+		writeError(w, r, http.StatusNotFound, "source not found")
 		return
 	}
 
-	// For RSS sources, try to parse the feed and return first item info
-	if src.FeedType == "rss" {
+	// For RSS and JSON Feed sources, try to parse the feed and return first item info
+	if src.FeedType == "rss" || src.FeedType == "jsonfeed" {
 		if src.FeedURL == "" {
 			writeJSON(w, http.StatusOK, map[string]any{
 				"success": false,
@@ -371,7 +548,11 @@ func (h *SourcesHandler) TestScrape(w http.ResponseWriter, r *http.Request) {
 			})
 			return
 		}
-		items, err := scraper.ParseFeed(ctx, src.FeedURL)
+		parse := scraper.ParseFeed
+		if src.FeedType == "jsonfeed" {
+			parse = scraper.ParseJSONFeed
+		}
+		items, err := parse(ctx, src.FeedURL)
 		if err != nil {
 			writeJSON(w, http.StatusOK, map[string]any{
 				"success": false,
@@ -397,6 +578,76 @@ func (h *SourcesHandler) TestScrape(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// For partner API sources, fetch and map the response
+	if src.FeedType == "api" {
+		items, _, err := scraper.FetchPartnerAPI(ctx, *src, scraper.FeedValidators{})
+		if err != nil {
+			writeJSON(w, http.StatusOK, map[string]any{
+				"success": false,
+				"error":   fmt.Sprintf("api fetch error: %v", err),
+			})
+			return
+		}
+		if len(items) == 0 {
+			writeJSON(w, http.StatusOK, map[string]any{
+				"success": false,
+				"error":   "api returned 0 items",
+			})
+			return
+		}
+		item := items[0]
+		writeJSON(w, http.StatusOK, map[string]any{
+			"success":     true,
+			"title":       item.Title,
+			"text_length": len(item.Description),
+			"image_found": item.ImageURL != "",
+			"items_count": len(items),
+		})
+		return
+	}
+
+	// For Grants.gov and Federal Register sources, fetch and show the first record
+	if src.FeedType == "grantsgov" || src.FeedType == "federalregister" {
+		var title string
+		var count int
+		var err error
+		if src.FeedType == "grantsgov" {
+			var opps []models.GrantOpportunity
+			opps, err = scraper.FetchGrantsGov(ctx, *src)
+			if len(opps) > 0 {
+				title = opps[0].Title
+			}
+			count = len(opps)
+		} else {
+			var docs []models.FederalRegisterDocument
+			docs, err = scraper.FetchFederalRegister(ctx, *src)
+			if len(docs) > 0 {
+				title = docs[0].Title
+			}
+			count = len(docs)
+		}
+		if err != nil {
+			writeJSON(w, http.StatusOK, map[string]any{
+				"success": false,
+				"error":   fmt.Sprintf("api fetch error: %v", err),
+			})
+			return
+		}
+		if count == 0 {
+			writeJSON(w, http.StatusOK, map[string]any{
+				"success": false,
+				"error":   "api returned 0 records",
+			})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{
+			"success":     true,
+			"title":       title,
+			"items_count": count,
+		})
+		return
+	}
+
 	// For scrape sources, try to scrape links and then one article
 	if src.FeedType == "scrape" {
 		if len(src.ListURLs) == 0 {
@@ -422,7 +673,7 @@ func (h *SourcesHandler) TestScrape(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
-		links, err := h.Scraper.ScrapeLinks(ctx, src.ListURLs[0], src.LinkSelector)
+		links, err := h.Scraper.ScrapeLinks(ctx, src.ListURLs[0], src.LinkSelector, src.RenderJS)
 		if err != nil {
 			writeJSON(w, http.StatusOK, map[string]any{
 				"success": false,
@@ -443,6 +694,7 @@ func (h *SourcesHandler) TestScrape(w http.ResponseWriter, r *http.Request) {
 			TitleSelector: src.TitleSelector,
 			BodySelector:  src.BodySelector,
 			DateSelector:  src.DateSelector,
+			RenderJS:      src.RenderJS,
 		}
 		article, err := h.Scraper.ScrapeArticle(ctx, links[0], selectors)
 		if err != nil {
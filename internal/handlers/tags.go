@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// tagNamePattern matches the names the tags table accepts.
+var tagNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// TagsHandler groups tag taxonomy HTTP handlers.
+type TagsHandler struct {
+	Tags *models.TagStore
+}
+
+type tagRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Active      *bool  `json:"active"`
+}
+
+// ListTags handles GET /api/tags?active=true.
+func (h *TagsHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.Tags.List(r.Context(), r.URL.Query().Get("active") == "true")
+	if err != nil {
+		slog.Error("list tags", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if tags == nil {
+		tags = []models.Tag{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"tags": tags, "count": len(tags)})
+}
+
+// CreateTag handles POST /api/tags.
+// Body: { "name": "luma", "description": "LUMA Energy and the power grid", "active": true }
+func (h *TagsHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	var req tagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	tag := &models.Tag{
+		Name:        strings.ToLower(strings.TrimSpace(req.Name)),
+		Description: strings.TrimSpace(req.Description),
+		Active:      req.Active == nil || *req.Active,
+	}
+	if !tagNamePattern.MatchString(tag.Name) {
+		writeError(w, r, http.StatusBadRequest, "name must be lowercase letters, digits, and hyphens")
+		return
+	}
+
+	err := h.Tags.Create(r.Context(), tag)
+	if errors.Is(err, models.ErrTagExists) {
+		writeError(w, r, http.StatusConflict, "tag already exists")
+		return
+	}
+	if err != nil {
+		slog.Error("create tag", "name", tag.Name, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not create tag")
+		return
+	}
+	ai.InvalidateTaxonomy()
+	writeJSON(w, http.StatusCreated, tag)
+}
+
+// UpdateTag handles PUT /api/tags/{name}.
+// Body: { "description", "active" }
+func (h *TagsHandler) UpdateTag(w http.ResponseWriter, r *http.Request) {
+	var req tagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	tag := &models.Tag{
+		Name:        chi.URLParam(r, "name"),
+		Description: strings.TrimSpace(req.Description),
+		Active:      req.Active == nil || *req.Active,
+	}
+
+	if err := h.Tags.Update(r.Context(), tag); err != nil {
+		writeError(w, r, http.StatusNotFound, "tag not found")
+		return
+	}
+	ai.InvalidateTaxonomy()
+	writeJSON(w, http.StatusOK, tag)
+}
+
+// DeleteTag handles DELETE /api/tags/{name}. Articles keep the tag; it is
+// only removed from the classifier's taxonomy. Prefer deactivating a tag.
+func (h *TagsHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	if err := h.Tags.Delete(r.Context(), chi.URLParam(r, "name")); err != nil {
+		writeError(w, r, http.StatusNotFound, "tag not found")
+		return
+	}
+	ai.InvalidateTaxonomy()
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/flags"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// TriageHandler serves the AI triage suggestions for inbox items.
+type TriageHandler struct {
+	Suggestions *models.TriageSuggestionStore
+}
+
+// ListSuggestions handles GET /api/triage/suggestions?action=trash&limit=100&offset=0.
+// The list is empty for users the auto_triage flag is off for.
+func (h *TriageHandler) ListSuggestions(w http.ResponseWriter, r *http.Request) {
+	if !flags.Enabled(r.Context(), flags.AutoTriage) {
+		writeJSON(w, http.StatusOK, map[string]any{"suggestions": []models.TriageSuggestion{}, "count": 0})
+		return
+	}
+	action := r.URL.Query().Get("action")
+	if action != "" && !models.ValidTriageAction(action) {
+		writeError(w, r, http.StatusBadRequest, "action must be save or trash")
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+	suggestions, err := h.Suggestions.ListPending(r.Context(), action, limit, offset)
+	if err != nil {
+		slog.Error("list triage suggestions", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if suggestions == nil {
+		suggestions = []models.TriageSuggestion{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"suggestions": suggestions, "count": len(suggestions)})
+}
+
+// AcceptSuggestions handles POST /api/triage/suggestions/accept.
+// Body: { "ids": ["<article id>", ...] } accepts those suggestions;
+// { "action": "trash" } accepts every pending trash suggestion; {} accepts
+// all pending suggestions.
+func (h *TriageHandler) AcceptSuggestions(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		IDs    []uuid.UUID `json:"ids"`
+		Action string      `json:"action"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if req.Action != "" && !models.ValidTriageAction(req.Action) {
+		writeError(w, r, http.StatusBadRequest, "action must be save or trash")
+		return
+	}
+	if req.IDs != nil && len(req.IDs) == 0 {
+		writeJSON(w, http.StatusOK, map[string]int{"saved": 0, "trashed": 0})
+		return
+	}
+
+	saved, trashed, err := h.Suggestions.Accept(r.Context(), req.IDs, req.Action)
+	if err != nil {
+		slog.Error("accept triage suggestions", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "could not apply suggestions")
+		return
+	}
+	slog.Info("triage suggestions accepted", "saved", saved, "trashed", trashed)
+	writeJSON(w, http.StatusOK, map[string]int{"saved": saved, "trashed": trashed})
+}
+
+// DismissSuggestion handles DELETE /api/triage/suggestions/{id}, where id is
+// the article id. The item stays in the inbox and is not suggested again.
+func (h *TriageHandler) DismissSuggestion(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid article id")
+		return
+	}
+	if err := h.Suggestions.Dismiss(r.Context(), id); err != nil {
+		writeError(w, r, http.StatusNotFound, "suggestion not found")
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -3,11 +3,11 @@ package handlers
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -16,6 +16,8 @@ import (
 	"github.com/Saul-Punybz/folio/internal/ai"
 	"github.com/Saul-Punybz/folio/internal/middleware"
 	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
+	"github.com/Saul-Punybz/folio/internal/workerctl"
 )
 
 // WatchlistHandler groups watchlist HTTP handlers.
@@ -23,7 +25,10 @@ type WatchlistHandler struct {
 	Orgs     *models.WatchlistOrgStore
 	Hits     *models.WatchlistHitStore
 	Articles *models.ArticleStore
-	AI       *ai.OllamaClient
+	AI       ai.Provider
+	Digests  *models.WatchlistDigestStore
+	Reports  *models.WatchlistReportStore
+	Worker   *models.WorkerJobStore
 }
 
 // ── Org endpoints ────────────────────────────────────────────────
@@ -32,14 +37,14 @@ type WatchlistHandler struct {
 func (h *WatchlistHandler) ListOrgs(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	orgs, err := h.Orgs.ListByUser(r.Context(), user.ID)
 	if err != nil {
 		slog.Error("list watchlist orgs", "user_id", user.ID, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	if orgs == nil {
@@ -54,23 +59,40 @@ type createOrgRequest struct {
 	Website         string   `json:"website"`
 	Keywords        []string `json:"keywords"`
 	YouTubeChannels []string `json:"youtube_channels"`
+	SocialPages     []string `json:"social_pages"`
+	Priority        *int     `json:"priority,omitempty"`
+
+	SemanticWatch     bool    `json:"semantic_watch"`
+	SemanticThreshold float64 `json:"semantic_threshold"` // 0 uses the default
 }
 
 // CreateOrg handles POST /api/watchlist/orgs.
 func (h *WatchlistHandler) CreateOrg(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	var req createOrgRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 	if req.Name == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "name is required"})
+		writeError(w, r, http.StatusBadRequest, "name is required")
+		return
+	}
+	priority := scraper.PriorityNormal
+	if req.Priority != nil {
+		if !validOrgPriority(*req.Priority) {
+			writeError(w, r, http.StatusBadRequest, "priority must be 0 (low), 1 (normal), or 2 (high)")
+			return
+		}
+		priority = *req.Priority
+	}
+	if !validSemanticThreshold(req.SemanticThreshold) {
+		writeError(w, r, http.StatusBadRequest, "semantic_threshold must be between 0 and 1")
 		return
 	}
 
@@ -80,6 +102,13 @@ func (h *WatchlistHandler) CreateOrg(w http.ResponseWriter, r *http.Request) {
 	if req.YouTubeChannels == nil {
 		req.YouTubeChannels = []string{}
 	}
+	socialPages, ok := normalizeSocialPages(w, r, req.SocialPages)
+	if !ok {
+		return
+	}
+	if socialPages == nil {
+		socialPages = []string{}
+	}
 
 	org := &models.WatchlistOrg{
 		UserID:          user.ID,
@@ -87,12 +116,17 @@ func (h *WatchlistHandler) CreateOrg(w http.ResponseWriter, r *http.Request) {
 		Website:         strings.TrimSpace(req.Website),
 		Keywords:        req.Keywords,
 		YouTubeChannels: req.YouTubeChannels,
+		SocialPages:     socialPages,
 		Active:          true,
+		Priority:        priority,
+
+		SemanticWatch:     req.SemanticWatch,
+		SemanticThreshold: req.SemanticThreshold,
 	}
 
 	if err := h.Orgs.Create(r.Context(), org); err != nil {
 		slog.Error("create watchlist org", "user_id", user.ID, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "could not create org"})
+		writeError(w, r, http.StatusInternalServerError, "could not create org")
 		return
 	}
 
@@ -104,24 +138,61 @@ type updateOrgRequest struct {
 	Website         string   `json:"website"`
 	Keywords        []string `json:"keywords"`
 	YouTubeChannels []string `json:"youtube_channels"`
+	SocialPages     []string `json:"social_pages,omitempty"` // omitted keeps the stored pages
 	Active          *bool    `json:"active,omitempty"`
+	Priority        *int     `json:"priority,omitempty"`
+
+	// Omitted semantic watch settings keep the stored ones.
+	SemanticWatch     *bool    `json:"semantic_watch,omitempty"`
+	SemanticThreshold *float64 `json:"semantic_threshold,omitempty"`
+}
+
+// normalizeSocialPages converts social pages to their stored form, writing a
+// 400 and returning false if one is invalid. A nil input stays nil.
+func normalizeSocialPages(w http.ResponseWriter, r *http.Request, pages []string) ([]string, bool) {
+	if pages == nil {
+		return nil, true
+	}
+	normalized := []string{}
+	for _, p := range pages {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		page, ok := agents.NormalizeSocialPage(p)
+		if !ok {
+			writeError(w, r, http.StatusBadRequest, "invalid social page %q, use facebook:<page> or instagram:<account id>", p)
+			return nil, false
+		}
+		normalized = append(normalized, page)
+	}
+	return normalized, true
+}
+
+func validOrgPriority(p int) bool {
+	return p >= scraper.PriorityLow && p <= scraper.PriorityHigh
+}
+
+// validSemanticThreshold reports whether t is a usable cosine similarity
+// threshold; 0 stands for the default.
+func validSemanticThreshold(t float64) bool {
+	return t >= 0 && t < 1
 }
 
 // UpdateOrg handles PUT /api/watchlist/orgs/{id}.
 func (h *WatchlistHandler) UpdateOrg(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid org id"})
+		writeError(w, r, http.StatusBadRequest, "invalid org id")
 		return
 	}
 
 	var req updateOrgRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 	if req.Name == "" {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "name is required"})
+		writeError(w, r, http.StatusBadRequest, "name is required")
 		return
 	}
 
@@ -132,10 +203,27 @@ func (h *WatchlistHandler) UpdateOrg(w http.ResponseWriter, r *http.Request) {
 		req.YouTubeChannels = []string{}
 	}
 
+	socialPages, ok := normalizeSocialPages(w, r, req.SocialPages)
+	if !ok {
+		return
+	}
+
 	active := true
 	if req.Active != nil {
 		active = *req.Active
 	}
+	priority := -1 // keep the stored priority
+	if req.Priority != nil {
+		if !validOrgPriority(*req.Priority) {
+			writeError(w, r, http.StatusBadRequest, "priority must be 0 (low), 1 (normal), or 2 (high)")
+			return
+		}
+		priority = *req.Priority
+	}
+	if req.SemanticThreshold != nil && !validSemanticThreshold(*req.SemanticThreshold) {
+		writeError(w, r, http.StatusBadRequest, "semantic_threshold must be between 0 and 1")
+		return
+	}
 
 	org := &models.WatchlistOrg{
 		ID:              id,
@@ -143,14 +231,23 @@ func (h *WatchlistHandler) UpdateOrg(w http.ResponseWriter, r *http.Request) {
 		Website:         strings.TrimSpace(req.Website),
 		Keywords:        req.Keywords,
 		YouTubeChannels: req.YouTubeChannels,
+		SocialPages:     socialPages,
 		Active:          active,
+		Priority:        priority,
 	}
 
 	if err := h.Orgs.Update(r.Context(), org); err != nil {
 		slog.Error("update watchlist org", "id", id, "err", err)
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "org not found"})
+		writeError(w, r, http.StatusNotFound, "org not found")
 		return
 	}
+	if req.SemanticWatch != nil || req.SemanticThreshold != nil {
+		if err := h.Orgs.SetSemanticWatch(r.Context(), org, req.SemanticWatch, req.SemanticThreshold); err != nil {
+			slog.Error("update watchlist org semantic watch", "id", id, "err", err)
+			writeError(w, r, http.StatusInternalServerError, "could not update semantic watch")
+			return
+		}
+	}
 
 	writeJSON(w, http.StatusOK, org)
 }
@@ -159,13 +256,13 @@ func (h *WatchlistHandler) UpdateOrg(w http.ResponseWriter, r *http.Request) {
 func (h *WatchlistHandler) DeleteOrg(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid org id"})
+		writeError(w, r, http.StatusBadRequest, "invalid org id")
 		return
 	}
 
 	if err := h.Orgs.Delete(r.Context(), id); err != nil {
 		slog.Error("delete watchlist org", "id", id, "err", err)
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "org not found"})
+		writeError(w, r, http.StatusNotFound, "org not found")
 		return
 	}
 
@@ -176,7 +273,7 @@ func (h *WatchlistHandler) DeleteOrg(w http.ResponseWriter, r *http.Request) {
 func (h *WatchlistHandler) ToggleOrg(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid org id"})
+		writeError(w, r, http.StatusBadRequest, "invalid org id")
 		return
 	}
 
@@ -184,13 +281,13 @@ func (h *WatchlistHandler) ToggleOrg(w http.ResponseWriter, r *http.Request) {
 		Active bool `json:"active"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
 		return
 	}
 
 	if err := h.Orgs.ToggleActive(r.Context(), id, body.Active); err != nil {
 		slog.Error("toggle watchlist org", "id", id, "err", err)
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "org not found"})
+		writeError(w, r, http.StatusNotFound, "org not found")
 		return
 	}
 
@@ -199,11 +296,16 @@ func (h *WatchlistHandler) ToggleOrg(w http.ResponseWriter, r *http.Request) {
 
 // ── Hit endpoints ────────────────────────────────────────────────
 
-// ListHits handles GET /api/watchlist/hits?limit=50&offset=0&org_id=...&source_type=...
+// ListHits handles GET /api/watchlist/hits?limit=50&offset=0&org_id=...
+// Optional filters: sentiment, source_type, seen=true|false, from/to
+// (RFC3339 or YYYY-MM-DD; a date-only `to` is inclusive) and q (words in the
+// title or snippet).
+// With group=story, hits covering the same story are collapsed and the
+// response lists stories (representative hit + member count) instead.
 func (h *WatchlistHandler) ListHits(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
@@ -213,63 +315,216 @@ func (h *WatchlistHandler) ListHits(w http.ResponseWriter, r *http.Request) {
 	}
 	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
 
-	orgIDStr := r.URL.Query().Get("org_id")
+	filter, msg := parseHitFilter(r)
+	if msg != "" {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
 
-	var hits []models.WatchlistHit
-	var err error
+	switch r.URL.Query().Get("group") {
+	case "":
+	case "story":
+		h.listHitStories(w, r, user.ID, filter, limit, offset)
+		return
+	default:
+		writeError(w, r, http.StatusBadRequest, "invalid group, use story")
+		return
+	}
 
-	if orgIDStr != "" {
-		orgID, parseErr := uuid.Parse(orgIDStr)
-		if parseErr != nil {
-			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid org_id"})
+	hits, err := h.Hits.ListByUser(r.Context(), user.ID, filter, limit, offset)
+	if err != nil {
+		slog.Error("list watchlist hits", "user_id", user.ID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if hits == nil {
+		hits = []models.WatchlistHit{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"hits": hits, "count": len(hits)})
+}
+
+// Timeline handles GET /api/watchlist/timeline?limit=50&before=...
+// Returns the user's hits across all orgs as one chronological view, newest
+// first: a URL found by several orgs appears once, with every org that found
+// it in orgs. Accepts the ListHits filters; org_id keeps hits linked to that
+// org. Pass next_before from the response as before to page back.
+func (h *WatchlistHandler) Timeline(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 || limit > 200 {
+		limit = 50
+	}
+	var before time.Time
+	if v := r.URL.Query().Get("before"); v != "" {
+		t, err := time.Parse(time.RFC3339Nano, v)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid before, use RFC3339")
 			return
 		}
-		hits, err = h.Hits.ListByOrg(r.Context(), orgID, limit, offset)
-	} else {
-		hits, err = h.Hits.ListByUser(r.Context(), user.ID, limit, offset)
+		before = t
 	}
 
+	filter, msg := parseHitFilter(r)
+	if msg != "" {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+
+	hits, err := h.Hits.ListTimeline(r.Context(), user.ID, filter, before, limit)
 	if err != nil {
-		slog.Error("list watchlist hits", "user_id", user.ID, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		slog.Error("watchlist timeline", "user_id", user.ID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 	if hits == nil {
 		hits = []models.WatchlistHit{}
 	}
 
-	writeJSON(w, http.StatusOK, map[string]any{"hits": hits, "count": len(hits)})
+	resp := map[string]any{"hits": hits, "count": len(hits)}
+	if len(hits) == limit {
+		resp["next_before"] = hits[len(hits)-1].CreatedAt.Format(time.RFC3339Nano)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// listHitStories serves ListHits with group=story.
+func (h *WatchlistHandler) listHitStories(w http.ResponseWriter, r *http.Request, userID uuid.UUID, filter models.HitFilter, limit, offset int) {
+	stories, err := h.Hits.ListStoriesByUser(r.Context(), userID, filter, limit, offset)
+	if err != nil {
+		slog.Error("list watchlist hit stories", "user_id", userID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if stories == nil {
+		stories = []models.HitStory{}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{"stories": stories, "count": len(stories)})
+}
+
+// parseHitFilter reads ListHits' filter parameters. It returns a user-facing
+// error message, or "" if they are valid.
+func parseHitFilter(r *http.Request) (models.HitFilter, string) {
+	q := r.URL.Query()
+	f := models.HitFilter{
+		Sentiment:  q.Get("sentiment"),
+		SourceType: q.Get("source_type"),
+		Query:      strings.TrimSpace(q.Get("q")),
+	}
+
+	if s := q.Get("org_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return f, "invalid org_id"
+		}
+		f.OrgID = id
+	}
+	if f.Sentiment != "" && !containsString(webhookSentiments, f.Sentiment) {
+		return f, "invalid sentiment, use positive, negative, neutral, or unknown"
+	}
+	if f.SourceType != "" && !containsString(webhookSourceTypes, f.SourceType) {
+		return f, "invalid source_type"
+	}
+	if s := q.Get("seen"); s != "" {
+		seen, err := strconv.ParseBool(s)
+		if err != nil {
+			return f, "invalid seen, use true or false"
+		}
+		f.Seen = &seen
+	}
+	if s := q.Get("from"); s != "" {
+		t, _, ok := parseHitDate(s)
+		if !ok {
+			return f, "invalid 'from' date, use RFC3339 or YYYY-MM-DD"
+		}
+		f.From = t
+	}
+	if s := q.Get("to"); s != "" {
+		t, dateOnly, ok := parseHitDate(s)
+		if !ok {
+			return f, "invalid 'to' date, use RFC3339 or YYYY-MM-DD"
+		}
+		if dateOnly {
+			t = t.AddDate(0, 0, 1)
+		}
+		f.To = t
+	}
+	if !f.From.IsZero() && !f.To.IsZero() && !f.To.After(f.From) {
+		return f, "'to' must be after 'from'"
+	}
+	return f, ""
+}
+
+// parseHitDate parses an RFC3339 timestamp or a YYYY-MM-DD date, reporting
+// which it was.
+func parseHitDate(s string) (t time.Time, dateOnly, ok bool) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, false, true
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true, true
+	}
+	return time.Time{}, false, false
 }
 
 // CountUnseen handles GET /api/watchlist/hits/unseen.
 func (h *WatchlistHandler) CountUnseen(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	count, err := h.Hits.CountUnseenByUser(r.Context(), user.ID)
 	if err != nil {
 		slog.Error("count unseen hits", "user_id", user.ID, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 
 	writeJSON(w, http.StatusOK, map[string]any{"unseen": count})
 }
 
+// LatestDigest handles GET /api/watchlist/digest/latest.
+// Returns the user's most recent daily digest of new hits grouped by org.
+func (h *WatchlistHandler) LatestDigest(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	digest, err := h.Digests.LatestByUser(r.Context(), user.ID)
+	if err != nil {
+		slog.Error("latest watchlist digest", "user_id", user.ID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if digest == nil {
+		writeError(w, r, http.StatusNotFound, "no digest yet")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, digest)
+}
+
 // MarkSeen handles POST /api/watchlist/hits/{id}/seen.
 func (h *WatchlistHandler) MarkSeen(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid hit id"})
+		writeError(w, r, http.StatusBadRequest, "invalid hit id")
 		return
 	}
 
 	if err := h.Hits.MarkSeen(r.Context(), id); err != nil {
 		slog.Error("mark hit seen", "id", id, "err", err)
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "hit not found"})
+		writeError(w, r, http.StatusNotFound, "hit not found")
 		return
 	}
 
@@ -280,14 +535,14 @@ func (h *WatchlistHandler) MarkSeen(w http.ResponseWriter, r *http.Request) {
 func (h *WatchlistHandler) MarkAllSeen(w http.ResponseWriter, r *http.Request) {
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
 	count, err := h.Hits.MarkAllSeenByUser(r.Context(), user.ID)
 	if err != nil {
 		slog.Error("mark all hits seen", "user_id", user.ID, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
+		writeError(w, r, http.StatusInternalServerError, "internal error")
 		return
 	}
 
@@ -298,13 +553,13 @@ func (h *WatchlistHandler) MarkAllSeen(w http.ResponseWriter, r *http.Request) {
 func (h *WatchlistHandler) DeleteHit(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid hit id"})
+		writeError(w, r, http.StatusBadRequest, "invalid hit id")
 		return
 	}
 
 	if err := h.Hits.Delete(r.Context(), id); err != nil {
 		slog.Error("delete hit", "id", id, "err", err)
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "hit not found"})
+		writeError(w, r, http.StatusNotFound, "hit not found")
 		return
 	}
 
@@ -316,31 +571,18 @@ func (h *WatchlistHandler) DeleteHit(w http.ResponseWriter, r *http.Request) {
 func (h *WatchlistHandler) EnrichOrg(w http.ResponseWriter, r *http.Request) {
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
-		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid org id"})
+		writeError(w, r, http.StatusBadRequest, "invalid org id")
 		return
 	}
 
 	user := middleware.UserFromContext(r.Context())
 	if user == nil {
-		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
 		return
 	}
 
-	// Find the org.
-	orgs, err := h.Orgs.ListByUser(r.Context(), user.ID)
-	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "internal error"})
-		return
-	}
-	var org *models.WatchlistOrg
-	for i := range orgs {
-		if orgs[i].ID == id {
-			org = &orgs[i]
-			break
-		}
-	}
-	if org == nil {
-		writeJSON(w, http.StatusNotFound, map[string]string{"error": "org not found"})
+	org, ok := h.userOrg(w, r, user.ID, id)
+	if !ok {
 		return
 	}
 
@@ -351,7 +593,7 @@ func (h *WatchlistHandler) EnrichOrg(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, http.StatusOK, map[string]any{
 			"status":   "partial",
 			"keywords": org.Keywords,
-			"message":  "No se pudieron extraer palabras clave automaticamente.",
+			"message":  localize(r, "Could not extract keywords automatically."),
 		})
 		return
 	}
@@ -363,17 +605,104 @@ func (h *WatchlistHandler) EnrichOrg(w http.ResponseWriter, r *http.Request) {
 	org.Keywords = merged
 	if err := h.Orgs.Update(r.Context(), org); err != nil {
 		slog.Error("enrich org update", "id", id, "err", err)
-		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "failed to update org"})
+		writeError(w, r, http.StatusInternalServerError, "failed to update org")
 		return
 	}
 
 	writeJSON(w, http.StatusOK, map[string]any{
 		"status":   "enriched",
 		"keywords": merged,
-		"message":  fmt.Sprintf("Se extrajeron %d palabras clave automaticamente.", len(keywords)),
+		"message":  localize(r, "Extracted %d keywords automatically.", len(keywords)),
 	})
 }
 
+// KeywordSuggestions handles GET /api/watchlist/orgs/{id}/keyword-suggestions.
+// Proposes keywords mined from the org's reviewed hits; see
+// agents.SuggestKeywords.
+func (h *WatchlistHandler) KeywordSuggestions(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid org id")
+		return
+	}
+
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	org, ok := h.userOrg(w, r, user.ID, id)
+	if !ok {
+		return
+	}
+
+	suggestions, err := agents.SuggestKeywords(r.Context(), h.Hits, *org)
+	if err != nil {
+		slog.Error("keyword suggestions", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, suggestions)
+}
+
+// AcceptKeywords handles POST /api/watchlist/orgs/{id}/keyword-suggestions/accept.
+// Body: { "keywords": ["..."] }. Appends the keywords to the org's list,
+// skipping ones it already has, and returns the updated org.
+func (h *WatchlistHandler) AcceptKeywords(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid org id")
+		return
+	}
+
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var body struct {
+		Keywords []string `json:"keywords"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || len(body.Keywords) == 0 {
+		writeError(w, r, http.StatusBadRequest, "keywords are required")
+		return
+	}
+
+	org, ok := h.userOrg(w, r, user.ID, id)
+	if !ok {
+		return
+	}
+
+	org.Keywords = mergeKeywords(org.Keywords, body.Keywords)
+	if err := h.Orgs.Update(r.Context(), org); err != nil {
+		slog.Error("accept keywords: update org", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "failed to update org")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, org)
+}
+
+// userOrg finds one of the user's orgs by ID, writing a 404 (or 500) and
+// returning false when it cannot.
+func (h *WatchlistHandler) userOrg(w http.ResponseWriter, r *http.Request, userID, id uuid.UUID) (*models.WatchlistOrg, bool) {
+	orgs, err := h.Orgs.ListByUser(r.Context(), userID)
+	if err != nil {
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return nil, false
+	}
+	for i := range orgs {
+		if orgs[i].ID == id {
+			return &orgs[i], true
+		}
+	}
+	writeError(w, r, http.StatusNotFound, "org not found")
+	return nil, false
+}
+
 // mergeKeywords combines AI-extracted keywords with user-provided ones, deduplicating.
 func mergeKeywords(aiKeywords, userKeywords []string) []string {
 	seen := make(map[string]bool)
@@ -395,18 +724,50 @@ func mergeKeywords(aiKeywords, userKeywords []string) []string {
 	return result
 }
 
+// PreviewScan handles POST /api/watchlist/preview.
+// Body: { "query": "Fundación X" }. Runs the news and web agents once for the
+// query and returns the results a scan would store as hits, and why the rest
+// would be dropped. Nothing is saved, but search budgets are used.
+func (h *WatchlistHandler) PreviewScan(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req struct {
+		Query string `json:"query"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	query := strings.TrimSpace(req.Query)
+	if query == "" || len(query) > 200 {
+		writeError(w, r, http.StatusBadRequest, "query is required (max 200 characters)")
+		return
+	}
+
+	preview, err := agents.PreviewScan(r.Context(), user.ID, query, h.Hits)
+	if err != nil {
+		slog.Error("watchlist preview", "query", query, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "preview failed")
+		return
+	}
+	writeJSON(w, http.StatusOK, preview)
+}
+
 // TriggerScan handles POST /api/watchlist/scan.
-// Launches the watchlist scan in the background and returns immediately.
+// Queues a watchlist scan for the worker and returns immediately; progress
+// is reported by GET /api/watchlist/scan.
 func (h *WatchlistHandler) TriggerScan(w http.ResponseWriter, r *http.Request) {
-	go agents.RunWatchlistScan(context.Background(), agents.Deps{
-		Orgs:     h.Orgs,
-		Hits:     h.Hits,
-		Articles: h.Articles,
-		AI:       h.AI,
-	})
+	queueWorkerJob(w, r, h.Worker, workerctl.JobWatchlistScan,
+		"Scan queued. Results will appear in a few minutes.",
+		"A scan is already in progress. Results will appear in a few minutes.")
+}
 
-	writeJSON(w, http.StatusAccepted, map[string]string{
-		"status":  "started",
-		"message": "Escaneo iniciado. Los resultados aparecerán en segundos.",
-	})
+// ScanStatus handles GET /api/watchlist/scan.
+// Returns the watchlist scan job's last run and the latest scan request.
+func (h *WatchlistHandler) ScanStatus(w http.ResponseWriter, r *http.Request) {
+	workerJobStatus(w, r, h.Worker, workerctl.JobWatchlistScan)
 }
@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Saul-Punybz/folio/internal/agents"
+	"github.com/Saul-Punybz/folio/internal/middleware"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
+)
+
+// maxOrgCSVSize caps an uploaded watchlist CSV.
+const maxOrgCSVSize = 1 << 20
+
+// orgCSVColumns is the header of an exported watchlist CSV. List cells hold
+// values separated by orgCSVListSep.
+var orgCSVColumns = []string{"name", "website", "keywords", "youtube_channels", "social_pages", "priority",
+	"semantic_watch", "semantic_threshold"}
+
+const orgCSVListSep = ";"
+
+// orgCSVError is a CSV row that could not be imported.
+type orgCSVError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
+
+// ImportOrgs handles POST /api/watchlist/orgs/import.
+// The CSV is sent as the request body or as the "file" field of a multipart
+// form. Its header row names the columns: name is required; website,
+// keywords, youtube_channels, social_pages (all ";"-separated), priority
+// (low, normal, high or 0-2), semantic_watch (true or false) and
+// semantic_threshold (0-1) are optional. Orgs the user already watches are skipped by
+// name; rows with errors are reported and the rest are imported.
+func (h *WatchlistHandler) ImportOrgs(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	data, err := readUpload(w, r, maxOrgCSVSize)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	orgs, rowErrs, err := parseOrgCSV(data)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	result := &models.WatchlistImportResult{Created: []models.WatchlistOrg{}, Skipped: []string{}}
+	if len(orgs) > 0 {
+		result, err = h.Orgs.Import(r.Context(), user.ID, orgs)
+		if err != nil {
+			slog.Error("import watchlist orgs", "user_id", user.ID, "err", err)
+			writeError(w, r, http.StatusInternalServerError, "could not import orgs")
+			return
+		}
+	}
+
+	slog.Info("watchlist orgs imported", "user_id", user.ID, "created", len(result.Created), "skipped", len(result.Skipped), "errors", len(rowErrs))
+	writeJSON(w, http.StatusOK, map[string]any{
+		"created": result.Created,
+		"skipped": result.Skipped,
+		"errors":  rowErrs,
+	})
+}
+
+// ExportOrgs handles GET /api/watchlist/orgs/export.csv — the user's orgs in
+// the format ImportOrgs reads.
+func (h *WatchlistHandler) ExportOrgs(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	orgs, err := h.Orgs.ListByUser(r.Context(), user.ID)
+	if err != nil {
+		slog.Error("export watchlist orgs", "user_id", user.ID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := writeOrgCSV(&buf, orgs); err != nil {
+		slog.Error("export watchlist orgs: write csv", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="watchlist-orgs-%s.csv"`, time.Now().Format("2006-01-02")))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// writeOrgCSV writes orgs as a CSV with a header row, in the format
+// parseOrgCSV reads.
+func writeOrgCSV(w io.Writer, orgs []models.WatchlistOrg) error {
+	cw := csv.NewWriter(w)
+	cw.Write(orgCSVColumns)
+	for _, o := range orgs {
+		cw.Write([]string{
+			o.Name,
+			o.Website,
+			strings.Join(o.Keywords, orgCSVListSep+" "),
+			strings.Join(o.YouTubeChannels, orgCSVListSep+" "),
+			strings.Join(o.SocialPages, orgCSVListSep+" "),
+			orgPriorityName(o.Priority),
+			strconv.FormatBool(o.SemanticWatch),
+			strconv.FormatFloat(o.SemanticThreshold, 'g', -1, 64),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// parseOrgCSV reads watchlist orgs from a CSV with a header row. It returns
+// an error only when the file as a whole is unusable; bad rows are returned
+// as orgCSVErrors.
+func parseOrgCSV(data []byte) ([]models.WatchlistOrg, []orgCSVError, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // BOM written by Excel
+	cr := csv.NewReader(bytes.NewReader(data))
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid CSV: missing header row")
+	}
+	cols := make(map[string]int, len(header))
+	for i, name := range header {
+		cols[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := cols["name"]; !ok {
+		return nil, nil, fmt.Errorf("invalid CSV: header must include a name column")
+	}
+
+	var orgs []models.WatchlistOrg
+	rowErrs := []orgCSVError{}
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := cr.FieldPos(0)
+		if err != nil {
+			var perr *csv.ParseError
+			if errors.As(err, &perr) {
+				return nil, nil, fmt.Errorf("invalid CSV: line %d: %v", perr.Line, perr.Err)
+			}
+			return nil, nil, fmt.Errorf("invalid CSV: %v", err)
+		}
+
+		cell := func(col string) string {
+			if i, ok := cols[col]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		org := models.WatchlistOrg{
+			Name:            cell("name"),
+			Website:         cell("website"),
+			Keywords:        splitOrgCSVList(cell("keywords")),
+			YouTubeChannels: splitOrgCSVList(cell("youtube_channels")),
+			Priority:        scraper.PriorityNormal,
+		}
+		if org.Name == "" {
+			if strings.Join(record, "") != "" {
+				rowErrs = append(rowErrs, orgCSVError{Line: line, Error: "name is required"})
+			}
+			continue
+		}
+		pages, badPage := parseOrgSocialPages(cell("social_pages"))
+		if badPage != "" {
+			rowErrs = append(rowErrs, orgCSVError{Line: line, Error: fmt.Sprintf("invalid social page %q", badPage)})
+			continue
+		}
+		org.SocialPages = pages
+		if p := cell("priority"); p != "" {
+			priority, ok := parseOrgPriority(p)
+			if !ok {
+				rowErrs = append(rowErrs, orgCSVError{Line: line, Error: "priority must be low, normal, or high"})
+				continue
+			}
+			org.Priority = priority
+		}
+		if v := cell("semantic_watch"); v != "" {
+			watch, err := strconv.ParseBool(v)
+			if err != nil {
+				rowErrs = append(rowErrs, orgCSVError{Line: line, Error: "semantic_watch must be true or false"})
+				continue
+			}
+			org.SemanticWatch = watch
+		}
+		if v := cell("semantic_threshold"); v != "" {
+			threshold, err := strconv.ParseFloat(v, 64)
+			if err != nil || threshold < 0 || threshold > 1 {
+				rowErrs = append(rowErrs, orgCSVError{Line: line, Error: "semantic_threshold must be between 0 and 1"})
+				continue
+			}
+			org.SemanticThreshold = threshold
+		}
+		orgs = append(orgs, org)
+	}
+	return orgs, rowErrs, nil
+}
+
+// splitOrgCSVList splits a list cell, dropping empty values.
+func splitOrgCSVList(cell string) []string {
+	values := []string{}
+	for _, v := range strings.Split(cell, orgCSVListSep) {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// parseOrgSocialPages normalizes a social_pages cell, returning the first
+// invalid page if there is one.
+func parseOrgSocialPages(cell string) ([]string, string) {
+	pages := []string{}
+	for _, p := range splitOrgCSVList(cell) {
+		page, ok := agents.NormalizeSocialPage(p)
+		if !ok {
+			return nil, p
+		}
+		pages = append(pages, page)
+	}
+	return pages, ""
+}
+
+// parseOrgPriority accepts a priority by name or number.
+func parseOrgPriority(s string) (int, bool) {
+	switch strings.ToLower(s) {
+	case "low":
+		return scraper.PriorityLow, true
+	case "normal":
+		return scraper.PriorityNormal, true
+	case "high":
+		return scraper.PriorityHigh, true
+	}
+	p, err := strconv.Atoi(s)
+	if err != nil || !validOrgPriority(p) {
+		return 0, false
+	}
+	return p, true
+}
+
+func orgPriorityName(p int) string {
+	switch p {
+	case scraper.PriorityLow:
+		return "low"
+	case scraper.PriorityHigh:
+		return "high"
+	}
+	return "normal"
+}
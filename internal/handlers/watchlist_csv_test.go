@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/scraper"
+)
+
+func TestOrgCSVRoundTrip(t *testing.T) {
+	orgs := []models.WatchlistOrg{
+		{
+			Name:              "Fundación Ejemplo, Inc.",
+			Website:           "https://ejemplo.org",
+			Keywords:          []string{"Fundación Ejemplo", "FE"},
+			YouTubeChannels:   []string{"UC123"},
+			SocialPages:       []string{"facebook:ejemplo"},
+			Priority:          scraper.PriorityHigh,
+			SemanticWatch:     true,
+			SemanticThreshold: 0.82,
+		},
+		{
+			Name:            "Otra Org",
+			Keywords:        []string{},
+			YouTubeChannels: []string{},
+			SocialPages:     []string{},
+			Priority:        scraper.PriorityNormal,
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := writeOrgCSV(&buf, orgs); err != nil {
+		t.Fatalf("writeOrgCSV: %v", err)
+	}
+	got, rowErrs, err := parseOrgCSV(buf.Bytes())
+	if err != nil {
+		t.Fatalf("parseOrgCSV: %v", err)
+	}
+	if len(rowErrs) > 0 {
+		t.Fatalf("row errors: %+v", rowErrs)
+	}
+	if !reflect.DeepEqual(got, orgs) {
+		t.Errorf("round trip:\n got %+v\nwant %+v", got, orgs)
+	}
+}
+
+func TestParseOrgCSVSemanticErrors(t *testing.T) {
+	csv := "name,semantic_watch,semantic_threshold\n" +
+		"A,maybe,\n" +
+		"B,true,1.5\n" +
+		"C,false,0.7\n"
+	orgs, rowErrs, err := parseOrgCSV([]byte(csv))
+	if err != nil {
+		t.Fatalf("parseOrgCSV: %v", err)
+	}
+	want := []orgCSVError{
+		{Line: 2, Error: "semantic_watch must be true or false"},
+		{Line: 3, Error: "semantic_threshold must be between 0 and 1"},
+	}
+	if !reflect.DeepEqual(rowErrs, want) {
+		t.Errorf("row errors = %+v, want %+v", rowErrs, want)
+	}
+	if len(orgs) != 1 || orgs[0].Name != "C" || orgs[0].SemanticWatch || orgs[0].SemanticThreshold != 0.7 {
+		t.Errorf("orgs = %+v, want only C with threshold 0.7", orgs)
+	}
+}
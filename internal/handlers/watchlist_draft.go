@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/middleware"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// maxDraftLen caps an edited response.
+const maxDraftLen = 20000
+
+// communicationsCSVColumns is the header of an exported communications CSV.
+var communicationsCSVColumns = []string{
+	"org", "status", "approved_at", "sent_at", "hit_title", "hit_url",
+	"sentiment", "response", "ai_draft",
+}
+
+// EditDraft handles PUT /api/watchlist/hits/{id}/draft.
+// Body: { "text": "..." }. Stores the revised response next to the AI draft
+// and marks it edited; an approved response has to be approved again.
+func (h *WatchlistHandler) EditDraft(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid hit id")
+		return
+	}
+
+	var req struct {
+		Text string `json:"text"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	text := strings.TrimSpace(req.Text)
+	if text == "" || len(text) > maxDraftLen {
+		writeError(w, r, http.StatusBadRequest, "text is required (max %d characters)", maxDraftLen)
+		return
+	}
+
+	if err := h.Hits.EditDraft(r.Context(), user.ID, id, text); err != nil {
+		h.writeDraftError(w, r, id, err)
+		return
+	}
+	h.writeHit(w, r, user.ID, id)
+}
+
+// SetDraftStatus handles POST /api/watchlist/hits/{id}/draft/status.
+// Body: { "status": "approved" }. Status is approved (from draft or edited),
+// sent (from approved) or draft (reopens an approved response for editing).
+func (h *WatchlistHandler) SetDraftStatus(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid hit id")
+		return
+	}
+
+	var req struct {
+		Status string `json:"status"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if !models.ValidDraftStatus(req.Status) {
+		writeError(w, r, http.StatusBadRequest, "invalid status, use draft, approved, or sent")
+		return
+	}
+
+	if err := h.Hits.SetDraftStatus(r.Context(), user.ID, id, req.Status); err != nil {
+		h.writeDraftError(w, r, id, err)
+		return
+	}
+	slog.Info("watchlist draft status", "hit_id", id, "user_id", user.ID, "status", req.Status)
+	h.writeHit(w, r, user.ID, id)
+}
+
+// ExportCommunications handles
+// GET /api/watchlist/communications/export?org_id=&from=&to=&format=csv.
+// Returns the approved and sent responses approved in the date range (dates
+// are YYYY-MM-DD, to inclusive; default the last 30 days) for one org or all
+// of the user's orgs, as CSV (default) or JSON.
+func (h *WatchlistHandler) ExportCommunications(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	q := r.URL.Query()
+	orgID := uuid.Nil
+	if s := q.Get("org_id"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid org_id")
+			return
+		}
+		if _, ok := h.userOrg(w, r, user.ID, id); !ok {
+			return
+		}
+		orgID = id
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	from, to := today.AddDate(0, 0, -30), today
+	if s := q.Get("from"); s != "" {
+		parsed, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid 'from' date, use YYYY-MM-DD")
+			return
+		}
+		from = parsed
+	}
+	if s := q.Get("to"); s != "" {
+		parsed, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid 'to' date, use YYYY-MM-DD")
+			return
+		}
+		to = parsed
+	}
+	if to.Before(from) {
+		writeError(w, r, http.StatusBadRequest, "'to' must not be before 'from'")
+		return
+	}
+	format := q.Get("format")
+	if format == "" {
+		format = "csv"
+	}
+	if format != "csv" && format != "json" {
+		writeError(w, r, http.StatusBadRequest, "invalid format, use csv or json")
+		return
+	}
+
+	hits, err := h.Hits.ListCommunications(r.Context(), user.ID, orgID, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		slog.Error("export communications", "user_id", user.ID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	filename := fmt.Sprintf("communications-%s-%s", from.Format("2006-01-02"), to.Format("2006-01-02"))
+	if format == "json" {
+		if hits == nil {
+			hits = []models.WatchlistHit{}
+		}
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.json"`, filename))
+		writeJSON(w, http.StatusOK, hits)
+		return
+	}
+
+	var buf bytes.Buffer
+	cw := csv.NewWriter(&buf)
+	cw.Write(communicationsCSVColumns)
+	for _, hit := range hits {
+		aiDraft := ""
+		if hit.AIDraft != nil {
+			aiDraft = *hit.AIDraft
+		}
+		cw.Write([]string{
+			hit.OrgName,
+			hit.DraftStatus,
+			formatOptionalTime(hit.DraftApprovedAt),
+			formatOptionalTime(hit.DraftSentAt),
+			hit.Title,
+			hit.URL,
+			hit.Sentiment,
+			hit.ResponseText(),
+			aiDraft,
+		})
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		slog.Error("export communications: write csv", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.csv"`, filename))
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// writeDraftError maps a draft store error to a response.
+func (h *WatchlistHandler) writeDraftError(w http.ResponseWriter, r *http.Request, id uuid.UUID, err error) {
+	switch {
+	case errors.Is(err, models.ErrHitNotFound):
+		writeError(w, r, http.StatusNotFound, "hit not found")
+	case errors.Is(err, models.ErrDraftTransition):
+		writeError(w, r, http.StatusConflict, "the draft's current status does not allow this change")
+	default:
+		slog.Error("watchlist draft", "hit_id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+	}
+}
+
+// writeHit responds with the hit's current state.
+func (h *WatchlistHandler) writeHit(w http.ResponseWriter, r *http.Request, userID, id uuid.UUID) {
+	hit, err := h.Hits.GetForUser(r.Context(), userID, id)
+	if err != nil {
+		h.writeDraftError(w, r, id, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, hit)
+}
+
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/agents"
+	"github.com/Saul-Punybz/folio/internal/middleware"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+const (
+	defaultMuteDays = 30
+	maxMuteDays     = 365
+)
+
+// MuteStory handles POST /api/watchlist/hits/{id}/mute-story.
+// Body (optional): { "days": 30 }. Hides the hit and the rest of its story,
+// and suppresses new hits of the same story for the hit's org for the given
+// number of days (1-365). Returns the mute.
+func (h *WatchlistHandler) MuteStory(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid hit id")
+		return
+	}
+
+	var req struct {
+		Days int `json:"days"`
+	}
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, r, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+	if req.Days == 0 {
+		req.Days = defaultMuteDays
+	}
+	if req.Days < 1 || req.Days > maxMuteDays {
+		writeError(w, r, http.StatusBadRequest, "days must be between 1 and 365")
+		return
+	}
+
+	hit, err := h.Hits.GetForUser(r.Context(), user.ID, id)
+	if errors.Is(err, models.ErrHitNotFound) {
+		writeError(w, r, http.StatusNotFound, "hit not found")
+		return
+	}
+	if err != nil {
+		slog.Error("mute story: get hit", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	org, ok := h.userOrg(w, r, user.ID, hit.OrgID)
+	if !ok {
+		return
+	}
+
+	mute, err := agents.MuteStory(r.Context(), h.Hits, h.AI, *org, hit, req.Days, user.ID)
+	if err != nil {
+		slog.Error("mute story", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusCreated, mute)
+}
+
+// ListMutedStories handles GET /api/watchlist/orgs/{id}/muted-stories.
+// Lists the org's active mutes, or all of them with ?all=true.
+func (h *WatchlistHandler) ListMutedStories(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid org id")
+		return
+	}
+	if _, ok := h.userOrg(w, r, user.ID, id); !ok {
+		return
+	}
+
+	mutes, err := h.Hits.ListMutedStories(r.Context(), id, r.URL.Query().Get("all") == "true")
+	if err != nil {
+		slog.Error("list muted stories", "org_id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if mutes == nil {
+		mutes = []models.MutedStory{}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"muted_stories": mutes, "count": len(mutes)})
+}
+
+// UnmuteStory handles DELETE /api/watchlist/orgs/{id}/muted-stories/{muteId}.
+// Revokes the mute; the hits it hid show up again.
+func (h *WatchlistHandler) UnmuteStory(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid org id")
+		return
+	}
+	muteID, err := uuid.Parse(chi.URLParam(r, "muteId"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid mute id")
+		return
+	}
+	if _, ok := h.userOrg(w, r, user.ID, id); !ok {
+		return
+	}
+
+	err = h.Hits.DeleteMutedStory(r.Context(), id, muteID)
+	if errors.Is(err, models.ErrMuteNotFound) {
+		writeError(w, r, http.StatusNotFound, "muted story not found")
+		return
+	}
+	if err != nil {
+		slog.Error("unmute story", "id", muteID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "unmuted"})
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/middleware"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// ListReports handles GET /api/watchlist/reports?limit=20&offset=0.
+// Lists the user's weekly watchlist reports, newest week first, with their
+// totals; fetch one report for the per-org breakdown and notable hits.
+func (h *WatchlistHandler) ListReports(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+	if offset < 0 {
+		offset = 0
+	}
+
+	reports, err := h.Reports.ListByUser(r.Context(), user.ID, limit, offset)
+	if err != nil {
+		slog.Error("list watchlist reports", "user_id", user.ID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if reports == nil {
+		reports = []models.WatchlistReport{}
+	}
+
+	writeJSON(w, http.StatusOK, reports)
+}
+
+// GetReport handles GET /api/watchlist/reports/{id}.
+// Returns a weekly report with hit counts and sentiment per org and the
+// notable negative hits with their responses.
+func (h *WatchlistHandler) GetReport(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid report id")
+		return
+	}
+
+	report, err := h.Reports.GetForUser(r.Context(), user.ID, id)
+	if errors.Is(err, models.ErrReportNotFound) {
+		writeError(w, r, http.StatusNotFound, "report not found")
+		return
+	}
+	if err != nil {
+		slog.Error("get watchlist report", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/middleware"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// SetSentiment handles PUT /api/watchlist/hits/{id}/sentiment.
+// Body: { "sentiment": "positive" | "neutral" | "negative" }. Overrides the
+// model's classification; the hit is marked human-verified so later
+// classification runs leave it alone.
+func (h *WatchlistHandler) SetSentiment(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid hit id")
+		return
+	}
+
+	var req struct {
+		Sentiment string `json:"sentiment"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if !models.ValidSentiment(req.Sentiment) {
+		writeError(w, r, http.StatusBadRequest, "sentiment must be positive, neutral, or negative")
+		return
+	}
+
+	verifiedAt, err := h.Hits.VerifySentiment(r.Context(), user.ID, id, req.Sentiment)
+	if errors.Is(err, models.ErrHitNotFound) {
+		writeError(w, r, http.StatusNotFound, "hit not found")
+		return
+	}
+	if err != nil {
+		slog.Error("set hit sentiment", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]any{
+		"id":                    id,
+		"sentiment":             req.Sentiment,
+		"sentiment_verified_at": verifiedAt,
+	})
+}
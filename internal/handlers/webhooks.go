@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/middleware"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/notify"
+)
+
+// WebhooksHandler groups webhook target HTTP handlers.
+type WebhooksHandler struct {
+	Webhooks *models.WebhookStore
+}
+
+var (
+	webhookSentiments  = []string{"positive", "negative", "neutral", "unknown"}
+	webhookSourceTypes = []string{"google_news", "bing_news", "web", "local", "semantic", "youtube", "reddit", "social", "gov"}
+)
+
+type webhookRequest struct {
+	Name        string   `json:"name"`
+	URL         string   `json:"url"`
+	Kind        string   `json:"kind"`
+	Sentiments  []string `json:"sentiments"`
+	SourceTypes []string `json:"source_types"`
+	Secret      *string  `json:"secret"`
+	Active      *bool    `json:"active"`
+}
+
+// validate normalizes the request and returns a user-facing error message, or
+// "" if the request is valid. The URL's host must resolve to public
+// addresses only, so targets cannot be used to reach internal services.
+func (req *webhookRequest) validate(ctx context.Context) string {
+	req.Name = strings.TrimSpace(req.Name)
+	req.URL = strings.TrimSpace(req.URL)
+	if req.Kind == "" {
+		req.Kind = models.WebhookGeneric
+	}
+
+	u, err := url.Parse(req.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "url must be an absolute http(s) URL"
+	}
+	if err := notify.CheckURL(ctx, req.URL); err != nil {
+		return "url must resolve to a public address"
+	}
+	switch req.Kind {
+	case models.WebhookSlack, models.WebhookDiscord, models.WebhookGeneric:
+	default:
+		return "kind must be slack, discord, or generic"
+	}
+	for _, s := range req.Sentiments {
+		if !containsString(webhookSentiments, s) {
+			return "invalid sentiment: " + s
+		}
+	}
+	for _, s := range req.SourceTypes {
+		if !containsString(webhookSourceTypes, s) {
+			return "invalid source_type: " + s
+		}
+	}
+	return ""
+}
+
+func containsString(list []string, v string) bool {
+	for _, s := range list {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// List handles GET /api/webhooks.
+func (h *WebhooksHandler) List(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	targets, err := h.Webhooks.ListByUser(r.Context(), user.ID)
+	if err != nil {
+		slog.Error("list webhooks", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return
+	}
+	if targets == nil {
+		targets = []models.WebhookTarget{}
+	}
+	writeJSON(w, http.StatusOK, targets)
+}
+
+// Create handles POST /api/webhooks.
+func (h *WebhooksHandler) Create(w http.ResponseWriter, r *http.Request) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	var req webhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if msg := req.validate(r.Context()); msg != "" {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+
+	target := &models.WebhookTarget{
+		UserID:      user.ID,
+		Name:        req.Name,
+		URL:         req.URL,
+		Kind:        req.Kind,
+		Sentiments:  req.Sentiments,
+		SourceTypes: req.SourceTypes,
+		Active:      true,
+	}
+	if req.Secret != nil {
+		target.Secret = *req.Secret
+	}
+	if req.Active != nil {
+		target.Active = *req.Active
+	}
+
+	if err := h.Webhooks.Create(r.Context(), target); err != nil {
+		slog.Error("create webhook", "err", err)
+		writeError(w, r, http.StatusInternalServerError, "failed to create webhook")
+		return
+	}
+	writeJSON(w, http.StatusCreated, target)
+}
+
+// Update handles PUT /api/webhooks/{id}. Omitting secret keeps the stored one;
+// an empty string clears it.
+func (h *WebhooksHandler) Update(w http.ResponseWriter, r *http.Request) {
+	target, ok := h.ownedTarget(w, r)
+	if !ok {
+		return
+	}
+
+	var req webhookRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if msg := req.validate(r.Context()); msg != "" {
+		writeError(w, r, http.StatusBadRequest, msg)
+		return
+	}
+
+	target.Name = req.Name
+	target.URL = req.URL
+	target.Kind = req.Kind
+	target.Sentiments = req.Sentiments
+	target.SourceTypes = req.SourceTypes
+	if req.Secret != nil {
+		target.Secret = *req.Secret
+	}
+	if req.Active != nil {
+		target.Active = *req.Active
+	}
+
+	if err := h.Webhooks.Update(r.Context(), target); err != nil {
+		slog.Error("update webhook", "id", target.ID, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "failed to update webhook")
+		return
+	}
+	writeJSON(w, http.StatusOK, target)
+}
+
+// Delete handles DELETE /api/webhooks/{id}.
+func (h *WebhooksHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	target, ok := h.ownedTarget(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.Webhooks.Delete(r.Context(), target.ID); err != nil {
+		slog.Error("delete webhook", "id", target.ID, "err", err)
+		writeError(w, r, http.StatusNotFound, "webhook not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "deleted"})
+}
+
+// Test handles POST /api/webhooks/{id}/test. It sends a sample hit
+// synchronously and reports the delivery result.
+func (h *WebhooksHandler) Test(w http.ResponseWriter, r *http.Request) {
+	target, ok := h.ownedTarget(w, r)
+	if !ok {
+		return
+	}
+
+	sample := models.WatchlistHit{
+		ID:         uuid.New(),
+		OrgName:    "Folio",
+		SourceType: "web",
+		Title:      "Prueba de webhook de Folio",
+		URL:        "https://example.com/folio-webhook-test",
+		Snippet:    "Este es un mensaje de prueba. Las menciones nuevas llegarán así.",
+		Sentiment:  "neutral",
+		CreatedAt:  time.Now(),
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 20*time.Second)
+	defer cancel()
+	if err := notify.Deliver(ctx, target, sample); err != nil {
+		// Only the status reaches the user: response bodies and dial errors
+		// would reveal what answers at the target.
+		slog.Warn("test webhook", "id", target.ID, "err", err)
+		var statusErr *notify.StatusError
+		switch {
+		case errors.As(err, &statusErr):
+			writeError(w, r, http.StatusBadGateway, "delivery failed: status %d", statusErr.Code)
+		case errors.Is(err, notify.ErrPrivateTarget):
+			writeError(w, r, http.StatusBadGateway, "url must resolve to a public address")
+		default:
+			writeError(w, r, http.StatusBadGateway, "delivery failed")
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{"status": "delivered"})
+}
+
+// ownedTarget loads the {id} target and checks it belongs to the current
+// user, writing the error response itself when it does not.
+func (h *WebhooksHandler) ownedTarget(w http.ResponseWriter, r *http.Request) (*models.WebhookTarget, bool) {
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		writeError(w, r, http.StatusUnauthorized, "unauthorized")
+		return nil, false
+	}
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeError(w, r, http.StatusBadRequest, "invalid webhook id")
+		return nil, false
+	}
+
+	target, err := h.Webhooks.GetByID(r.Context(), id)
+	if err != nil {
+		slog.Error("get webhook", "id", id, "err", err)
+		writeError(w, r, http.StatusInternalServerError, "internal error")
+		return nil, false
+	}
+	if target == nil || target.UserID != user.ID {
+		writeError(w, r, http.StatusNotFound, "webhook not found")
+		return nil, false
+	}
+	return target, true
+}
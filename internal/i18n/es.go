@@ -0,0 +1,332 @@
+package i18n
+
+// es is the Spanish catalog. Keys are the English messages as written in the
+// code, format verbs included; keep the verbs in the same order.
+var es = map[string]string{
+	// ── Auth and access ──────────────────────────────────────────
+	"unauthorized":                           "no autorizado",
+	"forbidden":                              "acceso denegado",
+	"session expired":                        "la sesión expiró",
+	"account deactivated":                    "la cuenta está desactivada",
+	"admin user not found":                   "no se encontró el usuario administrador",
+	"missing api key":                        "falta la clave de API",
+	"invalid api key":                        "clave de API inválida",
+	"too many requests":                      "demasiadas solicitudes",
+	"invalid credentials":                    "credenciales inválidas",
+	"email and password required":            "se requieren correo y contraseña",
+	"valid email required":                   "se requiere un correo válido",
+	"email already registered":               "el correo ya está registrado",
+	"could not hash password":                "no se pudo procesar la contraseña",
+	"password must be at least 8 characters": "la contraseña debe tener al menos 8 caracteres",
+	"password must be at most 72 bytes":      "la contraseña debe tener como máximo 72 bytes",
+	"role must be admin or member":           "el rol debe ser admin o member",
+	"cannot delete your own account":         "no puede eliminar su propia cuenta",
+	"cannot remove the last active admin":    "no se puede quitar el último administrador activo",
+	"invalid lang, use es or en":             "idioma inválido, use es o en",
+	"language must be es, en, or empty":      "el idioma debe ser es, en o vacío",
+
+	// ── Generic ──────────────────────────────────────────────────
+	"internal error":                 "error interno",
+	"invalid request body":           "cuerpo de la solicitud inválido",
+	"invalid JSON body":              "cuerpo JSON inválido",
+	"invalid id":                     "id inválido",
+	"invalid url":                    "URL inválida",
+	"invalid cursor":                 "cursor inválido",
+	"name is required":               "el nombre es obligatorio",
+	"url is required":                "la URL es obligatoria",
+	"content is required":            "el contenido es obligatorio",
+	"topic is required":              "el tema es obligatorio",
+	"question is required":           "la pregunta es obligatoria",
+	"keywords are required":          "las palabras clave son obligatorias",
+	"enabled is required":            "enabled es obligatorio",
+	"domain is required":             "el dominio es obligatorio",
+	"at least one id is required":    "se requiere al menos un id",
+	"days must be a positive number": "days debe ser un número positivo",
+	"unknown service":                "servicio desconocido",
+	"page not found":                 "página no encontrada",
+	"AI not configured":              "la IA no está configurada",
+	"AI failed to respond":           "la IA no respondió",
+
+	// ── Invalid identifiers ──────────────────────────────────────
+	"invalid article id":     "id de artículo inválido",
+	"invalid brief id":       "id de resumen inválido",
+	"invalid command id":     "id de comando inválido",
+	"invalid domain_id":      "domain_id inválido",
+	"invalid entity name":    "nombre de entidad inválido",
+	"invalid entityId":       "entityId inválido",
+	"invalid escrito id":     "id de escrito inválido",
+	"invalid hit id":         "id de alerta inválido",
+	"invalid report id":      "id de informe inválido",
+	"invalid key id":         "id de clave inválido",
+	"invalid mute id":        "id de silenciado inválido",
+	"invalid note id":        "id de nota inválido",
+	"invalid opportunity id": "id de oportunidad inválido",
+	"invalid org id":         "id de organización inválido",
+	"invalid org_id":         "org_id inválido",
+	"invalid project id":     "id de proyecto inválido",
+	"invalid rule id":        "id de regla inválido",
+	"invalid session id":     "id de sesión inválido",
+	"invalid source id":      "id de fuente inválido",
+	"invalid user id":        "id de usuario inválido",
+	"invalid webhook id":     "id de webhook inválido",
+
+	// ── Not found ────────────────────────────────────────────────
+	"article not found":      "artículo no encontrado",
+	"brief not found":        "resumen no encontrado",
+	"bundle not found":       "paquete no encontrado",
+	"command not found":      "comando no encontrado",
+	"domain not found":       "dominio no encontrado",
+	"entity not found":       "entidad no encontrada",
+	"escrito not found":      "escrito no encontrado",
+	"flag not found":         "flag no encontrado",
+	"flag or user not found": "flag o usuario no encontrado",
+	"hit not found":          "alerta no encontrada",
+	"item not found":         "artículo no encontrado",
+	"job not found":          "tarea no encontrada",
+	"key not found":          "clave no encontrada",
+	"muted story not found":  "historia silenciada no encontrada",
+	"note not found":         "nota no encontrada",
+	"opportunity not found":  "oportunidad no encontrada",
+	"org not found":          "organización no encontrada",
+	"override not found":     "excepción no encontrada",
+	"project not found":      "proyecto no encontrado",
+	"report not found":       "informe no encontrado",
+	"rule not found":         "regla no encontrada",
+	"session not found":      "sesión no encontrada",
+	"source not found":       "fuente no encontrada",
+	"suggestion not found":   "sugerencia no encontrada",
+	"tag not found":          "etiqueta no encontrada",
+	"user not found":         "usuario no encontrado",
+	"webhook not found":      "webhook no encontrado",
+	"no briefs available":    "no hay resúmenes disponibles",
+	"no digest yet":          "todavía no hay resumen",
+
+	// ── Could not / failed ───────────────────────────────────────
+	"could not apply suggestions":     "no se pudieron aplicar las sugerencias",
+	"could not calculate score":       "no se pudo calcular la puntuación",
+	"could not collect item":          "no se pudo recopilar el artículo",
+	"could not create escrito":        "no se pudo crear el escrito",
+	"could not create flag":           "no se pudo crear el flag",
+	"could not create key":            "no se pudo crear la clave",
+	"could not create note":           "no se pudo crear la nota",
+	"could not create org":            "no se pudo crear la organización",
+	"could not create project":        "no se pudo crear el proyecto",
+	"could not create rule":           "no se pudo crear la regla",
+	"could not create session":        "no se pudo crear la sesión",
+	"could not create source":         "no se pudo crear la fuente",
+	"could not create tag":            "no se pudo crear la etiqueta",
+	"could not create user":           "no se pudo crear el usuario",
+	"could not delete escrito":        "no se pudo eliminar el escrito",
+	"could not delete note":           "no se pudo eliminar la nota",
+	"could not delete project":        "no se pudo eliminar el proyecto",
+	"could not delete session":        "no se pudo eliminar la sesión",
+	"could not delete sessions":       "no se pudieron eliminar las sesiones",
+	"could not delete source":         "no se pudo eliminar la fuente",
+	"could not delete user":           "no se pudo eliminar el usuario",
+	"could not embed query":           "no se pudo vectorizar la consulta",
+	"could not find similar articles": "no se pudieron buscar artículos similares",
+	"could not import orgs":           "no se pudieron importar las organizaciones",
+	"could not import sources":        "no se pudieron importar las fuentes",
+	"could not install bundle":        "no se pudo instalar el paquete",
+	"could not pin item":              "no se pudo fijar el artículo",
+	"could not re-fetch escrito":      "no se pudo volver a cargar el escrito",
+	"could not record tip":            "no se pudo registrar el aviso",
+	"could not reset escrito":         "no se pudo reiniciar el escrito",
+	"could not restore item":          "no se pudo restaurar el artículo",
+	"could not save item":             "no se pudo guardar el artículo",
+	"could not save score":            "no se pudo guardar la puntuación",
+	"could not toggle source":         "no se pudo activar o desactivar la fuente",
+	"could not archive source":        "no se pudo archivar la fuente",
+	"could not unarchive source":      "no se pudo desarchivar la fuente",
+	"could not trash item":            "no se pudo enviar el artículo a la papelera",
+	"could not undo item":             "no se pudo deshacer el cambio",
+	"could not update escrito":        "no se pudo actualizar el escrito",
+	"could not update retention":      "no se pudo actualizar la retención",
+	"could not update semantic watch": "no se pudo actualizar la vigilancia semántica",
+	"could not update session":        "no se pudo actualizar la sesión",
+	"could not update source":         "no se pudo actualizar la fuente",
+	"could not update status":         "no se pudo actualizar el estado",
+	"could not update user":           "no se pudo actualizar el usuario",
+	"failed to clear garbage data":    "no se pudieron borrar los datos basura",
+	"failed to create domain":         "no se pudo crear el dominio",
+	"failed to create webhook":        "no se pudo crear el webhook",
+	"failed to generate feed token":   "no se pudo generar el enlace del feed",
+	"failed to regenerate feed token": "no se pudo regenerar el enlace del feed",
+	"failed to list articles":         "no se pudieron listar los artículos",
+	"failed to list sources":          "no se pudieron listar las fuentes",
+	"failed to render docx":           "no se pudo generar el documento Word",
+	"failed to render pdf":            "no se pudo generar el PDF",
+	"failed to update org":            "no se pudo actualizar la organización",
+	"failed to update webhook":        "no se pudo actualizar el webhook",
+	"preview failed":                  "la vista previa falló",
+	"search failed":                   "la búsqueda falló",
+	"image unavailable":               "imagen no disponible",
+	"article has no image":            "el artículo no tiene imagen",
+
+	// ── Analytics ────────────────────────────────────────────────
+	"failed to query article volume":         "no se pudo consultar el volumen de artículos",
+	"failed to scan article volume":          "no se pudo leer el volumen de artículos",
+	"failed to iterate article volume":       "no se pudo recorrer el volumen de artículos",
+	"failed to query co-occurrences":         "no se pudieron consultar las coocurrencias",
+	"failed to scan co-occurrences":          "no se pudieron leer las coocurrencias",
+	"failed to iterate co-occurrences":       "no se pudieron recorrer las coocurrencias",
+	"failed to query org hits":               "no se pudieron consultar las alertas por organización",
+	"failed to scan org hits":                "no se pudieron leer las alertas por organización",
+	"failed to iterate org hits":             "no se pudieron recorrer las alertas por organización",
+	"failed to query region volume":          "no se pudo consultar el volumen por región",
+	"failed to scan region volume":           "no se pudo leer el volumen por región",
+	"failed to iterate region volume":        "no se pudo recorrer el volumen por región",
+	"failed to query sentiment distribution": "no se pudo consultar la distribución de sentimiento",
+	"failed to scan sentiment":               "no se pudo leer el sentimiento",
+	"failed to iterate sentiment":            "no se pudo recorrer el sentimiento",
+	"failed to query source health":          "no se pudo consultar el estado de las fuentes",
+	"failed to scan source health":           "no se pudo leer el estado de las fuentes",
+	"failed to iterate source health":        "no se pudo recorrer el estado de las fuentes",
+	"failed to query tag trends":             "no se pudieron consultar las tendencias de etiquetas",
+	"failed to scan tag trends":              "no se pudieron leer las tendencias de etiquetas",
+	"failed to iterate tag trends":           "no se pudieron recorrer las tendencias de etiquetas",
+	"failed to query top entities":           "no se pudieron consultar las entidades principales",
+	"failed to scan top entities":            "no se pudieron leer las entidades principales",
+	"failed to iterate top entities":         "no se pudieron recorrer las entidades principales",
+
+	// ── Not configured ───────────────────────────────────────────
+	"audit log not configured":                  "el registro de auditoría no está configurado",
+	"evidence storage not configured":           "el almacenamiento de evidencia no está configurado",
+	"evidence store unavailable":                "el almacenamiento de evidencia no está disponible",
+	"feed access log not configured":            "el registro de accesos al feed no está configurado",
+	"fetch log not configured":                  "el registro de descargas no está configurado",
+	"ingestion history not configured":          "el historial de ingesta no está configurado",
+	"watchlist not configured":                  "la vigilancia no está configurada",
+	"worker control not configured":             "el control del worker no está configurado",
+	"worker not running: job %s not registered": "el worker no está en ejecución: la tarea %s no está registrada",
+	"url must resolve to a public address":      "la url debe resolver a una dirección pública",
+	"delivery failed: status %d":                "la entrega falló: estado %d",
+	"delivery failed":                           "la entrega falló",
+
+	// ── Validation ───────────────────────────────────────────────
+	"%s search is not enabled for your account":                             "la búsqueda %s no está habilitada para su cuenta",
+	"q is required for %s search":                                           "q es obligatorio para la búsqueda %s",
+	"missing q parameter":                                                   "falta el parámetro q",
+	"query is required (max 200 characters)":                                "la consulta es obligatoria (máximo 200 caracteres)",
+	"text is required (max %d characters)":                                  "el texto es obligatorio (máximo %d caracteres)",
+	"instructions required":                                                 "se requieren instrucciones",
+	"no content to improve":                                                 "no hay contenido que mejorar",
+	"'to' must not be before 'from'":                                        "'to' no puede ser anterior a 'from'",
+	"'to' must be after 'from'":                                             "'to' debe ser posterior a 'from'",
+	"invalid 'from' date, use RFC3339 or YYYY-MM-DD":                        "fecha 'from' inválida, use RFC3339 o AAAA-MM-DD",
+	"invalid 'from' date, use YYYY-MM-DD":                                   "fecha 'from' inválida, use AAAA-MM-DD",
+	"invalid 'to' date, use RFC3339 or YYYY-MM-DD":                          "fecha 'to' inválida, use RFC3339 o AAAA-MM-DD",
+	"invalid 'to' date, use YYYY-MM-DD":                                     "fecha 'to' inválida, use AAAA-MM-DD",
+	"invalid from, use RFC3339 or YYYY-MM-DD":                               "from inválido, use RFC3339 o AAAA-MM-DD",
+	"invalid to, use RFC3339 or YYYY-MM-DD":                                 "to inválido, use RFC3339 o AAAA-MM-DD",
+	"invalid before, use RFC3339":                                           "before inválido, use RFC3339",
+	"invalid as_of, use YYYY-MM-DD or RFC 3339":                             "as_of inválido, use AAAA-MM-DD o RFC 3339",
+	"as_of cannot be combined with cursor or expiring_within":               "as_of no se puede combinar con cursor ni expiring_within",
+	"as_of must not be in the future":                                       "as_of no puede estar en el futuro",
+	"cursor does not match this search":                                     "el cursor no corresponde a esta búsqueda",
+	"cursor is not supported with expiring_within, use offset":              "cursor no se admite con expiring_within, use offset",
+	"cursor is only supported for fulltext search, use offset":              "cursor solo se admite en la búsqueda de texto completo, use offset",
+	"expiring_within must be a positive number of days":                     "expiring_within debe ser un número positivo de días",
+	"invalid mode, use fulltext, semantic, or hybrid":                       "modo inválido, use fulltext, semantic o hybrid",
+	"invalid scope, use local, federal, or diaspora":                        "alcance inválido, use local, federal o diaspora",
+	"invalid type, use person, organization, or place":                      "tipo inválido, use person, organization o place",
+	"invalid language, use es or en":                                        "idioma inválido, use es o en",
+	"invalid model":                                                         "modelo inválido",
+	"date range must not exceed %d days":                                    "el rango de fechas no debe exceder %d días",
+	"invalid format, use csv or json":                                       "formato inválido, use csv o json",
+	"format must be zip or pdf":                                             "el formato debe ser zip o pdf",
+	"invalid group, use story":                                              "agrupación inválida, use story",
+	"invalid status, use draft, approved, or sent":                          "estado inválido, use draft, approved o sent",
+	"invalid publish_status":                                                "publish_status inválido",
+	"invalid seen, use true or false":                                       "seen inválido, use true o false",
+	"invalid sentiment, use positive, negative, neutral, or unknown":        "sentimiento inválido, use positive, negative, neutral o unknown",
+	"invalid source_type":                                                   "source_type inválido",
+	"invalid social page %q, use facebook:<page> or instagram:<account id>": "página social %q inválida, use facebook:<página> o instagram:<id de cuenta>",
+	"invalid OPML: %v":                                                      "OPML inválido: %v",
+	"no feeds found in OPML":                                                "no se encontraron feeds en el OPML",
+	"entity parameter is required":                                          "el parámetro entity es obligatorio",
+	"action must be save or trash":                                          "action debe ser save o trash",
+	"action must be trash or save":                                          "action debe ser trash o save",
+	"action would not change the status":                                    "la acción no cambiaría el estado",
+	"from_status must be inbox or saved":                                    "from_status debe ser inbox o saved",
+	"tag or source is required":                                             "se requiere una etiqueta o una fuente",
+	"filter must select something; set at least one field":                  "el filtro debe seleccionar algo; indique al menos un campo",
+	"filter.expiring_within_days must not be negative":                      "filter.expiring_within_days no puede ser negativo",
+	"filter.policy must be ret_3m, ret_6m, ret_12m, or keep":                "filter.policy debe ser ret_3m, ret_6m, ret_12m o keep",
+	"invalid filter.from, use YYYY-MM-DD":                                   "filter.from inválido, use AAAA-MM-DD",
+	"invalid filter.to, use YYYY-MM-DD":                                     "filter.to inválido, use AAAA-MM-DD",
+	"min_age_days must not be negative":                                     "min_age_days no puede ser negativo",
+	"policy must be ret_6m, ret_12m, or keep":                               "policy debe ser ret_6m, ret_12m o keep",
+	"artifact must be raw, extracted or screenshot":                         "artifact debe ser raw, extracted o screenshot",
+	"no %s evidence for this article":                                       "no hay evidencia %s para este artículo",
+	"no evidence captured for this article":                                 "no se capturó evidencia para este artículo",
+	"item is not in the trash":                                              "el artículo no está en la papelera",
+	"maximum 100 articles per export":                                       "máximo 100 artículos por exportación",
+	"min_award must be a whole number of dollars":                           "min_award debe ser un número entero de dólares",
+	"sort must be deadline or amount":                                       "sort debe ser deadline o amount",
+	"tracking must be pursuing, declined or none":                           "tracking debe ser pursuing, declined o none",
+	"tracking must be pursuing, declined or null":                           "tracking debe ser pursuing, declined o null",
+	"min_similarity must be between 0 and 1":                                "min_similarity debe estar entre 0 y 1",
+	"semantic_threshold must be between 0 and 1":                            "semantic_threshold debe estar entre 0 y 1",
+	"rollout_percent must be between 0 and 100":                             "rollout_percent debe estar entre 0 y 100",
+	"minutes must be between 1 and 1440":                                    "minutes debe estar entre 1 y 1440",
+	"days must be between 1 and 365":                                        "days debe estar entre 1 y 365",
+	"priority must be 0 (low), 1 (normal), or 2 (high)":                     "la prioridad debe ser 0 (baja), 1 (normal) o 2 (alta)",
+	"level must be debug, info, warn, or error":                             "level debe ser debug, info, warn o error",
+	"name must be lowercase letters, digits, and hyphens":                   "el nombre debe tener solo minúsculas, dígitos y guiones",
+	"name must be lowercase letters, digits, and underscores":               "el nombre debe tener solo minúsculas, dígitos y guiones bajos",
+	"name, base_url, region, and feed_type are required":                    "name, base_url, region y feed_type son obligatorios",
+	"feed_url is required for api sources":                                  "feed_url es obligatorio para las fuentes api",
+	"api_mapping is required for api sources":                               "api_mapping es obligatorio para las fuentes api",
+	"kind must be slack, discord, or generic":                               "kind debe ser slack, discord o generic",
+	"url must be an absolute http(s) URL":                                   "la URL debe ser absoluta y http(s)",
+	"url or description is required":                                        "se requiere una URL o una descripción",
+	"url, title, or snippet is required":                                    "se requiere url, title o snippet",
+	"description is too long":                                               "la descripción es demasiado larga",
+	"title and submitter fields must be at most 200 characters":             "el título y los datos del remitente deben tener como máximo 200 caracteres",
+	"tag already exists":                                                    "la etiqueta ya existe",
+	"flag already exists":                                                   "el flag ya existe",
+	"source is archived; unarchive it first":                                "la fuente está archivada; desarchívela primero",
+	"source has articles attributed to it; archive it instead":              "la fuente tiene artículos atribuidos; archívela en su lugar",
+	"sentiment must be positive, neutral, or negative":                      "el sentimiento debe ser positive, neutral o negative",
+	"a re-classification is already running":                                "ya hay una reclasificación en curso",
+	"project is not in queued state":                                        "el proyecto no está en cola",
+	"the draft's current status does not allow this change":                 "el estado actual del borrador no permite este cambio",
+	"streaming chat is not enabled for your account, use /api/admin/chat":   "el chat en streaming no está habilitado para su cuenta, use /api/admin/chat",
+
+	// ── Status messages ──────────────────────────────────────────
+	"Ingestion queued; the worker will start it within seconds. New articles will appear shortly.":         "Ingesta en cola; el worker la iniciará en segundos. Los artículos nuevos aparecerán pronto.",
+	"Ingestion already in progress. New articles will appear shortly.":                                     "Ya hay una ingesta en curso. Los artículos nuevos aparecerán pronto.",
+	"Scan queued. Results will appear in a few minutes.":                                                   "Escaneo en cola. Los resultados aparecerán en unos minutos.",
+	"A scan is already in progress. Results will appear in a few minutes.":                                 "Ya hay un escaneo en curso. Los resultados aparecerán en unos minutos.",
+	"Embedding backfill queued; the worker will start it within seconds.":                                  "Generación de embeddings pendientes en cola; el worker la iniciará en segundos.",
+	"Embedding backfill already in progress.":                                                              "Ya hay una generación de embeddings pendientes en curso.",
+	"Re-enrichment started. Articles will be processed in the background.":                                 "Re-enriquecimiento iniciado. Los artículos se procesarán en segundo plano.",
+	"Re-classification started. Hits will be updated in the background.":                                   "Reclasificación iniciada. Las alertas se actualizarán en segundo plano.",
+	"Crawl started in background":                                                                          "Rastreo iniciado en segundo plano",
+	"Regenerating the article from scratch.":                                                               "Regenerando artículo desde cero.",
+	"Improving the article with your instructions.":                                                        "Mejorando artículo con tus instrucciones.",
+	"Research started. Results will appear within minutes.":                                                "Investigación iniciada. Los resultados aparecerán en minutos.",
+	"Could not extract keywords automatically.":                                                            "No se pudieron extraer palabras clave automáticamente.",
+	"Extracted %d keywords automatically.":                                                                 "Se extrajeron %d palabras clave automáticamente.",
+	"RSS feed detected and source created. Articles will appear on next worker cycle.":                     "Se detectó un feed RSS y se creó la fuente. Los artículos aparecerán en el próximo ciclo del worker.",
+	"JSON Feed detected and source created. Articles will appear on next worker cycle.":                    "Se detectó un JSON Feed y se creó la fuente. Los artículos aparecerán en el próximo ciclo del worker.",
+	"No RSS feed detected. Source created as scrape type — configure CSS selectors in Settings > Sources.": "No se detectó un feed RSS. La fuente se creó de tipo scrape — configure los selectores CSS en Configuración > Fuentes.",
+
+	// ── Bot notifications ────────────────────────────────────────
+	"<b>Daily Summary</b>\n\n%s":                                                          "<b>Resumen Diario</b>\n\n%s",
+	"<b>Watchlist Alert: %s</b>\n\n%s\n<a href=\"%s\">View</a>":                           "<b>Alerta Vigilancia: %s</b>\n\n%s\n<a href=\"%s\">Ver</a>",
+	"<b>Watchlist Summary</b>\n\n%s":                                                      "<b>Resumen Vigilancia</b>\n\n%s",
+	"Grant closing soon":                                                                  "Fondos: cierre próximo",
+	"Pursued grant closing soon":                                                          "Fondos en curso: cierre próximo",
+	"<b>%s</b>\n\n%s\n%s · closes %s\n<a href=\"%s\">View</a>":                            "<b>%s</b>\n\n%s\n%s · cierra %s\n<a href=\"%s\">Ver</a>",
+	"<b>Evidence expiring</b>\n\n%d article(s) lose their evidence in the coming days:\n": "<b>Evidencia por expirar</b>\n\n%d artículo(s) pierden su evidencia en los próximos días:\n",
+	"\n• %s (expires %s)":                                                                 "\n• %s (expira %s)",
+	"\n… and %d more":                                                                     "\n… y %d más",
+	"\n\nExtend the retention of anything that must be kept.":                             "\n\nExtienda la retención de lo que deba conservarse.",
+	"<b>RSS feed possibly exposed</b>\n\nYour feed was read from %d different addresses":  "<b>Feed RSS posiblemente expuesto</b>\n\nTu feed fue leído desde %d direcciones distintas",
+	" and linked from other sites %d time(s)":                                             " y enlazado desde otros sitios %d vez/veces",
+	".\n\nThe link was replaced; copy the new one into your reader from Settings.":        ".\n\nEl enlace fue reemplazado; copia el nuevo en tu lector desde Configuración.",
+	".\n\nIf you did not share it, regenerate the link in Settings.":                      ".\n\nSi no lo compartiste, regenera el enlace en Configuración.",
+	"Unformatted notification":                                                            "Notificación sin formato",
+}
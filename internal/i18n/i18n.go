@@ -0,0 +1,101 @@
+// Package i18n translates the user-facing texts of the API: error and status
+// messages, and bot notifications.
+//
+// Messages are keyed by their English text, so English needs no catalog and a
+// message missing from a catalog falls back to English. Format verbs in a
+// key are filled from the arguments passed to T.
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Lang is a supported language code.
+type Lang string
+
+// Supported languages.
+const (
+	ES Lang = "es"
+	EN Lang = "en"
+)
+
+// catalogs maps a language to its translations of the English messages.
+var catalogs = map[Lang]map[string]string{
+	ES: es,
+}
+
+// defaultLang is used when neither the user nor the request picks a language.
+var defaultLang = ES
+
+// SetDefault sets the language used when none is chosen. Unsupported values
+// are ignored. Call it once at startup.
+func SetDefault(lang string) {
+	if l, ok := Parse(lang); ok {
+		defaultLang = l
+	}
+}
+
+// Default returns the language used when none is chosen.
+func Default() Lang {
+	return defaultLang
+}
+
+// Parse returns the supported language for a code such as "en", "en-US" or
+// "es_PR".
+func Parse(s string) (Lang, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(s, "-_"); i >= 0 {
+		s = s[:i]
+	}
+	switch Lang(s) {
+	case ES, EN:
+		return Lang(s), true
+	}
+	return "", false
+}
+
+// FromAcceptLanguage returns the supported language the Accept-Language
+// header prefers most, if any.
+func FromAcceptLanguage(header string) (Lang, bool) {
+	type choice struct {
+		lang Lang
+		q    float64
+	}
+	var choices []choice
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		lang, ok := Parse(tag)
+		if !ok {
+			continue
+		}
+		q := 1.0
+		if v, found := strings.CutPrefix(strings.TrimSpace(params), "q="); found {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if q > 0 {
+			choices = append(choices, choice{lang, q})
+		}
+	}
+	if len(choices) == 0 {
+		return "", false
+	}
+	sort.SliceStable(choices, func(i, j int) bool { return choices[i].q > choices[j].q })
+	return choices[0].lang, true
+}
+
+// T returns msg translated into lang, formatted with args when there are
+// any. Messages without a translation are returned in English.
+func T(lang Lang, msg string, args ...any) string {
+	if translated, ok := catalogs[lang][msg]; ok {
+		msg = translated
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
@@ -0,0 +1,104 @@
+package i18n
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in     string
+		want   Lang
+		wantOK bool
+	}{
+		{"es", ES, true},
+		{"en", EN, true},
+		{"en-US", EN, true},
+		{"es_PR", ES, true},
+		{" ES-419 ", ES, true},
+		{"fr", "", false},
+		{"", "", false},
+		{"*", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Parse(tt.in)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("Parse(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   Lang
+		wantOK bool
+	}{
+		{"empty", "", "", false},
+		{"single", "en", EN, true},
+		{"region subtag", "es-PR", ES, true},
+		{"first wins at equal weight", "en-US,es", EN, true},
+		{"highest q wins", "en;q=0.5,es;q=0.9", ES, true},
+		{"missing q is 1", "es;q=0.8, en", EN, true},
+		{"unsupported languages are skipped", "fr-FR,de;q=0.9,es;q=0.1", ES, true},
+		{"only unsupported", "fr, de;q=0.5", "", false},
+		{"q=0 excludes", "en;q=0, es;q=0.2", ES, true},
+		{"all excluded", "en;q=0", "", false},
+		{"bad q counts as 1", "es;q=0.5, en;q=abc", EN, true},
+		{"wildcard is ignored", "*, es;q=0.3", ES, true},
+		{"spaces around tags", "  fr ;q=0.9 ,  en-GB ; q=0.7", EN, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := FromAcceptLanguage(tt.header)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("FromAcceptLanguage(%q) = %q, %v; want %q, %v", tt.header, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestT(t *testing.T) {
+	tests := []struct {
+		name string
+		lang Lang
+		msg  string
+		args []any
+		want string
+	}{
+		{"english is the key", EN, "delivery failed: status %d", []any{502}, "delivery failed: status 502"},
+		{"translated and formatted", ES, "delivery failed: status %d", []any{502}, "la entrega falló: estado 502"},
+		{"missing translation falls back to english", ES, "no such message %s", []any{"x"}, "no such message x"},
+		{"no args leaves verbs alone", EN, "100%s literal", nil, "100%s literal"},
+		{"unknown language falls back to english", "fr", "delivery failed: status %d", []any{500}, "delivery failed: status 500"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := T(tt.lang, tt.msg, tt.args...); got != tt.want {
+				t.Errorf("T(%q, %q) = %q, want %q", tt.lang, tt.msg, got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCatalogVerbs checks that every translation keeps the format verbs of
+// its English key, in order, so T formats both the same way.
+func TestCatalogVerbs(t *testing.T) {
+	verb := regexp.MustCompile(`%[-+# 0]*[0-9]*(\.[0-9]+)?[a-zA-Z%]`)
+	for lang, catalog := range catalogs {
+		for key, translated := range catalog {
+			want, got := verb.FindAllString(key, -1), verb.FindAllString(translated, -1)
+			if len(want) != len(got) {
+				t.Errorf("%s: %q has verbs %v, translation %q has %v", lang, key, want, translated, got)
+				continue
+			}
+			for i := range want {
+				if want[i] != got[i] {
+					t.Errorf("%s: %q has verbs %v, translation %q has %v", lang, key, want, translated, got)
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,217 @@
+package intelligence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// AgentDeps are the dependencies of the tool-calling chat agent.
+type AgentDeps struct {
+	Deps
+	Hits *models.WatchlistHitStore
+	// Collect stores the page at rawURL as an inbox article (or returns the
+	// article already stored under it) and reports whether it was new.
+	Collect func(ctx context.Context, rawURL, title string) (*models.Article, bool, error)
+}
+
+// AgentRequest is the input for a tool-calling chat turn.
+type AgentRequest struct {
+	UserID   uuid.UUID // whose watchlist the hit tool lists
+	Question string
+	Model    string // default "llama3.2:3b"
+}
+
+// AgentResponse is the output of Agent: the answer and the tools called to
+// reach it, in order.
+type AgentResponse struct {
+	Answer    string        `json:"answer"`
+	ToolCalls []ai.ToolCall `json:"tool_calls"`
+}
+
+const (
+	// agentMaxResults caps the articles or hits a tool lists.
+	agentMaxResults = 10
+
+	// agentArticleTextLen caps the article text get_article returns.
+	agentArticleTextLen = 2500
+)
+
+// Agent answers a question with the model free to call the agent tools:
+// search_articles, get_article, list_watchlist_hits and collect_url. Only
+// collect_url changes anything, and it only adds inbox articles.
+func Agent(ctx context.Context, deps AgentDeps, req AgentRequest) (*AgentResponse, error) {
+	if req.Model == "" {
+		req.Model = "llama3.2:3b"
+	}
+
+	profile := coverage.Current()
+	systemPrompt := `Eres el asistente de Folio, un archivo de noticias de ` + profile.Name + `. Usa las herramientas para buscar la información que necesites antes de responder.
+
+REGLAS:
+1. Basa tu respuesta SOLO en los resultados de las herramientas; NO inventes información.
+2. Usa collect_url solo si el usuario pide guardar o recolectar un enlace.
+3. Responde en ` + profile.LanguageName() + `, breve y directo.`
+
+	answer, calls, err := deps.AI.RunTools(ai.WithTask(ctx, ai.TaskChat), req.Model, systemPrompt, req.Question, agentTools(deps, req.UserID))
+	if err != nil {
+		return nil, fmt.Errorf("agent: %w", err)
+	}
+	if calls == nil {
+		calls = []ai.ToolCall{}
+	}
+	return &AgentResponse{Answer: answer, ToolCalls: calls}, nil
+}
+
+// agentTools returns the tools available to userID's agent.
+func agentTools(deps AgentDeps, userID uuid.UUID) []ai.Tool {
+	tools := []ai.Tool{
+		{
+			Name:        "search_articles",
+			Description: "Busca artículos guardados por significado; devuelve id, título, fuente, fecha y resumen.",
+			Params: map[string]string{
+				"query": "texto a buscar (requerido)",
+				"limit": "máximo de artículos, 1-10 (por defecto 5)",
+			},
+			Run: func(ctx context.Context, args map[string]any) (string, error) {
+				return searchArticlesTool(ctx, deps.Deps, args)
+			},
+		},
+		{
+			Name:        "get_article",
+			Description: "Devuelve el texto completo de un artículo.",
+			Params:      map[string]string{"id": "id del artículo (requerido)"},
+			Run: func(ctx context.Context, args map[string]any) (string, error) {
+				return getArticleTool(ctx, deps.Articles, args)
+			},
+		},
+	}
+	if deps.Hits != nil {
+		tools = append(tools, ai.Tool{
+			Name:        "list_watchlist_hits",
+			Description: "Lista las menciones recientes de las organizaciones que vigila el usuario.",
+			Params: map[string]string{
+				"sentiment":   "positive, negative, neutral o unknown",
+				"source_type": "tipo de fuente, por ejemplo google_news o reddit",
+				"limit":       "máximo de menciones, 1-10 (por defecto 10)",
+			},
+			Run: func(ctx context.Context, args map[string]any) (string, error) {
+				return listHitsTool(ctx, deps.Hits, userID, args)
+			},
+		})
+	}
+	if deps.Collect != nil {
+		tools = append(tools, ai.Tool{
+			Name:        "collect_url",
+			Description: "Guarda una página web en la bandeja de entrada del archivo.",
+			Params: map[string]string{
+				"url":   "URL http(s) de la página (requerido)",
+				"title": "título de la página",
+			},
+			Run: func(ctx context.Context, args map[string]any) (string, error) {
+				return collectURLTool(ctx, deps.Collect, args)
+			},
+		})
+	}
+	return tools
+}
+
+func searchArticlesTool(ctx context.Context, deps Deps, args map[string]any) (string, error) {
+	query := ai.ArgString(args, "query")
+	if query == "" {
+		return "", errors.New("query is required")
+	}
+	limit := clampResults(ai.ArgInt(args, "limit", 5))
+
+	articles, _, _ := retrieveForAsk(ctx, deps, AskRequest{Question: query, TopK: limit})
+	if len(articles) == 0 {
+		return "Sin resultados.", nil
+	}
+	var b strings.Builder
+	for _, a := range articles {
+		date := a.CreatedAt
+		if a.PublishedAt != nil {
+			date = *a.PublishedAt
+		}
+		fmt.Fprintf(&b, "- id=%s | %s | %s | %s\n", a.ID, a.Title, a.Source, date.Format("2006-01-02"))
+		if a.Summary != "" {
+			fmt.Fprintf(&b, "  %s\n", a.Summary)
+		}
+	}
+	return b.String(), nil
+}
+
+func getArticleTool(ctx context.Context, articles *models.ArticleStore, args map[string]any) (string, error) {
+	id, err := uuid.Parse(ai.ArgString(args, "id"))
+	if err != nil {
+		return "", errors.New("invalid article id")
+	}
+	a, err := articles.GetByID(ctx, id)
+	if err != nil || a.Status == "trashed" {
+		return "", errors.New("article not found")
+	}
+
+	text := a.CleanText
+	if text == "" {
+		text = a.Summary
+	}
+	if len(text) > agentArticleTextLen {
+		text = text[:agentArticleTextLen] + "..."
+	}
+	return fmt.Sprintf("%s\n%s — %s\n\n%s", a.Title, a.Source, a.URL, text), nil
+}
+
+func listHitsTool(ctx context.Context, hits *models.WatchlistHitStore, userID uuid.UUID, args map[string]any) (string, error) {
+	filter := models.HitFilter{
+		Sentiment:  ai.ArgString(args, "sentiment"),
+		SourceType: ai.ArgString(args, "source_type"),
+	}
+	list, err := hits.ListRecentByUser(ctx, userID, filter, clampResults(ai.ArgInt(args, "limit", agentMaxResults)))
+	if err != nil {
+		return "", errors.New("could not list hits")
+	}
+	if len(list) == 0 {
+		return "Sin menciones.", nil
+	}
+	var b strings.Builder
+	for _, h := range list {
+		fmt.Fprintf(&b, "- [%s] %s | %s | %s | %s\n  %s\n",
+			h.OrgName, h.Title, h.Sentiment, h.SourceType, h.CreatedAt.Format("2006-01-02"), h.URL)
+	}
+	return b.String(), nil
+}
+
+func collectURLTool(ctx context.Context, collect func(context.Context, string, string) (*models.Article, bool, error), args map[string]any) (string, error) {
+	rawURL := ai.ArgString(args, "url")
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", errors.New("url must be an absolute http(s) URL")
+	}
+	a, created, err := collect(ctx, rawURL, ai.ArgString(args, "title"))
+	if err != nil {
+		return "", errors.New("could not collect url")
+	}
+	if !created {
+		return fmt.Sprintf("Ya estaba en el archivo: id=%s (%s)", a.ID, a.Status), nil
+	}
+	return fmt.Sprintf("Guardado en la bandeja de entrada: id=%s", a.ID), nil
+}
+
+// clampResults bounds a tool's result count to 1..agentMaxResults.
+func clampResults(n int) int {
+	if n < 1 {
+		return 1
+	}
+	if n > agentMaxResults {
+		return agentMaxResults
+	}
+	return n
+}
@@ -0,0 +1,129 @@
+package intelligence
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+const (
+	// askDefaultTopK and askMaxTopK bound the articles Ask retrieves.
+	askDefaultTopK = 8
+	askMaxTopK     = 20
+
+	// askMinRelevance is the lowest vector relevance (1 - distance/2) an
+	// article needs to be used as context.
+	askMinRelevance = 0.55
+
+	// askSnippetLen caps the article text given per source when it has no
+	// summary.
+	askSnippetLen = 600
+)
+
+// Ask answers a question from the stored articles most similar to it: the
+// question is embedded, the top-k closest articles by pgvector cosine
+// distance are retrieved, and the model answers from their summaries only,
+// citing them as [N]. When the question can't be embedded or no article is
+// similar enough, retrieval falls back to the keyword search Chat uses.
+// Unlike Chat, Ask never searches the web.
+func Ask(ctx context.Context, deps Deps, req AskRequest) (*AskResponse, error) {
+	if req.TopK <= 0 {
+		req.TopK = askDefaultTopK
+	}
+	if req.TopK > askMaxTopK {
+		req.TopK = askMaxTopK
+	}
+	if req.Model == "" {
+		req.Model = "llama3.2:3b"
+	}
+
+	articles, relevances, retrieval := retrieveForAsk(ctx, deps, req)
+
+	resp := &AskResponse{Retrieval: retrieval, Sources: make([]AskSource, len(articles))}
+	var sb strings.Builder
+	for i, a := range articles {
+		src := AskSource{
+			ID:          a.ID,
+			Title:       a.Title,
+			Source:      a.Source,
+			URL:         a.URL,
+			Summary:     a.Summary,
+			PublishedAt: a.PublishedAt,
+		}
+		if relevances != nil {
+			src.Relevance = relevances[i]
+		}
+		resp.Sources[i] = src
+
+		date := a.CreatedAt
+		if a.PublishedAt != nil {
+			date = *a.PublishedAt
+		}
+		sb.WriteString(fmt.Sprintf("[%d] %s (%s, %s)\n", i+1, a.Title, a.Source, date.Format("2006-01-02")))
+		if text := askArticleText(a); text != "" {
+			sb.WriteString(text + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(articles) == 0 {
+		sb.WriteString("(No hay artículos relevantes.)\n")
+	}
+
+	profile := coverage.Current()
+	systemPrompt := `Eres analista de noticias de ` + profile.Name + `. Responde la pregunta usando SOLO los artículos numerados de abajo.
+
+REGLAS:
+1. Cita cada dato con el número del artículo entre corchetes, por ejemplo [1] o [2][3].
+2. Menciona nombres, fechas y hechos específicos de los artículos.
+3. Si los artículos no contienen la respuesta, dilo claramente; NO inventes información.
+4. Responde en ` + profile.LanguageName() + `, breve y directo.
+
+ARTÍCULOS:
+` + sb.String()
+
+	answer, err := deps.AI.GenerateWithModel(ai.WithTask(ctx, ai.TaskChat), req.Model, systemPrompt, req.Question)
+	if err != nil {
+		return nil, fmt.Errorf("ask: AI generate: %w", err)
+	}
+	resp.Answer = strings.TrimSpace(answer)
+	return resp, nil
+}
+
+// retrieveForAsk returns the articles to answer req from, their vector
+// relevances (nil for keyword retrieval) and the retrieval method used.
+func retrieveForAsk(ctx context.Context, deps Deps, req AskRequest) ([]models.Article, []float64, string) {
+	embedding, err := deps.AI.Embed(ctx, req.Question)
+	if err != nil {
+		slog.Warn("ask: embed question, falling back to keyword search", "err", err)
+	} else {
+		articles, relevances, err := deps.Articles.SearchByVector(ctx, embedding, req.TopK, askMinRelevance)
+		if err != nil {
+			slog.Error("ask: vector search", "err", err)
+		} else if len(articles) > 0 {
+			return articles, relevances, "vector"
+		}
+	}
+
+	articles, err := deps.Articles.SearchChat(ctx, req.Question, req.TopK)
+	if err != nil {
+		slog.Error("ask: keyword search", "err", err)
+	}
+	return articles, nil, "keyword"
+}
+
+// askArticleText returns the article's summary, or the start of its text.
+func askArticleText(a models.Article) string {
+	if a.Summary != "" {
+		return a.Summary
+	}
+	if len(a.CleanText) > askSnippetLen {
+		return a.CleanText[:askSnippetLen] + "..."
+	}
+	return a.CleanText
+}
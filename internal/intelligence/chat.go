@@ -8,6 +8,8 @@ import (
 	"sync"
 
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/scraper"
 )
@@ -15,12 +17,51 @@ import (
 // Deps groups dependencies needed by the intelligence package.
 type Deps struct {
 	Articles *models.ArticleStore
-	AI       *ai.OllamaClient
+	AI       ai.Provider
 }
 
 // Chat performs AI-powered news chat: searches local DB, runs web search, and
 // generates an AI response.
 func Chat(ctx context.Context, deps Deps, req ChatRequest) (*ChatResponse, error) {
+	pc := prepareChat(ctx, deps, &req)
+
+	// Use the specified model for interactive chat.
+	answer, err := deps.AI.GenerateWithModel(ai.WithTask(ctx, ai.TaskChat), req.Model, pc.systemPrompt, req.Question)
+	if err != nil {
+		return nil, fmt.Errorf("chat: AI generate: %w", err)
+	}
+
+	return buildChatResponse(ctx, deps, pc, answer), nil
+}
+
+// ChatStream is like Chat but forwards each generated token to onToken as the
+// model produces it. The returned ChatResponse carries the full answer and the
+// source links, which are only known once generation has finished.
+func ChatStream(ctx context.Context, deps Deps, req ChatRequest, onToken ai.TokenFunc) (*ChatResponse, error) {
+	pc := prepareChat(ctx, deps, &req)
+
+	answer, err := deps.AI.GenerateStream(ai.WithTask(ctx, ai.TaskChat), req.Model, pc.systemPrompt, req.Question, onToken)
+	if err != nil {
+		return nil, fmt.Errorf("chat: AI stream: %w", err)
+	}
+
+	return buildChatResponse(ctx, deps, pc, answer), nil
+}
+
+// chatContext holds the retrieval results and prompt assembled for a chat
+// question before generation.
+type chatContext struct {
+	searched     []models.Article
+	merged       []models.Article
+	webResults   []scraper.WebResult
+	systemPrompt string
+}
+
+// prepareChat applies request defaults, gathers local and web context, and
+// builds the system prompt for a chat question.
+func prepareChat(ctx context.Context, deps Deps, req *ChatRequest) *chatContext {
+	ctx = fetchlog.WithPurpose(ctx, fetchlog.Chat)
+
 	// Apply defaults.
 	if req.MaxArticles == 0 {
 		req.MaxArticles = 15
@@ -92,8 +133,8 @@ func Chat(ctx context.Context, deps Deps, req ChatRequest) (*ChatResponse, error
 		}
 	}
 
-	// Filter out results that are clearly not about Puerto Rico. Cap at 10.
-	allWebResults = filterPRResults(allWebResults)
+	// Filter out results that are clearly not about the covered jurisdiction. Cap at 10.
+	allWebResults = filterRegionResults(allWebResults)
 	slog.Info("chat: web search results", "count", len(allWebResults))
 
 	slog.Info("chat: context built", "searched", len(searched), "recent", len(recent), "merged", len(merged), "web", len(allWebResults))
@@ -137,28 +178,37 @@ func Chat(ctx context.Context, deps Deps, req ChatRequest) (*ChatResponse, error
 
 	newsContext := sb.String()
 
-	systemPrompt := `Eres analista de noticias de Puerto Rico. Tu trabajo es RESUMIR la informacion de las fuentes que se te proporcionan abajo.
+	profile := coverage.Current()
+	systemPrompt := `Eres analista de noticias de ` + profile.Name + `. Tu trabajo es RESUMIR la informacion de las fuentes que se te proporcionan abajo.
 
 REGLAS ESTRICTAS:
 1. LEE TODOS los resultados abajo (locales e internet). La respuesta ESTA en esos resultados.
 2. RESUME lo que dicen los titulos y snippets. NO digas "no encontre" si hay resultados relevantes abajo.
 3. Menciona los nombres, fechas y hechos especificos que aparecen en los titulos.
-4. IGNORA resultados que NO sean sobre Puerto Rico (otros paises, Republica Dominicana, etc.) a menos que mencionen a PR directamente.
+4. IGNORA resultados que NO sean sobre ` + profile.Name + ` (otros paises o regiones) a menos que lo mencionen directamente.
 5. SOLO di "No encontre informacion" si NINGUNO de los resultados abajo es relevante a la pregunta.
-6. Responde en espanol, breve y directo.
+6. Responde en ` + profile.LanguageName() + `, breve y directo.
 7. NO inventes informacion. Solo usa lo que aparece en los resultados.
 
 ` + newsContext
+	if history := condenseHistory(req.History); history != "" {
+		systemPrompt += "\n--- CONVERSACION PREVIA (para entender la pregunta de seguimiento) ---\n" + history
+	}
 
-	// Use the specified model for interactive chat.
-	answer, err := deps.AI.GenerateWithModel(ctx, req.Model, systemPrompt, req.Question)
-	if err != nil {
-		return nil, fmt.Errorf("chat: AI generate: %w", err)
+	return &chatContext{
+		searched:     searched,
+		merged:       merged,
+		webResults:   allWebResults,
+		systemPrompt: systemPrompt,
 	}
+}
 
+// buildChatResponse assembles the final response from a generated answer,
+// attaching web sources and the local articles the answer references.
+func buildChatResponse(ctx context.Context, deps Deps, pc *chatContext, answer string) *ChatResponse {
 	// Build web source links with savable flag.
 	var webSources []WebSource
-	for _, wr := range allWebResults {
+	for _, wr := range pc.webResults {
 		savable := true
 		exists, err := deps.Articles.ExistsByURL(ctx, wr.URL)
 		if err == nil && exists {
@@ -181,7 +231,7 @@ REGLAS ESTRICTAS:
 	// actually references (simple substring check), to avoid showing irrelevant noise.
 	var sources []LocalSource
 	answerLower := strings.ToLower(answer)
-	for _, a := range searched {
+	for _, a := range pc.searched {
 		if len(sources) >= 5 {
 			break
 		}
@@ -200,20 +250,52 @@ REGLAS ESTRICTAS:
 
 	return &ChatResponse{
 		Answer:       answer,
-		ArticlesUsed: len(merged),
+		ArticlesUsed: len(pc.merged),
 		Sources:      sources,
 		WebSources:   webSources,
-	}, nil
+	}
+}
+
+const (
+	// historyTurns is how many of the latest messages condenseHistory keeps.
+	historyTurns = 6
+
+	// historyTurnLen caps each message kept by condenseHistory.
+	historyTurnLen = 300
+)
+
+// condenseHistory renders the latest historyTurns messages of a conversation
+// for the prompt, each cut to historyTurnLen bytes.
+func condenseHistory(history []ChatTurn) string {
+	if len(history) > historyTurns {
+		history = history[len(history)-historyTurns:]
+	}
+	var sb strings.Builder
+	for _, t := range history {
+		content := strings.TrimSpace(t.Content)
+		if content == "" {
+			continue
+		}
+		if len(content) > historyTurnLen {
+			content = content[:historyTurnLen] + "..."
+		}
+		speaker := "Usuario"
+		if t.Role == "assistant" {
+			speaker = "Asistente"
+		}
+		sb.WriteString(speaker + ": " + content + "\n")
+	}
+	return sb.String()
 }
 
 // buildChatSearchQueries generates 2-3 search queries from the user's question.
 func buildChatSearchQueries(question string) []string {
+	profile := coverage.Current()
 	base := question
-	hasPR := strings.Contains(strings.ToLower(question), "puerto rico") ||
-		strings.Contains(strings.ToLower(question), "PR")
+	mentions := profile.Mentions(question)
 
-	if !hasPR {
-		base += " Puerto Rico"
+	if !mentions {
+		base += " " + profile.Name
 	}
 
 	queries := []string{base}
@@ -226,8 +308,8 @@ func buildChatSearchQueries(question string) []string {
 	if len(words) > 4 {
 		// Take the last 3 significant words as an additional query.
 		short := strings.Join(words[len(words)-3:], " ")
-		if !hasPR {
-			short += " Puerto Rico"
+		if !mentions {
+			short += " " + profile.Name
 		}
 		queries = append(queries, short)
 	}
@@ -245,17 +327,13 @@ var prRelevantDomains = []string{
 	"grants.gov", "fema.gov", "hud.gov",
 }
 
-// prIrrelevantPatterns are patterns that indicate non-PR content to exclude.
-var prIrrelevantPatterns = []string{
-	"dominicana", "dominicano", "santo domingo", "republica dominicana",
-	"mexico", "colombia", "venezuela", "argentina",
-	"spain", "paraguay", "chile", "peru",
-	"cuba", "panama", "ecuador", "bolivia",
-	"google noticias", "news.google.com",
-}
+// aggregatorPatterns indicate aggregator pages rather than articles.
+var aggregatorPatterns = []string{"google noticias", "news.google.com"}
 
-// filterPRResults removes web search results that are clearly not about Puerto Rico.
-func filterPRResults(results []scraper.WebResult) []scraper.WebResult {
+// filterRegionResults removes web search results that are clearly not about
+// the coverage profile's jurisdiction, and aggregator pages.
+func filterRegionResults(results []scraper.WebResult) []scraper.WebResult {
+	profile := coverage.Current()
 	var filtered []scraper.WebResult
 	for _, r := range results {
 		lower := strings.ToLower(r.Title + " " + r.Snippet + " " + r.URL)
@@ -266,19 +344,19 @@ func filterPRResults(results []scraper.WebResult) []scraper.WebResult {
 			continue
 		}
 
-		// Check if it contains irrelevant country/location patterns.
-		irrelevant := false
-		for _, pattern := range prIrrelevantPatterns {
-			if strings.Contains(lower, pattern) {
-				// Exception: if it also mentions Puerto Rico, keep it.
-				if !strings.Contains(lower, "puerto rico") && !strings.Contains(lower, "boricua") {
+		// Skip other countries/regions and aggregators, unless the
+		// jurisdiction is also mentioned.
+		if !profile.Mentions(lower) {
+			irrelevant := profile.IrrelevantPattern(lower) != ""
+			for _, pattern := range aggregatorPatterns {
+				if strings.Contains(lower, pattern) {
 					irrelevant = true
 					break
 				}
 			}
-		}
-		if irrelevant {
-			continue
+			if irrelevant {
+				continue
+			}
 		}
 
 		filtered = append(filtered, r)
@@ -1,10 +1,23 @@
 package intelligence
 
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
 // ChatRequest is the input for an AI chat about news.
 type ChatRequest struct {
 	Question    string
-	MaxArticles int    // default 15
-	Model       string // default "llama3.2:3b"
+	MaxArticles int        // default 15
+	Model       string     // default "llama3.2:3b"
+	History     []ChatTurn // earlier messages of the conversation, oldest first
+}
+
+// ChatTurn is one earlier message of a conversation.
+type ChatTurn struct {
+	Role    string // "user" or "assistant"
+	Content string
 }
 
 // LocalSource is a reference to a locally stored article.
@@ -30,3 +43,29 @@ type ChatResponse struct {
 	Sources      []LocalSource `json:"sources"`
 	WebSources   []WebSource   `json:"web_sources"`
 }
+
+// AskRequest is the input for a question answered from retrieved articles.
+type AskRequest struct {
+	Question string
+	TopK     int    // articles retrieved; default 8, max 20
+	Model    string // default "llama3.2:3b"
+}
+
+// AskSource is an article retrieved to answer a question. The answer cites
+// it as [N], its 1-based position in AskResponse.Sources.
+type AskSource struct {
+	ID          uuid.UUID  `json:"id"`
+	Title       string     `json:"title"`
+	Source      string     `json:"source"`
+	URL         string     `json:"url"`
+	Summary     string     `json:"summary,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+	Relevance   float64    `json:"relevance,omitempty"` // 0-1; set for vector retrieval
+}
+
+// AskResponse is the output of Ask.
+type AskResponse struct {
+	Answer    string      `json:"answer"`
+	Retrieval string      `json:"retrieval"` // "vector" or "keyword"
+	Sources   []AskSource `json:"sources"`
+}
@@ -0,0 +1,188 @@
+// Package logging sets up the process-wide slog logger: the level comes from
+// LOG_LEVEL, high-volume debug messages can be sampled, and an admin can
+// raise or lower the level for a while without a restart.
+//
+//	logging.Setup("worker", cfg.Log, logging.JSON)
+//	go logging.Watch(ctx, models.NewLogLevelStore(pool))
+//
+// Overrides are stored in the log_level_overrides table (see
+// models.LogLevelStore); each process polls its own row every pollInterval.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/Saul-Punybz/folio/internal/config"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// Services whose level can be overridden.
+var Services = []string{"api", "worker", "app", "bot"}
+
+// Format selects the log output encoding.
+type Format int
+
+const (
+	Text Format = iota
+	JSON
+)
+
+// Source supplies a service's active override.
+type Source interface {
+	ActiveLogLevel(ctx context.Context, service string) (*models.LogLevelOverride, error)
+}
+
+// pollInterval is how often Watch checks for an override.
+const pollInterval = 30 * time.Second
+
+var (
+	level   slog.LevelVar
+	base    atomic.Int64 // configured slog.Level
+	service atomic.Pointer[string]
+)
+
+// Setup installs the default logger for service, writing to stdout at the
+// configured level.
+func Setup(name string, cfg config.LogConfig, format Format) {
+	lvl, err := ParseLevel(cfg.Level)
+	if err != nil {
+		lvl = slog.LevelInfo
+	}
+	base.Store(int64(lvl))
+	level.Set(lvl)
+	service.Store(&name)
+
+	opts := &slog.HandlerOptions{Level: &level}
+	var h slog.Handler
+	if format == JSON {
+		h = slog.NewJSONHandler(os.Stdout, opts)
+	} else {
+		h = slog.NewTextHandler(os.Stdout, opts)
+	}
+	if cfg.DebugSample > 1 {
+		h = newSampler(h, cfg.DebugSample)
+	}
+	slog.SetDefault(slog.New(h))
+
+	if err != nil {
+		slog.Warn("ignoring LOG_LEVEL", "err", err)
+	}
+}
+
+// ParseLevel parses debug, info, warn or error.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info", "":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return slog.LevelInfo, fmt.Errorf("unknown log level %q", s)
+}
+
+// LevelName returns the lowercase name of a level.
+func LevelName(l slog.Level) string {
+	return strings.ToLower(l.String())
+}
+
+// Service returns the name passed to Setup.
+func Service() string {
+	if s := service.Load(); s != nil {
+		return *s
+	}
+	return ""
+}
+
+// Level returns the level the process is logging at now.
+func Level() slog.Level {
+	return level.Level()
+}
+
+// BaseLevel returns the configured level, used when there is no override.
+func BaseLevel() slog.Level {
+	return slog.Level(base.Load())
+}
+
+// Watch applies the service's override until ctx is done, returning to the
+// configured level when it expires or is removed.
+func Watch(ctx context.Context, src Source) {
+	Refresh(ctx, src)
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			Refresh(ctx, src)
+		}
+	}
+}
+
+// Refresh applies the service's current override right away. Call it after
+// editing this process's override.
+func Refresh(ctx context.Context, src Source) {
+	o, err := src.ActiveLogLevel(ctx, Service())
+	if err != nil {
+		slog.Warn("logging: load override", "err", err)
+		return
+	}
+
+	want := BaseLevel()
+	if o != nil {
+		if lvl, err := ParseLevel(o.Level); err == nil {
+			want = lvl
+		}
+	}
+	if prev := level.Level(); prev != want {
+		level.Set(want)
+		// Logged at warn so the change shows at every level.
+		slog.Warn("logging: level changed", "from", LevelName(prev), "to", LevelName(want), "override", o != nil)
+	}
+}
+
+// sampler passes on one in every n records below info of each message, so
+// per-article debug logs stay readable during ingestion. Counts are shared
+// by the handlers derived with WithAttrs and WithGroup.
+type sampler struct {
+	next   slog.Handler
+	n      uint64
+	counts *sync.Map // message -> *atomic.Uint64
+}
+
+func newSampler(next slog.Handler, n int) *sampler {
+	return &sampler{next: next, n: uint64(n), counts: &sync.Map{}}
+}
+
+func (s *sampler) Enabled(ctx context.Context, l slog.Level) bool {
+	return s.next.Enabled(ctx, l)
+}
+
+func (s *sampler) Handle(ctx context.Context, r slog.Record) error {
+	if r.Level < slog.LevelInfo {
+		c, _ := s.counts.LoadOrStore(r.Message, new(atomic.Uint64))
+		if (c.(*atomic.Uint64).Add(1)-1)%s.n != 0 {
+			return nil
+		}
+	}
+	return s.next.Handle(ctx, r)
+}
+
+func (s *sampler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &sampler{next: s.next.WithAttrs(attrs), n: s.n, counts: s.counts}
+}
+
+func (s *sampler) WithGroup(name string) slog.Handler {
+	return &sampler{next: s.next.WithGroup(name), n: s.n, counts: s.counts}
+}
@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"context"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// auditRecordTimeout bounds writing one audit entry after the response.
+const auditRecordTimeout = 5 * time.Second
+
+// Audit returns middleware that records every mutating request (any method
+// but GET, HEAD and OPTIONS) and every export in the audit log, with the
+// authenticated user, the matched route, the entity it names and the
+// response status. Must be placed after SessionAuth (or AutoAuth). A failure
+// to record is logged and does not affect the response.
+func Audit(store *models.AuditStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			isExport := r.Method == http.MethodGet && strings.Contains(r.URL.Path, "export")
+			if !auditedMethod(r.Method) && !isExport {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ww := chimw.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+
+			// The route pattern and URL params are only complete once the
+			// router has matched the request, i.e. after next has run.
+			pattern := r.URL.Path
+			var rctx *chi.Context
+			if rctx = chi.RouteContext(r.Context()); rctx != nil && rctx.RoutePattern() != "" {
+				pattern = rctx.RoutePattern()
+			}
+
+			entry := models.AuditEntry{
+				Action:    r.Method + " " + pattern,
+				Status:    ww.Status(),
+				IP:        r.RemoteAddr, // chi's RealIP has applied X-Forwarded-For
+				RequestID: chimw.GetReqID(r.Context()),
+			}
+			if entry.Status == 0 {
+				entry.Status = http.StatusOK
+			}
+			if host, _, err := net.SplitHostPort(entry.IP); err == nil {
+				entry.IP = host
+			}
+			if user := UserFromContext(r.Context()); user != nil {
+				entry.UserID = &user.ID
+				entry.UserEmail = user.Email
+			}
+			entry.Entity, entry.EntityID = auditEntity(pattern, rctx)
+
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), auditRecordTimeout)
+			defer cancel()
+			if err := store.Record(ctx, &entry); err != nil {
+				slog.Warn("audit: record", "action", entry.Action, "err", err)
+			}
+		})
+	}
+}
+
+// auditedMethod reports whether requests with the method change state.
+func auditedMethod(method string) bool {
+	return method != http.MethodGet && method != http.MethodHead && method != http.MethodOptions
+}
+
+// auditEntity names the entity a route acts on: the last URL parameter is
+// its ID and the path segment before it its kind, so
+// "/api/items/{id}/notes/{noteID}" is the note and "/api/items/{id}/trash"
+// the item. Routes without parameters name their last segment.
+func auditEntity(pattern string, rctx *chi.Context) (entity, id string) {
+	segments := strings.Split(strings.Trim(pattern, "/"), "/")
+	last := -1
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, "{") {
+			last = i
+		}
+	}
+	if last < 0 {
+		return segments[len(segments)-1], ""
+	}
+	if last > 0 {
+		entity = segments[last-1]
+	}
+	if rctx != nil {
+		name := strings.Trim(segments[last], "{}")
+		if j := strings.IndexByte(name, ':'); j >= 0 {
+			name = name[:j] // {id:[0-9]+}
+		}
+		id = rctx.URLParam(name)
+	}
+	return entity, id
+}
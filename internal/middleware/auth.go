@@ -23,28 +23,33 @@ func SessionAuth(sessions *models.SessionStore, users *models.UserStore) func(ht
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			cookie, err := r.Cookie("session_token")
 			if err != nil {
-				http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+				writeError(w, r, http.StatusUnauthorized, "unauthorized")
 				return
 			}
 
 			session, err := sessions.GetByToken(r.Context(), cookie.Value)
 			if err != nil {
 				slog.Debug("session lookup failed", "err", err)
-				http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+				writeError(w, r, http.StatusUnauthorized, "unauthorized")
 				return
 			}
 
 			if session.ExpiresAt.Before(time.Now()) {
 				// Session expired — clean it up.
 				_ = sessions.Delete(r.Context(), session.ID)
-				http.Error(w, `{"error":"session expired"}`, http.StatusUnauthorized)
+				writeError(w, r, http.StatusUnauthorized, "session expired")
 				return
 			}
 
 			user, err := users.GetByID(r.Context(), session.UserID)
 			if err != nil {
 				slog.Error("user lookup failed for valid session", "user_id", session.UserID, "err", err)
-				http.Error(w, `{"error":"unauthorized"}`, http.StatusUnauthorized)
+				writeError(w, r, http.StatusUnauthorized, "unauthorized")
+				return
+			}
+			if !user.Active {
+				_ = sessions.Delete(r.Context(), session.ID)
+				writeError(w, r, http.StatusUnauthorized, "account deactivated")
 				return
 			}
 
@@ -72,7 +77,7 @@ func AutoAuth(users *models.UserStore) func(http.Handler) http.Handler {
 				cachedUser = u
 			})
 			if cachedUser == nil {
-				http.Error(w, `{"error":"admin user not found"}`, http.StatusInternalServerError)
+				writeError(w, r, http.StatusInternalServerError, "admin user not found")
 				return
 			}
 			ctx := context.WithValue(r.Context(), userContextKey, cachedUser)
@@ -87,7 +92,7 @@ func RequireAdmin(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		user := UserFromContext(r.Context())
 		if user == nil || user.Role != "admin" {
-			http.Error(w, `{"error":"forbidden"}`, http.StatusForbidden)
+			writeError(w, r, http.StatusForbidden, "forbidden")
 			return
 		}
 		next.ServeHTTP(w, r)
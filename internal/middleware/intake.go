@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+const intakeKeyContextKey contextKey = "intake_key"
+
+// IntakeKeyAuth returns middleware that authenticates external tip
+// submitters by intake API key, sent as "X-API-Key: <key>" or
+// "Authorization: Bearer <key>". The key is injected into the request
+// context; requests without a valid key receive 401. Intake keys grant no
+// access to session-authenticated routes.
+func IntakeKeyAuth(keys *models.IntakeKeyStore) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			plain := r.Header.Get("X-API-Key")
+			if plain == "" {
+				if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+					plain = strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+				}
+			}
+			if plain == "" {
+				writeError(w, r, http.StatusUnauthorized, "missing api key")
+				return
+			}
+
+			key, err := keys.Authenticate(r.Context(), plain)
+			if err != nil {
+				if !errors.Is(err, models.ErrInvalidIntakeKey) {
+					slog.Error("intake key lookup failed", "err", err)
+				}
+				writeError(w, r, http.StatusUnauthorized, "invalid api key")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), intakeKeyContextKey, key)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// IntakeKeyFromContext returns the intake key that authenticated the
+// request, or nil.
+func IntakeKeyFromContext(ctx context.Context) *models.IntakeKey {
+	k, _ := ctx.Value(intakeKeyContextKey).(*models.IntakeKey)
+	return k
+}
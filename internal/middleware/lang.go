@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Saul-Punybz/folio/internal/i18n"
+)
+
+// Lang returns the language to answer a request in: the signed-in user's
+// preference, else the best supported match for Accept-Language, else the
+// default language.
+func Lang(r *http.Request) i18n.Lang {
+	if user := UserFromContext(r.Context()); user != nil {
+		if lang, ok := i18n.Parse(user.Language); ok {
+			return lang
+		}
+	}
+	if lang, ok := i18n.FromAcceptLanguage(r.Header.Get("Accept-Language")); ok {
+		return lang
+	}
+	return i18n.Default()
+}
+
+// writeError writes a JSON error body with msg translated into the request's
+// language.
+func writeError(w http.ResponseWriter, r *http.Request, status int, msg string) {
+	body, _ := json.Marshal(map[string]string{"error": i18n.T(Lang(r), msg)})
+	http.Error(w, string(body), status)
+}
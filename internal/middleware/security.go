@@ -99,7 +99,7 @@ func RateLimit(rl *RateLimiter) func(http.Handler) http.Handler {
 			}
 			if !rl.Allow(ip) {
 				w.Header().Set("Retry-After", "60")
-				http.Error(w, `{"error":"too many requests"}`, http.StatusTooManyRequests)
+				writeError(w, r, http.StatusTooManyRequests, "too many requests")
 				return
 			}
 			next.ServeHTTP(w, r)
@@ -0,0 +1,130 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// writeGrace is added to a route's timeout when extending the connection's
+// write deadline, so the handler's own 504 (or final bytes) can still be
+// written after its context expires.
+const writeGrace = 5 * time.Second
+
+// RouteTimeout overrides the request timeout for matching routes. Pattern is
+// a path where "*" matches exactly one segment (e.g. "/api/items/*/export");
+// a trailing "/" makes it a prefix match. An empty Method matches any method.
+// A zero Timeout marks a streaming route: no context deadline and no write
+// deadline, so it runs until the client disconnects.
+type RouteTimeout struct {
+	Method  string
+	Pattern string
+	Timeout time.Duration
+}
+
+// Timeouts holds the durations DefaultRouteTimeouts assigns to route classes.
+// Unlisted routes get the default passed to RouteTimeouts.
+type Timeouts struct {
+	Read   time.Duration // plain GETs
+	AI     time.Duration // synchronous LLM calls and on-demand scraping
+	Export time.Duration // ZIP/PDF/DOCX exports
+}
+
+// DefaultRouteTimeouts returns Folio's route timeout table. Streaming routes
+// come first; otherwise the first matching rule wins.
+func DefaultRouteTimeouts(t Timeouts) []RouteTimeout {
+	return []RouteTimeout{
+		// Streaming.
+		{Method: http.MethodPost, Pattern: "/api/chat/stream", Timeout: 0},
+
+		// Exports.
+		{Method: http.MethodGet, Pattern: "/api/items/*/export", Timeout: t.Export},
+		{Method: http.MethodPost, Pattern: "/api/export", Timeout: t.Export},
+		{Method: http.MethodPost, Pattern: "/api/escritos/*/export", Timeout: t.Export},
+		{Method: http.MethodGet, Pattern: "/api/briefs/*/export.docx", Timeout: t.Export},
+
+		// Synchronous AI and scraping.
+		{Method: http.MethodPost, Pattern: "/api/admin/chat", Timeout: t.AI},
+		{Method: http.MethodPost, Pattern: "/api/admin/reenrich", Timeout: t.AI},
+		{Method: http.MethodPost, Pattern: "/api/briefs/generate", Timeout: t.AI},
+		{Method: http.MethodPost, Pattern: "/api/chat/agent", Timeout: t.AI},
+		{Method: http.MethodPost, Pattern: "/api/chat/ask", Timeout: t.AI},
+		{Method: http.MethodPost, Pattern: "/api/chat/sessions/*/message", Timeout: t.AI},
+		{Method: http.MethodPost, Pattern: "/api/collect", Timeout: t.AI},
+		{Method: http.MethodPost, Pattern: "/api/escritos/", Timeout: t.AI},
+		{Method: http.MethodPost, Pattern: "/api/research/", Timeout: t.AI},
+		{Method: http.MethodPost, Pattern: "/api/sources/*/test", Timeout: t.AI},
+		{Method: http.MethodPost, Pattern: "/api/sources/quick", Timeout: t.AI},
+		{Method: http.MethodPost, Pattern: "/api/watchlist/orgs/*/enrich", Timeout: t.AI},
+		{Method: http.MethodGet, Pattern: "/api/search", Timeout: t.AI}, // semantic/hybrid embed the query
+
+		// Everything else that only reads.
+		{Method: http.MethodGet, Pattern: "/", Timeout: t.Read},
+	}
+}
+
+// RouteTimeouts bounds each request by the timeout of the first matching
+// route, or def when none match. It replaces a single global timeout: the
+// request context gets the deadline, and the connection's write deadline is
+// moved to match, so long routes are not cut off by http.Server.WriteTimeout.
+// As with chi's Timeout middleware, a handler that returns after its deadline
+// without writing a response gets a 504.
+func RouteTimeouts(def time.Duration, routes []RouteTimeout) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			timeout := def
+			for _, rt := range routes {
+				if rt.matches(r) {
+					timeout = rt.Timeout
+					break
+				}
+			}
+
+			rc := http.NewResponseController(w)
+			if timeout <= 0 {
+				// Streaming: rely on client disconnects, not deadlines.
+				_ = rc.SetWriteDeadline(time.Time{})
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			_ = rc.SetWriteDeadline(time.Now().Add(timeout + writeGrace))
+			ctx, cancel := context.WithTimeout(r.Context(), timeout)
+			defer func() {
+				cancel()
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					w.WriteHeader(http.StatusGatewayTimeout)
+				}
+			}()
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func (rt RouteTimeout) matches(r *http.Request) bool {
+	if rt.Method != "" && rt.Method != r.Method {
+		return false
+	}
+	return matchPath(rt.Pattern, r.URL.Path)
+}
+
+// matchPath reports whether path matches pattern (see RouteTimeout).
+func matchPath(pattern, path string) bool {
+	prefix := strings.HasSuffix(pattern, "/")
+	pat := strings.Split(strings.Trim(pattern, "/"), "/")
+	segs := strings.Split(strings.Trim(path, "/"), "/")
+	if pat[0] == "" {
+		return prefix // "/" matches everything as a prefix
+	}
+	if len(segs) < len(pat) || (!prefix && len(segs) != len(pat)) {
+		return false
+	}
+	for i, p := range pat {
+		if p != "*" && p != segs[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AICacheStore persists AI results in ai_cache. It implements ai.CacheStore.
+type AICacheStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewAICacheStore creates a new AICacheStore.
+func NewAICacheStore(pool *pgxpool.Pool) *AICacheStore {
+	return &AICacheStore{pool: pool}
+}
+
+// GetAICache returns the cached JSON result of an operation, reporting false
+// when there is none.
+func (s *AICacheStore) GetAICache(ctx context.Context, operation, model, hash string) ([]byte, bool, error) {
+	var result []byte
+	err := s.pool.QueryRow(ctx, `
+		SELECT result FROM ai_cache
+		WHERE operation = $1 AND model = $2 AND content_hash = $3
+	`, operation, model, hash).Scan(&result)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("ai cache get: %w", err)
+	}
+	return result, true, nil
+}
+
+// PutAICache stores the JSON result of an operation, replacing any previous
+// one.
+func (s *AICacheStore) PutAICache(ctx context.Context, operation, model, hash string, result []byte) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO ai_cache (operation, model, content_hash, result)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (operation, model, content_hash) DO UPDATE
+		SET result = EXCLUDED.result, created_at = NOW()
+	`, operation, model, hash, result)
+	if err != nil {
+		return fmt.Errorf("ai cache put: %w", err)
+	}
+	return nil
+}
+
+// DeleteOlderThan deletes entries cached more than olderThanDays days ago
+// and returns how many were deleted.
+func (s *AICacheStore) DeleteOlderThan(ctx context.Context, olderThanDays int) (int, error) {
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM ai_cache
+		WHERE created_at < NOW() - make_interval(days => $1)
+	`, olderThanDays)
+	if err != nil {
+		return 0, fmt.Errorf("ai cache delete old: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// analyticsViews are the materialized views the analytics endpoints read
+// (migration 054), in refresh order.
+var analyticsViews = []string{"mv_article_daily", "mv_tag_daily", "mv_org_hit_daily"}
+
+// AnalyticsStore maintains the analytics materialized views.
+type AnalyticsStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewAnalyticsStore creates a new AnalyticsStore.
+func NewAnalyticsStore(pool *pgxpool.Pool) *AnalyticsStore {
+	return &AnalyticsStore{pool: pool}
+}
+
+// Refresh recomputes every analytics view in one REPEATABLE READ
+// transaction, so all of them reflect the same snapshot of the data, and
+// records the snapshot time. Views stay readable while they refresh. It
+// returns the snapshot time.
+func (s *AnalyticsStore) Refresh(ctx context.Context) (time.Time, error) {
+	start := time.Now()
+	tx, err := s.pool.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("analytics refresh begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for _, view := range analyticsViews {
+		if _, err := tx.Exec(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY "+view); err != nil {
+			return time.Time{}, fmt.Errorf("analytics refresh %s: %w", view, err)
+		}
+	}
+
+	// now() is the transaction start, which is when the snapshot was taken.
+	var refreshedAt time.Time
+	err = tx.QueryRow(ctx, `
+		INSERT INTO analytics_refresh (id, refreshed_at, duration_ms)
+		VALUES (true, now(), $1)
+		ON CONFLICT (id) DO UPDATE SET refreshed_at = EXCLUDED.refreshed_at, duration_ms = EXCLUDED.duration_ms
+		RETURNING refreshed_at
+	`, time.Since(start).Milliseconds()).Scan(&refreshedAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("analytics refresh record: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return time.Time{}, fmt.Errorf("analytics refresh commit: %w", err)
+	}
+	return refreshedAt, nil
+}
+
+// RefreshedAt returns the snapshot time of the last refresh.
+func (s *AnalyticsStore) RefreshedAt(ctx context.Context) (time.Time, error) {
+	var t time.Time
+	err := s.pool.QueryRow(ctx, `SELECT refreshed_at FROM analytics_refresh`).Scan(&t)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("analytics refreshed at: %w", err)
+	}
+	return t, nil
+}
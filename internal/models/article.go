@@ -3,12 +3,17 @@ package models
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Saul-Punybz/folio/internal/coverage"
 )
 
 // Article represents a collected news article or grant posting.
@@ -28,7 +33,34 @@ type Article struct {
 	EvidencePolicy    string     `json:"evidence_policy,omitempty"`
 	EvidenceExpiresAt *time.Time `json:"evidence_expires_at,omitempty"`
 	Tags              []string   `json:"tags,omitempty"`
+	Scope             string     `json:"scope,omitempty"`       // local, federal, diaspora; "" until classified
+	Language          string     `json:"language,omitempty"`    // es, en; "" until detected
+	ArchiveURL        string     `json:"archive_url,omitempty"` // Wayback Machine snapshot, when one was taken
+	TagSource         string     `json:"tag_source,omitempty"`  // "inferred" for centroid-matched tags; "" from the classifier
 	CreatedAt         time.Time  `json:"created_at"`
+
+	// EvidenceExpiresInDays counts down to EvidenceExpiresAt in whole days
+	// (0 once expired). Nil when the evidence has no expiry.
+	EvidenceExpiresInDays *int `json:"evidence_expires_in_days,omitempty"`
+
+	// DeletedAt is when a trashed article entered the trash, and PurgeAt
+	// when the purge job will delete it. Only trash listings set them
+	// (SetTrashTimes).
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	PurgeAt   *time.Time `json:"purge_at,omitempty"`
+}
+
+// setExpiresInDays derives EvidenceExpiresInDays from EvidenceExpiresAt.
+func (a *Article) setExpiresInDays(now time.Time) {
+	if a.EvidenceExpiresAt == nil || a.EvidencePolicy == "keep" {
+		a.EvidenceExpiresInDays = nil
+		return
+	}
+	days := int(math.Ceil(a.EvidenceExpiresAt.Sub(now).Hours() / 24))
+	if days < 0 {
+		days = 0
+	}
+	a.EvidenceExpiresInDays = &days
 }
 
 // scanTags unmarshals a JSONB tags column (scanned as []byte) into a []string.
@@ -43,6 +75,29 @@ func scanTags(raw []byte) []string {
 	return tags
 }
 
+// Article scopes: whom the story is about, independent of the free-text region.
+const (
+	ScopeLocal    = "local"    // Puerto Rico local coverage
+	ScopeFederal  = "federal"  // federal (US) news affecting Puerto Rico
+	ScopeDiaspora = "diaspora" // Puerto Ricans in the States and abroad
+)
+
+// ValidScope reports whether s is one of the article scopes.
+func ValidScope(s string) bool {
+	return s == ScopeLocal || s == ScopeFederal || s == ScopeDiaspora
+}
+
+// Article languages, as detected by scraper.DetectLanguage.
+const (
+	LanguageSpanish = "es"
+	LanguageEnglish = "en"
+)
+
+// ValidLanguage reports whether s is one of the article languages.
+func ValidLanguage(s string) bool {
+	return s == LanguageSpanish || s == LanguageEnglish
+}
+
 // ArticleStore provides data access methods for articles.
 type ArticleStore struct {
 	pool *pgxpool.Pool
@@ -67,10 +122,10 @@ func (s *ArticleStore) ListByStatus(ctx context.Context, status string, limit, o
 	rows, err := s.pool.Query(ctx, `
 		SELECT id, title, source, url, canonical_url, region, published_at,
 		       clean_text, summary, image_url, status, pinned, evidence_policy,
-		       evidence_expires_at, tags, created_at
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
 		FROM articles
 		WHERE status = $1
-		ORDER BY pinned DESC, published_at DESC NULLS LAST, created_at DESC
+		ORDER BY pinned DESC, published_at DESC NULLS LAST, created_at DESC, id DESC
 		LIMIT $2 OFFSET $3
 	`, status, limit, offset)
 	if err != nil {
@@ -90,6 +145,65 @@ func (s *ArticleStore) ListByStatus(ctx context.Context, status string, limit, o
 	return articles, rows.Err()
 }
 
+// ListByStatusAfter returns one page of articles with the given status in the
+// same order as ListByStatus, starting after the cursor (nil for the first
+// page).
+func (s *ArticleStore) ListByStatusAfter(ctx context.Context, status string, after *ArticleCursor, limit int) (*ArticlePage, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	where := "status = $1"
+	args := []any{status, limit}
+	if after != nil {
+		// published_at sorts NULLS LAST under DESC, which is what -infinity
+		// gives in a row comparison.
+		where += ` AND (pinned, COALESCE(published_at, '-infinity'), created_at, id)
+		         < ($3::bool, COALESCE($4::timestamptz, '-infinity'), $5::timestamptz, $6::uuid)`
+		args = append(args, after.Pinned, after.PublishedAt, after.CreatedAt, after.ID)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, title, source, url, canonical_url, region, published_at,
+		       clean_text, summary, image_url, status, pinned, evidence_policy,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
+		FROM articles
+		WHERE `+where+`
+		ORDER BY pinned DESC, published_at DESC NULLS LAST, created_at DESC, id DESC
+		LIMIT $2
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("article list page: %w", err)
+	}
+	defer rows.Close()
+
+	page := &ArticlePage{}
+	for rows.Next() {
+		a := scanArticleFromRow(rows)
+		if a == nil {
+			return nil, fmt.Errorf("article page scan: failed")
+		}
+		page.Articles = append(page.Articles, *a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(page.Articles) == limit {
+		page.Next = cursorAfter(page.Articles[len(page.Articles)-1], nil)
+	}
+	return page, nil
+}
+
+// CountByStatus returns the number of articles with the given status.
+func (s *ArticleStore) CountByStatus(ctx context.Context, status string) (int, error) {
+	var count int
+	err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM articles WHERE status = $1`, status).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("article count by status: %w", err)
+	}
+	return count, nil
+}
+
 // scannable is an interface for pgx Row and Rows.
 type scannable interface {
 	Scan(dest ...any) error
@@ -103,11 +217,12 @@ func scanArticleFromRow(row scannable) *Article {
 	if err := row.Scan(
 		&a.ID, &a.Title, &a.Source, &a.URL, &canonicalURL, &a.Region,
 		&a.PublishedAt, &cleanText, &summary, &imageURL, &a.Status, &a.Pinned,
-		&a.EvidencePolicy, &a.EvidenceExpiresAt, &tagsRaw, &a.CreatedAt,
+		&a.EvidencePolicy, &a.EvidenceExpiresAt, &tagsRaw, &a.Scope, &a.Language, &a.ArchiveURL, &a.TagSource, &a.CreatedAt,
 	); err != nil {
 		return nil
 	}
 	a.Tags = scanTags(tagsRaw)
+	a.setExpiresInDays(time.Now())
 	if imageURL != nil {
 		a.ImageURL = *imageURL
 	}
@@ -128,7 +243,7 @@ func (s *ArticleStore) GetByID(ctx context.Context, id uuid.UUID) (*Article, err
 	row := s.pool.QueryRow(ctx, `
 		SELECT id, title, source, url, canonical_url, region, published_at,
 		       clean_text, summary, image_url, status, pinned, evidence_policy,
-		       evidence_expires_at, tags, created_at
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
 		FROM articles
 		WHERE id = $1
 	`, id)
@@ -139,21 +254,52 @@ func (s *ArticleStore) GetByID(ctx context.Context, id uuid.UUID) (*Article, err
 	return a, nil
 }
 
-// UpdateStatus changes an article's status.
+// UpdateStatus changes an article's status, recording the change in the
+// status history. Moving an article to the trash dates it (deleted_at) for
+// the purge job; moving it out clears the date and gives back the evidence
+// policy and expiry saved by Trash. Use Trash to trash an article by hand.
 func (s *ArticleStore) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
-	tag, err := s.pool.Exec(ctx, `UPDATE articles SET status = $1 WHERE id = $2`, status, id)
+	var updated int
+	err := s.pool.QueryRow(ctx, `
+		WITH changed AS (
+			UPDATE articles a
+			SET status = $1,
+			    deleted_at = CASE WHEN $1 = 'trashed' THEN COALESCE(a.deleted_at, NOW()) END,
+			    evidence_policy = CASE WHEN old.status = 'trashed' AND $1 <> 'trashed'
+			        THEN COALESCE(a.pre_trash_evidence_policy, a.evidence_policy)
+			        ELSE a.evidence_policy END,
+			    evidence_expires_at = CASE WHEN old.status = 'trashed' AND $1 <> 'trashed'
+			        AND a.pre_trash_evidence_policy IS NOT NULL AND a.evidence_expires_at IS NOT NULL
+			        THEN a.pre_trash_expires_at
+			        ELSE a.evidence_expires_at END,
+			    pre_trash_evidence_policy = CASE WHEN $1 = 'trashed' THEN a.pre_trash_evidence_policy END,
+			    pre_trash_expires_at = CASE WHEN $1 = 'trashed' THEN a.pre_trash_expires_at END
+			FROM (SELECT id, status FROM articles WHERE id = $2 FOR UPDATE) old
+			WHERE a.id = old.id
+			RETURNING a.id, old.status AS from_status
+		), logged AS (
+			INSERT INTO article_status_history (article_id, from_status, to_status, reason)
+			SELECT id, from_status, $1, 'user' FROM changed WHERE from_status <> $1
+		)
+		SELECT COUNT(*) FROM changed
+	`, status, id).Scan(&updated)
 	if err != nil {
 		return fmt.Errorf("article update status: %w", err)
 	}
-	if tag.RowsAffected() == 0 {
+	if updated == 0 {
 		return fmt.Errorf("article not found: %s", id)
 	}
 	return nil
 }
 
-// SetPinned sets the pinned flag on an article.
+// SetPinned sets the pinned flag on an article, recording when it was pinned.
 func (s *ArticleStore) SetPinned(ctx context.Context, id uuid.UUID, pinned bool) error {
-	tag, err := s.pool.Exec(ctx, `UPDATE articles SET pinned = $1 WHERE id = $2`, pinned, id)
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE articles
+		SET pinned = $1,
+		    pinned_at = CASE WHEN NOT $1 THEN NULL WHEN pinned THEN pinned_at ELSE NOW() END
+		WHERE id = $2
+	`, pinned, id)
 	if err != nil {
 		return fmt.Errorf("article set pinned: %w", err)
 	}
@@ -177,16 +323,23 @@ func (s *ArticleStore) Create(ctx context.Context, article *Article) error {
 	}
 
 	err := s.pool.QueryRow(ctx, `
-		INSERT INTO articles (id, title, source, url, canonical_url, region,
-		                      published_at, clean_text, summary, image_url, status, pinned,
-		                      evidence_policy, evidence_expires_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
-		RETURNING created_at
+		WITH created AS (
+			INSERT INTO articles (id, title, source, url, canonical_url, region,
+			                      published_at, clean_text, summary, image_url, status, pinned, pinned_at,
+			                      evidence_policy, evidence_expires_at, language)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12,
+			        CASE WHEN $12 THEN NOW() END, $13, $14, $15)
+			RETURNING id, status, created_at
+		), logged AS (
+			INSERT INTO article_status_history (article_id, to_status, reason, changed_at)
+			SELECT id, status, 'created', created_at FROM created
+		)
+		SELECT created_at FROM created
 	`,
 		article.ID, article.Title, article.Source, article.URL,
 		article.CanonicalURL, article.Region, article.PublishedAt,
 		article.CleanText, article.Summary, imageURL, article.Status, article.Pinned,
-		article.EvidencePolicy, article.EvidenceExpiresAt,
+		article.EvidencePolicy, article.EvidenceExpiresAt, article.Language,
 	).Scan(&article.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("article create: %w", err)
@@ -215,7 +368,7 @@ func (s *ArticleStore) UpdateEnrichment(ctx context.Context, id uuid.UUID, summa
 
 	tag, err := s.pool.Exec(ctx, `
 		UPDATE articles
-		SET summary = $1, tags = $2, embedding = $3
+		SET summary = $1, tags = $2, embedding = $3, tag_source = ''
 		WHERE id = $4
 	`, summary, tagsJSON, embeddingStr, id)
 	if err != nil {
@@ -227,25 +380,48 @@ func (s *ArticleStore) UpdateEnrichment(ctx context.Context, id uuid.UUID, summa
 	return nil
 }
 
-// SimilarArticles returns articles similar to the given article using pgvector
-// cosine distance on embeddings.
-func (s *ArticleStore) SimilarArticles(ctx context.Context, id uuid.UUID, limit int) ([]Article, error) {
-	if limit <= 0 {
-		limit = 5
+// SetScope records an article's classified scope.
+func (s *ArticleStore) SetScope(ctx context.Context, id uuid.UUID, scope string) error {
+	tag, err := s.pool.Exec(ctx, `UPDATE articles SET scope = $2 WHERE id = $1`, id, scope)
+	if err != nil {
+		return fmt.Errorf("article set scope: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("article not found: %s", id)
+	}
+	return nil
+}
+
+// SetLanguage records an article's detected language.
+func (s *ArticleStore) SetLanguage(ctx context.Context, id uuid.UUID, language string) error {
+	tag, err := s.pool.Exec(ctx, `UPDATE articles SET language = $2 WHERE id = $1`, id, language)
+	if err != nil {
+		return fmt.Errorf("article set language: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("article not found: %s", id)
 	}
+	return nil
+}
 
+// ListMissingLanguage returns up to limit articles with text but no detected
+// language, ordered by id and starting after the given id (uuid.Nil for the
+// first batch), for backfilling.
+func (s *ArticleStore) ListMissingLanguage(ctx context.Context, after uuid.UUID, limit int) ([]Article, error) {
+	if limit <= 0 {
+		limit = 500
+	}
 	rows, err := s.pool.Query(ctx, `
 		SELECT id, title, source, url, canonical_url, region, published_at,
 		       clean_text, summary, image_url, status, pinned, evidence_policy,
-		       evidence_expires_at, tags, created_at
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
 		FROM articles
-		WHERE id != $1
-		  AND embedding IS NOT NULL
-		ORDER BY embedding <=> (SELECT embedding FROM articles WHERE id = $1)
+		WHERE language = '' AND clean_text <> '' AND id > $1
+		ORDER BY id
 		LIMIT $2
-	`, id, limit)
+	`, after, limit)
 	if err != nil {
-		return nil, fmt.Errorf("article similar: %w", err)
+		return nil, fmt.Errorf("article list missing language: %w", err)
 	}
 	defer rows.Close()
 
@@ -253,20 +429,106 @@ func (s *ArticleStore) SimilarArticles(ctx context.Context, id uuid.UUID, limit
 	for rows.Next() {
 		a := scanArticleFromRow(rows)
 		if a == nil {
-			return nil, fmt.Errorf("article similar scan: failed")
+			return nil, fmt.Errorf("article list missing language scan: failed")
 		}
 		articles = append(articles, *a)
 	}
-
 	return articles, rows.Err()
 }
 
+// SimilarArticle is a SimilarArticles result with the reasons it matched.
+type SimilarArticle struct {
+	Article
+	Distance       float64  `json:"distance"`   // pgvector cosine distance, 0-2
+	Similarity     float64  `json:"similarity"` // 1 - distance
+	SharedTags     []string `json:"shared_tags"`
+	SharedEntities []string `json:"shared_entities"`
+}
+
+// SimilarArticles returns articles similar to the given article using pgvector
+// cosine distance on embeddings, along with the tags and entities each result
+// shares with it. Results with a cosine similarity (1 - distance) below
+// minSimilarity are dropped; pass 0 or less to keep everything.
+func (s *ArticleStore) SimilarArticles(ctx context.Context, id uuid.UUID, limit int, minSimilarity float64) ([]SimilarArticle, error) {
+	if limit <= 0 {
+		limit = 5
+	}
+	if minSimilarity <= 0 {
+		minSimilarity = -1 // cosine similarity bottoms out at -1
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		WITH src AS (
+			SELECT embedding, COALESCE(tags, '[]'::jsonb) AS tags
+			FROM articles WHERE id = $1
+		), ranked AS (
+			SELECT a.id, a.title, a.source, a.url, a.canonical_url, a.region, a.published_at,
+			       a.clean_text, a.summary, a.image_url, a.status, a.pinned, a.evidence_policy,
+			       a.evidence_expires_at, a.tags, a.scope, a.language, a.archive_url, a.tag_source, a.created_at,
+			       a.embedding <=> src.embedding AS distance,
+			       src.tags AS src_tags
+			FROM articles a, src
+			WHERE a.id != $1
+			  AND a.embedding IS NOT NULL
+			ORDER BY a.embedding <=> src.embedding
+			LIMIT $2
+		)
+		SELECT id, title, source, url, canonical_url, region, published_at,
+		       clean_text, summary, image_url, status, pinned, evidence_policy,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at, distance,
+		       COALESCE((
+		           SELECT array_agg(t ORDER BY t)
+		           FROM jsonb_array_elements_text(COALESCE(ranked.tags, '[]'::jsonb)) AS t
+		           WHERE ranked.src_tags ? t
+		       ), '{}') AS shared_tags,
+		       COALESCE((
+		           SELECT array_agg(DISTINCT e.name)
+		           FROM article_entities mine
+		           JOIN article_entities theirs ON theirs.entity_id = mine.entity_id AND theirs.article_id = $1
+		           JOIN entities e ON e.id = mine.entity_id
+		           WHERE mine.article_id = ranked.id
+		       ), '{}') AS shared_entities
+		FROM ranked
+		WHERE 1 - distance >= $3
+		ORDER BY distance
+	`, id, limit, minSimilarity)
+	if err != nil {
+		return nil, fmt.Errorf("article similar: %w", err)
+	}
+	defer rows.Close()
+
+	var results []SimilarArticle
+	for rows.Next() {
+		var sa SimilarArticle
+		a := scanArticleFromRow(similarRow{rows, &sa})
+		if a == nil {
+			return nil, fmt.Errorf("article similar scan: failed")
+		}
+		sa.Article = *a
+		sa.Similarity = 1 - sa.Distance
+		results = append(results, sa)
+	}
+
+	return results, rows.Err()
+}
+
+// similarRow adapts a SimilarArticles row, which carries the distance and
+// shared tags/entities after the standard columns, to scanArticleFromRow.
+type similarRow struct {
+	row scannable
+	sa  *SimilarArticle
+}
+
+func (r similarRow) Scan(dest ...any) error {
+	return r.row.Scan(append(dest, &r.sa.Distance, &r.sa.SharedTags, &r.sa.SharedEntities)...)
+}
+
 // ListRecent returns articles created in the last N hours, ordered by creation time.
 func (s *ArticleStore) ListRecent(ctx context.Context, hours int) ([]Article, error) {
 	rows, err := s.pool.Query(ctx, `
 		SELECT id, title, source, url, canonical_url, region, published_at,
 		       clean_text, summary, image_url, status, pinned, evidence_policy,
-		       evidence_expires_at, tags, created_at
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
 		FROM articles
 		WHERE created_at >= now() - make_interval(hours => $1)
 		ORDER BY created_at DESC
@@ -288,6 +550,73 @@ func (s *ArticleStore) ListRecent(ctx context.Context, hours int) ([]Article, er
 	return articles, rows.Err()
 }
 
+// SavedArticle is an article in the saved-articles feed, with when it was
+// saved or pinned, whichever is later.
+type SavedArticle struct {
+	Article
+	SavedAt time.Time
+}
+
+// ListSavedForFeed returns the most recently saved or pinned articles
+// (trashed ones excluded), for the public saved-articles feed. An article is
+// dated by its latest move to saved in the status history or by when it was
+// pinned, so saving an old article puts it at the top.
+func (s *ArticleStore) ListSavedForFeed(ctx context.Context, limit int) ([]SavedArticle, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, title, source, url, canonical_url, region, published_at,
+		       clean_text, summary, image_url, status, pinned, evidence_policy,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at,
+		       saved_at
+		FROM (
+			SELECT a.*, COALESCE(GREATEST(
+				CASE WHEN a.status = 'saved' THEN (
+					SELECT MAX(h.changed_at) FROM article_status_history h
+					WHERE h.article_id = a.id AND h.to_status = 'saved'
+				) END,
+				CASE WHEN a.pinned THEN a.pinned_at END
+			), a.created_at) AS saved_at
+			FROM articles a
+			WHERE (a.status = 'saved' OR a.pinned) AND a.status <> 'trashed'
+		) saved
+		ORDER BY saved_at DESC, id DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("article list saved for feed: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []SavedArticle
+	for rows.Next() {
+		var savedAt time.Time
+		a := scanArticleFromRow(withExtraScan(rows, &savedAt))
+		if a == nil {
+			return nil, fmt.Errorf("article list saved for feed scan: failed")
+		}
+		articles = append(articles, SavedArticle{Article: *a, SavedAt: savedAt})
+	}
+
+	return articles, rows.Err()
+}
+
+// extraScan scans the columns of a row after the ones its caller asks for
+// into extra.
+type extraScan struct {
+	row   scannable
+	extra []any
+}
+
+func withExtraScan(row scannable, extra ...any) scannable {
+	return extraScan{row: row, extra: extra}
+}
+
+func (e extraScan) Scan(dest ...any) error {
+	return e.row.Scan(append(dest, e.extra...)...)
+}
+
 // UpdateRetention updates the evidence policy and recalculates the expiry date.
 func (s *ArticleStore) UpdateRetention(ctx context.Context, id uuid.UUID, policy string) error {
 	var expiresAt *time.Time
@@ -318,6 +647,45 @@ func (s *ArticleStore) UpdateRetention(ctx context.Context, id uuid.UUID, policy
 	return nil
 }
 
+// ListExpiring returns articles whose evidence expires within the given
+// number of days (and has not expired yet), soonest first. An empty status
+// matches every status. This is the pre-expiry review queue: analysts extend
+// retention on anything worth keeping before CleanupExpiredEvidence runs.
+func (s *ArticleStore) ListExpiring(ctx context.Context, withinDays int, status string, limit, offset int) ([]Article, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, title, source, url, canonical_url, region, published_at,
+		       clean_text, summary, image_url, status, pinned, evidence_policy,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
+		FROM articles
+		WHERE evidence_expires_at IS NOT NULL
+		  AND evidence_policy != 'keep'
+		  AND evidence_expires_at >= now()
+		  AND evidence_expires_at < now() + make_interval(days => $1)
+		  AND ($2 = '' OR status = $2)
+		ORDER BY evidence_expires_at ASC
+		LIMIT $3 OFFSET $4
+	`, withinDays, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("article list expiring: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	for rows.Next() {
+		a := scanArticleFromRow(rows)
+		if a == nil {
+			return nil, fmt.Errorf("article expiring scan: failed")
+		}
+		articles = append(articles, *a)
+	}
+
+	return articles, rows.Err()
+}
+
 // CountToday returns the number of articles created since the start of today (UTC).
 func (s *ArticleStore) CountToday(ctx context.Context) (int, error) {
 	var count int
@@ -336,7 +704,7 @@ func (s *ArticleStore) ListExpiredEvidence(ctx context.Context) ([]Article, erro
 	rows, err := s.pool.Query(ctx, `
 		SELECT id, title, source, url, canonical_url, region, published_at,
 		       clean_text, summary, image_url, status, pinned, evidence_policy,
-		       evidence_expires_at, tags, created_at
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
 		FROM articles
 		WHERE evidence_expires_at < now()
 		  AND evidence_policy != 'keep'
@@ -373,13 +741,22 @@ func (s *ArticleStore) SetImageURL(ctx context.Context, id uuid.UUID, imageURL s
 	return nil
 }
 
+// SetArchiveURL records an article's Wayback Machine snapshot.
+func (s *ArticleStore) SetArchiveURL(ctx context.Context, id uuid.UUID, archiveURL string) error {
+	_, err := s.pool.Exec(ctx, `UPDATE articles SET archive_url = $1, archived_at = NOW() WHERE id = $2`, archiveURL, id)
+	if err != nil {
+		return fmt.Errorf("article set archive_url: %w", err)
+	}
+	return nil
+}
+
 // ClearGarbageEnrichment clears summary and tags for articles where the summary
 // contains AI garbage patterns. Returns the number of articles cleared.
 func (s *ArticleStore) ClearGarbageEnrichment(ctx context.Context) (int, error) {
 	// These patterns match the garbage output from poorly prompted LLMs.
 	tag, err := s.pool.Exec(ctx, `
 		UPDATE articles
-		SET summary = '', tags = '[]'::jsonb
+		SET summary = '', tags = '[]'::jsonb, tag_source = ''
 		WHERE summary != '' AND (
 			lower(summary) LIKE '%no hay información%'
 			OR lower(summary) LIKE '%no tengo%'
@@ -413,7 +790,7 @@ func (s *ArticleStore) ListNeedingEnrichment(ctx context.Context, limit int) ([]
 	rows, err := s.pool.Query(ctx, `
 		SELECT id, title, source, url, canonical_url, region, published_at,
 		       clean_text, summary, image_url, status, pinned, evidence_policy,
-		       evidence_expires_at, tags, created_at
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
 		FROM articles
 		WHERE clean_text != '' AND (summary = '' OR summary IS NULL)
 		ORDER BY created_at DESC
@@ -446,82 +823,229 @@ func (s *ArticleStore) ClearEvidenceExpiry(ctx context.Context, id uuid.UUID) er
 	return nil
 }
 
-// Search performs a full-text search on articles with optional filters.
-// Uses 'simple' text search config which works for both English and Spanish content.
-// Supports tag filtering via the tag parameter (matches articles containing the tag).
-func (s *ArticleStore) Search(ctx context.Context, query string, from, to time.Time, region, status, tag string, limit, offset int) ([]Article, error) {
-	if limit <= 0 {
-		limit = 50
-	}
+// SearchFilters holds the optional filters shared by the article search modes.
+type SearchFilters struct {
+	From     time.Time
+	To       time.Time
+	Region   string
+	Status   string
+	Tag      string
+	Scope    string
+	Language string
+}
 
+// conditions builds SQL WHERE conditions for the filters, numbering
+// placeholders from argN. It returns the conditions, their arguments, and the
+// next free placeholder number.
+func (f SearchFilters) conditions(argN int) ([]string, []any, int) {
 	var conditions []string
 	var args []any
-	argN := 1
-	hasQuery := query != ""
-
-	if hasQuery {
-		conditions = append(conditions, fmt.Sprintf(
-			"to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(clean_text, '')) @@ plainto_tsquery('simple', $%d)", argN))
-		args = append(args, query)
-		argN++
-	}
 
-	if !from.IsZero() {
+	if !f.From.IsZero() {
 		conditions = append(conditions, fmt.Sprintf("published_at >= $%d", argN))
-		args = append(args, from)
+		args = append(args, f.From)
 		argN++
 	}
-	if !to.IsZero() {
+	if !f.To.IsZero() {
 		conditions = append(conditions, fmt.Sprintf("published_at <= $%d", argN))
-		args = append(args, to)
+		args = append(args, f.To)
 		argN++
 	}
-	if region != "" {
+	if f.Region != "" {
 		conditions = append(conditions, fmt.Sprintf("region = $%d", argN))
-		args = append(args, region)
+		args = append(args, f.Region)
 		argN++
 	}
-	if status != "" {
+	if f.Status != "" {
 		conditions = append(conditions, fmt.Sprintf("status = $%d", argN))
-		args = append(args, status)
+		args = append(args, f.Status)
 		argN++
 	}
-	if tag != "" {
+	if f.Tag != "" {
 		// Filter by tag using JSONB containment: tags @> '["politics"]'::jsonb
 		conditions = append(conditions, fmt.Sprintf("tags @> to_jsonb(ARRAY[$%d::text])", argN))
-		args = append(args, tag)
+		args = append(args, f.Tag)
 		argN++
 	}
+	if f.Scope != "" {
+		conditions = append(conditions, fmt.Sprintf("scope = $%d", argN))
+		args = append(args, f.Scope)
+		argN++
+	}
+	if f.Language != "" {
+		conditions = append(conditions, fmt.Sprintf("language = $%d", argN))
+		args = append(args, f.Language)
+		argN++
+	}
+
+	return conditions, args, argN
+}
+
+// searchDoc is the document full-text search matches and ranks against: a
+// generated column indexing title and text with the article's language config.
+const searchDoc = "search_tsv"
 
+// searchMatch returns the condition matching tsq against an article's
+// searchDoc or its indexed evidence text, which can be longer than clean_text.
+func searchMatch(tsq string) string {
+	return fmt.Sprintf(`(%[1]s @@ %[2]s OR id IN (
+			SELECT e.article_id FROM article_evidence_text e WHERE e.search_tsv @@ %[2]s))`, searchDoc, tsq)
+}
+
+// searchRank returns an article's ts_rank for tsq: the better of its
+// searchDoc and evidence text ranks. normalization is ts_rank's flag.
+func searchRank(tsq string, normalization int) string {
+	return fmt.Sprintf(`GREATEST(ts_rank(%[1]s, %[2]s, %[3]d), COALESCE((
+			SELECT ts_rank(e.search_tsv, %[2]s, %[3]d) FROM article_evidence_text e
+			WHERE e.article_id = articles.id), 0))`, searchDoc, tsq, normalization)
+}
+
+// searchQuery returns the tsquery for the query in placeholder $n. With a
+// language filter the query is parsed with that language's config; otherwise
+// the Spanish, English and simple parses are OR-ed so it matches documents
+// indexed under any of them.
+func searchQuery(filters SearchFilters, n int) string {
+	switch filters.Language {
+	case LanguageSpanish:
+		return fmt.Sprintf("plainto_tsquery('spanish', $%d)", n)
+	case LanguageEnglish:
+		return fmt.Sprintf("plainto_tsquery('english', $%d)", n)
+	}
+	return fmt.Sprintf("(plainto_tsquery('spanish', $%[1]d) || plainto_tsquery('english', $%[1]d) || plainto_tsquery('simple', $%[1]d))", n)
+}
+
+// Search performs a full-text search on articles with optional filters.
+// Each article is indexed with its detected language's config (see searchQuery).
+// Supports tag filtering via filters.Tag (matches articles containing the tag).
+func (s *ArticleStore) Search(ctx context.Context, query string, filters SearchFilters, limit, offset int) ([]Article, error) {
+	articles, _, err := s.search(ctx, query, filters, nil, limit, offset)
+	return articles, err
+}
+
+// SearchAfter returns one page of Search results starting after the cursor
+// (nil for the first page). A cursor from a search with a query only
+// continues a search with a query, and vice versa, since the sort keys
+// differ.
+func (s *ArticleStore) SearchAfter(ctx context.Context, query string, filters SearchFilters, after *ArticleCursor, limit int) (*ArticlePage, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if after != nil && (after.Rank != nil) != (query != "") {
+		return nil, ErrInvalidCursor
+	}
+
+	articles, ranks, err := s.search(ctx, query, filters, after, limit, 0)
+	if err != nil {
+		return nil, err
+	}
+	page := &ArticlePage{Articles: articles}
+	if len(articles) == limit {
+		var rank *float64
+		if ranks != nil {
+			rank = &ranks[len(ranks)-1]
+		}
+		page.Next = cursorAfter(articles[len(articles)-1], rank)
+	}
+	return page, nil
+}
+
+// SearchCount returns how many articles match a Search query and filters.
+func (s *ArticleStore) SearchCount(ctx context.Context, query string, filters SearchFilters) (int, error) {
+	conditions, args, _ := searchConditions(query, filters)
 	where := ""
 	if len(conditions) > 0 {
 		where = "WHERE " + strings.Join(conditions, " AND ")
 	}
 
+	var count int
+	if err := s.pool.QueryRow(ctx, "SELECT COUNT(*) FROM articles "+where, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("article search count: %w", err)
+	}
+	return count, nil
+}
+
+// searchConditions returns the WHERE conditions shared by Search and
+// SearchCount. The query, when present, is always $1.
+func searchConditions(query string, filters SearchFilters) ([]string, []any, int) {
+	var conditions []string
+	var args []any
+	argN := 1
+
+	if query != "" {
+		conditions = append(conditions, searchMatch(searchQuery(filters, argN)))
+		args = append(args, query)
+		argN++
+	}
+
+	filterConds, filterArgs, argN := filters.conditions(argN)
+	conditions = append(conditions, filterConds...)
+	args = append(args, filterArgs...)
+	return conditions, args, argN
+}
+
+// search runs a full-text search page, either by offset or after a cursor.
+// With a query it also returns each article's ts_rank.
+func (s *ArticleStore) search(ctx context.Context, query string, filters SearchFilters, after *ArticleCursor, limit, offset int) ([]Article, []float64, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	hasQuery := query != ""
+
+	conditions, args, argN := searchConditions(query, filters)
+
 	// Use ts_rank for relevance ordering when a search query is present.
+	// Every ordering ends in id so cursors have a unique position.
+	rankExpr := searchRank(searchQuery(filters, 1), 0)
 	var orderBy string
 	if hasQuery {
-		orderBy = fmt.Sprintf(
-			"ORDER BY ts_rank(to_tsvector('simple', coalesce(title, '') || ' ' || coalesce(clean_text, '')), plainto_tsquery('simple', $1)) DESC, published_at DESC NULLS LAST, created_at DESC")
+		orderBy = "ORDER BY " + rankExpr + " DESC, published_at DESC NULLS LAST, created_at DESC, id DESC"
 	} else {
-		orderBy = "ORDER BY published_at DESC NULLS LAST, created_at DESC"
+		orderBy = "ORDER BY published_at DESC NULLS LAST, created_at DESC, id DESC"
+	}
+
+	if after != nil {
+		keys := "COALESCE(published_at, '-infinity'), created_at, id"
+		vals := fmt.Sprintf("COALESCE($%d::timestamptz, '-infinity'), $%d::timestamptz, $%d::uuid", argN, argN+1, argN+2)
+		args = append(args, after.PublishedAt, after.CreatedAt, after.ID)
+		argN += 3
+		if hasQuery {
+			keys = rankExpr + ", " + keys
+			vals = fmt.Sprintf("$%d::real, ", argN) + vals
+			args = append(args, *after.Rank)
+			argN++
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s) < (%s)", keys, vals))
 	}
 
-	q := fmt.Sprintf(`
-		SELECT id, title, source, url, canonical_url, region, published_at,
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	cols := `id, title, source, url, canonical_url, region, published_at,
 		       clean_text, summary, image_url, status, pinned, evidence_policy,
-		       evidence_expires_at, tags, created_at
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at`
+	if hasQuery {
+		cols += ",\n\t\t       " + rankExpr + " AS rank"
+	}
+
+	q := fmt.Sprintf(`
+		SELECT %s
 		FROM articles
 		%s
 		%s
 		LIMIT $%d OFFSET $%d
-	`, where, orderBy, argN, argN+1)
+	`, cols, where, orderBy, argN, argN+1)
 
 	args = append(args, limit, offset)
 
+	if hasQuery {
+		return s.queryScored(ctx, "article search", q, args...)
+	}
+
 	rows, err := s.pool.Query(ctx, q, args...)
 	if err != nil {
-		return nil, fmt.Errorf("article search: %w", err)
+		return nil, nil, fmt.Errorf("article search: %w", err)
 	}
 	defer rows.Close()
 
@@ -529,18 +1053,126 @@ func (s *ArticleStore) Search(ctx context.Context, query string, from, to time.T
 	for rows.Next() {
 		a := scanArticleFromRow(rows)
 		if a == nil {
-			return nil, fmt.Errorf("article search scan: failed")
+			return nil, nil, fmt.Errorf("article search scan: failed")
 		}
 		articles = append(articles, *a)
 	}
 
-	return articles, rows.Err()
+	return articles, nil, rows.Err()
+}
+
+// SemanticSearch finds articles whose embeddings are closest to the given query
+// embedding by pgvector cosine distance, applying the same optional filters as
+// Search. Scores are returned as 0-1 relevance: relevance = 1 - (distance / 2).
+func (s *ArticleStore) SemanticSearch(ctx context.Context, embedding []float32, filters SearchFilters, limit, offset int) ([]Article, []float64, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	conditions := []string{"embedding IS NOT NULL"}
+	args := []any{formatVector(embedding)}
+	filterConds, filterArgs, argN := filters.conditions(2)
+	conditions = append(conditions, filterConds...)
+	args = append(args, filterArgs...)
+
+	q := fmt.Sprintf(`
+		SELECT id, title, source, url, canonical_url, region, published_at,
+		       clean_text, summary, image_url, status, pinned, evidence_policy,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at,
+		       1 - ((embedding <=> $1::vector) / 2) AS score
+		FROM articles
+		WHERE %s
+		ORDER BY embedding <=> $1::vector
+		LIMIT $%d OFFSET $%d
+	`, strings.Join(conditions, " AND "), argN, argN+1)
+	args = append(args, limit, offset)
+
+	return s.queryScored(ctx, "article semantic search", q, args...)
+}
+
+// HybridSearch blends full-text ts_rank and embedding similarity into a single
+// score: semanticWeight * vector relevance + (1 - semanticWeight) * text rank.
+// The text rank is normalized into 0-1 (rank / (rank + 1)) so both components
+// are on the same scale. Articles without an embedding still match on text.
+func (s *ArticleStore) HybridSearch(ctx context.Context, query string, embedding []float32, filters SearchFilters, semanticWeight float64, limit, offset int) ([]Article, []float64, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	if semanticWeight < 0 || semanticWeight > 1 {
+		semanticWeight = 0.5
+	}
+
+	tsq := searchQuery(filters, 1)
+	conditions := []string{fmt.Sprintf(
+		"(%s OR (embedding IS NOT NULL AND (embedding <=> $2::vector) < 0.8))", searchMatch(tsq))}
+	args := []any{query, formatVector(embedding), semanticWeight}
+	filterConds, filterArgs, argN := filters.conditions(4)
+	conditions = append(conditions, filterConds...)
+	args = append(args, filterArgs...)
+
+	q := fmt.Sprintf(`
+		SELECT id, title, source, url, canonical_url, region, published_at,
+		       clean_text, summary, image_url, status, pinned, evidence_policy,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at,
+		       $3::float8 * COALESCE(1 - ((embedding <=> $2::vector) / 2), 0)
+		       + (1 - $3::float8) * %s AS score
+		FROM articles
+		WHERE %s
+		ORDER BY score DESC, published_at DESC NULLS LAST
+		LIMIT $%d OFFSET $%d
+	`, searchRank(tsq, 32), strings.Join(conditions, " AND "), argN, argN+1)
+	args = append(args, limit, offset)
+
+	return s.queryScored(ctx, "article hybrid search", q, args...)
+}
+
+// queryScored runs a query selecting the standard article columns followed by
+// a float score column.
+func (s *ArticleStore) queryScored(ctx context.Context, op, q string, args ...any) ([]Article, []float64, error) {
+	rows, err := s.pool.Query(ctx, q, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	var scores []float64
+	for rows.Next() {
+		var score float64
+		a := scanArticleFromRow(scoredRow{rows, &score})
+		if a == nil {
+			return nil, nil, fmt.Errorf("%s scan: failed", op)
+		}
+		articles = append(articles, *a)
+		scores = append(scores, score)
+	}
+
+	return articles, scores, rows.Err()
+}
+
+// scoredRow adapts a row with a trailing score column to scanArticleFromRow.
+type scoredRow struct {
+	row   scannable
+	score *float64
+}
+
+func (r scoredRow) Scan(dest ...any) error {
+	return r.row.Scan(append(dest, r.score)...)
+}
+
+// formatVector formats an embedding as a pgvector literal: [0.1,0.2,...].
+func formatVector(embedding []float32) string {
+	parts := make([]string, len(embedding))
+	for i, v := range embedding {
+		parts[i] = fmt.Sprintf("%g", v)
+	}
+	return "[" + strings.Join(parts, ",") + "]"
 }
 
 // SearchByKeywords searches articles using ILIKE on individual keywords extracted
 // from the topic. Unlike FTS, this handles accented vs unaccented characters
-// naturally (e.g. "energia" matches "energía"). Filters out geographic terms
-// like "puerto rico" to focus on topical keywords. Searches across all statuses.
+// naturally (e.g. "energia" matches "energía"). Filters out the coverage
+// profile's generic terms (e.g. "puerto", "rico") to focus on topical keywords. Searches across all statuses.
 func (s *ArticleStore) SearchByKeywords(ctx context.Context, topic string, limit int) ([]Article, error) {
 	if limit <= 0 {
 		limit = 30
@@ -554,16 +1186,14 @@ func (s *ArticleStore) SearchByKeywords(ctx context.Context, topic string, limit
 		"the": true, "is": true, "are": true, "in": true, "of": true, "and": true,
 		"on": true, "to": true, "for": true, "at": true,
 	}
-	// Geographic terms that appear in most PR articles — skip to focus on topic
-	geoterms := map[string]bool{
-		"puerto": true, "rico": true, "isla": true, "boricua": true,
-	}
+	// Geographic terms that appear in most articles — skip to focus on topic
+	profile := coverage.Current()
 
 	words := strings.Fields(strings.ToLower(topic))
 	var keywords []string
 	for _, w := range words {
 		w = strings.Trim(w, "¿?¡!.,;:\"'()[]")
-		if len(w) >= 3 && !stopwords[w] && !geoterms[w] {
+		if len(w) >= 3 && !stopwords[w] && !profile.IsGeneric(w) {
 			keywords = append(keywords, w)
 		}
 	}
@@ -588,7 +1218,7 @@ func (s *ArticleStore) SearchByKeywords(ctx context.Context, topic string, limit
 	q := fmt.Sprintf(`
 		SELECT id, title, source, url, canonical_url, region, published_at,
 		       clean_text, summary, image_url, status, pinned, evidence_policy,
-		       evidence_expires_at, tags, created_at
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
 		FROM articles
 		WHERE (%s) AND status != 'trashed'
 		ORDER BY published_at DESC NULLS LAST
@@ -640,7 +1270,7 @@ func (s *ArticleStore) SearchByVector(ctx context.Context, embedding []float32,
 	rows, err := s.pool.Query(ctx, `
 		SELECT id, title, source, url, canonical_url, region, published_at,
 		       clean_text, summary, image_url, status, pinned, evidence_policy,
-		       evidence_expires_at, tags, created_at,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at,
 		       embedding <=> $1::vector AS distance
 		FROM articles
 		WHERE embedding IS NOT NULL
@@ -657,29 +1287,54 @@ func (s *ArticleStore) SearchByVector(ctx context.Context, embedding []float32,
 	var articles []Article
 	var relevances []float64
 	for rows.Next() {
-		var a Article
 		var distance float64
-		var tagsJSON []byte
-		err := rows.Scan(
-			&a.ID, &a.Title, &a.Source, &a.URL, &a.CanonicalURL,
-			&a.Region, &a.PublishedAt, &a.CleanText, &a.Summary,
-			&a.ImageURL, &a.Status, &a.Pinned, &a.EvidencePolicy,
-			&a.EvidenceExpiresAt, &tagsJSON, &a.CreatedAt,
-			&distance,
-		)
-		if err != nil {
-			return nil, nil, fmt.Errorf("article search by vector scan: %w", err)
-		}
-		if tagsJSON != nil {
-			_ = json.Unmarshal(tagsJSON, &a.Tags)
+		a := scanArticleFromRow(scoredRow{rows, &distance})
+		if a == nil {
+			return nil, nil, fmt.Errorf("article search by vector scan: failed")
 		}
-		articles = append(articles, a)
+		articles = append(articles, *a)
 		relevances = append(relevances, 1.0-distance/2.0)
 	}
 
 	return articles, relevances, rows.Err()
 }
 
+// ListSimilarSince returns articles created since the given time whose
+// embedding is within maxDistance (cosine) of the given one, closest first,
+// along with each article's cosine similarity (1 - distance).
+func (s *ArticleStore) ListSimilarSince(ctx context.Context, embedding []float32, since time.Time, maxDistance float64, limit int) ([]Article, []float64, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, title, source, url, canonical_url, region, published_at,
+		       clean_text, summary, image_url, status, pinned, evidence_policy,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at,
+		       embedding <=> $1::vector AS distance
+		FROM articles
+		WHERE embedding IS NOT NULL
+		  AND status != 'trashed'
+		  AND created_at >= $2
+		  AND (embedding <=> $1::vector) <= $3
+		ORDER BY embedding <=> $1::vector
+		LIMIT $4
+	`, formatVector(embedding), since, maxDistance, limit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("article list similar since: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	var similarities []float64
+	for rows.Next() {
+		var distance float64
+		a := scanArticleFromRow(scoredRow{rows, &distance})
+		if a == nil {
+			return nil, nil, fmt.Errorf("article list similar since scan: failed")
+		}
+		articles = append(articles, *a)
+		similarities = append(similarities, 1.0-distance)
+	}
+	return articles, similarities, rows.Err()
+}
+
 // ExistsByURL checks whether an article with the given URL already exists.
 func (s *ArticleStore) ExistsByURL(ctx context.Context, rawURL string) (bool, error) {
 	var exists bool
@@ -690,6 +1345,25 @@ func (s *ArticleStore) ExistsByURL(ctx context.Context, rawURL string) (bool, er
 	return exists, nil
 }
 
+// IDByURL returns the id of the newest article stored under the given URL
+// (or canonical URL), or uuid.Nil if there is none.
+func (s *ArticleStore) IDByURL(ctx context.Context, rawURL string) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := s.pool.QueryRow(ctx, `
+		SELECT id FROM articles
+		WHERE url = $1 OR canonical_url = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, rawURL).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("article id by url: %w", err)
+	}
+	return id, nil
+}
+
 // SearchChat searches articles using OR-based keyword matching for the AI chat.
 func (s *ArticleStore) SearchChat(ctx context.Context, question string, limit int) ([]Article, error) {
 	if limit <= 0 {
@@ -750,7 +1424,7 @@ func (s *ArticleStore) SearchChat(ctx context.Context, question string, limit in
 	q := fmt.Sprintf(`
 		SELECT id, title, source, url, canonical_url, region, published_at,
 		       clean_text, summary, image_url, status, pinned, evidence_policy,
-		       evidence_expires_at, tags, created_at
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
 		FROM articles
 		%s
 		ORDER BY published_at DESC NULLS LAST
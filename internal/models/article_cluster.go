@@ -0,0 +1,233 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/bits"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ── Story clustering ─────────────────────────────────────────────
+
+// ClusterMember is a compact reference to another article in a story cluster.
+type ClusterMember struct {
+	ID          uuid.UUID  `json:"id"`
+	Title       string     `json:"title"`
+	Source      string     `json:"source"`
+	URL         string     `json:"url"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+// ArticleCluster is an article listed as the representative of its story
+// cluster. ClusterSize counts every article in the cluster with the listed
+// status, the representative included; Related holds the others.
+type ArticleCluster struct {
+	Article
+	ClusterID   uuid.UUID       `json:"cluster_id"`
+	ClusterSize int             `json:"cluster_size"`
+	Related     []ClusterMember `json:"related,omitempty"`
+}
+
+// FindClusterBySimHash returns the cluster of an article created since
+// `since` whose SimHash differs from simhash in at most maxBits bits, closest
+// first. Returns uuid.Nil when none matches.
+func (s *ArticleStore) FindClusterBySimHash(ctx context.Context, id uuid.UUID, simhash uint64, since time.Time, maxBits int) (uuid.UUID, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT COALESCE(cluster_id, id), simhash
+		FROM articles
+		WHERE simhash IS NOT NULL AND id != $1 AND created_at >= $2
+	`, id, since)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("article find cluster by simhash: %w", err)
+	}
+	defer rows.Close()
+
+	best, bestBits := uuid.Nil, maxBits+1
+	for rows.Next() {
+		var clusterID uuid.UUID
+		var other int64
+		if err := rows.Scan(&clusterID, &other); err != nil {
+			return uuid.Nil, fmt.Errorf("article simhash scan: %w", err)
+		}
+		if d := bits.OnesCount64(simhash ^ uint64(other)); d < bestBits {
+			best, bestBits = clusterID, d
+		}
+	}
+	return best, rows.Err()
+}
+
+// FindClusterByEmbedding returns the cluster of the nearest article created
+// since `since` whose embedding is within maxDistance (cosine) of the given
+// one. Returns uuid.Nil when none matches.
+func (s *ArticleStore) FindClusterByEmbedding(ctx context.Context, id uuid.UUID, embedding []float32, since time.Time, maxDistance float64) (uuid.UUID, error) {
+	var clusterID uuid.UUID
+	err := s.pool.QueryRow(ctx, `
+		SELECT COALESCE(cluster_id, id)
+		FROM articles
+		WHERE embedding IS NOT NULL AND id != $1 AND created_at >= $2
+		  AND (embedding <=> $3::vector) <= $4
+		ORDER BY embedding <=> $3::vector
+		LIMIT 1
+	`, id, since, formatVector(embedding), maxDistance).Scan(&clusterID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("article find cluster by embedding: %w", err)
+	}
+	return clusterID, nil
+}
+
+// SetCluster records an article's cluster and the SimHash used to match later
+// articles against it.
+func (s *ArticleStore) SetCluster(ctx context.Context, id, clusterID uuid.UUID, simhash uint64) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE articles SET cluster_id = $2, simhash = $3 WHERE id = $1
+	`, id, clusterID, int64(simhash))
+	if err != nil {
+		return fmt.Errorf("article set cluster: %w", err)
+	}
+	return nil
+}
+
+// clusteredByStatus ranks the articles with status $1 within their cluster
+// (unclustered articles are clusters of one) in inbox order.
+const clusteredByStatus = `
+	WITH ranked AS (
+		SELECT a.id, a.title, a.source, a.url, a.canonical_url, a.region, a.published_at,
+		       a.clean_text, a.summary, a.image_url, a.status, a.pinned, a.evidence_policy,
+		       a.evidence_expires_at, a.tags, a.scope, a.language, a.archive_url, a.tag_source, a.created_at,
+		       COALESCE(a.cluster_id, a.id) AS cid,
+		       row_number() OVER (PARTITION BY COALESCE(a.cluster_id, a.id)
+		           ORDER BY a.pinned DESC, a.published_at DESC NULLS LAST, a.created_at DESC, a.id DESC) AS rn,
+		       count(*) OVER (PARTITION BY COALESCE(a.cluster_id, a.id)) AS cluster_size
+		FROM articles a
+		WHERE a.status = $1
+	)`
+
+// ListClustersByStatus returns articles with the given status collapsed to
+// one representative per story cluster, in the same order as ListByStatus.
+// The representative is the cluster member that would be listed first. Pages
+// continue after the cursor when it is set, otherwise at offset.
+func (s *ArticleStore) ListClustersByStatus(ctx context.Context, status string, after *ArticleCursor, limit, offset int) ([]ArticleCluster, *ArticleCursor, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	where := "rn = 1"
+	args := []any{status, limit, offset}
+	if after != nil {
+		where += ` AND (pinned, COALESCE(published_at, '-infinity'), created_at, id)
+		         < ($4::bool, COALESCE($5::timestamptz, '-infinity'), $6::timestamptz, $7::uuid)`
+		args = append(args, after.Pinned, after.PublishedAt, after.CreatedAt, after.ID)
+		args[2] = 0
+	}
+
+	rows, err := s.pool.Query(ctx, clusteredByStatus+`
+		SELECT id, title, source, url, canonical_url, region, published_at,
+		       clean_text, summary, image_url, status, pinned, evidence_policy,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at,
+		       cid, cluster_size
+		FROM ranked
+		WHERE `+where+`
+		ORDER BY pinned DESC, published_at DESC NULLS LAST, created_at DESC, id DESC
+		LIMIT $2 OFFSET $3
+	`, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("article list clusters: %w", err)
+	}
+	defer rows.Close()
+
+	var clusters []ArticleCluster
+	for rows.Next() {
+		var c ArticleCluster
+		a := scanArticleFromRow(clusterRow{rows, &c.ClusterID, &c.ClusterSize})
+		if a == nil {
+			return nil, nil, fmt.Errorf("article cluster scan: failed")
+		}
+		c.Article = *a
+		clusters = append(clusters, c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if err := s.attachRelated(ctx, status, clusters); err != nil {
+		return nil, nil, err
+	}
+
+	var next *ArticleCursor
+	if len(clusters) == limit {
+		next = cursorAfter(clusters[len(clusters)-1].Article, nil)
+	}
+	return clusters, next, nil
+}
+
+// CountClustersByStatus returns the number of story clusters among articles
+// with the given status.
+func (s *ArticleStore) CountClustersByStatus(ctx context.Context, status string) (int, error) {
+	var count int
+	err := s.pool.QueryRow(ctx, `
+		SELECT COUNT(DISTINCT COALESCE(cluster_id, id)) FROM articles WHERE status = $1
+	`, status).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("article count clusters: %w", err)
+	}
+	return count, nil
+}
+
+// attachRelated fills Related for clusters with more than one member.
+func (s *ArticleStore) attachRelated(ctx context.Context, status string, clusters []ArticleCluster) error {
+	index := make(map[uuid.UUID]int)
+	var ids []uuid.UUID
+	for i, c := range clusters {
+		if c.ClusterSize > 1 {
+			index[c.ClusterID] = i
+			ids = append(ids, c.ClusterID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT COALESCE(cluster_id, id), id, title, source, url, published_at
+		FROM articles
+		WHERE status = $1 AND COALESCE(cluster_id, id) = ANY($2)
+		ORDER BY published_at DESC NULLS LAST, created_at DESC
+	`, status, ids)
+	if err != nil {
+		return fmt.Errorf("article cluster members: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var clusterID uuid.UUID
+		var m ClusterMember
+		if err := rows.Scan(&clusterID, &m.ID, &m.Title, &m.Source, &m.URL, &m.PublishedAt); err != nil {
+			return fmt.Errorf("article cluster member scan: %w", err)
+		}
+		i, ok := index[clusterID]
+		if !ok || clusters[i].ID == m.ID {
+			continue
+		}
+		clusters[i].Related = append(clusters[i].Related, m)
+	}
+	return rows.Err()
+}
+
+// clusterRow adapts a row with trailing cluster id and size columns to
+// scanArticleFromRow.
+type clusterRow struct {
+	row  scannable
+	id   *uuid.UUID
+	size *int
+}
+
+func (r clusterRow) Scan(dest ...any) error {
+	return r.row.Scan(append(dest, r.id, r.size)...)
+}
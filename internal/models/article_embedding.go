@@ -0,0 +1,65 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// unembeddedWhere selects live articles that never got an embedding. Archived
+// embeddings are regenerated on restore instead, and articles from the last
+// hour are left to their enrichment job.
+const unembeddedWhere = `
+	embedding IS NULL AND embedding_archived_at IS NULL AND status <> 'trashed'
+	AND created_at < NOW() - INTERVAL '1 hour'
+	AND (clean_text <> '' OR title <> '' OR summary <> '')`
+
+// ListUnembedded returns up to limit articles lacking an embedding with an ID
+// after the given one, in ID order, so a caller can page through them. Only
+// the fields needed to embed them are set: ID, Title, Summary and CleanText.
+func (s *ArticleStore) ListUnembedded(ctx context.Context, after uuid.UUID, limit int) ([]Article, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, title, COALESCE(summary, ''), COALESCE(clean_text, '') FROM articles
+		WHERE id > $1 AND `+unembeddedWhere+`
+		ORDER BY id
+		LIMIT $2
+	`, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("article list unembedded: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	for rows.Next() {
+		var a Article
+		if err := rows.Scan(&a.ID, &a.Title, &a.Summary, &a.CleanText); err != nil {
+			return nil, fmt.Errorf("article list unembedded scan: %w", err)
+		}
+		articles = append(articles, a)
+	}
+	return articles, rows.Err()
+}
+
+// CountUnembedded returns how many articles ListUnembedded would walk.
+func (s *ArticleStore) CountUnembedded(ctx context.Context) (int, error) {
+	var n int
+	if err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM articles WHERE `+unembeddedWhere).Scan(&n); err != nil {
+		return 0, fmt.Errorf("article count unembedded: %w", err)
+	}
+	return n, nil
+}
+
+// SetMissingEmbedding stores the embedding of an article that has none,
+// leaving it alone if enrichment stored one in the meantime or it was
+// trashed. Reports whether it was stored.
+func (s *ArticleStore) SetMissingEmbedding(ctx context.Context, id uuid.UUID, embedding []float32) (bool, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE articles SET embedding = $2::vector
+		WHERE id = $1 AND embedding IS NULL AND embedding_archived_at IS NULL AND status <> 'trashed'
+	`, id, formatVector(embedding))
+	if err != nil {
+		return false, fmt.Errorf("article set missing embedding: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// ── Evidence text index ──────────────────────────────────────────
+
+// maxEvidenceIndexBytes caps the text indexed per article, keeping the
+// tsvector well under PostgreSQL's 1 MB limit.
+const maxEvidenceIndexBytes = 512 * 1024
+
+// ListEvidenceUnindexed returns articles whose evidence text has not been
+// indexed yet, newest first. Articles younger than minAge are left for later
+// so their evidence upload job has had a chance to run.
+func (s *ArticleStore) ListEvidenceUnindexed(ctx context.Context, minAge time.Duration, limit int) ([]Article, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT a.id, a.title, a.source, a.url, a.canonical_url, a.region, a.published_at,
+		       a.clean_text, a.summary, a.image_url, a.status, a.pinned, a.evidence_policy,
+		       a.evidence_expires_at, a.tags, a.scope, a.language, a.archive_url, a.tag_source, a.created_at
+		FROM articles a
+		LEFT JOIN article_evidence_text e ON e.article_id = a.id
+		WHERE e.article_id IS NULL
+		  AND a.created_at < $1
+		ORDER BY a.created_at DESC
+		LIMIT $2
+	`, time.Now().Add(-minAge), limit)
+	if err != nil {
+		return nil, fmt.Errorf("article list evidence unindexed: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	for rows.Next() {
+		a := scanArticleFromRow(rows)
+		if a == nil {
+			return nil, fmt.Errorf("article evidence unindexed scan: failed")
+		}
+		articles = append(articles, *a)
+	}
+	return articles, rows.Err()
+}
+
+// SetEvidenceText indexes an article's full evidence text with the article's
+// language config. An empty text records that the article has no evidence.
+func (s *ArticleStore) SetEvidenceText(ctx context.Context, id uuid.UUID, text string) error {
+	if len(text) > maxEvidenceIndexBytes {
+		text = text[:maxEvidenceIndexBytes]
+		for !utf8.ValidString(text) {
+			text = text[:len(text)-1]
+		}
+	}
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO article_evidence_text (article_id, search_tsv, char_count, indexed_at)
+		SELECT a.id,
+		       CASE WHEN $2 = '' THEN NULL ELSE to_tsvector(
+		           CASE a.language
+		               WHEN 'es' THEN 'spanish'::regconfig
+		               WHEN 'en' THEN 'english'::regconfig
+		               ELSE 'simple'::regconfig
+		           END, $2) END,
+		       char_length($2), NOW()
+		FROM articles a
+		WHERE a.id = $1
+		ON CONFLICT (article_id) DO UPDATE
+		SET search_tsv = EXCLUDED.search_tsv,
+		    char_count = EXCLUDED.char_count,
+		    indexed_at = EXCLUDED.indexed_at
+	`, id, text)
+	if err != nil {
+		return fmt.Errorf("article set evidence text: %w", err)
+	}
+	return nil
+}
+
+// ResetEvidenceText drops an article's evidence text index so it is indexed
+// again, e.g. after new evidence was uploaded.
+func (s *ArticleStore) ResetEvidenceText(ctx context.Context, id uuid.UUID) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM article_evidence_text WHERE article_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("article reset evidence text: %w", err)
+	}
+	return nil
+}
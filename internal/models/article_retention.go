@@ -0,0 +1,151 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RetentionFilter selects the articles a bulk retention change applies to.
+// Zero fields match every article; at least one must be set (Empty).
+type RetentionFilter struct {
+	IDs                []uuid.UUID
+	Status             string
+	Source             string
+	Tag                string
+	Region             string
+	Policy             string    // current evidence policy
+	From               time.Time // created_at >= From
+	To                 time.Time // created_at < To
+	ExpiringWithinDays int
+}
+
+// Empty reports whether the filter would match every article.
+func (f RetentionFilter) Empty() bool {
+	return len(f.IDs) == 0 && f.Status == "" && f.Source == "" && f.Tag == "" &&
+		f.Region == "" && f.Policy == "" && f.From.IsZero() && f.To.IsZero() &&
+		f.ExpiringWithinDays <= 0
+}
+
+// clause returns the WHERE clause selecting the filter's articles, numbering
+// placeholders from argN.
+func (f RetentionFilter) clause(argN int) (string, []any) {
+	conditions := []string{"TRUE"}
+	var args []any
+	add := func(cond string, arg any) {
+		conditions = append(conditions, fmt.Sprintf(cond, argN))
+		args = append(args, arg)
+		argN++
+	}
+
+	if len(f.IDs) > 0 {
+		add("id = ANY($%d)", f.IDs)
+	}
+	if f.Status != "" {
+		add("status = $%d", f.Status)
+	}
+	if f.Source != "" {
+		add("source = $%d", f.Source)
+	}
+	if f.Tag != "" {
+		add("tags @> to_jsonb(ARRAY[$%d::text])", f.Tag)
+	}
+	if f.Region != "" {
+		add("region = $%d", f.Region)
+	}
+	if f.Policy != "" {
+		add("evidence_policy = $%d", f.Policy)
+	}
+	if !f.From.IsZero() {
+		add("created_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("created_at < $%d", f.To)
+	}
+	if f.ExpiringWithinDays > 0 {
+		conditions = append(conditions, "evidence_policy != 'keep'", "evidence_expires_at >= now()")
+		add("evidence_expires_at < now() + make_interval(days => $%d)", f.ExpiringWithinDays)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// CountRetention returns how many articles match the filter, for a dry run
+// of BulkUpdateRetention.
+func (s *ArticleStore) CountRetention(ctx context.Context, f RetentionFilter) (int, error) {
+	where, args := f.clause(1)
+	var n int
+	if err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM articles WHERE `+where, args...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("article count retention: %w", err)
+	}
+	return n, nil
+}
+
+// BulkUpdateRetention sets the evidence policy of every article matching the
+// filter, recalculating the expiry from now as UpdateRetention does. It
+// returns the number of articles updated.
+func (s *ArticleStore) BulkUpdateRetention(ctx context.Context, f RetentionFilter, policy string) (int, error) {
+	var interval *string // nil (no expiry) for keep
+	switch policy {
+	case "ret_6m":
+		i := "6 months"
+		interval = &i
+	case "ret_12m":
+		i := "12 months"
+		interval = &i
+	case "keep":
+	default:
+		return 0, fmt.Errorf("article bulk update retention: invalid policy %q", policy)
+	}
+
+	where, args := f.clause(3)
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE articles
+		SET evidence_policy = $1,
+		    evidence_expires_at = now() + $2::interval
+		WHERE `+where,
+		append([]any{policy, interval}, args...)...)
+	if err != nil {
+		return 0, fmt.Errorf("article bulk update retention: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// FlagExpiringEvidence marks the articles whose evidence expires within the
+// given number of days and that were not warned about for their current
+// expiry date, and returns them soonest first. Extending retention moves the
+// expiry date, so an article is warned about again if it nears the new one.
+func (s *ArticleStore) FlagExpiringEvidence(ctx context.Context, withinDays int) ([]Article, error) {
+	rows, err := s.pool.Query(ctx, `
+		WITH flagged AS (
+			UPDATE articles
+			SET evidence_expiry_warned_for = evidence_expires_at
+			WHERE evidence_expires_at IS NOT NULL
+			  AND evidence_policy != 'keep'
+			  AND evidence_expires_at >= now()
+			  AND evidence_expires_at < now() + make_interval(days => $1)
+			  AND evidence_expiry_warned_for IS DISTINCT FROM evidence_expires_at
+			RETURNING id, title, source, url, canonical_url, region, published_at,
+			          clean_text, summary, image_url, status, pinned, evidence_policy,
+			          evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
+		)
+		SELECT * FROM flagged
+		ORDER BY evidence_expires_at ASC
+	`, withinDays)
+	if err != nil {
+		return nil, fmt.Errorf("article flag expiring evidence: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	for rows.Next() {
+		a := scanArticleFromRow(rows)
+		if a == nil {
+			return nil, fmt.Errorf("article flag expiring evidence: scan failed")
+		}
+		articles = append(articles, *a)
+	}
+	return articles, rows.Err()
+}
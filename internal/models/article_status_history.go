@@ -0,0 +1,98 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StatusChange is one entry of an article's status history.
+type StatusChange struct {
+	FromStatus *string   `json:"from_status"` // nil when the article was created
+	ToStatus   string    `json:"to_status"`
+	Reason     string    `json:"reason"` // created, user, retention, triage, backfill
+	ChangedAt  time.Time `json:"changed_at"`
+}
+
+// statusAsOfJoin selects, for each article a, its status at $2 according to
+// the status history as s.status.
+const statusAsOfJoin = `
+		JOIN LATERAL (
+			SELECT h.to_status AS status FROM article_status_history h
+			WHERE h.article_id = a.id AND h.changed_at <= $2
+			ORDER BY h.changed_at DESC, h.id DESC
+			LIMIT 1
+		) s ON true`
+
+// ListByStatusAsOf returns the articles that had the given status at asOf,
+// according to the status history, newest first. The articles are returned
+// as they are now; only set membership is historical.
+func (s *ArticleStore) ListByStatusAsOf(ctx context.Context, status string, asOf time.Time, limit, offset int) ([]Article, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT a.id, a.title, a.source, a.url, a.canonical_url, a.region, a.published_at,
+		       a.clean_text, a.summary, a.image_url, a.status, a.pinned, a.evidence_policy,
+		       a.evidence_expires_at, a.tags, a.scope, a.language, a.archive_url, a.tag_source, a.created_at
+		FROM articles a`+statusAsOfJoin+`
+		WHERE s.status = $1
+		ORDER BY a.published_at DESC NULLS LAST, a.created_at DESC, a.id DESC
+		LIMIT $3 OFFSET $4
+	`, status, asOf, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("article list as of: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	for rows.Next() {
+		a := scanArticleFromRow(rows)
+		if a == nil {
+			return nil, fmt.Errorf("article scan: failed")
+		}
+		articles = append(articles, *a)
+	}
+	return articles, rows.Err()
+}
+
+// CountByStatusAsOf returns the number of articles that had the given status
+// at asOf.
+func (s *ArticleStore) CountByStatusAsOf(ctx context.Context, status string, asOf time.Time) (int, error) {
+	var count int
+	err := s.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM articles a`+statusAsOfJoin+`
+		WHERE s.status = $1
+	`, status, asOf).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("article count as of: %w", err)
+	}
+	return count, nil
+}
+
+// StatusHistory returns an article's status changes, oldest first.
+func (s *ArticleStore) StatusHistory(ctx context.Context, id uuid.UUID) ([]StatusChange, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT from_status, to_status, reason, changed_at
+		FROM article_status_history
+		WHERE article_id = $1
+		ORDER BY changed_at, id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("article status history: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []StatusChange
+	for rows.Next() {
+		var c StatusChange
+		if err := rows.Scan(&c.FromStatus, &c.ToStatus, &c.Reason, &c.ChangedAt); err != nil {
+			return nil, fmt.Errorf("article status history scan: %w", err)
+		}
+		changes = append(changes, c)
+	}
+	return changes, rows.Err()
+}
@@ -0,0 +1,114 @@
+package models
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// TagSourceInferred marks tags assigned by InferTags rather than the
+// classifier.
+const TagSourceInferred = "inferred"
+
+// TagInference tunes InferTags.
+type TagInference struct {
+	MinSupport  int     // classifier-tagged articles a tag needs to get a centroid
+	MaxDistance float64 // cosine distance (0–2) within which a centroid's tag is assigned
+	MaxTags     int     // tags assigned per article, nearest first
+}
+
+// TagInferenceBatch reports one InferTags call.
+type TagInferenceBatch struct {
+	Checked int       // untagged articles looked at
+	Tagged  int       // of those, articles that got tags
+	Last    uuid.UUID // cursor for the next batch; uuid.Nil when none were left
+}
+
+// InferTags tags up to limit untagged, embedded articles with ids after
+// the cursor (uuid.Nil for the first batch) by nearest-centroid matching: each
+// active tag's centroid is the mean embedding of the articles the classifier
+// gave it, and an article gets the tags of its nearest centroids within
+// MaxDistance. Tagged articles are marked TagSourceInferred; inferred tags
+// never feed the centroids, and are replaced when the article is enriched
+// again.
+func (s *ArticleStore) InferTags(ctx context.Context, after uuid.UUID, limit int, opts TagInference) (TagInferenceBatch, error) {
+	var b TagInferenceBatch
+	var last *uuid.UUID
+	err := s.pool.QueryRow(ctx, `
+		WITH candidates AS (
+			SELECT id, embedding
+			FROM articles
+			WHERE embedding IS NOT NULL
+			  AND COALESCE(tags, '[]'::jsonb) = '[]'::jsonb
+			  AND status != 'trashed'
+			  AND id > $1
+			ORDER BY id
+			LIMIT $2
+		), centroids AS (
+			SELECT t.tag, avg(a.embedding) AS centroid
+			FROM articles a
+			CROSS JOIN LATERAL jsonb_array_elements_text(a.tags) AS t(tag)
+			WHERE a.embedding IS NOT NULL
+			  AND a.tag_source = ''
+			  AND t.tag IN (SELECT name FROM tags WHERE active)
+			GROUP BY t.tag
+			HAVING count(*) >= $3
+		), matches AS (
+			SELECT c.id, m.tag, m.distance
+			FROM candidates c
+			CROSS JOIN LATERAL (
+				SELECT tag, c.embedding <=> centroid AS distance
+				FROM centroids
+				ORDER BY distance
+				LIMIT $4
+			) m
+			WHERE m.distance <= $5
+		), updated AS (
+			UPDATE articles a
+			SET tags = m.tags, tag_source = 'inferred'
+			FROM (
+				SELECT id, jsonb_agg(tag ORDER BY distance) AS tags
+				FROM matches
+				GROUP BY id
+			) m
+			WHERE a.id = m.id
+			RETURNING a.id
+		)
+		SELECT (SELECT count(*) FROM candidates),
+		       (SELECT count(*) FROM updated),
+		       (SELECT id FROM candidates ORDER BY id DESC LIMIT 1)
+	`, after, limit, opts.MinSupport, opts.MaxTags, opts.MaxDistance).Scan(&b.Checked, &b.Tagged, &last)
+	if err != nil {
+		return b, fmt.Errorf("article infer tags: %w", err)
+	}
+	if last != nil {
+		b.Last = *last
+	}
+	return b, nil
+}
+
+// ListInferredTagIDs returns up to limit ids of articles whose tags were
+// inferred, for handing back to the classifier.
+func (s *ArticleStore) ListInferredTagIDs(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id FROM articles
+		WHERE tag_source = 'inferred'
+		ORDER BY created_at DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("article list inferred tags: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("article list inferred tags: scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
@@ -0,0 +1,257 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// TrashEvidenceDays is how long the evidence of a trashed article is kept
+// (the ret_3m policy), unless the article is restored first.
+const TrashEvidenceDays = 90
+
+// Trash moves an article to the trash: it is dated for the purge job and
+// its evidence switches to the 3-month policy. The policy and expiry it had
+// are saved so that moving it out of the trash (Restore, or UpdateStatus to
+// any other status) gives them back. Trashing an article already in the
+// trash changes nothing.
+func (s *ArticleStore) Trash(ctx context.Context, id uuid.UUID) error {
+	var found int
+	err := s.pool.QueryRow(ctx, `
+		WITH old AS (
+			SELECT id, status FROM articles WHERE id = $1 FOR UPDATE
+		), changed AS (
+			UPDATE articles a
+			SET status = 'trashed',
+			    deleted_at = NOW(),
+			    pre_trash_evidence_policy = a.evidence_policy,
+			    pre_trash_expires_at = a.evidence_expires_at,
+			    evidence_policy = 'ret_3m',
+			    evidence_expires_at = NOW() + make_interval(days => $2)
+			FROM old
+			WHERE a.id = old.id AND old.status <> 'trashed'
+			RETURNING a.id, old.status AS from_status
+		), logged AS (
+			INSERT INTO article_status_history (article_id, from_status, to_status, reason)
+			SELECT id, from_status, 'trashed', 'user' FROM changed
+		)
+		SELECT COUNT(*) FROM old
+	`, id, TrashEvidenceDays).Scan(&found)
+	if err != nil {
+		return fmt.Errorf("article trash: %w", err)
+	}
+	if found == 0 {
+		return fmt.Errorf("article not found: %s", id)
+	}
+	return nil
+}
+
+// Restore moves a trashed article back to the status it had before it was
+// trashed, per the status history (inbox when unknown), and gives back its
+// evidence policy and expiry. Evidence already deleted while the article was
+// in the trash is not recovered; its expiry stays cleared. It returns the
+// restored status.
+func (s *ArticleStore) Restore(ctx context.Context, id uuid.UUID) (string, error) {
+	var status, previous string
+	err := s.pool.QueryRow(ctx, `
+		SELECT a.status, COALESCE((
+			SELECT h.from_status FROM article_status_history h
+			WHERE h.article_id = a.id AND h.to_status = 'trashed' AND h.from_status <> 'trashed'
+			ORDER BY h.changed_at DESC, h.id DESC
+			LIMIT 1
+		), 'inbox')
+		FROM articles a
+		WHERE a.id = $1
+	`, id).Scan(&status, &previous)
+	if err != nil {
+		return "", fmt.Errorf("article restore: %w", err)
+	}
+	if status != "trashed" {
+		return "", fmt.Errorf("article restore: %s is not trashed", id)
+	}
+	if err := s.UpdateStatus(ctx, id, previous); err != nil {
+		return "", err
+	}
+	return previous, nil
+}
+
+// SetTrashTimes fills DeletedAt and, when purgeAfterDays is positive,
+// PurgeAt on the given trashed articles.
+func (s *ArticleStore) SetTrashTimes(ctx context.Context, articles []*Article, purgeAfterDays int) error {
+	if len(articles) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(articles))
+	for i, a := range articles {
+		ids[i] = a.ID
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, deleted_at FROM articles WHERE id = ANY($1) AND deleted_at IS NOT NULL
+	`, ids)
+	if err != nil {
+		return fmt.Errorf("article trash times: %w", err)
+	}
+	defer rows.Close()
+
+	deleted := make(map[uuid.UUID]time.Time, len(articles))
+	for rows.Next() {
+		var id uuid.UUID
+		var t time.Time
+		if err := rows.Scan(&id, &t); err != nil {
+			return fmt.Errorf("article trash times scan: %w", err)
+		}
+		deleted[id] = t
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("article trash times: %w", err)
+	}
+
+	for _, a := range articles {
+		t, ok := deleted[a.ID]
+		if !ok {
+			continue
+		}
+		a.DeletedAt = &t
+		if purgeAfterDays > 0 {
+			purge := t.AddDate(0, 0, purgeAfterDays)
+			a.PurgeAt = &purge
+		}
+	}
+	return nil
+}
+
+// ListPurgeable returns up to limit articles trashed more than olderThanDays
+// days ago, oldest first. Pinned articles are never purged.
+func (s *ArticleStore) ListPurgeable(ctx context.Context, olderThanDays, limit int) ([]uuid.UUID, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id FROM articles
+		WHERE status = 'trashed' AND pinned = false
+		  AND deleted_at < NOW() - make_interval(days => $1)
+		ORDER BY deleted_at ASC
+		LIMIT $2
+	`, olderThanDays, limit)
+	if err != nil {
+		return nil, fmt.Errorf("article list purgeable: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("article list purgeable scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// Purge hard-deletes the given articles, together with their notes, entity
+// links, status history and other dependent rows, provided they are still
+// purgeable: an article restored since ListPurgeable is kept. It returns
+// the IDs actually deleted.
+func (s *ArticleStore) Purge(ctx context.Context, ids []uuid.UUID, olderThanDays int) ([]uuid.UUID, error) {
+	rows, err := s.pool.Query(ctx, `
+		DELETE FROM articles
+		WHERE id = ANY($1) AND status = 'trashed' AND pinned = false
+		  AND deleted_at < NOW() - make_interval(days => $2)
+		RETURNING id
+	`, ids, olderThanDays)
+	if err != nil {
+		return nil, fmt.Errorf("article purge: %w", err)
+	}
+	defer rows.Close()
+
+	var purged []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("article purge scan: %w", err)
+		}
+		purged = append(purged, id)
+	}
+	return purged, rows.Err()
+}
+
+// ArchiveTrashedEmbeddings drops the embeddings of up to limit articles
+// trashed more than olderThanDays days ago, taking them out of the HNSW
+// index and similarity results, and marks them so their embedding is
+// regenerated if they are restored. Pinned articles keep theirs. It returns
+// how many were archived.
+func (s *ArticleStore) ArchiveTrashedEmbeddings(ctx context.Context, olderThanDays, limit int) (int64, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE articles
+		SET embedding = NULL, embedding_archived_at = NOW()
+		WHERE id IN (
+			SELECT id FROM articles
+			WHERE status = 'trashed' AND pinned = false AND embedding IS NOT NULL
+			  AND deleted_at < NOW() - make_interval(days => $1)
+			ORDER BY deleted_at ASC
+			LIMIT $2
+		)
+	`, olderThanDays, limit)
+	if err != nil {
+		return 0, fmt.Errorf("article archive embeddings: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
+
+// EmbeddingArchived reports whether an article's embedding was archived by
+// ArchiveTrashedEmbeddings and not regenerated since.
+func (s *ArticleStore) EmbeddingArchived(ctx context.Context, id uuid.UUID) (bool, error) {
+	var archived bool
+	err := s.pool.QueryRow(ctx, `
+		SELECT embedding_archived_at IS NOT NULL FROM articles WHERE id = $1
+	`, id).Scan(&archived)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("article embedding archived: %w", err)
+	}
+	return archived, nil
+}
+
+// ListRestoredUnembedded returns up to limit articles restored from the
+// trash whose embedding was archived and not yet regenerated.
+func (s *ArticleStore) ListRestoredUnembedded(ctx context.Context, limit int) ([]uuid.UUID, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id FROM articles
+		WHERE embedding_archived_at IS NOT NULL AND status <> 'trashed'
+		ORDER BY embedding_archived_at ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("article list restored unembedded: %w", err)
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("article list restored unembedded scan: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// RestoreEmbedding stores a regenerated embedding on an article whose
+// embedding was archived, provided it is out of the trash. Reports whether
+// it was stored.
+func (s *ArticleStore) RestoreEmbedding(ctx context.Context, id uuid.UUID, embedding []float32) (bool, error) {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE articles
+		SET embedding = $2::vector, embedding_archived_at = NULL
+		WHERE id = $1 AND embedding_archived_at IS NOT NULL AND status <> 'trashed'
+	`, id, formatVector(embedding))
+	if err != nil {
+		return false, fmt.Errorf("article restore embedding: %w", err)
+	}
+	return tag.RowsAffected() > 0, nil
+}
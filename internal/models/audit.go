@@ -0,0 +1,130 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AuditEntry is one audited request: who did what to which entity.
+type AuditEntry struct {
+	ID        int64      `json:"id"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	UserEmail string     `json:"user_email"`
+	Action    string     `json:"action"` // method and route pattern
+	Entity    string     `json:"entity,omitempty"`
+	EntityID  string     `json:"entity_id,omitempty"`
+	Status    int        `json:"status"`
+	IP        string     `json:"ip,omitempty"`
+	RequestID string     `json:"request_id,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// AuditFilter selects audit entries. Zero fields match everything.
+type AuditFilter struct {
+	UserID    uuid.UUID
+	UserEmail string // case-insensitive
+	Action    string // case-insensitive substring of the action, e.g. "trash"
+	Entity    string
+	EntityID  string
+	From      time.Time // created_at >= From
+	To        time.Time // created_at < To
+}
+
+// clause returns the WHERE clause selecting the filter's entries, numbering
+// placeholders from argN.
+func (f AuditFilter) clause(argN int) (string, []any) {
+	conditions := []string{"TRUE"}
+	var args []any
+	add := func(cond string, arg any) {
+		conditions = append(conditions, fmt.Sprintf(cond, argN))
+		args = append(args, arg)
+		argN++
+	}
+
+	if f.UserID != uuid.Nil {
+		add("user_id = $%d", f.UserID)
+	}
+	if f.UserEmail != "" {
+		add("lower(user_email) = lower($%d)", f.UserEmail)
+	}
+	if f.Action != "" {
+		add("action ILIKE '%%' || $%d || '%%'", f.Action)
+	}
+	if f.Entity != "" {
+		add("entity = $%d", f.Entity)
+	}
+	if f.EntityID != "" {
+		add("entity_id = $%d", f.EntityID)
+	}
+	if !f.From.IsZero() {
+		add("created_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("created_at < $%d", f.To)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// AuditStore records and lists audited requests.
+type AuditStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewAuditStore creates a new AuditStore.
+func NewAuditStore(pool *pgxpool.Pool) *AuditStore {
+	return &AuditStore{pool: pool}
+}
+
+// Record inserts an audit entry.
+func (s *AuditStore) Record(ctx context.Context, e *AuditEntry) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO audit_log (user_id, user_email, action, entity, entity_id, status, ip, request_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, e.UserID, e.UserEmail, e.Action, e.Entity, e.EntityID, e.Status, e.IP, e.RequestID)
+	if err != nil {
+		return fmt.Errorf("audit record: %w", err)
+	}
+	return nil
+}
+
+// List returns the entries matching the filter, newest first.
+func (s *AuditStore) List(ctx context.Context, f AuditFilter, limit, offset int) ([]AuditEntry, error) {
+	where, args := f.clause(3)
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, user_id, user_email, action, entity, entity_id, status, ip, request_id, created_at
+		FROM audit_log
+		WHERE `+where+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`, append([]any{limit, offset}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("audit list: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []AuditEntry
+	for rows.Next() {
+		var e AuditEntry
+		if err := rows.Scan(&e.ID, &e.UserID, &e.UserEmail, &e.Action, &e.Entity, &e.EntityID,
+			&e.Status, &e.IP, &e.RequestID, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("audit scan: %w", err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, rows.Err()
+}
+
+// Count returns how many entries match the filter.
+func (s *AuditStore) Count(ctx context.Context, f AuditFilter) (int, error) {
+	where, args := f.clause(1)
+	var n int
+	if err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM audit_log WHERE `+where, args...).Scan(&n); err != nil {
+		return 0, fmt.Errorf("audit count: %w", err)
+	}
+	return n, nil
+}
@@ -0,0 +1,117 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// BackupRun records one database backup attempt.
+type BackupRun struct {
+	ID         uuid.UUID  `json:"id"`
+	StartedAt  time.Time  `json:"started_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+	Method     string     `json:"method"` // pg_dump or copy
+	ObjectKey  string     `json:"object_key"`
+	SizeBytes  int64      `json:"size_bytes"`
+	Tables     int        `json:"tables"`
+	Status     string     `json:"status"` // running, ok, failed
+	Error      string     `json:"error,omitempty"`
+	Pruned     int        `json:"pruned"` // old backups deleted by rotation
+}
+
+// BackupRunStore provides database operations for backup runs.
+type BackupRunStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewBackupRunStore creates a new BackupRunStore.
+func NewBackupRunStore(pool *pgxpool.Pool) *BackupRunStore {
+	return &BackupRunStore{pool: pool}
+}
+
+// Start records a new running backup and returns it.
+func (s *BackupRunStore) Start(ctx context.Context, method string) (*BackupRun, error) {
+	run := &BackupRun{Method: method, Status: "running"}
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO backup_runs (method) VALUES ($1)
+		RETURNING id, started_at
+	`, method).Scan(&run.ID, &run.StartedAt)
+	if err != nil {
+		return nil, fmt.Errorf("backup run start: %w", err)
+	}
+	return run, nil
+}
+
+// Finish stores the outcome of a run. Status is "failed" when run.Error is
+// set and "ok" otherwise.
+func (s *BackupRunStore) Finish(ctx context.Context, run *BackupRun) error {
+	run.Status = "ok"
+	if run.Error != "" {
+		run.Status = "failed"
+	}
+	err := s.pool.QueryRow(ctx, `
+		UPDATE backup_runs
+		SET finished_at = now(), object_key = $2, size_bytes = $3, tables = $4,
+		    status = $5, error = $6, pruned = $7
+		WHERE id = $1
+		RETURNING finished_at
+	`, run.ID, run.ObjectKey, run.SizeBytes, run.Tables, run.Status, run.Error, run.Pruned).Scan(&run.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("backup run finish: %w", err)
+	}
+	return nil
+}
+
+// List returns the most recent runs, newest first.
+func (s *BackupRunStore) List(ctx context.Context, limit int) ([]BackupRun, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, started_at, finished_at, method, object_key, size_bytes, tables, status, error, pruned
+		FROM backup_runs
+		ORDER BY started_at DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("backup runs list: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []BackupRun
+	for rows.Next() {
+		var r BackupRun
+		if err := rows.Scan(&r.ID, &r.StartedAt, &r.FinishedAt, &r.Method, &r.ObjectKey,
+			&r.SizeBytes, &r.Tables, &r.Status, &r.Error, &r.Pruned); err != nil {
+			return nil, fmt.Errorf("backup runs scan: %w", err)
+		}
+		runs = append(runs, r)
+	}
+	return runs, rows.Err()
+}
+
+// LastSuccess returns the most recent successful run, or nil if there is none.
+func (s *BackupRunStore) LastSuccess(ctx context.Context) (*BackupRun, error) {
+	var r BackupRun
+	err := s.pool.QueryRow(ctx, `
+		SELECT id, started_at, finished_at, method, object_key, size_bytes, tables, status, error, pruned
+		FROM backup_runs
+		WHERE status = 'ok'
+		ORDER BY started_at DESC
+		LIMIT 1
+	`).Scan(&r.ID, &r.StartedAt, &r.FinishedAt, &r.Method, &r.ObjectKey,
+		&r.SizeBytes, &r.Tables, &r.Status, &r.Error, &r.Pruned)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("backup run last success: %w", err)
+	}
+	return &r, nil
+}
@@ -12,12 +12,45 @@ import (
 
 // Brief represents a daily intelligence summary.
 type Brief struct {
-	ID           uuid.UUID `json:"id"`
-	Date         time.Time `json:"date"`
-	Summary      string    `json:"summary"`
-	TopTags      []string  `json:"top_tags"`
-	ArticleCount int       `json:"article_count"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           uuid.UUID      `json:"id"`
+	Date         time.Time      `json:"date"`
+	Summary      string         `json:"summary"`
+	TopTags      []string       `json:"top_tags"`
+	ArticleCount int            `json:"article_count"`
+	Quotes       []BriefQuote   `json:"quotes"`
+	TopEntities  []EntityCount  `json:"top_entities"`
+	Sections     []BriefSection `json:"sections"`
+	Params       *BriefParams   `json:"params,omitempty"` // nil for briefs generated before parameters were recorded
+	CreatedAt    time.Time      `json:"created_at"`
+}
+
+// BriefSection summarizes the brief's articles on one topic. Summary cites
+// them by their 1-based position in Articles, e.g. "[2]".
+type BriefSection struct {
+	Tag      string                `json:"tag"` // empty for the catch-all section
+	Title    string                `json:"title"`
+	Summary  string                `json:"summary"`
+	Articles []BriefSectionArticle `json:"articles"`
+}
+
+// BriefSectionArticle is an article a brief section was written from.
+type BriefSectionArticle struct {
+	ID     uuid.UUID `json:"id"`
+	Title  string    `json:"title"`
+	Source string    `json:"source"`
+	URL    string    `json:"url"`
+}
+
+// BriefParams are the parameters a brief was generated with. Articles
+// created in [From, To) are summarized; Tags (any of them) and Region
+// narrow them when set.
+type BriefParams struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Tags     []string  `json:"tags,omitempty"`
+	Region   string    `json:"region,omitempty"`
+	Language string    `json:"language"`
+	Model    string    `json:"model"`
 }
 
 // BriefStore provides data access methods for daily briefs.
@@ -33,33 +66,61 @@ func NewBriefStore(pool *pgxpool.Pool) *BriefStore {
 // GetLatest returns the most recent daily brief.
 func (s *BriefStore) GetLatest(ctx context.Context) (*Brief, error) {
 	var b Brief
-	var tagsRaw []byte
+	var tagsRaw, quotesRaw, entitiesRaw, sectionsRaw, paramsRaw []byte
 	err := s.pool.QueryRow(ctx, `
-		SELECT id, date, summary, top_tags, article_count, created_at
+		SELECT id, date, summary, top_tags, article_count, quotes, top_entities, sections, params, created_at
 		FROM briefs
 		ORDER BY date DESC
 		LIMIT 1
-	`).Scan(&b.ID, &b.Date, &b.Summary, &tagsRaw, &b.ArticleCount, &b.CreatedAt)
+	`).Scan(&b.ID, &b.Date, &b.Summary, &tagsRaw, &b.ArticleCount, &quotesRaw, &entitiesRaw, &sectionsRaw, &paramsRaw, &b.CreatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("brief get latest: %w", err)
 	}
 	b.TopTags = scanBriefTags(tagsRaw)
+	b.Quotes = scanBriefQuotes(quotesRaw)
+	b.TopEntities = scanBriefEntities(entitiesRaw)
+	b.Sections = scanBriefSections(sectionsRaw)
+	b.Params = scanBriefParams(paramsRaw)
+	return &b, nil
+}
+
+// GetByID returns a brief by ID.
+func (s *BriefStore) GetByID(ctx context.Context, id uuid.UUID) (*Brief, error) {
+	var b Brief
+	var tagsRaw, quotesRaw, entitiesRaw, sectionsRaw, paramsRaw []byte
+	err := s.pool.QueryRow(ctx, `
+		SELECT id, date, summary, top_tags, article_count, quotes, top_entities, sections, params, created_at
+		FROM briefs
+		WHERE id = $1
+	`, id).Scan(&b.ID, &b.Date, &b.Summary, &tagsRaw, &b.ArticleCount, &quotesRaw, &entitiesRaw, &sectionsRaw, &paramsRaw, &b.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("brief get by id: %w", err)
+	}
+	b.TopTags = scanBriefTags(tagsRaw)
+	b.Quotes = scanBriefQuotes(quotesRaw)
+	b.TopEntities = scanBriefEntities(entitiesRaw)
+	b.Sections = scanBriefSections(sectionsRaw)
+	b.Params = scanBriefParams(paramsRaw)
 	return &b, nil
 }
 
 // GetByDate returns the brief for a specific date.
 func (s *BriefStore) GetByDate(ctx context.Context, date time.Time) (*Brief, error) {
 	var b Brief
-	var tagsRaw []byte
+	var tagsRaw, quotesRaw, entitiesRaw, sectionsRaw, paramsRaw []byte
 	err := s.pool.QueryRow(ctx, `
-		SELECT id, date, summary, top_tags, article_count, created_at
+		SELECT id, date, summary, top_tags, article_count, quotes, top_entities, sections, params, created_at
 		FROM briefs
 		WHERE date = $1
-	`, date).Scan(&b.ID, &b.Date, &b.Summary, &tagsRaw, &b.ArticleCount, &b.CreatedAt)
+	`, date).Scan(&b.ID, &b.Date, &b.Summary, &tagsRaw, &b.ArticleCount, &quotesRaw, &entitiesRaw, &sectionsRaw, &paramsRaw, &b.CreatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("brief get by date: %w", err)
 	}
 	b.TopTags = scanBriefTags(tagsRaw)
+	b.Quotes = scanBriefQuotes(quotesRaw)
+	b.TopEntities = scanBriefEntities(entitiesRaw)
+	b.Sections = scanBriefSections(sectionsRaw)
+	b.Params = scanBriefParams(paramsRaw)
 	return &b, nil
 }
 
@@ -73,17 +134,50 @@ func (s *BriefStore) Create(ctx context.Context, brief *Brief) error {
 	if err != nil {
 		return fmt.Errorf("brief create: marshal tags: %w", err)
 	}
+	if brief.Quotes == nil {
+		brief.Quotes = []BriefQuote{}
+	}
+	quotesJSON, err := json.Marshal(brief.Quotes)
+	if err != nil {
+		return fmt.Errorf("brief create: marshal quotes: %w", err)
+	}
+	if brief.TopEntities == nil {
+		brief.TopEntities = []EntityCount{}
+	}
+	entitiesJSON, err := json.Marshal(brief.TopEntities)
+	if err != nil {
+		return fmt.Errorf("brief create: marshal entities: %w", err)
+	}
+
+	if brief.Sections == nil {
+		brief.Sections = []BriefSection{}
+	}
+	sectionsJSON, err := json.Marshal(brief.Sections)
+	if err != nil {
+		return fmt.Errorf("brief create: marshal sections: %w", err)
+	}
+	var paramsJSON []byte
+	if brief.Params != nil {
+		paramsJSON, err = json.Marshal(brief.Params)
+		if err != nil {
+			return fmt.Errorf("brief create: marshal params: %w", err)
+		}
+	}
 
 	err = s.pool.QueryRow(ctx, `
-		INSERT INTO briefs (id, date, summary, top_tags, article_count)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO briefs (id, date, summary, top_tags, article_count, quotes, top_entities, sections, params)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		ON CONFLICT (date) DO UPDATE SET
 			summary = EXCLUDED.summary,
 			top_tags = EXCLUDED.top_tags,
 			article_count = EXCLUDED.article_count,
+			quotes = EXCLUDED.quotes,
+			top_entities = EXCLUDED.top_entities,
+			sections = EXCLUDED.sections,
+			params = EXCLUDED.params,
 			created_at = now()
-		RETURNING created_at
-	`, brief.ID, brief.Date, brief.Summary, tagsJSON, brief.ArticleCount).Scan(&brief.CreatedAt)
+		RETURNING id, created_at
+	`, brief.ID, brief.Date, brief.Summary, tagsJSON, brief.ArticleCount, quotesJSON, entitiesJSON, sectionsJSON, paramsJSON).Scan(&brief.ID, &brief.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("brief create: %w", err)
 	}
@@ -97,7 +191,7 @@ func (s *BriefStore) List(ctx context.Context, limit int) ([]Brief, error) {
 	}
 
 	rows, err := s.pool.Query(ctx, `
-		SELECT id, date, summary, top_tags, article_count, created_at
+		SELECT id, date, summary, top_tags, article_count, quotes, top_entities, sections, params, created_at
 		FROM briefs
 		ORDER BY date DESC
 		LIMIT $1
@@ -110,11 +204,15 @@ func (s *BriefStore) List(ctx context.Context, limit int) ([]Brief, error) {
 	var briefs []Brief
 	for rows.Next() {
 		var b Brief
-		var tagsRaw []byte
-		if err := rows.Scan(&b.ID, &b.Date, &b.Summary, &tagsRaw, &b.ArticleCount, &b.CreatedAt); err != nil {
+		var tagsRaw, quotesRaw, entitiesRaw, sectionsRaw, paramsRaw []byte
+		if err := rows.Scan(&b.ID, &b.Date, &b.Summary, &tagsRaw, &b.ArticleCount, &quotesRaw, &entitiesRaw, &sectionsRaw, &paramsRaw, &b.CreatedAt); err != nil {
 			return nil, fmt.Errorf("brief scan: %w", err)
 		}
 		b.TopTags = scanBriefTags(tagsRaw)
+		b.Quotes = scanBriefQuotes(quotesRaw)
+		b.TopEntities = scanBriefEntities(entitiesRaw)
+		b.Sections = scanBriefSections(sectionsRaw)
+		b.Params = scanBriefParams(paramsRaw)
 		briefs = append(briefs, b)
 	}
 
@@ -132,3 +230,80 @@ func scanBriefTags(raw []byte) []string {
 	}
 	return tags
 }
+
+// scanBriefQuotes unmarshals a JSONB quotes column, never returning nil.
+func scanBriefQuotes(raw []byte) []BriefQuote {
+	quotes := []BriefQuote{}
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &quotes)
+	}
+	return quotes
+}
+
+// scanBriefEntities unmarshals a JSONB top_entities column, never returning
+// nil.
+func scanBriefEntities(raw []byte) []EntityCount {
+	entities := []EntityCount{}
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &entities)
+	}
+	return entities
+}
+
+// scanBriefSections unmarshals a JSONB sections column, never returning nil.
+func scanBriefSections(raw []byte) []BriefSection {
+	sections := []BriefSection{}
+	if len(raw) > 0 {
+		_ = json.Unmarshal(raw, &sections)
+	}
+	return sections
+}
+
+// scanBriefParams unmarshals a JSONB params column; nil when unset.
+func scanBriefParams(raw []byte) *BriefParams {
+	if len(raw) == 0 {
+		return nil
+	}
+	var p BriefParams
+	if err := json.Unmarshal(raw, &p); err != nil {
+		return nil
+	}
+	return &p
+}
+
+// ListForBrief returns the articles created in [p.From, p.To) carrying any
+// of p.Tags and in p.Region (each when set), newest first.
+func (s *ArticleStore) ListForBrief(ctx context.Context, p BriefParams) ([]Article, error) {
+	query := `
+		SELECT id, title, source, url, canonical_url, region, published_at,
+		       clean_text, summary, image_url, status, pinned, evidence_policy,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
+		FROM articles
+		WHERE created_at >= $1 AND created_at < $2`
+	args := []any{p.From, p.To}
+	if len(p.Tags) > 0 {
+		args = append(args, p.Tags)
+		query += fmt.Sprintf(" AND tags ?| $%d::text[]", len(args))
+	}
+	if p.Region != "" {
+		args = append(args, p.Region)
+		query += fmt.Sprintf(" AND region = $%d", len(args))
+	}
+	query += " ORDER BY created_at DESC"
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("article list for brief: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	for rows.Next() {
+		a := scanArticleFromRow(rows)
+		if a == nil {
+			return nil, fmt.Errorf("article list for brief scan: failed")
+		}
+		articles = append(articles, *a)
+	}
+	return articles, rows.Err()
+}
@@ -3,13 +3,19 @@ package models
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// ErrChatSessionNotFound is returned when a chat session does not exist or
+// belongs to another user.
+var ErrChatSessionNotFound = errors.New("chat session not found")
+
 type ChatSession struct {
 	ID        uuid.UUID       `json:"id"`
 	UserID    uuid.UUID       `json:"user_id"`
@@ -19,6 +25,15 @@ type ChatSession struct {
 	UpdatedAt time.Time       `json:"updated_at"`
 }
 
+// ChatMessage is one message of a chat session, in the shape the frontend
+// stores them. Source lists are kept as-is.
+type ChatMessage struct {
+	Role       string          `json:"role"` // "user" or "assistant"
+	Content    string          `json:"content"`
+	Sources    json.RawMessage `json:"sources,omitempty"`
+	WebSources json.RawMessage `json:"webSources,omitempty"`
+}
+
 type ChatSessionStore struct {
 	pool *pgxpool.Pool
 }
@@ -97,6 +112,34 @@ func (s *ChatSessionStore) Update(ctx context.Context, id uuid.UUID, title strin
 	return nil
 }
 
+// AppendMessages appends msgs to one of the user's sessions in a single
+// statement, so concurrent exchanges are never lost, and sets the title if
+// the session has none yet. It returns the updated session, or
+// ErrChatSessionNotFound.
+func (s *ChatSessionStore) AppendMessages(ctx context.Context, id, userID uuid.UUID, title string, msgs []ChatMessage) (*ChatSession, error) {
+	data, err := json.Marshal(msgs)
+	if err != nil {
+		return nil, fmt.Errorf("chat session append: marshal: %w", err)
+	}
+
+	var cs ChatSession
+	err = s.pool.QueryRow(ctx, `
+		UPDATE chat_sessions
+		SET messages = messages || $3::jsonb,
+		    title = CASE WHEN title = '' THEN $4 ELSE title END,
+		    updated_at = NOW()
+		WHERE id = $1 AND user_id = $2
+		RETURNING id, user_id, title, messages, created_at, updated_at
+	`, id, userID, data, title).Scan(&cs.ID, &cs.UserID, &cs.Title, &cs.Messages, &cs.CreatedAt, &cs.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrChatSessionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("chat session append: %w", err)
+	}
+	return &cs, nil
+}
+
 func (s *ChatSessionStore) Delete(ctx context.Context, id uuid.UUID) error {
 	tag, err := s.pool.Exec(ctx, `DELETE FROM chat_sessions WHERE id = $1`, id)
 	if err != nil {
@@ -107,3 +150,26 @@ func (s *ChatSessionStore) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 	return nil
 }
+
+// DeleteAllByUser deletes all of a user's chat sessions and returns how many
+// were deleted.
+func (s *ChatSessionStore) DeleteAllByUser(ctx context.Context, userID uuid.UUID) (int, error) {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM chat_sessions WHERE user_id = $1`, userID)
+	if err != nil {
+		return 0, fmt.Errorf("chat sessions delete by user: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// DeleteInactive deletes chat sessions not updated in the last olderThanDays
+// days and returns how many were deleted.
+func (s *ChatSessionStore) DeleteInactive(ctx context.Context, olderThanDays int) (int, error) {
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM chat_sessions
+		WHERE updated_at < NOW() - make_interval(days => $1)
+	`, olderThanDays)
+	if err != nil {
+		return 0, fmt.Errorf("chat sessions delete inactive: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
@@ -0,0 +1,70 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrInvalidCursor is returned when a pagination cursor cannot be decoded or
+// does not belong to the listing it was passed to.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ArticleCursor marks the last article of a page for keyset pagination. It
+// carries the sort key of that article so the next page starts strictly after
+// it, without the cost of a deep OFFSET and without skipping or repeating rows
+// when articles arrive between requests. Clients treat the encoded form as an
+// opaque token.
+type ArticleCursor struct {
+	Rank        *float64   `json:"r,omitempty"` // ts_rank, full-text searches only
+	Pinned      bool       `json:"p,omitempty"` // status listings only
+	PublishedAt *time.Time `json:"t,omitempty"`
+	CreatedAt   time.Time  `json:"c"`
+	ID          uuid.UUID  `json:"i"`
+}
+
+// cursorAfter builds the cursor for the last article of a page.
+func cursorAfter(a Article, rank *float64) *ArticleCursor {
+	return &ArticleCursor{
+		Rank:        rank,
+		Pinned:      a.Pinned,
+		PublishedAt: a.PublishedAt,
+		CreatedAt:   a.CreatedAt,
+		ID:          a.ID,
+	}
+}
+
+// Encode returns the cursor as a URL-safe token.
+func (c *ArticleCursor) Encode() string {
+	if c == nil {
+		return ""
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeArticleCursor parses a token produced by Encode. An empty token
+// yields a nil cursor (the first page).
+func DecodeArticleCursor(token string) (*ArticleCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var c ArticleCursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == uuid.Nil {
+		return nil, ErrInvalidCursor
+	}
+	return &c, nil
+}
+
+// ArticlePage is one page of a keyset-paginated article listing.
+type ArticlePage struct {
+	Articles []Article
+	Next     *ArticleCursor // nil on the last page
+}
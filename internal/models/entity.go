@@ -168,3 +168,103 @@ func (s *EntityStore) EntitiesForArticle(ctx context.Context, articleID uuid.UUI
 	}
 	return entities, rows.Err()
 }
+
+// GetByName returns the entities whose canonical name matches name, one per
+// type (a name can be both a person and an organization). entityType, when
+// set, narrows the match to that type.
+func (s *EntityStore) GetByName(ctx context.Context, name, entityType string) ([]Entity, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, name, type, canonical, created_at
+		FROM entities
+		WHERE canonical = $1 AND ($2 = '' OR type = $2)
+		ORDER BY type
+	`, strings.ToLower(strings.TrimSpace(name)), entityType)
+	if err != nil {
+		return nil, fmt.Errorf("entity get by name: %w", err)
+	}
+	defer rows.Close()
+
+	var entities []Entity
+	for rows.Next() {
+		var e Entity
+		if err := rows.Scan(&e.ID, &e.Name, &e.Type, &e.Canonical, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("entity get by name scan: %w", err)
+		}
+		entities = append(entities, e)
+	}
+	return entities, rows.Err()
+}
+
+// ArticlesForEntity returns the articles linked to any entity with the given
+// canonical name (and type, when set), newest first.
+func (s *EntityStore) ArticlesForEntity(ctx context.Context, name, entityType string, limit, offset int) ([]Article, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT a.id, a.title, a.source, a.url, a.canonical_url, a.region, a.published_at,
+		       a.clean_text, a.summary, a.image_url, a.status, a.pinned, a.evidence_policy,
+		       a.evidence_expires_at, a.tags, a.scope, a.language, a.archive_url, a.tag_source, a.created_at
+		FROM articles a
+		WHERE a.status != 'trashed'
+		  AND EXISTS (
+		      SELECT 1
+		      FROM article_entities ae
+		      JOIN entities e ON e.id = ae.entity_id
+		      WHERE ae.article_id = a.id
+		        AND e.canonical = $1 AND ($2 = '' OR e.type = $2)
+		  )
+		ORDER BY a.published_at DESC NULLS LAST, a.created_at DESC, a.id DESC
+		LIMIT $3 OFFSET $4
+	`, strings.ToLower(strings.TrimSpace(name)), entityType, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("entity articles: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	for rows.Next() {
+		a := scanArticleFromRow(rows)
+		if a == nil {
+			return nil, fmt.Errorf("entity articles scan: failed")
+		}
+		articles = append(articles, *a)
+	}
+	return articles, rows.Err()
+}
+
+// TopEntitiesForArticles returns the entities mentioned by the most of the
+// given articles, across all types.
+func (s *EntityStore) TopEntitiesForArticles(ctx context.Context, ids []uuid.UUID, limit int) ([]EntityCount, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = 10
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT e.name, e.type, COUNT(*) AS cnt
+		FROM article_entities ae
+		JOIN entities e ON e.id = ae.entity_id
+		WHERE ae.article_id = ANY($1)
+		GROUP BY e.id, e.name, e.type
+		ORDER BY cnt DESC, e.name
+		LIMIT $2
+	`, ids, limit)
+	if err != nil {
+		return nil, fmt.Errorf("entity top for articles: %w", err)
+	}
+	defer rows.Close()
+
+	var results []EntityCount
+	for rows.Next() {
+		var ec EntityCount
+		if err := rows.Scan(&ec.Name, &ec.Type, &ec.Count); err != nil {
+			return nil, fmt.Errorf("entity top for articles scan: %w", err)
+		}
+		results = append(results, ec)
+	}
+	return results, rows.Err()
+}
@@ -0,0 +1,175 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrFlagExists is returned by FeatureFlagStore.Create when the name is taken.
+var ErrFlagExists = errors.New("feature flag already exists")
+
+// FeatureFlag gates a feature that is being rolled out. See the flags
+// package for how a flag is evaluated for a user.
+type FeatureFlag struct {
+	Name           string    `json:"name"`
+	Description    string    `json:"description"`
+	Enabled        bool      `json:"enabled"`
+	RolloutPercent int       `json:"rollout_percent"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+
+	// Overrides force the flag on or off for individual users.
+	Overrides map[uuid.UUID]bool `json:"overrides"`
+}
+
+// FeatureFlagStore provides data access methods for feature flags.
+type FeatureFlagStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewFeatureFlagStore creates a new FeatureFlagStore.
+func NewFeatureFlagStore(pool *pgxpool.Pool) *FeatureFlagStore {
+	return &FeatureFlagStore{pool: pool}
+}
+
+// List returns all flags with their overrides, ordered by name.
+func (s *FeatureFlagStore) List(ctx context.Context) ([]FeatureFlag, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT name, description, enabled, rollout_percent, created_at, updated_at
+		FROM feature_flags
+		ORDER BY name
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("feature flag list: %w", err)
+	}
+	defer rows.Close()
+
+	var flags []FeatureFlag
+	index := make(map[string]int)
+	for rows.Next() {
+		f := FeatureFlag{Overrides: map[uuid.UUID]bool{}}
+		if err := rows.Scan(&f.Name, &f.Description, &f.Enabled, &f.RolloutPercent, &f.CreatedAt, &f.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("feature flag scan: %w", err)
+		}
+		index[f.Name] = len(flags)
+		flags = append(flags, f)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	orows, err := s.pool.Query(ctx, `SELECT flag_name, user_id, enabled FROM feature_flag_overrides`)
+	if err != nil {
+		return nil, fmt.Errorf("feature flag overrides: %w", err)
+	}
+	defer orows.Close()
+	for orows.Next() {
+		var name string
+		var userID uuid.UUID
+		var enabled bool
+		if err := orows.Scan(&name, &userID, &enabled); err != nil {
+			return nil, fmt.Errorf("feature flag override scan: %w", err)
+		}
+		if i, ok := index[name]; ok {
+			flags[i].Overrides[userID] = enabled
+		}
+	}
+	return flags, orows.Err()
+}
+
+// FeatureFlags returns every flag keyed by name. It implements
+// flags.Source.
+func (s *FeatureFlagStore) FeatureFlags(ctx context.Context) (map[string]FeatureFlag, error) {
+	list, err := s.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]FeatureFlag, len(list))
+	for _, f := range list {
+		m[f.Name] = f
+	}
+	return m, nil
+}
+
+// Create inserts a flag. It returns ErrFlagExists if the name is taken.
+func (s *FeatureFlagStore) Create(ctx context.Context, f *FeatureFlag) error {
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO feature_flags (name, description, enabled, rollout_percent)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (name) DO NOTHING
+		RETURNING created_at, updated_at
+	`, f.Name, f.Description, f.Enabled, f.RolloutPercent).Scan(&f.CreatedAt, &f.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrFlagExists
+	}
+	if err != nil {
+		return fmt.Errorf("feature flag create: %w", err)
+	}
+	if f.Overrides == nil {
+		f.Overrides = map[uuid.UUID]bool{}
+	}
+	return nil
+}
+
+// Update changes a flag's description, enabled state and rollout.
+func (s *FeatureFlagStore) Update(ctx context.Context, f *FeatureFlag) error {
+	err := s.pool.QueryRow(ctx, `
+		UPDATE feature_flags
+		SET description = $2, enabled = $3, rollout_percent = $4, updated_at = NOW()
+		WHERE name = $1
+		RETURNING created_at, updated_at
+	`, f.Name, f.Description, f.Enabled, f.RolloutPercent).Scan(&f.CreatedAt, &f.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("feature flag not found: %s", f.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("feature flag update: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a flag and its overrides. Code checking the flag then sees
+// it as off.
+func (s *FeatureFlagStore) Delete(ctx context.Context, name string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM feature_flags WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("feature flag delete: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("feature flag not found: %s", name)
+	}
+	return nil
+}
+
+// SetOverride forces a flag on or off for one user.
+func (s *FeatureFlagStore) SetOverride(ctx context.Context, name string, userID uuid.UUID, enabled bool) error {
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO feature_flag_overrides (flag_name, user_id, enabled)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (flag_name, user_id) DO UPDATE SET enabled = EXCLUDED.enabled
+	`, name, userID, enabled)
+	if err != nil {
+		return fmt.Errorf("feature flag set override: %w", err)
+	}
+	return nil
+}
+
+// DeleteOverride returns a user to the flag's rollout.
+func (s *FeatureFlagStore) DeleteOverride(ctx context.Context, name string, userID uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM feature_flag_overrides WHERE flag_name = $1 AND user_id = $2
+	`, name, userID)
+	if err != nil {
+		return fmt.Errorf("feature flag delete override: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("feature flag override not found: %s/%s", name, userID)
+	}
+	return nil
+}
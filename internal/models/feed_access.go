@@ -0,0 +1,211 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Feed access kinds.
+const (
+	FeedAccessFeed  = "feed"
+	FeedAccessShare = "share"
+)
+
+// FeedTokenPrefixLen is how much of a feed token the access log keeps.
+const FeedTokenPrefixLen = 8
+
+// FeedAccess is one fetch of a public feed or share page.
+type FeedAccess struct {
+	ID          int64      `json:"id"`
+	Kind        string     `json:"kind"`
+	UserID      *uuid.UUID `json:"user_id,omitempty"`
+	TokenPrefix string     `json:"token_prefix,omitempty"`
+	ArticleID   *uuid.UUID `json:"article_id,omitempty"`
+	IP          string     `json:"ip"`
+	UserAgent   string     `json:"user_agent"`
+	Referer     string     `json:"referer,omitempty"`
+	Status      int        `json:"status"`
+	AccessedAt  time.Time  `json:"accessed_at"`
+}
+
+// FeedAccessSummary counts the distinct clients of a user's feed.
+type FeedAccessSummary struct {
+	Fetches24h     int        `json:"fetches_24h"`
+	DistinctIPs24h int        `json:"distinct_ips_24h"`
+	DistinctIPs7d  int        `json:"distinct_ips_7d"`
+	LastAccessAt   *time.Time `json:"last_access_at,omitempty"`
+}
+
+// FeedLeak is a feed token whose recent fetches look like it was shared
+// publicly, or an alert already raised for one.
+type FeedLeak struct {
+	ID              uuid.UUID `json:"id"`
+	UserID          uuid.UUID `json:"user_id"`
+	TokenPrefix     string    `json:"token_prefix"`
+	DistinctIPs     int       `json:"distinct_ips"`
+	DistinctAgents  int       `json:"distinct_agents"`
+	OutsideReferers int       `json:"outside_referers"`
+	Rotated         bool      `json:"rotated"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// FeedAccessStore records and analyzes public feed and share page fetches.
+type FeedAccessStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewFeedAccessStore creates a new FeedAccessStore.
+func NewFeedAccessStore(pool *pgxpool.Pool) *FeedAccessStore {
+	return &FeedAccessStore{pool: pool}
+}
+
+// Record logs one access. The token is cut to FeedTokenPrefixLen.
+func (s *FeedAccessStore) Record(ctx context.Context, a *FeedAccess) error {
+	if len(a.TokenPrefix) > FeedTokenPrefixLen {
+		a.TokenPrefix = a.TokenPrefix[:FeedTokenPrefixLen]
+	}
+	_, err := s.pool.Exec(ctx, `
+		INSERT INTO feed_access_log (kind, user_id, token_prefix, article_id, ip, user_agent, referer, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+	`, a.Kind, a.UserID, a.TokenPrefix, a.ArticleID, a.IP, a.UserAgent, a.Referer, a.Status)
+	if err != nil {
+		return fmt.Errorf("feed access record: %w", err)
+	}
+	return nil
+}
+
+// ListByUser returns the most recent fetches of the user's feed, newest
+// first.
+func (s *FeedAccessStore) ListByUser(ctx context.Context, userID uuid.UUID, limit int) ([]FeedAccess, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, kind, user_id, token_prefix, article_id, ip, user_agent, referer, status, accessed_at
+		FROM feed_access_log
+		WHERE user_id = $1
+		ORDER BY accessed_at DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("feed access list: %w", err)
+	}
+	defer rows.Close()
+
+	var accesses []FeedAccess
+	for rows.Next() {
+		var a FeedAccess
+		if err := rows.Scan(&a.ID, &a.Kind, &a.UserID, &a.TokenPrefix, &a.ArticleID,
+			&a.IP, &a.UserAgent, &a.Referer, &a.Status, &a.AccessedAt); err != nil {
+			return nil, fmt.Errorf("feed access scan: %w", err)
+		}
+		accesses = append(accesses, a)
+	}
+	return accesses, rows.Err()
+}
+
+// SummaryByUser counts the fetches and distinct addresses of the user's feed
+// over the last day and week.
+func (s *FeedAccessStore) SummaryByUser(ctx context.Context, userID uuid.UUID) (*FeedAccessSummary, error) {
+	var sum FeedAccessSummary
+	err := s.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE accessed_at >= NOW() - INTERVAL '24 hours'),
+		       COUNT(DISTINCT ip) FILTER (WHERE accessed_at >= NOW() - INTERVAL '24 hours'),
+		       COUNT(DISTINCT ip),
+		       MAX(accessed_at)
+		FROM feed_access_log
+		WHERE user_id = $1 AND accessed_at >= NOW() - INTERVAL '7 days'
+	`, userID).Scan(&sum.Fetches24h, &sum.DistinctIPs24h, &sum.DistinctIPs7d, &sum.LastAccessAt)
+	if err != nil {
+		return nil, fmt.Errorf("feed access summary: %w", err)
+	}
+	return &sum, nil
+}
+
+// FlagLeaks finds the current feed tokens fetched since `since` from at
+// least minIPs distinct addresses, or following a link on another site, and
+// not flagged yet. It records an alert for each and returns them.
+func (s *FeedAccessStore) FlagLeaks(ctx context.Context, since time.Time, minIPs int) ([]FeedLeak, error) {
+	rows, err := s.pool.Query(ctx, `
+		WITH usage AS (
+			SELECT l.user_id, l.token_prefix,
+			       COUNT(DISTINCT l.ip) AS ips,
+			       COUNT(DISTINCT l.user_agent) AS agents,
+			       COUNT(*) FILTER (WHERE l.referer != '') AS referers
+			FROM feed_access_log l
+			JOIN users u ON u.id = l.user_id
+			WHERE l.kind = 'feed' AND l.accessed_at >= $1
+			  AND u.feed_token LIKE l.token_prefix || '%'
+			GROUP BY l.user_id, l.token_prefix
+		)
+		INSERT INTO feed_leak_alerts (user_id, token_prefix, distinct_ips, distinct_agents, outside_referers)
+		SELECT user_id, token_prefix, ips, agents, referers
+		FROM usage
+		WHERE ips >= $2 OR referers > 0
+		ON CONFLICT (user_id, token_prefix) DO NOTHING
+		RETURNING id, user_id, token_prefix, distinct_ips, distinct_agents, outside_referers, rotated, created_at
+	`, since, minIPs)
+	if err != nil {
+		return nil, fmt.Errorf("feed access flag leaks: %w", err)
+	}
+	defer rows.Close()
+	return scanFeedLeaks(rows)
+}
+
+// MarkRotated records that the token behind an alert was replaced.
+func (s *FeedAccessStore) MarkRotated(ctx context.Context, alertID uuid.UUID) error {
+	_, err := s.pool.Exec(ctx, `UPDATE feed_leak_alerts SET rotated = true WHERE id = $1`, alertID)
+	if err != nil {
+		return fmt.Errorf("feed leak mark rotated: %w", err)
+	}
+	return nil
+}
+
+// ListAlerts returns the user's leak alerts, newest first.
+func (s *FeedAccessStore) ListAlerts(ctx context.Context, userID uuid.UUID, limit int) ([]FeedLeak, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, user_id, token_prefix, distinct_ips, distinct_agents, outside_referers, rotated, created_at
+		FROM feed_leak_alerts
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, userID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("feed leak alerts list: %w", err)
+	}
+	defer rows.Close()
+	return scanFeedLeaks(rows)
+}
+
+// Prune deletes log entries older than the given number of days and returns
+// how many were deleted.
+func (s *FeedAccessStore) Prune(ctx context.Context, olderThanDays int) (int, error) {
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM feed_access_log WHERE accessed_at < NOW() - make_interval(days => $1)
+	`, olderThanDays)
+	if err != nil {
+		return 0, fmt.Errorf("feed access prune: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+func scanFeedLeaks(rows interface {
+	Next() bool
+	Scan(dest ...any) error
+	Err() error
+}) ([]FeedLeak, error) {
+	var leaks []FeedLeak
+	for rows.Next() {
+		var l FeedLeak
+		if err := rows.Scan(&l.ID, &l.UserID, &l.TokenPrefix, &l.DistinctIPs, &l.DistinctAgents,
+			&l.OutsideReferers, &l.Rotated, &l.CreatedAt); err != nil {
+			return nil, fmt.Errorf("feed leak scan: %w", err)
+		}
+		leaks = append(leaks, l)
+	}
+	return leaks, rows.Err()
+}
@@ -0,0 +1,365 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// GrantOpportunity is a funding opportunity listed on Grants.gov.
+type GrantOpportunity struct {
+	ID                uuid.UUID  `json:"id"`
+	SourceID          *uuid.UUID `json:"source_id,omitempty"`
+	OpportunityID     string     `json:"opportunity_id"`
+	OpportunityNumber string     `json:"opportunity_number"`
+	Title             string     `json:"title"`
+	Agency            string     `json:"agency"`
+	AgencyCode        string     `json:"agency_code"`
+	CFDANumbers       []string   `json:"cfda_numbers"`
+	OppStatus         string     `json:"opp_status"` // forecasted, posted, closed, archived
+	DocType           string     `json:"doc_type"`
+	OpenDate          *time.Time `json:"open_date,omitempty"`
+	CloseDate         *time.Time `json:"close_date,omitempty"`
+	URL               string     `json:"url"`
+	FirstSeenAt       time.Time  `json:"first_seen_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+
+	// From the opportunity detail; nil or empty until it has been fetched.
+	AwardCeiling     *int64     `json:"award_ceiling,omitempty"`
+	AwardFloor       *int64     `json:"award_floor,omitempty"`
+	EstimatedFunding *int64     `json:"estimated_funding,omitempty"`
+	Eligibility      string     `json:"eligibility"`
+	ApplicantTypes   []string   `json:"applicant_types"`
+	DetailsFetchedAt *time.Time `json:"details_fetched_at,omitempty"`
+
+	Tracking          *string    `json:"tracking"` // pursuing, declined, or nil when undecided
+	TrackingUpdatedAt *time.Time `json:"tracking_updated_at,omitempty"`
+	ClosingFlaggedAt  *time.Time `json:"closing_flagged_at,omitempty"`
+}
+
+// GrantDetails are the fields of a Grants.gov opportunity detail that the
+// search results do not include.
+type GrantDetails struct {
+	AwardCeiling     *int64
+	AwardFloor       *int64
+	EstimatedFunding *int64
+	Eligibility      string
+	ApplicantTypes   []string
+}
+
+// Grant tracking values.
+const (
+	GrantPursuing = "pursuing"
+	GrantDeclined = "declined"
+)
+
+// FederalRegisterDocument is a rule, notice or presidential document
+// published in the Federal Register.
+type FederalRegisterDocument struct {
+	DocumentNumber  string     `json:"document_number"`
+	SourceID        *uuid.UUID `json:"source_id,omitempty"`
+	Title           string     `json:"title"`
+	DocType         string     `json:"doc_type"`
+	Abstract        string     `json:"abstract"`
+	Agencies        []string   `json:"agencies"`
+	PublicationDate *time.Time `json:"publication_date,omitempty"`
+	CommentsCloseOn *time.Time `json:"comments_close_on,omitempty"`
+	EffectiveOn     *time.Time `json:"effective_on,omitempty"`
+	URL             string     `json:"url"`
+	FirstSeenAt     time.Time  `json:"first_seen_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
+
+// GrantFilters narrows grant opportunity and Federal Register listings.
+// Zero values are ignored.
+type GrantFilters struct {
+	Query        string    // title or number contains, case-insensitive
+	Agency       string    // agency name or code contains
+	CFDA         string    // exact Assistance Listing number (opportunities only)
+	DocType      string    // exact document type (Federal Register only)
+	ClosingAfter time.Time // close date on or after (opportunities only)
+	ClosingBy    time.Time // close date on or before (opportunities only)
+	Tracking     string    // pursuing, declined, or "none" for undecided (opportunities only)
+	SkipDeclined bool      // leave out declined opportunities
+	Eligible     string    // eligible entity contains (postings only)
+	MinAward     int64     // award ceiling at least, in dollars (opportunities and postings)
+	Sort         string    // GrantSortAmount for largest award first; otherwise soonest closing first
+	Limit        int
+	Offset       int
+}
+
+// GrantSortAmount sorts grant listings by award ceiling, largest first.
+const GrantSortAmount = "amount"
+
+// GrantStore provides data access methods for Grants.gov opportunities and
+// Federal Register documents.
+type GrantStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewGrantStore creates a new GrantStore.
+func NewGrantStore(pool *pgxpool.Pool) *GrantStore {
+	return &GrantStore{pool: pool}
+}
+
+const grantOpportunityColumns = `id, source_id, opportunity_id, opportunity_number, title, agency,
+		       agency_code, cfda_numbers, opp_status, doc_type, open_date, close_date,
+		       url, first_seen_at, updated_at, award_ceiling, award_floor, estimated_funding,
+		       eligibility, applicant_types, details_fetched_at, tracking, tracking_updated_at,
+		       closing_flagged_at`
+
+func scanGrantOpportunity(row scannable, g *GrantOpportunity) error {
+	return row.Scan(
+		&g.ID, &g.SourceID, &g.OpportunityID, &g.OpportunityNumber, &g.Title, &g.Agency,
+		&g.AgencyCode, &g.CFDANumbers, &g.OppStatus, &g.DocType, &g.OpenDate, &g.CloseDate,
+		&g.URL, &g.FirstSeenAt, &g.UpdatedAt, &g.AwardCeiling, &g.AwardFloor, &g.EstimatedFunding,
+		&g.Eligibility, &g.ApplicantTypes, &g.DetailsFetchedAt, &g.Tracking, &g.TrackingUpdatedAt,
+		&g.ClosingFlaggedAt,
+	)
+}
+
+// UpsertOpportunity inserts an opportunity or refreshes the stored one with
+// the same Grants.gov id. Reports whether it was new. A changed close date
+// re-arms the closing-soon flag. g.DetailsFetchedAt is set from the stored
+// row.
+func (s *GrantStore) UpsertOpportunity(ctx context.Context, g *GrantOpportunity) (bool, error) {
+	if g.CFDANumbers == nil {
+		g.CFDANumbers = []string{}
+	}
+	var inserted bool
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO grant_opportunities (source_id, opportunity_id, opportunity_number, title,
+		    agency, agency_code, cfda_numbers, opp_status, doc_type, open_date, close_date, url)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (opportunity_id) DO UPDATE
+		SET opportunity_number = EXCLUDED.opportunity_number, title = EXCLUDED.title,
+		    agency = EXCLUDED.agency, agency_code = EXCLUDED.agency_code,
+		    cfda_numbers = EXCLUDED.cfda_numbers, opp_status = EXCLUDED.opp_status,
+		    doc_type = EXCLUDED.doc_type, open_date = EXCLUDED.open_date,
+		    close_date = EXCLUDED.close_date, url = EXCLUDED.url, updated_at = NOW(),
+		    closing_flagged_at = CASE
+		        WHEN grant_opportunities.close_date IS DISTINCT FROM EXCLUDED.close_date THEN NULL
+		        ELSE grant_opportunities.closing_flagged_at END
+		RETURNING id, first_seen_at, updated_at, details_fetched_at, (xmax = 0)
+	`, g.SourceID, g.OpportunityID, g.OpportunityNumber, g.Title, g.Agency, g.AgencyCode,
+		g.CFDANumbers, g.OppStatus, g.DocType, g.OpenDate, g.CloseDate, g.URL,
+	).Scan(&g.ID, &g.FirstSeenAt, &g.UpdatedAt, &g.DetailsFetchedAt, &inserted)
+	if err != nil {
+		return false, fmt.Errorf("grant opportunity upsert: %w", err)
+	}
+	return inserted, nil
+}
+
+// SetDetails stores the fetched detail of an opportunity.
+func (s *GrantStore) SetDetails(ctx context.Context, id uuid.UUID, d GrantDetails) error {
+	if d.ApplicantTypes == nil {
+		d.ApplicantTypes = []string{}
+	}
+	_, err := s.pool.Exec(ctx, `
+		UPDATE grant_opportunities
+		SET award_ceiling = $2, award_floor = $3, estimated_funding = $4,
+		    eligibility = $5, applicant_types = $6, details_fetched_at = NOW()
+		WHERE id = $1
+	`, id, d.AwardCeiling, d.AwardFloor, d.EstimatedFunding, d.Eligibility, d.ApplicantTypes)
+	if err != nil {
+		return fmt.Errorf("grant opportunity set details: %w", err)
+	}
+	return nil
+}
+
+// SetTracking marks an opportunity as pursuing or declined, or clears the
+// mark when tracking is nil. Returns the updated opportunity, or nil if it
+// does not exist.
+func (s *GrantStore) SetTracking(ctx context.Context, id uuid.UUID, tracking *string) (*GrantOpportunity, error) {
+	var g GrantOpportunity
+	err := scanGrantOpportunity(s.pool.QueryRow(ctx, `
+		UPDATE grant_opportunities
+		SET tracking = $2, tracking_updated_at = NOW()
+		WHERE id = $1
+		RETURNING `+grantOpportunityColumns, id, tracking), &g)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("grant opportunity set tracking: %w", err)
+	}
+	return &g, nil
+}
+
+// FlagClosingSoon marks the opportunities closing within the next days
+// (today included) that were not flagged yet, skipping declined ones, and
+// returns them soonest first.
+func (s *GrantStore) FlagClosingSoon(ctx context.Context, days int) ([]GrantOpportunity, error) {
+	rows, err := s.pool.Query(ctx, `
+		WITH flagged AS (
+			UPDATE grant_opportunities
+			SET closing_flagged_at = NOW()
+			WHERE close_date BETWEEN CURRENT_DATE AND CURRENT_DATE + $1::int
+			  AND closing_flagged_at IS NULL
+			  AND tracking IS DISTINCT FROM 'declined'
+			RETURNING `+grantOpportunityColumns+`
+		)
+		SELECT `+grantOpportunityColumns+` FROM flagged
+		ORDER BY close_date, title
+	`, days)
+	if err != nil {
+		return nil, fmt.Errorf("grant opportunity flag closing: %w", err)
+	}
+	defer rows.Close()
+
+	var grants []GrantOpportunity
+	for rows.Next() {
+		var g GrantOpportunity
+		if err := scanGrantOpportunity(rows, &g); err != nil {
+			return nil, fmt.Errorf("grant opportunity scan: %w", err)
+		}
+		grants = append(grants, g)
+	}
+	return grants, rows.Err()
+}
+
+// ListOpportunities returns opportunities matching the filters, soonest
+// closing first, or largest award ceiling first with Sort "amount"; those
+// without a close date or award ceiling come last.
+func (s *GrantStore) ListOpportunities(ctx context.Context, f GrantFilters) ([]GrantOpportunity, error) {
+	var conditions []string
+	var args []any
+	if f.Query != "" {
+		args = append(args, "%"+f.Query+"%")
+		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR opportunity_number ILIKE $%d)", len(args), len(args)))
+	}
+	if f.Agency != "" {
+		args = append(args, "%"+f.Agency+"%")
+		conditions = append(conditions, fmt.Sprintf("(agency ILIKE $%d OR agency_code ILIKE $%d)", len(args), len(args)))
+	}
+	if f.CFDA != "" {
+		args = append(args, f.CFDA)
+		conditions = append(conditions, fmt.Sprintf("$%d = ANY(cfda_numbers)", len(args)))
+	}
+	if !f.ClosingAfter.IsZero() {
+		args = append(args, f.ClosingAfter)
+		conditions = append(conditions, fmt.Sprintf("close_date >= $%d::date", len(args)))
+	}
+	if !f.ClosingBy.IsZero() {
+		args = append(args, f.ClosingBy)
+		conditions = append(conditions, fmt.Sprintf("close_date <= $%d::date", len(args)))
+	}
+	switch f.Tracking {
+	case "":
+	case "none":
+		conditions = append(conditions, "tracking IS NULL")
+	default:
+		args = append(args, f.Tracking)
+		conditions = append(conditions, fmt.Sprintf("tracking = $%d", len(args)))
+	}
+	if f.SkipDeclined {
+		conditions = append(conditions, "tracking IS DISTINCT FROM 'declined'")
+	}
+	if f.MinAward > 0 {
+		args = append(args, f.MinAward)
+		conditions = append(conditions, fmt.Sprintf("award_ceiling >= $%d", len(args)))
+	}
+
+	query := `SELECT ` + grantOpportunityColumns + ` FROM grant_opportunities`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	order := "close_date ASC NULLS LAST"
+	if f.Sort == GrantSortAmount {
+		order = "award_ceiling DESC NULLS LAST, close_date ASC NULLS LAST"
+	}
+	args = append(args, f.Limit, f.Offset)
+	query += fmt.Sprintf(" ORDER BY %s, first_seen_at DESC LIMIT $%d OFFSET $%d", order, len(args)-1, len(args))
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("grant opportunity list: %w", err)
+	}
+	defer rows.Close()
+
+	var grants []GrantOpportunity
+	for rows.Next() {
+		var g GrantOpportunity
+		if err := scanGrantOpportunity(rows, &g); err != nil {
+			return nil, fmt.Errorf("grant opportunity scan: %w", err)
+		}
+		grants = append(grants, g)
+	}
+	return grants, rows.Err()
+}
+
+// UpsertFederalDocument inserts a Federal Register document or refreshes the
+// stored one with the same document number. Reports whether it was new.
+func (s *GrantStore) UpsertFederalDocument(ctx context.Context, d *FederalRegisterDocument) (bool, error) {
+	if d.Agencies == nil {
+		d.Agencies = []string{}
+	}
+	var inserted bool
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO federal_register_documents (document_number, source_id, title, doc_type,
+		    abstract, agencies, publication_date, comments_close_on, effective_on, url)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (document_number) DO UPDATE
+		SET title = EXCLUDED.title, doc_type = EXCLUDED.doc_type, abstract = EXCLUDED.abstract,
+		    agencies = EXCLUDED.agencies, publication_date = EXCLUDED.publication_date,
+		    comments_close_on = EXCLUDED.comments_close_on, effective_on = EXCLUDED.effective_on,
+		    url = EXCLUDED.url, updated_at = NOW()
+		RETURNING first_seen_at, updated_at, (xmax = 0)
+	`, d.DocumentNumber, d.SourceID, d.Title, d.DocType, d.Abstract, d.Agencies,
+		d.PublicationDate, d.CommentsCloseOn, d.EffectiveOn, d.URL,
+	).Scan(&d.FirstSeenAt, &d.UpdatedAt, &inserted)
+	if err != nil {
+		return false, fmt.Errorf("federal register upsert: %w", err)
+	}
+	return inserted, nil
+}
+
+// ListFederalDocuments returns documents matching the filters, newest first.
+func (s *GrantStore) ListFederalDocuments(ctx context.Context, f GrantFilters) ([]FederalRegisterDocument, error) {
+	var conditions []string
+	var args []any
+	if f.Query != "" {
+		args = append(args, "%"+f.Query+"%")
+		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR abstract ILIKE $%d OR document_number = $%d)", len(args), len(args), len(args)))
+	}
+	if f.Agency != "" {
+		args = append(args, "%"+f.Agency+"%")
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM unnest(agencies) a WHERE a ILIKE $%d)", len(args)))
+	}
+	if f.DocType != "" {
+		args = append(args, f.DocType)
+		conditions = append(conditions, fmt.Sprintf("doc_type = $%d", len(args)))
+	}
+
+	query := `SELECT document_number, source_id, title, doc_type, abstract, agencies,
+		       publication_date, comments_close_on, effective_on, url, first_seen_at, updated_at
+		FROM federal_register_documents`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	args = append(args, f.Limit, f.Offset)
+	query += fmt.Sprintf(" ORDER BY publication_date DESC NULLS LAST, first_seen_at DESC LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("federal register list: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []FederalRegisterDocument
+	for rows.Next() {
+		var d FederalRegisterDocument
+		if err := rows.Scan(&d.DocumentNumber, &d.SourceID, &d.Title, &d.DocType, &d.Abstract, &d.Agencies,
+			&d.PublicationDate, &d.CommentsCloseOn, &d.EffectiveOn, &d.URL, &d.FirstSeenAt, &d.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("federal register scan: %w", err)
+		}
+		docs = append(docs, d)
+	}
+	return docs, rows.Err()
+}
@@ -0,0 +1,181 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// GrantPostingTag is the topic tag that marks grant-kind articles, whose
+// postings are extracted into grant_postings.
+const GrantPostingTag = "grants"
+
+// GrantPosting is the structured who/what/where/when extracted from a
+// grant-kind article. Fields the posting does not state are nil or empty.
+type GrantPosting struct {
+	ArticleID        uuid.UUID  `json:"article_id"`
+	Funder           string     `json:"funder"`
+	EligibleEntities []string   `json:"eligible_entities"`
+	AwardCeiling     *int64     `json:"award_ceiling,omitempty"` // whole US dollars per award
+	AwardFloor       *int64     `json:"award_floor,omitempty"`
+	MatchRequired    *bool      `json:"match_required"` // nil when the posting does not say
+	MatchRequirement string     `json:"match_requirement"`
+	Deadline         *time.Time `json:"deadline,omitempty"`
+	ExtractedAt      time.Time  `json:"extracted_at"`
+
+	// The article the posting was extracted from; set by ListPostings.
+	Title       string     `json:"title,omitempty"`
+	Source      string     `json:"source,omitempty"`
+	URL         string     `json:"url,omitempty"`
+	Region      string     `json:"region,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+const grantPostingColumns = `g.article_id, g.funder, g.eligible_entities, g.award_ceiling, g.award_floor,
+		       g.match_required, g.match_requirement, g.deadline, g.extracted_at`
+
+func scanGrantPosting(row scannable, p *GrantPosting, extra ...any) error {
+	return row.Scan(append([]any{
+		&p.ArticleID, &p.Funder, &p.EligibleEntities, &p.AwardCeiling, &p.AwardFloor,
+		&p.MatchRequired, &p.MatchRequirement, &p.Deadline, &p.ExtractedAt,
+	}, extra...)...)
+}
+
+// SetPosting stores the posting extracted from an article, replacing any
+// earlier extraction.
+func (s *GrantStore) SetPosting(ctx context.Context, p *GrantPosting) error {
+	if p.EligibleEntities == nil {
+		p.EligibleEntities = []string{}
+	}
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO grant_postings (article_id, funder, eligible_entities, award_ceiling,
+		    award_floor, match_required, match_requirement, deadline)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (article_id) DO UPDATE
+		SET funder = EXCLUDED.funder, eligible_entities = EXCLUDED.eligible_entities,
+		    award_ceiling = EXCLUDED.award_ceiling, award_floor = EXCLUDED.award_floor,
+		    match_required = EXCLUDED.match_required, match_requirement = EXCLUDED.match_requirement,
+		    deadline = EXCLUDED.deadline, extracted_at = NOW()
+		RETURNING extracted_at
+	`, p.ArticleID, p.Funder, p.EligibleEntities, p.AwardCeiling, p.AwardFloor,
+		p.MatchRequired, p.MatchRequirement, p.Deadline,
+	).Scan(&p.ExtractedAt)
+	if err != nil {
+		return fmt.Errorf("grant posting set: %w", err)
+	}
+	return nil
+}
+
+// GetPosting returns the posting extracted from an article, or nil if it
+// has not been extracted.
+func (s *GrantStore) GetPosting(ctx context.Context, articleID uuid.UUID) (*GrantPosting, error) {
+	var p GrantPosting
+	err := scanGrantPosting(s.pool.QueryRow(ctx, `
+		SELECT `+grantPostingColumns+` FROM grant_postings g WHERE g.article_id = $1
+	`, articleID), &p)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("grant posting get: %w", err)
+	}
+	return &p, nil
+}
+
+// ListUnextractedPostings returns grant-kind articles with text whose
+// posting has not been extracted yet, newest first. Trashed articles are
+// skipped.
+func (s *GrantStore) ListUnextractedPostings(ctx context.Context, limit int) ([]Article, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, title, source, url, canonical_url, region, published_at,
+		       clean_text, summary, image_url, status, pinned, evidence_policy,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
+		FROM articles a
+		WHERE tags ? $1 AND status <> 'trashed' AND clean_text <> ''
+		  AND NOT EXISTS (SELECT 1 FROM grant_postings g WHERE g.article_id = a.id)
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, GrantPostingTag, limit)
+	if err != nil {
+		return nil, fmt.Errorf("grant posting list unextracted: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	for rows.Next() {
+		a := scanArticleFromRow(rows)
+		if a == nil {
+			return nil, fmt.Errorf("grant posting unextracted scan: failed")
+		}
+		articles = append(articles, *a)
+	}
+	return articles, rows.Err()
+}
+
+// ListPostings returns extracted grant postings with their articles. Query
+// matches the title or funder, Agency the funder and Eligible an eligible
+// entity; ClosingAfter/ClosingBy bound the deadline and MinAward the award
+// ceiling. Postings are sorted soonest deadline first, or largest award
+// first with Sort "amount"; those without one come last. Trashed articles
+// are left out.
+func (s *GrantStore) ListPostings(ctx context.Context, f GrantFilters) ([]GrantPosting, error) {
+	conditions := []string{"a.status <> 'trashed'"}
+	var args []any
+	if f.Query != "" {
+		args = append(args, "%"+f.Query+"%")
+		conditions = append(conditions, fmt.Sprintf("(a.title ILIKE $%d OR g.funder ILIKE $%d)", len(args), len(args)))
+	}
+	if f.Agency != "" {
+		args = append(args, "%"+f.Agency+"%")
+		conditions = append(conditions, fmt.Sprintf("g.funder ILIKE $%d", len(args)))
+	}
+	if f.Eligible != "" {
+		args = append(args, "%"+f.Eligible+"%")
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM unnest(g.eligible_entities) e WHERE e ILIKE $%d)", len(args)))
+	}
+	if !f.ClosingAfter.IsZero() {
+		args = append(args, f.ClosingAfter)
+		conditions = append(conditions, fmt.Sprintf("g.deadline >= $%d::date", len(args)))
+	}
+	if !f.ClosingBy.IsZero() {
+		args = append(args, f.ClosingBy)
+		conditions = append(conditions, fmt.Sprintf("g.deadline <= $%d::date", len(args)))
+	}
+	if f.MinAward > 0 {
+		args = append(args, f.MinAward)
+		conditions = append(conditions, fmt.Sprintf("g.award_ceiling >= $%d", len(args)))
+	}
+
+	order := "g.deadline ASC NULLS LAST, g.award_ceiling DESC NULLS LAST"
+	if f.Sort == GrantSortAmount {
+		order = "g.award_ceiling DESC NULLS LAST, g.deadline ASC NULLS LAST"
+	}
+	args = append(args, f.Limit, f.Offset)
+	query := `SELECT ` + grantPostingColumns + `, a.title, a.source, a.url, a.region, a.status, a.published_at
+		FROM grant_postings g
+		JOIN articles a ON a.id = g.article_id
+		WHERE ` + strings.Join(conditions, " AND ") +
+		fmt.Sprintf(" ORDER BY %s, a.published_at DESC NULLS LAST LIMIT $%d OFFSET $%d", order, len(args)-1, len(args))
+
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("grant posting list: %w", err)
+	}
+	defer rows.Close()
+
+	var postings []GrantPosting
+	for rows.Next() {
+		var p GrantPosting
+		if err := scanGrantPosting(rows, &p, &p.Title, &p.Source, &p.URL, &p.Region, &p.Status, &p.PublishedAt); err != nil {
+			return nil, fmt.Errorf("grant posting scan: %w", err)
+		}
+		postings = append(postings, p)
+	}
+	return postings, rows.Err()
+}
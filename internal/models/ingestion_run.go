@@ -0,0 +1,149 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// IngestionRun records one ingestion run: what it processed, what it created,
+// and what went wrong.
+type IngestionRun struct {
+	ID               uuid.UUID             `json:"id"`
+	StartedAt        time.Time             `json:"started_at"`
+	FinishedAt       *time.Time            `json:"finished_at,omitempty"`
+	DurationMS       int64                 `json:"duration_ms"`
+	Status           string                `json:"status"`         // running, ok, skipped, failed
+	Note             string                `json:"note,omitempty"` // why a run was skipped or failed
+	SourcesProcessed int                   `json:"sources_processed"`
+	ArticlesCreated  int                   `json:"articles_created"`
+	ArticlesSkipped  int                   `json:"articles_skipped"` // duplicates, blocked, empty, or noise
+	ErrorCount       int                   `json:"error_count"`
+	Errors           []IngestionError      `json:"errors"` // capped; ErrorCount has the full count
+	Sources          []IngestionSourceStat `json:"sources"`
+}
+
+// IngestionError is one failure during an ingestion run.
+type IngestionError struct {
+	Source string `json:"source,omitempty"`
+	URL    string `json:"url,omitempty"`
+	Stage  string `json:"stage"` // discover, fingerprint, scrape, create, enqueue, enrich
+	Error  string `json:"error"`
+}
+
+// IngestionSourceStat summarizes what one source contributed to a run.
+type IngestionSourceStat struct {
+	Source     string `json:"source"`
+	Discovered int    `json:"discovered"`
+	Created    int    `json:"created"`
+	Skipped    int    `json:"skipped"`
+	Errors     int    `json:"errors"`
+	Unchanged  bool   `json:"unchanged,omitempty"` // feed answered 304 Not Modified
+
+	// Warnings describe repairs needed to parse a malformed feed (bare
+	// ampersands, stray bytes, broken items skipped).
+	Warnings []string `json:"warnings,omitempty"`
+}
+
+// IngestionRunStore provides database operations for ingestion runs.
+type IngestionRunStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewIngestionRunStore creates a new IngestionRunStore.
+func NewIngestionRunStore(pool *pgxpool.Pool) *IngestionRunStore {
+	return &IngestionRunStore{pool: pool}
+}
+
+// Start records a new running ingestion and returns it.
+func (s *IngestionRunStore) Start(ctx context.Context) (*IngestionRun, error) {
+	run := &IngestionRun{Status: "running", Errors: []IngestionError{}, Sources: []IngestionSourceStat{}}
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO ingestion_runs DEFAULT VALUES
+		RETURNING id, started_at
+	`).Scan(&run.ID, &run.StartedAt)
+	if err != nil {
+		return nil, fmt.Errorf("ingestion run start: %w", err)
+	}
+	return run, nil
+}
+
+// Finish stores the outcome of a run. Callers set Status; an empty Status is
+// stored as "ok".
+func (s *IngestionRunStore) Finish(ctx context.Context, run *IngestionRun) error {
+	if run.Status == "" || run.Status == "running" {
+		run.Status = "ok"
+	}
+	if run.Errors == nil {
+		run.Errors = []IngestionError{}
+	}
+	if run.Sources == nil {
+		run.Sources = []IngestionSourceStat{}
+	}
+	errorsJSON, err := json.Marshal(run.Errors)
+	if err != nil {
+		return fmt.Errorf("ingestion run marshal errors: %w", err)
+	}
+	sourcesJSON, err := json.Marshal(run.Sources)
+	if err != nil {
+		return fmt.Errorf("ingestion run marshal sources: %w", err)
+	}
+
+	err = s.pool.QueryRow(ctx, `
+		UPDATE ingestion_runs
+		SET finished_at = now(), duration_ms = $2, status = $3, note = $4,
+		    sources_processed = $5, articles_created = $6, articles_skipped = $7,
+		    error_count = $8, errors = $9, sources = $10
+		WHERE id = $1
+		RETURNING finished_at
+	`, run.ID, run.DurationMS, run.Status, run.Note, run.SourcesProcessed,
+		run.ArticlesCreated, run.ArticlesSkipped, run.ErrorCount, errorsJSON, sourcesJSON,
+	).Scan(&run.FinishedAt)
+	if err != nil {
+		return fmt.Errorf("ingestion run finish: %w", err)
+	}
+	return nil
+}
+
+// List returns the most recent runs, newest first.
+func (s *IngestionRunStore) List(ctx context.Context, limit int) ([]IngestionRun, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, started_at, finished_at, duration_ms, status, note, sources_processed,
+		       articles_created, articles_skipped, error_count, errors, sources
+		FROM ingestion_runs
+		ORDER BY started_at DESC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("ingestion runs list: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []IngestionRun
+	for rows.Next() {
+		var r IngestionRun
+		var errorsJSON, sourcesJSON []byte
+		if err := rows.Scan(&r.ID, &r.StartedAt, &r.FinishedAt, &r.DurationMS, &r.Status, &r.Note,
+			&r.SourcesProcessed, &r.ArticlesCreated, &r.ArticlesSkipped, &r.ErrorCount,
+			&errorsJSON, &sourcesJSON); err != nil {
+			return nil, fmt.Errorf("ingestion runs scan: %w", err)
+		}
+		r.Errors = []IngestionError{}
+		r.Sources = []IngestionSourceStat{}
+		if err := json.Unmarshal(errorsJSON, &r.Errors); err != nil {
+			return nil, fmt.Errorf("ingestion runs decode errors: %w", err)
+		}
+		if err := json.Unmarshal(sourcesJSON, &r.Sources); err != nil {
+			return nil, fmt.Errorf("ingestion runs decode sources: %w", err)
+		}
+		runs = append(runs, r)
+	}
+	return runs, rows.Err()
+}
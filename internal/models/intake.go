@@ -0,0 +1,202 @@
+package models
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SourceTip is the articles.source of inbox items created from tips.
+const SourceTip = "tip"
+
+// intakeKeyPrefix starts every intake key so leaked keys are recognizable.
+const intakeKeyPrefix = "fik_"
+
+// ErrInvalidIntakeKey is returned by IntakeKeyStore.Authenticate for unknown
+// or revoked keys.
+var ErrInvalidIntakeKey = errors.New("invalid intake key")
+
+// IntakeKey authorizes an external form or partner system to submit tips.
+// Only the key's hash is stored; Prefix identifies it in listings.
+type IntakeKey struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Active     bool       `json:"active"`
+	CreatedBy  *uuid.UUID `json:"created_by,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// Tip is a submission received through the intake endpoint. Its ID is the
+// receipt given to the submitter.
+type Tip struct {
+	ID               uuid.UUID  `json:"id"`
+	ArticleID        *uuid.UUID `json:"article_id,omitempty"`
+	IntakeKeyID      *uuid.UUID `json:"intake_key_id,omitempty"`
+	URL              string     `json:"url"`
+	Description      string     `json:"description"`
+	SubmitterName    string     `json:"submitter_name"`
+	SubmitterContact string     `json:"submitter_contact"`
+	SubmitterOrg     string     `json:"submitter_org"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+// hashIntakeKey returns the stored form of an intake key.
+func hashIntakeKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// ── IntakeKeyStore ───────────────────────────────────────────────
+
+// IntakeKeyStore provides data access methods for intake API keys.
+type IntakeKeyStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewIntakeKeyStore creates a new IntakeKeyStore.
+func NewIntakeKeyStore(pool *pgxpool.Pool) *IntakeKeyStore {
+	return &IntakeKeyStore{pool: pool}
+}
+
+// List returns all intake keys, newest first.
+func (s *IntakeKeyStore) List(ctx context.Context) ([]IntakeKey, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, name, key_prefix, active, created_by, last_used_at, created_at
+		FROM intake_keys
+		ORDER BY created_at DESC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("intake key list: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []IntakeKey
+	for rows.Next() {
+		var k IntakeKey
+		if err := rows.Scan(&k.ID, &k.Name, &k.Prefix, &k.Active, &k.CreatedBy, &k.LastUsedAt, &k.CreatedAt); err != nil {
+			return nil, fmt.Errorf("intake key scan: %w", err)
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// Create generates a new intake key. The returned plaintext key is not
+// stored and cannot be retrieved again.
+func (s *IntakeKeyStore) Create(ctx context.Context, name string, createdBy *uuid.UUID) (*IntakeKey, string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", fmt.Errorf("intake key generate: %w", err)
+	}
+	plain := intakeKeyPrefix + hex.EncodeToString(b)
+
+	k := &IntakeKey{
+		Name:      name,
+		Prefix:    plain[:len(intakeKeyPrefix)+8],
+		Active:    true,
+		CreatedBy: createdBy,
+	}
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO intake_keys (name, key_hash, key_prefix, created_by)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, k.Name, hashIntakeKey(plain), k.Prefix, k.CreatedBy).Scan(&k.ID, &k.CreatedAt)
+	if err != nil {
+		return nil, "", fmt.Errorf("intake key create: %w", err)
+	}
+	return k, plain, nil
+}
+
+// Revoke deactivates an intake key; tips it submitted are kept.
+func (s *IntakeKeyStore) Revoke(ctx context.Context, id uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `UPDATE intake_keys SET active = false WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("intake key revoke: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("intake key not found: %s", id)
+	}
+	return nil
+}
+
+// Authenticate returns the active key matching plain and records its use.
+// It returns ErrInvalidIntakeKey when there is none.
+func (s *IntakeKeyStore) Authenticate(ctx context.Context, plain string) (*IntakeKey, error) {
+	var k IntakeKey
+	err := s.pool.QueryRow(ctx, `
+		UPDATE intake_keys SET last_used_at = NOW()
+		WHERE key_hash = $1 AND active = true
+		RETURNING id, name, key_prefix, active, created_by, last_used_at, created_at
+	`, hashIntakeKey(plain)).Scan(&k.ID, &k.Name, &k.Prefix, &k.Active, &k.CreatedBy, &k.LastUsedAt, &k.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrInvalidIntakeKey
+	}
+	if err != nil {
+		return nil, fmt.Errorf("intake key authenticate: %w", err)
+	}
+	return &k, nil
+}
+
+// ── TipStore ─────────────────────────────────────────────────────
+
+// TipStore provides data access methods for received tips.
+type TipStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewTipStore creates a new TipStore.
+func NewTipStore(pool *pgxpool.Pool) *TipStore {
+	return &TipStore{pool: pool}
+}
+
+// Create records a tip. The ID and CreatedAt fields are set by the database.
+func (s *TipStore) Create(ctx context.Context, t *Tip) error {
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO tips (article_id, intake_key_id, url, description,
+		                  submitter_name, submitter_contact, submitter_org)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id, created_at
+	`, t.ArticleID, t.IntakeKeyID, t.URL, t.Description,
+		t.SubmitterName, t.SubmitterContact, t.SubmitterOrg,
+	).Scan(&t.ID, &t.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("tip create: %w", err)
+	}
+	return nil
+}
+
+// ListByArticle returns the tips pointing at an article, oldest first.
+func (s *TipStore) ListByArticle(ctx context.Context, articleID uuid.UUID) ([]Tip, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, article_id, intake_key_id, url, description,
+		       submitter_name, submitter_contact, submitter_org, created_at
+		FROM tips
+		WHERE article_id = $1
+		ORDER BY created_at
+	`, articleID)
+	if err != nil {
+		return nil, fmt.Errorf("tip list: %w", err)
+	}
+	defer rows.Close()
+
+	var tips []Tip
+	for rows.Next() {
+		var t Tip
+		if err := rows.Scan(&t.ID, &t.ArticleID, &t.IntakeKeyID, &t.URL, &t.Description,
+			&t.SubmitterName, &t.SubmitterContact, &t.SubmitterOrg, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("tip scan: %w", err)
+		}
+		tips = append(tips, t)
+	}
+	return tips, rows.Err()
+}
@@ -0,0 +1,199 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Job kinds processed by the worker's job runner.
+const (
+	JobEnrichArticle   = "enrich_article"
+	JobCollectArticle  = "collect_article"
+	JobUploadEvidence  = "upload_evidence"
+	JobDeliverWebhook  = "deliver_webhook"
+	JobArchiveSnapshot = "archive_snapshot"
+	JobEmbedArticle    = "embed_article"
+)
+
+const (
+	// jobBaseBackoff is the delay before the first retry; each further
+	// attempt doubles it up to jobMaxBackoff.
+	jobBaseBackoff = 30 * time.Second
+	jobMaxBackoff  = 6 * time.Hour
+
+	// jobStaleAfter is how long a job may stay "running" before it is assumed
+	// its worker died and it becomes claimable again.
+	jobStaleAfter = 15 * time.Minute
+)
+
+// Job is a unit of background work persisted in the jobs table.
+type Job struct {
+	ID          uuid.UUID       `json:"id"`
+	Kind        string          `json:"kind"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"max_attempts"`
+	LastError   string          `json:"last_error"`
+	RunAt       time.Time       `json:"run_at"`
+	LockedAt    *time.Time      `json:"locked_at,omitempty"`
+	FinishedAt  *time.Time      `json:"finished_at,omitempty"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+// JobStore provides database operations for the job queue.
+type JobStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewJobStore creates a new JobStore.
+func NewJobStore(pool *pgxpool.Pool) *JobStore {
+	return &JobStore{pool: pool}
+}
+
+// Enqueue adds a job of the given kind. payload is marshalled to JSON.
+func (s *JobStore) Enqueue(ctx context.Context, kind string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("enqueue job: marshal payload: %w", err)
+	}
+
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO jobs (kind, payload) VALUES ($1, $2)
+	`, kind, data)
+	if err != nil {
+		return fmt.Errorf("enqueue job: %w", err)
+	}
+	return nil
+}
+
+// ClaimBatch atomically claims up to `limit` due jobs using FOR UPDATE SKIP
+// LOCKED so concurrent runners never pick the same job. Jobs left "running"
+// longer than jobStaleAfter (their worker crashed) are claimed again while
+// they have attempts left, and marked failed once they have none.
+func (s *JobStore) ClaimBatch(ctx context.Context, limit int) ([]Job, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	rows, err := s.pool.Query(ctx, `
+		WITH exhausted AS (
+			UPDATE jobs
+			SET status = 'failed', finished_at = NOW(),
+			    last_error = COALESCE(NULLIF(last_error, ''), 'worker died during the last attempt')
+			WHERE status = 'running' AND locked_at < NOW() - make_interval(secs => $2)
+			  AND attempts >= max_attempts
+		)
+		UPDATE jobs
+		SET status = 'running', locked_at = NOW(), attempts = attempts + 1
+		WHERE id IN (
+			SELECT id FROM jobs
+			WHERE (status = 'pending' AND run_at <= NOW())
+			   OR (status = 'running' AND locked_at < NOW() - make_interval(secs => $2)
+			       AND attempts < max_attempts)
+			ORDER BY run_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, kind, payload, status, attempts, max_attempts, last_error,
+		          run_at, locked_at, finished_at, created_at
+	`, limit, jobStaleAfter.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim jobs: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []Job
+	for rows.Next() {
+		var j Job
+		if err := rows.Scan(
+			&j.ID, &j.Kind, &j.Payload, &j.Status, &j.Attempts, &j.MaxAttempts, &j.LastError,
+			&j.RunAt, &j.LockedAt, &j.FinishedAt, &j.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("scan job: %w", err)
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, rows.Err()
+}
+
+// Complete marks a job as done.
+func (s *JobStore) Complete(ctx context.Context, id uuid.UUID) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE jobs SET status = 'done', last_error = '', finished_at = NOW() WHERE id = $1
+	`, id)
+	if err != nil {
+		return fmt.Errorf("complete job: %w", err)
+	}
+	return nil
+}
+
+// Fail records a failed attempt. If the job has attempts left it is
+// rescheduled after an exponential backoff; otherwise it is marked failed.
+// It returns the time of the next attempt, or nil if the job gave up.
+func (s *JobStore) Fail(ctx context.Context, job *Job, errMsg string) (*time.Time, error) {
+	if job.Attempts >= job.MaxAttempts {
+		_, err := s.pool.Exec(ctx, `
+			UPDATE jobs SET status = 'failed', last_error = $2, finished_at = NOW() WHERE id = $1
+		`, job.ID, errMsg)
+		if err != nil {
+			return nil, fmt.Errorf("fail job: %w", err)
+		}
+		return nil, nil
+	}
+
+	next := time.Now().Add(JobBackoff(job.Attempts))
+	_, err := s.pool.Exec(ctx, `
+		UPDATE jobs SET status = 'pending', last_error = $2, run_at = $3, locked_at = NULL WHERE id = $1
+	`, job.ID, errMsg, next)
+	if err != nil {
+		return nil, fmt.Errorf("reschedule job: %w", err)
+	}
+	return &next, nil
+}
+
+// Release returns a claimed job to the queue without counting the attempt,
+// for work interrupted by shutdown rather than by its own failure.
+func (s *JobStore) Release(ctx context.Context, id uuid.UUID) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE jobs
+		SET status = 'pending', attempts = GREATEST(attempts - 1, 0), locked_at = NULL, run_at = NOW()
+		WHERE id = $1 AND status = 'running'
+	`, id)
+	if err != nil {
+		return fmt.Errorf("release job: %w", err)
+	}
+	return nil
+}
+
+// DeleteDoneBefore removes completed jobs finished before the cutoff. Failed
+// jobs are kept for inspection.
+func (s *JobStore) DeleteDoneBefore(ctx context.Context, cutoff time.Time) (int, error) {
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM jobs WHERE status = 'done' AND finished_at < $1
+	`, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("delete done jobs: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
+
+// JobBackoff returns the delay before retrying a job that has failed
+// `attempts` times: 30s, 1m, 2m, 4m, ... capped at 6h.
+func JobBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	d := jobBaseBackoff
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= jobMaxBackoff {
+			return jobMaxBackoff
+		}
+	}
+	return d
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestJobStoreClaimBatchStaleJobs(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	kind := "test_" + uuid.NewString()
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM jobs WHERE kind = $1`, kind)
+	})
+
+	// Both jobs were left running by a worker that died an hour ago.
+	stale := func(attempts int) uuid.UUID {
+		var id uuid.UUID
+		err := pool.QueryRow(ctx, `
+			INSERT INTO jobs (kind, status, attempts, max_attempts, locked_at, run_at)
+			VALUES ($1, 'running', $2, 3, NOW() - INTERVAL '1 hour', NOW() - INTERVAL '1 hour')
+			RETURNING id
+		`, kind, attempts).Scan(&id)
+		if err != nil {
+			t.Fatalf("insert job: %v", err)
+		}
+		return id
+	}
+	retryable, exhausted := stale(1), stale(3)
+
+	jobs, err := NewJobStore(pool).ClaimBatch(ctx, 1000)
+	if err != nil {
+		t.Fatalf("ClaimBatch: %v", err)
+	}
+	claimed := map[uuid.UUID]Job{}
+	for _, j := range jobs {
+		claimed[j.ID] = j
+	}
+
+	if j, ok := claimed[retryable]; !ok || j.Attempts != 2 {
+		t.Errorf("job with attempts left: claimed = %v, attempts %d; want claimed with attempts 2", ok, j.Attempts)
+	}
+	if _, ok := claimed[exhausted]; ok {
+		t.Errorf("job out of attempts was claimed again")
+	}
+
+	var status, lastError string
+	var finished bool
+	err = pool.QueryRow(ctx, `
+		SELECT status, last_error, finished_at IS NOT NULL FROM jobs WHERE id = $1
+	`, exhausted).Scan(&status, &lastError, &finished)
+	if err != nil {
+		t.Fatalf("read job: %v", err)
+	}
+	if status != "failed" || lastError == "" || !finished {
+		t.Errorf("job out of attempts: status %q, last_error %q, finished %v; want failed with an error", status, lastError, finished)
+	}
+}
@@ -0,0 +1,97 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// LogLevelOverride temporarily replaces a service's configured log level.
+type LogLevelOverride struct {
+	Service   string     `json:"service"`
+	Level     string     `json:"level"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	SetBy     *uuid.UUID `json:"set_by,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// LogLevelStore provides data access methods for log level overrides.
+type LogLevelStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewLogLevelStore creates a new LogLevelStore.
+func NewLogLevelStore(pool *pgxpool.Pool) *LogLevelStore {
+	return &LogLevelStore{pool: pool}
+}
+
+// List returns the overrides that have not expired, ordered by service.
+func (s *LogLevelStore) List(ctx context.Context) ([]LogLevelOverride, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT service, level, expires_at, set_by, created_at
+		FROM log_level_overrides
+		WHERE expires_at > NOW()
+		ORDER BY service
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("log level list: %w", err)
+	}
+	defer rows.Close()
+
+	var overrides []LogLevelOverride
+	for rows.Next() {
+		var o LogLevelOverride
+		if err := rows.Scan(&o.Service, &o.Level, &o.ExpiresAt, &o.SetBy, &o.CreatedAt); err != nil {
+			return nil, fmt.Errorf("log level scan: %w", err)
+		}
+		overrides = append(overrides, o)
+	}
+	return overrides, rows.Err()
+}
+
+// ActiveLogLevel returns the service's unexpired override, or nil if it has
+// none.
+func (s *LogLevelStore) ActiveLogLevel(ctx context.Context, service string) (*LogLevelOverride, error) {
+	var o LogLevelOverride
+	err := s.pool.QueryRow(ctx, `
+		SELECT service, level, expires_at, set_by, created_at
+		FROM log_level_overrides
+		WHERE service = $1 AND expires_at > NOW()
+	`, service).Scan(&o.Service, &o.Level, &o.ExpiresAt, &o.SetBy, &o.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("log level get: %w", err)
+	}
+	return &o, nil
+}
+
+// Set replaces the service's override.
+func (s *LogLevelStore) Set(ctx context.Context, o *LogLevelOverride) error {
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO log_level_overrides (service, level, expires_at, set_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (service) DO UPDATE
+		SET level = EXCLUDED.level, expires_at = EXCLUDED.expires_at,
+		    set_by = EXCLUDED.set_by, created_at = NOW()
+		RETURNING created_at
+	`, o.Service, o.Level, o.ExpiresAt, o.SetBy).Scan(&o.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("log level set: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the service's override. It is not an error if there is none.
+func (s *LogLevelStore) Delete(ctx context.Context, service string) error {
+	if _, err := s.pool.Exec(ctx, `DELETE FROM log_level_overrides WHERE service = $1`, service); err != nil {
+		return fmt.Errorf("log level delete: %w", err)
+	}
+	return nil
+}
@@ -53,6 +53,16 @@ func (s *NoteStore) ListByArticle(ctx context.Context, articleID uuid.UUID) ([]N
 	return notes, rows.Err()
 }
 
+// CountByArticle returns the number of notes attached to an article.
+func (s *NoteStore) CountByArticle(ctx context.Context, articleID uuid.UUID) (int, error) {
+	var count int
+	err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM notes WHERE article_id = $1`, articleID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("note count: %w", err)
+	}
+	return count, nil
+}
+
 // GetByID returns a single note by its UUID.
 func (s *NoteStore) GetByID(ctx context.Context, id uuid.UUID) (*Note, error) {
 	var n Note
@@ -14,7 +14,7 @@ import (
 type BotNotification struct {
 	ID          uuid.UUID       `json:"id"`
 	UserID      uuid.UUID       `json:"user_id"`
-	Type        string          `json:"type"` // "digest", "watchlist_hit", "system"
+	Type        string          `json:"type"` // "digest", "watchlist_hit", "watchlist_digest", "grant_deadline", "evidence_expiring", "feed_leak", "system"
 	Payload     json.RawMessage `json:"payload"`
 	Delivered   bool            `json:"delivered"`
 	CreatedAt   time.Time       `json:"created_at"`
@@ -118,6 +118,122 @@ func (s *NotificationStore) CreateWatchlistHit(ctx context.Context, userID uuid.
 	return nil
 }
 
+// CreateWatchlistDigest creates a notification announcing a watchlist digest.
+func (s *NotificationStore) CreateWatchlistDigest(ctx context.Context, userID, digestID uuid.UUID, hitCount int, summary string) error {
+	id := uuid.New()
+	payload, err := json.Marshal(map[string]any{
+		"digest_id": digestID,
+		"hit_count": hitCount,
+		"summary":   summary,
+	})
+	if err != nil {
+		return fmt.Errorf("notification create watchlist digest: marshal payload: %w", err)
+	}
+
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO bot_notifications (id, user_id, type, payload)
+		VALUES ($1, $2, 'watchlist_digest', $3)
+	`, id, userID, payload)
+	if err != nil {
+		return fmt.Errorf("notification create watchlist digest: %w", err)
+	}
+	return nil
+}
+
+// CreateGrantDeadline creates a notification announcing that a grant
+// opportunity closes soon.
+func (s *NotificationStore) CreateGrantDeadline(ctx context.Context, userID uuid.UUID, g GrantOpportunity) error {
+	id := uuid.New()
+	closeDate := ""
+	if g.CloseDate != nil {
+		closeDate = g.CloseDate.Format("2006-01-02")
+	}
+	payload, err := json.Marshal(map[string]any{
+		"grant_id":   g.ID,
+		"title":      g.Title,
+		"agency":     g.Agency,
+		"close_date": closeDate,
+		"tracking":   g.Tracking,
+		"url":        g.URL,
+	})
+	if err != nil {
+		return fmt.Errorf("notification create grant deadline: marshal payload: %w", err)
+	}
+
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO bot_notifications (id, user_id, type, payload)
+		VALUES ($1, $2, 'grant_deadline', $3)
+	`, id, userID, payload)
+	if err != nil {
+		return fmt.Errorf("notification create grant deadline: %w", err)
+	}
+	return nil
+}
+
+// evidenceExpiringSample caps the articles listed in an evidence expiry
+// notification.
+const evidenceExpiringSample = 5
+
+// CreateEvidenceExpiring creates a notification announcing that the evidence
+// of the given articles expires soon. Only the soonest few are listed.
+func (s *NotificationStore) CreateEvidenceExpiring(ctx context.Context, userID uuid.UUID, articles []Article) error {
+	type item struct {
+		ID        uuid.UUID `json:"id"`
+		Title     string    `json:"title"`
+		ExpiresAt string    `json:"expires_at"`
+	}
+	items := make([]item, 0, min(len(articles), evidenceExpiringSample))
+	for _, a := range articles[:min(len(articles), evidenceExpiringSample)] {
+		expiresAt := ""
+		if a.EvidenceExpiresAt != nil {
+			expiresAt = a.EvidenceExpiresAt.Format("2006-01-02")
+		}
+		items = append(items, item{ID: a.ID, Title: a.Title, ExpiresAt: expiresAt})
+	}
+
+	id := uuid.New()
+	payload, err := json.Marshal(map[string]any{
+		"count":    len(articles),
+		"articles": items,
+	})
+	if err != nil {
+		return fmt.Errorf("notification create evidence expiring: marshal payload: %w", err)
+	}
+
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO bot_notifications (id, user_id, type, payload)
+		VALUES ($1, $2, 'evidence_expiring', $3)
+	`, id, userID, payload)
+	if err != nil {
+		return fmt.Errorf("notification create evidence expiring: %w", err)
+	}
+	return nil
+}
+
+// CreateFeedLeak creates a notification warning that the user's feed token
+// looks like it was shared publicly.
+func (s *NotificationStore) CreateFeedLeak(ctx context.Context, userID uuid.UUID, leak FeedLeak) error {
+	id := uuid.New()
+	payload, err := json.Marshal(map[string]any{
+		"token_prefix":     leak.TokenPrefix,
+		"distinct_ips":     leak.DistinctIPs,
+		"outside_referers": leak.OutsideReferers,
+		"rotated":          leak.Rotated,
+	})
+	if err != nil {
+		return fmt.Errorf("notification create feed leak: marshal payload: %w", err)
+	}
+
+	_, err = s.pool.Exec(ctx, `
+		INSERT INTO bot_notifications (id, user_id, type, payload)
+		VALUES ($1, $2, 'feed_leak', $3)
+	`, id, userID, payload)
+	if err != nil {
+		return fmt.Errorf("notification create feed leak: %w", err)
+	}
+	return nil
+}
+
 // Cleanup deletes delivered notifications older than the specified number of days.
 // Returns the number of rows deleted.
 func (s *NotificationStore) Cleanup(ctx context.Context, olderThanDays int) (int, error) {
@@ -0,0 +1,206 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// OutboundFetch is one request Folio made to another site.
+type OutboundFetch struct {
+	ID         int64     `json:"id"`
+	FetchedAt  time.Time `json:"fetched_at"`
+	Purpose    string    `json:"purpose"`
+	Method     string    `json:"method"`
+	URL        string    `json:"url"`
+	Domain     string    `json:"domain"`
+	Status     int       `json:"status"` // 0 when no response was received
+	Bytes      int64     `json:"bytes"`
+	DurationMS int       `json:"duration_ms"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// OutboundFetchFilter selects outbound fetches. Zero fields match everything.
+type OutboundFetchFilter struct {
+	Domain  string // also matches subdomains
+	Purpose string
+	From    time.Time // fetched_at >= From
+	To      time.Time // fetched_at < To
+}
+
+// clause returns the WHERE clause selecting the filter's fetches, numbering
+// placeholders from argN.
+func (f OutboundFetchFilter) clause(argN int) (string, []any) {
+	conditions := []string{"TRUE"}
+	var args []any
+	add := func(cond string, arg any) {
+		conditions = append(conditions, fmt.Sprintf(cond, argN))
+		args = append(args, arg)
+		argN++
+	}
+
+	if f.Domain != "" {
+		add("(domain = $%[1]d OR domain LIKE '%%.' || $%[1]d)", NormalizeFetchDomain(f.Domain))
+	}
+	if f.Purpose != "" {
+		add("purpose = $%d", f.Purpose)
+	}
+	if !f.From.IsZero() {
+		add("fetched_at >= $%d", f.From)
+	}
+	if !f.To.IsZero() {
+		add("fetched_at < $%d", f.To)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// NormalizeFetchDomain lower-cases a host and strips a leading "www.", as
+// domains are stored.
+func NormalizeFetchDomain(host string) string {
+	return strings.TrimPrefix(strings.ToLower(strings.TrimSpace(host)), "www.")
+}
+
+// OutboundFetchDay counts a domain's fetches for one day and purpose.
+type OutboundFetchDay struct {
+	Day     time.Time `json:"day"`
+	Purpose string    `json:"purpose"`
+	Fetches int       `json:"fetches"`
+	Bytes   int64     `json:"bytes"`
+	Errors  int       `json:"errors"` // no response, or a 4xx/5xx status
+}
+
+// OutboundDomain summarizes the fetches to one domain.
+type OutboundDomain struct {
+	Domain        string    `json:"domain"`
+	Fetches       int       `json:"fetches"`
+	Bytes         int64     `json:"bytes"`
+	LastFetchedAt time.Time `json:"last_fetched_at"`
+}
+
+// OutboundFetchStore records and queries outbound fetches.
+type OutboundFetchStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewOutboundFetchStore creates a new OutboundFetchStore.
+func NewOutboundFetchStore(pool *pgxpool.Pool) *OutboundFetchStore {
+	return &OutboundFetchStore{pool: pool}
+}
+
+// InsertBatch records several fetches at once.
+func (s *OutboundFetchStore) InsertBatch(ctx context.Context, fetches []OutboundFetch) error {
+	_, err := s.pool.CopyFrom(ctx,
+		pgx.Identifier{"outbound_fetches"},
+		[]string{"fetched_at", "purpose", "method", "url", "domain", "status", "bytes", "duration_ms", "error"},
+		pgx.CopyFromSlice(len(fetches), func(i int) ([]any, error) {
+			f := fetches[i]
+			return []any{f.FetchedAt, f.Purpose, f.Method, f.URL, f.Domain, f.Status, f.Bytes, f.DurationMS, f.Error}, nil
+		}),
+	)
+	if err != nil {
+		return fmt.Errorf("outbound fetch insert: %w", err)
+	}
+	return nil
+}
+
+// List returns the fetches matching the filter, newest first.
+func (s *OutboundFetchStore) List(ctx context.Context, f OutboundFetchFilter, limit, offset int) ([]OutboundFetch, error) {
+	where, args := f.clause(3)
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, fetched_at, purpose, method, url, domain, status, bytes, duration_ms, error
+		FROM outbound_fetches
+		WHERE `+where+`
+		ORDER BY fetched_at DESC, id DESC
+		LIMIT $1 OFFSET $2
+	`, append([]any{limit, offset}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("outbound fetch list: %w", err)
+	}
+	defer rows.Close()
+
+	var fetches []OutboundFetch
+	for rows.Next() {
+		var o OutboundFetch
+		if err := rows.Scan(&o.ID, &o.FetchedAt, &o.Purpose, &o.Method, &o.URL, &o.Domain,
+			&o.Status, &o.Bytes, &o.DurationMS, &o.Error); err != nil {
+			return nil, fmt.Errorf("outbound fetch scan: %w", err)
+		}
+		fetches = append(fetches, o)
+	}
+	return fetches, rows.Err()
+}
+
+// Daily counts the fetches matching the filter per day (UTC) and purpose,
+// newest day first.
+func (s *OutboundFetchStore) Daily(ctx context.Context, f OutboundFetchFilter) ([]OutboundFetchDay, error) {
+	where, args := f.clause(1)
+	rows, err := s.pool.Query(ctx, `
+		SELECT date_trunc('day', fetched_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS day, purpose,
+		       COUNT(*), COALESCE(SUM(bytes), 0),
+		       COUNT(*) FILTER (WHERE status = 0 OR status >= 400)
+		FROM outbound_fetches
+		WHERE `+where+`
+		GROUP BY day, purpose
+		ORDER BY day DESC, purpose
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("outbound fetch daily: %w", err)
+	}
+	defer rows.Close()
+
+	var days []OutboundFetchDay
+	for rows.Next() {
+		var d OutboundFetchDay
+		if err := rows.Scan(&d.Day, &d.Purpose, &d.Fetches, &d.Bytes, &d.Errors); err != nil {
+			return nil, fmt.Errorf("outbound fetch daily scan: %w", err)
+		}
+		days = append(days, d)
+	}
+	return days, rows.Err()
+}
+
+// Domains returns the most fetched domains matching the filter.
+func (s *OutboundFetchStore) Domains(ctx context.Context, f OutboundFetchFilter, limit int) ([]OutboundDomain, error) {
+	where, args := f.clause(2)
+	rows, err := s.pool.Query(ctx, `
+		SELECT domain, COUNT(*), COALESCE(SUM(bytes), 0), MAX(fetched_at)
+		FROM outbound_fetches
+		WHERE `+where+`
+		GROUP BY domain
+		ORDER BY COUNT(*) DESC, domain
+		LIMIT $1
+	`, append([]any{limit}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("outbound fetch domains: %w", err)
+	}
+	defer rows.Close()
+
+	var domains []OutboundDomain
+	for rows.Next() {
+		var d OutboundDomain
+		if err := rows.Scan(&d.Domain, &d.Fetches, &d.Bytes, &d.LastFetchedAt); err != nil {
+			return nil, fmt.Errorf("outbound fetch domains scan: %w", err)
+		}
+		domains = append(domains, d)
+	}
+	return domains, rows.Err()
+}
+
+// Prune deletes fetches older than olderThanDays and, beyond that, all but
+// the newest maxRows, so the table works as a ring buffer. A non-positive
+// limit is not applied. It returns how many rows were deleted.
+func (s *OutboundFetchStore) Prune(ctx context.Context, olderThanDays, maxRows int) (int, error) {
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM outbound_fetches
+		WHERE ($1 > 0 AND fetched_at < NOW() - make_interval(days => $1))
+		   OR ($2 > 0 AND id <= (SELECT MAX(id) FROM outbound_fetches) - $2)
+	`, olderThanDays, maxRows)
+	if err != nil {
+		return 0, fmt.Errorf("outbound fetch prune: %w", err)
+	}
+	return int(tag.RowsAffected()), nil
+}
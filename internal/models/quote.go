@@ -0,0 +1,116 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Quote is a direct quotation extracted from an article, with attribution.
+type Quote struct {
+	Text    string `json:"quote"`
+	Speaker string `json:"speaker"`
+	Role    string `json:"role,omitempty"`
+}
+
+// BriefQuote is a quote kept on a daily brief, with the article it came from.
+type BriefQuote struct {
+	Quote
+	ArticleID uuid.UUID `json:"article_id"`
+	Source    string    `json:"source"`
+	Title     string    `json:"title"`
+	URL       string    `json:"url"`
+}
+
+// SetQuotes stores the quotes extracted from an article.
+func (s *ArticleStore) SetQuotes(ctx context.Context, id uuid.UUID, quotes []Quote) error {
+	if quotes == nil {
+		quotes = []Quote{}
+	}
+	data, err := json.Marshal(quotes)
+	if err != nil {
+		return fmt.Errorf("article set quotes: marshal: %w", err)
+	}
+	if _, err := s.pool.Exec(ctx, `UPDATE articles SET quotes = $2 WHERE id = $1`, id, data); err != nil {
+		return fmt.Errorf("article set quotes: %w", err)
+	}
+	return nil
+}
+
+// GetQuotes returns an article's extracted quotes.
+func (s *ArticleStore) GetQuotes(ctx context.Context, id uuid.UUID) ([]Quote, error) {
+	var raw []byte
+	if err := s.pool.QueryRow(ctx, `SELECT quotes FROM articles WHERE id = $1`, id).Scan(&raw); err != nil {
+		return nil, fmt.Errorf("article get quotes: %w", err)
+	}
+	return scanQuotes(raw), nil
+}
+
+// QuotesByArticle returns the quotes of the given articles, keyed by article
+// id. Articles without quotes are omitted.
+func (s *ArticleStore) QuotesByArticle(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]Quote, error) {
+	result := make(map[uuid.UUID][]Quote)
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, quotes FROM articles
+		WHERE id = ANY($1) AND quotes != '[]'::jsonb
+	`, ids)
+	if err != nil {
+		return nil, fmt.Errorf("article quotes by id: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id uuid.UUID
+		var raw []byte
+		if err := rows.Scan(&id, &raw); err != nil {
+			return nil, fmt.Errorf("article quotes scan: %w", err)
+		}
+		if quotes := scanQuotes(raw); len(quotes) > 0 {
+			result[id] = quotes
+		}
+	}
+	return result, rows.Err()
+}
+
+// QuotesByURL returns the quotes of the article stored under the given URL
+// or canonical URL, or nil if there is no such article.
+func (s *ArticleStore) QuotesByURL(ctx context.Context, url, canonicalURL string) ([]Quote, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT quotes FROM articles
+		WHERE url = $1 OR ($2 != '' AND canonical_url = $2)
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, url, canonicalURL)
+	if err != nil {
+		return nil, fmt.Errorf("article quotes by url: %w", err)
+	}
+	defer rows.Close()
+
+	var quotes []Quote
+	for rows.Next() {
+		var raw []byte
+		if err := rows.Scan(&raw); err != nil {
+			return nil, fmt.Errorf("article quotes by url scan: %w", err)
+		}
+		quotes = scanQuotes(raw)
+	}
+	return quotes, rows.Err()
+}
+
+// scanQuotes unmarshals a JSONB quotes column.
+func scanQuotes(raw []byte) []Quote {
+	if len(raw) == 0 {
+		return nil
+	}
+	var quotes []Quote
+	if err := json.Unmarshal(raw, &quotes); err != nil {
+		return nil
+	}
+	return quotes
+}
@@ -0,0 +1,204 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RetentionRule automatically moves articles matching a tag and/or source out
+// of a status once they reach a minimum age, e.g. trash crime items left in
+// the inbox for 30 days, or save every grants item.
+type RetentionRule struct {
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Tag          string     `json:"tag"`
+	Source       string     `json:"source"`
+	FromStatus   string     `json:"from_status"` // inbox or saved
+	MinAgeDays   int        `json:"min_age_days"`
+	Action       string     `json:"action"` // trash or save
+	Active       bool       `json:"active"`
+	LastRunAt    *time.Time `json:"last_run_at,omitempty"`
+	LastAffected int        `json:"last_affected"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// TargetStatus returns the article status the rule's action moves items to.
+func (r *RetentionRule) TargetStatus() string {
+	if r.Action == "save" {
+		return "saved"
+	}
+	return "trashed"
+}
+
+// matchClause returns the WHERE clause (starting at $1) selecting the
+// articles the rule applies to. Pinned articles are never touched.
+func (r *RetentionRule) matchClause() (string, []any) {
+	return `status = $1 AND pinned = false
+		  AND created_at < NOW() - make_interval(days => $2)
+		  AND ($3 = '' OR tags @> to_jsonb(ARRAY[$3::text]))
+		  AND ($4 = '' OR source = $4)`,
+		[]any{r.FromStatus, r.MinAgeDays, r.Tag, r.Source}
+}
+
+type RetentionRuleStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewRetentionRuleStore(pool *pgxpool.Pool) *RetentionRuleStore {
+	return &RetentionRuleStore{pool: pool}
+}
+
+const retentionRuleColumns = `id, name, tag, source, from_status, min_age_days, action,
+		       active, last_run_at, last_affected, created_at`
+
+func scanRetentionRule(row scannable, r *RetentionRule) error {
+	return row.Scan(
+		&r.ID, &r.Name, &r.Tag, &r.Source, &r.FromStatus, &r.MinAgeDays, &r.Action,
+		&r.Active, &r.LastRunAt, &r.LastAffected, &r.CreatedAt,
+	)
+}
+
+// List returns all rules; if activeOnly is set, only active ones.
+func (s *RetentionRuleStore) List(ctx context.Context, activeOnly bool) ([]RetentionRule, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+retentionRuleColumns+`
+		FROM retention_rules
+		WHERE ($1 = false OR active = true)
+		ORDER BY created_at
+	`, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("retention rules list: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []RetentionRule
+	for rows.Next() {
+		var r RetentionRule
+		if err := scanRetentionRule(rows, &r); err != nil {
+			return nil, fmt.Errorf("retention rule scan: %w", err)
+		}
+		rules = append(rules, r)
+	}
+	return rules, rows.Err()
+}
+
+func (s *RetentionRuleStore) GetByID(ctx context.Context, id uuid.UUID) (*RetentionRule, error) {
+	var r RetentionRule
+	row := s.pool.QueryRow(ctx, `SELECT `+retentionRuleColumns+` FROM retention_rules WHERE id = $1`, id)
+	if err := scanRetentionRule(row, &r); err != nil {
+		return nil, fmt.Errorf("retention rule get: %w", err)
+	}
+	return &r, nil
+}
+
+func (s *RetentionRuleStore) Create(ctx context.Context, r *RetentionRule) error {
+	if r.ID == uuid.Nil {
+		r.ID = uuid.New()
+	}
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO retention_rules (id, name, tag, source, from_status, min_age_days, action, active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at
+	`, r.ID, r.Name, r.Tag, r.Source, r.FromStatus, r.MinAgeDays, r.Action, r.Active).Scan(&r.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("retention rule create: %w", err)
+	}
+	return nil
+}
+
+func (s *RetentionRuleStore) Update(ctx context.Context, r *RetentionRule) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE retention_rules
+		SET name = $2, tag = $3, source = $4, from_status = $5, min_age_days = $6, action = $7, active = $8
+		WHERE id = $1
+	`, r.ID, r.Name, r.Tag, r.Source, r.FromStatus, r.MinAgeDays, r.Action, r.Active)
+	if err != nil {
+		return fmt.Errorf("retention rule update: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("retention rule not found: %s", r.ID)
+	}
+	return nil
+}
+
+func (s *RetentionRuleStore) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM retention_rules WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("retention rule delete: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("retention rule not found: %s", id)
+	}
+	return nil
+}
+
+// Preview is a dry run of a rule: it returns how many articles the rule
+// would move right now and up to `limit` of them, oldest first.
+func (s *RetentionRuleStore) Preview(ctx context.Context, r *RetentionRule, limit int) (int, []Article, error) {
+	where, args := r.matchClause()
+
+	var total int
+	if err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM articles WHERE `+where, args...).Scan(&total); err != nil {
+		return 0, nil, fmt.Errorf("retention rule preview count: %w", err)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, title, source, url, canonical_url, region, published_at,
+		       clean_text, summary, image_url, status, pinned, evidence_policy,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
+		FROM articles
+		WHERE `+where+`
+		ORDER BY created_at ASC
+		LIMIT $5
+	`, append(args, limit)...)
+	if err != nil {
+		return 0, nil, fmt.Errorf("retention rule preview: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	for rows.Next() {
+		a := scanArticleFromRow(rows)
+		if a == nil {
+			return 0, nil, fmt.Errorf("retention rule preview: scan failed")
+		}
+		articles = append(articles, *a)
+	}
+	return total, articles, rows.Err()
+}
+
+// Apply moves every article matching the rule to its target status and
+// records the run; trashed articles are dated for the purge job. It returns
+// the number of articles moved.
+func (s *RetentionRuleStore) Apply(ctx context.Context, r *RetentionRule) (int, error) {
+	where, args := r.matchClause()
+
+	var affected int
+	err := s.pool.QueryRow(ctx, `
+		WITH moved AS (
+			UPDATE articles
+			SET status = $5,
+			    deleted_at = CASE WHEN $5 = 'trashed' THEN NOW() END
+			WHERE `+where+`
+			RETURNING id
+		), logged AS (
+			INSERT INTO article_status_history (article_id, from_status, to_status, reason)
+			SELECT id, $1, $5, 'retention' FROM moved
+		)
+		SELECT COUNT(*) FROM moved`,
+		append(args, r.TargetStatus())...).Scan(&affected)
+	if err != nil {
+		return 0, fmt.Errorf("retention rule apply: %w", err)
+	}
+
+	if _, err := s.pool.Exec(ctx, `
+		UPDATE retention_rules SET last_run_at = NOW(), last_affected = $2 WHERE id = $1
+	`, r.ID, affected); err != nil {
+		return affected, fmt.Errorf("retention rule record run: %w", err)
+	}
+	return affected, nil
+}
@@ -0,0 +1,93 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SearchUsage is one engine's query count for one day.
+type SearchUsage struct {
+	Day     time.Time `json:"day"`
+	Engine  string    `json:"engine"`
+	Queries int       `json:"queries"`
+	Denied  int       `json:"denied"` // queries refused because the budget was spent
+}
+
+type SearchUsageStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewSearchUsageStore(pool *pgxpool.Pool) *SearchUsageStore {
+	return &SearchUsageStore{pool: pool}
+}
+
+// Reserve counts one query against today's usage for the engine if fewer than
+// limit queries have been made so far (limit <= 0 means unlimited; the first
+// query of the day is always allowed). It reports
+// whether the query may go ahead; refused queries are counted as denied.
+func (s *SearchUsageStore) Reserve(ctx context.Context, engine string, limit int) (bool, error) {
+	var queries int
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO search_usage (day, engine, queries)
+		VALUES (CURRENT_DATE, $1, 1)
+		ON CONFLICT (day, engine) DO UPDATE
+		SET queries = search_usage.queries + 1
+		WHERE $2 <= 0 OR search_usage.queries < $2
+		RETURNING queries
+	`, engine, limit).Scan(&queries)
+	if err == nil {
+		return true, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return false, fmt.Errorf("search usage reserve: %w", err)
+	}
+
+	if _, err := s.pool.Exec(ctx, `
+		UPDATE search_usage SET denied = denied + 1 WHERE day = CURRENT_DATE AND engine = $1
+	`, engine); err != nil {
+		return false, fmt.Errorf("search usage deny: %w", err)
+	}
+	return false, nil
+}
+
+// Used returns today's query count for the engine.
+func (s *SearchUsageStore) Used(ctx context.Context, engine string) (int, error) {
+	var n int
+	err := s.pool.QueryRow(ctx, `
+		SELECT COALESCE(SUM(queries), 0) FROM search_usage WHERE day = CURRENT_DATE AND engine = $1
+	`, engine).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("search usage used: %w", err)
+	}
+	return n, nil
+}
+
+// ListSince returns usage rows for the last `days` days (including today),
+// newest first.
+func (s *SearchUsageStore) ListSince(ctx context.Context, days int) ([]SearchUsage, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT day, engine, queries, denied
+		FROM search_usage
+		WHERE day > CURRENT_DATE - $1::int
+		ORDER BY day DESC, engine ASC
+	`, days)
+	if err != nil {
+		return nil, fmt.Errorf("search usage list: %w", err)
+	}
+	defer rows.Close()
+
+	var usage []SearchUsage
+	for rows.Next() {
+		var u SearchUsage
+		if err := rows.Scan(&u.Day, &u.Engine, &u.Queries, &u.Denied); err != nil {
+			return nil, fmt.Errorf("search usage scan: %w", err)
+		}
+		usage = append(usage, u)
+	}
+	return usage, rows.Err()
+}
@@ -63,6 +63,15 @@ func (s *SessionStore) Delete(ctx context.Context, token string) error {
 	return nil
 }
 
+// DeleteByUser removes all of a user's sessions, signing them out everywhere.
+func (s *SessionStore) DeleteByUser(ctx context.Context, userID uuid.UUID) error {
+	_, err := s.pool.Exec(ctx, `DELETE FROM sessions WHERE user_id = $1`, userID)
+	if err != nil {
+		return fmt.Errorf("session delete by user: %w", err)
+	}
+	return nil
+}
+
 // DeleteExpired removes all sessions that have passed their expiry time.
 func (s *SessionStore) DeleteExpired(ctx context.Context) error {
 	_, err := s.pool.Exec(ctx, `DELETE FROM sessions WHERE expires_at < now()`)
@@ -3,28 +3,106 @@ package models
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+var (
+	// ErrSourceNotFound is returned when no source has the given id.
+	ErrSourceNotFound = errors.New("source not found")
+	// ErrSourceArchived is returned when activating an archived source.
+	ErrSourceArchived = errors.New("source is archived")
+	// ErrSourceInUse is returned when deleting a source articles are
+	// attributed to.
+	ErrSourceInUse = errors.New("source is referenced by articles")
+)
+
 // Source represents a news or grants feed source configuration.
 type Source struct {
-	ID            uuid.UUID `json:"id"`
-	Name          string    `json:"name"`
-	BaseURL       string    `json:"base_url"`
-	Region        string    `json:"region"`
-	FeedType      string    `json:"feed_type"`
-	FeedURL       string    `json:"feed_url,omitempty"`
-	ListURLs      []string  `json:"list_urls,omitempty"`
-	LinkSelector  string    `json:"link_selector,omitempty"`
-	TitleSelector string    `json:"title_selector,omitempty"`
-	BodySelector  string    `json:"body_selector,omitempty"`
-	DateSelector  string    `json:"date_selector,omitempty"`
-	Active        bool      `json:"active"`
-	CreatedAt     time.Time `json:"created_at"`
+	ID               uuid.UUID `json:"id"`
+	Name             string    `json:"name"`
+	BaseURL          string    `json:"base_url"`
+	Region           string    `json:"region"`
+	FeedType         string    `json:"feed_type"`
+	FeedURL          string    `json:"feed_url,omitempty"`
+	ListURLs         []string  `json:"list_urls,omitempty"`
+	LinkSelector     string    `json:"link_selector,omitempty"`
+	TitleSelector    string    `json:"title_selector,omitempty"`
+	BodySelector     string    `json:"body_selector,omitempty"`
+	DateSelector     string    `json:"date_selector,omitempty"`
+	RenderJS         bool      `json:"render_js"`         // scrape through the headless renderer
+	ArchiveSnapshots bool      `json:"archive_snapshots"` // request a Wayback Machine snapshot of new articles
+	Active           bool      `json:"active"`
+	CreatedAt        time.Time `json:"created_at"`
+
+	// ArchivedAt is set while the source is archived: never ingested and
+	// hidden from pickers, but kept for the articles attributed to it.
+	ArchivedAt *time.Time `json:"archived_at,omitempty"`
+
+	// APIMapping configures feed_type "api" sources.
+	APIMapping *APIMapping `json:"api_mapping,omitempty"`
+
+	// FeedETag and FeedLastModified are the cache validators from the last
+	// successful feed fetch, used for conditional GETs.
+	FeedETag         string `json:"-"`
+	FeedLastModified string `json:"-"`
+}
+
+// APIMapping describes a partner's JSON API: how to authenticate and where
+// each article field sits in the response. Paths are dot-separated keys, with
+// numeric segments indexing arrays (e.g. "data.articles", "images.0.url").
+type APIMapping struct {
+	Items     string  `json:"items"` // path to the article array; "" when the response is the array
+	URL       string  `json:"url"`
+	Title     string  `json:"title"`
+	Body      string  `json:"body"` // full text, plain or HTML
+	Published string  `json:"published,omitempty"`
+	Image     string  `json:"image,omitempty"`
+	Auth      APIAuth `json:"auth"`
+}
+
+// APIAuth is how requests to a partner API authenticate. The credential is
+// read from the environment variable SecretEnv, which must start with
+// PartnerSecretEnvPrefix.
+type APIAuth struct {
+	Type      string `json:"type"`           // "", "bearer", "header", "query", or "basic"
+	Name      string `json:"name,omitempty"` // header or query parameter name for "header" and "query"
+	SecretEnv string `json:"secret_env,omitempty"`
+}
+
+// PartnerSecretEnvPrefix limits which environment variables an API source
+// may read its credential from.
+const PartnerSecretEnvPrefix = "FOLIO_PARTNER_"
+
+// Validate reports the first problem with the mapping, or "".
+func (m *APIMapping) Validate() string {
+	switch {
+	case m.URL == "":
+		return "api_mapping.url is required"
+	case m.Title == "" && m.Body == "":
+		return "api_mapping needs title or body"
+	}
+	switch m.Auth.Type {
+	case "":
+		return ""
+	case "bearer", "basic":
+	case "header", "query":
+		if m.Auth.Name == "" {
+			return "api_mapping.auth.name is required for " + m.Auth.Type + " auth"
+		}
+	default:
+		return "api_mapping.auth.type must be bearer, header, query, or basic"
+	}
+	if !strings.HasPrefix(m.Auth.SecretEnv, PartnerSecretEnvPrefix) {
+		return "api_mapping.auth.secret_env must start with " + PartnerSecretEnvPrefix
+	}
+	return ""
 }
 
 // SourceStore provides data access methods for sources.
@@ -37,25 +115,32 @@ func NewSourceStore(pool *pgxpool.Pool) *SourceStore {
 	return &SourceStore{pool: pool}
 }
 
-// ListAll returns all sources regardless of active status.
+// ListAll returns all sources regardless of active status, archived ones
+// included.
 func (s *SourceStore) ListAll(ctx context.Context) ([]Source, error) {
-	return s.listSources(ctx, false)
+	return s.listSources(ctx, "")
 }
 
-// ListActive returns all sources where active = true.
+// ListUnarchived returns every source that is not archived.
+func (s *SourceStore) ListUnarchived(ctx context.Context) ([]Source, error) {
+	return s.listSources(ctx, "archived_at IS NULL")
+}
+
+// ListActive returns the sources to ingest: active and not archived.
 func (s *SourceStore) ListActive(ctx context.Context) ([]Source, error) {
-	return s.listSources(ctx, true)
+	return s.listSources(ctx, "active = true AND archived_at IS NULL")
 }
 
-func (s *SourceStore) listSources(ctx context.Context, activeOnly bool) ([]Source, error) {
+func (s *SourceStore) listSources(ctx context.Context, where string) ([]Source, error) {
 	query := `
 		SELECT id, name, base_url, region, feed_type, feed_url, list_urls,
 		       link_selector, title_selector, body_selector, date_selector,
-		       active, created_at
+		       render_js, active, created_at, feed_etag, feed_last_modified,
+		       api_mapping, archive_snapshots, archived_at
 		FROM sources
 	`
-	if activeOnly {
-		query += " WHERE active = true"
+	if where != "" {
+		query += " WHERE " + where
 	}
 	query += " ORDER BY name ASC"
 
@@ -73,7 +158,9 @@ func (s *SourceStore) listSources(ctx context.Context, activeOnly bool) ([]Sourc
 		if err := rows.Scan(
 			&src.ID, &src.Name, &src.BaseURL, &src.Region, &src.FeedType,
 			&feedURL, &listURLsJSON, &linkSel, &titleSel,
-			&bodySel, &dateSel, &src.Active, &src.CreatedAt,
+			&bodySel, &dateSel, &src.RenderJS, &src.Active, &src.CreatedAt,
+			&src.FeedETag, &src.FeedLastModified, &src.APIMapping, &src.ArchiveSnapshots,
+			&src.ArchivedAt,
 		); err != nil {
 			return nil, fmt.Errorf("source scan: %w", err)
 		}
@@ -117,13 +204,15 @@ func (s *SourceStore) Create(ctx context.Context, source *Source) error {
 	err = s.pool.QueryRow(ctx, `
 		INSERT INTO sources (id, name, base_url, region, feed_type, feed_url,
 		                     list_urls, link_selector, title_selector,
-		                     body_selector, date_selector, active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		                     body_selector, date_selector, render_js, active,
+		                     api_mapping, archive_snapshots)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
 		RETURNING created_at
 	`,
 		source.ID, source.Name, source.BaseURL, source.Region, source.FeedType,
 		source.FeedURL, listURLsJSON, source.LinkSelector, source.TitleSelector,
-		source.BodySelector, source.DateSelector, source.Active,
+		source.BodySelector, source.DateSelector, source.RenderJS, source.Active,
+		source.APIMapping, source.ArchiveSnapshots,
 	).Scan(&source.CreatedAt)
 	if err != nil {
 		return fmt.Errorf("source create: %w", err)
@@ -131,7 +220,8 @@ func (s *SourceStore) Create(ctx context.Context, source *Source) error {
 	return nil
 }
 
-// Update modifies an existing source.
+// Update modifies an existing source. Changing the feed type or URL clears
+// the stored feed validators. An archived source stays inactive.
 func (s *SourceStore) Update(ctx context.Context, source *Source) error {
 	listURLsJSON, err := json.Marshal(source.ListURLs)
 	if err != nil {
@@ -142,12 +232,18 @@ func (s *SourceStore) Update(ctx context.Context, source *Source) error {
 		UPDATE sources
 		SET name = $1, base_url = $2, region = $3, feed_type = $4, feed_url = $5,
 		    list_urls = $6, link_selector = $7, title_selector = $8,
-		    body_selector = $9, date_selector = $10, active = $11
+		    body_selector = $9, date_selector = $10, active = $11 AND archived_at IS NULL, render_js = $13,
+		    api_mapping = $14, archive_snapshots = $15,
+		    feed_etag = CASE WHEN feed_type = $4 AND feed_url IS NOT DISTINCT FROM $5
+		                     THEN feed_etag ELSE '' END,
+		    feed_last_modified = CASE WHEN feed_type = $4 AND feed_url IS NOT DISTINCT FROM $5
+		                              THEN feed_last_modified ELSE '' END
 		WHERE id = $12
 	`,
 		source.Name, source.BaseURL, source.Region, source.FeedType,
 		source.FeedURL, listURLsJSON, source.LinkSelector, source.TitleSelector,
 		source.BodySelector, source.DateSelector, source.Active, source.ID,
+		source.RenderJS, source.APIMapping, source.ArchiveSnapshots,
 	)
 	if err != nil {
 		return fmt.Errorf("source update: %w", err)
@@ -158,26 +254,152 @@ func (s *SourceStore) Update(ctx context.Context, source *Source) error {
 	return nil
 }
 
-// ToggleActive sets only the active flag on a source without modifying other fields.
+// SourceImportResult reports what a bulk import did.
+type SourceImportResult struct {
+	Created []Source `json:"created"`
+	Skipped []string `json:"skipped"` // feed URLs that already had a source
+}
+
+// ImportFeeds creates feed sources in one transaction, skipping any whose
+// feed URL already belongs to a source or appeared earlier in the import.
+func (s *SourceStore) ImportFeeds(ctx context.Context, sources []Source) (*SourceImportResult, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("source import begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result := &SourceImportResult{Created: []Source{}, Skipped: []string{}}
+	seen := make(map[string]bool)
+	for _, src := range sources {
+		if seen[src.FeedURL] {
+			result.Skipped = append(result.Skipped, src.FeedURL)
+			continue
+		}
+		seen[src.FeedURL] = true
+
+		var exists bool
+		err := tx.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM sources WHERE feed_url = $1)
+		`, src.FeedURL).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("source import check %q: %w", src.FeedURL, err)
+		}
+		if exists {
+			result.Skipped = append(result.Skipped, src.FeedURL)
+			continue
+		}
+
+		err = tx.QueryRow(ctx, `
+			INSERT INTO sources (name, base_url, region, feed_type, feed_url, active)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			RETURNING id, created_at
+		`,
+			src.Name, src.BaseURL, src.Region, src.FeedType, src.FeedURL, src.Active,
+		).Scan(&src.ID, &src.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("source import %q: %w", src.FeedURL, err)
+		}
+		result.Created = append(result.Created, src)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("source import commit: %w", err)
+	}
+	return result, nil
+}
+
+// SetFeedValidators stores the ETag and Last-Modified of a source's latest
+// feed response.
+func (s *SourceStore) SetFeedValidators(ctx context.Context, id uuid.UUID, etag, lastModified string) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE sources SET feed_etag = $2, feed_last_modified = $3 WHERE id = $1
+	`, id, etag, lastModified)
+	if err != nil {
+		return fmt.Errorf("source set feed validators: %w", err)
+	}
+	return nil
+}
+
+// ToggleActive sets only the active flag on a source without modifying other
+// fields. Archived sources cannot be activated.
 func (s *SourceStore) ToggleActive(ctx context.Context, id uuid.UUID, active bool) error {
-	tag, err := s.pool.Exec(ctx, `UPDATE sources SET active = $1 WHERE id = $2`, active, id)
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE sources SET active = $1 WHERE id = $2 AND (NOT $1 OR archived_at IS NULL)
+	`, active, id)
 	if err != nil {
 		return fmt.Errorf("source toggle: %w", err)
 	}
 	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("source not found: %s", id)
+		if err := s.checkExists(ctx, id); err != nil {
+			return err
+		}
+		return ErrSourceArchived
+	}
+	return nil
+}
+
+// Archive deactivates a source and takes it out of ingestion and pickers,
+// keeping it for the articles attributed to it. Archiving an archived source
+// keeps its original date.
+func (s *SourceStore) Archive(ctx context.Context, id uuid.UUID) (time.Time, error) {
+	var archivedAt time.Time
+	err := s.pool.QueryRow(ctx, `
+		UPDATE sources SET active = false, archived_at = COALESCE(archived_at, NOW())
+		WHERE id = $1
+		RETURNING archived_at
+	`, id).Scan(&archivedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, ErrSourceNotFound
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("source archive: %w", err)
+	}
+	return archivedAt, nil
+}
+
+// Unarchive brings an archived source back, inactive until it is toggled on.
+func (s *SourceStore) Unarchive(ctx context.Context, id uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `UPDATE sources SET archived_at = NULL WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("source unarchive: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrSourceNotFound
 	}
 	return nil
 }
 
-// Delete removes a source by ID.
+// Delete removes a source by ID. Sources that articles are attributed to
+// (by source_id or by name) are kept and ErrSourceInUse is returned; archive
+// them instead.
 func (s *SourceStore) Delete(ctx context.Context, id uuid.UUID) error {
-	tag, err := s.pool.Exec(ctx, `DELETE FROM sources WHERE id = $1`, id)
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM sources src
+		WHERE src.id = $1
+		  AND NOT EXISTS (SELECT 1 FROM articles a WHERE a.source_id = src.id OR a.source = src.name)
+	`, id)
 	if err != nil {
 		return fmt.Errorf("source delete: %w", err)
 	}
 	if tag.RowsAffected() == 0 {
-		return fmt.Errorf("source not found: %s", id)
+		if err := s.checkExists(ctx, id); err != nil {
+			return err
+		}
+		return ErrSourceInUse
+	}
+	return nil
+}
+
+// checkExists returns ErrSourceNotFound when there is no source with the id.
+func (s *SourceStore) checkExists(ctx context.Context, id uuid.UUID) error {
+	var exists bool
+	err := s.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM sources WHERE id = $1)`, id).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("source exists: %w", err)
+	}
+	if !exists {
+		return ErrSourceNotFound
 	}
 	return nil
 }
@@ -0,0 +1,154 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SourceBundle is a predefined set of sources that can be installed together.
+type SourceBundle struct {
+	Slug        string    `json:"slug"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Sources     []Source  `json:"sources"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// BundleInstallResult reports what installing a bundle did.
+type BundleInstallResult struct {
+	Bundle  string   `json:"bundle"`
+	Created []Source `json:"created"`
+	Skipped []string `json:"skipped"` // names of sources that already existed
+}
+
+// SourceBundleStore provides data access methods for source bundles.
+type SourceBundleStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewSourceBundleStore creates a new SourceBundleStore.
+func NewSourceBundleStore(pool *pgxpool.Pool) *SourceBundleStore {
+	return &SourceBundleStore{pool: pool}
+}
+
+// List returns all bundles ordered by name.
+func (s *SourceBundleStore) List(ctx context.Context) ([]SourceBundle, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT slug, name, description, sources, created_at
+		FROM source_bundles
+		ORDER BY name ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("source bundle list: %w", err)
+	}
+	defer rows.Close()
+
+	var bundles []SourceBundle
+	for rows.Next() {
+		b, err := scanSourceBundle(rows)
+		if err != nil {
+			return nil, err
+		}
+		bundles = append(bundles, *b)
+	}
+	return bundles, rows.Err()
+}
+
+// GetBySlug returns a bundle, or nil if none exists with that slug.
+func (s *SourceBundleStore) GetBySlug(ctx context.Context, slug string) (*SourceBundle, error) {
+	row := s.pool.QueryRow(ctx, `
+		SELECT slug, name, description, sources, created_at
+		FROM source_bundles
+		WHERE slug = $1
+	`, slug)
+	b, err := scanSourceBundle(row)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	return b, err
+}
+
+func scanSourceBundle(row pgx.Row) (*SourceBundle, error) {
+	var b SourceBundle
+	var sourcesJSON []byte
+	if err := row.Scan(&b.Slug, &b.Name, &b.Description, &sourcesJSON, &b.CreatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("source bundle scan: %w", err)
+	}
+	if err := json.Unmarshal(sourcesJSON, &b.Sources); err != nil {
+		return nil, fmt.Errorf("source bundle unmarshal sources: %w", err)
+	}
+	if b.Sources == nil {
+		b.Sources = []Source{}
+	}
+	return &b, nil
+}
+
+// Install creates the bundle's sources in one transaction. Sources already
+// present — matched by name, or by feed URL when the template has one — are
+// skipped, so installing a bundle twice is harmless. Installed sources are
+// active.
+func (s *SourceBundleStore) Install(ctx context.Context, bundle *SourceBundle) (*BundleInstallResult, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("source bundle install begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result := &BundleInstallResult{Bundle: bundle.Slug, Created: []Source{}, Skipped: []string{}}
+	for _, tmpl := range bundle.Sources {
+		var exists bool
+		err := tx.QueryRow(ctx, `
+			SELECT EXISTS (
+				SELECT 1 FROM sources
+				WHERE lower(name) = lower($1) OR ($2 <> '' AND feed_url = $2)
+			)
+		`, tmpl.Name, tmpl.FeedURL).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("source bundle install check %q: %w", tmpl.Name, err)
+		}
+		if exists {
+			result.Skipped = append(result.Skipped, tmpl.Name)
+			continue
+		}
+
+		src := tmpl
+		src.Active = true
+		src.FeedType = strings.ToLower(src.FeedType)
+		listURLsJSON, err := json.Marshal(src.ListURLs)
+		if err != nil {
+			return nil, fmt.Errorf("source marshal list_urls: %w", err)
+		}
+		err = tx.QueryRow(ctx, `
+			INSERT INTO sources (name, base_url, region, feed_type, feed_url,
+			                     list_urls, link_selector, title_selector,
+			                     body_selector, date_selector, render_js, active,
+			                     api_mapping, archive_snapshots)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+			RETURNING id, created_at
+		`,
+			src.Name, src.BaseURL, src.Region, src.FeedType, src.FeedURL,
+			listURLsJSON, src.LinkSelector, src.TitleSelector,
+			src.BodySelector, src.DateSelector, src.RenderJS, src.Active,
+			src.APIMapping, src.ArchiveSnapshots,
+		).Scan(&src.ID, &src.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("source bundle install %q: %w", src.Name, err)
+		}
+		result.Created = append(result.Created, src)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("source bundle install commit: %w", err)
+	}
+	return result, nil
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrTagExists is returned by TagStore.Create when the name is taken.
+var ErrTagExists = errors.New("tag already exists")
+
+// Tag is a topic in the classification taxonomy. Only active tags are
+// offered to the classifier; existing articles keep retired tags.
+type Tag struct {
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// TagStore provides data access methods for the tag taxonomy.
+type TagStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewTagStore creates a new TagStore.
+func NewTagStore(pool *pgxpool.Pool) *TagStore {
+	return &TagStore{pool: pool}
+}
+
+// List returns all tags ordered by name; if activeOnly is set, only active
+// ones.
+func (s *TagStore) List(ctx context.Context, activeOnly bool) ([]Tag, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT name, description, active, created_at
+		FROM tags
+		WHERE ($1 = false OR active = true)
+		ORDER BY name
+	`, activeOnly)
+	if err != nil {
+		return nil, fmt.Errorf("tag list: %w", err)
+	}
+	defer rows.Close()
+
+	var tags []Tag
+	for rows.Next() {
+		var t Tag
+		if err := rows.Scan(&t.Name, &t.Description, &t.Active, &t.CreatedAt); err != nil {
+			return nil, fmt.Errorf("tag scan: %w", err)
+		}
+		tags = append(tags, t)
+	}
+	return tags, rows.Err()
+}
+
+// TaxonomyTags returns the active tags mapped to their descriptions. It
+// implements ai.TaxonomySource.
+func (s *TagStore) TaxonomyTags(ctx context.Context) (map[string]string, error) {
+	tags, err := s.List(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]string, len(tags))
+	for _, t := range tags {
+		m[t.Name] = t.Description
+	}
+	return m, nil
+}
+
+// Create inserts a tag. It returns ErrTagExists if the name is taken.
+func (s *TagStore) Create(ctx context.Context, t *Tag) error {
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO tags (name, description, active)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (name) DO NOTHING
+		RETURNING created_at
+	`, t.Name, t.Description, t.Active).Scan(&t.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrTagExists
+	}
+	if err != nil {
+		return fmt.Errorf("tag create: %w", err)
+	}
+	return nil
+}
+
+// Update changes a tag's description and active flag.
+func (s *TagStore) Update(ctx context.Context, t *Tag) error {
+	err := s.pool.QueryRow(ctx, `
+		UPDATE tags SET description = $2, active = $3
+		WHERE name = $1
+		RETURNING created_at
+	`, t.Name, t.Description, t.Active).Scan(&t.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("tag not found: %s", t.Name)
+	}
+	if err != nil {
+		return fmt.Errorf("tag update: %w", err)
+	}
+	return nil
+}
+
+// Delete removes a tag from the taxonomy. Articles already tagged with it
+// are left as they are.
+func (s *TagStore) Delete(ctx context.Context, name string) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM tags WHERE name = $1`, name)
+	if err != nil {
+		return fmt.Errorf("tag delete: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("tag not found: %s", name)
+	}
+	return nil
+}
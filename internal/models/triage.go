@@ -0,0 +1,216 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// TriageSuggestion is the save/trash action the model proposes for an inbox
+// item. It is only applied when an analyst accepts it.
+type TriageSuggestion struct {
+	ArticleID uuid.UUID `json:"article_id"`
+	Action    string    `json:"action"` // save, trash
+	Rationale string    `json:"rationale"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Article is the inbox item the suggestion is for; set by ListPending.
+	Article *Article `json:"article,omitempty"`
+}
+
+// ValidTriageAction reports whether s is an action a suggestion can propose.
+func ValidTriageAction(s string) bool {
+	return s == "save" || s == "trash"
+}
+
+// TriageSuggestionStore provides data access methods for triage suggestions.
+type TriageSuggestionStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewTriageSuggestionStore creates a new TriageSuggestionStore.
+func NewTriageSuggestionStore(pool *pgxpool.Pool) *TriageSuggestionStore {
+	return &TriageSuggestionStore{pool: pool}
+}
+
+// Create stores a suggestion, replacing any earlier one for the article.
+func (s *TriageSuggestionStore) Create(ctx context.Context, t *TriageSuggestion) error {
+	err := s.pool.QueryRow(ctx, `
+		INSERT INTO triage_suggestions (article_id, action, rationale)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (article_id) DO UPDATE
+		SET action = EXCLUDED.action, rationale = EXCLUDED.rationale,
+		    dismissed = false, created_at = NOW()
+		RETURNING created_at
+	`, t.ArticleID, t.Action, t.Rationale).Scan(&t.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("triage suggestion create: %w", err)
+	}
+	return nil
+}
+
+// ListPending returns the suggestions for items still in the inbox that have
+// not been dismissed, newest items first. An empty action matches both.
+func (s *TriageSuggestionStore) ListPending(ctx context.Context, action string, limit, offset int) ([]TriageSuggestion, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT a.id, a.title, a.source, a.url, a.canonical_url, a.region, a.published_at,
+		       a.clean_text, a.summary, a.image_url, a.status, a.pinned, a.evidence_policy,
+		       a.evidence_expires_at, a.tags, a.scope, a.language, a.archive_url, a.tag_source, a.created_at,
+		       t.action, t.rationale, t.created_at
+		FROM triage_suggestions t
+		JOIN articles a ON a.id = t.article_id
+		WHERE a.status = 'inbox' AND t.dismissed = false
+		  AND ($1 = '' OR t.action = $1)
+		ORDER BY a.published_at DESC NULLS LAST, a.created_at DESC, a.id DESC
+		LIMIT $2 OFFSET $3
+	`, action, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("triage suggestion list: %w", err)
+	}
+	defer rows.Close()
+
+	var suggestions []TriageSuggestion
+	for rows.Next() {
+		var t TriageSuggestion
+		a := scanArticleFromRow(triageRow{rows, &t})
+		if a == nil {
+			return nil, fmt.Errorf("triage suggestion scan: failed")
+		}
+		t.ArticleID = a.ID
+		t.Article = a
+		suggestions = append(suggestions, t)
+	}
+	return suggestions, rows.Err()
+}
+
+// triageRow adapts a row with trailing suggestion columns to
+// scanArticleFromRow.
+type triageRow struct {
+	row scannable
+	t   *TriageSuggestion
+}
+
+func (r triageRow) Scan(dest ...any) error {
+	return r.row.Scan(append(dest, &r.t.Action, &r.t.Rationale, &r.t.CreatedAt)...)
+}
+
+// ListUnsuggested returns enriched inbox items created within the last
+// maxAgeDays that have no suggestion yet, newest first.
+func (s *TriageSuggestionStore) ListUnsuggested(ctx context.Context, maxAgeDays, limit int) ([]Article, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, title, source, url, canonical_url, region, published_at,
+		       clean_text, summary, image_url, status, pinned, evidence_policy,
+		       evidence_expires_at, tags, scope, language, archive_url, tag_source, created_at
+		FROM articles a
+		WHERE status = 'inbox' AND summary != ''
+		  AND created_at > NOW() - make_interval(days => $1)
+		  AND NOT EXISTS (SELECT 1 FROM triage_suggestions t WHERE t.article_id = a.id)
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, maxAgeDays, limit)
+	if err != nil {
+		return nil, fmt.Errorf("triage list unsuggested: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []Article
+	for rows.Next() {
+		a := scanArticleFromRow(rows)
+		if a == nil {
+			return nil, fmt.Errorf("triage unsuggested scan: failed")
+		}
+		articles = append(articles, *a)
+	}
+	return articles, rows.Err()
+}
+
+// Accept applies pending suggestions: items are moved to the suggested
+// status (trashed items get the default 3-month retention, as when trashed by
+// hand) and the suggestions are removed. With ids nil every pending
+// suggestion matching action ("" for both) is accepted. Items that have left
+// the inbox since are skipped. It returns how many items were saved and
+// trashed.
+func (s *TriageSuggestionStore) Accept(ctx context.Context, ids []uuid.UUID, action string) (saved, trashed int, err error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return 0, 0, fmt.Errorf("triage accept: begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		UPDATE articles a
+		SET status = CASE t.action WHEN 'save' THEN 'saved' ELSE 'trashed' END,
+		    evidence_policy = CASE t.action WHEN 'trash' THEN 'ret_3m' ELSE a.evidence_policy END,
+		    evidence_expires_at = CASE t.action WHEN 'trash' THEN NOW() + INTERVAL '90 days' ELSE a.evidence_expires_at END,
+		    deleted_at = CASE t.action WHEN 'trash' THEN NOW() END,
+		    pre_trash_evidence_policy = CASE t.action WHEN 'trash' THEN a.evidence_policy END,
+		    pre_trash_expires_at = CASE t.action WHEN 'trash' THEN a.evidence_expires_at END
+		FROM triage_suggestions t
+		WHERE t.article_id = a.id AND a.status = 'inbox' AND t.dismissed = false
+		  AND ($1::uuid[] IS NULL OR a.id = ANY($1))
+		  AND ($2 = '' OR t.action = $2)
+		RETURNING a.id, t.action
+	`, ids, action)
+	if err != nil {
+		return 0, 0, fmt.Errorf("triage accept: %w", err)
+	}
+	var accepted []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		var act string
+		if err := rows.Scan(&id, &act); err != nil {
+			rows.Close()
+			return 0, 0, fmt.Errorf("triage accept scan: %w", err)
+		}
+		accepted = append(accepted, id)
+		if act == "save" {
+			saved++
+		} else {
+			trashed++
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, 0, fmt.Errorf("triage accept: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO article_status_history (article_id, from_status, to_status, reason)
+		SELECT id, 'inbox', status, 'triage' FROM articles WHERE id = ANY($1)
+	`, accepted); err != nil {
+		return 0, 0, fmt.Errorf("triage accept: history: %w", err)
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM triage_suggestions WHERE article_id = ANY($1)`, accepted); err != nil {
+		return 0, 0, fmt.Errorf("triage accept: delete: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, 0, fmt.Errorf("triage accept: commit: %w", err)
+	}
+	return saved, trashed, nil
+}
+
+// Dismiss rejects the suggestion for an article. The row is kept so the
+// item is not suggested again.
+func (s *TriageSuggestionStore) Dismiss(ctx context.Context, articleID uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE triage_suggestions SET dismissed = true WHERE article_id = $1
+	`, articleID)
+	if err != nil {
+		return fmt.Errorf("triage dismiss: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("triage suggestion not found: %s", articleID)
+	}
+	return nil
+}
@@ -17,8 +17,11 @@ type User struct {
 	Email        string    `json:"email"`
 	PasswordHash string    `json:"-"` // never serialize to JSON
 	Role         string    `json:"role"`
-	FeedToken    *string   `json:"-"` // RSS feed token, never serialize
+	Active       bool      `json:"active"`   // deactivated users cannot log in
+	FeedToken    *string   `json:"-"`        // RSS feed token, never serialize
+	Language     string    `json:"language"` // "es", "en", or "" to follow Accept-Language
 	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
 }
 
 // UserStore provides data access methods for users.
@@ -35,10 +38,10 @@ func NewUserStore(pool *pgxpool.Pool) *UserStore {
 func (s *UserStore) GetByEmail(ctx context.Context, email string) (*User, error) {
 	var u User
 	err := s.pool.QueryRow(ctx, `
-		SELECT id, email, password_hash, role, created_at
+		SELECT id, email, password_hash, role, active, language, created_at, updated_at
 		FROM users
 		WHERE email = $1
-	`, email).Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role, &u.CreatedAt)
+	`, email).Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role, &u.Active, &u.Language, &u.CreatedAt, &u.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("user get by email: %w", err)
 	}
@@ -49,10 +52,10 @@ func (s *UserStore) GetByEmail(ctx context.Context, email string) (*User, error)
 func (s *UserStore) GetByID(ctx context.Context, id uuid.UUID) (*User, error) {
 	var u User
 	err := s.pool.QueryRow(ctx, `
-		SELECT id, email, password_hash, role, created_at
+		SELECT id, email, password_hash, role, active, language, created_at, updated_at
 		FROM users
 		WHERE id = $1
-	`, id).Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role, &u.CreatedAt)
+	`, id).Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role, &u.Active, &u.Language, &u.CreatedAt, &u.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("user get by id: %w", err)
 	}
@@ -67,26 +70,113 @@ func (s *UserStore) Create(ctx context.Context, user *User) error {
 	if user.Role == "" {
 		user.Role = "member"
 	}
+	user.Active = true
 
 	err := s.pool.QueryRow(ctx, `
 		INSERT INTO users (id, email, password_hash, role)
 		VALUES ($1, $2, $3, $4)
-		RETURNING created_at
-	`, user.ID, user.Email, user.PasswordHash, user.Role).Scan(&user.CreatedAt)
+		RETURNING created_at, updated_at
+	`, user.ID, user.Email, user.PasswordHash, user.Role).Scan(&user.CreatedAt, &user.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("user create: %w", err)
 	}
 	return nil
 }
 
-// GetByFeedToken returns a user by their RSS feed token.
+// List returns all users ordered by email.
+func (s *UserStore) List(ctx context.Context) ([]User, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, email, role, active, language, created_at, updated_at
+		FROM users
+		ORDER BY email ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("user list: %w", err)
+	}
+	defer rows.Close()
+
+	var users []User
+	for rows.Next() {
+		var u User
+		if err := rows.Scan(&u.ID, &u.Email, &u.Role, &u.Active, &u.Language, &u.CreatedAt, &u.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("user list scan: %w", err)
+		}
+		users = append(users, u)
+	}
+	return users, rows.Err()
+}
+
+// Update saves a user's role, active flag, and password hash.
+func (s *UserStore) Update(ctx context.Context, user *User) error {
+	err := s.pool.QueryRow(ctx, `
+		UPDATE users
+		SET role = $2, active = $3, password_hash = $4, updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at
+	`, user.ID, user.Role, user.Active, user.PasswordHash).Scan(&user.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("user update: %w", err)
+	}
+	return nil
+}
+
+// SetLanguage saves the language the user wants API messages and
+// notifications in; "" follows the browser's Accept-Language.
+func (s *UserStore) SetLanguage(ctx context.Context, userID uuid.UUID, language string) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE users SET language = $2, updated_at = NOW() WHERE id = $1
+	`, userID, language)
+	if err != nil {
+		return fmt.Errorf("user set language: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("user not found: %s", userID)
+	}
+	return nil
+}
+
+// Language returns the user's language preference, "" when unset.
+func (s *UserStore) Language(ctx context.Context, userID uuid.UUID) (string, error) {
+	var language string
+	err := s.pool.QueryRow(ctx, `SELECT language FROM users WHERE id = $1`, userID).Scan(&language)
+	if err != nil {
+		return "", fmt.Errorf("user language: %w", err)
+	}
+	return language, nil
+}
+
+// Delete removes a user. Their sessions, chats, watchlist, notes and other
+// owned data go with them (ON DELETE CASCADE); records kept for others, such
+// as the audit log, keep the user's email but lose the link.
+func (s *UserStore) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM users WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("user delete: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("user not found: %s", id)
+	}
+	return nil
+}
+
+// CountActiveAdmins returns the number of active admin users.
+func (s *UserStore) CountActiveAdmins(ctx context.Context) (int, error) {
+	var n int
+	err := s.pool.QueryRow(ctx, `SELECT COUNT(*) FROM users WHERE role = 'admin' AND active = true`).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("user count admins: %w", err)
+	}
+	return n, nil
+}
+
+// GetByFeedToken returns an active user by their RSS feed token.
 func (s *UserStore) GetByFeedToken(ctx context.Context, token string) (*User, error) {
 	var u User
 	err := s.pool.QueryRow(ctx, `
-		SELECT id, email, password_hash, role, feed_token, created_at
+		SELECT id, email, password_hash, role, active, language, feed_token, created_at, updated_at
 		FROM users
-		WHERE feed_token = $1
-	`, token).Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role, &u.FeedToken, &u.CreatedAt)
+		WHERE feed_token = $1 AND active = true
+	`, token).Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role, &u.Active, &u.Language, &u.FeedToken, &u.CreatedAt, &u.UpdatedAt)
 	if err != nil {
 		return nil, fmt.Errorf("user get by feed token: %w", err)
 	}
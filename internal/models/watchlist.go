@@ -2,14 +2,25 @@ package models
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"regexp"
+	"strings"
 	"time"
+	"unicode"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// duplicateHitWindow is how far back a new hit is compared against earlier
+// hits for the same org when checking for syndicated (same-content) copies.
+const duplicateHitWindow = 7 * 24 * time.Hour
+
 // WatchlistOrg represents an NGO or organization being monitored.
 type WatchlistOrg struct {
 	ID              uuid.UUID `json:"id"`
@@ -18,9 +29,19 @@ type WatchlistOrg struct {
 	Website         string    `json:"website"`
 	Keywords        []string  `json:"keywords"`
 	YouTubeChannels []string  `json:"youtube_channels"`
-	Active          bool      `json:"active"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	// SocialPages are public Facebook pages and Instagram business accounts
+	// polled through the Graph API, as "facebook:<page>" or
+	// "instagram:<account id>".
+	SocialPages []string `json:"social_pages"`
+	Active      bool     `json:"active"`
+	Priority    int      `json:"priority"` // 0 low, 1 normal, 2 high; low-priority orgs lose search budget first
+	// SemanticWatch also matches new articles whose embedding is close to the
+	// org's profile, catching mentions that use none of the keywords.
+	// SemanticThreshold is the minimum cosine similarity; 0 uses the default.
+	SemanticWatch     bool      `json:"semantic_watch"`
+	SemanticThreshold float64   `json:"semantic_threshold"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 // WatchlistHit represents a single mention found by a scanning agent.
@@ -37,6 +58,38 @@ type WatchlistHit struct {
 	AIDraft    *string   `json:"ai_draft"`
 	Seen       bool      `json:"seen"`
 	CreatedAt  time.Time `json:"created_at"`
+
+	// ContentHash is a hash of the normalized snippet/title, used to detect
+	// the same story syndicated under different URLs.
+	ContentHash string `json:"content_hash,omitempty"`
+	// DupCount is the number of syndicated copies folded into this hit.
+	DupCount int `json:"dup_count"`
+
+	// DraftStatus tracks the response draft through review: draft, edited,
+	// approved or sent; empty when the hit has no draft. DraftText is the
+	// human revision; AIDraft keeps the model's original.
+	DraftStatus     string     `json:"draft_status,omitempty"`
+	DraftText       *string    `json:"draft_text"`
+	DraftUpdatedAt  *time.Time `json:"draft_updated_at,omitempty"`
+	DraftApprovedAt *time.Time `json:"draft_approved_at,omitempty"`
+	DraftSentAt     *time.Time `json:"draft_sent_at,omitempty"`
+
+	// ArticleID is the article collected from the hit, if any.
+	ArticleID *uuid.UUID `json:"article_id,omitempty"`
+
+	// SentimentVerifiedAt is set when a person confirmed or overrode the
+	// sentiment; the model no longer reclassifies such hits.
+	SentimentVerifiedAt *time.Time `json:"sentiment_verified_at,omitempty"`
+
+	// Orgs lists every org of the user that found the hit, the primary
+	// (OrgID) first; set by ListByUser and ListTimeline.
+	Orgs []HitOrg `json:"orgs,omitempty"`
+}
+
+// HitOrg is an org a watchlist hit is linked to.
+type HitOrg struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
 }
 
 // ── WatchlistOrgStore ────────────────────────────────────────────
@@ -51,7 +104,8 @@ func NewWatchlistOrgStore(pool *pgxpool.Pool) *WatchlistOrgStore {
 
 func (s *WatchlistOrgStore) ListByUser(ctx context.Context, userID uuid.UUID) ([]WatchlistOrg, error) {
 	rows, err := s.pool.Query(ctx, `
-		SELECT id, user_id, name, website, keywords, youtube_channels, active, created_at, updated_at
+		SELECT id, user_id, name, website, keywords, youtube_channels, social_pages, active, priority,
+		       semantic_watch, semantic_threshold, created_at, updated_at
 		FROM watchlist_orgs
 		WHERE user_id = $1
 		ORDER BY name ASC
@@ -64,22 +118,29 @@ func (s *WatchlistOrgStore) ListByUser(ctx context.Context, userID uuid.UUID) ([
 	var orgs []WatchlistOrg
 	for rows.Next() {
 		var o WatchlistOrg
-		var kwRaw, ytRaw []byte
-		if err := rows.Scan(&o.ID, &o.UserID, &o.Name, &o.Website, &kwRaw, &ytRaw, &o.Active, &o.CreatedAt, &o.UpdatedAt); err != nil {
+		var kwRaw, ytRaw, spRaw []byte
+		if err := rows.Scan(&o.ID, &o.UserID, &o.Name, &o.Website, &kwRaw, &ytRaw, &spRaw, &o.Active, &o.Priority,
+			&o.SemanticWatch, &o.SemanticThreshold, &o.CreatedAt, &o.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("watchlist orgs scan: %w", err)
 		}
 		o.Keywords = scanJSONStringSlice(kwRaw)
 		o.YouTubeChannels = scanJSONStringSlice(ytRaw)
+		o.SocialPages = scanJSONStringSlice(spRaw)
+		o.SocialPages = scanJSONStringSlice(spRaw)
 		orgs = append(orgs, o)
 	}
 	return orgs, rows.Err()
 }
 
+// ListActive returns active orgs, highest priority first so they are scanned
+// before search budgets run low.
 func (s *WatchlistOrgStore) ListActive(ctx context.Context) ([]WatchlistOrg, error) {
 	rows, err := s.pool.Query(ctx, `
-		SELECT id, user_id, name, website, keywords, youtube_channels, active, created_at, updated_at
+		SELECT id, user_id, name, website, keywords, youtube_channels, social_pages, active, priority,
+		       semantic_watch, semantic_threshold, created_at, updated_at
 		FROM watchlist_orgs
 		WHERE active = true
+		ORDER BY priority DESC, name ASC
 	`)
 	if err != nil {
 		return nil, fmt.Errorf("watchlist orgs list active: %w", err)
@@ -89,17 +150,40 @@ func (s *WatchlistOrgStore) ListActive(ctx context.Context) ([]WatchlistOrg, err
 	var orgs []WatchlistOrg
 	for rows.Next() {
 		var o WatchlistOrg
-		var kwRaw, ytRaw []byte
-		if err := rows.Scan(&o.ID, &o.UserID, &o.Name, &o.Website, &kwRaw, &ytRaw, &o.Active, &o.CreatedAt, &o.UpdatedAt); err != nil {
+		var kwRaw, ytRaw, spRaw []byte
+		if err := rows.Scan(&o.ID, &o.UserID, &o.Name, &o.Website, &kwRaw, &ytRaw, &spRaw, &o.Active, &o.Priority,
+			&o.SemanticWatch, &o.SemanticThreshold, &o.CreatedAt, &o.UpdatedAt); err != nil {
 			return nil, fmt.Errorf("watchlist orgs scan: %w", err)
 		}
 		o.Keywords = scanJSONStringSlice(kwRaw)
 		o.YouTubeChannels = scanJSONStringSlice(ytRaw)
+		o.SocialPages = scanJSONStringSlice(spRaw)
+		o.SocialPages = scanJSONStringSlice(spRaw)
 		orgs = append(orgs, o)
 	}
 	return orgs, rows.Err()
 }
 
+// GetByID returns an org by ID.
+func (s *WatchlistOrgStore) GetByID(ctx context.Context, id uuid.UUID) (*WatchlistOrg, error) {
+	var o WatchlistOrg
+	var kwRaw, ytRaw, spRaw []byte
+	err := s.pool.QueryRow(ctx, `
+		SELECT id, user_id, name, website, keywords, youtube_channels, social_pages, active, priority,
+		       semantic_watch, semantic_threshold, created_at, updated_at
+		FROM watchlist_orgs
+		WHERE id = $1
+	`, id).Scan(&o.ID, &o.UserID, &o.Name, &o.Website, &kwRaw, &ytRaw, &spRaw, &o.Active, &o.Priority,
+		&o.SemanticWatch, &o.SemanticThreshold, &o.CreatedAt, &o.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist org get: %w", err)
+	}
+	o.Keywords = scanJSONStringSlice(kwRaw)
+	o.YouTubeChannels = scanJSONStringSlice(ytRaw)
+	o.SocialPages = scanJSONStringSlice(spRaw)
+	return &o, nil
+}
+
 func (s *WatchlistOrgStore) Create(ctx context.Context, org *WatchlistOrg) error {
 	if org.ID == uuid.Nil {
 		org.ID = uuid.New()
@@ -112,18 +196,105 @@ func (s *WatchlistOrgStore) Create(ctx context.Context, org *WatchlistOrg) error
 	if err != nil {
 		return fmt.Errorf("watchlist org create: marshal youtube: %w", err)
 	}
+	if org.SocialPages == nil {
+		org.SocialPages = []string{}
+	}
+	spJSON, err := json.Marshal(org.SocialPages)
+	if err != nil {
+		return fmt.Errorf("watchlist org create: marshal social pages: %w", err)
+	}
 
 	err = s.pool.QueryRow(ctx, `
-		INSERT INTO watchlist_orgs (id, user_id, name, website, keywords, youtube_channels, active)
-		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		INSERT INTO watchlist_orgs (id, user_id, name, website, keywords, youtube_channels, social_pages, active, priority,
+		                            semantic_watch, semantic_threshold)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
 		RETURNING created_at, updated_at
-	`, org.ID, org.UserID, org.Name, org.Website, kwJSON, ytJSON, org.Active).Scan(&org.CreatedAt, &org.UpdatedAt)
+	`, org.ID, org.UserID, org.Name, org.Website, kwJSON, ytJSON, spJSON, org.Active, org.Priority,
+		org.SemanticWatch, org.SemanticThreshold).Scan(&org.CreatedAt, &org.UpdatedAt)
 	if err != nil {
 		return fmt.Errorf("watchlist org create: %w", err)
 	}
 	return nil
 }
 
+// WatchlistImportResult reports what a bulk org import did.
+type WatchlistImportResult struct {
+	Created []WatchlistOrg `json:"created"`
+	Skipped []string       `json:"skipped"` // names the user already watches
+}
+
+// Import creates orgs for a user in one transaction, skipping any whose name
+// (case-insensitively) the user already watches or that appeared earlier in
+// the import. Imported orgs are active.
+func (s *WatchlistOrgStore) Import(ctx context.Context, userID uuid.UUID, orgs []WatchlistOrg) (*WatchlistImportResult, error) {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist org import begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	result := &WatchlistImportResult{Created: []WatchlistOrg{}, Skipped: []string{}}
+	seen := make(map[string]bool)
+	for _, org := range orgs {
+		key := strings.ToLower(org.Name)
+		if seen[key] {
+			result.Skipped = append(result.Skipped, org.Name)
+			continue
+		}
+		seen[key] = true
+
+		var exists bool
+		err := tx.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM watchlist_orgs WHERE user_id = $1 AND lower(name) = lower($2))
+		`, userID, org.Name).Scan(&exists)
+		if err != nil {
+			return nil, fmt.Errorf("watchlist org import check %q: %w", org.Name, err)
+		}
+		if exists {
+			result.Skipped = append(result.Skipped, org.Name)
+			continue
+		}
+
+		org.ID = uuid.New()
+		org.UserID = userID
+		org.Active = true
+		kwJSON, err := json.Marshal(org.Keywords)
+		if err != nil {
+			return nil, fmt.Errorf("watchlist org import: marshal keywords: %w", err)
+		}
+		ytJSON, err := json.Marshal(org.YouTubeChannels)
+		if err != nil {
+			return nil, fmt.Errorf("watchlist org import: marshal youtube: %w", err)
+		}
+		if org.SocialPages == nil {
+			org.SocialPages = []string{}
+		}
+		spJSON, err := json.Marshal(org.SocialPages)
+		if err != nil {
+			return nil, fmt.Errorf("watchlist org import: marshal social pages: %w", err)
+		}
+		err = tx.QueryRow(ctx, `
+			INSERT INTO watchlist_orgs (id, user_id, name, website, keywords, youtube_channels, social_pages, active, priority,
+			                            semantic_watch, semantic_threshold)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			RETURNING created_at, updated_at
+		`, org.ID, org.UserID, org.Name, org.Website, kwJSON, ytJSON, spJSON, org.Active, org.Priority,
+			org.SemanticWatch, org.SemanticThreshold).Scan(&org.CreatedAt, &org.UpdatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("watchlist org import %q: %w", org.Name, err)
+		}
+		result.Created = append(result.Created, org)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("watchlist org import commit: %w", err)
+	}
+	return result, nil
+}
+
+// Update saves an org. A negative Priority leaves the stored priority as is,
+// and nil SocialPages leave the stored pages; the stored values are read back
+// into org. The semantic watch settings are changed with SetSemanticWatch.
 func (s *WatchlistOrgStore) Update(ctx context.Context, org *WatchlistOrg) error {
 	kwJSON, err := json.Marshal(org.Keywords)
 	if err != nil {
@@ -133,30 +304,84 @@ func (s *WatchlistOrgStore) Update(ctx context.Context, org *WatchlistOrg) error
 	if err != nil {
 		return fmt.Errorf("watchlist org update: marshal youtube: %w", err)
 	}
+	var spJSON []byte
+	if org.SocialPages != nil {
+		if spJSON, err = json.Marshal(org.SocialPages); err != nil {
+			return fmt.Errorf("watchlist org update: marshal social pages: %w", err)
+		}
+	}
 
-	tag, err := s.pool.Exec(ctx, `
+	var spRaw []byte
+	err = s.pool.QueryRow(ctx, `
 		UPDATE watchlist_orgs
-		SET name = $2, website = $3, keywords = $4, youtube_channels = $5, active = $6, updated_at = NOW()
+		SET name = $2, website = $3, keywords = $4, youtube_channels = $5, active = $6,
+		    priority = CASE WHEN $7 < 0 THEN priority ELSE $7 END,
+		    social_pages = COALESCE($8::jsonb, social_pages), updated_at = NOW()
 		WHERE id = $1
-	`, org.ID, org.Name, org.Website, kwJSON, ytJSON, org.Active)
+		RETURNING priority, social_pages, semantic_watch, semantic_threshold
+	`, org.ID, org.Name, org.Website, kwJSON, ytJSON, org.Active, org.Priority, spJSON).Scan(&org.Priority, &spRaw,
+		&org.SemanticWatch, &org.SemanticThreshold)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("watchlist org not found: %s", org.ID)
+	}
 	if err != nil {
 		return fmt.Errorf("watchlist org update: %w", err)
 	}
-	if tag.RowsAffected() == 0 {
+	org.SocialPages = scanJSONStringSlice(spRaw)
+	return nil
+}
+
+// SetSemanticWatch turns semantic watch on or off and sets its threshold; a
+// nil argument leaves that setting as is. The stored settings are read back
+// into org.
+func (s *WatchlistOrgStore) SetSemanticWatch(ctx context.Context, org *WatchlistOrg, enabled *bool, threshold *float64) error {
+	err := s.pool.QueryRow(ctx, `
+		UPDATE watchlist_orgs
+		SET semantic_watch = COALESCE($2, semantic_watch),
+		    semantic_threshold = COALESCE($3, semantic_threshold), updated_at = NOW()
+		WHERE id = $1
+		RETURNING semantic_watch, semantic_threshold, updated_at
+	`, org.ID, enabled, threshold).Scan(&org.SemanticWatch, &org.SemanticThreshold, &org.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
 		return fmt.Errorf("watchlist org not found: %s", org.ID)
 	}
+	if err != nil {
+		return fmt.Errorf("watchlist org set semantic watch: %w", err)
+	}
 	return nil
 }
 
+// Delete removes an org. Hits it shares with other orgs are kept and move
+// to the org linked to them earliest; the rest are deleted with it.
 func (s *WatchlistOrgStore) Delete(ctx context.Context, id uuid.UUID) error {
-	tag, err := s.pool.Exec(ctx, `DELETE FROM watchlist_orgs WHERE id = $1`, id)
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("watchlist org delete: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE watchlist_hits wh SET org_id = next.org_id
+		FROM (
+			SELECT DISTINCT ON (l.hit_id) l.hit_id, l.org_id
+			FROM watchlist_hit_orgs l
+			JOIN watchlist_hits h ON h.id = l.hit_id
+			WHERE h.org_id = $1 AND l.org_id <> $1
+			ORDER BY l.hit_id, l.linked_at ASC
+		) next
+		WHERE wh.id = next.hit_id
+	`, id); err != nil {
+		return fmt.Errorf("watchlist org delete: move shared hits: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `DELETE FROM watchlist_orgs WHERE id = $1`, id)
 	if err != nil {
 		return fmt.Errorf("watchlist org delete: %w", err)
 	}
 	if tag.RowsAffected() == 0 {
 		return fmt.Errorf("watchlist org not found: %s", id)
 	}
-	return nil
+	return tx.Commit(ctx)
 }
 
 func (s *WatchlistOrgStore) ToggleActive(ctx context.Context, id uuid.UUID, active bool) error {
@@ -182,46 +407,187 @@ func NewWatchlistHitStore(pool *pgxpool.Pool) *WatchlistHitStore {
 	return &WatchlistHitStore{pool: pool}
 }
 
-func (s *WatchlistHitStore) ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]WatchlistHit, error) {
+// ListByUser returns the user's hits matching the filter, newest first.
+// Syndicated copies and muted hits are excluded.
+func (s *WatchlistHitStore) ListByUser(ctx context.Context, userID uuid.UUID, filter HitFilter, limit, offset int) ([]WatchlistHit, error) {
 	if limit <= 0 {
 		limit = 50
 	}
-	rows, err := s.pool.Query(ctx, `
+	where, args := filter.clause(4)
+	rows, err := s.pool.Query(ctx, fmt.Sprintf(`
 		SELECT wh.id, wh.org_id, wo.name, wh.source_type, wh.title, wh.url, wh.url_hash,
-		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at
+		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+		       wh.content_hash, wh.dup_count,
+		       COALESCE(wh.draft_status, ''), wh.draft_text, wh.draft_updated_at,
+		       wh.draft_approved_at, wh.draft_sent_at, wh.article_id,
+		       wh.sentiment_verified_at
 		FROM watchlist_hits wh
 		JOIN watchlist_orgs wo ON wo.id = wh.org_id
-		WHERE wo.user_id = $1
+		WHERE wo.user_id = $1 AND wh.duplicate_of IS NULL AND wh.muted_by IS NULL%s
 		ORDER BY wh.created_at DESC
 		LIMIT $2 OFFSET $3
-	`, userID, limit, offset)
+	`, where), append([]any{userID, limit, offset}, args...)...)
 	if err != nil {
 		return nil, fmt.Errorf("watchlist hits list: %w", err)
 	}
-	defer rows.Close()
-	return scanHitRows(rows)
+	hits, err := scanHitRows(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return hits, s.attachOrgs(ctx, hits)
 }
 
-func (s *WatchlistHitStore) ListByOrg(ctx context.Context, orgID uuid.UUID, limit, offset int) ([]WatchlistHit, error) {
+// ListTimeline returns the user's hits matching the filter as one merged
+// chronological view, newest first: each URL appears once however many of
+// the user's orgs found it, with those orgs in Orgs. Syndicated copies and
+// muted hits are excluded. A non-zero before pages back from that created_at.
+func (s *WatchlistHitStore) ListTimeline(ctx context.Context, userID uuid.UUID, filter HitFilter, before time.Time, limit int) ([]WatchlistHit, error) {
 	if limit <= 0 {
 		limit = 50
 	}
+	where, args := filter.clause(4)
+	var cursor *time.Time
+	if !before.IsZero() {
+		cursor = &before
+	}
+	rows, err := s.pool.Query(ctx, fmt.Sprintf(`
+		SELECT wh.id, wh.org_id, wo.name, wh.source_type, wh.title, wh.url, wh.url_hash,
+		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+		       wh.content_hash, wh.dup_count,
+		       COALESCE(wh.draft_status, ''), wh.draft_text, wh.draft_updated_at,
+		       wh.draft_approved_at, wh.draft_sent_at, wh.article_id,
+		       wh.sentiment_verified_at
+		FROM watchlist_hits wh
+		JOIN watchlist_orgs wo ON wo.id = wh.org_id
+		WHERE wh.user_id = $1 AND wh.duplicate_of IS NULL AND wh.muted_by IS NULL
+		  AND ($3::timestamptz IS NULL OR wh.created_at < $3)%s
+		ORDER BY wh.created_at DESC
+		LIMIT $2
+	`, where), append([]any{userID, limit, cursor}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hits timeline: %w", err)
+	}
+	hits, err := scanHitRows(rows)
+	rows.Close()
+	if err != nil {
+		return nil, err
+	}
+	return hits, s.attachOrgs(ctx, hits)
+}
+
+// attachOrgs fills in the orgs each hit is linked to, the primary first and
+// the rest in the order they found it.
+func (s *WatchlistHitStore) attachOrgs(ctx context.Context, hits []WatchlistHit) error {
+	if len(hits) == 0 {
+		return nil
+	}
+	ids := make([]uuid.UUID, len(hits))
+	index := make(map[uuid.UUID]int, len(hits))
+	for i, h := range hits {
+		ids[i] = h.ID
+		index[h.ID] = i
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT l.hit_id, wo.id, wo.name
+		FROM watchlist_hit_orgs l
+		JOIN watchlist_hits wh ON wh.id = l.hit_id
+		JOIN watchlist_orgs wo ON wo.id = l.org_id
+		WHERE l.hit_id = ANY($1)
+		ORDER BY l.hit_id, (l.org_id = wh.org_id) DESC, l.linked_at ASC
+	`, ids)
+	if err != nil {
+		return fmt.Errorf("watchlist hit orgs: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var hitID uuid.UUID
+		var o HitOrg
+		if err := rows.Scan(&hitID, &o.ID, &o.Name); err != nil {
+			return fmt.Errorf("watchlist hit orgs scan: %w", err)
+		}
+		if i, ok := index[hitID]; ok {
+			hits[i].Orgs = append(hits[i].Orgs, o)
+		}
+	}
+	return rows.Err()
+}
+
+// ListReviewedByOrg returns the hits linked to the org created since `since`
+// that a user has seen and kept (deleting a hit is how it is rejected),
+// newest first. Syndicated copies and muted hits are excluded. These are the
+// hits keyword suggestions are mined from.
+func (s *WatchlistHitStore) ListReviewedByOrg(ctx context.Context, orgID uuid.UUID, since time.Time, limit int) ([]WatchlistHit, error) {
+	if limit <= 0 {
+		limit = 500
+	}
 	rows, err := s.pool.Query(ctx, `
 		SELECT wh.id, wh.org_id, wo.name, wh.source_type, wh.title, wh.url, wh.url_hash,
-		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at
+		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+		       wh.content_hash, wh.dup_count,
+		       COALESCE(wh.draft_status, ''), wh.draft_text, wh.draft_updated_at,
+		       wh.draft_approved_at, wh.draft_sent_at, wh.article_id,
+		       wh.sentiment_verified_at
 		FROM watchlist_hits wh
 		JOIN watchlist_orgs wo ON wo.id = wh.org_id
-		WHERE wh.org_id = $1
+		WHERE EXISTS (SELECT 1 FROM watchlist_hit_orgs l WHERE l.hit_id = wh.id AND l.org_id = $1)
+		  AND wh.seen = true AND wh.duplicate_of IS NULL AND wh.muted_by IS NULL
+		  AND wh.created_at >= $2
 		ORDER BY wh.created_at DESC
-		LIMIT $2 OFFSET $3
-	`, orgID, limit, offset)
+		LIMIT $3
+	`, orgID, since, limit)
 	if err != nil {
-		return nil, fmt.Errorf("watchlist hits list by org: %w", err)
+		return nil, fmt.Errorf("watchlist hits list reviewed: %w", err)
 	}
 	defer rows.Close()
 	return scanHitRows(rows)
 }
 
+// HitEntityCount is an entity extracted from the ingested articles behind an
+// org's hits, with the number of distinct hits it appears in.
+type HitEntityCount struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Hits int    `json:"hits"`
+}
+
+// EntityCountsForHits counts, per entity, how many of the given hits link to
+// an ingested article (by URL or canonical URL) that mentions it.
+func (s *WatchlistHitStore) EntityCountsForHits(ctx context.Context, hitIDs []uuid.UUID, limit int) ([]HitEntityCount, error) {
+	if len(hitIDs) == 0 {
+		return nil, nil
+	}
+	if limit <= 0 {
+		limit = 50
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT e.name, e.type, COUNT(DISTINCT wh.id) AS hits
+		FROM watchlist_hits wh
+		JOIN articles a ON a.url = wh.url OR (wh.canonical_url != '' AND a.canonical_url = wh.canonical_url)
+		JOIN article_entities ae ON ae.article_id = a.id
+		JOIN entities e ON e.id = ae.entity_id
+		WHERE wh.id = ANY($1)
+		GROUP BY e.id, e.name, e.type
+		ORDER BY hits DESC, e.name ASC
+		LIMIT $2
+	`, hitIDs, limit)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hit entity counts: %w", err)
+	}
+	defer rows.Close()
+
+	var counts []HitEntityCount
+	for rows.Next() {
+		var c HitEntityCount
+		if err := rows.Scan(&c.Name, &c.Type, &c.Hits); err != nil {
+			return nil, fmt.Errorf("watchlist hit entity scan: %w", err)
+		}
+		counts = append(counts, c)
+	}
+	return counts, rows.Err()
+}
+
 func (s *WatchlistHitStore) CountUnseenByUser(ctx context.Context, userID uuid.UUID) (int, error) {
 	var count int
 	err := s.pool.QueryRow(ctx, `
@@ -236,25 +602,160 @@ func (s *WatchlistHitStore) CountUnseenByUser(ctx context.Context, userID uuid.U
 	return count, nil
 }
 
+// Create inserts a hit for the org's user. A URL the user already has a hit
+// for is not stored again but linked to the org, and hits whose content
+// matches an earlier hit for the same org within duplicateHitWindow are
+// stored as a seen duplicate of that hit (bumping its dup_count). In both
+// cases hit.ID is set to uuid.Nil to signal that no new visible hit was
+// created.
 func (s *WatchlistHitStore) Create(ctx context.Context, hit *WatchlistHit) error {
 	if hit.ID == uuid.Nil {
 		hit.ID = uuid.New()
 	}
-	// ON CONFLICT DO NOTHING — deduplication via url_hash.
-	err := s.pool.QueryRow(ctx, `
-		INSERT INTO watchlist_hits (id, org_id, source_type, title, url, url_hash, snippet, sentiment)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		ON CONFLICT (url_hash) DO NOTHING
-		RETURNING created_at
-	`, hit.ID, hit.OrgID, hit.SourceType, hit.Title, hit.URL, hit.URLHash, hit.Snippet, hit.Sentiment).Scan(&hit.CreatedAt)
-	if err != nil {
-		// ON CONFLICT DO NOTHING returns no rows — not an error, just a duplicate.
-		hit.ID = uuid.Nil // Signal that it was a duplicate.
+	if hit.ContentHash == "" {
+		hit.ContentHash = HitContentHash(hit.Title, hit.Snippet)
+	}
+
+	// Look for an earlier hit with the same content under a different URL.
+	var originalID *uuid.UUID
+	id, err := s.FindContentDuplicate(ctx, hit.OrgID, hit.ContentHash, hit.URLHash)
+	if err != nil {
+		return fmt.Errorf("watchlist hit create: %w", err)
+	}
+	if id != uuid.Nil {
+		originalID = &id
+	}
+
+	// ON CONFLICT DO NOTHING — one hit per URL per user.
+	err = s.pool.QueryRow(ctx, `
+		WITH ins AS (
+			INSERT INTO watchlist_hits (id, org_id, user_id, source_type, title, url, url_hash, snippet,
+			                            sentiment, content_hash, duplicate_of, seen)
+			SELECT $1, wo.id, wo.user_id, $3, $4, $5, $6, $7, $8, $9, $10, $11
+			FROM watchlist_orgs wo WHERE wo.id = $2
+			ON CONFLICT (user_id, url_hash) DO NOTHING
+			RETURNING id, org_id, created_at
+		), link AS (
+			INSERT INTO watchlist_hit_orgs (hit_id, org_id, linked_at)
+			SELECT id, org_id, created_at FROM ins
+		)
+		SELECT created_at FROM ins
+	`, hit.ID, hit.OrgID, hit.SourceType, hit.Title, hit.URL, hit.URLHash, hit.Snippet, hit.Sentiment,
+		hit.ContentHash, originalID, originalID != nil).Scan(&hit.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The user already has this URL: link it to the org as well.
+		hit.ID = uuid.Nil
+		if _, err := s.pool.Exec(ctx, `
+			INSERT INTO watchlist_hit_orgs (hit_id, org_id)
+			SELECT wh.id, wo.id
+			FROM watchlist_hits wh
+			JOIN watchlist_orgs wo ON wo.user_id = wh.user_id
+			WHERE wo.id = $1 AND wh.url_hash = $2
+			ON CONFLICT DO NOTHING
+		`, hit.OrgID, hit.URLHash); err != nil {
+			return fmt.Errorf("watchlist hit create: link org: %w", err)
+		}
 		return nil
 	}
+	if err != nil {
+		return fmt.Errorf("watchlist hit create: %w", err)
+	}
+
+	if originalID != nil {
+		if _, err := s.pool.Exec(ctx, `
+			UPDATE watchlist_hits SET dup_count = dup_count + 1 WHERE id = $1
+		`, *originalID); err != nil {
+			return fmt.Errorf("watchlist hit create: bump dup count: %w", err)
+		}
+		hit.ID = uuid.Nil // Stored, but folded into the original.
+	}
 	return nil
 }
 
+// ExistingURLHashes reports which of the given URL hashes the user already
+// has hits for.
+func (s *WatchlistHitStore) ExistingURLHashes(ctx context.Context, userID uuid.UUID, hashes []string) (map[string]bool, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT url_hash FROM watchlist_hits WHERE user_id = $1 AND url_hash = ANY($2)
+	`, userID, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hits existing: %w", err)
+	}
+	return collectURLHashes(rows)
+}
+
+// LinkedURLHashes reports which of the given URL hashes are already hits
+// linked to the org.
+func (s *WatchlistHitStore) LinkedURLHashes(ctx context.Context, orgID uuid.UUID, hashes []string) (map[string]bool, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT wh.url_hash
+		FROM watchlist_hits wh
+		JOIN watchlist_hit_orgs l ON l.hit_id = wh.id
+		WHERE l.org_id = $1 AND wh.url_hash = ANY($2)
+	`, orgID, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hits linked: %w", err)
+	}
+	return collectURLHashes(rows)
+}
+
+func collectURLHashes(rows pgx.Rows) (map[string]bool, error) {
+	defer rows.Close()
+	hashes := make(map[string]bool)
+	for rows.Next() {
+		var h string
+		if err := rows.Scan(&h); err != nil {
+			return nil, fmt.Errorf("watchlist hits url hash scan: %w", err)
+		}
+		hashes[h] = true
+	}
+	return hashes, rows.Err()
+}
+
+// FindContentDuplicate returns the original hit linked to the org, within
+// the duplicate window, with the same content hash under a different URL, or
+// uuid.Nil when there is none. Create folds such hits into the original.
+func (s *WatchlistHitStore) FindContentDuplicate(ctx context.Context, orgID uuid.UUID, contentHash, urlHash string) (uuid.UUID, error) {
+	if contentHash == "" {
+		return uuid.Nil, nil
+	}
+	var id uuid.UUID
+	err := s.pool.QueryRow(ctx, `
+		SELECT wh.id FROM watchlist_hits wh
+		JOIN watchlist_hit_orgs l ON l.hit_id = wh.id
+		WHERE l.org_id = $1 AND wh.content_hash = $2 AND wh.url_hash != $3
+		  AND wh.duplicate_of IS NULL AND wh.created_at >= $4
+		ORDER BY wh.created_at ASC
+		LIMIT 1
+	`, orgID, contentHash, urlHash, time.Now().Add(-duplicateHitWindow)).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("check duplicate: %w", err)
+	}
+	return id, nil
+}
+
+// FindByURLHash returns the ID of the user's hit stored under urlHash, or
+// uuid.Nil. With userID uuid.Nil any user's hit is returned.
+func (s *WatchlistHitStore) FindByURLHash(ctx context.Context, userID uuid.UUID, urlHash string) (uuid.UUID, error) {
+	var id uuid.UUID
+	err := s.pool.QueryRow(ctx, `
+		SELECT id FROM watchlist_hits
+		WHERE url_hash = $1 AND ($2 = $3 OR user_id = $2)
+		ORDER BY created_at ASC
+		LIMIT 1
+	`, urlHash, userID, uuid.Nil).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("watchlist hit find by url hash: %w", err)
+	}
+	return id, nil
+}
+
 func (s *WatchlistHitStore) MarkSeen(ctx context.Context, hitID uuid.UUID) error {
 	tag, err := s.pool.Exec(ctx, `UPDATE watchlist_hits SET seen = true WHERE id = $1`, hitID)
 	if err != nil {
@@ -279,7 +780,9 @@ func (s *WatchlistHitStore) MarkAllSeenByUser(ctx context.Context, userID uuid.U
 }
 
 func (s *WatchlistHitStore) UpdateSentiment(ctx context.Context, hitID uuid.UUID, sentiment string) error {
-	_, err := s.pool.Exec(ctx, `UPDATE watchlist_hits SET sentiment = $2 WHERE id = $1`, hitID, sentiment)
+	_, err := s.pool.Exec(ctx, `
+		UPDATE watchlist_hits SET sentiment = $2 WHERE id = $1 AND sentiment_verified_at IS NULL
+	`, hitID, sentiment)
 	if err != nil {
 		return fmt.Errorf("watchlist hit update sentiment: %w", err)
 	}
@@ -287,7 +790,11 @@ func (s *WatchlistHitStore) UpdateSentiment(ctx context.Context, hitID uuid.UUID
 }
 
 func (s *WatchlistHitStore) UpdateAIDraft(ctx context.Context, hitID uuid.UUID, draft string) error {
-	_, err := s.pool.Exec(ctx, `UPDATE watchlist_hits SET ai_draft = $2 WHERE id = $1`, hitID, draft)
+	_, err := s.pool.Exec(ctx, `
+		UPDATE watchlist_hits
+		SET ai_draft = $2, draft_status = COALESCE(draft_status, 'draft'), draft_updated_at = NOW()
+		WHERE id = $1
+	`, hitID, draft)
 	if err != nil {
 		return fmt.Errorf("watchlist hit update draft: %w", err)
 	}
@@ -305,19 +812,26 @@ func (s *WatchlistHitStore) Delete(ctx context.Context, hitID uuid.UUID) error {
 	return nil
 }
 
-func (s *WatchlistHitStore) ListRecentByUser(ctx context.Context, userID uuid.UUID, limit int) ([]WatchlistHit, error) {
+// ListRecentByUser returns the user's newest hits matching the filter,
+// excluding syndicated duplicates and muted hits.
+func (s *WatchlistHitStore) ListRecentByUser(ctx context.Context, userID uuid.UUID, filter HitFilter, limit int) ([]WatchlistHit, error) {
 	if limit <= 0 {
 		limit = 100
 	}
-	rows, err := s.pool.Query(ctx, `
+	where, args := filter.clause(3)
+	rows, err := s.pool.Query(ctx, fmt.Sprintf(`
 		SELECT wh.id, wh.org_id, wo.name, wh.source_type, wh.title, wh.url, wh.url_hash,
-		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at
+		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+		       wh.content_hash, wh.dup_count,
+		       COALESCE(wh.draft_status, ''), wh.draft_text, wh.draft_updated_at,
+		       wh.draft_approved_at, wh.draft_sent_at, wh.article_id,
+		       wh.sentiment_verified_at
 		FROM watchlist_hits wh
 		JOIN watchlist_orgs wo ON wo.id = wh.org_id
-		WHERE wo.user_id = $1
+		WHERE wo.user_id = $1 AND wh.duplicate_of IS NULL AND wh.muted_by IS NULL%s
 		ORDER BY wh.created_at DESC
 		LIMIT $2
-	`, userID, limit)
+	`, where), append([]any{userID, limit}, args...)...)
 	if err != nil {
 		return nil, fmt.Errorf("watchlist hits list recent: %w", err)
 	}
@@ -325,13 +839,83 @@ func (s *WatchlistHitStore) ListRecentByUser(ctx context.Context, userID uuid.UU
 	return scanHitRows(rows)
 }
 
+// ListNewByUser returns the user's hits created in (since, until], excluding
+// syndicated duplicates and muted hits, ordered by org name and then newest
+// first. At most perOrg of each org's newest hits are returned, so a noisy org
+// cannot crowd the others out; CountNewByOrg tells how many there were.
+func (s *WatchlistHitStore) ListNewByUser(ctx context.Context, userID uuid.UUID, since, until time.Time, perOrg int) ([]WatchlistHit, error) {
+	if perOrg <= 0 {
+		perOrg = 50
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, org_id, org_name, source_type, title, url, url_hash,
+		       snippet, sentiment, ai_draft, seen, created_at,
+		       content_hash, dup_count,
+		       draft_status, draft_text, draft_updated_at,
+		       draft_approved_at, draft_sent_at, article_id,
+		       sentiment_verified_at
+		FROM (
+			SELECT wh.id, wh.org_id, wo.name AS org_name, wh.source_type, wh.title, wh.url, wh.url_hash,
+			       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+			       wh.content_hash, wh.dup_count,
+			       COALESCE(wh.draft_status, '') AS draft_status, wh.draft_text, wh.draft_updated_at,
+			       wh.draft_approved_at, wh.draft_sent_at, wh.article_id,
+			       wh.sentiment_verified_at,
+			       ROW_NUMBER() OVER (PARTITION BY wh.org_id ORDER BY wh.created_at DESC) AS rn
+			FROM watchlist_hits wh
+			JOIN watchlist_orgs wo ON wo.id = wh.org_id
+			WHERE wo.user_id = $1 AND wh.duplicate_of IS NULL AND wh.muted_by IS NULL
+			  AND wh.created_at > $2 AND wh.created_at <= $3
+		) ranked
+		WHERE rn <= $4
+		ORDER BY org_name ASC, created_at DESC
+	`, userID, since, until, perOrg)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hits list new: %w", err)
+	}
+	defer rows.Close()
+	return scanHitRows(rows)
+}
+
+// CountNewByOrg counts, per org, the hits ListNewByUser would consider for
+// the same window before its per-org cap.
+func (s *WatchlistHitStore) CountNewByOrg(ctx context.Context, userID uuid.UUID, since, until time.Time) (map[uuid.UUID]int, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT wh.org_id, COUNT(*)
+		FROM watchlist_hits wh
+		JOIN watchlist_orgs wo ON wo.id = wh.org_id
+		WHERE wo.user_id = $1 AND wh.duplicate_of IS NULL AND wh.muted_by IS NULL
+		  AND wh.created_at > $2 AND wh.created_at <= $3
+		GROUP BY wh.org_id
+	`, userID, since, until)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hits count new: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[uuid.UUID]int)
+	for rows.Next() {
+		var orgID uuid.UUID
+		var n int
+		if err := rows.Scan(&orgID, &n); err != nil {
+			return nil, fmt.Errorf("watchlist hits count new scan: %w", err)
+		}
+		counts[orgID] = n
+	}
+	return counts, rows.Err()
+}
+
 func (s *WatchlistHitStore) ListBySentiment(ctx context.Context, sentiment string, limit int) ([]WatchlistHit, error) {
 	rows, err := s.pool.Query(ctx, `
 		SELECT wh.id, wh.org_id, wo.name, wh.source_type, wh.title, wh.url, wh.url_hash,
-		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at
+		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+		       wh.content_hash, wh.dup_count,
+		       COALESCE(wh.draft_status, ''), wh.draft_text, wh.draft_updated_at,
+		       wh.draft_approved_at, wh.draft_sent_at, wh.article_id,
+		       wh.sentiment_verified_at
 		FROM watchlist_hits wh
 		JOIN watchlist_orgs wo ON wo.id = wh.org_id
-		WHERE wh.sentiment = $1
+		WHERE wh.sentiment = $1 AND wh.duplicate_of IS NULL AND wh.muted_by IS NULL
 		ORDER BY wh.created_at DESC
 		LIMIT $2
 	`, sentiment, limit)
@@ -342,6 +926,200 @@ func (s *WatchlistHitStore) ListBySentiment(ctx context.Context, sentiment strin
 	return scanHitRows(rows)
 }
 
+// ListByArticleURL returns the user's watchlist hits (including folded
+// duplicates) that point at an article, matched by exact or canonical URL.
+func (s *WatchlistHitStore) ListByArticleURL(ctx context.Context, userID uuid.UUID, url, canonicalURL string) ([]WatchlistHit, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT wh.id, wh.org_id, wo.name, wh.source_type, wh.title, wh.url, wh.url_hash,
+		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+		       wh.content_hash, wh.dup_count,
+		       COALESCE(wh.draft_status, ''), wh.draft_text, wh.draft_updated_at,
+		       wh.draft_approved_at, wh.draft_sent_at, wh.article_id,
+		       wh.sentiment_verified_at
+		FROM watchlist_hits wh
+		JOIN watchlist_orgs wo ON wo.id = wh.org_id
+		WHERE wo.user_id = $1
+		  AND (wh.url = $2 OR wh.url = $3 OR ($3 != '' AND wh.canonical_url = $3))
+		ORDER BY wh.created_at DESC
+	`, userID, url, canonicalURL)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hits by article url: %w", err)
+	}
+	defer rows.Close()
+	return scanHitRows(rows)
+}
+
+// SetArticle records the article collected from one of the user's hits.
+func (s *WatchlistHitStore) SetArticle(ctx context.Context, userID, hitID, articleID uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE watchlist_hits wh SET article_id = $3
+		FROM watchlist_orgs wo
+		WHERE wo.id = wh.org_id AND wh.id = $1 AND wo.user_id = $2
+	`, hitID, userID, articleID)
+	if err != nil {
+		return fmt.Errorf("watchlist hit set article: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrHitNotFound
+	}
+	return nil
+}
+
+// ── Story grouping ───────────────────────────────────────────────
+
+// HitStory is a group of hits covering the same story, represented by the
+// first hit seen for it.
+type HitStory struct {
+	StoryID        uuid.UUID    `json:"story_id"`
+	Representative WatchlistHit `json:"representative"`
+	// MemberCount counts the story's hits plus their folded syndicated copies.
+	MemberCount int       `json:"member_count"`
+	LatestAt    time.Time `json:"latest_at"`
+}
+
+// ListUngrouped returns visible hits that have not been assigned to a story
+// yet, oldest first so that earlier hits become story representatives.
+func (s *WatchlistHitStore) ListUngrouped(ctx context.Context, limit int) ([]WatchlistHit, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT wh.id, wh.org_id, wo.name, wh.source_type, wh.title, wh.url, wh.url_hash,
+		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+		       wh.content_hash, wh.dup_count,
+		       COALESCE(wh.draft_status, ''), wh.draft_text, wh.draft_updated_at,
+		       wh.draft_approved_at, wh.draft_sent_at, wh.article_id,
+		       wh.sentiment_verified_at
+		FROM watchlist_hits wh
+		JOIN watchlist_orgs wo ON wo.id = wh.org_id
+		WHERE wh.story_id IS NULL AND wh.duplicate_of IS NULL
+		ORDER BY wh.created_at ASC
+		LIMIT $1
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hits list ungrouped: %w", err)
+	}
+	defer rows.Close()
+	return scanHitRows(rows)
+}
+
+// FindStory returns the story of an earlier grouped hit for the same org
+// within duplicateHitWindow that has the same canonical URL or, failing that,
+// whose embedding is within maxDistance (cosine) of the given one. Returns
+// uuid.Nil when no story matches. embedding may be nil to match by URL only.
+func (s *WatchlistHitStore) FindStory(ctx context.Context, hit *WatchlistHit, canonicalURL string, embedding []float32, maxDistance float64) (uuid.UUID, error) {
+	since := time.Now().Add(-duplicateHitWindow)
+
+	var storyID uuid.UUID
+	if canonicalURL != "" {
+		err := s.pool.QueryRow(ctx, `
+			SELECT story_id FROM watchlist_hits
+			WHERE org_id = $1 AND canonical_url = $2 AND id != $3
+			  AND story_id IS NOT NULL AND created_at >= $4
+			ORDER BY created_at ASC
+			LIMIT 1
+		`, hit.OrgID, canonicalURL, hit.ID, since).Scan(&storyID)
+		if err == nil {
+			return storyID, nil
+		}
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return uuid.Nil, fmt.Errorf("watchlist find story by url: %w", err)
+		}
+	}
+
+	if len(embedding) == 0 {
+		return uuid.Nil, nil
+	}
+
+	err := s.pool.QueryRow(ctx, `
+		SELECT story_id FROM watchlist_hits
+		WHERE org_id = $1 AND id != $2 AND story_id IS NOT NULL
+		  AND embedding IS NOT NULL AND created_at >= $3
+		  AND (embedding <=> $4::vector) <= $5
+		ORDER BY embedding <=> $4::vector
+		LIMIT 1
+	`, hit.OrgID, hit.ID, since, formatVector(embedding), maxDistance).Scan(&storyID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return uuid.Nil, nil
+	}
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("watchlist find story by embedding: %w", err)
+	}
+	return storyID, nil
+}
+
+// SetStory records a hit's story assignment along with the canonical URL and
+// embedding used to match later hits against it.
+func (s *WatchlistHitStore) SetStory(ctx context.Context, hitID, storyID uuid.UUID, canonicalURL string, embedding []float32) error {
+	var vec *string
+	if len(embedding) > 0 {
+		v := formatVector(embedding)
+		vec = &v
+	}
+	_, err := s.pool.Exec(ctx, `
+		UPDATE watchlist_hits
+		SET story_id = $2, canonical_url = $3, embedding = $4::vector
+		WHERE id = $1
+	`, hitID, storyID, canonicalURL, vec)
+	if err != nil {
+		return fmt.Errorf("watchlist hit set story: %w", err)
+	}
+	return nil
+}
+
+// ListStoriesByUser returns the user's hits matching the filter grouped by
+// story, most recently active story first; member counts cover the matching
+// hits only. Hits not yet grouped appear as single-hit stories.
+func (s *WatchlistHitStore) ListStoriesByUser(ctx context.Context, userID uuid.UUID, filter HitFilter, limit, offset int) ([]HitStory, error) {
+	if limit <= 0 {
+		limit = 50
+	}
+	where, args := filter.clause(4)
+	rows, err := s.pool.Query(ctx, fmt.Sprintf(`
+		WITH stories AS (
+			SELECT COALESCE(wh.story_id, wh.id) AS story_id,
+			       COUNT(*) + SUM(wh.dup_count) AS members,
+			       MAX(wh.created_at) AS latest_at
+			FROM watchlist_hits wh
+			JOIN watchlist_orgs wo ON wo.id = wh.org_id
+			WHERE wo.user_id = $1 AND wh.duplicate_of IS NULL AND wh.muted_by IS NULL%s
+			GROUP BY 1
+			ORDER BY latest_at DESC
+			LIMIT $2 OFFSET $3
+		)
+		SELECT wh.id, wh.org_id, wo.name, wh.source_type, wh.title, wh.url, wh.url_hash,
+		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+		       wh.content_hash, wh.dup_count,
+		       COALESCE(wh.draft_status, ''), wh.draft_text, wh.draft_updated_at,
+		       wh.draft_approved_at, wh.draft_sent_at, wh.article_id, wh.sentiment_verified_at,
+		       s.members, s.latest_at
+		FROM stories s
+		JOIN watchlist_hits wh ON wh.id = s.story_id
+		JOIN watchlist_orgs wo ON wo.id = wh.org_id
+		ORDER BY s.latest_at DESC
+	`, where), append([]any{userID, limit, offset}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hit stories list: %w", err)
+	}
+	defer rows.Close()
+
+	var stories []HitStory
+	for rows.Next() {
+		var st HitStory
+		h := &st.Representative
+		if err := rows.Scan(
+			&h.ID, &h.OrgID, &h.OrgName, &h.SourceType, &h.Title, &h.URL, &h.URLHash,
+			&h.Snippet, &h.Sentiment, &h.AIDraft, &h.Seen, &h.CreatedAt,
+			&h.ContentHash, &h.DupCount,
+			&h.DraftStatus, &h.DraftText, &h.DraftUpdatedAt,
+			&h.DraftApprovedAt, &h.DraftSentAt, &h.ArticleID, &h.SentimentVerifiedAt,
+			&st.MemberCount, &st.LatestAt,
+		); err != nil {
+			return nil, fmt.Errorf("watchlist hit story scan: %w", err)
+		}
+		st.StoryID = h.ID
+		stories = append(stories, st)
+	}
+	return stories, rows.Err()
+}
+
 // ── Helpers ──────────────────────────────────────────────────────
 
 func scanHitRows(rows interface {
@@ -355,6 +1133,9 @@ func scanHitRows(rows interface {
 		if err := rows.Scan(
 			&h.ID, &h.OrgID, &h.OrgName, &h.SourceType, &h.Title, &h.URL, &h.URLHash,
 			&h.Snippet, &h.Sentiment, &h.AIDraft, &h.Seen, &h.CreatedAt,
+			&h.ContentHash, &h.DupCount,
+			&h.DraftStatus, &h.DraftText, &h.DraftUpdatedAt,
+			&h.DraftApprovedAt, &h.DraftSentAt, &h.ArticleID, &h.SentimentVerifiedAt,
 		); err != nil {
 			return nil, fmt.Errorf("watchlist hit scan: %w", err)
 		}
@@ -363,6 +1144,40 @@ func scanHitRows(rows interface {
 	return hits, rows.Err()
 }
 
+// reHitMarkup matches HTML tags and entities in feed snippets.
+var reHitMarkup = regexp.MustCompile(`<[^>]*>|&[#a-zA-Z0-9]+;`)
+
+// minHitContentLen is the shortest normalized text worth fingerprinting;
+// shorter snippets are too generic to identify a story.
+const minHitContentLen = 40
+
+// HitContentHash returns a SHA-256 hash of the normalized hit snippet (or of
+// title + snippet when the snippet is short): markup stripped, lowercased,
+// punctuation dropped, whitespace collapsed. Returns "" if there is too little
+// text to fingerprint reliably.
+func HitContentHash(title, snippet string) string {
+	text := normalizeHitText(snippet)
+	if len(text) < 2*minHitContentLen {
+		text = strings.TrimSpace(normalizeHitText(title) + " " + text)
+	}
+	if len(text) < minHitContentLen {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
+
+func normalizeHitText(s string) string {
+	s = reHitMarkup.ReplaceAllString(s, " ")
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return ' '
+	}, s)
+	return strings.Join(strings.Fields(s), " ")
+}
+
 // scanJSONStringSlice unmarshals a JSONB column into a []string.
 func scanJSONStringSlice(raw []byte) []string {
 	if len(raw) == 0 {
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WatchlistDigest is a user's daily summary of new watchlist hits, grouped by org.
+type WatchlistDigest struct {
+	ID          uuid.UUID              `json:"id"`
+	UserID      uuid.UUID              `json:"user_id"`
+	PeriodStart time.Time              `json:"period_start"`
+	PeriodEnd   time.Time              `json:"period_end"`
+	HitCount    int                    `json:"hit_count"`
+	Groups      []WatchlistDigestGroup `json:"groups"`
+	Summary     string                 `json:"summary"` // plain-text rendering sent to notification channels
+	CreatedAt   time.Time              `json:"created_at"`
+}
+
+// WatchlistDigestGroup holds one org's new hits within a digest. Total is
+// how many new hits the org had; Hits keeps the newest of them and the
+// sentiment counts cover those.
+type WatchlistDigestGroup struct {
+	OrgID    uuid.UUID            `json:"org_id"`
+	OrgName  string               `json:"org_name"`
+	Total    int                  `json:"total"`
+	Positive int                  `json:"positive"`
+	Negative int                  `json:"negative"`
+	Neutral  int                  `json:"neutral"`
+	Hits     []WatchlistDigestHit `json:"hits"`
+}
+
+// WatchlistDigestHit is the slice of a WatchlistHit kept in a digest.
+type WatchlistDigestHit struct {
+	ID         uuid.UUID `json:"id"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	SourceType string    `json:"source_type"`
+	Sentiment  string    `json:"sentiment"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type WatchlistDigestStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewWatchlistDigestStore(pool *pgxpool.Pool) *WatchlistDigestStore {
+	return &WatchlistDigestStore{pool: pool}
+}
+
+func (s *WatchlistDigestStore) Create(ctx context.Context, d *WatchlistDigest) error {
+	if d.ID == uuid.Nil {
+		d.ID = uuid.New()
+	}
+	groups, err := json.Marshal(d.Groups)
+	if err != nil {
+		return fmt.Errorf("watchlist digest create: marshal groups: %w", err)
+	}
+
+	err = s.pool.QueryRow(ctx, `
+		INSERT INTO watchlist_digests (id, user_id, period_start, period_end, hit_count, groups, summary)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING created_at
+	`, d.ID, d.UserID, d.PeriodStart, d.PeriodEnd, d.HitCount, groups, d.Summary).Scan(&d.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("watchlist digest create: %w", err)
+	}
+	return nil
+}
+
+// LatestByUser returns the user's most recent digest, or nil if none has been
+// generated yet.
+func (s *WatchlistDigestStore) LatestByUser(ctx context.Context, userID uuid.UUID) (*WatchlistDigest, error) {
+	var d WatchlistDigest
+	var groups []byte
+	err := s.pool.QueryRow(ctx, `
+		SELECT id, user_id, period_start, period_end, hit_count, groups, summary, created_at
+		FROM watchlist_digests
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, userID).Scan(&d.ID, &d.UserID, &d.PeriodStart, &d.PeriodEnd, &d.HitCount, &groups, &d.Summary, &d.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("watchlist digest latest: %w", err)
+	}
+	if err := json.Unmarshal(groups, &d.Groups); err != nil {
+		return nil, fmt.Errorf("watchlist digest latest: decode groups: %w", err)
+	}
+	return &d, nil
+}
+
+// LastPeriodEnd returns the end of the user's most recent digest period, or
+// the zero time if the user has no digests.
+func (s *WatchlistDigestStore) LastPeriodEnd(ctx context.Context, userID uuid.UUID) (time.Time, error) {
+	var end *time.Time
+	err := s.pool.QueryRow(ctx, `
+		SELECT MAX(period_end) FROM watchlist_digests WHERE user_id = $1
+	`, userID).Scan(&end)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("watchlist digest last period: %w", err)
+	}
+	if end == nil {
+		return time.Time{}, nil
+	}
+	return *end, nil
+}
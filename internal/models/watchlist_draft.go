@@ -0,0 +1,177 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ── Response draft workflow ──────────────────────────────────────
+
+// Draft statuses of a hit's response, in workflow order.
+const (
+	DraftStatusDraft    = "draft"    // AI draft, not reviewed
+	DraftStatusEdited   = "edited"   // revised by a person
+	DraftStatusApproved = "approved" // ready to send
+	DraftStatusSent     = "sent"     // sent out; no further changes
+)
+
+var (
+	ErrHitNotFound     = errors.New("watchlist hit not found")
+	ErrDraftTransition = errors.New("invalid draft status change")
+)
+
+// draftFrom lists the statuses each status can be reached from. Moving back
+// to "draft" reopens an approved response for editing.
+var draftFrom = map[string][]string{
+	DraftStatusDraft:    {DraftStatusEdited, DraftStatusApproved},
+	DraftStatusApproved: {DraftStatusDraft, DraftStatusEdited},
+	DraftStatusSent:     {DraftStatusApproved},
+}
+
+// ValidDraftStatus reports whether status can be set with SetDraftStatus.
+// "edited" is only reached through EditDraft.
+func ValidDraftStatus(status string) bool {
+	_, ok := draftFrom[status]
+	return ok
+}
+
+// GetForUser returns one of the user's hits.
+func (s *WatchlistHitStore) GetForUser(ctx context.Context, userID, hitID uuid.UUID) (*WatchlistHit, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT wh.id, wh.org_id, wo.name, wh.source_type, wh.title, wh.url, wh.url_hash,
+		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+		       wh.content_hash, wh.dup_count,
+		       COALESCE(wh.draft_status, ''), wh.draft_text, wh.draft_updated_at,
+		       wh.draft_approved_at, wh.draft_sent_at, wh.article_id,
+		       wh.sentiment_verified_at
+		FROM watchlist_hits wh
+		JOIN watchlist_orgs wo ON wo.id = wh.org_id
+		WHERE wh.id = $1 AND wo.user_id = $2
+	`, hitID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hit get: %w", err)
+	}
+	defer rows.Close()
+	hits, err := scanHitRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(hits) == 0 {
+		return nil, ErrHitNotFound
+	}
+	return &hits[0], nil
+}
+
+// EditDraft stores a person's revision of a hit's response, leaving the AI
+// original in ai_draft. The draft moves to "edited", dropping any approval;
+// a response that was already sent cannot be edited.
+func (s *WatchlistHitStore) EditDraft(ctx context.Context, userID, hitID uuid.UUID, text string) error {
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE watchlist_hits wh
+		SET draft_text = $3, draft_status = 'edited', draft_updated_at = NOW(),
+		    draft_approved_by = NULL, draft_approved_at = NULL
+		FROM watchlist_orgs wo
+		WHERE wo.id = wh.org_id AND wh.id = $1 AND wo.user_id = $2
+		  AND wh.draft_status IS DISTINCT FROM 'sent'
+	`, hitID, userID, text)
+	if err != nil {
+		return fmt.Errorf("watchlist hit edit draft: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return s.draftUnchanged(ctx, userID, hitID)
+	}
+	return nil
+}
+
+// SetDraftStatus moves a hit's response to status (draft, approved or sent).
+// Approving records the approving user; reopening an approved response
+// returns it to "edited" when it has a revision. Returns ErrDraftTransition
+// when the current status does not allow the change.
+func (s *WatchlistHitStore) SetDraftStatus(ctx context.Context, userID, hitID uuid.UUID, status string) error {
+	from, ok := draftFrom[status]
+	if !ok {
+		return ErrDraftTransition
+	}
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE watchlist_hits wh
+		SET draft_status = CASE
+		        WHEN $3 = 'draft' AND wh.draft_text IS NOT NULL THEN 'edited'
+		        ELSE $3 END,
+		    draft_updated_at = NOW(),
+		    draft_approved_by = CASE $3 WHEN 'approved' THEN $2::uuid
+		        WHEN 'sent' THEN wh.draft_approved_by END,
+		    draft_approved_at = CASE $3 WHEN 'approved' THEN NOW()
+		        WHEN 'sent' THEN wh.draft_approved_at END,
+		    draft_sent_at = CASE $3 WHEN 'sent' THEN NOW() END
+		FROM watchlist_orgs wo
+		WHERE wo.id = wh.org_id AND wh.id = $1 AND wo.user_id = $2
+		  AND wh.draft_status = ANY($4)
+	`, hitID, userID, status, from)
+	if err != nil {
+		return fmt.Errorf("watchlist hit set draft status: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return s.draftUnchanged(ctx, userID, hitID)
+	}
+	return nil
+}
+
+// draftUnchanged explains an update that matched no row: the hit is missing
+// (ErrHitNotFound) or its status did not allow the change.
+func (s *WatchlistHitStore) draftUnchanged(ctx context.Context, userID, hitID uuid.UUID) error {
+	var exists bool
+	err := s.pool.QueryRow(ctx, `
+		SELECT true FROM watchlist_hits wh
+		JOIN watchlist_orgs wo ON wo.id = wh.org_id
+		WHERE wh.id = $1 AND wo.user_id = $2
+	`, hitID, userID).Scan(&exists)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrHitNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("watchlist hit draft lookup: %w", err)
+	}
+	return ErrDraftTransition
+}
+
+// ListCommunications returns the user's approved and sent responses approved
+// in [from, to), oldest first. If orgID is not uuid.Nil only that org's are
+// included.
+func (s *WatchlistHitStore) ListCommunications(ctx context.Context, userID, orgID uuid.UUID, from, to time.Time) ([]WatchlistHit, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT wh.id, wh.org_id, wo.name, wh.source_type, wh.title, wh.url, wh.url_hash,
+		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+		       wh.content_hash, wh.dup_count,
+		       COALESCE(wh.draft_status, ''), wh.draft_text, wh.draft_updated_at,
+		       wh.draft_approved_at, wh.draft_sent_at, wh.article_id,
+		       wh.sentiment_verified_at
+		FROM watchlist_hits wh
+		JOIN watchlist_orgs wo ON wo.id = wh.org_id
+		WHERE wo.user_id = $1 AND ($2 = $3 OR wh.org_id = $2)
+		  AND wh.draft_status IN ('approved', 'sent')
+		  AND wh.draft_approved_at >= $4 AND wh.draft_approved_at < $5
+		ORDER BY wh.draft_approved_at ASC
+	`, userID, orgID, uuid.Nil, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hit communications: %w", err)
+	}
+	defer rows.Close()
+	return scanHitRows(rows)
+}
+
+// ResponseText is the text of the hit's response: the human revision when
+// there is one, otherwise the AI draft.
+func (h *WatchlistHit) ResponseText() string {
+	if h.DraftText != nil {
+		return *h.DraftText
+	}
+	if h.AIDraft != nil {
+		return *h.AIDraft
+	}
+	return ""
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HitFilter holds the optional filters for listing a user's watchlist hits.
+// Zero values match every hit.
+type HitFilter struct {
+	OrgID      uuid.UUID // hits linked to the org; uuid.Nil for all of the user's orgs
+	Sentiment  string
+	SourceType string
+	Seen       *bool
+	From       time.Time // created_at >= From
+	To         time.Time // created_at < To
+	Query      string    // words matched against title and snippet
+}
+
+// clause builds the SQL conditions on watchlist_hits (aliased wh) for the
+// filter, numbering placeholders from argN. Each condition is prefixed with
+// AND so the result can be appended to an existing WHERE clause.
+func (f HitFilter) clause(argN int) (string, []any) {
+	var conditions []string
+	var args []any
+
+	if f.OrgID != uuid.Nil {
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM watchlist_hit_orgs l WHERE l.hit_id = wh.id AND l.org_id = $%d)", argN))
+		args = append(args, f.OrgID)
+		argN++
+	}
+	if f.Sentiment != "" {
+		conditions = append(conditions, fmt.Sprintf("wh.sentiment = $%d", argN))
+		args = append(args, f.Sentiment)
+		argN++
+	}
+	if f.SourceType != "" {
+		conditions = append(conditions, fmt.Sprintf("wh.source_type = $%d", argN))
+		args = append(args, f.SourceType)
+		argN++
+	}
+	if f.Seen != nil {
+		conditions = append(conditions, fmt.Sprintf("wh.seen = $%d", argN))
+		args = append(args, *f.Seen)
+		argN++
+	}
+	if !f.From.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("wh.created_at >= $%d", argN))
+		args = append(args, f.From)
+		argN++
+	}
+	if !f.To.IsZero() {
+		conditions = append(conditions, fmt.Sprintf("wh.created_at < $%d", argN))
+		args = append(args, f.To)
+		argN++
+	}
+	if q := strings.TrimSpace(f.Query); q != "" {
+		// Matches the expression index idx_wh_text (migration 052).
+		conditions = append(conditions, fmt.Sprintf(
+			"to_tsvector('simple', wh.title || ' ' || wh.snippet) @@ plainto_tsquery('simple', $%d)", argN))
+		args = append(args, q)
+	}
+
+	if len(conditions) == 0 {
+		return "", nil
+	}
+	return " AND " + strings.Join(conditions, " AND "), args
+}
@@ -0,0 +1,185 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrMuteNotFound is returned when a muted story does not exist for the org.
+var ErrMuteNotFound = errors.New("muted story not found")
+
+// MutedStory is a story silenced for an org until ExpiresAt. New hits linked
+// to the org that match its signature (canonical URL, or an embedding close
+// to it that names one of Entities) are hidden.
+type MutedStory struct {
+	ID           uuid.UUID  `json:"id"`
+	OrgID        uuid.UUID  `json:"org_id"`
+	HitID        *uuid.UUID `json:"hit_id,omitempty"` // hit it was muted from; nil once deleted
+	Title        string     `json:"title"`
+	CanonicalURL string     `json:"canonical_url"`
+	Entities     []string   `json:"entities"`
+	MutedHits    int        `json:"muted_hits"`
+	CreatedAt    time.Time  `json:"created_at"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	Active       bool       `json:"active"`
+}
+
+const mutedStoryColumns = `m.id, m.org_id, m.hit_id, m.title, m.canonical_url, m.entities,
+		       m.muted_hits, m.created_at, m.expires_at, m.expires_at > NOW()`
+
+func scanMutedStory(row scannable, m *MutedStory) error {
+	return row.Scan(&m.ID, &m.OrgID, &m.HitID, &m.Title, &m.CanonicalURL, &m.Entities,
+		&m.MutedHits, &m.CreatedAt, &m.ExpiresAt, &m.Active)
+}
+
+// MuteStory stores the mute and hides the hit it was made from together with
+// the rest of that hit's story for the org. embedding may be nil to use the
+// hit's stored one. m.ID, m.MutedHits and m.CreatedAt are set on return.
+func (s *WatchlistHitStore) MuteStory(ctx context.Context, m *MutedStory, embedding []float32, createdBy uuid.UUID) error {
+	if m.HitID == nil {
+		return fmt.Errorf("muted story create: hit is required")
+	}
+	if m.Entities == nil {
+		m.Entities = []string{}
+	}
+	var vec *string
+	if len(embedding) > 0 {
+		v := formatVector(embedding)
+		vec = &v
+	}
+
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("muted story create: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO watchlist_muted_stories (org_id, hit_id, title, canonical_url, entities,
+		                                     embedding, created_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5,
+		        COALESCE($6::vector, (SELECT embedding FROM watchlist_hits WHERE id = $2)), $7, $8)
+		RETURNING id, created_at
+	`, m.OrgID, *m.HitID, m.Title, m.CanonicalURL, m.Entities, vec, createdBy, m.ExpiresAt,
+	).Scan(&m.ID, &m.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("muted story create: %w", err)
+	}
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE watchlist_hits wh SET muted_by = $1, seen = true
+		FROM watchlist_hits src
+		WHERE src.id = $2 AND wh.muted_by IS NULL
+		  AND (wh.id = src.id OR (src.story_id IS NOT NULL AND wh.story_id = src.story_id))
+		  AND EXISTS (SELECT 1 FROM watchlist_hit_orgs l WHERE l.hit_id = wh.id AND l.org_id = $3)
+	`, m.ID, *m.HitID, m.OrgID)
+	if err != nil {
+		return fmt.Errorf("muted story create: hide story hits: %w", err)
+	}
+	m.MutedHits = int(tag.RowsAffected())
+	if _, err := tx.Exec(ctx, `
+		UPDATE watchlist_muted_stories SET muted_hits = $2 WHERE id = $1
+	`, m.ID, m.MutedHits); err != nil {
+		return fmt.Errorf("muted story create: %w", err)
+	}
+	m.Active = true
+	return tx.Commit(ctx)
+}
+
+// ListMutedStories returns the org's muted stories, newest first. Expired
+// ones are included only when includeExpired is set.
+func (s *WatchlistHitStore) ListMutedStories(ctx context.Context, orgID uuid.UUID, includeExpired bool) ([]MutedStory, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+mutedStoryColumns+`
+		FROM watchlist_muted_stories m
+		WHERE m.org_id = $1 AND ($2 OR m.expires_at > NOW())
+		ORDER BY m.created_at DESC
+	`, orgID, includeExpired)
+	if err != nil {
+		return nil, fmt.Errorf("muted stories list: %w", err)
+	}
+	defer rows.Close()
+
+	var mutes []MutedStory
+	for rows.Next() {
+		var m MutedStory
+		if err := scanMutedStory(rows, &m); err != nil {
+			return nil, fmt.Errorf("muted story scan: %w", err)
+		}
+		mutes = append(mutes, m)
+	}
+	return mutes, rows.Err()
+}
+
+// DeleteMutedStory revokes one of the org's mutes. The hits it hid become
+// visible again.
+func (s *WatchlistHitStore) DeleteMutedStory(ctx context.Context, orgID, id uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `
+		DELETE FROM watchlist_muted_stories WHERE id = $1 AND org_id = $2
+	`, id, orgID)
+	if err != nil {
+		return fmt.Errorf("muted story delete: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrMuteNotFound
+	}
+	return nil
+}
+
+// MatchMutedStories returns the active mutes of the orgs a hit is linked to
+// whose canonical URL equals canonicalURL or whose embedding is within
+// maxDistance (cosine) of the given one, closest first. embedding may be nil
+// to match by URL only.
+func (s *WatchlistHitStore) MatchMutedStories(ctx context.Context, hitID uuid.UUID, canonicalURL string, embedding []float32, maxDistance float64) ([]MutedStory, error) {
+	var vec *string
+	if len(embedding) > 0 {
+		v := formatVector(embedding)
+		vec = &v
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+mutedStoryColumns+`
+		FROM watchlist_muted_stories m
+		JOIN watchlist_hit_orgs l ON l.org_id = m.org_id AND l.hit_id = $1
+		WHERE m.expires_at > NOW()
+		  AND ((m.canonical_url <> '' AND m.canonical_url = $2)
+		    OR ($3::vector IS NOT NULL AND m.embedding IS NOT NULL AND (m.embedding <=> $3::vector) <= $4))
+		ORDER BY (m.canonical_url <> '' AND m.canonical_url = $2) DESC,
+		         m.embedding <=> $3::vector NULLS LAST
+	`, hitID, canonicalURL, vec, maxDistance)
+	if err != nil {
+		return nil, fmt.Errorf("muted stories match: %w", err)
+	}
+	defer rows.Close()
+
+	var mutes []MutedStory
+	for rows.Next() {
+		var m MutedStory
+		if err := scanMutedStory(rows, &m); err != nil {
+			return nil, fmt.Errorf("muted story scan: %w", err)
+		}
+		mutes = append(mutes, m)
+	}
+	return mutes, rows.Err()
+}
+
+// HideMutedHit marks a hit seen and hidden by a mute, and counts it against
+// the mute.
+func (s *WatchlistHitStore) HideMutedHit(ctx context.Context, hitID, muteID uuid.UUID) error {
+	_, err := s.pool.Exec(ctx, `
+		WITH hidden AS (
+			UPDATE watchlist_hits SET muted_by = $2, seen = true
+			WHERE id = $1 AND muted_by IS NULL
+			RETURNING id
+		)
+		UPDATE watchlist_muted_stories SET muted_hits = muted_hits + (SELECT COUNT(*) FROM hidden)
+		WHERE id = $2
+	`, hitID, muteID)
+	if err != nil {
+		return fmt.Errorf("watchlist hit hide muted: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ErrReportNotFound is returned when a watchlist report does not exist or
+// belongs to another user.
+var ErrReportNotFound = errors.New("watchlist report not found")
+
+// WatchlistReport is a user's weekly watchlist report: hit counts and
+// sentiment per org, and the notable negative hits with their responses.
+type WatchlistReport struct {
+	ID          uuid.UUID            `json:"id"`
+	UserID      uuid.UUID            `json:"user_id"`
+	PeriodStart time.Time            `json:"period_start"`
+	PeriodEnd   time.Time            `json:"period_end"`
+	HitCount    int                  `json:"hit_count"`
+	Positive    int                  `json:"positive"`
+	Negative    int                  `json:"negative"`
+	Neutral     int                  `json:"neutral"`
+	Orgs        []WatchlistReportOrg `json:"orgs"`
+	NotableHits []WatchlistReportHit `json:"notable_hits"`
+	Summary     string               `json:"summary"` // plain-text rendering
+	CreatedAt   time.Time            `json:"created_at"`
+}
+
+// WatchlistReportOrg holds one org's hit counts within a report.
+type WatchlistReportOrg struct {
+	OrgID    uuid.UUID `json:"org_id"`
+	OrgName  string    `json:"org_name"`
+	Hits     int       `json:"hits"`
+	Positive int       `json:"positive"`
+	Negative int       `json:"negative"`
+	Neutral  int       `json:"neutral"`
+}
+
+// WatchlistReportHit is the slice of a negative WatchlistHit kept in a
+// report, with its response as it stood when the report was generated.
+type WatchlistReportHit struct {
+	ID          uuid.UUID `json:"id"`
+	OrgID       uuid.UUID `json:"org_id"`
+	OrgName     string    `json:"org_name"`
+	Title       string    `json:"title"`
+	URL         string    `json:"url"`
+	SourceType  string    `json:"source_type"`
+	DupCount    int       `json:"dup_count"`
+	DraftStatus string    `json:"draft_status,omitempty"`
+	Response    string    `json:"response,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type WatchlistReportStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewWatchlistReportStore(pool *pgxpool.Pool) *WatchlistReportStore {
+	return &WatchlistReportStore{pool: pool}
+}
+
+// Create stores the report. It returns false, leaving the report unsaved,
+// when the user already has a report for the same period.
+func (s *WatchlistReportStore) Create(ctx context.Context, rep *WatchlistReport) (bool, error) {
+	if rep.ID == uuid.Nil {
+		rep.ID = uuid.New()
+	}
+	orgs, err := json.Marshal(rep.Orgs)
+	if err != nil {
+		return false, fmt.Errorf("watchlist report create: marshal orgs: %w", err)
+	}
+	notable, err := json.Marshal(rep.NotableHits)
+	if err != nil {
+		return false, fmt.Errorf("watchlist report create: marshal hits: %w", err)
+	}
+
+	err = s.pool.QueryRow(ctx, `
+		INSERT INTO watchlist_reports
+			(id, user_id, period_start, period_end, hit_count, positive, negative, neutral,
+			 orgs, notable_hits, summary)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (user_id, period_start) DO NOTHING
+		RETURNING created_at
+	`, rep.ID, rep.UserID, rep.PeriodStart, rep.PeriodEnd, rep.HitCount,
+		rep.Positive, rep.Negative, rep.Neutral, orgs, notable, rep.Summary).Scan(&rep.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("watchlist report create: %w", err)
+	}
+	return true, nil
+}
+
+// ListByUser returns the user's reports, newest period first, without their
+// org breakdown, notable hits or summary.
+func (s *WatchlistReportStore) ListByUser(ctx context.Context, userID uuid.UUID, limit, offset int) ([]WatchlistReport, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT id, user_id, period_start, period_end, hit_count, positive, negative, neutral, created_at
+		FROM watchlist_reports
+		WHERE user_id = $1
+		ORDER BY period_start DESC
+		LIMIT $2 OFFSET $3
+	`, userID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist reports list: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []WatchlistReport
+	for rows.Next() {
+		var rep WatchlistReport
+		if err := rows.Scan(&rep.ID, &rep.UserID, &rep.PeriodStart, &rep.PeriodEnd,
+			&rep.HitCount, &rep.Positive, &rep.Negative, &rep.Neutral, &rep.CreatedAt); err != nil {
+			return nil, fmt.Errorf("watchlist reports list scan: %w", err)
+		}
+		reports = append(reports, rep)
+	}
+	return reports, rows.Err()
+}
+
+// GetForUser returns one of the user's reports in full, or ErrReportNotFound.
+func (s *WatchlistReportStore) GetForUser(ctx context.Context, userID, id uuid.UUID) (*WatchlistReport, error) {
+	var rep WatchlistReport
+	var orgs, notable []byte
+	err := s.pool.QueryRow(ctx, `
+		SELECT id, user_id, period_start, period_end, hit_count, positive, negative, neutral,
+		       orgs, notable_hits, summary, created_at
+		FROM watchlist_reports
+		WHERE id = $1 AND user_id = $2
+	`, id, userID).Scan(&rep.ID, &rep.UserID, &rep.PeriodStart, &rep.PeriodEnd,
+		&rep.HitCount, &rep.Positive, &rep.Negative, &rep.Neutral,
+		&orgs, &notable, &rep.Summary, &rep.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrReportNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("watchlist report get: %w", err)
+	}
+	if err := json.Unmarshal(orgs, &rep.Orgs); err != nil {
+		return nil, fmt.Errorf("watchlist report get: decode orgs: %w", err)
+	}
+	if err := json.Unmarshal(notable, &rep.NotableHits); err != nil {
+		return nil, fmt.Errorf("watchlist report get: decode hits: %w", err)
+	}
+	return &rep, nil
+}
+
+// CountByOrg returns the user's hit counts per org for hits created in
+// [from, to), excluding syndicated duplicates and muted hits, busiest org
+// first. Hits count toward the org that found them first.
+func (s *WatchlistHitStore) CountByOrg(ctx context.Context, userID uuid.UUID, from, to time.Time) ([]WatchlistReportOrg, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT wh.org_id, wo.name, COUNT(*),
+		       COUNT(*) FILTER (WHERE wh.sentiment = 'positive'),
+		       COUNT(*) FILTER (WHERE wh.sentiment = 'negative'),
+		       COUNT(*) FILTER (WHERE wh.sentiment NOT IN ('positive', 'negative'))
+		FROM watchlist_hits wh
+		JOIN watchlist_orgs wo ON wo.id = wh.org_id
+		WHERE wo.user_id = $1 AND wh.duplicate_of IS NULL AND wh.muted_by IS NULL
+		  AND wh.created_at >= $2 AND wh.created_at < $3
+		GROUP BY wh.org_id, wo.name
+		ORDER BY COUNT(*) DESC, wo.name ASC
+	`, userID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hits count by org: %w", err)
+	}
+	defer rows.Close()
+
+	var orgs []WatchlistReportOrg
+	for rows.Next() {
+		var o WatchlistReportOrg
+		if err := rows.Scan(&o.OrgID, &o.OrgName, &o.Hits, &o.Positive, &o.Negative, &o.Neutral); err != nil {
+			return nil, fmt.Errorf("watchlist hits count by org scan: %w", err)
+		}
+		orgs = append(orgs, o)
+	}
+	return orgs, rows.Err()
+}
+
+// ListNotableNegative returns the user's negative hits created in [from, to),
+// excluding syndicated duplicates and muted hits: hits with a response draft
+// first, then the most syndicated, then the newest.
+func (s *WatchlistHitStore) ListNotableNegative(ctx context.Context, userID uuid.UUID, from, to time.Time, limit int) ([]WatchlistHit, error) {
+	if limit <= 0 {
+		limit = 10
+	}
+	rows, err := s.pool.Query(ctx, `
+		SELECT wh.id, wh.org_id, wo.name, wh.source_type, wh.title, wh.url, wh.url_hash,
+		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+		       wh.content_hash, wh.dup_count,
+		       COALESCE(wh.draft_status, ''), wh.draft_text, wh.draft_updated_at,
+		       wh.draft_approved_at, wh.draft_sent_at, wh.article_id,
+		       wh.sentiment_verified_at
+		FROM watchlist_hits wh
+		JOIN watchlist_orgs wo ON wo.id = wh.org_id
+		WHERE wo.user_id = $1 AND wh.duplicate_of IS NULL AND wh.muted_by IS NULL
+		  AND wh.sentiment = 'negative'
+		  AND wh.created_at >= $2 AND wh.created_at < $3
+		ORDER BY (wh.draft_text IS NOT NULL OR wh.ai_draft IS NOT NULL) DESC,
+		         wh.dup_count DESC, wh.created_at DESC
+		LIMIT $4
+	`, userID, from, to, limit)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hits list notable negative: %w", err)
+	}
+	defer rows.Close()
+	return scanHitRows(rows)
+}
@@ -0,0 +1,80 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// ValidSentiment reports whether sentiment can be given to a hit by hand.
+func ValidSentiment(sentiment string) bool {
+	switch sentiment {
+	case "positive", "neutral", "negative":
+		return true
+	}
+	return false
+}
+
+// VerifySentiment sets the sentiment of one of the user's hits as verified by
+// that user. Verified hits are skipped by automatic classification.
+func (s *WatchlistHitStore) VerifySentiment(ctx context.Context, userID, hitID uuid.UUID, sentiment string) (time.Time, error) {
+	var verifiedAt time.Time
+	err := s.pool.QueryRow(ctx, `
+		UPDATE watchlist_hits wh
+		SET sentiment = $3, sentiment_verified_at = NOW(), sentiment_verified_by = $2
+		FROM watchlist_orgs wo
+		WHERE wo.id = wh.org_id AND wh.id = $1 AND wo.user_id = $2
+		RETURNING wh.sentiment_verified_at
+	`, hitID, userID, sentiment).Scan(&verifiedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, ErrHitNotFound
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("watchlist hit verify sentiment: %w", err)
+	}
+	return verifiedAt, nil
+}
+
+// CountForReclassify counts the hits ListForReclassify walks through.
+func (s *WatchlistHitStore) CountForReclassify(ctx context.Context, from, to time.Time) (int, error) {
+	var n int
+	err := s.pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM watchlist_hits
+		WHERE created_at >= $1 AND created_at < $2
+		  AND sentiment_verified_at IS NULL AND duplicate_of IS NULL
+	`, from, to).Scan(&n)
+	if err != nil {
+		return 0, fmt.Errorf("watchlist hits count for reclassify: %w", err)
+	}
+	return n, nil
+}
+
+// ListForReclassify returns a page of the hits created in [from, to) whose
+// sentiment no person has verified, by id after the given one (uuid.Nil for
+// the first page). Folded duplicates are left out.
+func (s *WatchlistHitStore) ListForReclassify(ctx context.Context, from, to time.Time, after uuid.UUID, limit int) ([]WatchlistHit, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT wh.id, wh.org_id, wo.name, wh.source_type, wh.title, wh.url, wh.url_hash,
+		       wh.snippet, wh.sentiment, wh.ai_draft, wh.seen, wh.created_at,
+		       wh.content_hash, wh.dup_count,
+		       COALESCE(wh.draft_status, ''), wh.draft_text, wh.draft_updated_at,
+		       wh.draft_approved_at, wh.draft_sent_at, wh.article_id,
+		       wh.sentiment_verified_at
+		FROM watchlist_hits wh
+		JOIN watchlist_orgs wo ON wo.id = wh.org_id
+		WHERE wh.created_at >= $1 AND wh.created_at < $2
+		  AND wh.sentiment_verified_at IS NULL AND wh.duplicate_of IS NULL
+		  AND wh.id > $3
+		ORDER BY wh.id
+		LIMIT $4
+	`, from, to, after, limit)
+	if err != nil {
+		return nil, fmt.Errorf("watchlist hits list for reclassify: %w", err)
+	}
+	defer rows.Close()
+	return scanHitRows(rows)
+}
@@ -0,0 +1,370 @@
+package models
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/Saul-Punybz/folio/internal/config"
+	"github.com/Saul-Punybz/folio/internal/db"
+)
+
+func TestHitContentHash(t *testing.T) {
+	long := "El alcalde anunció hoy un plan de repavimentación para las carreteras municipales"
+
+	tests := []struct {
+		name           string
+		title, snippet string
+		same           [2]string // title and snippet expected to hash the same
+		wantEmpty      bool
+	}{
+		{
+			name:    "markup, case and punctuation are ignored",
+			snippet: "<b>El alcalde</b> anunció hoy un plan de repavimentación, para las carreteras municipales!",
+			same:    [2]string{"", strings.ToUpper(long)},
+		},
+		{
+			name:    "entities and whitespace are ignored",
+			snippet: "El alcalde&nbsp;anunció   hoy un plan de repavimentación\npara las carreteras municipales",
+			same:    [2]string{"", long},
+		},
+		{
+			name:    "a long snippet ignores the title",
+			title:   "Titular A",
+			snippet: long,
+			same:    [2]string{"Titular B", long},
+		},
+		{
+			name:    "a short snippet is hashed with the title",
+			title:   "El alcalde anunció un plan de repavimentación",
+			snippet: "Carreteras municipales",
+			same:    [2]string{"el alcalde anunció un plan de repavimentación", "carreteras, municipales"},
+		},
+		{
+			name:      "too little text has no hash",
+			title:     "Breve",
+			snippet:   "<p>Corto</p>",
+			wantEmpty: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HitContentHash(tt.title, tt.snippet)
+			if tt.wantEmpty {
+				if got != "" {
+					t.Fatalf("HitContentHash = %q, want empty", got)
+				}
+				return
+			}
+			if got == "" {
+				t.Fatal("HitContentHash is empty")
+			}
+			if want := HitContentHash(tt.same[0], tt.same[1]); got != want {
+				t.Errorf("HitContentHash(%q, %q) = %s, want %s (hash of %q, %q)",
+					tt.title, tt.snippet, got, want, tt.same[0], tt.same[1])
+			}
+		})
+	}
+}
+
+// testPool connects to the database in FOLIO_TEST_DSN, applying the
+// migrations, or skips the test when it is unset.
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+	dsn := os.Getenv("FOLIO_TEST_DSN")
+	if dsn == "" {
+		t.Skip("FOLIO_TEST_DSN not set")
+	}
+	pool, err := db.ConnectWithDSN(context.Background(), dsn, config.DBConfig{}, os.DirFS("../../migrations"))
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// testOrgs creates a user with n watchlist orgs, deleted with the user when
+// the test ends.
+func testOrgs(t *testing.T, pool *pgxpool.Pool, n int) (uuid.UUID, []uuid.UUID) {
+	t.Helper()
+	ctx := context.Background()
+	user := &User{Email: "watchlist-test-" + uuid.NewString() + "@example.com", PasswordHash: "x"}
+	if err := NewUserStore(pool).Create(ctx, user); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	t.Cleanup(func() {
+		pool.Exec(context.Background(), `DELETE FROM users WHERE id = $1`, user.ID)
+	})
+
+	orgs := NewWatchlistOrgStore(pool)
+	ids := make([]uuid.UUID, n)
+	for i := range ids {
+		org := &WatchlistOrg{UserID: user.ID, Name: "Org " + string(rune('A'+i)), Active: true, Priority: 1}
+		if err := orgs.Create(ctx, org); err != nil {
+			t.Fatalf("create org: %v", err)
+		}
+		ids[i] = org.ID
+	}
+	return user.ID, ids
+}
+
+// hitStep is one WatchlistHitStore.Create call: the org (by index) that
+// found url, and the hit's snippet.
+type hitStep struct {
+	org     int
+	url     string
+	snippet string
+}
+
+func createHits(t *testing.T, hits *WatchlistHitStore, orgIDs []uuid.UUID, steps []hitStep) []bool {
+	t.Helper()
+	created := make([]bool, len(steps))
+	for i, s := range steps {
+		hit := &WatchlistHit{
+			OrgID:      orgIDs[s.org],
+			SourceType: "web",
+			Title:      "Título " + s.url,
+			URL:        s.url,
+			URLHash:    s.url,
+			Snippet:    s.snippet,
+			Sentiment:  "unknown",
+		}
+		if err := hits.Create(context.Background(), hit); err != nil {
+			t.Fatalf("step %d: create: %v", i, err)
+		}
+		created[i] = hit.ID != uuid.Nil
+	}
+	return created
+}
+
+// hitState returns the primary org (by index, -1 when the hit is gone), the
+// linked orgs in link order and the dup_count of the user's hit for url.
+func hitState(t *testing.T, pool *pgxpool.Pool, userID uuid.UUID, orgIDs []uuid.UUID, url string) (int, []int, int) {
+	t.Helper()
+	ctx := context.Background()
+	orgIndex := func(id uuid.UUID) int {
+		for i, o := range orgIDs {
+			if o == id {
+				return i
+			}
+		}
+		t.Fatalf("unknown org %s", id)
+		return -1
+	}
+
+	var primary uuid.UUID
+	var dupCount int
+	err := pool.QueryRow(ctx, `
+		SELECT org_id, dup_count FROM watchlist_hits WHERE user_id = $1 AND url_hash = $2
+	`, userID, url).Scan(&primary, &dupCount)
+	if err != nil {
+		return -1, nil, 0
+	}
+
+	rows, err := pool.Query(ctx, `
+		SELECT l.org_id
+		FROM watchlist_hit_orgs l
+		JOIN watchlist_hits wh ON wh.id = l.hit_id
+		WHERE wh.user_id = $1 AND wh.url_hash = $2
+		ORDER BY l.linked_at ASC
+	`, userID, url)
+	if err != nil {
+		t.Fatalf("links: %v", err)
+	}
+	defer rows.Close()
+	var linked []int
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			t.Fatalf("links scan: %v", err)
+		}
+		linked = append(linked, orgIndex(id))
+	}
+	return orgIndex(primary), linked, dupCount
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestWatchlistHitStoreCreate(t *testing.T) {
+	pool := testPool(t)
+	snippet := "La junta aprobó el presupuesto del próximo año fiscal tras una larga sesión"
+
+	tests := []struct {
+		name        string
+		steps       []hitStep
+		wantCreated []bool
+		url         string // hit checked after the steps
+		wantPrimary int
+		wantLinked  []int
+		wantDups    int
+	}{
+		{
+			name:        "new URL is stored and linked to its org",
+			steps:       []hitStep{{0, "https://a.example/1", snippet}},
+			wantCreated: []bool{true},
+			url:         "https://a.example/1",
+			wantPrimary: 0,
+			wantLinked:  []int{0},
+		},
+		{
+			name:        "same URL from another org is linked, not stored again",
+			steps:       []hitStep{{0, "https://a.example/1", snippet}, {1, "https://a.example/1", snippet}},
+			wantCreated: []bool{true, false},
+			url:         "https://a.example/1",
+			wantPrimary: 0,
+			wantLinked:  []int{0, 1},
+		},
+		{
+			name:        "same URL from the same org is ignored",
+			steps:       []hitStep{{0, "https://a.example/1", snippet}, {0, "https://a.example/1", snippet}},
+			wantCreated: []bool{true, false},
+			url:         "https://a.example/1",
+			wantPrimary: 0,
+			wantLinked:  []int{0},
+		},
+		{
+			name:        "same content under another URL folds into the original",
+			steps:       []hitStep{{0, "https://a.example/1", snippet}, {0, "https://b.example/2", snippet}},
+			wantCreated: []bool{true, false},
+			url:         "https://a.example/1",
+			wantPrimary: 0,
+			wantLinked:  []int{0},
+			wantDups:    1,
+		},
+		{
+			name:        "same content found by another org is not folded",
+			steps:       []hitStep{{0, "https://a.example/1", snippet}, {1, "https://b.example/2", snippet}},
+			wantCreated: []bool{true, true},
+			url:         "https://a.example/1",
+			wantPrimary: 0,
+			wantLinked:  []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, orgIDs := testOrgs(t, pool, 2)
+			created := createHits(t, NewWatchlistHitStore(pool), orgIDs, tt.steps)
+			for i := range created {
+				if created[i] != tt.wantCreated[i] {
+					t.Errorf("step %d: created = %v, want %v", i, created[i], tt.wantCreated[i])
+				}
+			}
+
+			primary, linked, dups := hitState(t, pool, userID, orgIDs, tt.url)
+			if primary != tt.wantPrimary {
+				t.Errorf("primary org = %d, want %d", primary, tt.wantPrimary)
+			}
+			if !equalInts(linked, tt.wantLinked) {
+				t.Errorf("linked orgs = %v, want %v", linked, tt.wantLinked)
+			}
+			if dups != tt.wantDups {
+				t.Errorf("dup_count = %d, want %d", dups, tt.wantDups)
+			}
+		})
+	}
+}
+
+func TestWatchlistOrgStoreDelete(t *testing.T) {
+	pool := testPool(t)
+	const url = "https://a.example/1"
+
+	tests := []struct {
+		name        string
+		orgs        int
+		steps       []hitStep
+		delete      int
+		wantPrimary int // -1: the hit is deleted
+		wantLinked  []int
+	}{
+		{
+			name:        "a hit only the org has is deleted with it",
+			orgs:        2,
+			steps:       []hitStep{{0, url, ""}},
+			delete:      0,
+			wantPrimary: -1,
+		},
+		{
+			name:        "a shared hit moves to the other org",
+			orgs:        2,
+			steps:       []hitStep{{0, url, ""}, {1, url, ""}},
+			delete:      0,
+			wantPrimary: 1,
+			wantLinked:  []int{1},
+		},
+		{
+			name:        "a shared hit moves to the org linked earliest",
+			orgs:        3,
+			steps:       []hitStep{{0, url, ""}, {2, url, ""}, {1, url, ""}},
+			delete:      0,
+			wantPrimary: 2,
+			wantLinked:  []int{2, 1},
+		},
+		{
+			name:        "deleting a linked org keeps the primary",
+			orgs:        2,
+			steps:       []hitStep{{0, url, ""}, {1, url, ""}},
+			delete:      1,
+			wantPrimary: 0,
+			wantLinked:  []int{0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userID, orgIDs := testOrgs(t, pool, tt.orgs)
+			createHits(t, NewWatchlistHitStore(pool), orgIDs, tt.steps)
+
+			if err := NewWatchlistOrgStore(pool).Delete(context.Background(), orgIDs[tt.delete]); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+
+			primary, linked, _ := hitState(t, pool, userID, orgIDs, url)
+			if primary != tt.wantPrimary {
+				t.Errorf("primary org = %d, want %d", primary, tt.wantPrimary)
+			}
+			if !equalInts(linked, tt.wantLinked) {
+				t.Errorf("linked orgs = %v, want %v", linked, tt.wantLinked)
+			}
+		})
+	}
+}
+
+func TestWatchlistOrgStoreImport(t *testing.T) {
+	pool := testPool(t)
+	ctx := context.Background()
+	userID, _ := testOrgs(t, pool, 1) // already watches "Org A"
+	store := NewWatchlistOrgStore(pool)
+
+	result, err := store.Import(ctx, userID, []WatchlistOrg{
+		{Name: "org a", Priority: 1},
+		{Name: "Semántica", Priority: 2, SemanticWatch: true, SemanticThreshold: 0.8},
+		{Name: "semántica", Priority: 1},
+	})
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(result.Created) != 1 || strings.Join(result.Skipped, "|") != "org a|semántica" {
+		t.Fatalf("created %d, skipped %q; want 1 created and the duplicates skipped", len(result.Created), result.Skipped)
+	}
+
+	got, err := store.GetByID(ctx, result.Created[0].ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if !got.Active || got.Priority != 2 || !got.SemanticWatch || got.SemanticThreshold != 0.8 {
+		t.Errorf("imported org = %+v, want active, priority 2, semantic watch at 0.8", got)
+	}
+}
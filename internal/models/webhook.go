@@ -0,0 +1,205 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Webhook target kinds. Slack and Discord receive a formatted chat message;
+// generic targets receive the hit as JSON.
+const (
+	WebhookSlack   = "slack"
+	WebhookDiscord = "discord"
+	WebhookGeneric = "generic"
+)
+
+// WebhookTarget is a user-registered endpoint that new watchlist hits are
+// posted to. Empty Sentiments/SourceTypes match every hit.
+type WebhookTarget struct {
+	ID          uuid.UUID `json:"id"`
+	UserID      uuid.UUID `json:"user_id"`
+	Name        string    `json:"name"`
+	URL         string    `json:"url"`
+	Kind        string    `json:"kind"`
+	Sentiments  []string  `json:"sentiments"`
+	SourceTypes []string  `json:"source_types"`
+	Secret      string    `json:"-"`
+	HasSecret   bool      `json:"has_secret"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// Matches reports whether hit passes the target's sentiment and source type
+// filters.
+func (t *WebhookTarget) Matches(hit WatchlistHit) bool {
+	return matchesFilter(t.Sentiments, hit.Sentiment) && matchesFilter(t.SourceTypes, hit.SourceType)
+}
+
+func matchesFilter(allowed []string, value string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if a == value {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookStore provides database operations for webhook targets.
+type WebhookStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewWebhookStore creates a new WebhookStore.
+func NewWebhookStore(pool *pgxpool.Pool) *WebhookStore {
+	return &WebhookStore{pool: pool}
+}
+
+const webhookColumns = `id, user_id, name, url, kind, sentiments, source_types, secret, active, created_at`
+
+func scanWebhook(row scannable) (*WebhookTarget, error) {
+	var t WebhookTarget
+	var sentRaw, srcRaw []byte
+	if err := row.Scan(&t.ID, &t.UserID, &t.Name, &t.URL, &t.Kind, &sentRaw, &srcRaw, &t.Secret, &t.Active, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+	t.Sentiments = scanJSONStringSlice(sentRaw)
+	t.SourceTypes = scanJSONStringSlice(srcRaw)
+	t.HasSecret = t.Secret != ""
+	return &t, nil
+}
+
+func (s *WebhookStore) list(ctx context.Context, query string, args ...any) ([]WebhookTarget, error) {
+	rows, err := s.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("webhooks list: %w", err)
+	}
+	defer rows.Close()
+
+	var targets []WebhookTarget
+	for rows.Next() {
+		t, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("webhooks scan: %w", err)
+		}
+		targets = append(targets, *t)
+	}
+	return targets, rows.Err()
+}
+
+// ListByUser returns all of a user's webhook targets.
+func (s *WebhookStore) ListByUser(ctx context.Context, userID uuid.UUID) ([]WebhookTarget, error) {
+	return s.list(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhook_targets
+		WHERE user_id = $1
+		ORDER BY created_at ASC
+	`, userID)
+}
+
+// ListActive returns every active webhook target across users.
+func (s *WebhookStore) ListActive(ctx context.Context) ([]WebhookTarget, error) {
+	return s.list(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhook_targets
+		WHERE active = true
+		ORDER BY user_id, created_at ASC
+	`)
+}
+
+// GetByID returns a webhook target, or nil if it does not exist.
+func (s *WebhookStore) GetByID(ctx context.Context, id uuid.UUID) (*WebhookTarget, error) {
+	t, err := scanWebhook(s.pool.QueryRow(ctx, `
+		SELECT `+webhookColumns+` FROM webhook_targets WHERE id = $1
+	`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("webhook get: %w", err)
+	}
+	return t, nil
+}
+
+// Create inserts a new webhook target.
+func (s *WebhookStore) Create(ctx context.Context, t *WebhookTarget) error {
+	if t.ID == uuid.Nil {
+		t.ID = uuid.New()
+	}
+	sentJSON, srcJSON, err := marshalWebhookFilters(t)
+	if err != nil {
+		return fmt.Errorf("webhook create: %w", err)
+	}
+
+	err = s.pool.QueryRow(ctx, `
+		INSERT INTO webhook_targets (id, user_id, name, url, kind, sentiments, source_types, secret, active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING created_at
+	`, t.ID, t.UserID, t.Name, t.URL, t.Kind, sentJSON, srcJSON, t.Secret, t.Active).Scan(&t.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("webhook create: %w", err)
+	}
+	t.HasSecret = t.Secret != ""
+	return nil
+}
+
+// Update saves a webhook target's editable fields.
+func (s *WebhookStore) Update(ctx context.Context, t *WebhookTarget) error {
+	sentJSON, srcJSON, err := marshalWebhookFilters(t)
+	if err != nil {
+		return fmt.Errorf("webhook update: %w", err)
+	}
+
+	tag, err := s.pool.Exec(ctx, `
+		UPDATE webhook_targets
+		SET name = $2, url = $3, kind = $4, sentiments = $5, source_types = $6, secret = $7, active = $8
+		WHERE id = $1
+	`, t.ID, t.Name, t.URL, t.Kind, sentJSON, srcJSON, t.Secret, t.Active)
+	if err != nil {
+		return fmt.Errorf("webhook update: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("webhook not found: %s", t.ID)
+	}
+	t.HasSecret = t.Secret != ""
+	return nil
+}
+
+// Delete removes a webhook target.
+func (s *WebhookStore) Delete(ctx context.Context, id uuid.UUID) error {
+	tag, err := s.pool.Exec(ctx, `DELETE FROM webhook_targets WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("webhook delete: %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("webhook not found: %s", id)
+	}
+	return nil
+}
+
+func marshalWebhookFilters(t *WebhookTarget) ([]byte, []byte, error) {
+	if t.Sentiments == nil {
+		t.Sentiments = []string{}
+	}
+	if t.SourceTypes == nil {
+		t.SourceTypes = []string{}
+	}
+	sentJSON, err := json.Marshal(t.Sentiments)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal sentiments: %w", err)
+	}
+	srcJSON, err := json.Marshal(t.SourceTypes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("marshal source types: %w", err)
+	}
+	return sentJSON, srcJSON, nil
+}
@@ -0,0 +1,333 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// WorkerJob is a cron job registered by the worker, with the outcome of its
+// last run.
+type WorkerJob struct {
+	Name           string     `json:"name"`
+	Schedule       string     `json:"schedule"`
+	Paused         bool       `json:"paused"`
+	Running        int        `json:"running"` // runs in progress
+	RegisteredAt   time.Time  `json:"registered_at"`
+	LastStartedAt  *time.Time `json:"last_started_at,omitempty"`
+	LastFinishedAt *time.Time `json:"last_finished_at,omitempty"`
+	LastTrigger    string     `json:"last_trigger,omitempty"` // schedule, manual, startup
+	LastStatus     string     `json:"last_status,omitempty"`  // ok, timeout, canceled, failed
+	LastError      string     `json:"last_error,omitempty"`
+	LastDurationMS int        `json:"last_duration_ms"`
+	RunCount       int        `json:"run_count"`
+
+	// NextRunAt is computed from the schedule when listing; nil while paused.
+	NextRunAt *time.Time `json:"next_run_at,omitempty"`
+}
+
+// WorkerCommand is a request to run a worker job now, queued by the API and
+// executed by the worker.
+type WorkerCommand struct {
+	ID          uuid.UUID  `json:"id"`
+	Job         string     `json:"job"`
+	RequestedBy *uuid.UUID `json:"requested_by,omitempty"`
+	Status      string     `json:"status"` // pending, running, done, failed
+	Error       string     `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// WorkerJobStore provides data access methods for worker_jobs and
+// worker_commands.
+type WorkerJobStore struct {
+	pool *pgxpool.Pool
+}
+
+// NewWorkerJobStore creates a new WorkerJobStore.
+func NewWorkerJobStore(pool *pgxpool.Pool) *WorkerJobStore {
+	return &WorkerJobStore{pool: pool}
+}
+
+const workerJobColumns = `name, schedule, paused, running, registered_at, last_started_at, last_finished_at,
+	last_trigger, last_status, last_error, last_duration_ms, run_count`
+
+func scanWorkerJob(row scannable) (*WorkerJob, error) {
+	var j WorkerJob
+	err := row.Scan(&j.Name, &j.Schedule, &j.Paused, &j.Running, &j.RegisteredAt, &j.LastStartedAt, &j.LastFinishedAt,
+		&j.LastTrigger, &j.LastStatus, &j.LastError, &j.LastDurationMS, &j.RunCount)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+const workerCommandColumns = `id, job, requested_by, status, error, created_at, started_at, finished_at`
+
+func scanWorkerCommand(row scannable) (*WorkerCommand, error) {
+	var c WorkerCommand
+	if err := row.Scan(&c.ID, &c.Job, &c.RequestedBy, &c.Status, &c.Error, &c.CreatedAt, &c.StartedAt, &c.FinishedAt); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Register records the worker's jobs and their schedules on startup. Run
+// counters are reset, and commands left running by a previous worker process
+// are marked failed. Pause flags are kept.
+func (s *WorkerJobStore) Register(ctx context.Context, schedules map[string]string) error {
+	tx, err := s.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("worker jobs register begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	for name, schedule := range schedules {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO worker_jobs (name, schedule)
+			VALUES ($1, $2)
+			ON CONFLICT (name) DO UPDATE
+			SET schedule = EXCLUDED.schedule, registered_at = NOW(), running = 0
+		`, name, schedule)
+		if err != nil {
+			return fmt.Errorf("worker jobs register %s: %w", name, err)
+		}
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE worker_commands
+		SET status = 'failed', error = 'worker restarted', finished_at = NOW()
+		WHERE status = 'running'
+	`)
+	if err != nil {
+		return fmt.Errorf("worker jobs register: fail orphaned commands: %w", err)
+	}
+	return tx.Commit(ctx)
+}
+
+// List returns every registered job, by name.
+func (s *WorkerJobStore) List(ctx context.Context) ([]WorkerJob, error) {
+	rows, err := s.pool.Query(ctx, `SELECT `+workerJobColumns+` FROM worker_jobs ORDER BY name`)
+	if err != nil {
+		return nil, fmt.Errorf("worker jobs list: %w", err)
+	}
+	defer rows.Close()
+
+	var jobs []WorkerJob
+	for rows.Next() {
+		j, err := scanWorkerJob(rows)
+		if err != nil {
+			return nil, fmt.Errorf("worker jobs scan: %w", err)
+		}
+		jobs = append(jobs, *j)
+	}
+	return jobs, rows.Err()
+}
+
+// Get returns a job, or nil if no worker registered it.
+func (s *WorkerJobStore) Get(ctx context.Context, name string) (*WorkerJob, error) {
+	j, err := scanWorkerJob(s.pool.QueryRow(ctx, `SELECT `+workerJobColumns+` FROM worker_jobs WHERE name = $1`, name))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("worker job get: %w", err)
+	}
+	return j, nil
+}
+
+// Paused reports whether a job is paused. Unregistered jobs are not.
+func (s *WorkerJobStore) Paused(ctx context.Context, name string) (bool, error) {
+	var paused bool
+	err := s.pool.QueryRow(ctx, `SELECT paused FROM worker_jobs WHERE name = $1`, name).Scan(&paused)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("worker job paused: %w", err)
+	}
+	return paused, nil
+}
+
+// SetPaused pauses or resumes a job and returns it, or nil if no worker
+// registered it.
+func (s *WorkerJobStore) SetPaused(ctx context.Context, name string, paused bool) (*WorkerJob, error) {
+	j, err := scanWorkerJob(s.pool.QueryRow(ctx, `
+		UPDATE worker_jobs SET paused = $2 WHERE name = $1
+		RETURNING `+workerJobColumns, name, paused))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("worker job set paused: %w", err)
+	}
+	return j, nil
+}
+
+// Started records the start of a run.
+func (s *WorkerJobStore) Started(ctx context.Context, name, trigger string) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE worker_jobs
+		SET running = running + 1, last_started_at = NOW(), last_trigger = $2
+		WHERE name = $1
+	`, name, trigger)
+	if err != nil {
+		return fmt.Errorf("worker job started: %w", err)
+	}
+	return nil
+}
+
+// Finished records the outcome of a run.
+func (s *WorkerJobStore) Finished(ctx context.Context, name, status, errMsg string, duration time.Duration) error {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE worker_jobs
+		SET running = GREATEST(running - 1, 0), last_finished_at = NOW(), last_status = $2,
+		    last_error = $3, last_duration_ms = $4, run_count = run_count + 1
+		WHERE name = $1
+	`, name, status, errMsg, int(duration.Milliseconds()))
+	if err != nil {
+		return fmt.Errorf("worker job finished: %w", err)
+	}
+	return nil
+}
+
+// Enqueue queues a request to run a job now.
+func (s *WorkerJobStore) Enqueue(ctx context.Context, job string, requestedBy *uuid.UUID) (*WorkerCommand, error) {
+	c, err := scanWorkerCommand(s.pool.QueryRow(ctx, `
+		INSERT INTO worker_commands (job, requested_by) VALUES ($1, $2)
+		RETURNING `+workerCommandColumns, job, requestedBy))
+	if err != nil {
+		return nil, fmt.Errorf("worker command enqueue: %w", err)
+	}
+	return c, nil
+}
+
+// EnqueueOnce queues a request to run a job now unless one is already
+// pending or running, in which case that command is returned and queued is
+// false.
+func (s *WorkerJobStore) EnqueueOnce(ctx context.Context, job string, requestedBy *uuid.UUID) (cmd *WorkerCommand, queued bool, err error) {
+	cmd, err = scanWorkerCommand(s.pool.QueryRow(ctx, `
+		SELECT `+workerCommandColumns+`
+		FROM worker_commands
+		WHERE job = $1 AND status IN ('pending', 'running')
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, job))
+	if err == nil {
+		return cmd, false, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, fmt.Errorf("worker command find active: %w", err)
+	}
+	cmd, err = s.Enqueue(ctx, job, requestedBy)
+	if err != nil {
+		return nil, false, err
+	}
+	return cmd, true, nil
+}
+
+// ClaimCommands marks pending commands running and returns them, oldest
+// first. Commands pending for longer than expireAfter (no worker was up to
+// run them) are failed instead, so a worker coming back does not replay
+// stale requests.
+func (s *WorkerJobStore) ClaimCommands(ctx context.Context, expireAfter time.Duration) ([]WorkerCommand, error) {
+	_, err := s.pool.Exec(ctx, `
+		UPDATE worker_commands
+		SET status = 'failed', error = 'expired before a worker picked it up', finished_at = NOW()
+		WHERE status = 'pending' AND created_at < NOW() - make_interval(secs => $1)
+	`, expireAfter.Seconds())
+	if err != nil {
+		return nil, fmt.Errorf("worker commands expire: %w", err)
+	}
+
+	rows, err := s.pool.Query(ctx, `
+		UPDATE worker_commands
+		SET status = 'running', started_at = NOW()
+		WHERE id IN (
+			SELECT id FROM worker_commands
+			WHERE status = 'pending'
+			ORDER BY created_at
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+workerCommandColumns)
+	if err != nil {
+		return nil, fmt.Errorf("worker commands claim: %w", err)
+	}
+	defer rows.Close()
+
+	var cmds []WorkerCommand
+	for rows.Next() {
+		c, err := scanWorkerCommand(rows)
+		if err != nil {
+			return nil, fmt.Errorf("worker commands scan: %w", err)
+		}
+		cmds = append(cmds, *c)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// RETURNING does not follow the subquery's order.
+	sort.Slice(cmds, func(i, j int) bool { return cmds[i].CreatedAt.Before(cmds[j].CreatedAt) })
+	return cmds, nil
+}
+
+// FinishCommand records the outcome of a command: done, or failed with
+// errMsg.
+func (s *WorkerJobStore) FinishCommand(ctx context.Context, id uuid.UUID, errMsg string) error {
+	status := "done"
+	if errMsg != "" {
+		status = "failed"
+	}
+	_, err := s.pool.Exec(ctx, `
+		UPDATE worker_commands SET status = $2, error = $3, finished_at = NOW()
+		WHERE id = $1
+	`, id, status, errMsg)
+	if err != nil {
+		return fmt.Errorf("worker command finish: %w", err)
+	}
+	return nil
+}
+
+// GetCommand returns a command, or nil if it does not exist.
+func (s *WorkerJobStore) GetCommand(ctx context.Context, id uuid.UUID) (*WorkerCommand, error) {
+	c, err := scanWorkerCommand(s.pool.QueryRow(ctx, `SELECT `+workerCommandColumns+` FROM worker_commands WHERE id = $1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("worker command get: %w", err)
+	}
+	return c, nil
+}
+
+// ListCommands returns the most recent commands, newest first, optionally
+// only those for one job.
+func (s *WorkerJobStore) ListCommands(ctx context.Context, job string, limit int) ([]WorkerCommand, error) {
+	rows, err := s.pool.Query(ctx, `
+		SELECT `+workerCommandColumns+`
+		FROM worker_commands
+		WHERE ($1 = '' OR job = $1)
+		ORDER BY created_at DESC
+		LIMIT $2
+	`, job, limit)
+	if err != nil {
+		return nil, fmt.Errorf("worker commands list: %w", err)
+	}
+	defer rows.Close()
+
+	var cmds []WorkerCommand
+	for rows.Next() {
+		c, err := scanWorkerCommand(rows)
+		if err != nil {
+			return nil, fmt.Errorf("worker commands scan: %w", err)
+		}
+		cmds = append(cmds, *c)
+	}
+	return cmds, rows.Err()
+}
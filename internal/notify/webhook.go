@@ -0,0 +1,205 @@
+// Package notify delivers watchlist hits to user-registered webhook targets
+// (Slack, Discord, or generic JSON endpoints).
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+const (
+	deliveryTimeout = 15 * time.Second
+	userAgent       = "Folio-Webhooks/1.0"
+
+	// SignatureHeader carries the hex HMAC-SHA256 of the request body for
+	// generic targets that have a secret configured.
+	SignatureHeader = "X-Folio-Signature"
+)
+
+// httpClient posts deliveries. Its dialer refuses non-public addresses, so
+// a target whose name resolves to an internal host at delivery time (DNS
+// rebinding, or a redirect) is refused as well as one that did when it was
+// registered.
+var httpClient = &http.Client{
+	Timeout: deliveryTimeout,
+	Transport: &http.Transport{
+		DialContext: (&net.Dialer{
+			Timeout: 5 * time.Second,
+			Control: func(network, address string, _ syscall.RawConn) error {
+				host, _, err := net.SplitHostPort(address)
+				if err != nil {
+					return err
+				}
+				if !publicIP(net.ParseIP(host)) {
+					return ErrPrivateTarget
+				}
+				return nil
+			},
+		}).DialContext,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 10 * time.Second,
+	},
+}
+
+// ErrPrivateTarget is returned for webhook URLs whose host is, or resolves
+// to, a loopback, link-local, private or otherwise non-public address.
+var ErrPrivateTarget = errors.New("webhook target is not a public address")
+
+// StatusError is returned by Deliver when the target answers with a non-2xx
+// status. The response body is not kept, so the error can be shown to the
+// user without leaking what the target returned.
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("delivery failed: status %d", e.Code)
+}
+
+// publicIP reports whether ip is a global unicast address outside the
+// private ranges.
+func publicIP(ip net.IP) bool {
+	return ip != nil && ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
+
+// CheckURL resolves the host of a URL Folio will send to or fetch (a webhook
+// target, a tipped article) and returns ErrPrivateTarget unless every address
+// it resolves to is public. Deliver checks again when it dials, so for
+// webhooks this only rejects bad targets early.
+func CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("webhook url: %w", err)
+	}
+	host := u.Hostname()
+	if ip := net.ParseIP(host); ip != nil {
+		if !publicIP(ip) {
+			return ErrPrivateTarget
+		}
+		return nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("webhook url: resolve %s: %w", host, err)
+	}
+	if len(addrs) == 0 {
+		return fmt.Errorf("webhook url: %s has no addresses", host)
+	}
+	for _, addr := range addrs {
+		if !publicIP(addr.IP) {
+			return ErrPrivateTarget
+		}
+	}
+	return nil
+}
+
+// WebhookJobPayload is the payload of a models.JobDeliverWebhook job. The hit
+// is snapshotted at enqueue time so retries send what the scan found even if
+// the hit is later edited or deleted.
+type WebhookJobPayload struct {
+	TargetID uuid.UUID           `json:"target_id"`
+	Hit      models.WatchlistHit `json:"hit"`
+}
+
+// genericPayload is the body posted to generic webhook targets.
+type genericPayload struct {
+	Event  string              `json:"event"`
+	Hit    models.WatchlistHit `json:"hit"`
+	SentAt time.Time           `json:"sent_at"`
+}
+
+// Deliver posts hit to target. Any non-2xx response is returned as a
+// *StatusError so the job queue retries the delivery.
+func Deliver(ctx context.Context, target *models.WebhookTarget, hit models.WatchlistHit) error {
+	body, err := buildBody(target.Kind, hit)
+	if err != nil {
+		return fmt.Errorf("webhook body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	if target.Kind == models.WebhookGeneric && target.Secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(target.Secret, body))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook post: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return &StatusError{Code: resp.StatusCode}
+	}
+	return nil
+}
+
+// Sign returns the hex HMAC-SHA256 of body keyed by secret.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func buildBody(kind string, hit models.WatchlistHit) ([]byte, error) {
+	switch kind {
+	case models.WebhookSlack:
+		return json.Marshal(map[string]string{"text": formatMessage(hit, true)})
+	case models.WebhookDiscord:
+		return json.Marshal(map[string]string{"content": formatMessage(hit, false)})
+	default:
+		return json.Marshal(genericPayload{Event: "watchlist_hit", Hit: hit, SentAt: time.Now().UTC()})
+	}
+}
+
+// formatMessage renders a hit as a short chat message. Slack uses its own
+// <url|label> link syntax; Discord understands markdown.
+func formatMessage(hit models.WatchlistHit, slack bool) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s *%s* — %s\n", sentimentIcon(hit.Sentiment), hit.OrgName, hit.SourceType)
+	if slack {
+		fmt.Fprintf(&b, "<%s|%s>", hit.URL, hit.Title)
+	} else {
+		fmt.Fprintf(&b, "[%s](%s)", hit.Title, hit.URL)
+	}
+	if snippet := strings.TrimSpace(hit.Snippet); snippet != "" {
+		if len([]rune(snippet)) > 280 {
+			snippet = string([]rune(snippet)[:280]) + "…"
+		}
+		b.WriteString("\n> " + snippet)
+	}
+	return b.String()
+}
+
+func sentimentIcon(sentiment string) string {
+	switch sentiment {
+	case "positive":
+		return "🟢"
+	case "negative":
+		return "🔴"
+	default:
+		return "⚪"
+	}
+}
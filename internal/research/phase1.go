@@ -3,6 +3,7 @@ package research
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/url"
@@ -12,6 +13,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/Saul-Punybz/folio/internal/agents"
+	"github.com/Saul-Punybz/folio/internal/coverage"
 	"github.com/Saul-Punybz/folio/internal/crawler"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/scraper"
@@ -70,10 +72,12 @@ func searchGoogleNews(ctx context.Context, deps Deps, projectID uuid.UUID, queri
 			break
 		}
 
-		feedURL := fmt.Sprintf(
-			"https://news.google.com/rss/search?q=%s&hl=es-419&gl=PR&ceid=PR:es-419",
-			url.QueryEscape(query),
-		)
+		feedURL := coverage.Current().GoogleNewsSearchURL(query)
+
+		if err := scraper.ReserveSearch(ctx, scraper.EngineGoogleNews); err != nil {
+			slog.Warn("research/phase1/google_news: skipped", "err", err)
+			break
+		}
 
 		agentCtx, cancel := context.WithTimeout(ctx, agentTimeout)
 		items, err := scraper.ParseFeed(agentCtx, feedURL)
@@ -136,6 +140,10 @@ func searchBingNews(ctx context.Context, deps Deps, projectID uuid.UUID, queries
 		results, err := scraper.BingNewsSearch(agentCtx, query, maxResultsPerAgent)
 		cancel()
 
+		if errors.Is(err, scraper.ErrSearchBudgetExhausted) {
+			slog.Warn("research/phase1/bing_news: skipped", "err", err)
+			break
+		}
 		if err != nil {
 			slog.Warn("research/phase1/bing_news: search", "query", query, "err", err)
 			continue
@@ -186,6 +194,10 @@ func searchWeb(ctx context.Context, deps Deps, projectID uuid.UUID, queries []st
 		}
 
 		results, err := scraper.WebSearch(ctx, query, maxResultsPerAgent)
+		if errors.Is(err, scraper.ErrSearchBudgetExhausted) {
+			slog.Warn("research/phase1/web: skipped", "err", err)
+			break
+		}
 		if err != nil {
 			slog.Warn("research/phase1/web: search", "query", query, "err", err)
 			continue
@@ -12,8 +12,10 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/scraper"
+	"github.com/Saul-Punybz/folio/internal/useragent"
 )
 
 const (
@@ -106,9 +108,9 @@ func scrapePage(ctx context.Context, rawURL string) string {
 	if err != nil {
 		return ""
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36")
+	useragent.Apply(req)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := fetchlog.Client.Do(req)
 	if err != nil {
 		return ""
 	}
@@ -136,9 +138,9 @@ func deepCrawlPage(ctx context.Context, deps Deps, projectID uuid.UUID, pageURL,
 	if err != nil {
 		return 0
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36")
+	useragent.Apply(req)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := fetchlog.Client.Do(req)
 	if err != nil {
 		return 0
 	}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/coverage"
 	"github.com/Saul-Punybz/folio/internal/models"
 )
 
@@ -55,12 +56,13 @@ func RunPhase3(ctx context.Context, deps Deps, project *models.ResearchProject)
 
 // scoreRelevance uses AI to score each finding's relevance to the topic (0.0-1.0).
 func scoreRelevance(ctx context.Context, deps Deps, topic string, findings []models.ResearchFinding) {
-	systemPrompt := fmt.Sprintf(`Score the relevance of this text to the research topic "%s" in Puerto Rico.
+	place := coverage.Current().Name
+	systemPrompt := fmt.Sprintf(`Score the relevance of this text to the research topic "%s" in %s.
 Output ONLY a number between 0.0 and 1.0.
-- 1.0 = directly about the topic in Puerto Rico
+- 1.0 = directly about the topic in %s
 - 0.5 = somewhat related
 - 0.0 = completely unrelated
-Output ONLY the number, nothing else.`, topic)
+Output ONLY the number, nothing else.`, topic, place, place)
 
 	for i := range findings {
 		if ctx.Err() != nil {
@@ -72,7 +74,7 @@ Output ONLY the number, nothing else.`, topic)
 			text = truncateStr(findings[i].CleanText, 1000)
 		}
 
-		resp, err := deps.AI.Generate(ctx, systemPrompt, text)
+		resp, err := deps.AI.Generate(ai.WithTask(ctx, ai.TaskClassify), systemPrompt, text)
 		if err != nil {
 			continue
 		}
@@ -187,14 +189,16 @@ func buildTimeline(findings []models.ResearchFinding) []TimelineEvent {
 }
 
 // generateDossier uses AI to create a structured research report.
-func generateDossier(ctx context.Context, aiClient *ai.OllamaClient, topic string, findings []models.ResearchFinding, entities DossierEntities, timeline []TimelineEvent) string {
+func generateDossier(ctx context.Context, aiClient ai.Provider, topic string, findings []models.ResearchFinding, entities DossierEntities, timeline []TimelineEvent) string {
 	if aiClient == nil {
 		return buildFallbackDossier(topic, findings, entities)
 	}
 
+	profile := coverage.Current()
+
 	// Build context from top findings
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("Tema de investigacion: %s en Puerto Rico\n\n", topic))
+	sb.WriteString(fmt.Sprintf("Tema de investigacion: %s en %s\n\n", topic, profile.Name))
 	sb.WriteString(fmt.Sprintf("Total de fuentes encontradas: %d\n\n", len(findings)))
 
 	// Add top findings
@@ -229,7 +233,7 @@ func generateDossier(ctx context.Context, aiClient *ai.OllamaClient, topic strin
 		context = context[:8000]
 	}
 
-	systemPrompt := `Eres un analista de inteligencia politica especializado en Puerto Rico. Genera un dossier de investigacion completo en formato Markdown.
+	systemPrompt := `Eres un analista de inteligencia politica especializado en ` + profile.Name + `. Genera un dossier de investigacion completo en formato Markdown.
 
 ESTRUCTURA OBLIGATORIA:
 ## Resumen Ejecutivo
@@ -248,13 +252,13 @@ ESTRUCTURA OBLIGATORIA:
 (Que se puede concluir y que areas necesitan mas investigacion)
 
 REGLAS:
-- Escribe en español profesional
+- Escribe en ` + profile.LanguageName() + ` profesional
 - Se objetivo y basado en evidencia
 - Cita las fuentes cuando sea posible
 - NO inventes informacion — usa solo lo proporcionado
 - Si hay poca informacion, indica que la investigacion fue limitada`
 
-	dossier, err := aiClient.Generate(ctx, systemPrompt, context)
+	dossier, err := aiClient.Generate(ai.WithTask(ctx, ai.TaskBrief), systemPrompt, context)
 	if err != nil {
 		slog.Error("research/phase3: generate dossier", "err", err)
 		return buildFallbackDossier(topic, findings, entities)
@@ -266,7 +270,7 @@ REGLAS:
 // buildFallbackDossier creates a basic dossier without AI.
 func buildFallbackDossier(topic string, findings []models.ResearchFinding, entities DossierEntities) string {
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("# Investigacion: %s en Puerto Rico\n\n", topic))
+	sb.WriteString(fmt.Sprintf("# Investigacion: %s en %s\n\n", topic, coverage.Current().Name))
 	sb.WriteString(fmt.Sprintf("## Resumen\n\nSe encontraron %d fuentes relacionadas con el tema.\n\n", len(findings)))
 
 	sb.WriteString("## Fuentes Principales\n\n")
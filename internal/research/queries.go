@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/coverage"
 )
 
 // buildResearchQueries generates 8-15 search query variations from the topic and keywords.
@@ -24,10 +25,13 @@ func buildResearchQueries(topic string, keywords []string) []string {
 		}
 	}
 
+	profile := coverage.Current()
+	place := " " + profile.Name
+
 	// Core topic queries
-	add(topic + " Puerto Rico")
-	add(topic + " PR")
-	add(topic + " en Puerto Rico")
+	add(topic + place)
+	add(topic + " " + profile.Region)
+	add(topic + " en" + place)
 
 	// Keyword-based queries
 	for _, kw := range keywords {
@@ -38,20 +42,20 @@ func buildResearchQueries(topic string, keywords []string) []string {
 		if kw == "" {
 			continue
 		}
-		add(kw + " Puerto Rico")
+		add(kw + place)
 		add(topic + " " + kw)
 	}
 
 	// Time-scoped
-	add(topic + " Puerto Rico 2025 2026")
-	add(topic + " Puerto Rico noticias recientes")
+	add(topic + place + " 2025 2026")
+	add(topic + place + " noticias recientes")
 
 	// English variant
-	add(topic + " Puerto Rico news")
+	add(topic + place + " news")
 
 	// Government/policy angle
-	add(topic + " gobierno Puerto Rico")
-	add(topic + " legislacion Puerto Rico")
+	add(topic + " gobierno" + place)
+	add(topic + " legislacion" + place)
 
 	// Cap at 15
 	if len(queries) > 15 {
@@ -62,20 +66,21 @@ func buildResearchQueries(topic string, keywords []string) []string {
 }
 
 // expandTopicKeywords uses AI to generate 5-8 related search terms for a research topic.
-func expandTopicKeywords(ctx context.Context, aiClient *ai.OllamaClient, topic string) ([]string, error) {
-	systemPrompt := `Eres un investigador especializado en Puerto Rico. Dado un tema de investigacion, genera entre 5 y 8 palabras clave o frases cortas relacionadas que ayuden a encontrar informacion relevante.
+func expandTopicKeywords(ctx context.Context, aiClient ai.Provider, topic string) ([]string, error) {
+	profile := coverage.Current()
+	systemPrompt := `Eres un investigador especializado en ` + profile.Name + `. Dado un tema de investigacion, genera entre 5 y 8 palabras clave o frases cortas relacionadas que ayuden a encontrar informacion relevante.
 
 REGLAS:
-- Palabras clave en español (a menos que el tema sea en ingles)
+- Palabras clave en ` + profile.LanguageName() + ` (a menos que el tema sea en otro idioma)
 - Frases cortas (1-3 palabras max)
 - Incluye: sinonimos, subtemas, organizaciones relacionadas, leyes o programas relevantes
-- NO incluyas "Puerto Rico" como palabra clave (ya se agrega automaticamente)
+- NO incluyas "` + profile.Name + `" como palabra clave (ya se agrega automaticamente)
 - NO incluyas palabras genericas como "informacion", "noticias", "datos"
 - Output SOLO las palabras separadas por comas, sin numeros ni explicaciones`
 
 	userPrompt := fmt.Sprintf("Tema de investigacion: %s", topic)
 
-	resp, err := aiClient.Generate(ctx, systemPrompt, userPrompt)
+	resp, err := aiClient.Generate(ai.WithTask(ctx, ai.TaskExtract), systemPrompt, userPrompt)
 	if err != nil {
 		slog.Warn("research/queries: AI keyword expansion failed", "topic", topic, "err", err)
 		return nil, err
@@ -97,7 +102,7 @@ REGLAS:
 		}
 
 		lower := strings.ToLower(p)
-		if lower == "puerto rico" || lower == "informacion" || lower == "noticias" || lower == "datos" {
+		if profile.IsGeneric(lower) || lower == "informacion" || lower == "noticias" || lower == "datos" {
 			continue
 		}
 
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
 	"github.com/Saul-Punybz/folio/internal/models"
 )
 
@@ -19,11 +20,12 @@ type Deps struct {
 	Findings     *models.ResearchFindingStore
 	Articles     *models.ArticleStore
 	CrawledPages *models.CrawledPageStore
-	AI           *ai.OllamaClient
+	AI           ai.Provider
 }
 
 // RunProject orchestrates all 3 research phases for a single project.
 func RunProject(ctx context.Context, deps Deps, projectID uuid.UUID) {
+	ctx = fetchlog.WithPurpose(ctx, fetchlog.Research)
 	slog.Info("research: starting project", "id", projectID)
 
 	project, err := deps.Projects.GetByID(ctx, projectID)
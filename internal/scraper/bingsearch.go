@@ -9,6 +9,10 @@ import (
 
 // BingNewsSearch queries Bing News RSS and returns results as WebResult.
 func BingNewsSearch(ctx context.Context, query string, limit int) ([]WebResult, error) {
+	if err := ReserveSearch(ctx, EngineBingNews); err != nil {
+		return nil, fmt.Errorf("bingsearch: %w", err)
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
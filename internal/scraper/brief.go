@@ -8,26 +8,58 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/coverage"
 	"github.com/Saul-Punybz/folio/internal/models"
 )
 
-// GenerateDailyBrief creates a summary of the day's articles using Ollama.
-// It queries articles from the last 24 hours, concatenates titles and summaries,
-// calls the AI to generate a daily digest, counts top tags, and creates a brief record.
-func GenerateDailyBrief(ctx context.Context, articles *models.ArticleStore, briefs *models.BriefStore, aiClient *ai.OllamaClient) {
-	slog.Info("daily brief: starting generation")
+// DefaultBriefModel is the model briefs are written with unless another is
+// requested — quality matters more than speed for background tasks.
+const DefaultBriefModel = "llama3.1:8b"
+
+// DefaultBriefParams returns the daily brief's parameters: the 24 hours
+// before now, all tags and regions, in the coverage profile's language, with
+// DefaultBriefModel.
+func DefaultBriefParams(now time.Time) models.BriefParams {
+	return models.BriefParams{
+		From:     now.Add(-24 * time.Hour),
+		To:       now,
+		Language: coverage.Current().Language,
+		Model:    DefaultBriefModel,
+	}
+}
+
+// GenerateDailyBrief creates the brief of the last 24 hours' articles with
+// the default parameters. entities may be nil, leaving the brief without top
+// entities.
+func GenerateDailyBrief(ctx context.Context, articles *models.ArticleStore, briefs *models.BriefStore, entities *models.EntityStore, aiClient ai.Provider) {
+	GenerateBrief(ctx, articles, briefs, entities, aiClient, DefaultBriefParams(time.Now()))
+}
 
-	// Get top 60 most recent articles from the last 24 hours.
-	allRecent, err := articles.ListRecent(ctx, 24)
+// GenerateBrief creates a summary of the articles selected by p using
+// Ollama. It concatenates titles and summaries, calls p.Model to write the
+// brief in p.Language and then one section per topic citing its articles,
+// counts top tags and entities, and stores the brief —
+// with p — under the date of p.To, replacing that date's brief. It returns
+// nil if no article matches. entities may be nil, leaving the brief without
+// top entities.
+func GenerateBrief(ctx context.Context, articles *models.ArticleStore, briefs *models.BriefStore, entities *models.EntityStore, aiClient ai.Provider, p models.BriefParams) (*models.Brief, error) {
+	slog.Info("daily brief: starting generation",
+		"from", p.From, "to", p.To, "tags", p.Tags, "region", p.Region,
+		"language", p.Language, "model", p.Model)
+
+	// Get the top 60 most recent matching articles.
+	allRecent, err := articles.ListForBrief(ctx, p)
 	if err != nil {
-		slog.Error("daily brief: list recent articles", "err", err)
-		return
+		slog.Error("daily brief: list articles", "err", err)
+		return nil, err
 	}
 
 	if len(allRecent) == 0 {
-		slog.Info("daily brief: no articles in the last 24 hours, skipping")
-		return
+		slog.Info("daily brief: no matching articles, skipping")
+		return nil, nil
 	}
 
 	// Cap at 60 articles — enough for a quality brief without overwhelming the AI.
@@ -38,12 +70,41 @@ func GenerateDailyBrief(ctx context.Context, articles *models.ArticleStore, brie
 
 	slog.Info("daily brief: processing articles", "count", len(recentArticles))
 
-	// Build a text block of titles + summaries/snippets for the AI.
-	var sb strings.Builder
+	// Quotes extracted during enrichment let the brief cite exact language.
+	ids := make([]uuid.UUID, len(recentArticles))
 	for i, a := range recentArticles {
+		ids[i] = a.ID
+	}
+	quotesByArticle, err := articles.QuotesByArticle(ctx, ids)
+	if err != nil {
+		slog.Warn("daily brief: load quotes", "err", err)
+		quotesByArticle = nil
+	}
+
+	// The day's most mentioned people, organizations and places, from the
+	// entities linked at enrichment.
+	var topEntities []models.EntityCount
+	if entities != nil {
+		topEntities, err = entities.TopEntitiesForArticles(ctx, ids, maxBriefEntities)
+		if err != nil {
+			slog.Warn("daily brief: load top entities", "err", err)
+			topEntities = nil
+		}
+	}
+
+	// Build a text block of titles + summaries/snippets for the AI, grouped
+	// into the scope sections the brief is organized by.
+	var sb strings.Builder
+	var prevScope string
+	var briefQuotes []models.BriefQuote
+	for i, a := range orderByScope(recentArticles) {
 		if sb.Len() > 12000 {
 			break
 		}
+		if i == 0 || BriefSection(a.Scope) != BriefSection(prevScope) {
+			sb.WriteString("\n## " + BriefSection(a.Scope) + "\n")
+		}
+		prevScope = a.Scope
 		sb.WriteString(fmt.Sprintf("%d. [%s] %s", i+1, a.Source, a.Title))
 		if a.Summary != "" {
 			sb.WriteString(": ")
@@ -57,6 +118,21 @@ func GenerateDailyBrief(ctx context.Context, articles *models.ArticleStore, brie
 			sb.WriteString(snippet)
 		}
 		sb.WriteString("\n")
+		for _, q := range quotesByArticle[a.ID] {
+			sb.WriteString("   Cita: \"" + q.Text + "\" — " + QuoteAttribution(q) + "\n")
+		}
+		if quotes := quotesByArticle[a.ID]; len(quotes) > 0 && len(briefQuotes) < maxBriefQuotes {
+			briefQuotes = append(briefQuotes, models.BriefQuote{
+				Quote: quotes[0], ArticleID: a.ID, Source: a.Source, Title: a.Title, URL: a.URL,
+			})
+		}
+	}
+
+	if len(topEntities) > 0 {
+		sb.WriteString("\n## Entidades más mencionadas\n")
+		for _, e := range topEntities {
+			sb.WriteString(fmt.Sprintf("- %s (%s, %d noticias)\n", e.Name, EntityTypeLabel(e.Type), e.Count))
+		}
 	}
 
 	inputText := sb.String()
@@ -65,19 +141,22 @@ func GenerateDailyBrief(ctx context.Context, articles *models.ArticleStore, brie
 	}
 
 	// Generate the daily brief summary via AI.
-	systemPrompt := `Eres un analista de inteligencia política de Puerto Rico. Genera un resumen diario conciso de las noticias más importantes.
+	profile := coverage.Current()
+	systemPrompt := `Eres un analista de inteligencia política de ` + profile.Name + `. Genera un resumen diario conciso de las noticias más importantes.
 
 REGLAS:
-- Escribe en español
-- Agrupa las noticias por tema (política, economía, crimen, salud, etc.)
+- Escribe en ` + briefLanguageName(p.Language) + `
+- Organiza el resumen en secciones con estos encabezados, en este orden, omitiendo las que no tengan noticias: "` + profile.Name + `", "Federal", "Diáspora". Las noticias ya vienen agrupadas bajo esos encabezados
+- Dentro de cada sección, agrupa las noticias por tema (política, economía, crimen, salud, etc.)
 - Menciona nombres específicos de personas, agencias y lugares
-- Incluye 3-5 párrafos, cada uno sobre un tema diferente
+- Incluye 1-3 párrafos por sección, cada uno sobre un tema diferente
 - Usa un tono profesional y analítico
 - NO repitas la misma noticia más de una vez
+- La lista "Entidades más mencionadas" es contexto: úsala para destacar quién domina la agenda del día, sin crear una sección aparte
+- Cuando una noticia trae líneas "Cita:", puedes citar textualmente la más reveladora entre comillas y con su atribución. Copia las citas exactas; nunca inventes ni alteres una cita
 - Empieza directamente con el contenido, sin títulos como "Resumen Diario"`
 
-	// Use the 8b model for briefs — quality matters more than speed for background tasks.
-	summary, err := aiClient.GenerateWithModel(ctx, "llama3.1:8b", systemPrompt, inputText)
+	summary, err := aiClient.GenerateWithModel(ai.WithTask(ctx, ai.TaskBrief), p.Model, systemPrompt, inputText)
 	if err != nil {
 		slog.Error("daily brief: AI generation failed", "err", err)
 		// Fall back to a simple concatenation.
@@ -96,6 +175,9 @@ REGLAS:
 		}
 	}
 
+	// One section per leading tag, each citing the articles it was written from.
+	sections := generateBriefSections(ctx, aiClient, p, recentArticles)
+
 	// Count top tags from the day's articles.
 	tagCounts := make(map[string]int)
 	for _, a := range recentArticles {
@@ -127,15 +209,19 @@ REGLAS:
 
 	// Create the brief record.
 	brief := &models.Brief{
-		Date:         time.Now().UTC().Truncate(24 * time.Hour),
+		Date:         p.To.Add(-time.Nanosecond).UTC().Truncate(24 * time.Hour),
 		Summary:      summary,
 		TopTags:      topTags,
 		ArticleCount: len(recentArticles),
+		Quotes:       briefQuotes,
+		TopEntities:  topEntities,
+		Sections:     sections,
+		Params:       &p,
 	}
 
 	if err := briefs.Create(ctx, brief); err != nil {
 		slog.Error("daily brief: create record", "err", err)
-		return
+		return nil, err
 	}
 
 	slog.Info("daily brief: generated successfully",
@@ -143,4 +229,71 @@ REGLAS:
 		"article_count", brief.ArticleCount,
 		"top_tags", topTags,
 	)
+	return brief, nil
+}
+
+// briefLanguageName returns a brief language's name for the Spanish prompt.
+func briefLanguageName(lang string) string {
+	if lang == "en" {
+		return "inglés"
+	}
+	return "español"
+}
+
+// maxBriefQuotes caps the quotes kept on a brief record, one per article.
+const maxBriefQuotes = 8
+
+// maxBriefEntities caps the top entities kept on a brief record.
+const maxBriefEntities = 10
+
+// EntityTypeLabel is the Spanish label for an entity type in briefs.
+func EntityTypeLabel(entityType string) string {
+	switch entityType {
+	case "person":
+		return "persona"
+	case "organization":
+		return "organización"
+	case "place":
+		return "lugar"
+	}
+	return entityType
+}
+
+// QuoteAttribution formats who said a quote: "Speaker, Role" or "Speaker".
+func QuoteAttribution(q models.Quote) string {
+	if q.Role != "" {
+		return q.Speaker + ", " + q.Role
+	}
+	return q.Speaker
+}
+
+// briefScopeOrder is the order of the brief's sections.
+var briefScopeOrder = map[string]int{
+	models.ScopeLocal:    0,
+	models.ScopeFederal:  1,
+	models.ScopeDiaspora: 2,
+}
+
+// BriefSection returns the brief section heading for an article scope.
+// Local news is headed by the coverage profile's name; unclassified articles
+// are grouped with it.
+func BriefSection(scope string) string {
+	switch scope {
+	case models.ScopeFederal:
+		return "Federal"
+	case models.ScopeDiaspora:
+		return "Diáspora"
+	default:
+		return coverage.Current().Name
+	}
+}
+
+// orderByScope returns the articles stably sorted into brief section order.
+func orderByScope(articles []models.Article) []models.Article {
+	ordered := make([]models.Article, len(articles))
+	copy(ordered, articles)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return briefScopeOrder[ordered[i].Scope] < briefScopeOrder[ordered[j].Scope]
+	})
+	return ordered
 }
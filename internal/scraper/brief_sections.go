@@ -0,0 +1,149 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+const (
+	// maxBriefSections caps the topic sections of a brief; articles on other
+	// topics go to a final catch-all section.
+	maxBriefSections = 6
+
+	// maxSectionArticles caps the articles a section is written from.
+	maxSectionArticles = 10
+)
+
+// briefGroup is the articles one brief section is written from.
+type briefGroup struct {
+	tag      string
+	articles []models.Article
+}
+
+// groupBriefArticles assigns each article to its most common tag across the
+// brief (untagged articles, and those whose tag is outside the top
+// maxBriefSections, go to a catch-all group with an empty tag). Groups are
+// ordered by size, then tag; the catch-all group comes last. Articles keep
+// their order within a group.
+func groupBriefArticles(articles []models.Article) []briefGroup {
+	counts := make(map[string]int)
+	for _, a := range articles {
+		for _, t := range a.Tags {
+			counts[t]++
+		}
+	}
+
+	byTag := make(map[string][]models.Article)
+	for _, a := range articles {
+		best := ""
+		for _, t := range a.Tags {
+			if best == "" || counts[t] > counts[best] || (counts[t] == counts[best] && t < best) {
+				best = t
+			}
+		}
+		byTag[best] = append(byTag[best], a)
+	}
+
+	var groups []briefGroup
+	var other []models.Article
+	for tag, arts := range byTag {
+		if tag == "" {
+			other = append(other, arts...)
+			continue
+		}
+		groups = append(groups, briefGroup{tag: tag, articles: arts})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if len(groups[i].articles) != len(groups[j].articles) {
+			return len(groups[i].articles) > len(groups[j].articles)
+		}
+		return groups[i].tag < groups[j].tag
+	})
+	if len(groups) > maxBriefSections {
+		for _, g := range groups[maxBriefSections:] {
+			other = append(other, g.articles...)
+		}
+		groups = groups[:maxBriefSections]
+	}
+	if len(other) > 0 {
+		groups = append(groups, briefGroup{articles: other})
+	}
+	return groups
+}
+
+// generateBriefSections writes one section per topic group with p.Model, in
+// p.Language. A section whose generation fails lists its headlines instead.
+func generateBriefSections(ctx context.Context, aiClient ai.Provider, p models.BriefParams, articles []models.Article) []models.BriefSection {
+	groups := groupBriefArticles(articles)
+	sections := make([]models.BriefSection, 0, len(groups))
+	for _, g := range groups {
+		if ctx.Err() != nil {
+			break
+		}
+		arts := g.articles
+		if len(arts) > maxSectionArticles {
+			arts = arts[:maxSectionArticles]
+		}
+
+		section := models.BriefSection{
+			Tag:      g.tag,
+			Title:    g.tag,
+			Articles: make([]models.BriefSectionArticle, len(arts)),
+		}
+		if g.tag == "" {
+			section.Title = "Otros temas"
+			if p.Language == "en" {
+				section.Title = "Other topics"
+			}
+		}
+		var sb strings.Builder
+		for i, a := range arts {
+			section.Articles[i] = models.BriefSectionArticle{ID: a.ID, Title: a.Title, Source: a.Source, URL: a.URL}
+			sb.WriteString(fmt.Sprintf("[%d] (%s) %s", i+1, a.Source, a.Title))
+			if text := articleBriefText(a); text != "" {
+				sb.WriteString(": " + text)
+			}
+			sb.WriteString("\n")
+		}
+
+		systemPrompt := `Eres un analista de inteligencia política. Resume en 1-2 párrafos las noticias sobre el tema "` + section.Title + `".
+
+REGLAS:
+- Escribe en ` + briefLanguageName(p.Language) + `
+- Usa SOLO la información de las noticias proporcionadas; no inventes nada
+- Cita cada noticia que uses con su número entre corchetes, por ejemplo [1] o [2][3]
+- Menciona nombres específicos de personas, agencias y lugares
+- Usa un tono profesional y analítico
+- Empieza directamente con el contenido, sin títulos`
+
+		summary, err := aiClient.GenerateWithModel(ai.WithTask(ctx, ai.TaskBrief), p.Model, systemPrompt, sb.String())
+		if err != nil {
+			slog.Warn("daily brief: section generation failed", "tag", g.tag, "err", err)
+			titles := make([]string, len(arts))
+			for i, a := range arts {
+				titles[i] = fmt.Sprintf("[%d] %s", i+1, a.Title)
+			}
+			summary = strings.Join(titles, "\n")
+		}
+		section.Summary = strings.TrimSpace(summary)
+		sections = append(sections, section)
+	}
+	return sections
+}
+
+// articleBriefText returns the article's summary, or the start of its text.
+func articleBriefText(a models.Article) string {
+	if a.Summary != "" {
+		return a.Summary
+	}
+	if len(a.CleanText) > 400 {
+		return a.CleanText[:400] + "..."
+	}
+	return a.CleanText
+}
@@ -0,0 +1,170 @@
+package scraper
+
+import (
+	"bytes"
+	"mime"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/transform"
+)
+
+var (
+	utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+	// reXMLEncoding matches the encoding pseudo-attribute of an XML declaration.
+	reXMLEncoding = regexp.MustCompile(`^(\s*<\?xml[^>]*?encoding\s*=\s*)["']([A-Za-z0-9._:-]+)["']`)
+)
+
+// ToUTF8 transcodes a fetched document to UTF-8. The charset is taken, in
+// order, from a byte order mark, the Content-Type header, the XML declaration
+// and HTML <meta> tags. Bodies that claim UTF-8 but are not valid UTF-8 (a
+// common misconfiguration on Latin-1 sites) are decoded as Windows-1252. The
+// XML declaration, if any, is rewritten to say UTF-8 so encoding/xml accepts
+// the result.
+func ToUTF8(body []byte, contentType string) []byte {
+	body = bytes.TrimPrefix(body, utf8BOM)
+
+	label := headerCharset(contentType)
+	if label == "" {
+		if m := reXMLEncoding.FindSubmatch(head(body, 256)); m != nil {
+			label = string(m[2])
+		}
+	}
+
+	var (
+		enc  encoding.Encoding
+		name string
+	)
+	if label != "" {
+		enc, name = charset.Lookup(label)
+	}
+	if enc == nil {
+		enc, name, _ = charset.DetermineEncoding(body, contentType)
+	}
+
+	valid := utf8.Valid(body)
+	if name == "utf-8" {
+		if valid {
+			return markUTF8(body)
+		}
+		enc = charmap.Windows1252
+	} else if valid && hasMultibyte(body) && !strings.HasPrefix(name, "utf-16") {
+		// Latin-1 text with accents is almost never valid UTF-8 by accident,
+		// so a valid multibyte body means the declared charset is wrong.
+		return markUTF8(body)
+	}
+
+	decoded, _, err := transform.Bytes(enc.NewDecoder(), body)
+	if err != nil {
+		return markUTF8(body)
+	}
+	return markUTF8(decoded)
+}
+
+// headerCharset returns the charset parameter of a Content-Type header.
+func headerCharset(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(params["charset"])
+}
+
+// markUTF8 rewrites the XML declaration's encoding to UTF-8.
+func markUTF8(body []byte) []byte {
+	loc := reXMLEncoding.FindSubmatchIndex(head(body, 256))
+	if loc == nil {
+		return body
+	}
+	if strings.EqualFold(string(body[loc[4]:loc[5]]), "utf-8") {
+		return body
+	}
+	out := make([]byte, 0, len(body))
+	out = append(out, body[:loc[4]]...)
+	out = append(out, "UTF-8"...)
+	out = append(out, body[loc[5]:]...)
+	return out
+}
+
+func head(b []byte, n int) []byte {
+	if len(b) > n {
+		return b[:n]
+	}
+	return b
+}
+
+// SniffFeedFormat inspects the root element of a document and returns "rss",
+// "atom" or "rdf" (RSS 1.0) when it is a feed, or "" otherwise. It ignores
+// the Content-Type, so feeds served as text/plain or application/octet-stream
+// are still recognized. Leading BOMs, whitespace, XML declarations, comments,
+// processing instructions and doctypes are skipped.
+func SniffFeedFormat(data []byte) string {
+	data = bytes.TrimPrefix(head(data, 4096), utf8BOM)
+	for {
+		data = bytes.TrimLeft(data, " \t\r\n")
+		switch {
+		case bytes.HasPrefix(data, []byte("<?")):
+			data = skipPast(data, "?>")
+		case bytes.HasPrefix(data, []byte("<!--")):
+			data = skipPast(data, "-->")
+		case bytes.HasPrefix(data, []byte("<!")):
+			data = skipPast(data, ">")
+		case bytes.HasPrefix(data, []byte("<")):
+			name := data[1:]
+			if i := bytes.IndexAny(name, " \t\r\n/>"); i >= 0 {
+				name = name[:i]
+			}
+			// Drop a namespace prefix such as rdf:RDF or atom:feed.
+			if i := bytes.IndexByte(name, ':'); i >= 0 {
+				name = name[i+1:]
+			}
+			switch strings.ToLower(string(name)) {
+			case "rss":
+				return "rss"
+			case "feed":
+				return "atom"
+			case "rdf":
+				return "rdf"
+			}
+			return ""
+		default:
+			return ""
+		}
+		if data == nil {
+			return ""
+		}
+	}
+}
+
+func skipPast(data []byte, marker string) []byte {
+	i := bytes.Index(data, []byte(marker))
+	if i < 0 {
+		return nil
+	}
+	return data[i+len(marker):]
+}
+
+func hasMultibyte(b []byte) bool {
+	for _, c := range b {
+		if c >= utf8.RuneSelf {
+			return true
+		}
+	}
+	return false
+}
+
+// isTextContentType reports whether a response is textual (HTML, XML or
+// plain text) and therefore worth transcoding. A missing header counts as
+// text.
+func isTextContentType(contentType string) bool {
+	ct := strings.ToLower(contentType)
+	return ct == "" || strings.Contains(ct, "html") || strings.Contains(ct, "xml") || strings.HasPrefix(ct, "text/")
+}
@@ -0,0 +1,99 @@
+package scraper
+
+import (
+	"context"
+	"hash/fnv"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+const (
+	// clusterWindow is how far back an article looks for others covering the
+	// same story.
+	clusterWindow = 72 * time.Hour
+
+	// clusterSimHashBits is the largest SimHash Hamming distance between two
+	// articles' texts for them to count as copies of the same story.
+	clusterSimHashBits = 3
+
+	// clusterEmbeddingDistance is the largest cosine distance between two
+	// articles' embeddings for them to count as the same story told by
+	// different outlets.
+	clusterEmbeddingDistance = 0.12
+)
+
+// assignCluster puts an enriched article into a story cluster: the cluster of
+// a recent article with a near-identical text (by SimHash) or, failing that,
+// a close embedding; otherwise a new cluster of its own. embedding may be nil.
+// Errors are logged; clustering never fails enrichment.
+func assignCluster(ctx context.Context, articles *models.ArticleStore, id uuid.UUID, text string, embedding []float32) {
+	since := time.Now().Add(-clusterWindow)
+	simhash := SimHash(text)
+
+	var clusterID uuid.UUID
+	var err error
+	if simhash != 0 {
+		clusterID, err = articles.FindClusterBySimHash(ctx, id, simhash, since, clusterSimHashBits)
+		if err != nil {
+			slog.Error("cluster: find by simhash", "id", id, "err", err)
+		}
+	}
+	if clusterID == uuid.Nil && len(embedding) > 0 {
+		clusterID, err = articles.FindClusterByEmbedding(ctx, id, embedding, since, clusterEmbeddingDistance)
+		if err != nil {
+			slog.Error("cluster: find by embedding", "id", id, "err", err)
+		}
+	}
+
+	joined := clusterID != uuid.Nil
+	if !joined {
+		clusterID = id
+	}
+	if err := articles.SetCluster(ctx, id, clusterID, simhash); err != nil {
+		slog.Error("cluster: set", "id", id, "err", err)
+		return
+	}
+	if joined {
+		slog.Info("cluster: joined story", "id", id, "cluster", clusterID)
+	}
+}
+
+// SimHash returns a 64-bit SimHash of text over overlapping three-word
+// shingles. Unlike HashContent, near-identical texts (wire copies, light
+// edits) differ in only a few bits. Returns 0 for texts under three words.
+func SimHash(text string) uint64 {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) < 3 {
+		return 0
+	}
+
+	var weights [64]int
+	for i := 0; i+3 <= len(words); i++ {
+		f := fnv.New64a()
+		f.Write([]byte(words[i] + " " + words[i+1] + " " + words[i+2]))
+		h := f.Sum64()
+		for b := 0; b < 64; b++ {
+			if h&(1<<b) != 0 {
+				weights[b]++
+			} else {
+				weights[b]--
+			}
+		}
+	}
+
+	var out uint64
+	for b, w := range weights {
+		if w > 0 {
+			out |= 1 << b
+		}
+	}
+	return out
+}
@@ -0,0 +1,77 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/useragent"
+)
+
+// ErrFeedNotModified is returned by the conditional feed parsers when the
+// server answers 304 Not Modified to the validators sent.
+var ErrFeedNotModified = errors.New("feed not modified")
+
+// FeedValidators are the HTTP cache validators of a feed response, sent back
+// as If-None-Match / If-Modified-Since on the next fetch.
+type FeedValidators struct {
+	ETag         string
+	LastModified string
+}
+
+// fetchFeed GETs a feed, sending the validators when set. It returns the
+// body, its Content-Type, and the response's validators, or
+// ErrFeedNotModified on a 304. prefix labels errors ("rss", "jsonfeed").
+func fetchFeed(ctx context.Context, prefix, feedURL, accept string, v FeedValidators) ([]byte, string, FeedValidators, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	if err != nil {
+		return nil, "", v, fmt.Errorf("%s: create request: %w", prefix, err)
+	}
+	return fetchFeedRequest(req, prefix, feedURL, accept, v)
+}
+
+// fetchFeedRequest is fetchFeed for a prepared request, e.g. one carrying
+// credentials. feedURL is the URL as shown in errors, without secrets.
+func fetchFeedRequest(req *http.Request, prefix, feedURL, accept string, v FeedValidators) ([]byte, string, FeedValidators, error) {
+	useragent.Apply(req)
+	req.Header.Set("Accept", accept)
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+
+	resp, err := fetchlog.Client.Do(req)
+	if err != nil {
+		// *url.Error repeats the request URL, which may carry a credential.
+		var uerr *url.Error
+		if errors.As(err, &uerr) {
+			uerr.URL = feedURL
+		}
+		return nil, "", v, fmt.Errorf("%s: fetch %s: %w", prefix, feedURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, "", v, ErrFeedNotModified
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", v, fmt.Errorf("%s: fetch %s: status %d", prefix, feedURL, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024)) // 10 MB limit
+	if err != nil {
+		return nil, "", v, fmt.Errorf("%s: read body: %w", prefix, err)
+	}
+
+	next := FeedValidators{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}
+	return body, resp.Header.Get("Content-Type"), next, nil
+}
@@ -0,0 +1,135 @@
+package scraper
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// EmbedBackfillOptions bound BackfillEmbeddings.
+type EmbedBackfillOptions struct {
+	BatchSize   int // articles per batch embedding request (default 16)
+	Concurrency int // batch requests in flight (default 2)
+}
+
+// BackfillEmbeddings embeds the articles that have no embedding, typically
+// because the embedding call failed during enrichment. It pages through them
+// in ID order, sends opts.Concurrency batches of opts.BatchSize articles at a
+// time through the batch embedding API, and logs its progress after every
+// page. A failed batch is skipped; the run stops when a whole page fails,
+// since the embedding backend is then likely down.
+func BackfillEmbeddings(ctx context.Context, articles *models.ArticleStore, aiClient ai.Provider, opts EmbedBackfillOptions) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = 16
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = 2
+	}
+
+	total, err := articles.CountUnembedded(ctx)
+	if err != nil {
+		slog.Error("embedding backfill: count", "err", err)
+		return
+	}
+	if total == 0 {
+		slog.Debug("embedding backfill: nothing to embed")
+		return
+	}
+	slog.Info("embedding backfill: starting", "missing", total,
+		"batch_size", opts.BatchSize, "concurrency", opts.Concurrency)
+
+	var embedded, failed atomic.Int64
+	var after uuid.UUID
+	for ctx.Err() == nil {
+		page, err := articles.ListUnembedded(ctx, after, opts.BatchSize*opts.Concurrency)
+		if err != nil {
+			slog.Error("embedding backfill: list", "err", err)
+			break
+		}
+		if len(page) == 0 {
+			break
+		}
+		after = page[len(page)-1].ID
+
+		var wg sync.WaitGroup
+		var pageOK atomic.Bool
+		for start := 0; start < len(page); start += opts.BatchSize {
+			batch := page[start:min(start+opts.BatchSize, len(page))]
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				n, err := embedBatch(ctx, articles, aiClient, batch)
+				if err != nil {
+					slog.Warn("embedding backfill: batch", "first_id", batch[0].ID, "size", len(batch), "err", err)
+					failed.Add(int64(len(batch)))
+					return
+				}
+				pageOK.Store(true)
+				embedded.Add(int64(n))
+			}()
+		}
+		wg.Wait()
+
+		done := embedded.Load() + failed.Load()
+		slog.Info("embedding backfill: progress",
+			"embedded", embedded.Load(), "failed", failed.Load(),
+			"remaining", max(int64(total)-done, 0), "total", total)
+		if !pageOK.Load() {
+			slog.Warn("embedding backfill: every batch in a page failed, stopping")
+			break
+		}
+	}
+
+	if ctx.Err() != nil {
+		slog.Warn("embedding backfill: stopped", "embedded", embedded.Load(), "failed", failed.Load(), "err", ctx.Err())
+		return
+	}
+	slog.Info("embedding backfill: complete", "embedded", embedded.Load(), "failed", failed.Load())
+}
+
+// embedBatch embeds a batch of articles in one request and stores the
+// embeddings, returning how many were stored. Articles enriched or trashed
+// while the request ran are left alone. The error is the request's.
+func embedBatch(ctx context.Context, articles *models.ArticleStore, aiClient ai.Provider, batch []models.Article) (int, error) {
+	texts := make([]string, len(batch))
+	for i, a := range batch {
+		texts[i] = embeddingText(a)
+	}
+	embeddings, err := aiClient.EmbedBatch(ctx, texts)
+	if err != nil {
+		return 0, err
+	}
+
+	stored := 0
+	for i, a := range batch {
+		ok, err := articles.SetMissingEmbedding(ctx, a.ID, embeddings[i])
+		if err != nil {
+			slog.Warn("embedding backfill: store", "id", a.ID, "err", err)
+			continue
+		}
+		if ok {
+			stored++
+		}
+	}
+	return stored, nil
+}
+
+// embeddingText returns the text an article's embedding is computed from:
+// its clean text, or its title and summary when it has none, truncated for
+// the embedding model.
+func embeddingText(a models.Article) string {
+	text := a.CleanText
+	if text == "" {
+		text = a.Title + "\n" + a.Summary
+	}
+	if len(text) > 8000 {
+		text = text[:8000]
+	}
+	return text
+}
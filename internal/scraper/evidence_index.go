@@ -0,0 +1,84 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/storage"
+)
+
+const (
+	// evidenceIndexBatch is how many articles one indexing run handles.
+	evidenceIndexBatch = 200
+
+	// evidenceIndexMinAge leaves new articles alone until their evidence
+	// upload job has normally run.
+	evidenceIndexMinAge = 30 * time.Minute
+)
+
+// RunEvidenceIndexing indexes the full extracted text preserved in S3 for
+// articles not indexed yet, so search covers text that did not fit in
+// clean_text. Articles without evidence are recorded as such and skipped by
+// later runs until new evidence is uploaded for them.
+func RunEvidenceIndexing(ctx context.Context, articles *models.ArticleStore, storageClient *storage.Client) {
+	if storageClient == nil || !storageClient.Configured() {
+		return
+	}
+
+	pending, err := articles.ListEvidenceUnindexed(ctx, evidenceIndexMinAge, evidenceIndexBatch)
+	if err != nil {
+		slog.Error("evidence index: list", "err", err)
+		return
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	indexed, missing := 0, 0
+	for _, article := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+
+		text := ""
+		ev, err := storageClient.GetEvidenceForPolicy(ctx, article.ID, article.EvidencePolicy)
+		switch {
+		case errors.Is(err, storage.ErrNoEvidence):
+			missing++
+		case err != nil:
+			slog.Warn("evidence index: fetch", "id", article.ID, "err", err)
+			continue
+		default:
+			text = evidenceSearchText(ev.Extracted)
+		}
+
+		if err := articles.SetEvidenceText(ctx, article.ID, text); err != nil {
+			slog.Error("evidence index: store", "id", article.ID, "err", err)
+			continue
+		}
+		if text != "" {
+			indexed++
+		}
+	}
+
+	slog.Info("evidence index: complete", "indexed", indexed, "no_evidence", missing, "total", len(pending))
+}
+
+// evidenceSearchText returns the searchable text of an extracted.txt
+// artifact: the title and text of the JSON written at enrichment, or the
+// artifact as-is when it is plain text.
+func evidenceSearchText(extracted []byte) string {
+	var doc struct {
+		Title string `json:"title"`
+		Text  string `json:"text"`
+	}
+	if err := json.Unmarshal(extracted, &doc); err == nil && (doc.Title != "" || doc.Text != "") {
+		return strings.TrimSpace(doc.Title + "\n" + doc.Text)
+	}
+	return strings.TrimSpace(string(extracted))
+}
@@ -0,0 +1,64 @@
+package scraper
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+const (
+	// feedLeakWindow is how far back CheckFeedLeaks looks at feed fetches.
+	feedLeakWindow = 24 * time.Hour
+
+	// feedAccessRetentionDays is how long feed and share fetches are logged.
+	feedAccessRetentionDays = 90
+)
+
+// FeedLeakOptions tunes CheckFeedLeaks.
+type FeedLeakOptions struct {
+	MinIPs     int  // distinct addresses in feedLeakWindow that flag a token
+	AutoRotate bool // replace flagged tokens instead of only warning
+}
+
+// CheckFeedLeaks flags feed tokens whose fetches in the last day look like
+// the feed URL was shared publicly — many distinct addresses, or a link
+// followed from another site — and warns each owner. With AutoRotate the
+// token is replaced, which breaks the leaked URL (and the owner's own
+// reader until they copy the new one). Old log entries are pruned.
+func CheckFeedLeaks(ctx context.Context, feeds *models.FeedAccessStore, users *models.UserStore, notifications *models.NotificationStore, opts FeedLeakOptions) {
+	leaks, err := feeds.FlagLeaks(ctx, time.Now().Add(-feedLeakWindow), opts.MinIPs)
+	if err != nil {
+		slog.Error("feed leaks: flag", "err", err)
+		return
+	}
+
+	for _, leak := range leaks {
+		slog.Warn("feed token looks shared publicly",
+			"user_id", leak.UserID, "token_prefix", leak.TokenPrefix,
+			"distinct_ips", leak.DistinctIPs, "distinct_agents", leak.DistinctAgents,
+			"outside_referers", leak.OutsideReferers)
+
+		if opts.AutoRotate {
+			if _, err := users.ResetFeedToken(ctx, leak.UserID); err != nil {
+				slog.Error("feed leaks: rotate token", "user_id", leak.UserID, "err", err)
+			} else if err := feeds.MarkRotated(ctx, leak.ID); err != nil {
+				slog.Error("feed leaks: mark rotated", "user_id", leak.UserID, "err", err)
+			} else {
+				leak.Rotated = true
+			}
+		}
+		if err := notifications.CreateFeedLeak(ctx, leak.UserID, leak); err != nil {
+			slog.Error("feed leaks: create notification", "user_id", leak.UserID, "err", err)
+		}
+	}
+
+	pruned, err := feeds.Prune(ctx, feedAccessRetentionDays)
+	if err != nil {
+		slog.Error("feed leaks: prune access log", "err", err)
+	}
+	if len(leaks) > 0 || pruned > 0 {
+		slog.Info("feed leaks: checked", "flagged", len(leaks), "pruned", pruned)
+	}
+}
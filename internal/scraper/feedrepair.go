@@ -0,0 +1,348 @@
+package scraper
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Malformed feeds (mostly federal agency and CMS-generated ones) are parsed in
+// stages, each more forgiving than the last:
+//
+//  1. repairFeedXML fixes what breaks encoding/xml outright: stray bytes or
+//     BOMs before the root element, control characters, and bare "&".
+//  2. The feed is decoded strictly, with HTML entities (&nbsp;, &eacute;)
+//     accepted.
+//  3. Failing that, it is decoded leniently (mismatched tags, unquoted
+//     attributes, undeclared entities), and each <item>/<entry> is also
+//     decoded on its own, dropping only the broken ones; whichever recovers
+//     more items is used.
+//
+// Every repair is reported as a warning so the ingestion run shows which
+// feeds need fixing upstream, instead of the whole feed failing.
+
+// maxFeedWarnings caps the warnings reported for one feed.
+const maxFeedWarnings = 20
+
+var (
+	// reFeedItem matches a whole RSS <item> or Atom <entry> element,
+	// prefixed or not.
+	reFeedItem = regexp.MustCompile(`(?s)<(?:[A-Za-z_][\w.-]*:)?(?:item|entry)[\s>].*?</(?:[A-Za-z_][\w.-]*:)?(?:item|entry)>`)
+
+	// reNamespaceDecl matches namespace declarations on the root element.
+	reNamespaceDecl = regexp.MustCompile(`\sxmlns(?::[\w.-]+)?\s*=\s*("[^"]*"|'[^']*')`)
+
+	// reChildElement matches the start of an element in mixed content.
+	reChildElement = regexp.MustCompile(`<[A-Za-z_]`)
+
+	// reCDATA matches a CDATA section.
+	reCDATA = regexp.MustCompile(`(?s)<!\[CDATA\[.*?\]\]>`)
+)
+
+// feedText is the content of a text element that may hold HTML markup as
+// child elements (an unescaped <content:encoded><p>…</p>, an Atom
+// type="xhtml" <div>) rather than as escaped text or CDATA.
+type feedText struct {
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+// String returns the element's markup when it has child elements, otherwise
+// its text.
+func (t feedText) String() string {
+	if t.hasMarkup() {
+		return strings.TrimSpace(t.Inner)
+	}
+	return strings.TrimSpace(t.Text)
+}
+
+// Plain returns the element's text with any child markup removed.
+func (t feedText) Plain() string {
+	if t.hasMarkup() {
+		text := reHTMLTag.ReplaceAllString(reCDATA.ReplaceAllStringFunc(t.Inner, cdataText), " ")
+		return strings.Join(strings.Fields(html.UnescapeString(text)), " ")
+	}
+	return strings.TrimSpace(t.Text)
+}
+
+// cdataText returns the text of a CDATA section, escaped so it survives
+// unescaping with the markup around it.
+func cdataText(section string) string {
+	text := strings.TrimSuffix(strings.TrimPrefix(section, "<![CDATA["), "]]>")
+	return html.EscapeString(text)
+}
+
+func (t feedText) hasMarkup() bool {
+	return reChildElement.MatchString(reCDATA.ReplaceAllString(t.Inner, ""))
+}
+
+// rssLink is an RSS <link>. Items often carry an <atom:link href> as well,
+// which an unqualified struct tag also matches, so every link is kept and
+// rssItemLink picks the right one.
+type rssLink struct {
+	XMLName xml.Name
+	Href    string `xml:"href,attr"`
+	Rel     string `xml:"rel,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// rssItemLink returns the item's RSS link, falling back to an Atom-style
+// href link.
+func rssItemLink(links []rssLink) string {
+	for _, l := range links {
+		if text := strings.TrimSpace(l.Text); text != "" {
+			return text
+		}
+	}
+	for _, l := range links {
+		if l.Href != "" && (l.Rel == "" || l.Rel == "alternate") {
+			return strings.TrimSpace(l.Href)
+		}
+	}
+	return ""
+}
+
+// parseFeedBody parses an RSS 2.0, Atom, or RSS 1.0 (RDF) document, repairing
+// and relaxing the parse as needed. It returns the items along with warnings
+// describing what had to be worked around.
+func parseFeedBody(body []byte) ([]FeedItem, []string, error) {
+	body, warnings := repairFeedXML(body)
+
+	items, strictErr := decodeFeed(body, false)
+	if len(items) > 0 {
+		return items, capWarnings(warnings), nil
+	}
+	problem := "no items found"
+	if strictErr != nil {
+		problem = strictErr.Error()
+	}
+
+	// A lenient parse can stop early at the break, losing the items after
+	// it; keep the item-by-item parse when it recovers more.
+	lenientItems, _ := decodeFeed(body, true)
+	items, itemWarnings := decodeFeedItems(body)
+	if len(items) > len(lenientItems) {
+		warnings = append(warnings, "parsed item by item: "+problem)
+		warnings = append(warnings, itemWarnings...)
+		return items, capWarnings(warnings), nil
+	}
+	if len(lenientItems) > 0 {
+		warnings = append(warnings, "parsed leniently: "+problem)
+		return lenientItems, capWarnings(warnings), nil
+	}
+
+	return nil, capWarnings(warnings), errors.New(problem)
+}
+
+// decodeFeed tries each feed format in turn, returning the items of the
+// first that has any, or the first syntax error.
+func decodeFeed(body []byte, lenient bool) ([]FeedItem, error) {
+	var firstErr error
+	for _, parse := range []func([]byte, bool) ([]FeedItem, error){parseRSS, parseAtom, parseRDF} {
+		items, err := parse(body, lenient)
+		if err == nil && len(items) > 0 {
+			return items, nil
+		}
+		// Other errors only say the document is another format.
+		var syntaxErr *xml.SyntaxError
+		if firstErr == nil && errors.As(err, &syntaxErr) {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// decodeFeedXML decodes a feed document into v. HTML entities are accepted
+// in both modes; lenient mode also tolerates mismatched tags, unquoted
+// attributes and unknown entities.
+func decodeFeedXML(data []byte, v any, lenient bool) error {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	d.Entity = xml.HTMLEntity
+	d.Strict = !lenient
+	return d.Decode(v)
+}
+
+// decodeFeedItems decodes each <item> and <entry> element on its own, with
+// the root element's namespace declarations, skipping those that fail.
+func decodeFeedItems(body []byte) ([]FeedItem, []string) {
+	var namespaces string
+	if root := rootStartTag(body); root != nil {
+		for _, m := range reNamespaceDecl.FindAll(root, -1) {
+			namespaces += string(m)
+		}
+	}
+
+	var items []FeedItem
+	var warnings []string
+	for i, m := range reFeedItem.FindAll(body, -1) {
+		var wrapper struct {
+			Items   []rssItem   `xml:"item"`
+			Entries []atomEntry `xml:"entry"`
+		}
+		doc := append([]byte("<feed"+namespaces+">"), m...)
+		doc = append(doc, "</feed>"...)
+		if err := decodeFeedXML(doc, &wrapper, true); err != nil {
+			warnings = append(warnings, fmt.Sprintf("item %d skipped: %v", i+1, err))
+			continue
+		}
+		for _, ri := range wrapper.Items {
+			items = append(items, rssFeedItem(ri))
+		}
+		for _, entry := range wrapper.Entries {
+			items = append(items, atomFeedItem(entry))
+		}
+	}
+	return items, warnings
+}
+
+// rootStartTag returns the start tag of the document's root element, skipping
+// the XML declaration, comments, processing instructions and doctype.
+func rootStartTag(data []byte) []byte {
+	for {
+		data = bytes.TrimLeft(data, " \t\r\n")
+		switch {
+		case bytes.HasPrefix(data, []byte("<?")):
+			data = skipPast(data, "?>")
+		case bytes.HasPrefix(data, []byte("<!--")):
+			data = skipPast(data, "-->")
+		case bytes.HasPrefix(data, []byte("<!")):
+			data = skipPast(data, ">")
+		case bytes.HasPrefix(data, []byte("<")):
+			if i := bytes.IndexByte(data, '>'); i >= 0 {
+				return data[:i+1]
+			}
+			return nil
+		default:
+			return nil
+		}
+	}
+}
+
+// repairFeedXML fixes problems that make encoding/xml reject a feed outright:
+// anything before the first tag (stray BOMs, whitespace before the XML
+// declaration, PHP notices), BOMs elsewhere in the document, control
+// characters XML does not allow, and ampersands that do not start an entity.
+// It returns the repaired document and a warning for each kind of repair.
+func repairFeedXML(data []byte) ([]byte, []string) {
+	var warnings []string
+
+	if i := bytes.IndexByte(data, '<'); i > 0 {
+		if junk := bytes.TrimSpace(bytes.ReplaceAll(data[:i], utf8BOM, nil)); len(junk) > 0 {
+			warnings = append(warnings, fmt.Sprintf("removed %d bytes of content before the first tag", i))
+		}
+		data = data[i:]
+	}
+	if n := bytes.Count(data, utf8BOM); n > 0 {
+		data = bytes.ReplaceAll(data, utf8BOM, nil)
+		warnings = append(warnings, fmt.Sprintf("removed %d byte order marks inside the document", n))
+	}
+
+	var out bytes.Buffer
+	out.Grow(len(data))
+	controls, ampersands := 0, 0
+	for i := 0; i < len(data); {
+		// "&" needs no escaping in CDATA sections, but control characters
+		// are no more allowed there than anywhere else.
+		if bytes.HasPrefix(data[i:], []byte("<![CDATA[")) {
+			end := bytes.Index(data[i:], []byte("]]>"))
+			if end < 0 {
+				end = len(data) - i
+			} else {
+				end += 3
+			}
+			for _, c := range data[i : i+end] {
+				if isXMLControl(c) {
+					controls++
+					continue
+				}
+				out.WriteByte(c)
+			}
+			i += end
+			continue
+		}
+
+		c := data[i]
+		switch {
+		case isXMLControl(c):
+			controls++
+			i++
+			continue
+		case c == '&' && !startsEntity(data[i+1:]):
+			ampersands++
+			out.WriteString("&amp;")
+			i++
+			continue
+		case c >= utf8.RuneSelf:
+			// Copy multibyte runes whole so a continuation byte is never
+			// mistaken for markup.
+			_, size := utf8.DecodeRune(data[i:])
+			out.Write(data[i : i+size])
+			i += size
+			continue
+		}
+		out.WriteByte(c)
+		i++
+	}
+
+	if controls > 0 {
+		warnings = append(warnings, fmt.Sprintf("removed %d control characters not allowed in XML", controls))
+	}
+	if ampersands > 0 {
+		warnings = append(warnings, fmt.Sprintf("escaped %d bare ampersands", ampersands))
+	}
+	return out.Bytes(), warnings
+}
+
+// isXMLControl reports whether c is a control character XML does not allow.
+func isXMLControl(c byte) bool {
+	return c < 0x20 && c != '\t' && c != '\n' && c != '\r'
+}
+
+// startsEntity reports whether b (the text after an "&") is an entity or
+// character reference such as "amp;", "eacute;", "#233;" or "#xE9;".
+func startsEntity(b []byte) bool {
+	end := bytes.IndexByte(head(b, 32), ';')
+	if end <= 0 {
+		return false
+	}
+	name := b[:end]
+	if name[0] == '#' {
+		digits := name[1:]
+		hex := len(digits) > 0 && (digits[0] == 'x' || digits[0] == 'X')
+		if hex {
+			digits = digits[1:]
+		}
+		if len(digits) == 0 {
+			return false
+		}
+		for _, d := range digits {
+			isDigit := d >= '0' && d <= '9'
+			isHex := (d >= 'a' && d <= 'f') || (d >= 'A' && d <= 'F')
+			if !isDigit && !(hex && isHex) {
+				return false
+			}
+		}
+		return true
+	}
+	for i, ch := range name {
+		isLetter := (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+		if !isLetter && (i == 0 || ch < '0' || ch > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// capWarnings trims warnings to maxFeedWarnings.
+func capWarnings(warnings []string) []string {
+	if len(warnings) > maxFeedWarnings {
+		extra := len(warnings) - maxFeedWarnings + 1
+		warnings = append(warnings[:maxFeedWarnings-1], fmt.Sprintf("%d more warnings", extra))
+	}
+	return warnings
+}
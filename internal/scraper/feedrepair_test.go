@@ -0,0 +1,268 @@
+package scraper
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRepairFeedXML(t *testing.T) {
+	tests := []struct {
+		name         string
+		in           string
+		want         string
+		wantWarnings []string // substrings, in order
+	}{
+		{
+			name: "well-formed feed is untouched",
+			in:   `<?xml version="1.0"?><rss><channel><title>A &amp; B &#233; &eacute;</title></channel></rss>`,
+			want: `<?xml version="1.0"?><rss><channel><title>A &amp; B &#233; &eacute;</title></channel></rss>`,
+		},
+		{
+			name:         "content before the first tag is dropped",
+			in:           "Notice: undefined index\n<?xml version=\"1.0\"?><rss/>",
+			want:         `<?xml version="1.0"?><rss/>`,
+			wantWarnings: []string{"removed 24 bytes of content before the first tag"},
+		},
+		{
+			name: "a leading BOM and whitespace are dropped without a warning",
+			in:   "\xEF\xBB\xBF  <rss/>",
+			want: "<rss/>",
+		},
+		{
+			name:         "BOMs inside the document are removed",
+			in:           "<rss><title>a\xEF\xBB\xBFb</title></rss>",
+			want:         "<rss><title>ab</title></rss>",
+			wantWarnings: []string{"removed 1 byte order marks"},
+		},
+		{
+			name:         "control characters are removed",
+			in:           "<rss><title>a\x00b\x1Fc\td</title></rss>",
+			want:         "<rss><title>abc\td</title></rss>",
+			wantWarnings: []string{"removed 2 control characters"},
+		},
+		{
+			name:         "bare ampersands are escaped",
+			in:           `<rss><link>https://x.example/?a=1&b=2</link><title>Tom & Jerry &copy;</title></rss>`,
+			want:         `<rss><link>https://x.example/?a=1&amp;b=2</link><title>Tom &amp; Jerry &copy;</title></rss>`,
+			wantWarnings: []string{"escaped 2 bare ampersands"},
+		},
+		{
+			name:         "CDATA keeps its ampersands but loses control characters",
+			in:           "<rss><description><![CDATA[a & b \x01c]]></description></rss>",
+			want:         "<rss><description><![CDATA[a & b c]]></description></rss>",
+			wantWarnings: []string{"removed 1 control characters"},
+		},
+		{
+			name:         "multibyte runes are kept",
+			in:           "<rss><title>Año & niño</title></rss>",
+			want:         "<rss><title>Año &amp; niño</title></rss>",
+			wantWarnings: []string{"escaped 1 bare ampersands"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, warnings := repairFeedXML([]byte(tt.in))
+			if string(got) != tt.want {
+				t.Errorf("repairFeedXML = %q, want %q", got, tt.want)
+			}
+			if err := decodeFeedXML(got, new(struct{}), false); err != nil {
+				t.Errorf("repaired feed does not parse: %v", err)
+			}
+			if len(warnings) != len(tt.wantWarnings) {
+				t.Fatalf("warnings = %q, want %d matching %q", warnings, len(tt.wantWarnings), tt.wantWarnings)
+			}
+			for i, w := range tt.wantWarnings {
+				if !strings.Contains(warnings[i], w) {
+					t.Errorf("warning %d = %q, want it to contain %q", i, warnings[i], w)
+				}
+			}
+		})
+	}
+}
+
+func TestStartsEntity(t *testing.T) {
+	tests := []struct {
+		in   string
+		want bool
+	}{
+		{"amp; rest", true},
+		{"eacute;", true},
+		{"frac12;", true},
+		{"#233;", true},
+		{"#xE9;", true},
+		{"#XE9;", true},
+		{"#x;", false},
+		{"#;", false},
+		{"#12a;", false},
+		{"#xG1;", false},
+		{"1abc;", false},
+		{"a b;", false},
+		{";", false},
+		{"amp", false},
+		{"b=2&c=3", false},
+		{"", false},
+		{strings.Repeat("a", 40) + ";", false},
+	}
+	for _, tt := range tests {
+		if got := startsEntity([]byte(tt.in)); got != tt.want {
+			t.Errorf("startsEntity(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseFeedBody(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantTitles  []string
+		wantLinks   []string
+		wantWarning string // substring of some warning; "" means none expected
+		wantErr     bool
+	}{
+		{
+			name: "RSS 2.0",
+			body: `<?xml version="1.0"?><rss version="2.0"><channel>
+				<item><title>One</title><link>https://x.example/1</link></item>
+				<item><title>Two</title><link>https://x.example/2</link></item>
+			</channel></rss>`,
+			wantTitles: []string{"One", "Two"},
+			wantLinks:  []string{"https://x.example/1", "https://x.example/2"},
+		},
+		{
+			name: "Atom",
+			body: `<feed xmlns="http://www.w3.org/2005/Atom">
+				<entry><title>One</title><link rel="alternate" href="https://x.example/1"/></entry>
+			</feed>`,
+			wantTitles: []string{"One"},
+			wantLinks:  []string{"https://x.example/1"},
+		},
+		{
+			name: "RSS item with an atom:link as well",
+			body: `<rss xmlns:atom="http://www.w3.org/2005/Atom"><channel>
+				<item><atom:link href="https://x.example/self" rel="self"/><title>One</title><link>https://x.example/1</link></item>
+			</channel></rss>`,
+			wantTitles: []string{"One"},
+			wantLinks:  []string{"https://x.example/1"},
+		},
+		{
+			name: "HTML entities and bare ampersands",
+			body: `<rss><channel>
+				<item><title>Caf&eacute; &nbsp;&amp; Tom & Jerry</title><link>https://x.example/?a=1&b=2</link></item>
+			</channel></rss>`,
+			wantTitles:  []string{"Café  & Tom & Jerry"},
+			wantLinks:   []string{"https://x.example/?a=1&b=2"},
+			wantWarning: "escaped 2 bare ampersands",
+		},
+		{
+			name: "junk before the XML declaration",
+			body: "Warning: something\n" + `<?xml version="1.0"?><rss><channel>
+				<item><title>One</title><link>https://x.example/1</link></item>
+			</channel></rss>`,
+			wantTitles:  []string{"One"},
+			wantLinks:   []string{"https://x.example/1"},
+			wantWarning: "before the first tag",
+		},
+		{
+			name: "mismatched tags are parsed leniently",
+			body: `<rss><channel>
+				<item><title>One</title><link>https://x.example/1</link></item>
+				<item><title>Two<link>https://x.example/2</link></item>
+				<item><title>Three</title><link>https://x.example/3</link></item>
+			</channel></rss>`,
+			wantTitles:  []string{"One", "Two", "Three"},
+			wantLinks:   []string{"https://x.example/1", "", "https://x.example/3"},
+			wantWarning: "parsed leniently",
+		},
+		{
+			name: "an item that stops the lenient parse is dropped and the rest kept",
+			body: `<rss><channel>
+				<item><title>One</title><link>https://x.example/1</link></item>
+				<item><title>Two</title><link>https://x.example/2</link><1link></item>
+				<item><title>Three</title><link>https://x.example/3</link></item>
+			</channel></rss>`,
+			wantTitles:  []string{"One", "Three"},
+			wantLinks:   []string{"https://x.example/1", "https://x.example/3"},
+			wantWarning: "item 2 skipped",
+		},
+		{
+			name:    "no items",
+			body:    `<rss><channel><title>Empty</title></channel></rss>`,
+			wantErr: true,
+		},
+		{
+			name:    "not a feed",
+			body:    `<html><body>Not found</body></html>`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, warnings, err := parseFeedBody([]byte(tt.body))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseFeedBody = %d items, want an error", len(items))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFeedBody: %v (warnings %q)", err, warnings)
+			}
+
+			var titles, links []string
+			for _, it := range items {
+				titles = append(titles, it.Title)
+				links = append(links, it.Link)
+			}
+			if strings.Join(titles, "|") != strings.Join(tt.wantTitles, "|") {
+				t.Errorf("titles = %q, want %q", titles, tt.wantTitles)
+			}
+			if strings.Join(links, "|") != strings.Join(tt.wantLinks, "|") {
+				t.Errorf("links = %q, want %q", links, tt.wantLinks)
+			}
+
+			if tt.wantWarning == "" {
+				if len(warnings) > 0 {
+					t.Errorf("warnings = %q, want none", warnings)
+				}
+				return
+			}
+			found := false
+			for _, w := range warnings {
+				found = found || strings.Contains(w, tt.wantWarning)
+			}
+			if !found {
+				t.Errorf("warnings = %q, want one containing %q", warnings, tt.wantWarning)
+			}
+		})
+	}
+}
+
+func TestCapWarnings(t *testing.T) {
+	many := func(n int) []string {
+		w := make([]string, n)
+		for i := range w {
+			w[i] = "w"
+		}
+		return w
+	}
+	tests := []struct {
+		n        int
+		wantLen  int
+		wantLast string
+	}{
+		{0, 0, ""},
+		{maxFeedWarnings, maxFeedWarnings, "w"},
+		{maxFeedWarnings + 1, maxFeedWarnings, "2 more warnings"},
+		{maxFeedWarnings + 10, maxFeedWarnings, "11 more warnings"},
+	}
+	for _, tt := range tests {
+		got := capWarnings(many(tt.n))
+		if len(got) != tt.wantLen {
+			t.Errorf("capWarnings(%d) has %d warnings, want %d", tt.n, len(got), tt.wantLen)
+			continue
+		}
+		if tt.wantLen > 0 && got[len(got)-1] != tt.wantLast {
+			t.Errorf("capWarnings(%d) ends with %q, want %q", tt.n, got[len(got)-1], tt.wantLast)
+		}
+	}
+}
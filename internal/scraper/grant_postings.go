@@ -0,0 +1,80 @@
+package scraper
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// grantPostingBatchSize caps the grant-kind articles extracted per
+// RunGrantPostingExtraction.
+const grantPostingBatchSize = 50
+
+// isGrantPosting reports whether an article's tags mark it as grant-kind.
+func isGrantPosting(tags []string) bool {
+	return slices.Contains(tags, models.GrantPostingTag)
+}
+
+// extractGrantPosting pulls the structured posting (funder, eligibility,
+// award range, match, deadline) out of a grant-kind article and stores it.
+// The error is the model's; a failed store is logged.
+func extractGrantPosting(ctx context.Context, grants *models.GrantStore, aiClient ai.Provider, id uuid.UUID, text string) error {
+	extracted, err := aiClient.ExtractGrantPosting(ctx, text)
+	if err != nil {
+		return err
+	}
+	p := &models.GrantPosting{
+		ArticleID:        id,
+		Funder:           extracted.Funder,
+		EligibleEntities: extracted.EligibleEntities,
+		AwardCeiling:     extracted.AwardCeiling,
+		AwardFloor:       extracted.AwardFloor,
+		MatchRequired:    extracted.MatchRequired,
+		MatchRequirement: extracted.MatchRequirement,
+		Deadline:         extracted.Deadline,
+	}
+	if err := grants.SetPosting(ctx, p); err != nil {
+		slog.Error("enrichment: store grant posting", "id", id, "err", err)
+		return nil
+	}
+	slog.Debug("enrichment: grant posting extracted", "id", id, "funder", p.Funder, "deadline", p.Deadline)
+	return nil
+}
+
+// RunGrantPostingExtraction extracts the postings of grant-kind articles
+// that have none yet: articles tagged after enrichment, collected by hand,
+// or enriched before extraction existed. It stops at the first model error
+// and picks up where it left off on the next run.
+func RunGrantPostingExtraction(ctx context.Context, grants *models.GrantStore, aiClient ai.Provider) {
+	pending, err := grants.ListUnextractedPostings(ctx, grantPostingBatchSize)
+	if err != nil {
+		slog.Error("grant postings: list unextracted", "err", err)
+		return
+	}
+	if len(pending) == 0 {
+		return
+	}
+
+	extracted := 0
+	for i := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+		a := &pending[i]
+		text := a.CleanText
+		if len(text) > 8000 {
+			text = text[:8000]
+		}
+		if err := extractGrantPosting(ctx, grants, aiClient, a.ID, text); err != nil {
+			slog.Warn("grant postings: extract", "id", a.ID, "err", err)
+			break
+		}
+		extracted++
+	}
+	slog.Info("grant postings: extraction complete", "extracted", extracted, "pending", len(pending))
+}
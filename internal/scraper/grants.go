@@ -0,0 +1,425 @@
+package scraper
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/useragent"
+)
+
+const (
+	grantsGovSearchURL = "https://api.grants.gov/v1/api/search2"
+	grantsGovFetchURL  = "https://api.grants.gov/v1/api/fetchOpportunity"
+	grantsGovDetailURL = "https://www.grants.gov/search-results-detail/"
+
+	federalRegisterURL = "https://www.federalregister.gov/api/v1/documents.json"
+
+	// grantsPageSize is how many records one fetch asks for.
+	grantsPageSize = 100
+
+	// maxGrantDetailsPerRun caps the opportunity details fetched per source
+	// and run; the rest are fetched on later runs.
+	maxGrantDetailsPerRun = 50
+
+	// GrantClosingDays is the window, in days, in which FlagClosingGrants
+	// announces closing opportunities.
+	GrantClosingDays = 14
+)
+
+// grantsGovParams are the Search2 request fields a grantsgov source's
+// feed_url may set, as a query string (e.g. "keyword=Puerto Rico&agencies=HHS").
+var grantsGovParams = []string{"keyword", "oppNum", "eligibilities", "agencies", "oppStatuses", "aln", "fundingCategories"}
+
+// federalRegisterFields are the document fields requested from the Federal
+// Register API.
+var federalRegisterFields = []string{
+	"document_number", "title", "type", "abstract", "html_url", "agencies",
+	"publication_date", "comments_close_on", "effective_on",
+}
+
+// IsStructuredFeed reports whether sources of feedType are read into their
+// own tables by RunGrantsIngestion rather than into articles.
+func IsStructuredFeed(feedType string) bool {
+	return feedType == "grantsgov" || feedType == "federalregister"
+}
+
+// RunGrantsIngestion fetches every active grantsgov and federalregister
+// source and upserts the opportunities and documents they return.
+func RunGrantsIngestion(ctx context.Context, stores Stores) {
+	ctx = fetchlog.WithPurpose(ctx, fetchlog.Ingestion)
+	if stores.Grants == nil {
+		return
+	}
+	sources, err := stores.Sources.ListActive(ctx)
+	if err != nil {
+		slog.Error("grants: list active sources", "err", err)
+		return
+	}
+
+	for _, src := range sources {
+		if ctx.Err() != nil {
+			return
+		}
+		switch src.FeedType {
+		case "grantsgov":
+			opps, err := FetchGrantsGov(ctx, src)
+			if err != nil {
+				slog.Error("grants: fetch", "source", src.Name, "err", err)
+				continue
+			}
+			added, detailed := 0, 0
+			for i := range opps {
+				inserted, err := stores.Grants.UpsertOpportunity(ctx, &opps[i])
+				if err != nil {
+					slog.Error("grants: store opportunity", "source", src.Name, "opportunity", opps[i].OpportunityNumber, "err", err)
+					continue
+				}
+				if inserted {
+					added++
+				}
+				if opps[i].DetailsFetchedAt != nil || detailed >= maxGrantDetailsPerRun || ctx.Err() != nil {
+					continue
+				}
+				details, err := FetchGrantDetails(ctx, opps[i].OpportunityID)
+				if err != nil {
+					slog.Warn("grants: fetch details", "opportunity", opps[i].OpportunityNumber, "err", err)
+					continue
+				}
+				if err := stores.Grants.SetDetails(ctx, opps[i].ID, details); err != nil {
+					slog.Error("grants: store details", "opportunity", opps[i].OpportunityNumber, "err", err)
+					continue
+				}
+				detailed++
+			}
+			slog.Info("grants: source done", "source", src.Name, "fetched", len(opps), "new", added, "detailed", detailed)
+
+		case "federalregister":
+			docs, err := FetchFederalRegister(ctx, src)
+			if err != nil {
+				slog.Error("grants: fetch", "source", src.Name, "err", err)
+				continue
+			}
+			added := 0
+			for i := range docs {
+				inserted, err := stores.Grants.UpsertFederalDocument(ctx, &docs[i])
+				if err != nil {
+					slog.Error("grants: store federal register document", "source", src.Name, "document", docs[i].DocumentNumber, "err", err)
+					continue
+				}
+				if inserted {
+					added++
+				}
+			}
+			slog.Info("grants: source done", "source", src.Name, "fetched", len(docs), "new", added)
+		}
+	}
+}
+
+// FetchGrantsGov searches Grants.gov for a grantsgov source's open and
+// forecasted opportunities. The source's feed_url holds the search fields as
+// a query string; without oppStatuses only forecasted and posted
+// opportunities are returned.
+func FetchGrantsGov(ctx context.Context, src models.Source) ([]models.GrantOpportunity, error) {
+	params, err := url.ParseQuery(src.FeedURL)
+	if err != nil {
+		return nil, fmt.Errorf("source %s: grantsgov feed_url is not a query string: %w", src.Name, err)
+	}
+	body := map[string]any{"rows": grantsPageSize, "oppStatuses": "forecasted|posted"}
+	for _, key := range grantsGovParams {
+		if v := params.Get(key); v != "" {
+			body[key] = v
+		}
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, feedTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, grantsGovSearchURL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("grantsgov: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		ErrorCode int    `json:"errorcode"`
+		Msg       string `json:"msg"`
+		Data      struct {
+			OppHits []struct {
+				ID         string   `json:"id"`
+				Number     string   `json:"number"`
+				Title      string   `json:"title"`
+				AgencyCode string   `json:"agencyCode"`
+				Agency     string   `json:"agency"`
+				AgencyName string   `json:"agencyName"`
+				OpenDate   string   `json:"openDate"`
+				CloseDate  string   `json:"closeDate"`
+				OppStatus  string   `json:"oppStatus"`
+				DocType    string   `json:"docType"`
+				CFDAList   []string `json:"cfdaList"`
+				ALNList    []string `json:"alnist"`
+			} `json:"oppHits"`
+		} `json:"data"`
+	}
+	if err := doGrantsJSON(req, "grantsgov", &result); err != nil {
+		return nil, err
+	}
+	if result.ErrorCode != 0 {
+		return nil, fmt.Errorf("grantsgov: search: %s (code %d)", result.Msg, result.ErrorCode)
+	}
+
+	opps := make([]models.GrantOpportunity, 0, len(result.Data.OppHits))
+	for _, h := range result.Data.OppHits {
+		if h.ID == "" || h.Title == "" {
+			continue
+		}
+		agency := h.Agency
+		if agency == "" {
+			agency = h.AgencyName
+		}
+		cfda := h.CFDAList
+		if len(cfda) == 0 {
+			cfda = h.ALNList
+		}
+		srcID := src.ID
+		opps = append(opps, models.GrantOpportunity{
+			SourceID:          &srcID,
+			OpportunityID:     h.ID,
+			OpportunityNumber: h.Number,
+			Title:             strings.TrimSpace(h.Title),
+			Agency:            agency,
+			AgencyCode:        h.AgencyCode,
+			CFDANumbers:       cfda,
+			OppStatus:         h.OppStatus,
+			DocType:           h.DocType,
+			OpenDate:          parseAPIDate("01/02/2006", h.OpenDate),
+			CloseDate:         parseAPIDate("01/02/2006", h.CloseDate),
+			URL:               grantsGovDetailURL + url.PathEscape(h.ID),
+		})
+	}
+	return opps, nil
+}
+
+// FetchGrantDetails fetches a Grants.gov opportunity's award amounts and
+// eligibility.
+func FetchGrantDetails(ctx context.Context, opportunityID string) (models.GrantDetails, error) {
+	var d models.GrantDetails
+	payload, err := json.Marshal(map[string]string{"opportunityId": opportunityID})
+	if err != nil {
+		return d, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, feedTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, grantsGovFetchURL, bytes.NewReader(payload))
+	if err != nil {
+		return d, fmt.Errorf("grantsgov: create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result struct {
+		ErrorCode int    `json:"errorcode"`
+		Msg       string `json:"msg"`
+		Data      struct {
+			Synopsis struct {
+				AwardCeiling             json.RawMessage `json:"awardCeiling"`
+				AwardFloor               json.RawMessage `json:"awardFloor"`
+				EstimatedFunding         json.RawMessage `json:"estimatedFunding"`
+				ApplicantEligibilityDesc string          `json:"applicantEligibilityDesc"`
+				ApplicantTypes           []struct {
+					Description string `json:"description"`
+				} `json:"applicantTypes"`
+			} `json:"synopsis"`
+		} `json:"data"`
+	}
+	if err := doGrantsJSON(req, "grantsgov", &result); err != nil {
+		return d, err
+	}
+	if result.ErrorCode != 0 {
+		return d, fmt.Errorf("grantsgov: fetch opportunity %s: %s (code %d)", opportunityID, result.Msg, result.ErrorCode)
+	}
+
+	syn := result.Data.Synopsis
+	d.AwardCeiling = parseAmount(syn.AwardCeiling)
+	d.AwardFloor = parseAmount(syn.AwardFloor)
+	d.EstimatedFunding = parseAmount(syn.EstimatedFunding)
+	d.Eligibility = strings.TrimSpace(syn.ApplicantEligibilityDesc)
+	for _, t := range syn.ApplicantTypes {
+		if t.Description != "" {
+			d.ApplicantTypes = append(d.ApplicantTypes, t.Description)
+		}
+	}
+	return d, nil
+}
+
+// parseAmount reads a dollar amount that Grants.gov sends as a number or a
+// string ("500000", "$1,000,000"). Zero, "none" and the like are nil.
+func parseAmount(raw json.RawMessage) *int64 {
+	s := strings.Trim(strings.TrimSpace(string(raw)), `"`)
+	s = strings.NewReplacer("$", "", ",", "").Replace(s)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s = s[:i]
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n <= 0 {
+		return nil
+	}
+	return &n
+}
+
+// FlagClosingGrants flags the opportunities closing within GrantClosingDays
+// that were not announced yet and notifies every Telegram-linked user of
+// each. Declined opportunities are skipped.
+func FlagClosingGrants(ctx context.Context, grants *models.GrantStore, notifications *models.NotificationStore, telegramUsers *models.TelegramUserStore) {
+	closing, err := grants.FlagClosingSoon(ctx, GrantClosingDays)
+	if err != nil {
+		slog.Error("grants: flag closing", "err", err)
+		return
+	}
+	if len(closing) == 0 {
+		return
+	}
+
+	users, err := telegramUsers.ListAll(ctx)
+	if err != nil {
+		slog.Error("grants: list telegram users", "err", err)
+	}
+	for _, g := range closing {
+		for _, tu := range users {
+			if err := notifications.CreateGrantDeadline(ctx, tu.UserID, g); err != nil {
+				slog.Error("grants: create deadline notification", "opportunity", g.OpportunityNumber, "err", err)
+			}
+		}
+	}
+	slog.Info("grants: closing opportunities flagged", "count", len(closing), "days", GrantClosingDays)
+}
+
+// FetchFederalRegister fetches the newest documents for a federalregister
+// source. The source's feed_url is a documents.json search URL; when empty,
+// documents mentioning the coverage profile's jurisdiction are fetched.
+func FetchFederalRegister(ctx context.Context, src models.Source) ([]models.FederalRegisterDocument, error) {
+	endpoint := src.FeedURL
+	if endpoint == "" {
+		endpoint = federalRegisterURL + "?" + url.Values{"conditions[term]": {`"` + coverage.Current().Name + `"`}}.Encode()
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("source %s: invalid federalregister feed_url: %w", src.Name, err)
+	}
+	q := u.Query()
+	q.Del("fields[]")
+	for _, f := range federalRegisterFields {
+		q.Add("fields[]", f)
+	}
+	q.Set("per_page", fmt.Sprint(grantsPageSize))
+	if q.Get("order") == "" {
+		q.Set("order", "newest")
+	}
+	u.RawQuery = q.Encode()
+
+	ctx, cancel := context.WithTimeout(ctx, feedTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("federalregister: create request: %w", err)
+	}
+
+	var result struct {
+		Results []struct {
+			DocumentNumber  string `json:"document_number"`
+			Title           string `json:"title"`
+			Type            string `json:"type"`
+			Abstract        string `json:"abstract"`
+			HTMLURL         string `json:"html_url"`
+			PublicationDate string `json:"publication_date"`
+			CommentsCloseOn string `json:"comments_close_on"`
+			EffectiveOn     string `json:"effective_on"`
+			Agencies        []struct {
+				Name    string `json:"name"`
+				RawName string `json:"raw_name"`
+			} `json:"agencies"`
+		} `json:"results"`
+	}
+	if err := doGrantsJSON(req, "federalregister", &result); err != nil {
+		return nil, err
+	}
+
+	docs := make([]models.FederalRegisterDocument, 0, len(result.Results))
+	for _, r := range result.Results {
+		if r.DocumentNumber == "" || r.Title == "" || r.HTMLURL == "" {
+			continue
+		}
+		agencies := make([]string, 0, len(r.Agencies))
+		for _, a := range r.Agencies {
+			name := a.Name
+			if name == "" {
+				name = a.RawName
+			}
+			if name != "" {
+				agencies = append(agencies, name)
+			}
+		}
+		srcID := src.ID
+		docs = append(docs, models.FederalRegisterDocument{
+			DocumentNumber:  r.DocumentNumber,
+			SourceID:        &srcID,
+			Title:           strings.TrimSpace(r.Title),
+			DocType:         r.Type,
+			Abstract:        strings.TrimSpace(r.Abstract),
+			Agencies:        agencies,
+			PublicationDate: parseAPIDate("2006-01-02", r.PublicationDate),
+			CommentsCloseOn: parseAPIDate("2006-01-02", r.CommentsCloseOn),
+			EffectiveOn:     parseAPIDate("2006-01-02", r.EffectiveOn),
+			URL:             r.HTMLURL,
+		})
+	}
+	return docs, nil
+}
+
+// doGrantsJSON sends a grants API request and decodes the JSON response.
+// prefix labels errors.
+func doGrantsJSON(req *http.Request, prefix string, v any) error {
+	useragent.Apply(req)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := fetchlog.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s: fetch: %w", prefix, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s: fetch %s: status %d", prefix, req.URL.Host, resp.StatusCode)
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 10*1024*1024)).Decode(v); err != nil {
+		return fmt.Errorf("%s: decode: %w", prefix, err)
+	}
+	return nil
+}
+
+// parseAPIDate parses a date in layout, returning nil when it is empty or
+// malformed.
+func parseAPIDate(layout, s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
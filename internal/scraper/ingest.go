@@ -3,6 +3,7 @@ package scraper
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"strings"
@@ -13,6 +14,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
 	"github.com/Saul-Punybz/folio/internal/models"
 	"github.com/Saul-Punybz/folio/internal/storage"
 )
@@ -26,8 +28,31 @@ const (
 
 	// defaultEvidencePolicy is the default retention policy for new articles.
 	defaultEvidencePolicy = "ret_3m"
+
+	// defaultSitemapLookback limits sitemap discovery to URLs whose <lastmod>
+	// falls within this window, so we don't scrape years-old pages. Override it
+	// with SetSitemapLookback (SITEMAP_LOOKBACK_DAYS).
+	defaultSitemapLookback = 7 * 24 * time.Hour
 )
 
+// sitemapLookback is the window set by SetSitemapLookback; zero means
+// defaultSitemapLookback.
+var sitemapLookback atomic.Int64
+
+// SetSitemapLookback sets how far back sitemap discovery looks. Non-positive
+// values restore the default.
+func SetSitemapLookback(d time.Duration) {
+	sitemapLookback.Store(int64(max(d, 0)))
+}
+
+// currentSitemapLookback returns the sitemap discovery window.
+func currentSitemapLookback() time.Duration {
+	if d := time.Duration(sitemapLookback.Load()); d > 0 {
+		return d
+	}
+	return defaultSitemapLookback
+}
+
 // DiscoveredArticle holds structured data from feed discovery. For RSS feeds,
 // this includes the title, description, publish date, and image URL directly
 // from the feed — avoiding the need to re-scrape the page for basic content.
@@ -45,25 +70,35 @@ type Stores struct {
 	Sources      *models.SourceStore
 	Fingerprints *models.FingerprintStore
 	Entities     *models.EntityStore
+	Jobs         *models.JobStore // when set, enrichment is queued instead of run inline
+	Webhooks     *models.WebhookStore
+	Runs         *models.IngestionRunStore // when set, each run is recorded
+	Grants       *models.GrantStore        // when set, RunGrantsIngestion stores grant records and enrichment extracts grant postings
 }
 
 // RunIngestion is the main ingestion job. It iterates over all active sources,
 // discovers article URLs, deduplicates via fingerprints, scrapes content, and
-// enqueues AI enrichment in background goroutines.
-func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient *ai.OllamaClient, storageClient *storage.Client) {
+// enqueues AI enrichment on the persistent job queue (or, when stores.Jobs is
+// nil, runs it in background goroutines). When stores.Runs is set, the run's
+// counters and errors are recorded as an ingestion_runs row.
+func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient ai.Provider, storageClient *storage.Client) {
+	ctx = fetchlog.WithPurpose(ctx, fetchlog.Ingestion)
 	slog.Info("ingestion: starting run")
 	startTime := time.Now()
+	report := startIngestReport(ctx, stores.Runs)
 
 	// Check how many articles we've already ingested today.
 	todayCount, err := stores.Articles.CountToday(ctx)
 	if err != nil {
 		slog.Error("ingestion: count today", "err", err)
+		report.fail(-1, "", "", "budget", err)
 		todayCount = 0
 	}
 
 	remaining := maxDailyArticles - todayCount
 	if remaining <= 0 {
 		slog.Info("ingestion: daily limit reached", "count", todayCount)
+		report.finish(ctx, "skipped", fmt.Sprintf("daily limit of %d articles already reached (%d today)", maxDailyArticles, todayCount))
 		return
 	}
 
@@ -73,11 +108,14 @@ func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient
 	sources, err := stores.Sources.ListActive(ctx)
 	if err != nil {
 		slog.Error("ingestion: list active sources", "err", err)
+		report.fail(-1, "", "", "sources", err)
+		report.finish(ctx, "failed", "could not load active sources")
 		return
 	}
 
 	if len(sources) == 0 {
 		slog.Info("ingestion: no active sources configured")
+		report.finish(ctx, "skipped", "no active sources configured")
 		return
 	}
 
@@ -87,6 +125,7 @@ func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient
 	sem := make(chan struct{}, maxConcurrentAI)
 	var wg sync.WaitGroup
 	var ingested atomic.Int32
+	note := ""
 
 	for _, src := range sources {
 		if ctx.Err() != nil {
@@ -95,16 +134,30 @@ func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient
 
 		if int(ingested.Load()) >= remaining {
 			slog.Info("ingestion: daily limit reached mid-run")
+			note = fmt.Sprintf("daily limit of %d articles reached mid-run", maxDailyArticles)
 			break
 		}
 
-		discovered, err := discoverArticles(ctx, src, scraper)
+		// Grants.gov and Federal Register sources have their own job.
+		if IsStructuredFeed(src.FeedType) {
+			continue
+		}
+
+		discovered, validators, warnings, err := discoverArticles(ctx, src, scraper)
+		if errors.Is(err, ErrFeedNotModified) {
+			slog.Debug("ingestion: feed not modified", "source", src.Name)
+			report.unchanged(report.addSource(src.Name, 0))
+			continue
+		}
 		if err != nil {
 			slog.Error("ingestion: discover articles",
 				"source", src.Name,
 				"feed_type", src.FeedType,
 				"err", err,
 			)
+			srcIdx := report.addSource(src.Name, 0)
+			report.warn(srcIdx, warnings)
+			report.fail(srcIdx, src.Name, "", "discover", err)
 			continue
 		}
 
@@ -112,13 +165,24 @@ func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient
 			"source", src.Name,
 			"count", len(discovered),
 		)
+		srcIdx := report.addSource(src.Name, len(discovered))
+		if len(warnings) > 0 {
+			slog.Warn("ingestion: feed parsed with warnings", "source", src.Name, "warnings", warnings)
+			report.warn(srcIdx, warnings)
+		}
 
+		// Only remember the feed's validators once every item has been
+		// handled without errors; otherwise the next run's 304 would hide
+		// the items that were cut off or failed here.
+		complete := true
 		for _, da := range discovered {
 			if ctx.Err() != nil {
+				complete = false
 				break
 			}
 
 			if int(ingested.Load()) >= remaining {
+				complete = false
 				break
 			}
 
@@ -131,6 +195,7 @@ func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient
 			exists, blocked, err := stores.Fingerprints.ExistsOrBlocked(ctx, urlHash)
 			if err != nil {
 				slog.Error("ingestion: check fingerprint", "url", rawURL, "err", err)
+				report.fail(srcIdx, src.Name, rawURL, "fingerprint", err)
 				continue
 			}
 			if exists || blocked {
@@ -139,6 +204,7 @@ func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient
 					"exists", exists,
 					"blocked", blocked,
 				)
+				report.skipped(srcIdx)
 				continue
 			}
 
@@ -172,11 +238,13 @@ func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient
 					TitleSelector: src.TitleSelector,
 					BodySelector:  src.BodySelector,
 					DateSelector:  src.DateSelector,
+					RenderJS:      src.RenderJS,
 				}
 
 				scraped, scrapeErr := scraper.ScrapeArticle(ctx, rawURL, selectors)
 				if scrapeErr != nil {
 					slog.Error("ingestion: scrape article", "url", rawURL, "err", scrapeErr)
+					report.fail(srcIdx, src.Name, rawURL, "scrape", scrapeErr)
 					continue
 				}
 
@@ -202,12 +270,14 @@ func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient
 
 			if title == "" && cleanText == "" {
 				slog.Warn("ingestion: empty article, skipping", "url", rawURL)
+				report.skipped(srcIdx)
 				continue
 			}
 
 			// Filter out noise articles (Federal Register procedural filings, etc.)
 			if isNoiseTitle(title) {
 				slog.Debug("ingestion: skipping noise article", "title", truncate(title, 80), "url", rawURL)
+				report.skipped(srcIdx)
 				continue
 			}
 
@@ -219,6 +289,7 @@ func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient
 			}
 			if err := stores.Fingerprints.Create(ctx, fp); err != nil {
 				slog.Error("ingestion: create fingerprint", "url", rawURL, "err", err)
+				report.fail(srcIdx, src.Name, rawURL, "fingerprint", err)
 				continue
 			}
 
@@ -237,16 +308,19 @@ func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient
 				CleanText:    cleanText,
 				ImageURL:     imageURL,
 				Status:       "inbox",
+				Language:     DetectLanguage(title + " " + cleanText),
 				EvidencePolicy:    defaultEvidencePolicy,
 				EvidenceExpiresAt: evidenceExpiry,
 			}
 
 			if err := stores.Articles.Create(ctx, article); err != nil {
 				slog.Error("ingestion: create article", "url", rawURL, "err", err)
+				report.fail(srcIdx, src.Name, rawURL, "create", err)
 				continue
 			}
 
 			ingested.Add(1)
+			report.created(srcIdx)
 			slog.Info("ingestion: article created",
 				"id", article.ID,
 				"title", truncate(article.Title, 80),
@@ -254,21 +328,56 @@ func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient
 				"has_image", imageURL != "",
 			)
 
-			// Enqueue AI enrichment in background.
+			if src.ArchiveSnapshots {
+				QueueArchiveSnapshot(ctx, stores.Jobs, stores.Articles, ArchiveJobPayload{ArticleID: article.ID, URL: rawURL})
+			}
+
+			// Enqueue AI enrichment on the job queue so it survives a worker
+			// crash; fall back to a goroutine if there's no queue.
+			if stores.Jobs != nil {
+				err := stores.Jobs.Enqueue(ctx, models.JobEnrichArticle, EnrichJobPayload{
+					ArticleID: article.ID,
+					RawHTML:   rawHTML,
+				})
+				if err == nil {
+					continue
+				}
+				slog.Error("ingestion: enqueue enrichment, running inline", "id", article.ID, "err", err)
+				report.fail(srcIdx, src.Name, rawURL, "enqueue", err)
+			}
+
 			wg.Add(1)
-			go func(art *models.Article, html string) {
+			go func(art *models.Article, html string, srcIdx int) {
 				defer wg.Done()
 				sem <- struct{}{}
 				defer func() { <-sem }()
 
-				enrichArticle(ctx, art, html, stores, aiClient, storageClient)
-			}(article, rawHTML)
+				if err := enrichArticle(ctx, art, html, false, stores, aiClient, storageClient); err != nil {
+					slog.Error("enrichment: failed", "id", art.ID, "err", err)
+					report.fail(srcIdx, art.Source, art.URL, "enrich", err)
+				}
+			}(article, rawHTML, srcIdx)
+		}
+
+		changed := validators != nil &&
+			(validators.ETag != src.FeedETag || validators.LastModified != src.FeedLastModified)
+		if changed && complete && report.sourceErrors(srcIdx) == 0 {
+			if err := stores.Sources.SetFeedValidators(ctx, src.ID, validators.ETag, validators.LastModified); err != nil {
+				slog.Error("ingestion: store feed validators", "source", src.Name, "err", err)
+			}
 		}
 	}
 
 	// Wait for all background AI enrichment to finish.
 	wg.Wait()
 
+	status := "ok"
+	if err := ctx.Err(); err != nil {
+		status = "failed"
+		note = "interrupted: " + err.Error()
+	}
+	report.finish(ctx, status, note)
+
 	slog.Info("ingestion: run complete",
 		"articles_ingested", ingested.Load(),
 		"duration", time.Since(startTime).Round(time.Millisecond),
@@ -276,44 +385,49 @@ func RunIngestion(ctx context.Context, stores Stores, scraper *Scraper, aiClient
 }
 
 // discoverArticles returns a list of discovered articles from a source based on
-// its feed type. For RSS feeds, this includes structured data (title,
-// description, date, image) directly from the feed items.
-func discoverArticles(ctx context.Context, src models.Source, scraper *Scraper) ([]DiscoveredArticle, error) {
+// its feed type. For RSS and JSON Feed sources, this includes structured data
+// (title, description, date, image) directly from the feed items; partner
+// API sources ("api") likewise carry the licensed full text.
+//
+// RSS, JSON Feed and API sources are fetched conditionally with the source's
+// stored validators: an unchanged feed returns ErrFeedNotModified, and a
+// changed one returns the new validators for the caller to store once the
+// items are processed. Repairs made to parse a malformed RSS or Atom feed
+// are returned as warnings.
+func discoverArticles(ctx context.Context, src models.Source, scraper *Scraper) ([]DiscoveredArticle, *FeedValidators, []string, error) {
+	prev := FeedValidators{ETag: src.FeedETag, LastModified: src.FeedLastModified}
+
 	switch src.FeedType {
 	case "rss":
 		if src.FeedURL == "" {
-			return nil, fmt.Errorf("source %s: rss feed_url is empty", src.Name)
+			return nil, nil, nil, fmt.Errorf("source %s: rss feed_url is empty", src.Name)
 		}
-		items, err := ParseFeed(ctx, src.FeedURL)
+		items, next, warnings, err := fetchFeedItems(ctx, src.FeedURL, prev)
 		if err != nil {
-			return nil, err
+			return nil, nil, warnings, err
 		}
-		results := make([]DiscoveredArticle, 0, len(items))
-		for _, item := range items {
-			if item.Link == "" {
-				continue
-			}
-			da := DiscoveredArticle{
-				URL:         item.Link,
-				Title:       item.Title,
-				Description: CleanText(item.Description),
-				Published:   item.Published,
-				ImageURL:    item.ImageURL,
-			}
-			results = append(results, da)
+		return feedItemsToDiscovered(items), &next, warnings, nil
+
+	case "jsonfeed":
+		if src.FeedURL == "" {
+			return nil, nil, nil, fmt.Errorf("source %s: jsonfeed feed_url is empty", src.Name)
 		}
-		return results, nil
+		items, next, err := ParseJSONFeedConditional(ctx, src.FeedURL, prev)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return feedItemsToDiscovered(items), &next, nil, nil
 
 	case "scrape":
 		if len(src.ListURLs) == 0 {
-			return nil, fmt.Errorf("source %s: no list_urls configured", src.Name)
+			return nil, nil, nil, fmt.Errorf("source %s: no list_urls configured", src.Name)
 		}
 		if src.LinkSelector == "" {
-			return nil, fmt.Errorf("source %s: link_selector is empty", src.Name)
+			return nil, nil, nil, fmt.Errorf("source %s: link_selector is empty", src.Name)
 		}
 		var results []DiscoveredArticle
 		for _, listURL := range src.ListURLs {
-			links, err := scraper.ScrapeLinks(ctx, listURL, src.LinkSelector)
+			links, err := scraper.ScrapeLinks(ctx, listURL, src.LinkSelector, src.RenderJS)
 			if err != nil {
 				slog.Error("ingestion: scrape links", "list_url", listURL, "err", err)
 				continue
@@ -322,37 +436,74 @@ func discoverArticles(ctx context.Context, src models.Source, scraper *Scraper)
 				results = append(results, DiscoveredArticle{URL: link})
 			}
 		}
-		return results, nil
+		return results, nil, nil, nil
+
+	case "api":
+		items, next, err := FetchPartnerAPI(ctx, src, prev)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		return items, &next, nil, nil
 
 	case "sitemap":
 		if src.FeedURL == "" {
-			return nil, fmt.Errorf("source %s: sitemap feed_url is empty", src.Name)
+			return nil, nil, nil, fmt.Errorf("source %s: sitemap feed_url is empty", src.Name)
 		}
-		urls, err := ParseSitemap(ctx, src.FeedURL)
+		urls, err := ParseSitemap(ctx, src.FeedURL, time.Now().Add(-currentSitemapLookback()))
 		if err != nil {
-			return nil, err
+			return nil, nil, nil, err
 		}
 		results := make([]DiscoveredArticle, 0, len(urls))
 		for _, u := range urls {
 			results = append(results, DiscoveredArticle{URL: u})
 		}
-		return results, nil
+		return results, nil, nil, nil
 
 	default:
-		return nil, fmt.Errorf("source %s: unsupported feed_type %q", src.Name, src.FeedType)
+		return nil, nil, nil, fmt.Errorf("source %s: unsupported feed_type %q", src.Name, src.FeedType)
 	}
 }
 
+// feedItemsToDiscovered maps parsed feed items (RSS, Atom, or JSON Feed) into
+// discovered articles, skipping items without a link (or, for podcast
+// episodes, without either a page or show notes).
+func feedItemsToDiscovered(items []FeedItem) []DiscoveredArticle {
+	results := make([]DiscoveredArticle, 0, len(items))
+	for _, item := range items {
+		if item.Link == "" {
+			continue
+		}
+		// An episode whose only URL is its audio file has nothing to scrape.
+		if item.Link == item.EnclosureURL && item.Description == "" {
+			continue
+		}
+		results = append(results, DiscoveredArticle{
+			URL:         item.Link,
+			Title:       item.Title,
+			Description: CleanText(item.Description),
+			Published:   item.Published,
+			ImageURL:    item.ImageURL,
+		})
+	}
+	return results
+}
+
 // enrichArticle runs AI summarization, classification, entity extraction, and
-// embedding, then uploads evidence to S3 and updates the article record.
-func enrichArticle(ctx context.Context, article *models.Article, rawHTML string, stores Stores, aiClient *ai.OllamaClient, storageClient *storage.Client) {
+// embedding, then uploads evidence to S3 and updates the article record. It
+// returns an error when enrichment failed before anything was saved, so a
+// queued job can be retried. A failed embedding is not an error: the article
+// is saved without one and the embedding backfill fills it in later. With
+// reenrich set the article's stored evidence is kept: there is no raw HTML
+// to replace it with.
+func enrichArticle(ctx context.Context, article *models.Article, rawHTML string, reenrich bool, stores Stores, aiClient ai.Provider, storageClient *storage.Client) error {
+	ctx = fetchlog.WithPurpose(ctx, fetchlog.Enrichment)
 	articleID := article.ID
 	slog.Info("enrichment: starting", "id", articleID, "title", truncate(article.Title, 60))
 
 	text := article.CleanText
 	if text == "" {
 		slog.Warn("enrichment: no clean text, skipping", "id", articleID)
-		return
+		return nil
 	}
 
 	// Truncate very long texts for AI processing.
@@ -361,38 +512,25 @@ func enrichArticle(ctx context.Context, article *models.Article, rawHTML string,
 		aiText = aiText[:8000]
 	}
 
-	// Summarize.
-	summary, err := aiClient.Summarize(ctx, aiText)
+	// Summarize, classify and extract entities in one structured call.
+	enrichment, err := aiClient.Enrich(ctx, aiText)
 	if err != nil {
-		slog.Error("enrichment: summarize", "id", articleID, "err", err)
-	} else {
-		slog.Debug("enrichment: summary generated", "id", articleID, "len", len(summary))
-	}
-
-	// Classify.
-	tags, err := aiClient.Classify(ctx, aiText)
-	if err != nil {
-		slog.Error("enrichment: classify", "id", articleID, "err", err)
-	} else {
-		slog.Debug("enrichment: tags generated", "id", articleID, "tags", tags)
-	}
-
-	// Extract entities.
-	extractedEntities, err := aiClient.ExtractEntities(ctx, aiText)
-	if err != nil {
-		slog.Error("enrichment: extract entities", "id", articleID, "err", err)
-	} else {
-		totalEntities := len(extractedEntities.People) + len(extractedEntities.Organizations) + len(extractedEntities.Places)
-		slog.Debug("enrichment: entities extracted", "id", articleID,
-			"people", len(extractedEntities.People),
-			"orgs", len(extractedEntities.Organizations),
-			"places", len(extractedEntities.Places),
-			"total", totalEntities,
-		)
+		// The model is unreachable or broken; nothing has been saved yet, so
+		// bail out and let the job be retried.
+		return fmt.Errorf("enrich: %w", err)
 	}
+	summary, tags := enrichment.Summary, enrichment.Tags
+	extractedEntities := &enrichment.Entities
+	slog.Debug("enrichment: summary, tags and entities generated", "id", articleID,
+		"len", len(summary),
+		"tags", tags,
+		"people", len(extractedEntities.People),
+		"orgs", len(extractedEntities.Organizations),
+		"places", len(extractedEntities.Places),
+	)
 
 	// Persist entities to the entities table and link to the article.
-	if stores.Entities != nil && extractedEntities != nil {
+	if stores.Entities != nil {
 		for _, name := range extractedEntities.People {
 			if entityID, err := stores.Entities.Upsert(ctx, name, "person"); err != nil {
 				slog.Error("enrichment: upsert person entity", "id", articleID, "name", name, "err", err)
@@ -431,10 +569,29 @@ func enrichArticle(ctx context.Context, article *models.Article, rawHTML string,
 		slog.Debug("enrichment: sentiment classified", "id", articleID, "sentiment", sentiment)
 	}
 
-	// Generate embedding.
-	embedding, err := aiClient.Embed(ctx, aiText)
+	// Classify scope (PR local, federal, or diaspora).
+	scope, err := aiClient.ClassifyScope(ctx, aiText)
 	if err != nil {
-		slog.Error("enrichment: embed", "id", articleID, "err", err)
+		slog.Error("enrichment: classify scope", "id", articleID, "err", err)
+		scope = ""
+	} else if err := stores.Articles.SetScope(ctx, articleID, scope); err != nil {
+		slog.Error("enrichment: update scope", "id", articleID, "err", err)
+	}
+
+	// Extract quotable sentences (direct quotes with attribution).
+	quotes := extractQuotes(ctx, stores.Articles, aiClient, articleID, aiText)
+
+	// Grant-kind articles: extract funder, eligibility, award range and deadline.
+	if stores.Grants != nil && isGrantPosting(tags) {
+		if err := extractGrantPosting(ctx, stores.Grants, aiClient, articleID, aiText); err != nil {
+			slog.Error("enrichment: extract grant posting", "id", articleID, "err", err)
+		}
+	}
+
+	// Generate embedding.
+	embedding, embedErr := aiClient.Embed(ctx, aiText)
+	if embedErr != nil {
+		slog.Warn("enrichment: embed failed, leaving it to the backfill", "id", articleID, "err", embedErr)
 	} else {
 		slog.Debug("enrichment: embedding generated", "id", articleID)
 	}
@@ -446,6 +603,9 @@ func enrichArticle(ctx context.Context, article *models.Article, rawHTML string,
 		}
 	}
 
+	// Group with other outlets' coverage of the same story.
+	assignCluster(ctx, stores.Articles, articleID, text, embedding)
+
 	// Update entities and sentiment on the article record.
 	if stores.Entities != nil {
 		entitiesJSON, _ := json.Marshal(extractedEntities)
@@ -457,8 +617,9 @@ func enrichArticle(ctx context.Context, article *models.Article, rawHTML string,
 		}
 	}
 
-	// Upload evidence to S3.
-	if storageClient.Configured() {
+	// Upload evidence to S3 (as its own job when queued, so a storage outage
+	// doesn't force the AI work to be redone).
+	if storageClient.Configured() && !reenrich {
 		extracted, err := json.Marshal(map[string]interface{}{
 			"title":     article.Title,
 			"text":      article.CleanText,
@@ -466,23 +627,31 @@ func enrichArticle(ctx context.Context, article *models.Article, rawHTML string,
 			"entities":  extractedEntities,
 			"summary":   summary,
 			"sentiment": sentiment,
+			"scope":     scope,
+			"quotes":    quotes,
 		})
 		if err != nil {
 			slog.Error("enrichment: marshal extracted", "id", articleID, "err", err)
 		} else {
-			policy := article.EvidencePolicy
-			if policy == "" {
-				policy = defaultEvidencePolicy
+			payload := EvidenceJobPayload{
+				ArticleID: articleID,
+				Policy:    article.EvidencePolicy,
+				URL:       article.URL,
+				RawHTML:   rawHTML,
+				Extracted: extracted,
 			}
-			if err := storageClient.StoreEvidence(ctx, articleID, policy, []byte(rawHTML), extracted, nil); err != nil {
+			if stores.Jobs != nil {
+				if err := stores.Jobs.Enqueue(ctx, models.JobUploadEvidence, payload); err != nil {
+					slog.Error("enrichment: enqueue evidence upload", "id", articleID, "err", err)
+				}
+			} else if err := uploadEvidence(ctx, payload, stores, storageClient); err != nil {
 				slog.Error("enrichment: upload evidence", "id", articleID, "err", err)
-			} else {
-				slog.Debug("enrichment: evidence uploaded", "id", articleID)
 			}
 		}
 	}
 
-	slog.Info("enrichment: complete", "id", articleID)
+	slog.Info("enrichment: complete", "id", articleID, "embedded", embedErr == nil)
+	return nil
 }
 
 // RunEvidenceCleanup deletes expired evidence from S3 and clears the expiry
@@ -521,12 +690,141 @@ func RunEvidenceCleanup(ctx context.Context, stores Stores, storageClient *stora
 			continue
 		}
 
+		// The preserved text is gone, so stop matching it in search.
+		if err := stores.Articles.SetEvidenceText(ctx, article.ID, ""); err != nil {
+			slog.Error("evidence cleanup: clear text index", "id", article.ID, "err", err)
+		}
+
 		cleaned++
 	}
 
 	slog.Info("evidence cleanup: complete", "cleaned", cleaned, "total", len(expired))
 }
 
+// trashPurgeBatch is how many trashed articles PurgeTrash deletes per query.
+const trashPurgeBatch = 200
+
+// PurgeTrash hard-deletes articles trashed more than olderThanDays days ago,
+// with their evidence in S3. Pinned articles are kept. A non-positive
+// olderThanDays disables purging.
+func PurgeTrash(ctx context.Context, articles *models.ArticleStore, storageClient *storage.Client, olderThanDays int) {
+	if olderThanDays <= 0 {
+		return
+	}
+
+	purged := 0
+	for ctx.Err() == nil {
+		ids, err := articles.ListPurgeable(ctx, olderThanDays, trashPurgeBatch)
+		if err != nil {
+			slog.Error("trash purge: list", "err", err)
+			break
+		}
+		if len(ids) == 0 {
+			break
+		}
+
+		deleted, err := articles.Purge(ctx, ids, olderThanDays)
+		if err != nil {
+			slog.Error("trash purge: delete", "err", err)
+			break
+		}
+		// The rows are gone first so an article restored meanwhile keeps
+		// its evidence.
+		for _, id := range deleted {
+			if err := storageClient.DeleteEvidence(ctx, id); err != nil {
+				slog.Error("trash purge: delete evidence", "id", id, "err", err)
+			}
+		}
+		purged += len(deleted)
+		if len(ids) < trashPurgeBatch {
+			break
+		}
+	}
+
+	if purged > 0 {
+		slog.Info("trash purge: complete", "purged", purged, "older_than_days", olderThanDays)
+	}
+}
+
+// EvidenceWarningDays is the window, in days, in which WarnExpiringEvidence
+// announces evidence about to be deleted by RunEvidenceCleanup.
+const EvidenceWarningDays = 7
+
+// WarnExpiringEvidence flags the articles whose evidence expires within
+// EvidenceWarningDays and were not announced yet for that expiry, logs a
+// warning for each and notifies every Telegram-linked user, so retention can
+// be extended before the evidence is deleted. GET /api/items/expiring lists
+// the same articles.
+func WarnExpiringEvidence(ctx context.Context, articles *models.ArticleStore, notifications *models.NotificationStore, telegramUsers *models.TelegramUserStore) {
+	expiring, err := articles.FlagExpiringEvidence(ctx, EvidenceWarningDays)
+	if err != nil {
+		slog.Error("evidence expiry warnings: flag", "err", err)
+		return
+	}
+	if len(expiring) == 0 {
+		return
+	}
+
+	for _, a := range expiring {
+		slog.Warn("evidence expiring", "id", a.ID, "title", a.Title, "policy", a.EvidencePolicy, "expires_at", a.EvidenceExpiresAt)
+	}
+
+	users, err := telegramUsers.ListAll(ctx)
+	if err != nil {
+		slog.Error("evidence expiry warnings: list telegram users", "err", err)
+	}
+	for _, tu := range users {
+		if err := notifications.CreateEvidenceExpiring(ctx, tu.UserID, expiring); err != nil {
+			slog.Error("evidence expiry warnings: create notification", "user_id", tu.UserID, "err", err)
+		}
+	}
+	slog.Info("evidence expiry warnings: flagged", "count", len(expiring), "days", EvidenceWarningDays)
+}
+
+// RunRetentionRules applies every active retention rule, moving matching
+// articles to trash (or saved) once they reach the rule's minimum age.
+func RunRetentionRules(ctx context.Context, rules *models.RetentionRuleStore) {
+	slog.Info("retention rules: starting")
+
+	active, err := rules.List(ctx, true)
+	if err != nil {
+		slog.Error("retention rules: list", "err", err)
+		return
+	}
+
+	total := 0
+	for i := range active {
+		if ctx.Err() != nil {
+			break
+		}
+		rule := &active[i]
+
+		moved, err := rules.Apply(ctx, rule)
+		if err != nil {
+			slog.Error("retention rules: apply", "rule", rule.Name, "err", err)
+			continue
+		}
+		if moved > 0 {
+			slog.Info("retention rules: applied", "rule", rule.Name, "action", rule.Action, "articles", moved)
+		}
+		total += moved
+	}
+
+	slog.Info("retention rules: complete", "rules", len(active), "articles", total)
+}
+
+// RefreshAnalytics refreshes the materialized views behind the analytics
+// endpoints.
+func RefreshAnalytics(ctx context.Context, analytics *models.AnalyticsStore) {
+	start := time.Now()
+	refreshedAt, err := analytics.Refresh(ctx)
+	if err != nil {
+		slog.Error("analytics refresh", "err", err)
+		return
+	}
+	slog.Info("analytics refresh: complete", "snapshot", refreshedAt, "duration", time.Since(start))
+}
+
 // RunSessionCleanup deletes expired sessions from the database.
 func RunSessionCleanup(ctx context.Context, sessionStore *models.SessionStore) {
 	slog.Info("session cleanup: starting")
@@ -539,6 +837,40 @@ func RunSessionCleanup(ctx context.Context, sessionStore *models.SessionStore) {
 	slog.Info("session cleanup: complete")
 }
 
+// RunChatCleanup deletes chat sessions inactive for more than olderThanDays
+// days. A non-positive olderThanDays keeps chats forever.
+func RunChatCleanup(ctx context.Context, chatSessions *models.ChatSessionStore, olderThanDays int) {
+	if olderThanDays <= 0 {
+		return
+	}
+
+	deleted, err := chatSessions.DeleteInactive(ctx, olderThanDays)
+	if err != nil {
+		slog.Error("chat cleanup: delete inactive", "err", err)
+		return
+	}
+	if deleted > 0 {
+		slog.Info("chat cleanup: deleted inactive sessions", "count", deleted, "older_than_days", olderThanDays)
+	}
+}
+
+// RunAICacheCleanup deletes AI results cached more than olderThanDays days
+// ago. A non-positive olderThanDays (the cache is disabled) does nothing.
+func RunAICacheCleanup(ctx context.Context, aiCache *models.AICacheStore, olderThanDays int) {
+	if olderThanDays <= 0 {
+		return
+	}
+
+	deleted, err := aiCache.DeleteOlderThan(ctx, olderThanDays)
+	if err != nil {
+		slog.Error("ai cache cleanup: delete old", "err", err)
+		return
+	}
+	if deleted > 0 {
+		slog.Info("ai cache cleanup: deleted old entries", "count", deleted, "older_than_days", olderThanDays)
+	}
+}
+
 // evidenceExpiryTime calculates the evidence expiry time based on the policy.
 func evidenceExpiryTime(policy string) *time.Time {
 	now := time.Now().UTC()
@@ -666,13 +998,19 @@ var noiseTitlePatterns = []string{
 // isNoiseTitle returns true if the article title matches common bureaucratic
 // noise patterns that should be filtered out during ingestion.
 func isNoiseTitle(title string) bool {
+	return NoiseTitlePattern(title) != ""
+}
+
+// NoiseTitlePattern returns the noise pattern the title matches, or "" when
+// ingestion would keep it.
+func NoiseTitlePattern(title string) string {
 	lower := strings.ToLower(title)
 	for _, pattern := range noiseTitlePatterns {
 		if strings.Contains(lower, pattern) {
-			return true
+			return pattern
 		}
 	}
-	return false
+	return ""
 }
 
 // truncate shortens a string to the given maximum length, appending "..." if
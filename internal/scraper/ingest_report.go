@@ -0,0 +1,127 @@
+package scraper
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// maxRunErrors caps the errors stored with an ingestion run; the full count
+// is kept in ErrorCount.
+const maxRunErrors = 100
+
+// ingestReport accumulates an ingestion run's counters and errors. It is safe
+// for concurrent use, since inline enrichment reports from goroutines.
+type ingestReport struct {
+	mu    sync.Mutex
+	run   *models.IngestionRun
+	store *models.IngestionRunStore
+	start time.Time
+}
+
+// startIngestReport records the start of a run. When store is nil, or the
+// start cannot be recorded, the report is kept in memory only.
+func startIngestReport(ctx context.Context, store *models.IngestionRunStore) *ingestReport {
+	rep := &ingestReport{store: store, start: time.Now()}
+	if store != nil {
+		run, err := store.Start(ctx)
+		if err != nil {
+			slog.Error("ingestion: record run start", "err", err)
+			rep.store = nil
+		} else {
+			rep.run = run
+		}
+	}
+	if rep.run == nil {
+		rep.run = &models.IngestionRun{StartedAt: rep.start, Status: "running"}
+	}
+	return rep
+}
+
+// addSource starts the per-source stats for src and returns its index.
+func (r *ingestReport) addSource(name string, discovered int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.run.SourcesProcessed++
+	r.run.Sources = append(r.run.Sources, models.IngestionSourceStat{Source: name, Discovered: discovered})
+	return len(r.run.Sources) - 1
+}
+
+// unchanged marks a source whose feed answered 304 Not Modified.
+func (r *ingestReport) unchanged(src int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.run.Sources[src].Unchanged = true
+}
+
+func (r *ingestReport) created(src int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.run.ArticlesCreated++
+	r.run.Sources[src].Created++
+}
+
+func (r *ingestReport) skipped(src int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.run.ArticlesSkipped++
+	r.run.Sources[src].Skipped++
+}
+
+// warn records a source's feed parse warnings.
+func (r *ingestReport) warn(src int, warnings []string) {
+	if len(warnings) == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.run.Sources[src].Warnings = append(r.run.Sources[src].Warnings, warnings...)
+}
+
+// fail records an error at the given stage. src is -1 for errors not tied to
+// a source.
+func (r *ingestReport) fail(src int, source, url, stage string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.run.ErrorCount++
+	if src >= 0 {
+		r.run.Sources[src].Errors++
+	}
+	if len(r.run.Errors) < maxRunErrors {
+		r.run.Errors = append(r.run.Errors, models.IngestionError{
+			Source: source,
+			URL:    url,
+			Stage:  stage,
+			Error:  err.Error(),
+		})
+	}
+}
+
+// sourceErrors returns the number of errors recorded for a source so far.
+func (r *ingestReport) sourceErrors(src int) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.run.Sources[src].Errors
+}
+
+// finish stores the run's outcome. An empty status means the run completed.
+func (r *ingestReport) finish(ctx context.Context, status, note string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.run.Status = status
+	r.run.Note = note
+	r.run.DurationMS = time.Since(r.start).Milliseconds()
+	if r.store == nil {
+		return
+	}
+
+	// Record the outcome even if the run's context was cancelled.
+	finishCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 10*time.Second)
+	defer cancel()
+	if err := r.store.Finish(finishCtx, r.run); err != nil {
+		slog.Error("ingestion: record run", "id", r.run.ID, "err", err)
+	}
+}
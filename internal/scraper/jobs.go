@@ -0,0 +1,359 @@
+package scraper
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/notify"
+	"github.com/Saul-Punybz/folio/internal/storage"
+)
+
+const (
+	// jobTimeout bounds a single job attempt.
+	jobTimeout = 5 * time.Minute
+
+	// jobRetention is how long completed jobs are kept before being purged.
+	jobRetention = 7 * 24 * time.Hour
+
+	// jobBookkeepingTimeout bounds recording a job's outcome, which may
+	// happen after the run's context was cancelled.
+	jobBookkeepingTimeout = 10 * time.Second
+)
+
+// jobsRunning prevents overlapping RunJobs calls in one process from piling
+// more concurrent AI requests onto the model than maxConcurrentAI.
+var jobsRunning atomic.Bool
+
+// EnrichJobPayload is the payload of a models.JobEnrichArticle job. Reenrich
+// marks a stored article being enriched again: only its AI fields are
+// rewritten and the evidence captured at ingestion is left alone.
+type EnrichJobPayload struct {
+	ArticleID uuid.UUID `json:"article_id"`
+	RawHTML   string    `json:"raw_html,omitempty"`
+	Reenrich  bool      `json:"reenrich,omitempty"`
+}
+
+// CollectJobPayload is the payload of a models.JobCollectArticle job: a
+// manually collected URL that still needs scraping and enrichment.
+type CollectJobPayload struct {
+	ArticleID uuid.UUID `json:"article_id"`
+	URL       string    `json:"url"`
+}
+
+// EvidenceJobPayload is the payload of a models.JobUploadEvidence job.
+type EvidenceJobPayload struct {
+	ArticleID uuid.UUID       `json:"article_id"`
+	Policy    string          `json:"policy"`
+	URL       string          `json:"url,omitempty"` // page to screenshot
+	RawHTML   string          `json:"raw_html,omitempty"`
+	Extracted json.RawMessage `json:"extracted"`
+}
+
+// RunJobs drains due jobs from the persistent queue, running up to
+// maxConcurrentAI at a time. Failed jobs are rescheduled with exponential
+// backoff by the JobStore until they run out of attempts. When ctx is
+// cancelled (shutdown), in-flight AI calls are aborted and their jobs are
+// released back to the queue without using up an attempt.
+func RunJobs(ctx context.Context, stores Stores, scraper *Scraper, aiClient ai.Provider, storageClient *storage.Client) {
+	ctx = fetchlog.WithPurpose(ctx, fetchlog.Ingestion)
+	if stores.Jobs == nil {
+		return
+	}
+	if !jobsRunning.CompareAndSwap(false, true) {
+		slog.Debug("jobs: previous run still active, skipping")
+		return
+	}
+	defer jobsRunning.Store(false)
+
+	if purged, err := stores.Jobs.DeleteDoneBefore(ctx, time.Now().Add(-jobRetention)); err != nil {
+		slog.Error("jobs: purge done", "err", err)
+	} else if purged > 0 {
+		slog.Info("jobs: purged completed jobs", "count", purged)
+	}
+
+	sem := make(chan struct{}, maxConcurrentAI)
+	var wg sync.WaitGroup
+	var processed, failed atomic.Int32
+
+	for ctx.Err() == nil {
+		jobs, err := stores.Jobs.ClaimBatch(ctx, maxConcurrentAI*2)
+		if err != nil {
+			slog.Error("jobs: claim batch", "err", err)
+			break
+		}
+		if len(jobs) == 0 {
+			break
+		}
+
+		for i := range jobs {
+			job := &jobs[i]
+			wg.Add(1)
+			sem <- struct{}{}
+			go func() {
+				defer wg.Done()
+				defer func() { <-sem }()
+
+				jobCtx, cancel := context.WithTimeout(ctx, jobTimeout)
+				err := runJob(jobCtx, job, stores, scraper, aiClient, storageClient)
+				cancel()
+
+				// Record the outcome even if ctx was cancelled meanwhile.
+				bookCtx, bookCancel := context.WithTimeout(context.WithoutCancel(ctx), jobBookkeepingTimeout)
+				defer bookCancel()
+
+				if err == nil {
+					processed.Add(1)
+					if cErr := stores.Jobs.Complete(bookCtx, job.ID); cErr != nil {
+						slog.Error("jobs: mark complete", "id", job.ID, "err", cErr)
+					}
+					return
+				}
+
+				if ctx.Err() != nil {
+					if rErr := stores.Jobs.Release(bookCtx, job.ID); rErr != nil {
+						slog.Error("jobs: release interrupted job", "id", job.ID, "err", rErr)
+					} else {
+						slog.Info("jobs: interrupted by shutdown, re-queued", "id", job.ID, "kind", job.Kind)
+					}
+					return
+				}
+
+				processed.Add(1)
+				failed.Add(1)
+				next, fErr := stores.Jobs.Fail(bookCtx, job, err.Error())
+				switch {
+				case fErr != nil:
+					slog.Error("jobs: record failure", "id", job.ID, "err", fErr)
+				case next == nil:
+					slog.Error("jobs: giving up", "id", job.ID, "kind", job.Kind, "attempts", job.Attempts, "err", err)
+				default:
+					slog.Warn("jobs: attempt failed, will retry", "id", job.ID, "kind", job.Kind,
+						"attempt", job.Attempts, "retry_at", next.Format(time.RFC3339), "err", err)
+				}
+			}()
+		}
+		wg.Wait()
+	}
+
+	if processed.Load() > 0 {
+		slog.Info("jobs: run complete", "processed", processed.Load(), "failed", failed.Load())
+	}
+}
+
+// runJob dispatches a single job by kind.
+func runJob(ctx context.Context, job *models.Job, stores Stores, scraper *Scraper, aiClient ai.Provider, storageClient *storage.Client) error {
+	switch job.Kind {
+	case models.JobEnrichArticle:
+		var p EnrichJobPayload
+		if err := json.Unmarshal(job.Payload, &p); err != nil {
+			return fmt.Errorf("decode payload: %w", err)
+		}
+		article, err := stores.Articles.GetByID(ctx, p.ArticleID)
+		if err != nil {
+			return err
+		}
+		return enrichArticle(ctx, article, p.RawHTML, p.Reenrich, stores, aiClient, storageClient)
+
+	case models.JobCollectArticle:
+		var p CollectJobPayload
+		if err := json.Unmarshal(job.Payload, &p); err != nil {
+			return fmt.Errorf("decode payload: %w", err)
+		}
+		return EnrichCollected(ctx, stores.Articles, scraper, aiClient, p.ArticleID, p.URL)
+
+	case models.JobUploadEvidence:
+		var p EvidenceJobPayload
+		if err := json.Unmarshal(job.Payload, &p); err != nil {
+			return fmt.Errorf("decode payload: %w", err)
+		}
+		return uploadEvidence(ctx, p, stores, storageClient)
+
+	case models.JobDeliverWebhook:
+		var p notify.WebhookJobPayload
+		if err := json.Unmarshal(job.Payload, &p); err != nil {
+			return fmt.Errorf("decode payload: %w", err)
+		}
+		return deliverWebhook(ctx, p, stores)
+
+	case models.JobArchiveSnapshot:
+		var p ArchiveJobPayload
+		if err := json.Unmarshal(job.Payload, &p); err != nil {
+			return fmt.Errorf("decode payload: %w", err)
+		}
+		return archiveSnapshot(ctx, stores.Articles, p)
+
+	case models.JobEmbedArticle:
+		var p EmbedJobPayload
+		if err := json.Unmarshal(job.Payload, &p); err != nil {
+			return fmt.Errorf("decode payload: %w", err)
+		}
+		return reembedArticle(ctx, stores.Articles, aiClient, p.ArticleID)
+
+	default:
+		return fmt.Errorf("unknown job kind %q", job.Kind)
+	}
+}
+
+// uploadEvidence stores an article's raw HTML, extracted data and, when a
+// screenshot renderer is set, a full-page screenshot in S3, and queues the
+// article's evidence text to be (re)indexed for search. A failed screenshot
+// does not hold back the rest of the evidence.
+func uploadEvidence(ctx context.Context, p EvidenceJobPayload, stores Stores, storageClient *storage.Client) error {
+	policy := p.Policy
+	if policy == "" {
+		policy = defaultEvidencePolicy
+	}
+	var screenshot []byte
+	if r := screenshotter.Load(); r != nil && p.URL != "" {
+		png, err := r.Screenshot(ctx, p.URL)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			slog.Warn("enrichment: evidence screenshot", "id", p.ArticleID, "url", p.URL, "err", err)
+		}
+		screenshot = png
+	}
+	if err := storageClient.StoreEvidence(ctx, p.ArticleID, policy, []byte(p.RawHTML), p.Extracted, screenshot); err != nil {
+		return err
+	}
+	if err := stores.Articles.ResetEvidenceText(ctx, p.ArticleID); err != nil {
+		slog.Warn("enrichment: reset evidence text index", "id", p.ArticleID, "err", err)
+	}
+	slog.Debug("enrichment: evidence uploaded", "id", p.ArticleID)
+	return nil
+}
+
+// deliverWebhook posts a watchlist hit to its webhook target. Targets that
+// were deleted or deactivated since the job was queued are skipped.
+func deliverWebhook(ctx context.Context, p notify.WebhookJobPayload, stores Stores) error {
+	if stores.Webhooks == nil {
+		return fmt.Errorf("webhook store not configured")
+	}
+	target, err := stores.Webhooks.GetByID(ctx, p.TargetID)
+	if err != nil {
+		return err
+	}
+	if target == nil || !target.Active {
+		slog.Debug("webhooks: target gone or inactive, skipping", "target", p.TargetID, "hit", p.Hit.ID)
+		return nil
+	}
+	return notify.Deliver(ctx, target, p.Hit)
+}
+
+// EnrichCollected scrapes a manually collected URL for content and image, then
+// runs AI summarization, classification, and embedding to fill in all missing
+// data. It returns an error when the AI backend failed so the job is retried.
+func EnrichCollected(ctx context.Context, articles *models.ArticleStore, sc *Scraper, aiClient ai.Provider, id uuid.UUID, articleURL string) error {
+	ctx = fetchlog.WithPurpose(ctx, fetchlog.Enrichment)
+	slog.Info("collect: enriching", "id", id, "url", articleURL)
+
+	// Step 1: Extract og:image (always try, independent of text scraping).
+	imageURL := sc.ExtractImageURL(ctx, articleURL)
+	if imageURL != "" {
+		if err := articles.SetImageURL(ctx, id, imageURL); err != nil {
+			slog.Warn("collect: set image", "id", id, "err", err)
+		}
+	}
+
+	// Step 2: Try multiple selector strategies to extract text.
+	selectorSets := []SourceSelectors{
+		{TitleSelector: "h1", BodySelector: "article p"},
+		{TitleSelector: "h1", BodySelector: ".article-body p, .entry-content p, .post-content p"},
+		{TitleSelector: "h1", BodySelector: "main p"},
+		{TitleSelector: "h1", BodySelector: ".content p, #content p, .story-body p, .nota-body p"},
+		{TitleSelector: "h1", BodySelector: "p"},
+	}
+
+	var scraped *ScrapedArticle
+	for _, sel := range selectorSets {
+		result, err := sc.ScrapeArticle(ctx, articleURL, sel)
+		if err != nil {
+			slog.Warn("collect: scrape attempt failed", "id", id, "selector", sel.BodySelector, "err", err)
+			break // Site is unreachable, no point trying more selectors.
+		}
+		if result != nil && len(result.CleanText) > 100 {
+			scraped = result
+			slog.Info("collect: scraped text", "id", id, "selector", sel.BodySelector, "len", len(result.CleanText))
+			break
+		}
+	}
+
+	// A scrape cut short by shutdown is not "no text"; let the job be re-queued.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if scraped == nil || len(scraped.CleanText) < 50 {
+		slog.Warn("collect: no text extracted, skipping AI enrichment", "id", id, "url", articleURL)
+		return nil
+	}
+
+	// Step 3: Update title, clean_text and language.
+	cleanText := scraped.CleanText
+	title := scraped.Title
+
+	var pubAt *time.Time
+	if !scraped.PublishedAt.IsZero() {
+		pubAt = &scraped.PublishedAt
+	}
+
+	_, err := articles.Pool().Exec(ctx, `
+		UPDATE articles
+		SET clean_text = CASE WHEN clean_text = '' OR clean_text IS NULL THEN $1 ELSE clean_text END,
+		    title = CASE WHEN $2 != '' THEN $2 ELSE title END,
+		    published_at = COALESCE(published_at, $3),
+		    language = CASE WHEN language = '' THEN $5 ELSE language END
+		WHERE id = $4
+	`, cleanText, title, pubAt, id, DetectLanguage(title+" "+cleanText))
+	if err != nil {
+		slog.Warn("collect: update content", "id", id, "err", err)
+	}
+
+	// Step 4: AI enrichment — summarize, classify, embed.
+	text := cleanText
+	if len(text) > 8000 {
+		text = text[:8000]
+	}
+
+	enrichment, err := aiClient.Enrich(ctx, text)
+	if err != nil {
+		return fmt.Errorf("enrich: %w", err)
+	}
+	summary, tags := enrichment.Summary, enrichment.Tags
+
+	embedding, err := aiClient.Embed(ctx, text)
+	if err != nil {
+		slog.Warn("collect: embed", "id", id, "err", err)
+		embedding = nil
+	}
+
+	if scope, err := aiClient.ClassifyScope(ctx, text); err != nil {
+		slog.Warn("collect: classify scope", "id", id, "err", err)
+	} else if err := articles.SetScope(ctx, id, scope); err != nil {
+		slog.Warn("collect: update scope", "id", id, "err", err)
+	}
+
+	extractQuotes(ctx, articles, aiClient, id, text)
+
+	// Only overwrite summary if we got a better one from AI (don't clobber snippet).
+	if summary != "" {
+		if err := articles.UpdateEnrichment(ctx, id, summary, tags, embedding); err != nil {
+			return fmt.Errorf("update enrichment: %w", err)
+		}
+	}
+
+	assignCluster(ctx, articles, id, cleanText, embedding)
+
+	slog.Info("collect: enrichment complete", "id", id)
+	return nil
+}
@@ -0,0 +1,67 @@
+package scraper
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Stopwords that are frequent in one language and rare in the other. Words
+// shared by both ("a", "no", "me") are left out.
+var (
+	spanishStopwords = map[string]bool{
+		"el": true, "la": true, "los": true, "las": true, "de": true, "del": true,
+		"que": true, "y": true, "en": true, "un": true, "una": true, "por": true,
+		"con": true, "para": true, "es": true, "su": true, "sus": true, "se": true,
+		"al": true, "lo": true, "como": true, "más": true, "pero": true, "este": true,
+		"esta": true, "fue": true, "son": true, "entre": true, "también": true,
+		"sobre": true, "ha": true, "han": true, "dijo": true, "según": true,
+	}
+	englishStopwords = map[string]bool{
+		"the": true, "of": true, "and": true, "to": true, "in": true, "is": true,
+		"that": true, "for": true, "it": true, "with": true, "as": true, "was": true,
+		"on": true, "are": true, "by": true, "this": true, "be": true, "from": true,
+		"at": true, "or": true, "an": true, "have": true, "has": true, "which": true,
+		"its": true, "their": true, "will": true, "said": true, "were": true,
+		"would": true, "been": true, "who": true,
+	}
+)
+
+const (
+	// languageSampleWords caps how much of the text DetectLanguage reads.
+	languageSampleWords = 400
+
+	// minLanguageHits is the number of stopword matches below which the text
+	// is too short or too unusual to call.
+	minLanguageHits = 5
+)
+
+// DetectLanguage guesses whether text is Spanish ("es") or English ("en") by
+// counting language-specific stopwords. It returns "" when the text is too
+// short or neither language clearly dominates.
+func DetectLanguage(text string) string {
+	var es, en, words int
+	for _, field := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		if spanishStopwords[field] {
+			es++
+		}
+		if englishStopwords[field] {
+			en++
+		}
+		words++
+		if words >= languageSampleWords {
+			break
+		}
+	}
+
+	switch {
+	case es+en < minLanguageHits:
+		return ""
+	case es >= 2*en:
+		return "es"
+	case en >= 2*es:
+		return "en"
+	}
+	return ""
+}
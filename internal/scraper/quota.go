@@ -0,0 +1,143 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// Search engines whose daily query volume is tracked.
+const (
+	EngineDDG        = "ddg"
+	EngineBingNews   = "bing_news"
+	EngineGoogleNews = "google_news"
+)
+
+// Search priorities. Watchlist orgs carry one; other callers (chat, research,
+// enrichment) search at PriorityNormal.
+const (
+	PriorityLow    = 0
+	PriorityNormal = 1
+	PriorityHigh   = 2
+)
+
+// priorityBudgetPercent is the share of an engine's daily budget each
+// priority may use: as the budget runs low, low-priority searches stop first,
+// then normal ones, leaving the remainder for high-priority orgs.
+var priorityBudgetPercent = map[int]int{
+	PriorityLow:    70,
+	PriorityNormal: 90,
+	PriorityHigh:   100,
+}
+
+// ErrSearchBudgetExhausted is returned by search functions when the engine's
+// daily budget for the caller's priority has been spent.
+var ErrSearchBudgetExhausted = errors.New("search budget exhausted")
+
+// SearchQuota enforces daily per-engine query budgets.
+type SearchQuota struct {
+	Usage   *models.SearchUsageStore
+	Budgets map[string]int // daily queries per engine; 0 or missing = unlimited
+}
+
+// searchQuota is the process-wide quota set by SetSearchQuota. Nil means
+// searches are neither counted nor limited.
+var searchQuota atomic.Pointer[SearchQuota]
+
+// SetSearchQuota installs the quota used by WebSearch, BingNewsSearch and
+// ReserveSearch.
+func SetSearchQuota(q *SearchQuota) {
+	searchQuota.Store(q)
+}
+
+// CurrentSearchQuota returns the installed quota, or nil.
+func CurrentSearchQuota() *SearchQuota {
+	return searchQuota.Load()
+}
+
+type searchPriorityKey struct{}
+
+// WithSearchPriority tags ctx with the priority searches made under it count at.
+func WithSearchPriority(ctx context.Context, priority int) context.Context {
+	return context.WithValue(ctx, searchPriorityKey{}, priority)
+}
+
+func searchPriority(ctx context.Context) int {
+	if p, ok := ctx.Value(searchPriorityKey{}).(int); ok {
+		return p
+	}
+	return PriorityNormal
+}
+
+// ReserveSearch counts one query against the engine's daily budget, returning
+// ErrSearchBudgetExhausted (wrapped) if the caller's priority share is spent.
+// Tracking failures are logged and the search is allowed.
+func ReserveSearch(ctx context.Context, engine string) error {
+	q := searchQuota.Load()
+	if q == nil || q.Usage == nil {
+		return nil
+	}
+
+	priority := searchPriority(ctx)
+	limit := q.limitFor(engine, priority)
+	ok, err := q.Usage.Reserve(ctx, engine, limit)
+	if err != nil {
+		slog.Warn("search quota: reserve", "engine", engine, "err", err)
+		return nil
+	}
+	if !ok {
+		return fmt.Errorf("%s (priority %d, limit %d): %w", engine, priority, limit, ErrSearchBudgetExhausted)
+	}
+	return nil
+}
+
+// limitFor returns the number of queries the given priority may make today,
+// or 0 if the engine is unlimited.
+func (q *SearchQuota) limitFor(engine string, priority int) int {
+	budget := q.Budgets[engine]
+	if budget <= 0 {
+		return 0
+	}
+	pct, ok := priorityBudgetPercent[priority]
+	if !ok {
+		pct = priorityBudgetPercent[PriorityNormal]
+	}
+	limit := budget * pct / 100
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
+
+// EngineUsage summarizes today's usage of one engine for the admin stats.
+type EngineUsage struct {
+	Engine    string `json:"engine"`
+	Queries   int    `json:"queries"`
+	Budget    int    `json:"budget"`              // 0 = unlimited
+	Remaining *int   `json:"remaining,omitempty"` // nil when unlimited
+}
+
+// Today returns today's usage for every tracked engine.
+func (q *SearchQuota) Today(ctx context.Context) ([]EngineUsage, error) {
+	var out []EngineUsage
+	for _, engine := range []string{EngineDDG, EngineBingNews, EngineGoogleNews} {
+		used, err := q.Usage.Used(ctx, engine)
+		if err != nil {
+			return nil, err
+		}
+		eu := EngineUsage{Engine: engine, Queries: used, Budget: q.Budgets[engine]}
+		if eu.Budget > 0 {
+			remaining := eu.Budget - used
+			if remaining < 0 {
+				remaining = 0
+			}
+			eu.Remaining = &remaining
+		}
+		out = append(out, eu)
+	}
+	return out, nil
+}
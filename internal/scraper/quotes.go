@@ -0,0 +1,33 @@
+package scraper
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// extractQuotes pulls the article's most quotable direct quotes and stores
+// them on the article. Failures are logged and yield no quotes; they never
+// fail enrichment.
+func extractQuotes(ctx context.Context, articles *models.ArticleStore, aiClient ai.Provider, id uuid.UUID, text string) []models.Quote {
+	extracted, err := aiClient.ExtractQuotes(ctx, text)
+	if err != nil {
+		slog.Error("enrichment: extract quotes", "id", id, "err", err)
+		return nil
+	}
+
+	quotes := make([]models.Quote, len(extracted))
+	for i, q := range extracted {
+		quotes[i] = models.Quote{Text: q.Text, Speaker: q.Speaker, Role: q.Role}
+	}
+	if err := articles.SetQuotes(ctx, id, quotes); err != nil {
+		slog.Error("enrichment: update quotes", "id", id, "err", err)
+	} else if len(quotes) > 0 {
+		slog.Debug("enrichment: quotes extracted", "id", id, "count", len(quotes))
+	}
+	return quotes
+}
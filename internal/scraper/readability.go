@@ -0,0 +1,292 @@
+package scraper
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// minSelectorText is the body length below which ScrapeArticle treats the
+// selector result as a miss and falls back to ExtractReadable.
+const minSelectorText = 100
+
+// Class/id patterns used to weight candidate containers, after Mozilla's
+// Readability.
+var (
+	reUnlikely = regexp.MustCompile(`(?i)banner|breadcrumb|combx|comment|community|cookie|disqus|extra|footer|header|menu|modal|nav|newsletter|outbrain|pager|pagination|popup|promo|related|remark|rss|share|shoutbox|sidebar|skyscraper|social|sponsor|subscribe|suscri|taboola|tags|tool|widget|publicidad|anuncio`)
+	reMaybe    = regexp.MustCompile(`(?i)and|article|body|column|content|main|shadow`)
+	rePositive = regexp.MustCompile(`(?i)article|body|content|entry|hentry|h-entry|main|page|post|text|blog|story|nota|noticia|cuerpo`)
+	reNegative = regexp.MustCompile(`(?i)hidden|banner|combx|comment|com-|contact|foot|footer|footnote|masthead|media|meta|outbrain|promo|related|scroll|share|shoutbox|sidebar|skyscraper|sponsor|shopping|tags|tool|widget|publicidad`)
+)
+
+// skippedTags never hold article text.
+var skippedTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Nav: true,
+	atom.Header: true, atom.Footer: true, atom.Aside: true, atom.Form: true,
+	atom.Iframe: true, atom.Svg: true, atom.Button: true, atom.Select: true,
+	atom.Textarea: true, atom.Input: true, atom.Object: true, atom.Embed: true,
+}
+
+// textBlockTags are the elements whose text makes up the extracted article.
+var textBlockTags = map[atom.Atom]bool{
+	atom.P: true, atom.H2: true, atom.H3: true, atom.H4: true,
+	atom.Li: true, atom.Blockquote: true, atom.Pre: true,
+}
+
+// ExtractReadable finds the main article content of an HTML page without
+// site-specific selectors and returns it as plain text, one paragraph per
+// line pair. It is a simplified Readability: paragraphs are scored by length
+// and comma count, scores flow to their parent and grandparent containers
+// (weighted by class/id hints and link density), and the best container plus
+// its qualifying siblings is kept. Returns "" when no plausible article body
+// is found.
+func ExtractReadable(rawHTML string) string {
+	doc, err := html.Parse(strings.NewReader(rawHTML))
+	if err != nil {
+		return ""
+	}
+
+	body := findFirst(doc, atom.Body)
+	if body == nil {
+		body = doc
+	}
+	pruneUnlikely(body)
+
+	scores := make(map[*html.Node]float64)
+	var candidates []*html.Node
+	addScore := func(n *html.Node, score float64) {
+		if n == nil || n.Type != html.ElementNode {
+			return
+		}
+		if _, ok := scores[n]; !ok {
+			scores[n] = initialScore(n)
+			candidates = append(candidates, n)
+		}
+		scores[n] += score
+	}
+
+	walk(body, func(n *html.Node) bool {
+		if n.Type != html.ElementNode || (n.DataAtom != atom.P && n.DataAtom != atom.Pre && n.DataAtom != atom.Td) {
+			return true
+		}
+		text := nodeText(n)
+		if len(text) < 25 {
+			return false
+		}
+		score := 1 + float64(strings.Count(text, ",")) + float64(min(len(text)/100, 3))
+		addScore(n.Parent, score)
+		if n.Parent != nil {
+			addScore(n.Parent.Parent, score/2)
+		}
+		return false
+	})
+
+	var top *html.Node
+	var topScore float64
+	for _, c := range candidates {
+		score := scores[c] * (1 - linkDensity(c))
+		scores[c] = score
+		if top == nil || score > topScore {
+			top, topScore = c, score
+		}
+	}
+	if top == nil {
+		return ""
+	}
+
+	// Keep the top candidate plus siblings that look like part of the same
+	// article (split bodies, or loose paragraphs next to it).
+	threshold := max(10, topScore*0.2)
+	var parts []*html.Node
+	if top.Parent == nil {
+		parts = []*html.Node{top}
+	} else {
+		for sib := top.Parent.FirstChild; sib != nil; sib = sib.NextSibling {
+			if sib == top {
+				parts = append(parts, sib)
+				continue
+			}
+			if sib.Type != html.ElementNode {
+				continue
+			}
+			if score, ok := scores[sib]; ok && score >= threshold {
+				parts = append(parts, sib)
+				continue
+			}
+			if sib.DataAtom == atom.P {
+				text := nodeText(sib)
+				if len(text) > 80 && linkDensity(sib) < 0.25 {
+					parts = append(parts, sib)
+				}
+			}
+		}
+	}
+
+	var paragraphs []string
+	for _, part := range parts {
+		paragraphs = append(paragraphs, textBlocks(part)...)
+	}
+	text := strings.Join(paragraphs, "\n\n")
+	if len(text) < minSelectorText {
+		return ""
+	}
+	return text
+}
+
+// pruneUnlikely removes elements that never hold article text, and elements
+// whose class/id marks them as page furniture.
+func pruneUnlikely(root *html.Node) {
+	var remove []*html.Node
+	walk(root, func(n *html.Node) bool {
+		if n.Type == html.CommentNode {
+			remove = append(remove, n)
+			return false
+		}
+		if n.Type != html.ElementNode {
+			return true
+		}
+		if skippedTags[n.DataAtom] || attr(n, "aria-hidden") == "true" {
+			remove = append(remove, n)
+			return false
+		}
+		if n.DataAtom == atom.Body || n.DataAtom == atom.Article || n.DataAtom == atom.Main {
+			return true
+		}
+		hints := attr(n, "class") + " " + attr(n, "id")
+		if reUnlikely.MatchString(hints) && !reMaybe.MatchString(hints) {
+			remove = append(remove, n)
+			return false
+		}
+		return true
+	})
+	for _, n := range remove {
+		if n.Parent != nil {
+			n.Parent.RemoveChild(n)
+		}
+	}
+}
+
+// initialScore is a container's score before paragraphs contribute.
+func initialScore(n *html.Node) float64 {
+	score := classWeight(n)
+	switch n.DataAtom {
+	case atom.Article, atom.Main:
+		score += 10
+	case atom.Div:
+		score += 5
+	case atom.Pre, atom.Td, atom.Blockquote:
+		score += 3
+	case atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li, atom.Form:
+		score -= 3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		score -= 5
+	}
+	return score
+}
+
+// classWeight rewards and penalizes containers by their class and id.
+func classWeight(n *html.Node) float64 {
+	var weight float64
+	for _, hint := range []string{attr(n, "class"), attr(n, "id")} {
+		if hint == "" {
+			continue
+		}
+		if reNegative.MatchString(hint) {
+			weight -= 25
+		}
+		if rePositive.MatchString(hint) {
+			weight += 25
+		}
+	}
+	return weight
+}
+
+// linkDensity is the share of a node's text that sits inside links.
+func linkDensity(n *html.Node) float64 {
+	total := len(nodeText(n))
+	if total == 0 {
+		return 0
+	}
+	linked := 0
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.ElementNode && c.DataAtom == atom.A {
+			linked += len(nodeText(c))
+			return false
+		}
+		return true
+	})
+	return float64(linked) / float64(total)
+}
+
+// textBlocks returns the text of the block elements under n, or n's own text
+// when it has none.
+func textBlocks(n *html.Node) []string {
+	var blocks []string
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.ElementNode && textBlockTags[c.DataAtom] {
+			if text := nodeText(c); text != "" && linkDensity(c) < 0.5 {
+				blocks = append(blocks, text)
+			}
+			return false
+		}
+		return true
+	})
+	if len(blocks) == 0 {
+		if text := nodeText(n); text != "" {
+			blocks = append(blocks, text)
+		}
+	}
+	return blocks
+}
+
+// nodeText returns the whitespace-normalized text content of n.
+func nodeText(n *html.Node) string {
+	var sb strings.Builder
+	walk(n, func(c *html.Node) bool {
+		if c.Type == html.TextNode {
+			sb.WriteString(c.Data)
+			sb.WriteByte(' ')
+		}
+		return true
+	})
+	return strings.TrimSpace(reWhitespace.ReplaceAllString(sb.String(), " "))
+}
+
+// walk visits n and its descendants depth-first. Returning false from fn
+// skips the node's children.
+func walk(n *html.Node, fn func(*html.Node) bool) {
+	if !fn(n) {
+		return
+	}
+	for c := n.FirstChild; c != nil; {
+		next := c.NextSibling
+		walk(c, fn)
+		c = next
+	}
+}
+
+func findFirst(n *html.Node, a atom.Atom) *html.Node {
+	var found *html.Node
+	walk(n, func(c *html.Node) bool {
+		if found != nil {
+			return false
+		}
+		if c.Type == html.ElementNode && c.DataAtom == a {
+			found = c
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
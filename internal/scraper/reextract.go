@@ -0,0 +1,52 @@
+package scraper
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// ReextractText recomputes an article's text from its preserved raw HTML
+// with the current extraction rules: the readable article body, or CleanText
+// of the whole page when no body is found. Comparing it with the text stored
+// at capture shows whether rule changes alter how old evidence reads.
+func ReextractText(rawHTML string) string {
+	if text := ExtractReadable(rawHTML); text != "" {
+		return text
+	}
+	return CleanText(rawHTML)
+}
+
+// TextSimilarity returns the cosine similarity of the word counts of a and
+// b, from 0 (no words in common) to 1 (same words, same frequencies).
+// Formatting, case and punctuation are ignored. Two empty texts are
+// identical.
+func TextSimilarity(a, b string) float64 {
+	wa, wb := wordCounts(a), wordCounts(b)
+	if len(wa) == 0 || len(wb) == 0 {
+		if len(wa) == len(wb) {
+			return 1
+		}
+		return 0
+	}
+
+	var dot, na, nb float64
+	for w, ca := range wa {
+		dot += float64(ca * wb[w])
+		na += float64(ca * ca)
+	}
+	for _, cb := range wb {
+		nb += float64(cb * cb)
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+func wordCounts(s string) map[string]int {
+	counts := make(map[string]int)
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	}) {
+		counts[w]++
+	}
+	return counts
+}
@@ -0,0 +1,233 @@
+package scraper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/chromedp/chromedp"
+
+	"github.com/Saul-Punybz/folio/internal/useragent"
+)
+
+// ErrRendererUnavailable is returned when a source asks for JavaScript
+// rendering but no renderer has been installed with SetRenderer.
+var ErrRendererUnavailable = errors.New("scraper: render_js requested but no renderer configured")
+
+// RendererConfig holds the headless browser settings.
+type RendererConfig struct {
+	ExecPath    string        // Chrome/Chromium binary; empty searches the usual locations
+	MaxTabs     int           // pages rendered concurrently
+	PageTimeout time.Duration // budget for loading and rendering one page
+	Settle      time.Duration // extra wait after load for client-side rendering
+	UserAgent   string        // defaults to the configured crawl identity
+	LoadImages  bool          // images are skipped when scraping; screenshots need them
+}
+
+// Renderer loads pages in a shared headless Chrome so sites that build their
+// content client-side can be scraped. The browser is started on first use
+// and restarted if it dies; at most MaxTabs pages render at once.
+type Renderer struct {
+	cfg  RendererConfig
+	tabs chan struct{}
+
+	mu            sync.Mutex
+	browserCtx    context.Context
+	browserCancel context.CancelFunc
+	allocCancel   context.CancelFunc
+}
+
+// NewRenderer creates a Renderer. No browser is launched until the first
+// Render call.
+func NewRenderer(cfg RendererConfig) *Renderer {
+	if cfg.MaxTabs <= 0 {
+		cfg.MaxTabs = 2
+	}
+	if cfg.PageTimeout <= 0 {
+		cfg.PageTimeout = 30 * time.Second
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = useragent.String()
+	}
+	return &Renderer{cfg: cfg, tabs: make(chan struct{}, cfg.MaxTabs)}
+}
+
+// renderer is the process-wide renderer set by SetRenderer. Nil means
+// render_js sources fail with ErrRendererUnavailable.
+var renderer atomic.Pointer[Renderer]
+
+// SetRenderer installs the renderer used for sources with render_js set.
+func SetRenderer(r *Renderer) {
+	renderer.Store(r)
+}
+
+// screenshotter is the process-wide renderer set by SetScreenshotRenderer.
+// Nil means evidence is stored without a screenshot.
+var screenshotter atomic.Pointer[Renderer]
+
+// SetScreenshotRenderer installs the renderer that captures evidence
+// screenshots. It should load images (RendererConfig.LoadImages).
+func SetScreenshotRenderer(r *Renderer) {
+	screenshotter.Store(r)
+}
+
+// Render loads pageURL, waits for scripts to build the page and returns the
+// resulting DOM serialized as HTML.
+func (r *Renderer) Render(ctx context.Context, pageURL string) (string, error) {
+	start := time.Now()
+	var html string
+	err := r.run(ctx, pageURL, chromedp.OuterHTML("html", &html, chromedp.ByQuery))
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+		return "", fmt.Errorf("scraper: render %s: %w", pageURL, err)
+	}
+
+	slog.Debug("rendered page", "url", pageURL, "html_len", len(html), "duration", time.Since(start))
+	return html, nil
+}
+
+// Screenshot loads pageURL like Render and returns a PNG of the whole page,
+// not just the viewport.
+func (r *Renderer) Screenshot(ctx context.Context, pageURL string) ([]byte, error) {
+	start := time.Now()
+	var png []byte
+	// Quality 100 makes chromedp capture PNG rather than JPEG.
+	if err := r.run(ctx, pageURL, chromedp.FullScreenshot(&png, 100)); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("scraper: screenshot %s: %w", pageURL, err)
+	}
+
+	slog.Debug("screenshot taken", "url", pageURL, "png_len", len(png), "duration", time.Since(start))
+	return png, nil
+}
+
+// run opens pageURL in a new tab, waits for it to settle and runs action.
+func (r *Renderer) run(ctx context.Context, pageURL string, action chromedp.Action) error {
+	select {
+	case r.tabs <- struct{}{}:
+		defer func() { <-r.tabs }()
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+
+	browserCtx, err := r.browser()
+	if err != nil {
+		return err
+	}
+
+	tabCtx, cancelTab := chromedp.NewContext(browserCtx)
+	defer cancelTab()
+	tabCtx, cancelTimeout := context.WithTimeout(tabCtx, r.cfg.PageTimeout)
+	defer cancelTimeout()
+
+	// Tab contexts derive from the browser, not the caller; close the tab
+	// if the caller gives up first.
+	stop := context.AfterFunc(ctx, cancelTab)
+	defer stop()
+
+	return chromedp.Run(tabCtx,
+		chromedp.Navigate(pageURL),
+		chromedp.WaitReady("body", chromedp.ByQuery),
+		chromedp.Sleep(r.cfg.Settle),
+		action,
+	)
+}
+
+// browser returns the running browser's context, launching Chrome if it is
+// not running.
+func (r *Renderer) browser() (context.Context, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.browserCtx != nil && r.browserCtx.Err() == nil {
+		return r.browserCtx, nil
+	}
+	r.closeLocked()
+
+	opts := append(chromedp.DefaultExecAllocatorOptions[:],
+		chromedp.UserAgent(r.cfg.UserAgent),
+		chromedp.DisableGPU,
+	)
+	if !r.cfg.LoadImages {
+		opts = append(opts, chromedp.Flag("blink-settings", "imagesEnabled=false"))
+	}
+	if r.cfg.ExecPath != "" {
+		opts = append(opts, chromedp.ExecPath(r.cfg.ExecPath))
+	}
+	// Chrome refuses to start its sandbox as root, which is how the
+	// container runs.
+	if os.Geteuid() == 0 {
+		opts = append(opts, chromedp.NoSandbox)
+	}
+
+	allocCtx, allocCancel := chromedp.NewExecAllocator(context.Background(), opts...)
+	browserCtx, browserCancel := chromedp.NewContext(allocCtx)
+
+	// Running an empty action list starts the browser.
+	if err := chromedp.Run(browserCtx); err != nil {
+		browserCancel()
+		allocCancel()
+		return nil, fmt.Errorf("scraper: start browser: %w", err)
+	}
+
+	slog.Info("headless browser started", "max_tabs", r.cfg.MaxTabs)
+	r.browserCtx, r.browserCancel, r.allocCancel = browserCtx, browserCancel, allocCancel
+	return browserCtx, nil
+}
+
+// Close shuts the browser down. A later Render starts a new one.
+func (r *Renderer) Close() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.closeLocked()
+}
+
+func (r *Renderer) closeLocked() {
+	if r.browserCancel != nil {
+		r.browserCancel()
+	}
+	if r.allocCancel != nil {
+		r.allocCancel()
+	}
+	r.browserCtx, r.browserCancel, r.allocCancel = nil, nil, nil
+}
+
+// renderTransport is an http.RoundTripper that answers GETs with the page as
+// rendered by a Renderer, letting colly collectors apply selectors to
+// client-side content unchanged.
+type renderTransport struct {
+	renderer *Renderer
+}
+
+func (t *renderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return nil, fmt.Errorf("scraper: render: unsupported method %s", req.Method)
+	}
+	html, err := t.renderer.Render(req.Context(), req.URL.String())
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        http.Header{"Content-Type": {"text/html; charset=utf-8"}},
+		Body:          io.NopCloser(strings.NewReader(html)),
+		ContentLength: int64(len(html)),
+		Request:       req,
+	}, nil
+}
@@ -6,8 +6,7 @@ import (
 	"context"
 	"encoding/xml"
 	"fmt"
-	"io"
-	"net/http"
+	"log/slog"
 	"regexp"
 	"strings"
 	"time"
@@ -16,7 +15,7 @@ import (
 // reImgSrc matches src attribute in <img> tags.
 var reImgSrc = regexp.MustCompile(`<img[^>]+src=["']([^"']+)["']`)
 
-// FeedItem represents a single item parsed from an RSS or Atom feed.
+// FeedItem represents a single item parsed from an RSS, RDF, or Atom feed.
 type FeedItem struct {
 	Title       string
 	Link        string
@@ -24,8 +23,21 @@ type FeedItem struct {
 	Published   time.Time
 	GUID        string
 	ImageURL    string
+
+	// EnclosureURL and EnclosureType describe a non-image enclosure, such as
+	// a podcast episode's audio file.
+	EnclosureURL  string
+	EnclosureType string
 }
 
+// XML namespaces used by RSS extensions and RSS 1.0.
+const (
+	nsRDF     = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	nsDC      = "http://purl.org/dc/elements/1.1/"
+	nsContent = "http://purl.org/rss/1.0/modules/content/"
+	nsITunes  = "http://www.itunes.com/dtds/podcast-1.0.dtd"
+)
+
 // rssRoot is the top-level XML element for RSS 2.0 feeds.
 type rssRoot struct {
 	XMLName xml.Name   `xml:"rss"`
@@ -37,13 +49,41 @@ type rssChannel struct {
 }
 
 type rssItem struct {
-	Title       string         `xml:"title"`
-	Link        string         `xml:"link"`
-	Description string         `xml:"description"`
-	PubDate     string         `xml:"pubDate"`
-	GUID        string         `xml:"guid"`
-	Enclosure   rssEnclosure   `xml:"enclosure"`
-	MediaContent []rssMedia    `xml:"content"`
+	Title        string       `xml:"title"`
+	Links        []rssLink    `xml:"link"`
+	Description  feedText     `xml:"description"`
+	PubDate      string       `xml:"pubDate"`
+	GUID         string       `xml:"guid"`
+	Enclosure    rssEnclosure `xml:"enclosure"`
+	MediaContent []rssMedia   `xml:"content"`
+
+	// Extension elements: content:encoded, dc:date, and the iTunes podcast
+	// namespace.
+	ContentEncoded feedText    `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	DCDate         string      `xml:"http://purl.org/dc/elements/1.1/ date"`
+	ITunesSummary  string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd summary"`
+	ITunesSubtitle string      `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd subtitle"`
+	ITunesImage    itunesImage `xml:"http://www.itunes.com/dtds/podcast-1.0.dtd image"`
+}
+
+type itunesImage struct {
+	Href string `xml:"href,attr"`
+}
+
+// rdfRoot is the top-level element of an RSS 1.0 (RDF) feed. Unlike RSS 2.0,
+// items are siblings of the channel rather than children of it.
+type rdfRoot struct {
+	XMLName xml.Name  `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# RDF"`
+	Items   []rdfItem `xml:"item"`
+}
+
+type rdfItem struct {
+	About          string   `xml:"http://www.w3.org/1999/02/22-rdf-syntax-ns# about,attr"`
+	Title          string   `xml:"title"`
+	Link           string   `xml:"link"`
+	Description    feedText `xml:"description"`
+	DCDate         string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+	ContentEncoded feedText `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
 }
 
 type rssEnclosure struct {
@@ -58,15 +98,15 @@ type rssMedia struct {
 
 // atomFeed is the top-level XML element for Atom feeds.
 type atomFeed struct {
-	XMLName xml.Name   `xml:"feed"`
+	XMLName xml.Name    `xml:"feed"`
 	Entries []atomEntry `xml:"entry"`
 }
 
 type atomEntry struct {
-	Title   string     `xml:"title"`
+	Title   feedText   `xml:"title"`
 	Links   []atomLink `xml:"link"`
-	Summary string     `xml:"summary"`
-	Content string     `xml:"content"`
+	Summary feedText   `xml:"summary"`
+	Content feedText   `xml:"content"`
 	Updated string     `xml:"updated"`
 	ID      string     `xml:"id"`
 }
@@ -77,74 +117,121 @@ type atomLink struct {
 	Type string `xml:"type,attr"`
 }
 
-const (
-	feedUserAgent = "Folio/1.0 (+https://github.com/Saul-Punybz/folio)"
-	feedTimeout   = 30 * time.Second
-)
+const feedTimeout = 30 * time.Second
 
-// ParseFeed fetches and parses an RSS 2.0 or Atom feed from the given URL,
-// returning the list of items found.
+// ParseFeed fetches and parses an RSS 2.0, RSS 1.0 (RDF), or Atom feed from
+// the given URL, returning the list of items found.
 func ParseFeed(ctx context.Context, feedURL string) ([]FeedItem, error) {
+	items, _, err := ParseFeedConditional(ctx, feedURL, FeedValidators{})
+	return items, err
+}
+
+// ParseFeedConditional is ParseFeed with a conditional GET: it sends the
+// validators from the previous fetch and returns ErrFeedNotModified if the
+// feed is unchanged, or the items with the response's validators otherwise.
+// Repairs made to a malformed feed are logged as warnings.
+func ParseFeedConditional(ctx context.Context, feedURL string, v FeedValidators) ([]FeedItem, FeedValidators, error) {
+	items, next, warnings, err := fetchFeedItems(ctx, feedURL, v)
+	for _, w := range warnings {
+		slog.Warn("rss: feed parse warning", "url", feedURL, "warning", w)
+	}
+	return items, next, err
+}
+
+// fetchFeedItems is ParseFeedConditional, returning the parse warnings (see
+// parseFeedBody) instead of logging them.
+func fetchFeedItems(ctx context.Context, feedURL string, v FeedValidators) ([]FeedItem, FeedValidators, []string, error) {
 	ctx, cancel := context.WithTimeout(ctx, feedTimeout)
 	defer cancel()
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feedURL, nil)
+	body, contentType, next, err := fetchFeed(ctx, "rss", feedURL,
+		"application/rss+xml, application/atom+xml, application/xml, text/xml", v)
 	if err != nil {
-		return nil, fmt.Errorf("rss: create request: %w", err)
+		return nil, v, nil, err
 	}
-	req.Header.Set("User-Agent", feedUserAgent)
-	req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml, text/xml")
 
-	resp, err := http.DefaultClient.Do(req)
+	// Feeds are often served as ISO-8859-1/Windows-1252, sometimes with a
+	// charset that contradicts the XML declaration; normalize to UTF-8.
+	body = ToUTF8(body, contentType)
+
+	items, warnings, err := parseFeedBody(body)
 	if err != nil {
-		return nil, fmt.Errorf("rss: fetch %s: %w", feedURL, err)
+		return nil, v, warnings, fmt.Errorf("rss: unrecognized feed format at %s: %w", feedURL, err)
 	}
-	defer resp.Body.Close()
+	return items, next, warnings, nil
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("rss: fetch %s: status %d", feedURL, resp.StatusCode)
+// parseRSS attempts to decode RSS 2.0 XML.
+func parseRSS(data []byte, lenient bool) ([]FeedItem, error) {
+	var root rssRoot
+	if err := decodeFeedXML(data, &root, lenient); err != nil {
+		return nil, err
 	}
 
-	body, err := io.ReadAll(io.LimitReader(resp.Body, 10*1024*1024)) // 10 MB limit
-	if err != nil {
-		return nil, fmt.Errorf("rss: read body: %w", err)
+	if len(root.Channel.Items) == 0 {
+		return nil, fmt.Errorf("no RSS items found")
 	}
 
-	// Try RSS 2.0 first.
-	items, err := parseRSS(body)
-	if err == nil && len(items) > 0 {
-		return items, nil
+	items := make([]FeedItem, 0, len(root.Channel.Items))
+	for _, ri := range root.Channel.Items {
+		items = append(items, rssFeedItem(ri))
 	}
 
-	// Fall back to Atom.
-	items, err = parseAtom(body)
-	if err == nil && len(items) > 0 {
-		return items, nil
-	}
+	return items, nil
+}
 
-	return nil, fmt.Errorf("rss: unrecognized feed format at %s", feedURL)
+// rssFeedItem converts a decoded RSS 2.0 item.
+func rssFeedItem(ri rssItem) FeedItem {
+	item := FeedItem{
+		Title:       strings.TrimSpace(ri.Title),
+		Link:        rssItemLink(ri.Links),
+		Description: firstNonEmpty(ri.Description.String(), ri.ContentEncoded.String(), ri.ITunesSummary, ri.ITunesSubtitle),
+		GUID:        strings.TrimSpace(ri.GUID),
+		Published:   parseDate(firstNonEmpty(ri.PubDate, ri.DCDate)),
+		ImageURL:    extractRSSImageURL(ri),
+	}
+	if enc := ri.Enclosure; enc.URL != "" && !strings.HasPrefix(enc.Type, "image/") {
+		item.EnclosureURL = strings.TrimSpace(enc.URL)
+		item.EnclosureType = strings.TrimSpace(enc.Type)
+	}
+	// Podcast episodes often have no <link>; use a URL-shaped GUID or
+	// the audio file itself so the episode is not dropped.
+	if item.Link == "" {
+		if isHTTPURL(item.GUID) {
+			item.Link = item.GUID
+		} else {
+			item.Link = item.EnclosureURL
+		}
+	}
+	if item.GUID == "" {
+		item.GUID = item.Link
+	}
+	return item
 }
 
-// parseRSS attempts to decode RSS 2.0 XML.
-func parseRSS(data []byte) ([]FeedItem, error) {
-	var root rssRoot
-	if err := xml.Unmarshal(data, &root); err != nil {
+// parseRDF attempts to decode RSS 1.0 (RDF) XML.
+func parseRDF(data []byte, lenient bool) ([]FeedItem, error) {
+	var root rdfRoot
+	if err := decodeFeedXML(data, &root, lenient); err != nil {
 		return nil, err
 	}
 
-	if len(root.Channel.Items) == 0 {
-		return nil, fmt.Errorf("no RSS items found")
+	if len(root.Items) == 0 {
+		return nil, fmt.Errorf("no RDF items found")
 	}
 
-	items := make([]FeedItem, 0, len(root.Channel.Items))
-	for _, ri := range root.Channel.Items {
+	items := make([]FeedItem, 0, len(root.Items))
+	for _, ri := range root.Items {
+		description := firstNonEmpty(ri.Description.String(), ri.ContentEncoded.String())
 		item := FeedItem{
 			Title:       strings.TrimSpace(ri.Title),
-			Link:        strings.TrimSpace(ri.Link),
-			Description: strings.TrimSpace(ri.Description),
-			GUID:        strings.TrimSpace(ri.GUID),
-			Published:   parseDate(ri.PubDate),
-			ImageURL:    extractRSSImageURL(ri),
+			Link:        firstNonEmpty(ri.Link, ri.About),
+			Description: description,
+			GUID:        strings.TrimSpace(ri.About),
+			Published:   parseDate(ri.DCDate),
+		}
+		if m := reImgSrc.FindStringSubmatch(description); len(m) >= 2 {
+			item.ImageURL = strings.TrimSpace(m[1])
 		}
 		if item.GUID == "" {
 			item.GUID = item.Link
@@ -155,10 +242,24 @@ func parseRSS(data []byte) ([]FeedItem, error) {
 	return items, nil
 }
 
+// firstNonEmpty returns the first of values that is not blank, trimmed.
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func isHTTPURL(s string) bool {
+	return strings.HasPrefix(s, "http://") || strings.HasPrefix(s, "https://")
+}
+
 // parseAtom attempts to decode Atom XML.
-func parseAtom(data []byte) ([]FeedItem, error) {
+func parseAtom(data []byte, lenient bool) ([]FeedItem, error) {
 	var feed atomFeed
-	if err := xml.Unmarshal(data, &feed); err != nil {
+	if err := decodeFeedXML(data, &feed, lenient); err != nil {
 		return nil, err
 	}
 
@@ -168,28 +269,27 @@ func parseAtom(data []byte) ([]FeedItem, error) {
 
 	items := make([]FeedItem, 0, len(feed.Entries))
 	for _, entry := range feed.Entries {
-		link := atomEntryLink(entry.Links)
-		description := entry.Summary
-		if description == "" {
-			description = entry.Content
-		}
-
-		item := FeedItem{
-			Title:       strings.TrimSpace(entry.Title),
-			Link:        strings.TrimSpace(link),
-			Description: strings.TrimSpace(description),
-			GUID:        strings.TrimSpace(entry.ID),
-			Published:   parseDate(entry.Updated),
-		}
-		if item.GUID == "" {
-			item.GUID = item.Link
-		}
-		items = append(items, item)
+		items = append(items, atomFeedItem(entry))
 	}
 
 	return items, nil
 }
 
+// atomFeedItem converts a decoded Atom entry.
+func atomFeedItem(entry atomEntry) FeedItem {
+	item := FeedItem{
+		Title:       entry.Title.Plain(),
+		Link:        strings.TrimSpace(atomEntryLink(entry.Links)),
+		Description: firstNonEmpty(entry.Summary.String(), entry.Content.String()),
+		GUID:        strings.TrimSpace(entry.ID),
+		Published:   parseDate(entry.Updated),
+	}
+	if item.GUID == "" {
+		item.GUID = item.Link
+	}
+	return item
+}
+
 // atomEntryLink extracts the best link from an Atom entry. It prefers rel="alternate"
 // or the first href found.
 func atomEntryLink(links []atomLink) string {
@@ -208,6 +308,7 @@ func atomEntryLink(links []atomLink) string {
 // 1. <enclosure> with an image type
 // 2. <media:content> with an image type
 // 3. <img> tag in the description HTML
+// 4. <itunes:image>
 func extractRSSImageURL(ri rssItem) string {
 	// Check enclosure (e.g., <enclosure url="..." type="image/jpeg"/>).
 	if ri.Enclosure.URL != "" && strings.HasPrefix(ri.Enclosure.Type, "image/") {
@@ -222,13 +323,18 @@ func extractRSSImageURL(ri rssItem) string {
 	}
 
 	// Fall back to extracting <img src="..."> from description HTML.
-	if ri.Description != "" {
-		matches := reImgSrc.FindStringSubmatch(ri.Description)
+	if html := firstNonEmpty(ri.Description.String(), ri.ContentEncoded.String()); html != "" {
+		matches := reImgSrc.FindStringSubmatch(html)
 		if len(matches) >= 2 {
 			return strings.TrimSpace(matches[1])
 		}
 	}
 
+	// Podcast episode (or show) artwork.
+	if ri.ITunesImage.Href != "" {
+		return strings.TrimSpace(ri.ITunesImage.Href)
+	}
+
 	return ""
 }
 
@@ -240,13 +346,13 @@ func parseDate(s string) time.Time {
 	}
 
 	formats := []string{
-		time.RFC1123Z,                  // Mon, 02 Jan 2006 15:04:05 -0700
-		time.RFC1123,                   // Mon, 02 Jan 2006 15:04:05 MST
-		time.RFC3339,                   // 2006-01-02T15:04:05Z07:00
-		time.RFC3339Nano,               // 2006-01-02T15:04:05.999999999Z07:00
-		"2006-01-02T15:04:05Z",         // ISO without offset
-		"2006-01-02T15:04:05",          // ISO without timezone
-		"2006-01-02",                   // Date only
+		time.RFC1123Z,          // Mon, 02 Jan 2006 15:04:05 -0700
+		time.RFC1123,           // Mon, 02 Jan 2006 15:04:05 MST
+		time.RFC3339,           // 2006-01-02T15:04:05Z07:00
+		time.RFC3339Nano,       // 2006-01-02T15:04:05.999999999Z07:00
+		"2006-01-02T15:04:05Z", // ISO without offset
+		"2006-01-02T15:04:05",  // ISO without timezone
+		"2006-01-02",           // Date only
 		"Mon, 2 Jan 2006 15:04:05 -0700",
 		"Mon, 2 Jan 2006 15:04:05 MST",
 		"02 Jan 2006 15:04:05 -0700",
@@ -10,6 +10,9 @@ import (
 	"time"
 
 	"github.com/gocolly/colly/v2"
+
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/useragent"
 )
 
 // SourceSelectors defines the CSS selectors used to extract content from an
@@ -18,6 +21,7 @@ type SourceSelectors struct {
 	TitleSelector string
 	BodySelector  string
 	DateSelector  string
+	RenderJS      bool // load the page through the headless renderer
 }
 
 // ScrapedArticle holds the extracted content from a single article page.
@@ -29,26 +33,25 @@ type ScrapedArticle struct {
 }
 
 // Scraper wraps a Colly collector configured with respectful rate limiting.
-type Scraper struct {
-	userAgent string
-}
+// It identifies itself with the configured crawl identity (see useragent).
+type Scraper struct{}
 
 // NewScraper creates a new Scraper with rate limiting of 1 request/sec per
 // domain and at most 2 parallel requests.
 func NewScraper() *Scraper {
-	return &Scraper{
-		userAgent: "Folio/1.0",
-	}
+	return &Scraper{}
 }
 
 // newCollector creates a fresh Colly collector with standard settings and rate
 // limiting. Each scrape call gets its own collector to avoid state leakage.
-func (s *Scraper) newCollector() *colly.Collector {
+// Its fetches are logged with the purpose set on ctx.
+func (s *Scraper) newCollector(ctx context.Context) *colly.Collector {
 	c := colly.NewCollector(
-		colly.UserAgent(s.userAgent),
+		colly.UserAgent(useragent.String()),
 		colly.AllowURLRevisit(),
 		colly.MaxDepth(1),
 	)
+	c.WithTransport(fetchlog.Transport(nil, fetchlog.PurposeFrom(ctx)))
 
 	// Rate limit: 1 request per second per domain, 2 parallel requests.
 	_ = c.Limit(&colly.LimitRule{
@@ -62,15 +65,53 @@ func (s *Scraper) newCollector() *colly.Collector {
 	c.OnRequest(func(r *colly.Request) {
 		r.Headers.Set("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8")
 		r.Headers.Set("Accept-Language", "en-US,en;q=0.9,es;q=0.8")
+		if email := useragent.Email(); email != "" {
+			r.Headers.Set("From", email)
+		}
+	})
+
+	// Normalize page text to UTF-8 before any OnHTML callback runs. Colly only
+	// honours the Content-Type charset, which many local sites omit or get
+	// wrong, so <meta charset> and invalid-UTF-8 bodies are handled here.
+	c.OnResponse(func(r *colly.Response) {
+		if ct := r.Headers.Get("Content-Type"); isTextContentType(ct) {
+			r.Body = ToUTF8(r.Body, ct)
+		}
 	})
 
 	return c
 }
 
+// newRenderingCollector is newCollector for sources that build their content
+// client-side: pages are fetched through the installed Renderer.
+func (s *Scraper) newRenderingCollector(ctx context.Context) (*colly.Collector, error) {
+	r := renderer.Load()
+	if r == nil {
+		return nil, ErrRendererUnavailable
+	}
+	c := s.newCollector(ctx)
+	c.WithTransport(fetchlog.Transport(&renderTransport{renderer: r}, fetchlog.PurposeFrom(ctx)))
+	return c, nil
+}
+
+// collectorFor returns a rendering collector when renderJS is set, and a
+// plain one otherwise.
+func (s *Scraper) collectorFor(ctx context.Context, renderJS bool) (*colly.Collector, error) {
+	if renderJS {
+		return s.newRenderingCollector(ctx)
+	}
+	return s.newCollector(ctx), nil
+}
+
 // ScrapeArticle fetches a single article page and extracts its content using the
-// provided CSS selectors.
+// provided CSS selectors. When the body selector is empty or matches fewer
+// than minSelectorText characters, the body is extracted with ExtractReadable.
+// With selectors.RenderJS the page is loaded in the headless renderer first.
 func (s *Scraper) ScrapeArticle(ctx context.Context, articleURL string, selectors SourceSelectors) (*ScrapedArticle, error) {
-	c := s.newCollector()
+	c, err := s.collectorFor(ctx, selectors.RenderJS)
+	if err != nil {
+		return nil, err
+	}
 
 	var (
 		result ScrapedArticle
@@ -172,15 +213,29 @@ func (s *Scraper) ScrapeArticle(ctx context.Context, articleURL string, selector
 		result.Title = extractHTMLTitle(result.RawHTML)
 	}
 
+	// Selectors break whenever a site is redesigned; when they yield too
+	// little text, find the article body automatically instead.
+	if len(result.CleanText) < minSelectorText && result.RawHTML != "" {
+		if text := ExtractReadable(result.RawHTML); len(text) > len(result.CleanText) {
+			slog.Debug("scraped article: selectors missed, using readability extraction",
+				"url", articleURL, "selector_len", len(result.CleanText), "extracted_len", len(text))
+			result.CleanText = text
+		}
+	}
+
 	slog.Debug("scraped article", "url", articleURL, "title_len", len(result.Title), "body_len", len(result.CleanText))
 
 	return &result, nil
 }
 
 // ScrapeLinks fetches a listing/category page and extracts all matching links.
-// Returns a list of absolute URLs.
-func (s *Scraper) ScrapeLinks(ctx context.Context, listURL string, linkSelector string) ([]string, error) {
-	c := s.newCollector()
+// Returns a list of absolute URLs. With renderJS the page is loaded in the
+// headless renderer first.
+func (s *Scraper) ScrapeLinks(ctx context.Context, listURL string, linkSelector string, renderJS bool) ([]string, error) {
+	c, err := s.collectorFor(ctx, renderJS)
+	if err != nil {
+		return nil, err
+	}
 
 	base, err := url.Parse(listURL)
 	if err != nil {
@@ -263,7 +318,7 @@ func (s *Scraper) ExtractImageURL(ctx context.Context, pageURL string) string {
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	c := s.newCollector()
+	c := s.newCollector(ctx)
 
 	var (
 		imageURL string
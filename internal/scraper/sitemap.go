@@ -1,13 +1,27 @@
 package scraper
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/xml"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"strings"
 	"time"
+
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/useragent"
+)
+
+const (
+	// maxSitemapDepth bounds how deep sitemap indexes may nest.
+	maxSitemapDepth = 3
+
+	// maxChildSitemaps caps how many child sitemaps are fetched from one index.
+	maxChildSitemaps = 50
 )
 
 // sitemapURLSet is the root element of a sitemap.xml file.
@@ -21,9 +35,81 @@ type sitemapURL struct {
 	LastMod string `xml:"lastmod"`
 }
 
+// sitemapIndex is the root element of a sitemap index file, which lists
+// other sitemaps rather than pages.
+type sitemapIndex struct {
+	XMLName  xml.Name     `xml:"sitemapindex"`
+	Sitemaps []sitemapURL `xml:"sitemap"`
+}
+
 // ParseSitemap fetches and parses a sitemap.xml file, returning the list of
-// URLs found.
-func ParseSitemap(ctx context.Context, sitemapURL string) ([]string, error) {
+// page URLs found. Sitemap indexes are followed (up to maxSitemapDepth levels).
+// If since is non-zero, URLs and child sitemaps whose <lastmod> is before it
+// are skipped; entries without a <lastmod> are always kept.
+func ParseSitemap(ctx context.Context, sitemapURL string, since time.Time) ([]string, error) {
+	seen := make(map[string]bool)
+	return parseSitemapRecursive(ctx, sitemapURL, since, 0, seen)
+}
+
+func parseSitemapRecursive(ctx context.Context, sitemapURL string, since time.Time, depth int, seen map[string]bool) ([]string, error) {
+	if seen[sitemapURL] {
+		return nil, nil
+	}
+	seen[sitemapURL] = true
+
+	body, err := fetchSitemap(ctx, sitemapURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// A sitemap index lists child sitemaps.
+	var index sitemapIndex
+	if xml.Unmarshal(body, &index) == nil {
+		if depth >= maxSitemapDepth {
+			return nil, fmt.Errorf("sitemap: %s: index nested deeper than %d", sitemapURL, maxSitemapDepth)
+		}
+
+		var urls []string
+		fetched := 0
+		for _, sm := range index.Sitemaps {
+			loc := strings.TrimSpace(sm.Loc)
+			if loc == "" || !modifiedSince(sm.LastMod, since) {
+				continue
+			}
+			if fetched >= maxChildSitemaps {
+				slog.Warn("sitemap: too many child sitemaps, truncating", "index", sitemapURL, "max", maxChildSitemaps)
+				break
+			}
+			fetched++
+
+			child, err := parseSitemapRecursive(ctx, loc, since, depth+1, seen)
+			if err != nil {
+				slog.Warn("sitemap: child sitemap", "index", sitemapURL, "sitemap", loc, "err", err)
+				continue
+			}
+			urls = append(urls, child...)
+		}
+		return urls, nil
+	}
+
+	var urlSet sitemapURLSet
+	if err := xml.Unmarshal(body, &urlSet); err != nil {
+		return nil, fmt.Errorf("sitemap: parse %s: %w", sitemapURL, err)
+	}
+
+	urls := make([]string, 0, len(urlSet.URLs))
+	for _, u := range urlSet.URLs {
+		loc := strings.TrimSpace(u.Loc)
+		if loc != "" && modifiedSince(u.LastMod, since) {
+			urls = append(urls, loc)
+		}
+	}
+
+	return urls, nil
+}
+
+// fetchSitemap downloads a sitemap, transparently decompressing .xml.gz files.
+func fetchSitemap(ctx context.Context, sitemapURL string) ([]byte, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
@@ -31,9 +117,9 @@ func ParseSitemap(ctx context.Context, sitemapURL string) ([]string, error) {
 	if err != nil {
 		return nil, fmt.Errorf("sitemap: create request: %w", err)
 	}
-	req.Header.Set("User-Agent", feedUserAgent)
+	useragent.Apply(req)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := fetchlog.Client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("sitemap: fetch %s: %w", sitemapURL, err)
 	}
@@ -48,18 +134,39 @@ func ParseSitemap(ctx context.Context, sitemapURL string) ([]string, error) {
 		return nil, fmt.Errorf("sitemap: read body: %w", err)
 	}
 
-	var urlSet sitemapURLSet
-	if err := xml.Unmarshal(body, &urlSet); err != nil {
-		return nil, fmt.Errorf("sitemap: parse %s: %w", sitemapURL, err)
+	// Gzipped sitemaps are served as files, not with Content-Encoding, so
+	// net/http leaves them compressed. Detect by magic bytes.
+	if len(body) > 2 && body[0] == 0x1f && body[1] == 0x8b {
+		zr, err := gzip.NewReader(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("sitemap: gunzip %s: %w", sitemapURL, err)
+		}
+		defer zr.Close()
+		body, err = io.ReadAll(io.LimitReader(zr, 50*1024*1024))
+		if err != nil {
+			return nil, fmt.Errorf("sitemap: gunzip %s: %w", sitemapURL, err)
+		}
 	}
 
-	urls := make([]string, 0, len(urlSet.URLs))
-	for _, u := range urlSet.URLs {
-		loc := strings.TrimSpace(u.Loc)
-		if loc != "" {
-			urls = append(urls, loc)
-		}
+	return ToUTF8(body, resp.Header.Get("Content-Type")), nil
+}
+
+// modifiedSince reports whether a <lastmod> value is at or after since. Empty
+// or unparseable values count as modified, since we can't tell.
+func modifiedSince(lastMod string, since time.Time) bool {
+	if since.IsZero() {
+		return true
 	}
+	t := parseLastMod(lastMod)
+	return t.IsZero() || !t.Before(since)
+}
 
-	return urls, nil
+// parseLastMod parses a W3C datetime as used in sitemaps, which allows
+// minute precision ("2006-01-02T15:04Z07:00") on top of the feed formats.
+func parseLastMod(s string) time.Time {
+	s = strings.TrimSpace(s)
+	if t, err := time.Parse("2006-01-02T15:04Z07:00", s); err == nil {
+		return t
+	}
+	return parseDate(s)
 }
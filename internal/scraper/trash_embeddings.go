@@ -0,0 +1,99 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+const (
+	// trashEmbeddingBatch is how many embeddings ArchiveTrashEmbeddings
+	// drops per query.
+	trashEmbeddingBatch = 500
+
+	// reembedBatch caps the restored articles re-embedded per run.
+	reembedBatch = 100
+)
+
+// EmbedJobPayload is the payload of a models.JobEmbedArticle job.
+type EmbedJobPayload struct {
+	ArticleID uuid.UUID `json:"article_id"`
+}
+
+// ArchiveTrashEmbeddings drops the embeddings of articles trashed more than
+// olderThanDays days ago, so they stop taking space in the HNSW index and
+// showing up in similarity results, then regenerates the embeddings of
+// archived articles that were restored since (restores normally queue a
+// JobEmbedArticle; this catches the rest). A non-positive olderThanDays
+// disables archiving but not regeneration.
+func ArchiveTrashEmbeddings(ctx context.Context, articles *models.ArticleStore, aiClient ai.Provider, olderThanDays int) {
+	var archived int64
+	for olderThanDays > 0 && ctx.Err() == nil {
+		n, err := articles.ArchiveTrashedEmbeddings(ctx, olderThanDays, trashEmbeddingBatch)
+		if err != nil {
+			slog.Error("trash embeddings: archive", "err", err)
+			break
+		}
+		archived += n
+		if n < trashEmbeddingBatch {
+			break
+		}
+	}
+	if archived > 0 {
+		slog.Info("trash embeddings: archived", "count", archived, "older_than_days", olderThanDays)
+	}
+
+	ids, err := articles.ListRestoredUnembedded(ctx, reembedBatch)
+	if err != nil {
+		slog.Error("trash embeddings: list restored", "err", err)
+		return
+	}
+	restored := 0
+	for _, id := range ids {
+		if ctx.Err() != nil {
+			break
+		}
+		if err := reembedArticle(ctx, articles, aiClient, id); err != nil {
+			slog.Warn("trash embeddings: regenerate", "id", id, "err", err)
+			break
+		}
+		restored++
+	}
+	if restored > 0 {
+		slog.Info("trash embeddings: regenerated for restored articles", "count", restored)
+	}
+}
+
+// reembedArticle regenerates the archived embedding of a restored article.
+// Articles whose embedding was never archived, or that went back to the
+// trash, are left alone.
+func reembedArticle(ctx context.Context, articles *models.ArticleStore, aiClient ai.Provider, id uuid.UUID) error {
+	archived, err := articles.EmbeddingArchived(ctx, id)
+	if err != nil || !archived {
+		return err
+	}
+	article, err := articles.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	embedding, err := aiClient.Embed(ctx, embeddingText(*article))
+	if err != nil {
+		return fmt.Errorf("embed: %w", err)
+	}
+	if len(embedding) == 0 {
+		return fmt.Errorf("embed: empty embedding")
+	}
+	stored, err := articles.RestoreEmbedding(ctx, id, embedding)
+	if err != nil {
+		return err
+	}
+	if stored {
+		slog.Debug("trash embeddings: embedding regenerated", "id", id)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/Saul-Punybz/folio/internal/ai"
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+const (
+	// triageBatchSize caps the inbox items suggested per run.
+	triageBatchSize = 50
+
+	// triageMaxAgeDays is how old an inbox item may be and still get a
+	// suggestion; older items are left to retention rules.
+	triageMaxAgeDays = 3
+
+	// triageHistory is how many recently saved and trashed titles are shown
+	// to the model as examples of past triage decisions.
+	triageHistory = 25
+)
+
+// RunTriageSuggestions proposes a save/trash action for each new inbox item
+// and stores it for the analyst to accept or dismiss. Nothing is moved out
+// of the inbox here.
+func RunTriageSuggestions(ctx context.Context, articles *models.ArticleStore, suggestions *models.TriageSuggestionStore, orgs *models.WatchlistOrgStore, aiClient ai.Provider) {
+	pending, err := suggestions.ListUnsuggested(ctx, triageMaxAgeDays, triageBatchSize)
+	if err != nil {
+		slog.Error("triage: list inbox", "err", err)
+		return
+	}
+	if len(pending) == 0 {
+		return
+	}
+	slog.Info("triage: starting", "items", len(pending))
+
+	profile, err := triageProfile(ctx, articles, orgs)
+	if err != nil {
+		slog.Error("triage: build profile", "err", err)
+		return
+	}
+
+	created := 0
+	for i := range pending {
+		if ctx.Err() != nil {
+			break
+		}
+		a := &pending[i]
+
+		s, err := aiClient.SuggestTriage(ctx, profile, triageArticleText(a))
+		if err != nil {
+			slog.Warn("triage: suggest", "id", a.ID, "err", err)
+			continue
+		}
+		if s == nil {
+			continue
+		}
+		if err := suggestions.Create(ctx, &models.TriageSuggestion{
+			ArticleID: a.ID,
+			Action:    s.Action,
+			Rationale: s.Reason,
+		}); err != nil {
+			slog.Error("triage: store suggestion", "id", a.ID, "err", err)
+			continue
+		}
+		created++
+	}
+
+	slog.Info("triage: complete", "items", len(pending), "suggested", created)
+}
+
+// triageProfile describes past triage decisions and the watchlist for the
+// SuggestTriage prompt.
+func triageProfile(ctx context.Context, articles *models.ArticleStore, orgs *models.WatchlistOrgStore) (string, error) {
+	saved, err := articles.ListByStatus(ctx, "saved", triageHistory, 0)
+	if err != nil {
+		return "", fmt.Errorf("list saved: %w", err)
+	}
+	trashed, err := articles.ListByStatus(ctx, "trashed", triageHistory, 0)
+	if err != nil {
+		return "", fmt.Errorf("list trashed: %w", err)
+	}
+	watched, err := orgs.ListActive(ctx)
+	if err != nil {
+		return "", fmt.Errorf("list watchlist: %w", err)
+	}
+
+	var b strings.Builder
+	writeTitles := func(heading string, list []models.Article) {
+		b.WriteString(heading + "\n")
+		if len(list) == 0 {
+			b.WriteString("- (none yet)\n")
+		}
+		for _, a := range list {
+			fmt.Fprintf(&b, "- [%s] %s\n", a.Source, a.Title)
+		}
+		b.WriteString("\n")
+	}
+	writeTitles("RECENTLY SAVED:", saved)
+	writeTitles("RECENTLY TRASHED:", trashed)
+
+	b.WriteString("WATCHED ORGANIZATIONS:\n")
+	if len(watched) == 0 {
+		b.WriteString("- (none)\n")
+	}
+	for _, o := range watched {
+		line := "- " + o.Name
+		if len(o.Keywords) > 0 {
+			line += " (" + strings.Join(o.Keywords, ", ") + ")"
+		}
+		b.WriteString(line + "\n")
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// triageArticleText is the user prompt for one inbox item.
+func triageArticleText(a *models.Article) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Source: %s\nTitle: %s\n", a.Source, a.Title)
+	if len(a.Tags) > 0 {
+		fmt.Fprintf(&b, "Tags: %s\n", strings.Join(a.Tags, ", "))
+	}
+	fmt.Fprintf(&b, "Summary: %s", a.Summary)
+	return b.String()
+}
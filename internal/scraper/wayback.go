@@ -0,0 +1,98 @@
+package scraper
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/models"
+	"github.com/Saul-Punybz/folio/internal/useragent"
+)
+
+const (
+	waybackSaveURL = "https://web.archive.org/save/"
+	waybackHost    = "web.archive.org"
+
+	// waybackTimeout bounds one Save Page Now request; capturing a page
+	// commonly takes tens of seconds.
+	waybackTimeout = 2 * time.Minute
+)
+
+// ArchiveJobPayload is the payload of a models.JobArchiveSnapshot job.
+type ArchiveJobPayload struct {
+	ArticleID uuid.UUID `json:"article_id"`
+	URL       string    `json:"url"`
+}
+
+// QueueArchiveSnapshot queues a Wayback Machine snapshot of an article, or
+// takes it in the background when there is no job queue.
+func QueueArchiveSnapshot(ctx context.Context, jobs *models.JobStore, articles *models.ArticleStore, p ArchiveJobPayload) {
+	if jobs != nil {
+		err := jobs.Enqueue(ctx, models.JobArchiveSnapshot, p)
+		if err == nil {
+			return
+		}
+		slog.Error("wayback: enqueue snapshot, running inline", "id", p.ArticleID, "err", err)
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), waybackTimeout)
+		defer cancel()
+		if err := archiveSnapshot(ctx, articles, p); err != nil {
+			slog.Warn("wayback: snapshot failed", "id", p.ArticleID, "url", p.URL, "err", err)
+		}
+	}()
+}
+
+// archiveSnapshot requests a snapshot and stores its URL on the article.
+func archiveSnapshot(ctx context.Context, articles *models.ArticleStore, p ArchiveJobPayload) error {
+	archiveURL, err := SaveToWayback(ctx, p.URL)
+	if err != nil {
+		return err
+	}
+	if err := articles.SetArchiveURL(ctx, p.ArticleID, archiveURL); err != nil {
+		return err
+	}
+	slog.Info("wayback: snapshot saved", "id", p.ArticleID, "archive_url", archiveURL)
+	return nil
+}
+
+// SaveToWayback asks the Wayback Machine's Save Page Now to capture pageURL
+// and returns the snapshot's web.archive.org URL.
+func SaveToWayback(ctx context.Context, pageURL string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, waybackTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, waybackSaveURL+pageURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("wayback: create request: %w", err)
+	}
+	useragent.Apply(req)
+
+	resp, err := fetchlog.Client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("wayback: save: %w", err)
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("wayback: save %s: status %d", pageURL, resp.StatusCode)
+	}
+
+	// The capture is served from its /web/<timestamp>/<url> address, reached
+	// by redirect or named in Content-Location.
+	if final := resp.Request.URL; final.Host == waybackHost && strings.HasPrefix(final.Path, "/web/") {
+		return final.String(), nil
+	}
+	if loc := resp.Header.Get("Content-Location"); strings.HasPrefix(loc, "/web/") {
+		return "https://" + waybackHost + loc, nil
+	}
+	return "", fmt.Errorf("wayback: save %s: no snapshot location in response", pageURL)
+}
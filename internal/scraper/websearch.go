@@ -10,6 +10,9 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/Saul-Punybz/folio/internal/fetchlog"
+	"github.com/Saul-Punybz/folio/internal/useragent"
 )
 
 // WebResult holds a single web search result.
@@ -23,6 +26,10 @@ type WebResult struct {
 // This is used as a fallback when the local article database doesn't have
 // relevant results for a user's chat question.
 func WebSearch(ctx context.Context, query string, limit int) ([]WebResult, error) {
+	if err := ReserveSearch(ctx, EngineDDG); err != nil {
+		return nil, fmt.Errorf("websearch: %w", err)
+	}
+
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
@@ -32,9 +39,9 @@ func WebSearch(ctx context.Context, query string, limit int) ([]WebResult, error
 	if err != nil {
 		return nil, fmt.Errorf("websearch: create request: %w", err)
 	}
-	req.Header.Set("User-Agent", "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko)")
+	useragent.Apply(req)
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := fetchlog.Client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("websearch: request: %w", err)
 	}
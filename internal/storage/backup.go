@@ -0,0 +1,107 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// backupPrefix is the key prefix database backups are stored under, apart
+// from the per-policy evidence prefixes.
+const backupPrefix = "backups/"
+
+// BackupObject describes one stored database backup.
+type BackupObject struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// PutBackup uploads a database backup under backups/<name> and returns its
+// key. body is streamed, so large dumps need not fit in memory.
+func (c *Client) PutBackup(ctx context.Context, name string, body io.ReadSeeker, size int64) (string, error) {
+	if !c.Configured() {
+		return "", fmt.Errorf("storage: not configured")
+	}
+	key := backupPrefix + name
+
+	if c.mem != nil {
+		data, err := io.ReadAll(body)
+		if err != nil {
+			return "", fmt.Errorf("storage: read backup: %w", err)
+		}
+		return key, c.putObject(ctx, key, data)
+	}
+
+	_, err := c.s3.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:        &c.bucket,
+		Key:           &key,
+		Body:          body,
+		ContentLength: &size,
+	})
+	if err != nil {
+		return "", fmt.Errorf("storage: upload %s: %w", key, err)
+	}
+	return key, nil
+}
+
+// ListBackups returns the stored backups ordered oldest first. Backup names
+// start with a UTC timestamp, so key order is chronological.
+func (c *Client) ListBackups(ctx context.Context) ([]BackupObject, error) {
+	if !c.Configured() {
+		return nil, nil
+	}
+
+	var backups []BackupObject
+	if c.mem != nil {
+		c.mem.mu.RLock()
+		for key, data := range c.mem.objects {
+			if strings.HasPrefix(key, backupPrefix) {
+				backups = append(backups, BackupObject{Key: key, Size: int64(len(data))})
+			}
+		}
+		c.mem.mu.RUnlock()
+	} else {
+		prefix := backupPrefix
+		pages := s3.NewListObjectsV2Paginator(c.s3, &s3.ListObjectsV2Input{
+			Bucket: &c.bucket,
+			Prefix: &prefix,
+		})
+		for pages.HasMorePages() {
+			page, err := pages.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("storage: list backups: %w", err)
+			}
+			for _, obj := range page.Contents {
+				b := BackupObject{Key: *obj.Key}
+				if obj.Size != nil {
+					b.Size = *obj.Size
+				}
+				if obj.LastModified != nil {
+					b.LastModified = *obj.LastModified
+				}
+				backups = append(backups, b)
+			}
+		}
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Key < backups[j].Key })
+	return backups, nil
+}
+
+// DeleteBackup removes a stored backup. Only keys under the backups prefix
+// may be deleted through it.
+func (c *Client) DeleteBackup(ctx context.Context, key string) error {
+	if !strings.HasPrefix(key, backupPrefix) {
+		return fmt.Errorf("storage: %q is not a backup key", key)
+	}
+	if err := c.deleteObject(ctx, key); err != nil {
+		return fmt.Errorf("storage: delete %s: %w", key, err)
+	}
+	return nil
+}
@@ -8,30 +8,54 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"strings"
+	"sync"
 	"time"
 
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/google/uuid"
 
 	"github.com/Saul-Punybz/folio/internal/config"
 )
 
-// Client wraps an S3-compatible object storage client.
+// errObjectNotFound is returned (wrapped) by getObject when the key does not
+// exist, as opposed to the store being unreachable.
+var errObjectNotFound = errors.New("object not found")
+
+// ErrNoEvidence is returned (wrapped) by GetEvidence when no evidence was
+// captured for the article.
+var ErrNoEvidence = errors.New("no evidence found")
+
+// evidencePolicies lists every retention policy prefix evidence may live under.
+var evidencePolicies = []string{"ret_3m", "ret_6m", "ret_12m", "keep"}
+
+// Client wraps an S3-compatible object storage client. When mem is set the
+// client keeps objects in process memory instead (see NewMemoryClient).
 type Client struct {
 	s3     *s3.Client
+	mem    *memStore
 	bucket string
 }
 
+// memStore is a minimal in-memory object store keyed by object key.
+type memStore struct {
+	mu      sync.RWMutex
+	objects map[string][]byte
+}
+
 // Evidence holds the retrieved evidence artifacts for an article.
 type Evidence struct {
-	RawHTML   []byte         `json:"raw_html,omitempty"`
-	Extracted []byte         `json:"extracted,omitempty"`
-	Meta      *CaptureMeta   `json:"meta,omitempty"`
+	RawHTML    []byte       `json:"raw_html,omitempty"`
+	Extracted  []byte       `json:"extracted,omitempty"`
+	Screenshot []byte       `json:"screenshot,omitempty"` // full-page PNG; nil when none was captured
+	Meta       *CaptureMeta `json:"meta,omitempty"`
 }
 
 // CaptureMeta records metadata about the evidence capture.
@@ -41,11 +65,17 @@ type CaptureMeta struct {
 	RawHash     string    `json:"raw_hash_sha256"`
 	ExtractHash string    `json:"extract_hash_sha256"`
 	Policy      string    `json:"evidence_policy"`
+
+	ScreenshotHash string `json:"screenshot_hash_sha256,omitempty"`
 }
 
 // NewClient creates a new S3-compatible storage client configured for
 // Oracle Object Storage (or any S3-compatible endpoint).
 func NewClient(ctx context.Context, cfg config.S3Config) (*Client, error) {
+	if cfg.Fake {
+		slog.Warn("S3 fake mode enabled, evidence is kept in memory only")
+		return NewMemoryClient(cfg.Bucket), nil
+	}
 	if cfg.Endpoint == "" {
 		slog.Warn("S3 endpoint not configured, evidence storage disabled")
 		return &Client{bucket: cfg.Bucket}, nil
@@ -72,15 +102,40 @@ func NewClient(ctx context.Context, cfg config.S3Config) (*Client, error) {
 	}, nil
 }
 
+// NewMemoryClient creates a storage client backed by an in-memory map. Objects
+// are lost when the process exits; intended for local development and soak
+// tests without object storage. Enable with S3_FAKE=true.
+func NewMemoryClient(bucket string) *Client {
+	return &Client{
+		mem:    &memStore{objects: make(map[string][]byte)},
+		bucket: bucket,
+	}
+}
+
 // Configured returns true if the S3 client has a valid connection configured.
 func (c *Client) Configured() bool {
-	return c.s3 != nil
+	return c.s3 != nil || c.mem != nil
 }
 
-// StoreEvidence compresses and uploads the raw HTML, extracted text, and
-// capture metadata for an article to S3-compatible object storage.
-func (c *Client) StoreEvidence(ctx context.Context, articleID uuid.UUID, policy string, rawHTML []byte, extracted []byte, meta []byte) error {
+// CheckBucket verifies the bucket exists and the credentials can access it.
+func (c *Client) CheckBucket(ctx context.Context) error {
+	if c.mem != nil {
+		return nil
+	}
 	if c.s3 == nil {
+		return fmt.Errorf("storage: not configured")
+	}
+	if _, err := c.s3.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: &c.bucket}); err != nil {
+		return fmt.Errorf("storage: head bucket %s: %w", c.bucket, err)
+	}
+	return nil
+}
+
+// StoreEvidence compresses and uploads the raw HTML, extracted text, and
+// capture metadata for an article to S3-compatible object storage, plus the
+// page's screenshot PNG when one was taken.
+func (c *Client) StoreEvidence(ctx context.Context, articleID uuid.UUID, policy string, rawHTML []byte, extracted []byte, screenshot []byte) error {
+	if !c.Configured() {
 		slog.Warn("evidence storage not configured, skipping upload", "article_id", articleID)
 		return nil
 	}
@@ -99,6 +154,9 @@ func (c *Client) StoreEvidence(ctx context.Context, articleID uuid.UUID, policy
 		ExtractHash: extractHash,
 		Policy:      policy,
 	}
+	if len(screenshot) > 0 {
+		captureMeta.ScreenshotHash = sha256sum(screenshot)
+	}
 	metaJSON, err := json.MarshalIndent(captureMeta, "", "  ")
 	if err != nil {
 		return fmt.Errorf("storage: marshal meta: %w", err)
@@ -110,11 +168,14 @@ func (c *Client) StoreEvidence(ctx context.Context, articleID uuid.UUID, policy
 		prefix + "/extracted.txt.gz": extracted,
 		prefix + "/capture_meta.json": metaJSON,
 	}
+	if len(screenshot) > 0 {
+		uploads[prefix+"/screenshot.png"] = screenshot
+	}
 
 	for key, data := range uploads {
 		var body []byte
-		if key == prefix+"/capture_meta.json" {
-			// Meta is not compressed.
+		if key == prefix+"/capture_meta.json" || key == prefix+"/screenshot.png" {
+			// Meta is not compressed; PNG already is.
 			body = data
 		} else {
 			compressed, err := gzipCompress(data)
@@ -124,13 +185,8 @@ func (c *Client) StoreEvidence(ctx context.Context, articleID uuid.UUID, policy
 			body = compressed
 		}
 
-		_, err := c.s3.PutObject(ctx, &s3.PutObjectInput{
-			Bucket: &c.bucket,
-			Key:    &key,
-			Body:   bytes.NewReader(body),
-		})
-		if err != nil {
-			return fmt.Errorf("storage: upload %s: %w", key, err)
+		if err := c.putObject(ctx, key, body); err != nil {
+			return err
 		}
 
 		slog.Debug("evidence uploaded", "key", key, "size", len(body))
@@ -142,23 +198,18 @@ func (c *Client) StoreEvidence(ctx context.Context, articleID uuid.UUID, policy
 // DeleteEvidence removes all evidence artifacts for an article across all
 // retention policy prefixes.
 func (c *Client) DeleteEvidence(ctx context.Context, articleID uuid.UUID) error {
-	if c.s3 == nil {
+	if !c.Configured() {
 		slog.Warn("evidence storage not configured, skipping delete", "article_id", articleID)
 		return nil
 	}
 
-	policies := []string{"ret_3m", "ret_6m", "ret_12m", "keep"}
-	suffixes := []string{"/raw.html.gz", "/extracted.txt.gz", "/capture_meta.json"}
+	suffixes := []string{"/raw.html.gz", "/extracted.txt.gz", "/capture_meta.json", "/screenshot.png"}
 
-	for _, policy := range policies {
+	for _, policy := range evidencePolicies {
 		prefix := fmt.Sprintf("evidence/%s/%s", policy, articleID)
 		for _, suffix := range suffixes {
 			key := prefix + suffix
-			_, err := c.s3.DeleteObject(ctx, &s3.DeleteObjectInput{
-				Bucket: &c.bucket,
-				Key:    &key,
-			})
-			if err != nil {
+			if err := c.deleteObject(ctx, key); err != nil {
 				// Log but don't fail on individual object deletions — the
 				// object may not exist under this policy prefix.
 				slog.Debug("evidence delete (may not exist)", "key", key, "err", err)
@@ -173,21 +224,157 @@ func (c *Client) DeleteEvidence(ctx context.Context, articleID uuid.UUID) error
 // GetEvidence retrieves all evidence artifacts for an article.
 // It tries all retention policy prefixes and returns the first match.
 func (c *Client) GetEvidence(ctx context.Context, articleID uuid.UUID) (*Evidence, error) {
-	if c.s3 == nil {
+	return c.GetEvidenceForPolicy(ctx, articleID, "")
+}
+
+// GetEvidenceForPolicy is GetEvidence, but looks under the given policy prefix
+// first, saving round trips when the article's current policy is known. Store
+// errors (as opposed to missing objects) are returned immediately; an article
+// without evidence yields an error wrapping ErrNoEvidence.
+func (c *Client) GetEvidenceForPolicy(ctx context.Context, articleID uuid.UUID, policy string) (*Evidence, error) {
+	if !c.Configured() {
 		return nil, fmt.Errorf("storage: not configured")
 	}
 
-	policies := []string{"ret_3m", "ret_6m", "ret_12m", "keep"}
+	policies := evidencePolicies
+	if policy != "" {
+		policies = append([]string{policy}, evidencePolicies...)
+	}
 
-	for _, policy := range policies {
-		prefix := fmt.Sprintf("evidence/%s/%s", policy, articleID)
+	for _, p := range policies {
+		prefix := fmt.Sprintf("evidence/%s/%s", p, articleID)
 		ev, err := c.fetchEvidence(ctx, prefix)
 		if err == nil {
 			return ev, nil
 		}
+		if !errors.Is(err, errObjectNotFound) {
+			return nil, err
+		}
+	}
+
+	return nil, fmt.Errorf("storage: article %s: %w", articleID, ErrNoEvidence)
+}
+
+// VerifyEvidence retrieves the evidence for an article and recomputes the
+// SHA-256 hashes of the raw HTML and extracted text, comparing them against
+// those recorded in capture_meta.json at capture time.
+func (c *Client) VerifyEvidence(ctx context.Context, articleID uuid.UUID) (*CaptureMeta, error) {
+	ev, err := c.GetEvidence(ctx, articleID)
+	if err != nil {
+		return nil, err
+	}
+	if ev.Meta == nil {
+		return nil, fmt.Errorf("storage: evidence for %s has no capture meta", articleID)
+	}
+	if got := sha256sum(ev.RawHTML); got != ev.Meta.RawHash {
+		return ev.Meta, fmt.Errorf("storage: raw html hash mismatch: recorded %s, got %s", ev.Meta.RawHash, got)
+	}
+	if got := sha256sum(ev.Extracted); got != ev.Meta.ExtractHash {
+		return ev.Meta, fmt.Errorf("storage: extracted text hash mismatch: recorded %s, got %s", ev.Meta.ExtractHash, got)
+	}
+	if ev.Meta.ScreenshotHash != "" {
+		if got := sha256sum(ev.Screenshot); got != ev.Meta.ScreenshotHash {
+			return ev.Meta, fmt.Errorf("storage: screenshot hash mismatch: recorded %s, got %s", ev.Meta.ScreenshotHash, got)
+		}
+	}
+	return ev.Meta, nil
+}
+
+// GetCaptureMeta returns just the capture metadata for an article's evidence,
+// without downloading the artifacts. The given policy prefix is tried first,
+// then the others. It returns (nil, nil) when the article has no evidence.
+func (c *Client) GetCaptureMeta(ctx context.Context, articleID uuid.UUID, policy string) (*CaptureMeta, error) {
+	if !c.Configured() {
+		return nil, fmt.Errorf("storage: not configured")
+	}
+
+	policies := evidencePolicies
+	if policy != "" {
+		policies = append([]string{policy}, evidencePolicies...)
+	}
+
+	for _, p := range policies {
+		key := fmt.Sprintf("evidence/%s/%s/capture_meta.json", p, articleID)
+		data, err := c.getObject(ctx, key)
+		if errors.Is(err, errObjectNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		var meta CaptureMeta
+		if err := json.Unmarshal(data, &meta); err != nil {
+			return nil, fmt.Errorf("storage: unmarshal meta: %w", err)
+		}
+		return &meta, nil
+	}
+	return nil, nil
+}
+
+// Evidence artifacts readable one at a time with OpenArtifact.
+const (
+	ArtifactRaw        = "raw"        // the page's HTML as fetched
+	ArtifactExtracted  = "extracted"  // the enrichment JSON (title, text, tags, ...)
+	ArtifactScreenshot = "screenshot" // full-page PNG, when one was captured
+)
+
+// artifactSuffixes maps each artifact to its object key suffix.
+var artifactSuffixes = map[string]string{
+	ArtifactRaw:        "/raw.html.gz",
+	ArtifactExtracted:  "/extracted.txt.gz",
+	ArtifactScreenshot: "/screenshot.png",
+}
+
+// OpenArtifact streams one evidence artifact, decompressed, looking under the
+// given policy prefix first. The caller must close it. An article without the
+// artifact yields an error wrapping ErrNoEvidence.
+func (c *Client) OpenArtifact(ctx context.Context, articleID uuid.UUID, policy, artifact string) (io.ReadCloser, error) {
+	if !c.Configured() {
+		return nil, fmt.Errorf("storage: not configured")
+	}
+	suffix, ok := artifactSuffixes[artifact]
+	if !ok {
+		return nil, fmt.Errorf("storage: unknown artifact %q", artifact)
+	}
+
+	policies := evidencePolicies
+	if policy != "" {
+		policies = append([]string{policy}, evidencePolicies...)
+	}
+
+	for _, p := range policies {
+		key := fmt.Sprintf("evidence/%s/%s%s", p, articleID, suffix)
+		body, err := c.openObject(ctx, key)
+		if errors.Is(err, errObjectNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasSuffix(key, ".gz") {
+			return body, nil
+		}
+		zr, err := gzip.NewReader(body)
+		if err != nil {
+			body.Close()
+			return nil, fmt.Errorf("storage: decompress %s: %w", key, err)
+		}
+		return &gzipReadCloser{Reader: zr, body: body}, nil
 	}
 
-	return nil, fmt.Errorf("storage: no evidence found for article %s", articleID)
+	return nil, fmt.Errorf("storage: article %s %s: %w", articleID, artifact, ErrNoEvidence)
+}
+
+// gzipReadCloser closes both the gzip reader and the object body under it.
+type gzipReadCloser struct {
+	*gzip.Reader
+	body io.Closer
+}
+
+func (r *gzipReadCloser) Close() error {
+	r.Reader.Close()
+	return r.body.Close()
 }
 
 func (c *Client) fetchEvidence(ctx context.Context, prefix string) (*Evidence, error) {
@@ -224,15 +411,95 @@ func (c *Client) fetchEvidence(ctx context.Context, prefix string) (*Evidence, e
 	}
 	ev.Meta = &meta
 
+	// Screenshot, captured only when a screenshot renderer is configured.
+	if meta.ScreenshotHash != "" {
+		ev.Screenshot, err = c.getObject(ctx, prefix+"/screenshot.png")
+		if err != nil && !errors.Is(err, errObjectNotFound) {
+			return nil, err
+		}
+	}
+
 	return ev, nil
 }
 
+func (c *Client) putObject(ctx context.Context, key string, body []byte) error {
+	if c.mem != nil {
+		c.mem.mu.Lock()
+		c.mem.objects[key] = append([]byte(nil), body...)
+		c.mem.mu.Unlock()
+		return nil
+	}
+
+	_, err := c.s3.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: &c.bucket,
+		Key:    &key,
+		Body:   bytes.NewReader(body),
+	})
+	if err != nil {
+		return fmt.Errorf("storage: upload %s: %w", key, err)
+	}
+	return nil
+}
+
+func (c *Client) deleteObject(ctx context.Context, key string) error {
+	if c.mem != nil {
+		c.mem.mu.Lock()
+		delete(c.mem.objects, key)
+		c.mem.mu.Unlock()
+		return nil
+	}
+
+	_, err := c.s3.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: &c.bucket,
+		Key:    &key,
+	})
+	return err
+}
+
+// openObject is getObject without reading the body into memory.
+func (c *Client) openObject(ctx context.Context, key string) (io.ReadCloser, error) {
+	if c.mem != nil {
+		data, err := c.getObject(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	out, err := c.s3.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: &c.bucket,
+		Key:    &key,
+	})
+	if err != nil {
+		var nsk *types.NoSuchKey
+		if errors.As(err, &nsk) {
+			return nil, fmt.Errorf("storage: get %s: %w", key, errObjectNotFound)
+		}
+		return nil, fmt.Errorf("storage: get %s: %w", key, err)
+	}
+	return out.Body, nil
+}
+
 func (c *Client) getObject(ctx context.Context, key string) ([]byte, error) {
+	if c.mem != nil {
+		c.mem.mu.RLock()
+		data, ok := c.mem.objects[key]
+		c.mem.mu.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("storage: get %s: %w", key, errObjectNotFound)
+		}
+		return append([]byte(nil), data...), nil
+	}
+
 	out, err := c.s3.GetObject(ctx, &s3.GetObjectInput{
 		Bucket: &c.bucket,
 		Key:    &key,
 	})
 	if err != nil {
+		var nsk *types.NoSuchKey
+		if errors.As(err, &nsk) {
+			return nil, fmt.Errorf("storage: get %s: %w", key, errObjectNotFound)
+		}
 		return nil, fmt.Errorf("storage: get %s: %w", key, err)
 	}
 	defer out.Body.Close()
@@ -27,6 +27,9 @@ func (b *Bot) resolveUser(ctx context.Context, from *tgmodels.User) (*models.Use
 	if err != nil {
 		return nil, fmt.Errorf("user not found for email %s: %w", email, err)
 	}
+	if !user.Active {
+		return nil, fmt.Errorf("user %s is deactivated", email)
+	}
 
 	// Upsert telegram user mapping to keep username/display name current.
 	tu := &models.TelegramUser{
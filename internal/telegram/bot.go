@@ -23,7 +23,7 @@ type Bot struct {
 	telegramUsers    *models.TelegramUserStore
 	notifications    *models.NotificationStore
 	researchProjects *models.ResearchProjectStore
-	aiClient         *ai.OllamaClient
+	aiClient         ai.Provider
 	users            *models.UserStore
 }
 
@@ -36,7 +36,7 @@ type BotDeps struct {
 	TelegramUsers    *models.TelegramUserStore
 	Notifications    *models.NotificationStore
 	ResearchProjects *models.ResearchProjectStore
-	AI               *ai.OllamaClient
+	AI               ai.Provider
 	Users            *models.UserStore
 }
 
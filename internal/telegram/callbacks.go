@@ -42,7 +42,7 @@ func (b *Bot) handleCallback(ctx context.Context, bot *tgbot.Bot, update *tgmode
 		err = b.articles.UpdateStatus(ctx, articleID, "saved")
 		responseText = "Guardado"
 	case "trash":
-		err = b.articles.UpdateStatus(ctx, articleID, "trashed")
+		err = b.articles.Trash(ctx, articleID)
 		responseText = "Archivado"
 	case "pin":
 		err = b.articles.SetPinned(ctx, articleID, true)
@@ -8,6 +8,9 @@ import (
 
 	tgbot "github.com/go-telegram/bot"
 	tgmodels "github.com/go-telegram/bot/models"
+
+	"github.com/Saul-Punybz/folio/internal/coverage"
+	"github.com/Saul-Punybz/folio/internal/models"
 )
 
 // handleStart welcomes the user if they are in the allowlist.
@@ -27,7 +30,7 @@ func (b *Bot) handleStart(ctx context.Context, bot *tgbot.Bot, update *tgmodels.
 
 	text := fmt.Sprintf(`Bienvenido a <b>Folio Bot</b>, %s.
 
-Tu centro de inteligencia politica de Puerto Rico, ahora en Telegram.
+Tu centro de inteligencia politica de %s, ahora en Telegram.
 
 <b>Comandos disponibles:</b>
 /inbox - Ver articulos recientes
@@ -38,7 +41,7 @@ Tu centro de inteligencia politica de Puerto Rico, ahora en Telegram.
 /research &lt;tema&gt; - Investigacion profunda
 /help - Lista de comandos
 
-Tambien puedes enviar cualquier pregunta como texto libre y el asistente de IA te respondera usando las noticias locales.`, escapeHTML(user.Email))
+Tambien puedes enviar cualquier pregunta como texto libre y el asistente de IA te respondera usando las noticias locales.`, escapeHTML(user.Email), escapeHTML(coverage.Current().Name))
 
 	bot.SendMessage(ctx, &tgbot.SendMessageParams{
 		ChatID:    update.Message.Chat.ID,
@@ -300,6 +303,14 @@ func (b *Bot) handleBrief(ctx context.Context, bot *tgbot.Bot, update *tgmodels.
 		sb.WriteString("\n\n<b>Temas principales:</b> ")
 		sb.WriteString(escapeHTML(strings.Join(brief.TopTags, ", ")))
 	}
+	if len(brief.TopEntities) > 0 {
+		names := make([]string, len(brief.TopEntities))
+		for i, e := range brief.TopEntities {
+			names[i] = fmt.Sprintf("%s (%d)", e.Name, e.Count)
+		}
+		sb.WriteString("\n<b>Mas mencionados:</b> ")
+		sb.WriteString(escapeHTML(strings.Join(names, ", ")))
+	}
 
 	text := sb.String()
 	if len(text) > 4000 {
@@ -328,7 +339,7 @@ func (b *Bot) handleWatchlist(ctx context.Context, bot *tgbot.Bot, update *tgmod
 		return
 	}
 
-	hits, err := b.watchlistHits.ListRecentByUser(ctx, user.ID, 10)
+	hits, err := b.watchlistHits.ListRecentByUser(ctx, user.ID, models.HitFilter{}, 10)
 	if err != nil {
 		slog.Error("telegram: watchlist", "err", err)
 		bot.SendMessage(ctx, &tgbot.SendMessageParams{
@@ -3,12 +3,15 @@ package telegram
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
 	tgbot "github.com/go-telegram/bot"
 	tgmodels "github.com/go-telegram/bot/models"
+	"github.com/google/uuid"
+
+	"github.com/Saul-Punybz/folio/internal/i18n"
 )
 
 // StartNotificationPoller polls for pending notifications every 30 seconds and
@@ -48,6 +51,7 @@ func (b *Bot) deliverPendingNotifications(ctx context.Context) {
 			continue
 		}
 
+		lang := b.userLang(ctx, notif.UserID)
 		var text string
 		switch notif.Type {
 		case "digest":
@@ -55,7 +59,7 @@ func (b *Bot) deliverPendingNotifications(ctx context.Context) {
 				Summary string `json:"summary"`
 			}
 			json.Unmarshal(notif.Payload, &payload)
-			text = fmt.Sprintf("<b>Resumen Diario</b>\n\n%s", escapeHTML(payload.Summary))
+			text = i18n.T(lang, "<b>Daily Summary</b>\n\n%s", escapeHTML(payload.Summary))
 			if len(text) > 4000 {
 				text = text[:4000] + "..."
 			}
@@ -67,9 +71,72 @@ func (b *Bot) deliverPendingNotifications(ctx context.Context) {
 				URL     string `json:"url"`
 			}
 			json.Unmarshal(notif.Payload, &payload)
-			text = fmt.Sprintf("<b>Alerta Vigilancia: %s</b>\n\n%s\n<a href=\"%s\">Ver</a>",
+			text = i18n.T(lang, "<b>Watchlist Alert: %s</b>\n\n%s\n<a href=\"%s\">View</a>",
 				escapeHTML(payload.OrgName), escapeHTML(payload.Title), payload.URL)
 
+		case "watchlist_digest":
+			var payload struct {
+				Summary string `json:"summary"`
+			}
+			json.Unmarshal(notif.Payload, &payload)
+			text = i18n.T(lang, "<b>Watchlist Summary</b>\n\n%s", escapeHTML(payload.Summary))
+			if len(text) > 4000 {
+				text = text[:4000] + "..."
+			}
+
+		case "grant_deadline":
+			var payload struct {
+				Title     string  `json:"title"`
+				Agency    string  `json:"agency"`
+				CloseDate string  `json:"close_date"`
+				Tracking  *string `json:"tracking"`
+				URL       string  `json:"url"`
+			}
+			json.Unmarshal(notif.Payload, &payload)
+			heading := i18n.T(lang, "Grant closing soon")
+			if payload.Tracking != nil && *payload.Tracking == "pursuing" {
+				heading = i18n.T(lang, "Pursued grant closing soon")
+			}
+			text = i18n.T(lang, "<b>%s</b>\n\n%s\n%s · closes %s\n<a href=\"%s\">View</a>",
+				heading, escapeHTML(payload.Title), escapeHTML(payload.Agency), payload.CloseDate, payload.URL)
+
+		case "evidence_expiring":
+			var payload struct {
+				Count    int `json:"count"`
+				Articles []struct {
+					Title     string `json:"title"`
+					ExpiresAt string `json:"expires_at"`
+				} `json:"articles"`
+			}
+			json.Unmarshal(notif.Payload, &payload)
+			var sb strings.Builder
+			sb.WriteString(i18n.T(lang, "<b>Evidence expiring</b>\n\n%d article(s) lose their evidence in the coming days:\n", payload.Count))
+			for _, a := range payload.Articles {
+				sb.WriteString(i18n.T(lang, "\n• %s (expires %s)", escapeHTML(a.Title), a.ExpiresAt))
+			}
+			if payload.Count > len(payload.Articles) {
+				sb.WriteString(i18n.T(lang, "\n… and %d more", payload.Count-len(payload.Articles)))
+			}
+			sb.WriteString(i18n.T(lang, "\n\nExtend the retention of anything that must be kept."))
+			text = sb.String()
+
+		case "feed_leak":
+			var payload struct {
+				DistinctIPs     int  `json:"distinct_ips"`
+				OutsideReferers int  `json:"outside_referers"`
+				Rotated         bool `json:"rotated"`
+			}
+			json.Unmarshal(notif.Payload, &payload)
+			text = i18n.T(lang, "<b>RSS feed possibly exposed</b>\n\nYour feed was read from %d different addresses", payload.DistinctIPs)
+			if payload.OutsideReferers > 0 {
+				text += i18n.T(lang, " and linked from other sites %d time(s)", payload.OutsideReferers)
+			}
+			if payload.Rotated {
+				text += i18n.T(lang, ".\n\nThe link was replaced; copy the new one into your reader from Settings.")
+			} else {
+				text += i18n.T(lang, ".\n\nIf you did not share it, regenerate the link in Settings.")
+			}
+
 		case "system":
 			var payload struct {
 				Message string `json:"message"`
@@ -78,7 +145,7 @@ func (b *Bot) deliverPendingNotifications(ctx context.Context) {
 			text = payload.Message
 
 		default:
-			text = "Notificacion sin formato"
+			text = i18n.T(lang, "Unformatted notification")
 		}
 
 		_, err = b.bot.SendMessage(ctx, &tgbot.SendMessageParams{
@@ -97,3 +164,17 @@ func (b *Bot) deliverPendingNotifications(ctx context.Context) {
 		}
 	}
 }
+
+// userLang returns the language to send a user's notifications in: their
+// preference, else the default language.
+func (b *Bot) userLang(ctx context.Context, userID uuid.UUID) i18n.Lang {
+	if b.users != nil {
+		pref, err := b.users.Language(ctx, userID)
+		if err != nil {
+			slog.Warn("telegram: user language", "user_id", userID, "err", err)
+		} else if lang, ok := i18n.Parse(pref); ok {
+			return lang
+		}
+	}
+	return i18n.Default()
+}
@@ -9,6 +9,7 @@ import (
 	tgbot "github.com/go-telegram/bot"
 	tgmodels "github.com/go-telegram/bot/models"
 
+	"github.com/Saul-Punybz/folio/internal/coverage"
 	"github.com/Saul-Punybz/folio/internal/models"
 )
 
@@ -173,7 +174,7 @@ func statusEmoji(status string) string {
 func researchHelpText() string {
 	return `<b>Investigacion Profunda</b>
 
-Lanza una investigacion detallada sobre cualquier tema en Puerto Rico. El sistema busca en multiples fuentes, recopila informacion y genera un dossier completo.
+Lanza una investigacion detallada sobre cualquier tema en ` + escapeHTML(coverage.Current().Name) + `. El sistema busca en multiples fuentes, recopila informacion y genera un dossier completo.
 
 <b>Comandos:</b>
 /research &lt;tema&gt; — Crear nueva investigacion
@@ -0,0 +1,74 @@
+// Package useragent holds the identity Folio presents to the sites it
+// fetches from: the User-Agent of the scraper, the crawler, feed and sitemap
+// fetches, web search, enrichment agents and headless rendering, and a From
+// header with a contact address, which some publishers require before they
+// allow a crawler.
+//
+//	useragent.Setup(cfg.Crawl)
+//	useragent.Apply(req)
+//
+// Until Setup is called the default identity is used.
+package useragent
+
+import (
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Saul-Punybz/folio/internal/config"
+)
+
+// Product is the name and version at the start of the default User-Agent.
+const Product = "Folio/1.0"
+
+// defaultContactURL is advertised when no contact URL is configured.
+const defaultContactURL = "https://github.com/Saul-Punybz/folio"
+
+type identity struct {
+	userAgent string
+	email     string
+}
+
+var current atomic.Pointer[identity]
+
+func init() {
+	Setup(config.CrawlConfig{})
+}
+
+// Setup installs the process-wide identity. Without a UserAgent override the
+// User-Agent is "Folio/1.0 (+<contact URL>; <contact email>)".
+func Setup(cfg config.CrawlConfig) {
+	ua := strings.TrimSpace(cfg.UserAgent)
+	if ua == "" {
+		contact := cfg.ContactURL
+		if contact == "" {
+			contact = defaultContactURL
+		}
+		ua = Product + " (+" + contact
+		if cfg.ContactEmail != "" {
+			ua += "; " + cfg.ContactEmail
+		}
+		ua += ")"
+	}
+	current.Store(&identity{userAgent: ua, email: cfg.ContactEmail})
+}
+
+// String returns the User-Agent to send.
+func String() string {
+	return current.Load().userAgent
+}
+
+// Email returns the contact address, or "" when none is configured.
+func Email() string {
+	return current.Load().email
+}
+
+// Apply sets the User-Agent and, when a contact address is configured, the
+// From header on an outgoing request.
+func Apply(req *http.Request) {
+	id := current.Load()
+	req.Header.Set("User-Agent", id.userAgent)
+	if id.email != "" {
+		req.Header.Set("From", id.email)
+	}
+}
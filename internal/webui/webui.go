@@ -0,0 +1,181 @@
+// Package webui serves the built frontend (frontend/dist).
+//
+// The files are indexed once when the handler is created, so requests never
+// touch the filesystem to decide between a file and the index.html fallback
+// used for client-side routing. Hashed build assets get immutable cache
+// headers, and compressible files are served gzip- or brotli-encoded when the
+// client accepts it: precompressed .br and .gz siblings produced by
+// `make frontend` are used as-is, and gzip variants of the rest are built in
+// memory at startup.
+package webui
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// hashedDir holds Astro's content-hashed build output; its files never
+	// change under the same name.
+	hashedDir = "_astro/"
+
+	immutableCache = "public, max-age=31536000, immutable"
+	revalidate     = "no-cache"
+
+	// minCompressSize is the smallest file worth gzipping in memory.
+	minCompressSize = 1024
+)
+
+// compressible lists the extensions that are gzipped in memory when no .gz
+// sibling was built.
+var compressible = map[string]bool{
+	".html": true, ".js": true, ".mjs": true, ".css": true, ".svg": true,
+	".json": true, ".xml": true, ".txt": true, ".map": true, ".webmanifest": true,
+}
+
+// asset is one servable file and its encoded variants.
+type asset struct {
+	name    string // path in the file system
+	etag    string
+	modTime time.Time
+	data    []byte // identity encoding
+	gzip    []byte // nil when there is no gzip variant
+	br      []byte // nil when there is no precompressed .br sibling
+}
+
+// Handler serves the frontend files of fsys.
+type Handler struct {
+	assets map[string]*asset
+	index  *asset
+}
+
+// New reads every file of fsys and returns a handler serving them. fsys must
+// contain index.html.
+func New(fsys fs.FS) (*Handler, error) {
+	h := &Handler{assets: map[string]*asset{}}
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasSuffix(name, ".gz") || strings.HasSuffix(name, ".br") {
+			return nil
+		}
+		a, err := loadAsset(fsys, name)
+		if err != nil {
+			return err
+		}
+		h.assets[name] = a
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("webui: read files: %w", err)
+	}
+	h.index = h.assets["index.html"]
+	if h.index == nil {
+		return nil, fmt.Errorf("webui: index.html not found")
+	}
+	return h, nil
+}
+
+// loadAsset reads name and its encoded variants.
+func loadAsset(fsys fs.FS, name string) (*asset, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	a := &asset{name: name, data: data}
+	if info, err := fs.Stat(fsys, name); err == nil {
+		a.modTime = info.ModTime()
+	}
+	sum := sha256.Sum256(data)
+	a.etag = `"` + hex.EncodeToString(sum[:8]) + `"`
+
+	if br, err := fs.ReadFile(fsys, name+".br"); err == nil {
+		a.br = br
+	}
+	if gz, err := fs.ReadFile(fsys, name+".gz"); err == nil {
+		a.gzip = gz
+	} else if compressible[path.Ext(name)] && len(data) >= minCompressSize {
+		var buf bytes.Buffer
+		zw, _ := gzip.NewWriterLevel(&buf, gzip.BestCompression)
+		zw.Write(data)
+		zw.Close()
+		if buf.Len() < len(data) {
+			a.gzip = buf.Bytes()
+		}
+	}
+	return a, nil
+}
+
+// ServeHTTP serves the requested file, the index.html of a directory, or the
+// root index.html for any other path. Paths under /api are not found.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/api") {
+		http.NotFound(w, r)
+		return
+	}
+
+	name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+	a := h.assets[name]
+	if a == nil {
+		a = h.assets[path.Join(name, "index.html")]
+	}
+	if a == nil {
+		if strings.HasPrefix(name, hashedDir) {
+			http.NotFound(w, r) // a stale build's asset, not a client route
+			return
+		}
+		a = h.index
+	}
+
+	hdr := w.Header()
+	if strings.HasPrefix(a.name, hashedDir) {
+		hdr.Set("Cache-Control", immutableCache)
+	} else {
+		hdr.Set("Cache-Control", revalidate)
+	}
+	ctype := mime.TypeByExtension(path.Ext(a.name))
+	if ctype == "" {
+		ctype = "application/octet-stream"
+	}
+	hdr.Set("Content-Type", ctype)
+
+	body, etag := a.data, a.etag
+	if a.br != nil || a.gzip != nil {
+		hdr.Add("Vary", "Accept-Encoding")
+		accept := r.Header.Get("Accept-Encoding")
+		switch {
+		case a.br != nil && acceptsEncoding(accept, "br"):
+			hdr.Set("Content-Encoding", "br")
+			body, etag = a.br, strings.TrimSuffix(a.etag, `"`)+`-br"`
+		case a.gzip != nil && acceptsEncoding(accept, "gzip"):
+			hdr.Set("Content-Encoding", "gzip")
+			body, etag = a.gzip, strings.TrimSuffix(a.etag, `"`)+`-gz"`
+		}
+	}
+	hdr.Set("ETag", etag)
+
+	http.ServeContent(w, r, a.name, a.modTime, bytes.NewReader(body))
+}
+
+// acceptsEncoding reports whether an Accept-Encoding header allows enc.
+func acceptsEncoding(header, enc string) bool {
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(coding), enc) {
+			continue
+		}
+		q := strings.ReplaceAll(strings.TrimSpace(params), " ", "")
+		return q != "q=0" && q != "q=0.0" && q != "q=0.00" && q != "q=0.000"
+	}
+	return false
+}
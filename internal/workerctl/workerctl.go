@@ -0,0 +1,223 @@
+// Package workerctl runs the worker's cron jobs so they can be watched and
+// controlled from the admin API without access to the worker host.
+//
+//	jobs := workerctl.New(ctx, models.NewWorkerJobStore(pool), &wg)
+//	err := jobs.Add(c, "ingestion", "0 */4 * * *", 3*time.Hour, func(ctx context.Context) { ... })
+//	c.Start()
+//	go jobs.Run()
+//
+// Every run is recorded in worker_jobs with its trigger, status and
+// duration. Scheduled runs are skipped while a job is paused. "Run now"
+// requests are queued by the API in worker_commands; Run polls the queue and
+// executes them in the worker process.
+package workerctl
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/Saul-Punybz/folio/internal/models"
+)
+
+// What started a run.
+const (
+	TriggerSchedule = "schedule"
+	TriggerManual   = "manual"  // a run-now command from the admin API
+	TriggerStartup  = "startup" // the worker's initial run after starting
+)
+
+// Jobs the API queues runs of from its own endpoints.
+const (
+	JobIngestion         = "ingestion"
+	JobWatchlistScan     = "watchlist_scan"
+	JobEmbeddingBackfill = "embedding_backfill"
+)
+
+const (
+	pollInterval  = 5 * time.Second
+	commandExpiry = 15 * time.Minute // run-now requests no worker picked up by then are dropped
+	recordTimeout = 10 * time.Second
+)
+
+// Jobs is the set of cron jobs a worker process runs.
+type Jobs struct {
+	ctx   context.Context
+	store *models.WorkerJobStore
+	wg    *sync.WaitGroup
+	jobs  map[string]*job
+}
+
+type job struct {
+	name     string
+	schedule string
+	timeout  time.Duration
+	fn       func(context.Context)
+	running  atomic.Int32
+}
+
+type triggerKey struct{}
+
+// TriggerFrom returns what started the run whose context is ctx.
+func TriggerFrom(ctx context.Context) string {
+	trigger, _ := ctx.Value(triggerKey{}).(string)
+	return trigger
+}
+
+// New returns an empty job set. Runs get contexts derived from ctx, which is
+// cancelled on shutdown, and are tracked in wg.
+func New(ctx context.Context, store *models.WorkerJobStore, wg *sync.WaitGroup) *Jobs {
+	return &Jobs{ctx: ctx, store: store, wg: wg, jobs: make(map[string]*job)}
+}
+
+// Add schedules fn on c as the job name. Each run's context is bounded by
+// timeout. All jobs must be added before Run is called.
+func (j *Jobs) Add(c *cron.Cron, name, spec string, timeout time.Duration, fn func(ctx context.Context)) error {
+	if _, ok := j.jobs[name]; ok {
+		return fmt.Errorf("worker job %s added twice", name)
+	}
+	jb := &job{name: name, schedule: spec, timeout: timeout, fn: fn}
+	if _, err := c.AddFunc(spec, func() { j.run(jb, TriggerSchedule) }); err != nil {
+		return fmt.Errorf("worker job %s: %w", name, err)
+	}
+	j.jobs[name] = jb
+	return nil
+}
+
+// Run registers the jobs in worker_jobs, then executes queued run-now
+// commands until the context passed to New is done.
+func (j *Jobs) Run() {
+	schedules := make(map[string]string, len(j.jobs))
+	for name, jb := range j.jobs {
+		schedules[name] = jb.schedule
+	}
+	if err := j.store.Register(j.ctx, schedules); err != nil {
+		slog.Error("worker jobs: register", "err", err)
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-j.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		cmds, err := j.store.ClaimCommands(j.ctx, commandExpiry)
+		if err != nil {
+			if j.ctx.Err() == nil {
+				slog.Error("worker jobs: claim commands", "err", err)
+			}
+			continue
+		}
+		for _, cmd := range cmds {
+			go j.runCommand(cmd)
+		}
+	}
+}
+
+// Execute runs a job now and waits for it to finish. Scheduled and startup
+// runs are skipped while the job is paused; manual runs are refused while
+// the job is already running. The error describes why the job did not run,
+// or did not finish ok.
+func (j *Jobs) Execute(name, trigger string) error {
+	jb, ok := j.jobs[name]
+	if !ok {
+		return fmt.Errorf("unknown job %s", name)
+	}
+	if trigger == TriggerManual && jb.running.Load() > 0 {
+		return errors.New("already running")
+	}
+	return j.run(jb, trigger)
+}
+
+func (j *Jobs) runCommand(cmd models.WorkerCommand) {
+	slog.Info("worker jobs: run requested", "job", cmd.Job, "command", cmd.ID)
+	var msg string
+	if err := j.Execute(cmd.Job, TriggerManual); err != nil {
+		msg = err.Error()
+	}
+
+	ctx, cancel := j.recordContext()
+	defer cancel()
+	if err := j.store.FinishCommand(ctx, cmd.ID, msg); err != nil {
+		slog.Error("worker jobs: finish command", "command", cmd.ID, "err", err)
+	}
+}
+
+// run executes one run of a job and records it.
+func (j *Jobs) run(jb *job, trigger string) error {
+	j.wg.Add(1)
+	defer j.wg.Done()
+
+	if trigger != TriggerManual {
+		paused, err := j.store.Paused(j.ctx, jb.name)
+		if err != nil {
+			slog.Warn("worker jobs: check paused", "job", jb.name, "err", err)
+		}
+		if paused {
+			slog.Debug("worker jobs: paused, skipping run", "job", jb.name)
+			return errors.New("paused")
+		}
+	}
+
+	jb.running.Add(1)
+	defer jb.running.Add(-1)
+	ctx, cancel := j.recordContext()
+	if err := j.store.Started(ctx, jb.name, trigger); err != nil {
+		slog.Warn("worker jobs: record start", "job", jb.name, "err", err)
+	}
+	cancel()
+
+	start := time.Now()
+	jobCtx, jobCancel := context.WithTimeout(context.WithValue(j.ctx, triggerKey{}, trigger), jb.timeout)
+	defer jobCancel()
+	panicked, stack := call(jobCtx, jb.fn)
+	duration := time.Since(start)
+
+	status, msg := "ok", ""
+	switch {
+	case stack != nil:
+		status, msg = "failed", fmt.Sprintf("panic: %v", panicked)
+		slog.Error("worker jobs: job panicked", "job", jb.name, "panic", panicked, "stack", string(stack))
+	case errors.Is(jobCtx.Err(), context.DeadlineExceeded):
+		status, msg = "timeout", fmt.Sprintf("timed out after %s", jb.timeout)
+	case j.ctx.Err() != nil:
+		status, msg = "canceled", "worker shutting down"
+	}
+
+	ctx, cancel = j.recordContext()
+	defer cancel()
+	if err := j.store.Finished(ctx, jb.name, status, msg, duration); err != nil {
+		slog.Warn("worker jobs: record finish", "job", jb.name, "err", err)
+	}
+	if msg != "" {
+		return errors.New(msg)
+	}
+	return nil
+}
+
+// recordContext bounds a write to worker_jobs; it outlives shutdown so runs
+// cut short are still recorded.
+func (j *Jobs) recordContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.WithoutCancel(j.ctx), recordTimeout)
+}
+
+// call runs fn, recovering a panic; the stack is nil when it did not panic.
+func call(ctx context.Context, fn func(context.Context)) (panicked any, stack []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked, stack = r, debug.Stack()
+		}
+	}()
+	fn(ctx)
+	return nil, nil
+}
@@ -0,0 +1,12 @@
+-- Migration 017: Near-duplicate suppression for watchlist hits.
+-- Syndicated wire stories produce the same text under different URLs. Each
+-- hit now carries a hash of its normalized content; a hit whose hash matches
+-- an earlier hit for the same org (within a window) is stored as a duplicate
+-- of it, hidden from listings, and counted on the original.
+
+ALTER TABLE watchlist_hits ADD COLUMN IF NOT EXISTS content_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE watchlist_hits ADD COLUMN IF NOT EXISTS duplicate_of UUID REFERENCES watchlist_hits(id) ON DELETE SET NULL;
+ALTER TABLE watchlist_hits ADD COLUMN IF NOT EXISTS dup_count INT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_wh_org_content_hash ON watchlist_hits(org_id, content_hash, created_at DESC)
+    WHERE content_hash != '' AND duplicate_of IS NULL;
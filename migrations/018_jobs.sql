@@ -0,0 +1,24 @@
+-- Migration 018: Persistent background job queue.
+-- Article enrichment and evidence uploads used to run in fire-and-forget
+-- goroutines, so a worker crash mid-run left articles unenriched forever.
+-- They are now queued here and drained by the worker's job runner, which
+-- retries failures with exponential backoff and reclaims jobs whose worker
+-- died while running them.
+
+CREATE TABLE IF NOT EXISTS jobs (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    kind         TEXT NOT NULL,
+    payload      JSONB NOT NULL DEFAULT '{}',
+    status       TEXT NOT NULL DEFAULT 'pending'
+                 CHECK (status IN ('pending','running','done','failed')),
+    attempts     INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL DEFAULT 5,
+    last_error   TEXT NOT NULL DEFAULT '',
+    run_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    locked_at    TIMESTAMPTZ,
+    finished_at  TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_pending ON jobs(run_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_jobs_running ON jobs(locked_at) WHERE status = 'running';
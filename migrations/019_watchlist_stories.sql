@@ -0,0 +1,15 @@
+-- Migration 019: Group watchlist hits into stories.
+-- The same story covered by several outlets shows up as several hits. After
+-- each scan, hits are assigned to a story (story_id = the first hit seen for
+-- that story) by canonical URL or by embedding similarity to recent hits for
+-- the same org. Syndicated copies are already folded via duplicate_of.
+
+ALTER TABLE watchlist_hits ADD COLUMN IF NOT EXISTS canonical_url TEXT NOT NULL DEFAULT '';
+ALTER TABLE watchlist_hits ADD COLUMN IF NOT EXISTS embedding vector(768);
+ALTER TABLE watchlist_hits ADD COLUMN IF NOT EXISTS story_id UUID REFERENCES watchlist_hits(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_wh_story ON watchlist_hits(story_id);
+CREATE INDEX IF NOT EXISTS idx_wh_org_canonical ON watchlist_hits(org_id, canonical_url)
+    WHERE canonical_url != '';
+CREATE INDEX IF NOT EXISTS idx_wh_ungrouped ON watchlist_hits(created_at)
+    WHERE story_id IS NULL AND duplicate_of IS NULL;
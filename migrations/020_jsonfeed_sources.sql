@@ -0,0 +1,7 @@
+-- Migration 020: Allow JSON Feed sources.
+-- Some sources only publish JSON Feed (https://jsonfeed.org); ingest them
+-- like RSS with feed_type = 'jsonfeed'.
+
+ALTER TABLE sources DROP CONSTRAINT IF EXISTS sources_feed_type_check;
+ALTER TABLE sources ADD CONSTRAINT sources_feed_type_check
+    CHECK (feed_type IN ('rss', 'jsonfeed', 'sitemap', 'scrape', 'manual'));
@@ -0,0 +1,20 @@
+-- Migration 021: Per-tag/per-source retention rules.
+-- Rules move predictable categories out of manual triage, e.g. "crime items
+-- still in the inbox after 30 days go to trash" or "grants items are saved".
+-- A daily worker job applies every active rule; the API offers a dry-run
+-- preview of what a rule would touch.
+
+CREATE TABLE IF NOT EXISTS retention_rules (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name          TEXT NOT NULL,
+    tag           TEXT NOT NULL DEFAULT '',
+    source        TEXT NOT NULL DEFAULT '',
+    from_status   TEXT NOT NULL DEFAULT 'inbox' CHECK (from_status IN ('inbox', 'saved')),
+    min_age_days  INT NOT NULL DEFAULT 0 CHECK (min_age_days >= 0),
+    action        TEXT NOT NULL CHECK (action IN ('trash', 'save')),
+    active        BOOLEAN NOT NULL DEFAULT true,
+    last_run_at   TIMESTAMPTZ,
+    last_affected INT NOT NULL DEFAULT 0,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CHECK (tag != '' OR source != '')
+);
@@ -0,0 +1,20 @@
+-- Migration 022: Daily watchlist digest.
+-- After the 7am scan, each user with new hits gets one digest summarizing
+-- them grouped by org. The digest is stored so it can be fetched later
+-- (GET /api/watchlist/digest/latest) and is announced through bot_notifications.
+
+CREATE TABLE IF NOT EXISTS watchlist_digests (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end   TIMESTAMPTZ NOT NULL,
+    hit_count    INT NOT NULL DEFAULT 0,
+    groups       JSONB NOT NULL DEFAULT '[]',
+    summary      TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_watchlist_digests_user ON watchlist_digests(user_id, created_at DESC);
+
+ALTER TABLE bot_notifications DROP CONSTRAINT IF EXISTS bot_notifications_type_check;
+ALTER TABLE bot_notifications ADD CONSTRAINT bot_notifications_type_check
+    CHECK (type IN ('digest', 'watchlist_hit', 'watchlist_digest', 'system'));
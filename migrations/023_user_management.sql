@@ -0,0 +1,6 @@
+-- Migration 023: User management.
+-- Users can now be created and edited by admins through the API. Deactivated
+-- users keep their data but can no longer log in or use existing sessions.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS active BOOLEAN NOT NULL DEFAULT true;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW();
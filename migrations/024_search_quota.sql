@@ -0,0 +1,16 @@
+-- Migration 024: Daily web search quotas.
+-- Counts queries sent to each scraped search engine per day so budgets can be
+-- enforced before the engines start blocking us. Watchlist orgs get a
+-- priority: as an engine's budget runs low, lower-priority orgs stop using it
+-- first.
+
+CREATE TABLE IF NOT EXISTS search_usage (
+    day     DATE NOT NULL,
+    engine  TEXT NOT NULL,
+    queries INT NOT NULL DEFAULT 0,
+    denied  INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, engine)
+);
+
+ALTER TABLE watchlist_orgs ADD COLUMN IF NOT EXISTS priority SMALLINT NOT NULL DEFAULT 1
+    CHECK (priority BETWEEN 0 AND 2);
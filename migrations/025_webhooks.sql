@@ -0,0 +1,20 @@
+-- Migration 025: Watchlist hit webhooks.
+-- Each user can register Slack, Discord or generic JSON webhook targets. New
+-- watchlist hits matching a target's sentiment/source_type filters are posted
+-- to it by the job queue, which retries failed deliveries with backoff.
+
+CREATE TABLE IF NOT EXISTS webhook_targets (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id      UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name         TEXT NOT NULL DEFAULT '',
+    url          TEXT NOT NULL,
+    kind         TEXT NOT NULL DEFAULT 'generic'
+                 CHECK (kind IN ('slack', 'discord', 'generic')),
+    sentiments   JSONB NOT NULL DEFAULT '[]',
+    source_types JSONB NOT NULL DEFAULT '[]',
+    secret       TEXT NOT NULL DEFAULT '',
+    active       BOOLEAN NOT NULL DEFAULT true,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_targets_user ON webhook_targets (user_id);
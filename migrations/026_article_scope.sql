@@ -0,0 +1,8 @@
+-- Migration 026: Article scope.
+-- Separates Puerto Rico local coverage from federal news affecting Puerto Rico
+-- and diaspora coverage. Set by AI enrichment; '' means not yet classified.
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS scope TEXT NOT NULL DEFAULT ''
+    CHECK (scope IN ('', 'local', 'federal', 'diaspora'));
+
+CREATE INDEX IF NOT EXISTS idx_articles_scope ON articles (scope) WHERE scope <> '';
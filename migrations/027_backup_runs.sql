@@ -0,0 +1,19 @@
+-- Migration 027: Database backup runs.
+-- One row per scheduled (or manual) database backup uploaded to object
+-- storage, so admins can see when the last good backup happened.
+
+CREATE TABLE IF NOT EXISTS backup_runs (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    started_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at TIMESTAMPTZ,
+    method      TEXT NOT NULL DEFAULT '',
+    object_key  TEXT NOT NULL DEFAULT '',
+    size_bytes  BIGINT NOT NULL DEFAULT 0,
+    tables      INT NOT NULL DEFAULT 0,
+    status      TEXT NOT NULL DEFAULT 'running'
+                CHECK (status IN ('running', 'ok', 'failed')),
+    error       TEXT NOT NULL DEFAULT '',
+    pruned      INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_backup_runs_started ON backup_runs (started_at DESC);
@@ -0,0 +1,12 @@
+-- Migration 028: Cluster articles that cover the same story.
+-- The same story from several outlets shows up as several inbox items. During
+-- enrichment each article joins the cluster of a recent article whose text
+-- SimHash is within a few bits (wire copies, light rewrites) or whose
+-- embedding is close enough, and otherwise starts its own cluster
+-- (cluster_id = its own id). NULL means not yet clustered.
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS simhash BIGINT;
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS cluster_id UUID REFERENCES articles(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_articles_cluster ON articles (cluster_id) WHERE cluster_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_articles_simhash_recent ON articles (created_at) WHERE simhash IS NOT NULL;
@@ -0,0 +1,7 @@
+-- Migration 029: Quotable sentences.
+-- AI enrichment extracts up to three direct quotes with attribution from each
+-- article. Daily briefs keep the quotes they were given so readers (and the
+-- PR drafter) can reference exact language from the coverage.
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS quotes JSONB NOT NULL DEFAULT '[]';
+ALTER TABLE briefs ADD COLUMN IF NOT EXISTS quotes JSONB NOT NULL DEFAULT '[]';
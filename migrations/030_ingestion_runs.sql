@@ -0,0 +1,21 @@
+-- Migration 030: Ingestion run history.
+-- One row per ingestion run (scheduled, manual, or CLI) with counters and
+-- the errors it hit, so admins can see why a run produced no articles.
+
+CREATE TABLE IF NOT EXISTS ingestion_runs (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    started_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    finished_at       TIMESTAMPTZ,
+    duration_ms       BIGINT NOT NULL DEFAULT 0,
+    status            TEXT NOT NULL DEFAULT 'running'
+                      CHECK (status IN ('running', 'ok', 'skipped', 'failed')),
+    note              TEXT NOT NULL DEFAULT '',
+    sources_processed INT NOT NULL DEFAULT 0,
+    articles_created  INT NOT NULL DEFAULT 0,
+    articles_skipped  INT NOT NULL DEFAULT 0,
+    error_count       INT NOT NULL DEFAULT 0,
+    errors            JSONB NOT NULL DEFAULT '[]',
+    sources           JSONB NOT NULL DEFAULT '[]'
+);
+
+CREATE INDEX IF NOT EXISTS idx_ingestion_runs_started ON ingestion_runs (started_at DESC);
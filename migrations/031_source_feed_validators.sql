@@ -0,0 +1,6 @@
+-- Migration 031: Feed cache validators.
+-- ETag / Last-Modified from each source's last feed fetch, sent back as
+-- If-None-Match / If-Modified-Since so unchanged feeds cost a 304.
+
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS feed_etag TEXT NOT NULL DEFAULT '';
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS feed_last_modified TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,46 @@
+-- Migration 032: Source bundles.
+-- Predefined sets of sources (feed URLs and selectors already tested against
+-- the live sites) that an admin can install in one call on a new deployment.
+-- Each entry of `sources` uses the sources table's column names.
+
+CREATE TABLE IF NOT EXISTS source_bundles (
+    slug        TEXT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    sources     JSONB NOT NULL DEFAULT '[]',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO source_bundles (slug, name, description, sources) VALUES
+
+('pr-core-news',
+ 'Noticias PR (núcleo)',
+ 'Medios de Puerto Rico con feeds RSS estables.',
+ '[
+   {"name": "El Nuevo Dia", "base_url": "https://www.elnuevodia.com", "region": "PR",
+    "feed_type": "rss", "feed_url": "https://www.elnuevodia.com/arc/outboundfeeds/rss/?outputType=xml"},
+   {"name": "CPI", "base_url": "https://periodismoinvestigativo.com", "region": "PR",
+    "feed_type": "rss", "feed_url": "https://periodismoinvestigativo.com/feed/"},
+   {"name": "News is My Business", "base_url": "https://newsismybusiness.com", "region": "PR",
+    "feed_type": "rss", "feed_url": "https://newsismybusiness.com/feed/"},
+   {"name": "Es Noticia PR", "base_url": "https://esnoticiapr.com", "region": "PR",
+    "feed_type": "rss", "feed_url": "https://esnoticiapr.com/feed/"},
+   {"name": "TeleOnce", "base_url": "https://teleonce.com", "region": "PR",
+    "feed_type": "rss", "feed_url": "https://teleonce.com/feed/"},
+   {"name": "Radio Isla 1320", "base_url": "https://radioisla.tv", "region": "PR",
+    "feed_type": "rss", "feed_url": "https://radioisla.tv/feed/"}
+ ]'),
+
+('federal-pr',
+ 'Federal relevante a PR',
+ 'Publicaciones federales: Federal Register, GovInfo y GAO.',
+ '[
+   {"name": "Federal Register", "base_url": "https://www.federalregister.gov", "region": "Federal",
+    "feed_type": "rss", "feed_url": "https://www.federalregister.gov/api/v1/documents.rss"},
+   {"name": "GovInfo", "base_url": "https://www.govinfo.gov", "region": "Federal",
+    "feed_type": "rss", "feed_url": "https://www.govinfo.gov/rss/fr.xml"},
+   {"name": "GAO Reports", "base_url": "https://www.gao.gov", "region": "Federal",
+    "feed_type": "rss", "feed_url": "https://www.gao.gov/rss/reports.xml"}
+ ]')
+
+ON CONFLICT (slug) DO NOTHING;
@@ -0,0 +1,5 @@
+-- Migration 033: Headless rendering for JS-heavy sources.
+-- Sources with render_js are scraped through a headless Chrome so content
+-- built client-side (e.g. Telemundo PR) is visible to the selectors.
+
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS render_js BOOLEAN NOT NULL DEFAULT false;
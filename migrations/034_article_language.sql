@@ -0,0 +1,25 @@
+-- Migration 034: Article language.
+-- language is detected at ingestion ('es', 'en', or '' when unknown) and
+-- selects the text search config, so Spanish and English articles get
+-- stemming instead of the language-neutral 'simple' config.
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS search_tsv tsvector
+    GENERATED ALWAYS AS (
+        to_tsvector(
+            CASE language
+                WHEN 'es' THEN 'spanish'::regconfig
+                WHEN 'en' THEN 'english'::regconfig
+                ELSE 'simple'::regconfig
+            END,
+            coalesce(title, '') || ' ' || coalesce(clean_text, ''))
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS idx_articles_search_tsv ON articles USING GIN (search_tsv);
+CREATE INDEX IF NOT EXISTS idx_articles_language ON articles (language);
+
+-- Superseded by search_tsv.
+DROP INDEX IF EXISTS idx_articles_fts;
+DROP INDEX IF EXISTS idx_articles_fts_en;
+DROP INDEX IF EXISTS idx_articles_fts_simple;
@@ -0,0 +1,18 @@
+-- Migration 035: Tag taxonomy.
+-- The topic tags Classify may assign, previously hard-coded in internal/ai.
+-- Admins can add local topics (e.g. luma, fiscal-board) or retire tags
+-- without a redeploy. description is shown to the model as a hint for what
+-- belongs under the tag.
+
+CREATE TABLE IF NOT EXISTS tags (
+    name        TEXT PRIMARY KEY CHECK (name ~ '^[a-z0-9][a-z0-9-]*$'),
+    description TEXT NOT NULL DEFAULT '',
+    active      BOOLEAN NOT NULL DEFAULT true,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO tags (name) VALUES
+    ('politics'), ('economy'), ('health'), ('education'), ('infrastructure'),
+    ('environment'), ('crime'), ('grants'), ('federal'), ('legislation'),
+    ('government'), ('technology'), ('culture'), ('sports')
+ON CONFLICT (name) DO NOTHING;
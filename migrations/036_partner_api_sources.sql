@@ -0,0 +1,11 @@
+-- Migration 036: Partner API sources.
+-- feed_type 'api' ingests licensed full text from a publisher's JSON API.
+-- api_mapping describes how to authenticate and where each article field
+-- sits in the response; credentials themselves stay in the environment
+-- (FOLIO_PARTNER_* variables), never in the database.
+
+ALTER TABLE sources DROP CONSTRAINT IF EXISTS sources_feed_type_check;
+ALTER TABLE sources ADD CONSTRAINT sources_feed_type_check
+    CHECK (feed_type IN ('rss', 'jsonfeed', 'sitemap', 'scrape', 'manual', 'api'));
+
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS api_mapping JSONB;
@@ -0,0 +1,5 @@
+-- Migration 037: Top entities on daily briefs.
+-- Each brief keeps the people, organizations and places most mentioned in
+-- the day's articles, from the article_entities links written at enrichment.
+
+ALTER TABLE briefs ADD COLUMN IF NOT EXISTS top_entities JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,17 @@
+-- Migration 038: Inbox triage suggestions.
+-- When TRIAGE_SUGGESTIONS is enabled, a worker job asks the model whether
+-- each new inbox item should be saved or trashed, given recent triage
+-- decisions and the watchlist. Suggestions are never applied on their own;
+-- the analyst accepts them (in bulk) or dismisses them. A dismissed
+-- suggestion is kept so the item is not suggested again.
+
+CREATE TABLE IF NOT EXISTS triage_suggestions (
+    article_id  UUID PRIMARY KEY REFERENCES articles(id) ON DELETE CASCADE,
+    action      TEXT NOT NULL CHECK (action IN ('save', 'trash')),
+    rationale   TEXT NOT NULL DEFAULT '',
+    dismissed   BOOLEAN NOT NULL DEFAULT false,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_triage_suggestions_pending
+    ON triage_suggestions (created_at DESC) WHERE dismissed = false;
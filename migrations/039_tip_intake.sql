@@ -0,0 +1,30 @@
+-- Migration 039: Tip intake for external forms and partner systems.
+-- POST /api/intake accepts tips authenticated by an intake API key (stored
+-- as a SHA-256 hash; the key itself is only shown once, on creation). Each
+-- tip becomes an inbox article with source 'tip' and keeps the submitter
+-- metadata here; its id is the receipt returned to the submitter.
+
+CREATE TABLE IF NOT EXISTS intake_keys (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name         TEXT NOT NULL,
+    key_hash     TEXT NOT NULL UNIQUE,
+    key_prefix   TEXT NOT NULL,
+    active       BOOLEAN NOT NULL DEFAULT true,
+    created_by   UUID REFERENCES users(id) ON DELETE SET NULL,
+    last_used_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS tips (
+    id                UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    article_id        UUID REFERENCES articles(id) ON DELETE SET NULL,
+    intake_key_id     UUID REFERENCES intake_keys(id) ON DELETE SET NULL,
+    url               TEXT NOT NULL DEFAULT '',
+    description       TEXT NOT NULL DEFAULT '',
+    submitter_name    TEXT NOT NULL DEFAULT '',
+    submitter_contact TEXT NOT NULL DEFAULT '',
+    submitter_org     TEXT NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tips_article ON tips (article_id);
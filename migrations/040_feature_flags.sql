@@ -0,0 +1,30 @@
+-- Migration 040: Feature flags for dark launches.
+-- A flag is on for a user when their override says so; otherwise when it is
+-- enabled and the user falls within rollout_percent (users are bucketed by a
+-- hash of flag name and user id, so a user keeps their bucket as the rollout
+-- grows). Flags unknown to the database are off.
+
+CREATE TABLE IF NOT EXISTS feature_flags (
+    name            TEXT PRIMARY KEY CHECK (name ~ '^[a-z0-9][a-z0-9_]*$'),
+    description     TEXT NOT NULL DEFAULT '',
+    enabled         BOOLEAN NOT NULL DEFAULT false,
+    rollout_percent INT NOT NULL DEFAULT 100 CHECK (rollout_percent BETWEEN 0 AND 100),
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS feature_flag_overrides (
+    flag_name  TEXT NOT NULL REFERENCES feature_flags(name) ON DELETE CASCADE,
+    user_id    UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    enabled    BOOLEAN NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (flag_name, user_id)
+);
+
+-- Features that already shipped start fully on, so nothing changes until an
+-- admin narrows them.
+INSERT INTO feature_flags (name, description, enabled) VALUES
+    ('semantic_search', 'Semantic and hybrid search modes', true),
+    ('auto_triage', 'AI save/trash suggestions in the inbox', true),
+    ('sse_chat', 'Streaming chat responses', true)
+ON CONFLICT (name) DO NOTHING;
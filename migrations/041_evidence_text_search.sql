@@ -0,0 +1,15 @@
+-- Migration 041: Full-text index of preserved evidence text.
+-- clean_text can be shorter than the extracted text captured as evidence in
+-- S3. A background job indexes each article's extracted.txt here, with the
+-- article's language config, so search also matches the preserved text.
+-- A row with a NULL search_tsv records that the article has no evidence;
+-- deleting the row queues the article to be indexed again.
+
+CREATE TABLE IF NOT EXISTS article_evidence_text (
+    article_id UUID PRIMARY KEY REFERENCES articles(id) ON DELETE CASCADE,
+    search_tsv tsvector,
+    char_count INT NOT NULL DEFAULT 0,
+    indexed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_evidence_text_tsv ON article_evidence_text USING GIN (search_tsv);
@@ -0,0 +1,21 @@
+-- Migration 042: Approval workflow for AI response drafts on watchlist hits.
+-- ai_draft keeps the model's original text; draft_text holds the human
+-- revision. draft_status is NULL until a hit has a draft, then moves through
+-- draft -> edited -> approved -> sent.
+
+ALTER TABLE watchlist_hits
+    ADD COLUMN IF NOT EXISTS draft_status TEXT
+        CHECK (draft_status IN ('draft', 'edited', 'approved', 'sent')),
+    ADD COLUMN IF NOT EXISTS draft_text TEXT,
+    ADD COLUMN IF NOT EXISTS draft_updated_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS draft_approved_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS draft_approved_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS draft_sent_at TIMESTAMPTZ;
+
+UPDATE watchlist_hits SET draft_status = 'draft'
+WHERE ai_draft IS NOT NULL AND ai_draft != '' AND draft_status IS NULL;
+
+-- Export of approved communications per org and date range.
+CREATE INDEX IF NOT EXISTS idx_watchlist_hits_draft_status
+    ON watchlist_hits (org_id, draft_approved_at)
+    WHERE draft_status IN ('approved', 'sent');
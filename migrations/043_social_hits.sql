@@ -0,0 +1,6 @@
+-- Migration 043: Allow "social" watchlist hits (Mastodon and X posts).
+-- The original constraint also predates the Bing News agent.
+
+ALTER TABLE watchlist_hits DROP CONSTRAINT IF EXISTS watchlist_hits_source_type_check;
+ALTER TABLE watchlist_hits ADD CONSTRAINT watchlist_hits_source_type_check
+    CHECK (source_type IN ('google_news', 'bing_news', 'web', 'local', 'youtube', 'reddit', 'social'));
@@ -0,0 +1,5 @@
+-- Migration 044: Facebook pages and Instagram accounts polled per watchlist
+-- org, stored as "facebook:<page>" / "instagram:<account id>".
+
+ALTER TABLE watchlist_orgs
+    ADD COLUMN IF NOT EXISTS social_pages JSONB NOT NULL DEFAULT '[]';
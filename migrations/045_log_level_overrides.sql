@@ -0,0 +1,12 @@
+-- Migration 045: Temporary log level overrides.
+-- An admin can raise or lower a service's log level for a while without a
+-- restart; each process polls its row and returns to its LOG_LEVEL once the
+-- override expires or is deleted.
+
+CREATE TABLE IF NOT EXISTS log_level_overrides (
+    service    TEXT PRIMARY KEY CHECK (service IN ('api', 'worker', 'app', 'bot')),
+    level      TEXT NOT NULL CHECK (level IN ('debug', 'info', 'warn', 'error')),
+    expires_at TIMESTAMPTZ NOT NULL,
+    set_by     UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,5 @@
+-- Migration 046: Allow "gov" watchlist hits (government press releases).
+
+ALTER TABLE watchlist_hits DROP CONSTRAINT IF EXISTS watchlist_hits_source_type_check;
+ALTER TABLE watchlist_hits ADD CONSTRAINT watchlist_hits_source_type_check
+    CHECK (source_type IN ('google_news', 'bing_news', 'web', 'local', 'youtube', 'reddit', 'social', 'gov'));
@@ -0,0 +1,52 @@
+-- Migration 047: Structured Grants.gov and Federal Register records.
+-- Sources with feed_type "grantsgov" or "federalregister" are read through
+-- the Grants.gov Search2 API and the Federal Register documents API. Their
+-- records keep the fields those APIs return instead of becoming articles.
+
+ALTER TABLE sources DROP CONSTRAINT IF EXISTS sources_feed_type_check;
+ALTER TABLE sources ADD CONSTRAINT sources_feed_type_check
+    CHECK (feed_type IN ('rss', 'jsonfeed', 'sitemap', 'scrape', 'manual', 'api', 'grantsgov', 'federalregister'));
+
+CREATE TABLE IF NOT EXISTS grant_opportunities (
+    id                 UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    source_id          UUID REFERENCES sources(id) ON DELETE SET NULL,
+    opportunity_id     TEXT NOT NULL UNIQUE,          -- Grants.gov numeric id
+    opportunity_number TEXT NOT NULL DEFAULT '',      -- e.g. HHS-2025-ACF-OCS-EE-0001
+    title              TEXT NOT NULL,
+    agency             TEXT NOT NULL DEFAULT '',
+    agency_code        TEXT NOT NULL DEFAULT '',
+    cfda_numbers       TEXT[] NOT NULL DEFAULT '{}',  -- Assistance Listing numbers
+    opp_status         TEXT NOT NULL DEFAULT '',      -- forecasted, posted, closed, archived
+    doc_type           TEXT NOT NULL DEFAULT '',
+    open_date          DATE,
+    close_date         DATE,
+    url                TEXT NOT NULL,
+    first_seen_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_grant_opportunities_close_date ON grant_opportunities (close_date);
+CREATE INDEX IF NOT EXISTS idx_grant_opportunities_cfda ON grant_opportunities USING GIN (cfda_numbers);
+
+CREATE TABLE IF NOT EXISTS federal_register_documents (
+    document_number   TEXT PRIMARY KEY,
+    source_id         UUID REFERENCES sources(id) ON DELETE SET NULL,
+    title             TEXT NOT NULL,
+    doc_type          TEXT NOT NULL DEFAULT '',       -- Rule, Proposed Rule, Notice, Presidential Document
+    abstract          TEXT NOT NULL DEFAULT '',
+    agencies          TEXT[] NOT NULL DEFAULT '{}',
+    publication_date  DATE,
+    comments_close_on DATE,
+    effective_on      DATE,
+    url               TEXT NOT NULL,
+    first_seen_at     TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_federal_register_documents_publication ON federal_register_documents (publication_date DESC);
+
+-- The seeded RSS sources have been broken since the feeds were retired.
+UPDATE sources SET feed_type = 'grantsgov', feed_url = 'keyword=Puerto Rico', active = true
+WHERE name = 'Grants.gov';
+UPDATE sources SET feed_type = 'federalregister', feed_url = '', active = true
+WHERE name = 'Federal Register';
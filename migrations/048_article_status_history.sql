@@ -0,0 +1,22 @@
+-- Migration 048: Article status history.
+-- Every change of an article's status (inbox, saved, trashed) is recorded so
+-- the inbox and saved sets can be reconstructed as of a past date.
+-- Existing articles get one backfilled row placing them in their current
+-- status since they were created; their earlier moves are not known.
+
+CREATE TABLE IF NOT EXISTS article_status_history (
+    id          BIGSERIAL PRIMARY KEY,
+    article_id  UUID NOT NULL REFERENCES articles(id) ON DELETE CASCADE,
+    from_status TEXT,                       -- NULL when the article was created
+    to_status   TEXT NOT NULL,
+    reason      TEXT NOT NULL DEFAULT '',   -- created, user, retention, triage, backfill
+    changed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_article_status_history_article
+    ON article_status_history (article_id, changed_at DESC, id DESC);
+
+INSERT INTO article_status_history (article_id, from_status, to_status, reason, changed_at)
+SELECT a.id, NULL, a.status, 'backfill', a.created_at
+FROM articles a
+WHERE NOT EXISTS (SELECT 1 FROM article_status_history h WHERE h.article_id = a.id);
@@ -0,0 +1,22 @@
+-- Migration 049: Grant opportunity details and deadline tracking.
+-- Award amounts and eligibility come from the Grants.gov opportunity detail
+-- (fetched once per opportunity). tracking records whether the team is
+-- pursuing or has declined an opportunity, and closing_flagged_at when the
+-- deadline job announced that it closes within 14 days.
+
+ALTER TABLE grant_opportunities
+    ADD COLUMN IF NOT EXISTS award_ceiling       BIGINT,
+    ADD COLUMN IF NOT EXISTS award_floor         BIGINT,
+    ADD COLUMN IF NOT EXISTS estimated_funding   BIGINT,
+    ADD COLUMN IF NOT EXISTS eligibility         TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS applicant_types     TEXT[] NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS details_fetched_at  TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS tracking            TEXT CHECK (tracking IN ('pursuing', 'declined')),
+    ADD COLUMN IF NOT EXISTS tracking_updated_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS closing_flagged_at  TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_grant_opportunities_tracking ON grant_opportunities (tracking) WHERE tracking IS NOT NULL;
+
+ALTER TABLE bot_notifications DROP CONSTRAINT IF EXISTS bot_notifications_type_check;
+ALTER TABLE bot_notifications ADD CONSTRAINT bot_notifications_type_check
+    CHECK (type IN ('digest', 'watchlist_hit', 'watchlist_digest', 'grant_deadline', 'system'));
@@ -0,0 +1,12 @@
+-- Migration 050: Wayback Machine snapshots.
+-- archive_url is the web.archive.org copy requested through Save Page Now
+-- for articles whose evidence is at risk: those collected with "archive"
+-- set and those ingested from sources with archive_snapshots enabled
+-- (paywalled outlets, or ones known to edit stories after publication).
+
+ALTER TABLE articles
+    ADD COLUMN IF NOT EXISTS archive_url TEXT NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
+
+ALTER TABLE sources
+    ADD COLUMN IF NOT EXISTS archive_snapshots BOOLEAN NOT NULL DEFAULT false;
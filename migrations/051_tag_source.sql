@@ -0,0 +1,11 @@
+-- Migration 051: where an article's tags came from.
+-- '' is the LLM classifier (or no tags yet); 'inferred' marks tags assigned
+-- by the nearest-centroid backfill (folioctl tags infer) from the embeddings
+-- of classifier-tagged articles. Inferred tags are replaced, and the mark
+-- cleared, whenever the article is enriched again.
+
+ALTER TABLE articles
+    ADD COLUMN IF NOT EXISTS tag_source TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_articles_tag_source_inferred
+    ON articles (id) WHERE tag_source = 'inferred';
@@ -0,0 +1,20 @@
+-- Migration 052: indexes for filtering watchlist hits.
+-- GET /api/watchlist/hits filters a user's hits by org, sentiment, source
+-- type, seen state, date range and text, newest first. Syndicated copies
+-- (duplicate_of set) are never listed, so the indexes skip them.
+
+CREATE INDEX IF NOT EXISTS idx_wh_org_created
+    ON watchlist_hits (org_id, created_at DESC)
+    WHERE duplicate_of IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_wh_org_sentiment_created
+    ON watchlist_hits (org_id, sentiment, created_at DESC)
+    WHERE duplicate_of IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_wh_org_source_created
+    ON watchlist_hits (org_id, source_type, created_at DESC)
+    WHERE duplicate_of IS NULL;
+
+-- Must match the expression in models.HitFilter.
+CREATE INDEX IF NOT EXISTS idx_wh_text
+    ON watchlist_hits USING GIN (to_tsvector('simple', title || ' ' || snippet));
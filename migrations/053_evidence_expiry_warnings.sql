@@ -0,0 +1,8 @@
+-- Migration 053: pre-expiry evidence warnings.
+-- The worker warns about evidence expiring within 7 days once per expiry
+-- date: evidence_expiry_warned_for records the evidence_expires_at value last
+-- warned about, so extending retention (which moves the expiry) re-arms the
+-- warning without every writer having to reset a flag.
+
+ALTER TABLE articles
+    ADD COLUMN IF NOT EXISTS evidence_expiry_warned_for TIMESTAMPTZ;
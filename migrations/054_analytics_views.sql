@@ -0,0 +1,57 @@
+-- Migration 054: analytics materialized views.
+-- The analytics endpoints read daily aggregates from these views instead of
+-- scanning articles and watchlist_hits on every request. The worker refreshes
+-- them all in one REPEATABLE READ transaction (models.AnalyticsStore.Refresh),
+-- so they always describe the same snapshot, and records when in
+-- analytics_refresh. The unique indexes allow REFRESH ... CONCURRENTLY, which
+-- keeps the views readable during a refresh.
+
+-- Articles per day by source, region and sentiment; volume, source health,
+-- region and sentiment figures are sums over it.
+CREATE MATERIALIZED VIEW IF NOT EXISTS mv_article_daily AS
+SELECT (created_at AT TIME ZONE 'UTC')::date AS day,
+       source,
+       region,
+       COALESCE(sentiment, '') AS sentiment,
+       count(*) AS articles,
+       count(*) FILTER (WHERE summary IS NOT NULL AND summary != '') AS enriched,
+       max(created_at) AS last_created_at
+FROM articles
+GROUP BY 1, 2, 3, 4;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mv_article_daily
+    ON mv_article_daily (day, source, region, sentiment);
+
+-- Articles per day by tag.
+CREATE MATERIALIZED VIEW IF NOT EXISTS mv_tag_daily AS
+SELECT (a.created_at AT TIME ZONE 'UTC')::date AS day,
+       t.tag,
+       count(*) AS articles
+FROM articles a, jsonb_array_elements_text(COALESCE(a.tags, '[]'::jsonb)) AS t(tag)
+GROUP BY 1, 2;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mv_tag_daily ON mv_tag_daily (day, tag);
+
+-- Watchlist hits per day by org and sentiment. Syndicated copies are not
+-- counted, as in the hit lists.
+CREATE MATERIALIZED VIEW IF NOT EXISTS mv_org_hit_daily AS
+SELECT (created_at AT TIME ZONE 'UTC')::date AS day,
+       org_id,
+       sentiment,
+       count(*) AS hits
+FROM watchlist_hits
+WHERE duplicate_of IS NULL
+GROUP BY 1, 2, 3;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mv_org_hit_daily ON mv_org_hit_daily (day, org_id, sentiment);
+CREATE INDEX IF NOT EXISTS idx_mv_org_hit_daily_org ON mv_org_hit_daily (org_id, day);
+
+-- When the views were last refreshed (a single row).
+CREATE TABLE IF NOT EXISTS analytics_refresh (
+    id           BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+    refreshed_at TIMESTAMPTZ NOT NULL,
+    duration_ms  INTEGER NOT NULL DEFAULT 0
+);
+
+INSERT INTO analytics_refresh (refreshed_at) VALUES (NOW())
+ON CONFLICT (id) DO NOTHING;
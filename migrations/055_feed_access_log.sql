@@ -0,0 +1,35 @@
+-- Migration 055: access log for public feed and share endpoints.
+-- Feed tokens are bearer secrets; a token posted somewhere public shows up
+-- as fetches from many addresses or with outside referrers. Every fetch of
+-- /feed/{token}.xml and /share/{id} is logged. Only a prefix of the token is
+-- kept, enough to tell token generations apart.
+
+CREATE TABLE IF NOT EXISTS feed_access_log (
+    id           BIGSERIAL PRIMARY KEY,
+    kind         TEXT NOT NULL CHECK (kind IN ('feed', 'share')),
+    user_id      UUID REFERENCES users(id) ON DELETE CASCADE,       -- feed owner; NULL for shares and unknown tokens
+    token_prefix TEXT NOT NULL DEFAULT '',
+    article_id   UUID REFERENCES articles(id) ON DELETE CASCADE,    -- shared article
+    ip           TEXT NOT NULL DEFAULT '',
+    user_agent   TEXT NOT NULL DEFAULT '',
+    referer      TEXT NOT NULL DEFAULT '',                          -- only when from another site
+    status       INTEGER NOT NULL,
+    accessed_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_feed_access_user ON feed_access_log (user_id, accessed_at DESC)
+    WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_feed_access_at ON feed_access_log (accessed_at);
+
+-- Tokens flagged as shared publicly, at most once per token.
+CREATE TABLE IF NOT EXISTS feed_leak_alerts (
+    id                UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id           UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_prefix      TEXT NOT NULL,
+    distinct_ips      INTEGER NOT NULL,
+    distinct_agents   INTEGER NOT NULL,
+    outside_referers  INTEGER NOT NULL,
+    rotated           BOOLEAN NOT NULL DEFAULT false,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, token_prefix)
+);
@@ -0,0 +1,21 @@
+-- Migration 056: soft-deleted (trashed) articles.
+-- deleted_at records when an article entered the trash, so the worker can
+-- purge articles trashed more than TRASH_PURGE_DAYS ago. Trashing switches
+-- evidence to the 3-month policy; the policy and expiry the article had
+-- before are kept in pre_trash_* so restoring it gives them back.
+-- Articles already in the trash are dated now: the status history can't
+-- tell when most of them were trashed (048 backfilled it with their creation
+-- time), and an older date would let the first purge delete them, evidence
+-- included, without the grace period.
+
+ALTER TABLE articles
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS pre_trash_evidence_policy TEXT,
+    ADD COLUMN IF NOT EXISTS pre_trash_expires_at TIMESTAMPTZ;
+
+UPDATE articles
+SET deleted_at = NOW()
+WHERE status = 'trashed' AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_articles_deleted_at
+    ON articles (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,22 @@
+-- Migration 057: audit log.
+-- Every mutating request to the authenticated API (and every export) is
+-- recorded with who made it, the route it hit, the entity it named and the
+-- response status, so trashing, exporting and editing can be traced back to
+-- a user. Rows outlive their user: user_id is cleared, the email is kept.
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     UUID REFERENCES users(id) ON DELETE SET NULL,
+    user_email  TEXT NOT NULL DEFAULT '',
+    action      TEXT NOT NULL,                  -- method and route, e.g. "POST /api/items/{id}/trash"
+    entity      TEXT NOT NULL DEFAULT '',       -- resource the route belongs to, e.g. "items"
+    entity_id   TEXT NOT NULL DEFAULT '',
+    status      INTEGER NOT NULL,
+    ip          TEXT NOT NULL DEFAULT '',
+    request_id  TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log (created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_user ON audit_log (user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_entity ON audit_log (entity, entity_id, created_at DESC);
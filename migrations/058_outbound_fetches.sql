@@ -0,0 +1,22 @@
+-- Migration 058: outbound fetch log.
+-- Every request Folio makes to another site (scraping, feeds, sitemaps, web
+-- search, watchlist agents, crawling, rendering) is recorded with why it was
+-- made, so a publisher asking what Folio fetched from them, and how often,
+-- can be answered. The table is a ring buffer: the worker prunes entries past
+-- OUTBOUND_LOG_DAYS and keeps at most OUTBOUND_LOG_MAX_ROWS.
+
+CREATE TABLE IF NOT EXISTS outbound_fetches (
+    id           BIGSERIAL PRIMARY KEY,
+    fetched_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    purpose      TEXT NOT NULL DEFAULT 'other',   -- ingestion, watchlist, chat, enrichment, crawl, research, other
+    method       TEXT NOT NULL DEFAULT 'GET',
+    url          TEXT NOT NULL,
+    domain       TEXT NOT NULL,                   -- host, lower case, without www.
+    status       INTEGER NOT NULL DEFAULT 0,      -- 0 when no response was received
+    bytes        BIGINT NOT NULL DEFAULT 0,       -- response body bytes read
+    duration_ms  INTEGER NOT NULL DEFAULT 0,
+    error        TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbound_fetches_domain ON outbound_fetches (domain, fetched_at DESC);
+CREATE INDEX IF NOT EXISTS idx_outbound_fetches_at ON outbound_fetches (fetched_at);
@@ -0,0 +1,10 @@
+-- Migration 059: semantic watch.
+-- Keyword matching misses paraphrased mentions ("la organización que
+-- administra el programa X"). With semantic_watch on, each scan embeds the
+-- org's profile (name, website and keywords) and compares it against the
+-- embeddings of recently ingested articles; close enough articles become
+-- "semantic" hits with the best matching passage as the snippet.
+
+ALTER TABLE watchlist_orgs ADD COLUMN IF NOT EXISTS semantic_watch BOOLEAN NOT NULL DEFAULT false;
+-- Minimum cosine similarity (0-1) for a semantic hit; 0 uses the default.
+ALTER TABLE watchlist_orgs ADD COLUMN IF NOT EXISTS semantic_threshold DOUBLE PRECISION NOT NULL DEFAULT 0;
@@ -0,0 +1,35 @@
+-- Migration 060: worker job control.
+-- The worker registers each of its cron jobs here on startup and records
+-- every run, so job status is visible from the admin API. Pausing a job sets
+-- paused, which the worker checks before each scheduled run. "Run now"
+-- requests are queued in worker_commands and executed by the worker, never
+-- by the API process.
+
+CREATE TABLE IF NOT EXISTS worker_jobs (
+    name             TEXT PRIMARY KEY,
+    schedule         TEXT NOT NULL,                  -- 5-field cron expression
+    paused           BOOLEAN NOT NULL DEFAULT false,
+    running          INTEGER NOT NULL DEFAULT 0,     -- runs in progress
+    registered_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    last_started_at  TIMESTAMPTZ,
+    last_finished_at TIMESTAMPTZ,
+    last_trigger     TEXT NOT NULL DEFAULT '',       -- schedule, manual, startup
+    last_status      TEXT NOT NULL DEFAULT '',       -- ok, timeout, canceled, failed
+    last_error       TEXT NOT NULL DEFAULT '',
+    last_duration_ms INTEGER NOT NULL DEFAULT 0,
+    run_count        INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS worker_commands (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    job          TEXT NOT NULL,
+    requested_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    status       TEXT NOT NULL DEFAULT 'pending',    -- pending, running, done, failed
+    error        TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at   TIMESTAMPTZ,
+    finished_at  TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_worker_commands_pending ON worker_commands (created_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_worker_commands_created ON worker_commands (created_at DESC);
@@ -0,0 +1,21 @@
+-- Migration 061: Structured grant postings.
+-- Articles tagged "grants" get their funder, eligible applicants, award
+-- range, match requirement and deadline extracted by the model, so grant
+-- listings can sort and filter on them instead of searching free text. A row
+-- is written even when the posting states none of them, so the article is not
+-- extracted again.
+
+CREATE TABLE IF NOT EXISTS grant_postings (
+    article_id        UUID PRIMARY KEY REFERENCES articles(id) ON DELETE CASCADE,
+    funder            TEXT NOT NULL DEFAULT '',
+    eligible_entities TEXT[] NOT NULL DEFAULT '{}',
+    award_ceiling     BIGINT,                        -- whole US dollars per award
+    award_floor       BIGINT,
+    match_required    BOOLEAN,                       -- NULL when the posting does not say
+    match_requirement TEXT NOT NULL DEFAULT '',      -- e.g. "25% cost share"
+    deadline          DATE,
+    extracted_at      TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_grant_postings_deadline ON grant_postings (deadline);
+CREATE INDEX IF NOT EXISTS idx_grant_postings_award_ceiling ON grant_postings (award_ceiling DESC NULLS LAST);
@@ -0,0 +1,10 @@
+-- Migration 062: drop embeddings of articles left in the trash.
+-- Articles trashed more than TRASH_EMBEDDING_DAYS ago have their embedding
+-- nulled, which takes them out of the HNSW index (NULLs are not indexed) and
+-- out of similarity results. embedding_archived_at marks them so the worker
+-- regenerates the embedding if the article is restored.
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS embedding_archived_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_articles_embedding_archived
+    ON articles (embedding_archived_at) WHERE embedding_archived_at IS NOT NULL;
@@ -0,0 +1,49 @@
+-- Migration 063: one watchlist hit per URL per user, linked to every org that
+-- found it.
+-- url_hash was unique across all hits, so when two orgs shared keywords the
+-- second org's hit for the same URL was silently dropped — even when the orgs
+-- belonged to different users. Hits are now unique per user, and
+-- watchlist_hit_orgs links a hit to each of the user's orgs that found it.
+-- watchlist_hits.org_id stays the org that found it first: sentiment and the
+-- response draft are written for that org.
+
+ALTER TABLE watchlist_hits ADD COLUMN IF NOT EXISTS user_id UUID REFERENCES users(id) ON DELETE CASCADE;
+
+UPDATE watchlist_hits wh SET user_id = wo.user_id
+FROM watchlist_orgs wo
+WHERE wo.id = wh.org_id AND wh.user_id IS NULL;
+
+ALTER TABLE watchlist_hits ALTER COLUMN user_id SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_wh_url_hash;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_wh_user_url_hash ON watchlist_hits (user_id, url_hash);
+CREATE INDEX IF NOT EXISTS idx_wh_user_created ON watchlist_hits (user_id, created_at DESC)
+    WHERE duplicate_of IS NULL;
+
+CREATE TABLE IF NOT EXISTS watchlist_hit_orgs (
+    hit_id    UUID NOT NULL REFERENCES watchlist_hits(id) ON DELETE CASCADE,
+    org_id    UUID NOT NULL REFERENCES watchlist_orgs(id) ON DELETE CASCADE,
+    linked_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (hit_id, org_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_watchlist_hit_orgs_org ON watchlist_hit_orgs (org_id, linked_at DESC);
+
+INSERT INTO watchlist_hit_orgs (hit_id, org_id, linked_at)
+SELECT id, org_id, created_at FROM watchlist_hits
+ON CONFLICT DO NOTHING;
+
+-- Per-org hit counts include the hits an org shares with the user's others.
+DROP MATERIALIZED VIEW IF EXISTS mv_org_hit_daily;
+CREATE MATERIALIZED VIEW mv_org_hit_daily AS
+SELECT (wh.created_at AT TIME ZONE 'UTC')::date AS day,
+       l.org_id,
+       wh.sentiment,
+       count(*) AS hits
+FROM watchlist_hits wh
+JOIN watchlist_hit_orgs l ON l.hit_id = wh.id
+WHERE wh.duplicate_of IS NULL
+GROUP BY 1, 2, 3;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mv_org_hit_daily ON mv_org_hit_daily (day, org_id, sentiment);
+CREATE INDEX IF NOT EXISTS idx_mv_org_hit_daily_org ON mv_org_hit_daily (org_id, day);
@@ -0,0 +1,25 @@
+-- Migration 064: muted watchlist stories.
+-- Muting a hit stores a signature of its story for the org: the canonical
+-- URL, the title+snippet embedding and the entities it names. Until the mute
+-- expires, new hits for the org that match it are marked seen and hidden
+-- instead of being classified, drafted and pushed. Revoking a mute brings
+-- the hits it hid back.
+
+CREATE TABLE IF NOT EXISTS watchlist_muted_stories (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    org_id        UUID NOT NULL REFERENCES watchlist_orgs(id) ON DELETE CASCADE,
+    hit_id        UUID REFERENCES watchlist_hits(id) ON DELETE SET NULL, -- hit it was muted from
+    title         TEXT NOT NULL DEFAULT '',
+    canonical_url TEXT NOT NULL DEFAULT '',
+    entities      TEXT[] NOT NULL DEFAULT '{}',
+    embedding     vector(768),
+    muted_hits    INTEGER NOT NULL DEFAULT 0,  -- hits hidden so far
+    created_by    UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    expires_at    TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_watchlist_muted_stories_org ON watchlist_muted_stories (org_id, expires_at DESC);
+
+ALTER TABLE watchlist_hits ADD COLUMN IF NOT EXISTS muted_by UUID
+    REFERENCES watchlist_muted_stories(id) ON DELETE SET NULL;
@@ -0,0 +1,4 @@
+-- Migration 065: per-user language for API messages and notifications.
+-- Empty follows the browser's Accept-Language, then DEFAULT_LANGUAGE.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,9 @@
+-- Migration 066: link watchlist hits to the article collected from them.
+-- POST /api/watchlist/hits/{id}/collect creates an article from the hit URL
+-- (or finds the one already stored) and records it here.
+
+ALTER TABLE watchlist_hits
+    ADD COLUMN IF NOT EXISTS article_id UUID REFERENCES articles(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_watchlist_hits_article ON watchlist_hits(article_id)
+    WHERE article_id IS NOT NULL;
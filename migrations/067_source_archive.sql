@@ -0,0 +1,6 @@
+-- Migration 067: archived sources.
+-- An archived source is kept for the attribution of the articles it brought
+-- in but is skipped by ingestion and left out of source pickers. Sources
+-- that articles reference can no longer be deleted, only archived.
+
+ALTER TABLE sources ADD COLUMN IF NOT EXISTS archived_at TIMESTAMPTZ;
@@ -0,0 +1,8 @@
+-- Migration 068: human-verified hit sentiment.
+-- A person can override the sentiment the model gave a hit; the override is
+-- recorded with who made it and when, and automatic classification
+-- (including admin re-classification runs) leaves verified hits alone.
+
+ALTER TABLE watchlist_hits
+    ADD COLUMN IF NOT EXISTS sentiment_verified_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS sentiment_verified_by UUID REFERENCES users(id) ON DELETE SET NULL;
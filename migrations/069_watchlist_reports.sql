@@ -0,0 +1,22 @@
+-- Migration 069: weekly watchlist reports.
+-- Every Monday the worker stores one report per user covering the previous
+-- week (Monday to Monday, UTC): hit counts and sentiment per org and the
+-- notable negative hits with their response drafts. Reports are listed at
+-- GET /api/watchlist/reports.
+
+CREATE TABLE IF NOT EXISTS watchlist_reports (
+    id            UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id       UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    period_start  TIMESTAMPTZ NOT NULL,
+    period_end    TIMESTAMPTZ NOT NULL,
+    hit_count     INT NOT NULL DEFAULT 0,
+    positive      INT NOT NULL DEFAULT 0,
+    negative      INT NOT NULL DEFAULT 0,
+    neutral       INT NOT NULL DEFAULT 0,
+    orgs          JSONB NOT NULL DEFAULT '[]',
+    notable_hits  JSONB NOT NULL DEFAULT '[]',
+    summary       TEXT NOT NULL DEFAULT '',
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, period_start)
+);
+CREATE INDEX IF NOT EXISTS idx_watchlist_reports_user ON watchlist_reports(user_id, period_start DESC);
@@ -0,0 +1,6 @@
+-- Migration 070: brief generation parameters.
+-- POST /api/briefs/generate can narrow a brief to a date range, tags and a
+-- region, and pick its language and model. The parameters used are kept on
+-- the brief; briefs generated before this migration have none.
+
+ALTER TABLE briefs ADD COLUMN IF NOT EXISTS params JSONB;
@@ -0,0 +1,6 @@
+-- Migration 071: brief sections by topic.
+-- Besides the overall summary, a brief is split into one section per leading
+-- tag, each summarizing its articles and listing them (id, title, source,
+-- URL) so readers can open the sources behind every paragraph.
+
+ALTER TABLE briefs ADD COLUMN IF NOT EXISTS sections JSONB NOT NULL DEFAULT '[]';
@@ -0,0 +1,7 @@
+-- Migration 072: find articles that never got an embedding.
+-- The embedding backfill job walks live articles whose embedding is NULL
+-- (and was not archived with the trash) in id order; the partial index keeps
+-- that walk off the full table once most articles are embedded.
+
+CREATE INDEX IF NOT EXISTS idx_articles_embedding_missing
+    ON articles (id) WHERE embedding IS NULL AND embedding_archived_at IS NULL;
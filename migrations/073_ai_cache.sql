@@ -0,0 +1,15 @@
+-- Migration 073: cache of AI results.
+-- Summaries, tags, enrichments and embeddings are stored keyed on the
+-- operation, the model and a SHA-256 of the prompt and input text, so
+-- re-enriching unchanged content skips the model. Entries older than
+-- AI_CACHE_DAYS are deleted by the worker.
+
+CREATE TABLE IF NOT EXISTS ai_cache (
+    operation     TEXT NOT NULL,
+    model         TEXT NOT NULL,
+    content_hash  TEXT NOT NULL,
+    result        JSONB NOT NULL,
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (operation, model, content_hash)
+);
+CREATE INDEX IF NOT EXISTS idx_ai_cache_created ON ai_cache(created_at);
@@ -0,0 +1,9 @@
+-- Migration 074: when an article was pinned.
+-- The saved-articles feed is ordered and dated by when each article was
+-- saved (from the status history) or pinned, so a reader polling it sees an
+-- old article as soon as it is saved. Articles already pinned are dated by
+-- their creation, since when they were pinned is not known.
+
+ALTER TABLE articles ADD COLUMN IF NOT EXISTS pinned_at TIMESTAMPTZ;
+
+UPDATE articles SET pinned_at = created_at WHERE pinned AND pinned_at IS NULL;
@@ -0,0 +1,23 @@
+-- Migration 075: the Municipal source bundle.
+-- Municipal finances and audits (CRIM, the Comptroller's municipal audit
+-- reports) and regional press that covers the municipios. Scrape sources use
+-- the same press-link selector as the government press pages; article text
+-- comes from the readability fallback until body selectors are configured.
+
+INSERT INTO source_bundles (slug, name, description, sources) VALUES
+
+('pr-municipal',
+ 'Municipal',
+ 'Municipios de Puerto Rico: CRIM, auditorías municipales del Contralor y prensa regional.',
+ '[
+   {"name": "CRIM", "base_url": "https://www.crim.org", "region": "PR",
+    "feed_type": "scrape", "list_urls": ["https://www.crim.org/"],
+    "link_selector": "a[href*=\"comunicado\"], a[href*=\"prensa\"], a[href*=\"noticia\"]"},
+   {"name": "Oficina del Contralor", "base_url": "https://www.ocpr.gov.pr", "region": "PR",
+    "feed_type": "scrape", "list_urls": ["https://www.ocpr.gov.pr/"],
+    "link_selector": "a[href*=\"comunicado\"], a[href*=\"prensa\"], a[href*=\"noticia\"]"},
+   {"name": "La Perla del Sur", "base_url": "https://www.periodicolaperla.com", "region": "PR",
+    "feed_type": "rss", "feed_url": "https://www.periodicolaperla.com/feed/"}
+ ]')
+
+ON CONFLICT (slug) DO NOTHING;